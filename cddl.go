@@ -0,0 +1,144 @@
+package typegen
+
+import (
+	"fmt"
+	"io"
+	"reflect"
+	"strings"
+)
+
+// cddlMaxMapLength is the maximum number of entries of map fields, checked by
+// the generated code.
+const cddlMaxMapLength = 4096
+
+// PrintCddlHeader writes the header of a generated CDDL file, see
+// Gen.CddlFile.
+func PrintCddlHeader(w io.Writer) error {
+	return doTemplate(w, nil, `; Code generated by github.com/daotl/cbor-gen. DO NOT EDIT.
+
+`)
+}
+
+// GenCddlForType writes the CDDL (RFC 8610) rule of the type described by gti
+// in the representation repr, ReprTuple or ReprMap: an array of its fields in
+// tuple representation, a map of its map keys otherwise. Strings, byte strings,
+// arrays and maps are limited to the sizes the generated code accepts, pointer
+// fields can be null and optional fields can be missing. Generic types are
+// generic rules, e.g. Page<T0>. Fields omitted when equal to their default
+// value are optional, with a .default control. Decode-only fields are left
+// out, as the rule describes the encoded data.
+func GenCddlForType(gti *GenTypeInfo, repr string, w io.Writer) error {
+	type cddlField struct {
+		Key  string
+		Type string
+	}
+	data := struct {
+		Name   string
+		Tuple  bool
+		Fields []cddlField
+	}{Name: gti.Name, Tuple: repr == ReprTuple}
+	if len(gti.TypeParams) > 0 {
+		data.Name += "<" + strings.Join(gti.TypeParams, ", ") + ">"
+	}
+
+	for _, f := range gti.forEncoding().Fields {
+		typ := cddlType(f.Type)
+		if f.Pointer {
+			typ += " / null"
+		}
+		key := f.Name
+		if !data.Tuple {
+			key = fmt.Sprintf("%q", f.MapKey)
+			if f.IntKey {
+				key = f.MapKey
+			}
+		}
+		if f.OmitDefault {
+			// Types take one control operator.
+			if strings.Contains(typ, " .") {
+				typ = "(" + typ + ")"
+			}
+			typ += " .default " + f.DefaultValue()
+		}
+		if f.Optional || f.OmitDefault {
+			key = "? " + key
+		}
+		data.Fields = append(data.Fields, cddlField{Key: key, Type: typ})
+	}
+
+	return doTemplate(w, data, `{{ .Name }} = {{ if .Tuple }}[{{ else }}{{ "{" }}{{ end }}
+{{- range .Fields }}
+	{{ .Key }}: {{ .Type }},
+{{- end }}
+{{ if .Tuple }}]{{ else }}{{ "}" }}{{ end }}
+
+`)
+}
+
+// cddlType returns the CDDL type of t.
+func cddlType(t reflect.Type) string {
+	if i := typeParamIndex(t); i >= 0 {
+		return typeParamName(i)
+	}
+	switch t {
+	case cidType:
+		return "#6.42(bstr)"
+	case bigIntType:
+		return "#6.2(bstr)"
+	case deferredType:
+		return "any"
+	case cborTimeType:
+		return "int"
+	}
+
+	switch t.Kind() {
+	case reflect.Ptr:
+		return "(" + cddlType(t.Elem()) + " / null)"
+	case reflect.Bool:
+		return "bool"
+	case reflect.String:
+		return fmt.Sprintf("tstr .size (0..%d)", MaxLength)
+	case reflect.Uint8:
+		return "uint .size 1"
+	case reflect.Uint16:
+		return "uint .size 2"
+	case reflect.Uint32:
+		return "uint .size 4"
+	case reflect.Uint64, reflect.Uint:
+		return "uint"
+	case reflect.Int8:
+		return "-128..127"
+	case reflect.Int16:
+		return "-32768..32767"
+	case reflect.Int32:
+		return "-2147483648..2147483647"
+	case reflect.Int64, reflect.Int:
+		return "int"
+	case reflect.Slice:
+		if t.Elem().Kind() == reflect.Uint8 {
+			return fmt.Sprintf("bstr .size (0..%d)", ByteArrayMaxLen)
+		}
+		return fmt.Sprintf("[0*%d %s]", MaxLength, cddlGroupType(t.Elem()))
+	case reflect.Array:
+		if t.Elem().Kind() == reflect.Uint8 {
+			return fmt.Sprintf("bstr .size %d", t.Len())
+		}
+		return fmt.Sprintf("[%d*%d %s]", t.Len(), t.Len(), cddlGroupType(t.Elem()))
+	case reflect.Map:
+		return fmt.Sprintf("{0*%d %s => %s}", cddlMaxMapLength,
+			cddlGroupType(t.Key()), cddlGroupType(t.Elem()))
+	default:
+		return t.Name()
+	}
+}
+
+// cddlGroupType returns the CDDL type of t, in parentheses if it has a control
+// operator or is a choice, so it can be used as the type of array items and
+// map entries.
+func cddlGroupType(t reflect.Type) string {
+	s := cddlType(t)
+	if strings.Contains(s, " ") && !strings.ContainsAny(s[:1], "[{(") {
+		return "(" + s + ")"
+	}
+	return s
+}
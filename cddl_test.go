@@ -0,0 +1,61 @@
+package typegen
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/ipfs/go-cid"
+)
+
+type cddlStruct struct {
+	Name   string `cborgen:"name"`
+	Small  uint8
+	Delta  int16
+	Link   cid.Cid
+	Parent *cid.Cid
+	Tags   []string
+	Hash   [32]byte
+	Index  map[string]*cddlStruct
+}
+
+func TestGenCddlForType(t *testing.T) {
+	gti, _, err := ParseTypeInfo(cddlStruct{}, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	buf := new(bytes.Buffer)
+	if err := GenCddlForType(gti, ReprTuple, buf); err != nil {
+		t.Fatal(err)
+	}
+	expected := `cddlStruct = [
+	Name: tstr .size (0..8192),
+	Small: uint .size 1,
+	Delta: -32768..32767,
+	Link: #6.42(bstr),
+	Parent: #6.42(bstr) / null,
+	Tags: [0*8192 (tstr .size (0..8192))],
+	Hash: bstr .size 32,
+	Index: {0*4096 (tstr .size (0..8192)) => (cddlStruct / null)},
+]
+
+`
+	if buf.String() != expected {
+		t.Fatalf("unexpected CDDL:\n%s", buf.String())
+	}
+
+	buf.Reset()
+	gti.Fields = gti.Fields[:2]
+	if err := GenCddlForType(gti, ReprMap, buf); err != nil {
+		t.Fatal(err)
+	}
+	expected = `cddlStruct = {
+	"name": tstr .size (0..8192),
+	"Small": uint .size 1,
+}
+
+`
+	if buf.String() != expected {
+		t.Fatalf("unexpected CDDL:\n%s", buf.String())
+	}
+}
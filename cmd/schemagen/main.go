@@ -0,0 +1,58 @@
+// Command schemagen generates Go types and their cbor-gen codecs from an IPLD
+// Schema file:
+//
+//	schemagen -o ./types -pkg types schema.ipldsch
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/daotl/cbor-gen/schemagen"
+)
+
+var (
+	out   = flag.String("o", ".", "directory of the Go package to generate")
+	pkg   = flag.String("pkg", "", "name of the Go package (default: the name of the directory)")
+	tests = flag.Bool("tests", false, "also generate fuzz targets and roundtrip tests")
+)
+
+func main() {
+	flag.Usage = func() {
+		fmt.Fprintf(flag.CommandLine.Output(), "usage: %s [flags] schema.ipldsch\n", os.Args[0])
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+	if flag.NArg() != 1 {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	name := *pkg
+	if name == "" {
+		dir, err := filepath.Abs(*out)
+		if err != nil {
+			fatal(err)
+		}
+		name = filepath.Base(dir)
+	}
+
+	s, err := schemagen.ParseFile(flag.Arg(0))
+	if err != nil {
+		fatal(err)
+	}
+	if err := schemagen.Generate(s, schemagen.Options{
+		Dir:           *out,
+		Package:       name,
+		GenerateTests: *tests,
+	}); err != nil {
+		fatal(err)
+	}
+}
+
+func fatal(err error) {
+	fmt.Fprintln(os.Stderr, err)
+	os.Exit(1)
+}
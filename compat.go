@@ -0,0 +1,452 @@
+package typegen
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"math/big"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"golang.org/x/xerrors"
+)
+
+// Representations of the generated types.
+const (
+	ReprTuple = "tuple"
+	ReprMap   = "map"
+)
+
+// TypeSchema is a snapshot of the wire format of a generated type. It can be
+// saved as JSON with WriteSchemasToFile, and compared with the schema of a
+// later version of the type with CheckCompatibility.
+type TypeSchema struct {
+	Name           string        `json:"name"`
+	Representation string        `json:"representation"`
+	Fields         []FieldSchema `json:"fields"`
+}
+
+// FieldSchema describes a field of a TypeSchema.
+type FieldSchema struct {
+	Name string `json:"name"`
+	// Key is the map key of the field, only set in map representation.
+	Key string `json:"key,omitempty"`
+	// IntKey is set if Key is an integer map key, in decimal.
+	IntKey bool `json:"intKey,omitempty"`
+	// Aliases and IntAliases are the other text and integer map keys accepted
+	// by decoders, the latter in decimal.
+	Aliases    []string `json:"aliases,omitempty"`
+	IntAliases []string `json:"intAliases,omitempty"`
+	// Type describes what the field is encoded as, e.g. "uint", "string",
+	// "link", "[]SimpleTypeOne" or "map[string]*bytes". Go types encoded the
+	// same way get the same description.
+	Type     string `json:"type"`
+	Nullable bool   `json:"nullable,omitempty"`
+	Optional bool   `json:"optional,omitempty"`
+	// Default is the Go literal of the value of the field when its map key is
+	// missing, if it has a default value or is omitted when equal to it.
+	Default     string `json:"default,omitempty"`
+	OmitDefault bool   `json:"omitDefault,omitempty"`
+	// DecodeOnly fields aren't encoded, and EncodeOnly fields aren't decoded.
+	DecodeOnly bool `json:"decodeOnly,omitempty"`
+	EncodeOnly bool `json:"encodeOnly,omitempty"`
+	// Constraints are the len, min, max and enum options of the field, as
+	// written in cborgen tags, e.g. "max=100".
+	Constraints []string `json:"constraints,omitempty"`
+}
+
+// NewTypeSchema returns the schema of a type from its type info, as parsed
+// for the representation repr, ReprTuple or ReprMap.
+func NewTypeSchema(gti *GenTypeInfo, repr string) *TypeSchema {
+	s := &TypeSchema{
+		Name:           gti.Name,
+		Representation: repr,
+		Fields:         make([]FieldSchema, len(gti.Fields)),
+	}
+	for i, f := range gti.Fields {
+		fs := FieldSchema{
+			Name:       f.Name,
+			Type:       wireTypeName(f.Type),
+			Nullable:   f.Pointer,
+			Optional:   f.Optional,
+			DecodeOnly: f.DecodeOnly,
+			EncodeOnly: f.EncodeOnly,
+		}
+		for _, c := range f.Constraints {
+			fs.Constraints = append(fs.Constraints, c.Option)
+		}
+		if f.Default != "" || f.OmitDefault {
+			fs.Default = f.DefaultValue()
+			fs.OmitDefault = f.OmitDefault
+		}
+		if repr == ReprMap {
+			fs.Key = f.MapKey
+			fs.IntKey = f.IntKey
+			for _, a := range f.Aliases {
+				if a.Int {
+					fs.IntAliases = append(fs.IntAliases, strconv.FormatInt(a.Value, 10))
+				} else {
+					fs.Aliases = append(fs.Aliases, a.Text)
+				}
+			}
+		}
+		s.Fields[i] = fs
+	}
+	return s
+}
+
+// TupleSchema returns the schema of the tuple representation generated for
+// the type of t with the options of g.
+func (g Gen) TupleSchema(t interface{}) (*TypeSchema, error) {
+	gti, _, err := g.parseTupleTypeInfo(t)
+	if err != nil {
+		return nil, err
+	}
+	return NewTypeSchema(gti, ReprTuple), nil
+}
+
+// MapSchema returns the schema of the map representation generated for the
+// type of t with the options of g.
+func (g Gen) MapSchema(t interface{}) (*TypeSchema, error) {
+	gti, _, err := g.parseMapTypeInfo(t)
+	if err != nil {
+		return nil, err
+	}
+	return NewTypeSchema(gti, ReprMap), nil
+}
+
+// wireTypeName describes what values of t are encoded as.
+func wireTypeName(t reflect.Type) string {
+	if i := typeParamIndex(t); i >= 0 {
+		return typeParamName(i)
+	}
+	switch t {
+	case cidType:
+		return "link"
+	case bigIntType:
+		return "bigint"
+	case deferredType:
+		return "any"
+	}
+
+	switch t.Kind() {
+	case reflect.Ptr:
+		return "*" + wireTypeName(t.Elem())
+	case reflect.Bool:
+		return "bool"
+	case reflect.String:
+		return "string"
+	case reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uint:
+		return "uint"
+	case reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64, reflect.Int:
+		return "int"
+	case reflect.Slice:
+		if t.Elem().Kind() == reflect.Uint8 {
+			return "bytes"
+		}
+		return "[]" + wireTypeName(t.Elem())
+	case reflect.Array:
+		if t.Elem().Kind() == reflect.Uint8 {
+			return "bytes[" + strconv.Itoa(t.Len()) + "]"
+		}
+		return "[" + strconv.Itoa(t.Len()) + "]" + wireTypeName(t.Elem())
+	case reflect.Map:
+		return "map[" + wireTypeName(t.Key()) + "]" + wireTypeName(t.Elem())
+	default:
+		// Structs and types with their own codec.
+		return t.Name()
+	}
+}
+
+// Incompatibility is a breaking change between two versions of a type.
+type Incompatibility struct {
+	Type string
+	// Field is the Go name of the field in the old version, if the change
+	// is about a field.
+	Field  string
+	Reason string
+}
+
+func (i Incompatibility) String() string {
+	if i.Field == "" {
+		return fmt.Sprintf("%s: %s", i.Type, i.Reason)
+	}
+	return fmt.Sprintf("%s.%s: %s", i.Type, i.Field, i.Reason)
+}
+
+// CheckCompatibility returns the changes from old to new which prevent the new
+// version of a type from decoding data encoded with the old version without
+// losing information:
+//
+//   - changing the representation,
+//   - in tuple representation, removing or reordering fields, or adding
+//     fields which aren't optional, as the generated decoders check the
+//     number of fields,
+//   - in tuple representation, making an optional field required,
+//   - in map representation, removing a field or renaming its map key, as
+//     decoders ignore unknown keys, the text key "1" and the integer key 1
+//     being different keys, unless the old key is kept as an alias,
+//   - changing the encoded type of a field,
+//   - making a nullable field non-nullable,
+//   - changing the default value of a field omitted when equal to it,
+//   - tightening the constraints of a field, e.g. raising its min, lowering
+//     its max or removing values from its enum, as decoders would reject
+//     values encoded with old.
+//
+// Renaming a Go field is fine, as long as its tuple position or map key stays
+// the same. Only the fields old encodes and new decodes are compared, so
+// decode-only fields of old and encode-only fields of new are ignored.
+func CheckCompatibility(old, new *TypeSchema) []Incompatibility {
+	var incompatible []Incompatibility
+	report := func(field, format string, args ...interface{}) {
+		incompatible = append(incompatible, Incompatibility{
+			Type:   old.Name,
+			Field:  field,
+			Reason: fmt.Sprintf(format, args...),
+		})
+	}
+
+	if old.Representation != new.Representation {
+		report("", "representation changed from %s to %s", old.Representation, new.Representation)
+		return incompatible
+	}
+	oldFields := filterFieldSchemas(old.Fields, func(f FieldSchema) bool { return !f.DecodeOnly })
+	newFields := filterFieldSchemas(new.Fields, func(f FieldSchema) bool { return !f.EncodeOnly })
+
+	checkField := func(of, nf FieldSchema) {
+		if of.Type != nf.Type {
+			report(of.Name, "type changed from %s to %s", of.Type, nf.Type)
+		}
+		if of.Nullable && !nf.Nullable {
+			report(of.Name, "became non-nullable")
+		}
+		if of.OmitDefault && of.Default != nf.defaultValue() {
+			report(of.Name, "default value changed from %s to %s", of.Default, nf.defaultValue())
+		}
+		if constraintsTightened(of.Constraints, nf.Constraints) {
+			report(of.Name, "constraints tightened from %s to %s", constraintsString(of.Constraints), constraintsString(nf.Constraints))
+		}
+	}
+
+	switch old.Representation {
+	case ReprTuple:
+		oldPos := map[string]int{}
+		for i, f := range oldFields {
+			oldPos[f.Name] = i
+		}
+		newPos := map[string]int{}
+		for i, f := range newFields {
+			newPos[f.Name] = i
+		}
+		for i, of := range oldFields {
+			j, ok := newPos[of.Name]
+			switch {
+			case ok && i != j:
+				report(of.Name, "moved from position %d to %d", i, j)
+				checkField(of, newFields[j])
+			case ok:
+				checkField(of, newFields[j])
+				if of.Optional && !newFields[j].Optional {
+					report(of.Name, "became required")
+				}
+			case i < len(newFields) && !hasKey(oldPos, newFields[i].Name):
+				// Renamed in place.
+				checkField(of, newFields[i])
+			default:
+				report(of.Name, "removed")
+			}
+		}
+		if len(oldFields) > len(newFields) || !allOptional(newFields[len(oldFields):]) {
+			report("", "number of tuple fields changed from %d to %d", len(oldFields), len(newFields))
+		}
+	case ReprMap:
+		newByKey := map[string]FieldSchema{}
+		newByName := map[string]FieldSchema{}
+		for _, f := range newFields {
+			newByKey[f.mapKey()] = f
+			for _, a := range f.Aliases {
+				newByKey[TextKey(a).String()] = f
+			}
+			for _, a := range f.IntAliases {
+				newByKey[a] = f
+			}
+			newByName[f.Name] = f
+		}
+		for _, of := range oldFields {
+			if nf, ok := newByKey[of.mapKey()]; ok {
+				checkField(of, nf)
+			} else if nf, ok := newByName[of.Name]; ok {
+				report(of.Name, "map key renamed from %s to %s", of.mapKey(), nf.mapKey())
+			} else {
+				report(of.Name, "removed")
+			}
+		}
+	default:
+		report("", "unknown representation %q", old.Representation)
+	}
+
+	return incompatible
+}
+
+// mapKey returns the map key of f as written in Go, quoted unless it's an
+// integer.
+func (f FieldSchema) mapKey() string {
+	if f.IntKey {
+		return f.Key
+	}
+	return TextKey(f.Key).String()
+}
+
+// defaultValue returns the Go literal of the value of f when its map key is
+// missing.
+func (f FieldSchema) defaultValue() string {
+	if f.Default != "" {
+		return f.Default
+	}
+	switch f.Type {
+	case "bool":
+		return "false"
+	case "string":
+		return `""`
+	default:
+		return "0"
+	}
+}
+
+// constraintBounds are the values, or lengths, allowed by the constraints of
+// a field. Nil bounds and enum don't restrict them.
+type constraintBounds struct {
+	min, max *big.Int
+	enum     map[string]bool
+}
+
+func parseConstraintBounds(options []string) constraintBounds {
+	var b constraintBounds
+	for _, opt := range options {
+		kv := strings.SplitN(opt, "=", 2)
+		if len(kv) != 2 {
+			continue
+		}
+		if kv[0] == "enum" {
+			b.enum = map[string]bool{}
+			for _, v := range strings.Split(kv[1], "|") {
+				b.enum[v] = true
+			}
+			continue
+		}
+		n, ok := new(big.Int).SetString(kv[1], 10)
+		if !ok {
+			continue
+		}
+		if kv[0] != "max" && (b.min == nil || n.Cmp(b.min) > 0) {
+			b.min = n
+		}
+		if kv[0] != "min" && (b.max == nil || n.Cmp(b.max) < 0) {
+			b.max = n
+		}
+	}
+	return b
+}
+
+// constraintsTightened reports whether the constraint options of new reject
+// values, or lengths, which those of old allow.
+func constraintsTightened(old, new []string) bool {
+	o, n := parseConstraintBounds(old), parseConstraintBounds(new)
+	if n.min != nil && (o.min == nil || n.min.Cmp(o.min) > 0) {
+		return true
+	}
+	if n.max != nil && (o.max == nil || n.max.Cmp(o.max) < 0) {
+		return true
+	}
+	if n.enum == nil {
+		return false
+	}
+	if o.enum == nil {
+		return true
+	}
+	for v := range o.enum {
+		if !n.enum[v] {
+			return true
+		}
+	}
+	return false
+}
+
+func constraintsString(options []string) string {
+	if len(options) == 0 {
+		return "none"
+	}
+	return strings.Join(options, ",")
+}
+
+func filterFieldSchemas(fields []FieldSchema, keep func(FieldSchema) bool) []FieldSchema {
+	var filtered []FieldSchema
+	for _, f := range fields {
+		if keep(f) {
+			filtered = append(filtered, f)
+		}
+	}
+	return filtered
+}
+
+func allOptional(fields []FieldSchema) bool {
+	for _, f := range fields {
+		if !f.Optional {
+			return false
+		}
+	}
+	return true
+}
+
+func hasKey(m map[string]int, k string) bool {
+	_, ok := m[k]
+	return ok
+}
+
+// CheckSchemasCompatibility is like CheckCompatibility for sets of types
+// matched by name, typically loaded with ReadSchemasFromFile. Removing a type
+// is a breaking change, adding one isn't.
+func CheckSchemasCompatibility(old, new []*TypeSchema) []Incompatibility {
+	byName := map[string]*TypeSchema{}
+	for _, s := range new {
+		byName[s.Name] = s
+	}
+
+	var incompatible []Incompatibility
+	for _, o := range old {
+		n, ok := byName[o.Name]
+		if !ok {
+			incompatible = append(incompatible, Incompatibility{Type: o.Name, Reason: "removed"})
+			continue
+		}
+		incompatible = append(incompatible, CheckCompatibility(o, n)...)
+	}
+	return incompatible
+}
+
+// WriteSchemasToFile saves a snapshot of type schemas as JSON.
+func WriteSchemasToFile(fname string, schemas ...*TypeSchema) error {
+	data, err := json.MarshalIndent(schemas, "", "\t")
+	if err != nil {
+		return err
+	}
+	if err := ioutil.WriteFile(fname, append(data, '\n'), 0644); err != nil {
+		return xerrors.Errorf("failed to write schemas: %w", err)
+	}
+	return nil
+}
+
+// ReadSchemasFromFile loads a snapshot of type schemas saved with
+// WriteSchemasToFile.
+func ReadSchemasFromFile(fname string) ([]*TypeSchema, error) {
+	data, err := ioutil.ReadFile(fname)
+	if err != nil {
+		return nil, xerrors.Errorf("failed to read schemas: %w", err)
+	}
+	var schemas []*TypeSchema
+	if err := json.Unmarshal(data, &schemas); err != nil {
+		return nil, xerrors.Errorf("failed to parse schemas: %w", err)
+	}
+	return schemas, nil
+}
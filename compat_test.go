@@ -0,0 +1,335 @@
+package typegen
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/ipfs/go-cid"
+)
+
+type compatV1 struct {
+	Str   string
+	Num   uint64
+	Ptr   *cid.Cid
+	Items []compatItem
+}
+
+// compatV2 appends a field to compatV1 and renames a Go field.
+type compatV2 struct {
+	Str    string
+	Number uint64
+	Ptr    *cid.Cid
+	Items  []compatItem
+	Extra  []byte
+}
+
+// compatV3 swaps two fields, changes a type, removes a field and makes a field
+// non-nullable.
+type compatV3 struct {
+	Num   int64
+	Str   string
+	Ptr   cid.Cid
+	Other bool `cborgen:"Items2"`
+}
+
+// compatV4 appends an optional field to compatV1.
+type compatV4 struct {
+	Str   string
+	Num   uint64
+	Ptr   *cid.Cid
+	Items []compatItem
+	Extra []byte `cborgen:",optional"`
+}
+
+// compatV5 makes the optional field of compatV4 required.
+type compatV5 struct {
+	Str   string
+	Num   uint64
+	Ptr   *cid.Cid
+	Items []compatItem
+	Extra []byte
+}
+
+type compatItem struct {
+	Name string
+}
+
+func schemaOf(t *testing.T, repr string, v interface{}) *TypeSchema {
+	var s *TypeSchema
+	var err error
+	if repr == ReprTuple {
+		s, err = Gen{}.TupleSchema(v)
+	} else {
+		s, err = Gen{}.MapSchema(v)
+	}
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Compare the versions as the same type.
+	s.Name = "compat"
+	return s
+}
+
+func incompatibilities(is []Incompatibility) []string {
+	out := make([]string, len(is))
+	for i, inc := range is {
+		out[i] = inc.String()
+	}
+	return out
+}
+
+func TestCheckCompatibility(t *testing.T) {
+	for _, tc := range []struct {
+		repr     string
+		old, new interface{}
+		expected []string
+	}{{
+		repr:     ReprTuple,
+		old:      compatV1{},
+		new:      compatV1{},
+		expected: []string{},
+	}, {
+		repr:     ReprTuple,
+		old:      compatV1{},
+		new:      compatV2{},
+		expected: []string{"compat: number of tuple fields changed from 4 to 5"},
+	}, {
+		repr: ReprTuple,
+		old:  compatV1{},
+		new:  compatV3{},
+		expected: []string{
+			"compat.Str: moved from position 0 to 1",
+			"compat.Num: moved from position 1 to 0",
+			"compat.Num: type changed from uint to int",
+			"compat.Ptr: became non-nullable",
+			"compat.Items: type changed from []compatItem to bool",
+		},
+	}, {
+		repr:     ReprTuple,
+		old:      compatV1{},
+		new:      compatV4{},
+		expected: []string{},
+	}, {
+		repr:     ReprTuple,
+		old:      compatV4{},
+		new:      compatV5{},
+		expected: []string{"compat.Extra: became required"},
+	}, {
+		repr:     ReprMap,
+		old:      compatV4{},
+		new:      compatV5{},
+		expected: []string{},
+	}, {
+		repr:     ReprMap,
+		old:      compatV1{},
+		new:      compatV2{},
+		expected: []string{"compat.Num: removed"},
+	}, {
+		repr:     ReprMap,
+		old:      compatV2{},
+		new:      compatV1{},
+		expected: []string{"compat.Extra: removed", "compat.Number: removed"},
+	}, {
+		repr: ReprMap,
+		old:  compatV1{},
+		new:  compatV3{},
+		expected: []string{
+			"compat.Num: type changed from uint to int",
+			"compat.Ptr: became non-nullable",
+			"compat.Items: removed",
+		},
+	}} {
+		old := schemaOf(t, tc.repr, tc.old)
+		new := schemaOf(t, tc.repr, tc.new)
+		got := incompatibilities(CheckCompatibility(old, new))
+		if !equalStrings(got, tc.expected) {
+			t.Errorf("%s %T -> %T: expected %q, got %q", tc.repr, tc.old, tc.new, tc.expected, got)
+		}
+	}
+
+	tuple := schemaOf(t, ReprTuple, compatV1{})
+	m := schemaOf(t, ReprMap, compatV1{})
+	got := incompatibilities(CheckCompatibility(tuple, m))
+	if !equalStrings(got, []string{"compat: representation changed from tuple to map"}) {
+		t.Errorf("unexpected incompatibilities: %q", got)
+	}
+}
+
+func TestMapKeyRenamed(t *testing.T) {
+	old := schemaOf(t, ReprMap, compatItem{})
+	type renamed struct {
+		Name string `cborgen:"name"`
+	}
+	new := schemaOf(t, ReprMap, renamed{})
+	got := incompatibilities(CheckCompatibility(old, new))
+	if !equalStrings(got, []string{`compat.Name: map key renamed from "Name" to "name"`}) {
+		t.Fatalf("unexpected incompatibilities: %q", got)
+	}
+}
+
+func TestIntMapKeyChanged(t *testing.T) {
+	type intKey struct {
+		Alg int64 `cborgen:"1,intkey"`
+	}
+	type textKey struct {
+		Alg int64 `cborgen:"1"`
+	}
+	new := schemaOf(t, ReprMap, intKey{})
+	old := schemaOf(t, ReprMap, textKey{})
+	got := incompatibilities(CheckCompatibility(old, new))
+	if !equalStrings(got, []string{`compat.Alg: map key renamed from "1" to 1`}) {
+		t.Fatalf("unexpected incompatibilities: %q", got)
+	}
+	if got := CheckCompatibility(new, new); len(got) != 0 {
+		t.Fatalf("unexpected incompatibilities: %v", got)
+	}
+}
+
+func TestMapKeyAliases(t *testing.T) {
+	old := schemaOf(t, ReprMap, compatItem{})
+	type renamed struct {
+		Name string `cborgen:"name,alias=Name"`
+	}
+	new := schemaOf(t, ReprMap, renamed{})
+	if !equalStrings(new.Fields[0].Aliases, []string{"Name"}) {
+		t.Fatalf("unexpected aliases: %q", new.Fields[0].Aliases)
+	}
+	if got := CheckCompatibility(old, new); len(got) != 0 {
+		t.Fatalf("unexpected incompatibilities: %v", got)
+	}
+
+	type intKey struct {
+		Name string `cborgen:"1,intkey"`
+	}
+	type intRenamed struct {
+		Name string `cborgen:"name,intalias=1"`
+	}
+	old, new = schemaOf(t, ReprMap, intKey{}), schemaOf(t, ReprMap, intRenamed{})
+	if !equalStrings(new.Fields[0].IntAliases, []string{"1"}) {
+		t.Fatalf("unexpected integer aliases: %q", new.Fields[0].IntAliases)
+	}
+	if got := CheckCompatibility(old, new); len(got) != 0 {
+		t.Fatalf("unexpected incompatibilities: %v", got)
+	}
+}
+
+func TestSchemasSnapshot(t *testing.T) {
+	dir, err := ioutil.TempDir("", "cbor-gen-schemas")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	fname := filepath.Join(dir, "schemas.json")
+
+	v1, err := Gen{}.MapSchema(compatV1{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	item, err := Gen{}.TupleSchema(compatItem{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := WriteSchemasToFile(fname, v1, item); err != nil {
+		t.Fatal(err)
+	}
+
+	old, err := ReadSchemasFromFile(fname)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := CheckSchemasCompatibility(old, []*TypeSchema{v1, item}); len(got) != 0 {
+		t.Fatalf("unexpected incompatibilities: %q", incompatibilities(got))
+	}
+
+	got := incompatibilities(CheckSchemasCompatibility(old, []*TypeSchema{v1}))
+	if !equalStrings(got, []string{"compatItem: removed"}) {
+		t.Fatalf("unexpected incompatibilities: %q", got)
+	}
+}
+
+func TestDecodeOnlyEncodeOnly(t *testing.T) {
+	type v1 struct {
+		Name  string
+		Count uint64 `cborgen:"count"`
+	}
+	// v2 starts writing "total", without reading it.
+	type v2 struct {
+		Name  string
+		Count uint64 `cborgen:"count"`
+		Total uint64 `cborgen:"total,encodeonly"`
+	}
+	// v3 still reads "count" as a uint, but writes it as a string.
+	type v3 struct {
+		Name  string
+		Count uint64 `cborgen:"count,decodeonly"`
+		Total string `cborgen:"count,encodeonly"`
+	}
+	for _, v := range []interface{}{v2{}, v3{}} {
+		if got := CheckCompatibility(schemaOf(t, ReprMap, v1{}), schemaOf(t, ReprMap, v)); len(got) != 0 {
+			t.Errorf("%T: unexpected incompatibilities: %v", v, got)
+		}
+	}
+	got := incompatibilities(CheckCompatibility(schemaOf(t, ReprMap, v3{}), schemaOf(t, ReprMap, v1{})))
+	if !equalStrings(got, []string{"compat.Total: type changed from string to uint"}) {
+		t.Errorf("unexpected incompatibilities: %q", got)
+	}
+
+}
+
+func TestDefaultValues(t *testing.T) {
+	type v1 struct {
+		N uint64 `cborgen:"n,default=3,omitdefault"`
+		S string `cborgen:"s,omitdefault"`
+	}
+	type v2 struct {
+		N uint64 `cborgen:"n,default=4"`
+		S string `cborgen:"s,default=x"`
+	}
+	got := incompatibilities(CheckCompatibility(schemaOf(t, ReprMap, v1{}), schemaOf(t, ReprMap, v2{})))
+	if !equalStrings(got, []string{
+		"compat.N: default value changed from 3 to 4",
+		`compat.S: default value changed from "" to "x"`,
+	}) {
+		t.Errorf("unexpected incompatibilities: %q", got)
+	}
+	if got := CheckCompatibility(schemaOf(t, ReprMap, v2{}), schemaOf(t, ReprMap, v1{})); len(got) != 0 {
+		t.Errorf("unexpected incompatibilities: %v", got)
+	}
+}
+
+func TestTightenedConstraints(t *testing.T) {
+	type v1 struct {
+		Hash  []byte `cborgen:"hash"`
+		Kind  string `cborgen:"kind,enum=a|b"`
+		Name  string `cborgen:"name,min=1,max=16"`
+		Level int8   `cborgen:"level,min=-1"`
+	}
+	// v2 loosens the constraints of v1.
+	type v2 struct {
+		Hash  []byte `cborgen:"hash"`
+		Kind  string `cborgen:"kind,enum=a|b|c"`
+		Name  string `cborgen:"name,max=32"`
+		Level int8   `cborgen:"level"`
+	}
+	// v3 tightens them.
+	type v3 struct {
+		Hash  []byte `cborgen:"hash,len=32"`
+		Kind  string `cborgen:"kind,enum=a"`
+		Name  string `cborgen:"name,min=2,max=16"`
+		Level int8   `cborgen:"level,min=0"`
+	}
+	if got := CheckCompatibility(schemaOf(t, ReprMap, v1{}), schemaOf(t, ReprMap, v2{})); len(got) != 0 {
+		t.Errorf("unexpected incompatibilities: %v", got)
+	}
+	got := incompatibilities(CheckCompatibility(schemaOf(t, ReprTuple, v1{}), schemaOf(t, ReprTuple, v3{})))
+	if !equalStrings(got, []string{
+		"compat.Hash: constraints tightened from none to len=32",
+		"compat.Kind: constraints tightened from enum=a|b to enum=a",
+		"compat.Name: constraints tightened from min=1,max=16 to min=2,max=16",
+		"compat.Level: constraints tightened from min=-1 to min=0",
+	}) {
+		t.Errorf("unexpected incompatibilities: %q", got)
+	}
+}
@@ -0,0 +1,329 @@
+package typegen
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"math/big"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/ipfs/go-cid"
+	"golang.org/x/xerrors"
+)
+
+type DAGJSONMarshaler interface {
+	// MarshalDAGJSON marshals the Go type into DAG-JSON and writes it into io.Writer.
+	MarshalDAGJSON(io.Writer) error
+}
+
+type DAGJSONUnmarshaler interface {
+	// UnmarshalDAGJSON reads a DAG-JSON value from io.Reader and unmarshals it into the Go type.
+	UnmarshalDAGJSON(io.Reader) error
+}
+
+// MarshalDAGJSON writes the DAG-JSON form of a value by transcoding its CBOR
+// encoding, so the result has the same field names, renames and
+// representation as its CBOR codec. It is used by the generated
+// MarshalDAGJSON methods.
+func MarshalDAGJSON(w io.Writer, m CBORMarshaler) error {
+	buf := new(bytes.Buffer)
+	if _, err := m.MarshalCBOR(buf); err != nil {
+		return err
+	}
+	return CBORToDagJSON(w, buf)
+}
+
+// UnmarshalDAGJSON reads a DAG-JSON value and unmarshals it with the CBOR codec
+// of u, see MarshalDAGJSON. It is used by the generated UnmarshalDAGJSON
+// methods.
+func UnmarshalDAGJSON(r io.Reader, u CBORUnmarshaler) error {
+	buf := new(bytes.Buffer)
+	if err := DagJSONToCBOR(buf, r); err != nil {
+		return err
+	}
+	_, err := u.UnmarshalCBOR(buf)
+	return err
+}
+
+// CBORToDagJSON transcodes a single CBOR object read from r into DAG-JSON.
+func CBORToDagJSON(w io.Writer, r io.Reader) error {
+	nd, err := DecodeAny(r)
+	if err != nil {
+		return err
+	}
+	return EncodeDagJSON(w, nd)
+}
+
+// DagJSONToCBOR transcodes a single DAG-JSON value read from r into canonical
+// CBOR. As r is read through a json.Decoder, it may be read past the end of
+// the value.
+func DagJSONToCBOR(w io.Writer, r io.Reader) error {
+	nd, err := DecodeDagJSON(r)
+	if err != nil {
+		return err
+	}
+	_, err = EncodeAny(w, nd)
+	return err
+}
+
+func (d *Deferred) MarshalDAGJSON(w io.Writer) error {
+	if d == nil {
+		_, err := io.WriteString(w, "null")
+		return err
+	}
+	return CBORToDagJSON(w, bytes.NewReader(d.Raw))
+}
+
+func (d *Deferred) UnmarshalDAGJSON(r io.Reader) error {
+	buf := bytes.NewBuffer(d.Raw[:0])
+	d.Raw = nil
+	if err := DagJSONToCBOR(buf, r); err != nil {
+		return err
+	}
+	d.Raw = buf.Bytes()
+	return nil
+}
+
+// EncodeDagJSON writes a generic data model tree as canonical DAG-JSON: no
+// whitespace, map keys sorted by their bytes, links as {"/": "bafy..."} and
+// bytes as {"/": {"bytes": "<base64>"}}.
+func EncodeDagJSON(w io.Writer, nd Node) error {
+	bw := bufio.NewWriter(w)
+	if err := encodeDagJSON(bw, nd); err != nil {
+		return err
+	}
+	return bw.Flush()
+}
+
+func encodeDagJSON(w *bufio.Writer, nd Node) error {
+	switch nd := nd.(type) {
+	case nil, NullNode:
+		_, err := w.WriteString("null")
+		return err
+	case BoolNode:
+		_, err := w.WriteString(strconv.FormatBool(bool(nd)))
+		return err
+	case IntNode:
+		if nd.Negative {
+			v := new(big.Int).SetUint64(nd.Value)
+			v.Add(v, big.NewInt(1)).Neg(v)
+			_, err := w.WriteString(v.String())
+			return err
+		}
+		_, err := w.WriteString(strconv.FormatUint(nd.Value, 10))
+		return err
+	case FloatNode:
+		f := float64(nd)
+		if math.IsNaN(f) || math.IsInf(f, 0) {
+			return fmt.Errorf("cannot encode %v in DAG-JSON", f)
+		}
+		s := strconv.FormatFloat(f, 'g', -1, 64)
+		if !strings.ContainsAny(s, ".e") {
+			s += ".0"
+		}
+		_, err := w.WriteString(s)
+		return err
+	case StringNode:
+		return writeJSONString(w, string(nd))
+	case BytesNode:
+		_, err := fmt.Fprintf(w, `{"/":{"bytes":"%s"}}`, base64.RawStdEncoding.EncodeToString(nd))
+		return err
+	case LinkNode:
+		_, err := fmt.Fprintf(w, `{"/":"%s"}`, cid.Cid(nd).String())
+		return err
+	case ListNode:
+		if err := w.WriteByte('['); err != nil {
+			return err
+		}
+		for i, v := range nd {
+			if i > 0 {
+				if err := w.WriteByte(','); err != nil {
+					return err
+				}
+			}
+			if err := encodeDagJSON(w, v); err != nil {
+				return err
+			}
+		}
+		return w.WriteByte(']')
+	case MapNode:
+		entries := make([]MapEntry, len(nd))
+		copy(entries, nd)
+		sort.Slice(entries, func(i, j int) bool {
+			return entries[i].Key < entries[j].Key
+		})
+		if err := w.WriteByte('{'); err != nil {
+			return err
+		}
+		for i, e := range entries {
+			if i > 0 {
+				if e.Key == entries[i-1].Key {
+					return fmt.Errorf("duplicate map key %q", e.Key)
+				}
+				if err := w.WriteByte(','); err != nil {
+					return err
+				}
+			}
+			if err := writeJSONString(w, e.Key); err != nil {
+				return err
+			}
+			if err := w.WriteByte(':'); err != nil {
+				return err
+			}
+			if err := encodeDagJSON(w, e.Value); err != nil {
+				return err
+			}
+		}
+		return w.WriteByte('}')
+	default:
+		return fmt.Errorf("unsupported node type %T", nd)
+	}
+}
+
+func writeJSONString(w *bufio.Writer, s string) error {
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	enc.SetEscapeHTML(false)
+	if err := enc.Encode(s); err != nil {
+		return err
+	}
+	_, err := w.Write(bytes.TrimSuffix(buf.Bytes(), []byte{'\n'}))
+	return err
+}
+
+// DecodeDagJSON decodes a single DAG-JSON value into a generic data model tree.
+// Numbers without a fraction or exponent are decoded as integers, and the
+// reserved "/" key is decoded as a link or as bytes.
+func DecodeDagJSON(r io.Reader) (Node, error) {
+	dec := json.NewDecoder(r)
+	dec.UseNumber()
+	tok, err := dec.Token()
+	if err != nil {
+		return nil, err
+	}
+	return decodeDagJSON(dec, tok, 0)
+}
+
+func decodeDagJSON(dec *json.Decoder, tok json.Token, depth int) (Node, error) {
+	switch tok := tok.(type) {
+	case nil:
+		return NullNode{}, nil
+	case bool:
+		return BoolNode(tok), nil
+	case string:
+		return StringNode(tok), nil
+	case json.Number:
+		return parseDagJSONNumber(string(tok))
+	case json.Delim:
+		if depth >= MaxNestingDepth {
+			return nil, errNestingDepth
+		}
+		switch tok {
+		case '[':
+			l := ListNode{}
+			for dec.More() {
+				tok, err := dec.Token()
+				if err != nil {
+					return nil, err
+				}
+				nd, err := decodeDagJSON(dec, tok, depth+1)
+				if err != nil {
+					return nil, err
+				}
+				l = append(l, nd)
+			}
+			if _, err := dec.Token(); err != nil {
+				return nil, err
+			}
+			return l, nil
+		case '{':
+			m := MapNode{}
+			seen := map[string]struct{}{}
+			for dec.More() {
+				tok, err := dec.Token()
+				if err != nil {
+					return nil, err
+				}
+				k := tok.(string)
+				if _, ok := seen[k]; ok {
+					return nil, fmt.Errorf("duplicate map key %q", k)
+				}
+				seen[k] = struct{}{}
+
+				if tok, err = dec.Token(); err != nil {
+					return nil, err
+				}
+				v, err := decodeDagJSON(dec, tok, depth+1)
+				if err != nil {
+					return nil, err
+				}
+				m = append(m, MapEntry{Key: k, Value: v})
+			}
+			if _, err := dec.Token(); err != nil {
+				return nil, err
+			}
+			if len(m) == 1 && m[0].Key == "/" {
+				return decodeDagJSONReserved(m[0].Value)
+			}
+			return m, nil
+		}
+	}
+	return nil, fmt.Errorf("unexpected json token %v", tok)
+}
+
+// decodeDagJSONReserved decodes the value of a map with the single "/" key,
+// which is either a link or bytes.
+func decodeDagJSONReserved(v Node) (Node, error) {
+	switch v := v.(type) {
+	case StringNode:
+		c, err := cid.Decode(string(v))
+		if err != nil {
+			return nil, xerrors.Errorf("decoding link: %w", err)
+		}
+		return LinkNode(c), nil
+	case MapNode:
+		if len(v) == 1 && v[0].Key == "bytes" {
+			if s, ok := v[0].Value.(StringNode); ok {
+				b, err := base64.RawStdEncoding.DecodeString(strings.TrimRight(string(s), "="))
+				if err != nil {
+					return nil, xerrors.Errorf("decoding bytes: %w", err)
+				}
+				return BytesNode(b), nil
+			}
+		}
+	}
+	return nil, fmt.Errorf("invalid use of the reserved \"/\" map key")
+}
+
+func parseDagJSONNumber(s string) (Node, error) {
+	if strings.ContainsAny(s, ".eE") {
+		f, err := strconv.ParseFloat(s, 64)
+		if err != nil {
+			return nil, err
+		}
+		return FloatNode(f), nil
+	}
+	if strings.HasPrefix(s, "-") {
+		v, ok := new(big.Int).SetString(s, 10)
+		if !ok {
+			return nil, fmt.Errorf("invalid integer %s", s)
+		}
+		// -1 - v, which is the CBOR argument of a negative integer.
+		v.Neg(v).Sub(v, big.NewInt(1))
+		if !v.IsUint64() {
+			return nil, fmt.Errorf("integer %s out of range", s)
+		}
+		return IntNode{Negative: true, Value: v.Uint64()}, nil
+	}
+	v, err := strconv.ParseUint(s, 10, 64)
+	if err != nil {
+		return nil, err
+	}
+	return IntNode{Value: v}, nil
+}
@@ -0,0 +1,76 @@
+package typegen
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestDagJSONTranscode(t *testing.T) {
+	for _, in := range []string{
+		`null`,
+		`[1,-2,1.5,"x",true]`,
+		`{"a":{"/":"bafkqaaa"},"bb":{"/":{"bytes":"AQID"}},"c":[]}`,
+		`18446744073709551615`,
+		`-18446744073709551616`,
+	} {
+		cb := new(bytes.Buffer)
+		if err := DagJSONToCBOR(cb, strings.NewReader(in)); err != nil {
+			t.Fatalf("transcoding %s to cbor: %s", in, err)
+		}
+		if err := ValidateCBOR(cb.Bytes()); err != nil {
+			t.Fatal(err)
+		}
+
+		d := Deferred{Raw: cb.Bytes()}
+		js := new(bytes.Buffer)
+		if err := d.MarshalDAGJSON(js); err != nil {
+			t.Fatalf("transcoding %s back to json: %s", in, err)
+		}
+		if js.String() != in {
+			t.Fatalf("expected %s, got %s", in, js.String())
+		}
+	}
+}
+
+func TestDagJSONSortsKeys(t *testing.T) {
+	var d Deferred
+	if err := d.UnmarshalDAGJSON(strings.NewReader(`{"bb": 1, "c": 2, "a": 3}`)); err != nil {
+		t.Fatal(err)
+	}
+	// CBOR sorts keys by length first, DAG-JSON by bytes.
+	if s := d.String(); s != `{"a": 3, "c": 2, "bb": 1}` {
+		t.Fatalf("unexpected cbor %s", s)
+	}
+	js := new(bytes.Buffer)
+	if err := d.MarshalDAGJSON(js); err != nil {
+		t.Fatal(err)
+	}
+	if js.String() != `{"a":3,"bb":1,"c":2}` {
+		t.Fatalf("unexpected json %s", js.String())
+	}
+}
+
+func TestCBORToDagJSONInvalidUTF8(t *testing.T) {
+	// Replacing the invalid byte with U+FFFD would lose it.
+	err := CBORToDagJSON(new(bytes.Buffer), bytes.NewReader([]byte{0x61, 0xd8}))
+	if !errors.Is(err, ErrInvalidUTF8) {
+		t.Fatalf("expected an invalid utf-8 error, got %v", err)
+	}
+}
+
+func TestDagJSONErrors(t *testing.T) {
+	for _, in := range []string{
+		`{"/":"notacid"}`,
+		`{"/":{"bytes":1}}`,
+		`{"a":1,"a":2}`,
+		`[1,`,
+		`1.5e999`,
+		strings.Repeat("[", MaxNestingDepth+1) + strings.Repeat("]", MaxNestingDepth+1),
+	} {
+		if err := DagJSONToCBOR(new(bytes.Buffer), strings.NewReader(in)); err == nil {
+			t.Fatalf("expected an error transcoding %s", in)
+		}
+	}
+}
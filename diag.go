@@ -0,0 +1,426 @@
+package typegen
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"math/big"
+	"strconv"
+	"strings"
+
+	"github.com/ipfs/go-cid"
+	"golang.org/x/xerrors"
+)
+
+// Diagnose renders CBOR as diagnostic notation, as described in RFC 8949
+// section 8, e.g. `{"a": [1, h'0102', 42(h'0001...')]}`.
+//
+// Unlike the rest of this package, Diagnose accepts any well-formed CBOR,
+// including non-canonical and indefinite-length encodings, since it is meant to
+// help debugging payloads that fail to decode. Integers and lengths that are not
+// encoded in their shortest form get an encoding indicator (`_0` to `_3`). If b
+// holds more than one item, they are rendered as a comma separated CBOR
+// sequence. On error, the notation rendered so far is returned along with the
+// error.
+func Diagnose(b []byte) (string, error) {
+	return diagnose(b, false, nil)
+}
+
+// DiagnosePretty is like Diagnose, but follows each link (tag 42) with a
+// comment holding its CID string, e.g. `42(h'0001...') / bafy... /`.
+func DiagnosePretty(b []byte) (string, error) {
+	return diagnose(b, true, nil)
+}
+
+// DiagnoseWithLimits is like Diagnose, but also checks that the items of b are
+// within limits, see DecodeLimits. Elements of indefinite length arrays and
+// maps are counted one by one.
+func DiagnoseWithLimits(b []byte, limits *DecodeLimits) (string, error) {
+	return diagnose(b, false, limits)
+}
+
+func diagnose(b []byte, prettyCids bool, limits *DecodeLimits) (string, error) {
+	d := diagnoser{b: b, prettyCids: prettyCids, limits: limits}
+	for first := true; d.off < len(d.b); first = false {
+		if !first {
+			d.sb.WriteString(", ")
+		}
+		if err := d.item(); err != nil {
+			return d.sb.String(), xerrors.Errorf("at offset %d: %w", d.off, err)
+		}
+	}
+	return d.sb.String(), nil
+}
+
+// String renders the deferred CBOR in diagnostic notation, see DiagnosePretty.
+func (d *Deferred) String() string {
+	if d == nil {
+		return "null"
+	}
+	s, err := DiagnosePretty(d.Raw)
+	if err != nil {
+		return fmt.Sprintf("h'%x' / %s /", d.Raw, err)
+	}
+	return s
+}
+
+// diagIndefinite is the additional information signaling an indefinite length.
+const diagIndefinite = 31
+
+type diagnoser struct {
+	b          []byte
+	off        int
+	sb         strings.Builder
+	prettyCids bool
+	limits     *DecodeLimits
+}
+
+// header reads an item header. Floats are returned as their raw bits in extra,
+// with the size of the argument in bytes. An indefinite length is signaled by
+// indefinite, and the break code by maj == MajOther && indefinite.
+func (d *diagnoser) header() (maj byte, extra uint64, size int, indefinite bool, err error) {
+	if d.off >= len(d.b) {
+		return 0, 0, 0, false, io.ErrUnexpectedEOF
+	}
+	first := d.b[d.off]
+	d.off++
+
+	maj = first >> 5
+	low := first & 0x1f
+	switch {
+	case low < 24:
+		return maj, uint64(low), 0, false, nil
+	case low <= 27:
+		size = 1 << (low - 24)
+	case low == diagIndefinite:
+		switch maj {
+		case MajUnsignedInt, MajNegativeInt, MajTag:
+			return 0, 0, 0, false, fmt.Errorf("invalid header: (%x)", first)
+		}
+		return maj, 0, 0, true, nil
+	default:
+		return 0, 0, 0, false, fmt.Errorf("invalid header: (%x)", first)
+	}
+
+	if len(d.b)-d.off < size {
+		return 0, 0, 0, false, io.ErrUnexpectedEOF
+	}
+	arg := d.b[d.off : d.off+size]
+	d.off += size
+	switch size {
+	case 1:
+		extra = uint64(arg[0])
+	case 2:
+		extra = uint64(binary.BigEndian.Uint16(arg))
+	case 4:
+		extra = uint64(binary.BigEndian.Uint32(arg))
+	default:
+		extra = binary.BigEndian.Uint64(arg)
+	}
+	return maj, extra, size, false, nil
+}
+
+// indicator returns the encoding indicator to append to an integer or length
+// whose argument was encoded on size bytes, or "" if that's its shortest form.
+func indicator(extra uint64, size int) string {
+	if size == 0 || size == len(CborEncodeMajorType(0, extra))-1 {
+		return ""
+	}
+	switch size {
+	case 1:
+		return "_0"
+	case 2:
+		return "_1"
+	case 4:
+		return "_2"
+	default:
+		return "_3"
+	}
+}
+
+// diagLevel is an array, map or tag being rendered by item.
+type diagLevel struct {
+	maj        byte
+	extra      uint64
+	size       int
+	indefinite bool
+	// left is the number of elements or entries left to render when the
+	// length is definite, value is set between the key and the value of a map
+	// entry, and started once the first item is rendered.
+	left    uint64
+	value   bool
+	started bool
+	// itemStart is the offset of the tagged item.
+	itemStart int
+}
+
+// item renders the next item. Nested items are rendered in a loop, keeping the
+// arrays, maps and tags they are in on a stack rather than recursing, so that
+// deeply nested input can't overflow the goroutine stack.
+func (d *diagnoser) item() error {
+	var levels []diagLevel
+	defer func() {
+		for _, level := range levels {
+			if level.maj != MajTag {
+				d.limits.Leave()
+			}
+		}
+	}()
+
+	for {
+		start := d.off
+		maj, extra, size, indefinite, err := d.header()
+		if err != nil {
+			return err
+		}
+
+		opened := false
+		switch maj {
+		case MajUnsignedInt:
+			d.sb.WriteString(strconv.FormatUint(extra, 10))
+			d.sb.WriteString(indicator(extra, size))
+		case MajNegativeInt:
+			v := new(big.Int).SetUint64(extra)
+			v.Add(v, big.NewInt(1)).Neg(v)
+			d.sb.WriteString(v.String())
+			d.sb.WriteString(indicator(extra, size))
+		case MajByteString, MajTextString:
+			if indefinite {
+				err = d.chunks(maj)
+			} else {
+				err = d.str(maj, extra, size)
+			}
+			if err != nil {
+				return err
+			}
+		case MajArray, MajMap:
+			if maj == MajArray {
+				d.sb.WriteByte('[')
+			} else {
+				d.sb.WriteByte('{')
+			}
+			if indefinite {
+				d.sb.WriteString("_ ")
+			} else if err := d.limits.AddItems(extra); err != nil {
+				return err
+			}
+			if err := d.limits.Enter(); err != nil {
+				return err
+			}
+			levels = append(levels, diagLevel{maj: maj, extra: extra, size: size, indefinite: indefinite, left: extra})
+			opened = true
+		case MajTag:
+			d.sb.WriteString(strconv.FormatUint(extra, 10))
+			d.sb.WriteString(indicator(extra, size))
+			d.sb.WriteByte('(')
+			levels = append(levels, diagLevel{maj: maj, extra: extra, left: 1, itemStart: d.off})
+			opened = true
+		case MajOther:
+			if indefinite {
+				return fmt.Errorf("unexpected break code")
+			}
+			switch size {
+			case 2:
+				d.float(float64(float16ToFloat32(uint16(extra))))
+			case 4:
+				d.float(float64(math.Float32frombits(uint32(extra))))
+			case 8:
+				d.float(math.Float64frombits(extra))
+			default:
+				switch extra {
+				case 20:
+					d.sb.WriteString("false")
+				case 21:
+					d.sb.WriteString("true")
+				case 22:
+					d.sb.WriteString("null")
+				case 23:
+					d.sb.WriteString("undefined")
+				default:
+					if size == 1 && extra < 32 {
+						return fmt.Errorf("invalid header: (%x)", d.b[start:d.off])
+					}
+					fmt.Fprintf(&d.sb, "simple(%d)", extra)
+				}
+			}
+		}
+
+		// Count the rendered item in the level it belongs to, and close the
+		// levels it completes.
+		done := !opened
+		for len(levels) > 0 {
+			level := &levels[len(levels)-1]
+			if done {
+				level.started = true
+				if level.maj == MajMap && !level.value {
+					level.value = true
+				} else {
+					level.value = false
+					if !level.indefinite {
+						level.left--
+					} else if err := d.limits.AddItems(1); err != nil {
+						return err
+					}
+				}
+			}
+
+			// Indefinite levels end with a break code, which can't come
+			// between a key and its value, and definite ones after their
+			// last element.
+			switch {
+			case level.indefinite && level.value:
+				done = false
+			case level.indefinite:
+				if done, err = d.isBreak(true); err != nil {
+					return err
+				}
+			default:
+				done = level.left == 0
+			}
+			if !done {
+				break
+			}
+			d.close(level)
+			if level.maj != MajTag {
+				d.limits.Leave()
+			}
+			levels = levels[:len(levels)-1]
+		}
+		if len(levels) == 0 {
+			return nil
+		}
+
+		level := &levels[len(levels)-1]
+		switch {
+		case level.value:
+			d.sb.WriteString(": ")
+		case level.started && level.maj != MajTag:
+			d.sb.WriteString(", ")
+		}
+	}
+}
+
+// close ends the rendering of an array, map or tag.
+func (d *diagnoser) close(level *diagLevel) {
+	switch level.maj {
+	case MajArray, MajMap:
+		if level.maj == MajArray {
+			d.sb.WriteByte(']')
+		} else {
+			d.sb.WriteByte('}')
+		}
+		if !level.indefinite {
+			d.sb.WriteString(indicator(level.extra, level.size))
+		}
+	case MajTag:
+		d.sb.WriteByte(')')
+		if level.extra == 42 && d.prettyCids {
+			if c, err := diagCid(d.b[level.itemStart:d.off]); err == nil {
+				d.sb.WriteString(" / ")
+				d.sb.WriteString(c.String())
+				d.sb.WriteString(" /")
+			}
+		}
+	}
+}
+
+// isBreak consumes the break code ending an indefinite length item, if it's
+// next.
+func (d *diagnoser) isBreak(indefinite bool) (bool, error) {
+	if !indefinite {
+		return false, nil
+	}
+	if d.off >= len(d.b) {
+		return false, io.ErrUnexpectedEOF
+	}
+	if d.b[d.off] == 0xff {
+		d.off++
+		return true, nil
+	}
+	return false, nil
+}
+
+func (d *diagnoser) str(maj byte, extra uint64, size int) error {
+	if err := d.limits.AddBytes(extra); err != nil {
+		return err
+	}
+	if uint64(len(d.b)-d.off) < extra {
+		return io.ErrUnexpectedEOF
+	}
+	s := d.b[d.off : d.off+int(extra)]
+	d.off += int(extra)
+
+	if maj == MajByteString {
+		d.sb.WriteString("h'")
+		d.sb.WriteString(hex.EncodeToString(s))
+		d.sb.WriteByte('\'')
+	} else {
+		var buf bytes.Buffer
+		enc := json.NewEncoder(&buf)
+		enc.SetEscapeHTML(false)
+		if err := enc.Encode(string(s)); err != nil {
+			return err
+		}
+		d.sb.Write(bytes.TrimSuffix(buf.Bytes(), []byte{'\n'}))
+	}
+	d.sb.WriteString(indicator(extra, size))
+	return nil
+}
+
+// chunks renders an indefinite length string, made of definite length chunks
+// of the same major type.
+func (d *diagnoser) chunks(maj byte) error {
+	d.sb.WriteString("(_ ")
+	for i := 0; ; i++ {
+		if done, err := d.isBreak(true); err != nil {
+			return err
+		} else if done {
+			break
+		}
+		cmaj, extra, size, indefinite, err := d.header()
+		if err != nil {
+			return err
+		}
+		if cmaj != maj || indefinite {
+			return fmt.Errorf("invalid chunk in indefinite length string")
+		}
+		if i > 0 {
+			d.sb.WriteString(", ")
+		}
+		if err := d.str(maj, extra, size); err != nil {
+			return err
+		}
+	}
+	d.sb.WriteByte(')')
+	return nil
+}
+
+func (d *diagnoser) float(f float64) {
+	switch {
+	case math.IsNaN(f):
+		d.sb.WriteString("NaN")
+	case math.IsInf(f, 1):
+		d.sb.WriteString("Infinity")
+	case math.IsInf(f, -1):
+		d.sb.WriteString("-Infinity")
+	default:
+		s := strconv.FormatFloat(f, 'g', -1, 64)
+		if !strings.ContainsAny(s, ".e") {
+			s += ".0"
+		}
+		d.sb.WriteString(s)
+	}
+}
+
+// diagCid parses the byte string content of a link.
+func diagCid(b []byte) (cid.Cid, error) {
+	buf, _, err := ReadByteArray(bytes.NewReader(b), uint64(len(b)))
+	if err != nil {
+		return cid.Undef, err
+	}
+	return bufToCid(buf)
+}
@@ -0,0 +1,105 @@
+package typegen
+
+import (
+	"bytes"
+	"encoding/hex"
+	"strings"
+	"testing"
+
+	"github.com/ipfs/go-cid"
+)
+
+func TestDiagnose(t *testing.T) {
+	for in, expected := range map[string]string{
+		"00":                   "0",
+		"1903e8":               "1000",
+		"1800":                 "0_0",
+		"20":                   "-1",
+		"3bffffffffffffffff":   "-18446744073709551616",
+		"f4":                   "false",
+		"f6":                   "null",
+		"f7":                   "undefined",
+		"f93e00":               "1.5",
+		"fb4000000000000000":   "2.0",
+		"f97c00":               "Infinity",
+		"4401020304":           "h'01020304'",
+		"6449455446":           `"IETF"`,
+		"62225c":               `"\"\\"`,
+		"83010203":             "[1, 2, 3]",
+		"a201020304":           "{1: 2, 3: 4}",
+		"a26161016162820203":   `{"a": 1, "b": [2, 3]}`,
+		"9f018202039f0405ffff": "[_ 1, [2, 3], [_ 4, 5]]",
+		"5f42010243030405ff":   "(_ h'0102', h'030405')",
+		"c11a514b67b0":         "1(1363896240)",
+		"0102":                 "1, 2",
+		"80":                   "[]",
+		"bfff":                 "{_ }",
+		"bf616101ff":           `{_ "a": 1}`,
+		"bf6161bf6162f5ffff":   `{_ "a": {_ "b": true}}`,
+		"a1616180":             `{"a": []}`,
+		"82c10102":             "[1(1), 2]",
+		"98020102":             "[1, 2]_0",
+		"c1c2a10180":           "1(2({1: []}))",
+	} {
+		b, _ := hex.DecodeString(in)
+		out, err := Diagnose(b)
+		if err != nil {
+			t.Fatalf("diagnosing %s: %s", in, err)
+		}
+		if out != expected {
+			t.Fatalf("diagnosing %s: expected %s, got %s", in, expected, out)
+		}
+	}
+}
+
+func TestDiagnoseErrors(t *testing.T) {
+	for in, partial := range map[string]string{
+		"8301": "[1, ",
+		"a1":   "{",
+		"1c":   "",
+		"ff":   "",
+		"9f01": "[_ 1",
+	} {
+		b, _ := hex.DecodeString(in)
+		out, err := Diagnose(b)
+		if err == nil {
+			t.Fatalf("expected an error diagnosing %s", in)
+		}
+		if out != partial {
+			t.Fatalf("diagnosing %s: expected partial output %q, got %q", in, partial, out)
+		}
+	}
+}
+
+func TestDiagnoseDeep(t *testing.T) {
+	const depth = 1 << 20
+	b := append(bytes.Repeat([]byte{0x81}, depth), 0x00)
+	out, err := Diagnose(b)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if out != strings.Repeat("[", depth)+"0"+strings.Repeat("]", depth) {
+		t.Fatal("unexpected output")
+	}
+}
+
+func TestDeferredString(t *testing.T) {
+	c, err := cid.Parse("bafkqaaa")
+	if err != nil {
+		t.Fatal(err)
+	}
+	buf := new(bytes.Buffer)
+	if _, err := WriteCid(buf, c); err != nil {
+		t.Fatal(err)
+	}
+
+	d := Deferred{Raw: buf.Bytes()}
+	if s, expected := d.String(), "42(h'0001550000') / bafkqaaa /"; s != expected {
+		t.Fatalf("expected %s, got %s", expected, s)
+	}
+
+	d = Deferred{Raw: []byte{0x81}}
+	if s, expected := d.String(), "h'81' / at offset 1: unexpected EOF /"; s != expected {
+		t.Fatalf("expected %s, got %s", expected, s)
+	}
+}
@@ -0,0 +1,103 @@
+package typegen
+
+import (
+	"errors"
+	"fmt"
+)
+
+var (
+	// ErrMaxLength is returned when the length of a CBOR item is beyond the
+	// maximum allowed, see MaxLength and ByteArrayMaxLen.
+	ErrMaxLength = errors.New("length beyond maximum allowed")
+	// ErrUnexpectedType is returned when a CBOR item has an unexpected major
+	// type.
+	ErrUnexpectedType = errors.New("unexpected major type")
+	// ErrOverflow is returned when an integer doesn't fit the type decoded
+	// into.
+	ErrOverflow = errors.New("integer overflow")
+	// ErrInvalidUTF8 is returned when a CBOR text string isn't valid UTF-8,
+	// which RFC 8949 requires.
+	ErrInvalidUTF8 = errors.New("invalid utf-8 in text string")
+)
+
+// DecodeError is returned by generated decoders, and by the decoding
+// functions of this package, when they fail. It wraps one of the errors above
+// when it applies, so errors.Is and errors.As work through it.
+type DecodeError struct {
+	// Type is the name of the type being decoded, and Field is the path of
+	// the failing field within it, e.g. "Stuff.Arrrrrghay[1].Foo". Both are
+	// empty when a function of this package fails outside of a generated
+	// decoder.
+	Type  string
+	Field string
+	// Offset is the position of the failing item in the encoding of Type.
+	Offset int
+	// Expected and Found are the major types the decoder expected and found
+	// at Offset, when it got to read a header there.
+	Expected byte
+	Found    byte
+	Err      error
+}
+
+func (e *DecodeError) Error() string {
+	msg := e.Err.Error()
+	if errors.Is(e.Err, ErrUnexpectedType) {
+		msg = fmt.Sprintf("%s %d, expected %d", msg, e.Found, e.Expected)
+	}
+
+	path := e.Type
+	if e.Field != "" {
+		if path != "" {
+			path += "."
+		}
+		path += e.Field
+	}
+	if path == "" {
+		return fmt.Sprintf("at byte %d: %s", e.Offset, msg)
+	}
+	return fmt.Sprintf("%s at byte %d: %s", path, e.Offset, msg)
+}
+
+func (e *DecodeError) Unwrap() error {
+	return e.Err
+}
+
+// WrapDecodeError returns err, which was returned when decoding the field of
+// typ at offset, as a DecodeError on that field. The path and offset of a
+// DecodeError from a nested decoder are made relative to typ.
+func WrapDecodeError(err error, typ, field string, offset int) error {
+	de, ok := err.(*DecodeError)
+	if !ok {
+		return &DecodeError{Type: typ, Field: field, Offset: offset, Err: err}
+	}
+
+	wrapped := *de
+	wrapped.Type = typ
+	switch {
+	case field == "":
+	case de.Field == "":
+		wrapped.Field = field
+	default:
+		wrapped.Field = field + "." + de.Field
+	}
+	wrapped.Offset += offset
+	return &wrapped
+}
+
+// ConstraintError is wrapped in the DecodeError returned by generated decoders
+// when a decoded field violates a constraint of its cborgen tag, see
+// parseFieldTag. Length constraints on byte string, slice and map fields are
+// checked on their header, at the Offset of the DecodeError, before the value
+// is allocated. Other constraints are checked once the whole type is decoded,
+// so the Offset is the end of its encoding.
+type ConstraintError struct {
+	// Constraint is the violated tag option, e.g. "max=100".
+	Constraint string
+	// Got describes the decoded value, or its length, e.g. "101" or
+	// "length 31".
+	Got string
+}
+
+func (e *ConstraintError) Error() string {
+	return fmt.Sprintf("%s violates %s", e.Got, e.Constraint)
+}
@@ -0,0 +1,56 @@
+package typegen
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"testing"
+)
+
+func TestWrapDecodeError(t *testing.T) {
+	inner := &DecodeError{Type: "Inner", Field: "Value", Offset: 3, Expected: MajUnsignedInt, Found: MajTextString, Err: ErrUnexpectedType}
+	err := WrapDecodeError(inner, "Outer", "Items[2]", 10)
+	var derr *DecodeError
+	if !errors.As(err, &derr) || !errors.Is(err, ErrUnexpectedType) {
+		t.Fatalf("expected a DecodeError wrapping ErrUnexpectedType, got %v", err)
+	}
+	if derr.Type != "Outer" || derr.Field != "Items[2].Value" || derr.Offset != 13 {
+		t.Fatalf("unexpected wrapped error %+v", derr)
+	}
+	if want := "Outer.Items[2].Value at byte 13: unexpected major type 3, expected 0"; err.Error() != want {
+		t.Fatalf("expected %q, got %q", want, err)
+	}
+	if inner.Type != "Inner" || inner.Offset != 3 {
+		t.Fatal("wrapping modified the inner error")
+	}
+
+	err = WrapDecodeError(io.ErrUnexpectedEOF, "Outer", "Name", 4)
+	if !errors.Is(err, io.ErrUnexpectedEOF) || err.Error() != "Outer.Name at byte 4: unexpected EOF" {
+		t.Fatalf("unexpected wrapped error %v", err)
+	}
+}
+
+func TestReadErrors(t *testing.T) {
+	_, _, err := ReadStringBuf(bytes.NewReader(CborEncodeMajorType(MajByteString, 0)), make([]byte, 9))
+	if !errors.Is(err, ErrUnexpectedType) || err.Error() != "at byte 0: unexpected major type 2, expected 3" {
+		t.Fatalf("unexpected error %v", err)
+	}
+
+	_, _, err = ReadByteArray(bytes.NewReader(CborEncodeMajorType(MajByteString, 10)), 5)
+	if !errors.Is(err, ErrMaxLength) {
+		t.Fatalf("expected ErrMaxLength, got %v", err)
+	}
+
+	_, _, err = ReadMapKeyBuf(bytes.NewReader(CborEncodeMajorType(MajNegativeInt, 1<<63)), make([]byte, 9))
+	if !errors.Is(err, ErrOverflow) {
+		t.Fatalf("expected ErrOverflow, got %v", err)
+	}
+
+	// The tag header precedes the byte string.
+	enc := append(CborEncodeMajorType(MajTag, 42), CborEncodeMajorType(MajTextString, 0)...)
+	_, _, err = ReadCid(bytes.NewReader(enc))
+	var derr *DecodeError
+	if !errors.As(err, &derr) || derr.Offset != 2 || derr.Found != MajTextString {
+		t.Fatalf("unexpected error %v", err)
+	}
+}
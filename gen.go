@@ -1,11 +1,13 @@
 package typegen
 
 import (
+	"bytes"
 	"container/list"
 	"fmt"
 	"io"
 	"math/big"
 	"reflect"
+	"strconv"
 	"strings"
 	"text/template"
 
@@ -20,6 +22,9 @@ var (
 	cidType      = reflect.TypeOf(cid.Cid{})
 	bigIntType   = reflect.TypeOf(big.Int{})
 	deferredType = reflect.TypeOf(Deferred{})
+
+	preMarshalerType    = reflect.TypeOf((*PreMarshaler)(nil)).Elem()
+	postUnmarshalerType = reflect.TypeOf((*PostUnmarshaler)(nil)).Elem()
 )
 
 func doTemplate(w io.Writer, info interface{}, templ string) error {
@@ -79,11 +84,11 @@ var _ = sort.Sort
 func emitInitNilEmbeddedStructMethod(w io.Writer, gti *GenTypeInfo,
 	embeddedByPointerStructs []string) error {
 	data := struct {
-		Name   string
-		Embeds []string
-	}{gti.Name, embeddedByPointerStructs}
+		Receiver string
+		Embeds   []string
+	}{gti.Receiver(), embeddedByPointerStructs}
 	return doTemplate(w, data, `
-func (t *{{ .Name }}) InitNilEmbeddedStruct() {
+func (t *{{ .Receiver }}) InitNilEmbeddedStruct() {
 	if t != nil {
 		{{ range .Embeds }}if t.{{ . }} == nil {
 			t.{{ . }} = &{{ . }}{}
@@ -100,11 +105,43 @@ type Field struct {
 	Pointer bool
 	Type    reflect.Type
 	Pkg     string
+	// IntKey is set if MapKey is an integer, see parseFieldTag.
+	IntKey bool
+	// Aliases are other map keys accepted when decoding, see parseFieldTag.
+	Aliases []MapKey
+	// Optional fields may be missing from the input, see parseFieldTag.
+	Optional bool
+	// Default is the Go literal of the value of the field when its map key is
+	// missing, and OmitDefault fields equal to their default value aren't
+	// encoded, see parseFieldTag.
+	Default     string
+	OmitDefault bool
+	// Constraints are checked by decoders, see parseFieldTag.
+	Constraints []Constraint
+	// DecodeOnly fields aren't encoded, and EncodeOnly fields aren't decoded,
+	// see parseFieldTag.
+	DecodeOnly bool
+	EncodeOnly bool
+	// Index is the tuple position of the field if HasIndex is set, see
+	// parseFieldTag.
+	Index    int
+	HasIndex bool
+	// Embeds are the selectors of the struct pointers through which the field
+	// is promoted, when embedded structs are flattened.
+	Embeds []string
 
 	IterLabel string
+	// Struct is the name of the type whose decoder reads the field, and Path
+	// the Go expression of the path of the field in its decode errors, by
+	// default derived from Name, see DecodeError.
+	Struct string
+	Path   string
 }
 
 func typeName(pkg string, t reflect.Type) string {
+	if i := typeParamIndex(t); i >= 0 {
+		return typeParamName(i)
+	}
 	switch t.Kind() {
 	case reflect.Array:
 		return fmt.Sprintf("[%d]%s", t.Len(), typeName(pkg, t.Elem()))
@@ -126,6 +163,101 @@ func typeName(pkg string, t reflect.Type) string {
 	}
 }
 
+// encodedMapKey returns the CBOR encoding of the map key of f.
+func (f Field) encodedMapKey() []byte {
+	if f.IntKey {
+		v, _ := strconv.ParseInt(f.MapKey, 10, 64)
+		if v < 0 {
+			return CborEncodeMajorType(MajNegativeInt, uint64(-1-v))
+		}
+		return CborEncodeMajorType(MajUnsignedInt, uint64(v))
+	}
+	return append(CborEncodeMajorType(MajTextString, uint64(len(f.MapKey))), f.MapKey...)
+}
+
+// MapKeyAsByteString returns the encoded map key of f as a Go byte slice.
+func (f Field) MapKeyAsByteString() string {
+	s := "[]byte{"
+	for _, b := range f.encodedMapKey() {
+		s += fmt.Sprintf("%d,", b)
+	}
+	s += "}"
+	return s
+}
+
+// mapKeyLess orders fields by their encoded map keys, shorter ones first then
+// bytewise, as required by RFC 7049 canonical CBOR. For text keys, it's the
+// same as mapKeySort_RFC7049Less.
+func mapKeyLess(a, b Field) bool {
+	ka, kb := a.encodedMapKey(), b.encodedMapKey()
+	if len(ka) != len(kb) {
+		return len(ka) < len(kb)
+	}
+	return bytes.Compare(ka, kb) < 0
+}
+
+// mapKeys returns the map key of f followed by its aliases.
+func (f Field) mapKeys() []MapKey {
+	key := TextKey(f.MapKey)
+	if f.IntKey {
+		v, _ := strconv.ParseInt(f.MapKey, 10, 64)
+		key = IntKey(v)
+	}
+	return append([]MapKey{key}, f.Aliases...)
+}
+
+// mapKeyCases returns the expressions of the case clause matching the map
+// keys of f, compared as MapKey values if asMapKey is set, or as strings.
+func (f Field) mapKeyCases(asMapKey bool) string {
+	var cases []string
+	for _, k := range f.mapKeys() {
+		switch {
+		case !asMapKey:
+			cases = append(cases, strconv.Quote(k.Text))
+		case k.Int:
+			cases = append(cases, fmt.Sprintf("cbg.IntKey(%d)", k.Value))
+		default:
+			cases = append(cases, fmt.Sprintf("cbg.TextKey(%q)", k.Text))
+		}
+	}
+	return strings.Join(cases, ", ")
+}
+
+// DefaultValue returns the Go literal of the default value of f, its zero
+// value if it has none.
+func (f Field) DefaultValue() string {
+	if f.Default != "" {
+		return f.Default
+	}
+	switch f.Type.Kind() {
+	case reflect.Bool:
+		return "false"
+	case reflect.String:
+		return `""`
+	default:
+		return "0"
+	}
+}
+
+// IsDefault returns the condition that t.<field> equals its default value.
+func (f Field) IsDefault() string {
+	return f.defaultCond(true)
+}
+
+func (f Field) defaultCond(equal bool) string {
+	v := f.DefaultValue()
+	if f.Type.Kind() == reflect.Bool {
+		if (v == "true") == equal {
+			return "t." + f.Name
+		}
+		return "!t." + f.Name
+	}
+	if equal {
+		return fmt.Sprintf("t.%s == %s", f.Name, v)
+	}
+	return fmt.Sprintf("t.%s != %s", f.Name, v)
+}
+
 func (f Field) TypeName() string {
 	return typeName(f.Pkg, f.Type)
 }
@@ -134,6 +266,21 @@ func (f Field) ElemName() string {
 	return typeName(f.Pkg, f.Type.Elem())
 }
 
+// Comment describes the field in the comments of the generated code, e.g.
+// "t.Items ([]T0) (slice)".
+func (f Field) Comment() string {
+	kind := f.Type.Kind().String()
+	if f.IsTypeParam() {
+		kind = "type parameter"
+	}
+	return fmt.Sprintf("t.%s (%s) (%s)", f.Name, typeParamString(f.Type), kind)
+}
+
+// IsTypeParam reports whether the field is of a type parameter.
+func (f Field) IsTypeParam() bool {
+	return typeParamIndex(f.Type) >= 0
+}
+
 func (f Field) IsArray() bool {
 	return f.Type.Kind() == reflect.Array
 }
@@ -142,9 +289,93 @@ func (f Field) Len() int {
 	return f.Type.Len()
 }
 
+// errPath returns the Go expression of the path of the field in decode errors,
+// e.g. fmt.Sprintf("Test[%d]", i) for t.Test[i].
+func (f Field) errPath() string {
+	if f.Path != "" {
+		return f.Path
+	}
+	format, args := errPathFormat(f.Name)
+	return sprintfExpr(format, args)
+}
+
+// errPathFormat splits a field expression, like t.Test[i][j], into the format
+// and arguments of its path in decode errors, like "Test[%d][%d]", i and j.
+func errPathFormat(name string) (string, []string) {
+	var format strings.Builder
+	var args []string
+	rest := strings.TrimPrefix(name, "t.")
+	for {
+		open := strings.IndexByte(rest, '[')
+		if open < 0 {
+			break
+		}
+		end := open + strings.IndexByte(rest[open:], ']')
+		format.WriteString(rest[:open] + "[%d]")
+		args = append(args, rest[open+1:end])
+		rest = rest[end+1:]
+	}
+	format.WriteString(rest)
+	return format.String(), args
+}
+
+// sprintfExpr returns the Go expression formatting args with format.
+func sprintfExpr(format string, args []string) string {
+	if len(args) == 0 {
+		return strconv.Quote(format)
+	}
+	return fmt.Sprintf("fmt.Sprintf(%q, %s)", format, strings.Join(args, ", "))
+}
+
+// DecodeError returns the Go expression of a DecodeError wrapping err, for the
+// item of the field whose header was just read into maj and read.
+func (f Field) DecodeError(err, expected string) string {
+	return fmt.Sprintf("&cbg.DecodeError{Type: %q, Field: %s, Offset: bytesRead - read, Expected: %s, Found: maj, Err: %s}",
+		f.Struct, f.errPath(), expected, err)
+}
+
+// LengthChecks returns the Go statements checking the length constraints of
+// the field on the length read from its header, extra, so that values
+// violating them are rejected before being allocated.
+func (f Field) LengthChecks(expected string) string {
+	var b strings.Builder
+	for _, c := range f.Constraints {
+		if !c.Length {
+			continue
+		}
+		err := fmt.Sprintf(`&cbg.ConstraintError{Constraint: %q, Got: fmt.Sprintf("length %%d", extra)}`, c.Option)
+		fmt.Fprintf(&b, "\n\tif %s {\n\t\treturn bytesRead, %s\n\t}",
+			fmt.Sprintf(c.Violated, "extra"), f.DecodeError(err, expected))
+	}
+	return b.String()
+}
+
+// WrapError returns the Go expression of err, returned when reading the field
+// at bytesRead, wrapped in a DecodeError.
+func (f Field) WrapError() string {
+	return fmt.Sprintf("cbg.WrapDecodeError(err, %q, %s, bytesRead)", f.Struct, f.errPath())
+}
+
 type GenTypeInfo struct {
-	Name   string
-	Fields []Field
+	Name string
+	// TypeParams are the names of the type parameters of generic types, see
+	// TypeParam0.
+	TypeParams []string
+	Fields     []Field
+	// PreMarshal and PostUnmarshal are set if the type implements
+	// PreMarshaler and PostUnmarshaler, whose methods the generated code
+	// calls.
+	PreMarshal    bool
+	PostUnmarshal bool
+}
+
+// Receiver returns the receiver type of the generated methods, e.g. Page[T0]
+// for generic types.
+func (gti GenTypeInfo) Receiver() string {
+	if len(gti.TypeParams) == 0 {
+		return gti.Name
+	}
+	return gti.Name + "[" + strings.Join(gti.TypeParams, ", ") + "]"
 }
 
 func (gti *GenTypeInfo) Imports() []Import {
@@ -195,11 +426,194 @@ func (gti *GenTypeInfo) NeedsScratch() bool {
 	return false
 }
 
+// HasIntKeys reports whether some fields have integer map keys or aliases.
+func (gti *GenTypeInfo) HasIntKeys() bool {
+	for _, f := range gti.Fields {
+		if f.IntKey {
+			return true
+		}
+		for _, a := range f.Aliases {
+			if a.Int {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// fitsIPLD reports whether the encoded values of the type, in the repr
+// representation, fit the IPLD data model, which has neither integer map keys
+// nor bignums, so that they can be transcoded to DAG-JSON.
+func (gti *GenTypeInfo) fitsIPLD(repr string) bool {
+	for _, f := range gti.forEncoding().Fields {
+		if (repr == ReprMap && f.IntKey) || typeMayLeaveIPLD(f.Type, map[reflect.Type]bool{}) {
+			return false
+		}
+	}
+	return true
+}
+
+// typeMayLeaveIPLD reports whether values of type t may hold big.Int values or
+// structs encoding integer map keys. Type parameters may stand for such types.
+func typeMayLeaveIPLD(t reflect.Type, visiting map[reflect.Type]bool) bool {
+	switch t.Kind() {
+	case reflect.Ptr, reflect.Slice, reflect.Array, reflect.Map:
+		return typeMayLeaveIPLD(t.Elem(), visiting)
+	case reflect.Struct:
+		switch {
+		case t == bigIntType, typeParamIndex(t) >= 0:
+			return true
+		case t == cidType:
+			return false
+		}
+		if visiting[t] {
+			return false
+		}
+		visiting[t] = true
+		defer delete(visiting, t)
+		for i := 0; i < t.NumField(); i++ {
+			f := t.Field(i)
+			if !nameIsExported(f.Name) {
+				continue
+			}
+			if encodesIntKey(f.Tag.Get("cborgen")) || typeMayLeaveIPLD(f.Type, visiting) {
+				return true
+			}
+		}
+		return false
+	default:
+		return false
+	}
+}
+
+// encodesIntKey reports whether the cborgen tag of a field gives it an
+// integer map key used when encoding, see parseFieldTag.
+func encodesIntKey(tag string) bool {
+	intKey := false
+	for _, opt := range strings.Split(tag, ",")[1:] {
+		switch opt {
+		case "intkey":
+			intKey = true
+		case "decodeonly":
+			return false
+		}
+	}
+	return intKey
+}
+
+// SeenFields returns the fields whose map decoders track whether they were
+// seen, see Field.tracksSeen.
+func (gti *GenTypeInfo) SeenFields() []Field {
+	var fields []Field
+	for _, f := range gti.Fields {
+		if f.tracksSeen() {
+			fields = append(fields, f)
+		}
+	}
+	return fields
+}
+
+// tracksSeen reports whether map decoders track whether f was seen: to
+// reject its aliases as duplicates, to default it, or to only check the
+// constraints of optional fields when present.
+func (f Field) tracksSeen() bool {
+	return len(f.Aliases) > 0 || f.Default != "" || (f.Optional && len(f.Constraints) > 0)
+}
+
+// DefaultFields returns the fields with a default value.
+func (gti *GenTypeInfo) DefaultFields() []Field {
+	var fields []Field
+	for _, f := range gti.Fields {
+		if f.Default != "" {
+			fields = append(fields, f)
+		}
+	}
+	return fields
+}
+
+// OmittedFields returns the fields which aren't encoded when equal to their
+// default value.
+func (gti *GenTypeInfo) OmittedFields() []Field {
+	var fields []Field
+	for _, f := range gti.Fields {
+		if f.OmitDefault {
+			fields = append(fields, f)
+		}
+	}
+	return fields
+}
+
+// HasOptionalFields reports whether some fields are optional.
+func (gti *GenTypeInfo) HasOptionalFields() bool {
+	for _, f := range gti.Fields {
+		if f.Optional {
+			return true
+		}
+	}
+	return false
+}
+
+// HasConstraints reports whether some fields have constraints.
+func (gti *GenTypeInfo) HasConstraints() bool {
+	for _, f := range gti.Fields {
+		if len(f.Constraints) > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// IsAsymmetric reports whether some fields are only encoded or decoded.
+func (gti *GenTypeInfo) IsAsymmetric() bool {
+	for _, f := range gti.Fields {
+		if f.DecodeOnly || f.EncodeOnly {
+			return true
+		}
+	}
+	return false
+}
+
+// forEncoding returns gti without its decode-only fields.
+func (gti *GenTypeInfo) forEncoding() *GenTypeInfo {
+	return gti.filterFields(func(f Field) bool { return !f.DecodeOnly })
+}
+
+// forDecoding returns gti without its encode-only fields.
+func (gti *GenTypeInfo) forDecoding() *GenTypeInfo {
+	return gti.filterFields(func(f Field) bool { return !f.EncodeOnly })
+}
+
+func (gti *GenTypeInfo) filterFields(keep func(Field) bool) *GenTypeInfo {
+	filtered := *gti
+	filtered.Fields = nil
+	for _, f := range gti.Fields {
+		if keep(f) {
+			filtered.Fields = append(filtered.Fields, f)
+		}
+	}
+	return &filtered
+}
+
+// RequiredFields returns the number of fields before the first optional one.
+func (gti *GenTypeInfo) RequiredFields() int {
+	for i, f := range gti.Fields {
+		if f.Optional {
+			return i
+		}
+	}
+	return len(gti.Fields)
+}
+
 func nameIsExported(name string) bool {
 	return strings.ToUpper(name[0:1]) == name[0:1]
 }
 
 func ParseTypeInfo(i interface{}, flattenEmbeddedStruct bool) (
+	gti *GenTypeInfo, embeddedByPointerStructs *[]string, err error) {
+	return parseTypeInfo(i, flattenEmbeddedStruct, mapKeyOptions{})
+}
+
+func parseTypeInfo(i interface{}, flattenEmbeddedStruct bool, keys mapKeyOptions) (
 	gti *GenTypeInfo, embeddedByPointerStructs *[]string, err error) {
 	t := reflect.TypeOf(i)
 
@@ -208,11 +622,18 @@ func ParseTypeInfo(i interface{}, flattenEmbeddedStruct bool) (
 	fields := list.New()
 	fieldMap := map[string]*list.Element{}
 	embeddedByPointerStructs = &[]string{}
-	err = parseTypeInfoRecur(pkg, t, flattenEmbeddedStruct, 0, fields, fieldMap,
-		map[string]int{}, embeddedByPointerStructs)
+	err = parseTypeInfoRecur(pkg, t, flattenEmbeddedStruct, keys, 0, fields, fieldMap,
+		map[string]int{}, embeddedByPointerStructs, nil, nil)
 
+	name, typeParams, perr := parseTypeParams(t)
+	if perr != nil {
+		return nil, nil, perr
+	}
 	gti = &GenTypeInfo{
-		Name: t.Name(),
+		Name:          name,
+		TypeParams:    typeParams,
+		PreMarshal:    reflect.PtrTo(t).Implements(preMarshalerType),
+		PostUnmarshal: reflect.PtrTo(t).Implements(postUnmarshalerType),
 	}
 	for e := fields.Front(); e != nil; e = e.Next() {
 		gti.Fields = append(gti.Fields, e.Value.(Field))
@@ -222,8 +643,8 @@ func ParseTypeInfo(i interface{}, flattenEmbeddedStruct bool) (
 }
 
 func parseTypeInfoRecur(pkg string, t reflect.Type, flattenEmbeddedStruct bool,
-	depth int, fields *list.List, fieldMap map[string]*list.Element,
-	depths map[string]int, embeddedByPointerStructs *[]string) error {
+	keys mapKeyOptions, depth int, fields *list.List, fieldMap map[string]*list.Element,
+	depths map[string]int, embeddedByPointerStructs *[]string, sel, embeds []string) error {
 	for i := 0; i < t.NumField(); i++ {
 		f := t.Field(i)
 		if !nameIsExported(f.Name) {
@@ -243,7 +664,15 @@ func parseTypeInfoRecur(pkg string, t reflect.Type, flattenEmbeddedStruct bool,
 			if depth == 0 && pointer {
 				*embeddedByPointerStructs = append(*embeddedByPointerStructs, f.Name)
 			}
-			parseTypeInfoRecur(pkg, ft, true, depth+1, fields, fieldMap, depths, nil)
+			fsel := append(sel[:len(sel):len(sel)], f.Name)
+			fembeds := embeds
+			if pointer {
+				fembeds = append(embeds[:len(embeds):len(embeds)], strings.Join(fsel, "."))
+			}
+			if err := parseTypeInfoRecur(pkg, ft, true, keys, depth+1, fields, fieldMap, depths, nil,
+				fsel, fembeds); err != nil {
+				return err
+			}
 		} else {
 			if flattenEmbeddedStruct {
 				// If a previous field of the same name has a greater depth, skip
@@ -258,19 +687,18 @@ func parseTypeInfoRecur(pkg string, t reflect.Type, flattenEmbeddedStruct bool,
 				}
 			}
 
-			mapk := f.Name
-			tagval := f.Tag.Get("cborgen")
-			if tagval != "" {
-				mapk = tagval
-			}
-
-			f := Field{
+			field := Field{
 				Name:    f.Name,
-				MapKey:  mapk,
+				MapKey:  keys.mapKey(f),
 				Pointer: pointer,
 				Type:    ft,
 				Pkg:     pkg,
+				Embeds:  embeds,
+			}
+			if err := parseFieldTag(&field, f.Tag.Get("cborgen")); err != nil {
+				return err
 			}
+			f := field
 			// Push the new field to the back of the list
 			fieldMap[f.Name] = fields.PushBack(f)
 		}
@@ -279,6 +707,316 @@ func parseTypeInfoRecur(pkg string, t reflect.Type, flattenEmbeddedStruct bool,
 	return nil
 }
 
+// parseFieldTag applies a cborgen struct tag to f. The tag is the map key of
+// the field, optionally followed by comma separated options:
+//
+//   - alias=K: K is another map key of the field, accepted when decoding,
+//     typically its key before a rename. Fields are still encoded with their
+//     map key, and input holding several keys of a field is rejected. The
+//     option may be repeated.
+//   - intalias=N: like alias, with the integer map key N.
+//   - intkey: the map key is a decimal integer, as in `cborgen:"1,intkey"` or
+//     `cborgen:"-2,intkey"`, encoded as a CBOR integer instead of a text
+//     string, for compact map representations like the ones of COSE and CWT.
+//   - decodeonly: the field is decoded but never encoded, e.g. a deprecated
+//     field still found in old data. In tuple representation, decode-only
+//     fields must be optional and come after all the encoded fields.
+//   - encodeonly: the field is encoded but never decoded, e.g. a field added
+//     for readers which are upgraded first. Only supported in map
+//     representation.
+//   - default=V: the value of the field when its map key is missing from the
+//     input, for bool, string and integer fields. V can't hold commas, and
+//     must satisfy the constraints of the field.
+//   - len=N, min=N and max=N: the value of integer fields must be at least
+//     min and at most max. The length of string, slice and map fields must be
+//     len, at least min and at most max. Decoders return a *DecodeError
+//     wrapping a *ConstraintError otherwise.
+//   - enum=V1|V2|...: the value of string and integer fields must be one of
+//     the listed values, or decoders return a *DecodeError wrapping a
+//     *ConstraintError.
+//   - omitdefault: the field isn't encoded when equal to its default value,
+//     its zero value if there's no default option.
+//   - optional: the field may be missing from the input. In tuple
+//     representation, optional fields must come after all the others, and
+//     arrays missing some of them are accepted. Types implementing Upgrader
+//     get their Upgrade method called when optional fields were missing.
+//   - index=N: the position of the field in tuple representation, starting
+//     from 0. Either all the fields of a type have an index or none does, and
+//     the indices must be distinct and leave no gap. Indices can't be combined
+//     with decodeonly and encodeonly, as positions would differ between
+//     encoding and decoding. Ignored in map representation.
+//
+// An empty map key, as in `cborgen:",optional"`, keeps the field name, or the
+// key derived from it with Gen.JSONTagKeys or Gen.KeyNaming. Without the
+// intkey option, a map key is a text string even if it looks like an integer.
+func parseFieldTag(f *Field, tag string) error {
+	if tag == "" {
+		return nil
+	}
+	parts := strings.Split(tag, ",")
+	if parts[0] != "" {
+		f.MapKey = parts[0]
+	}
+	for _, opt := range parts[1:] {
+		switch {
+		case opt == "intkey":
+			if _, err := parseIntKey(parts[0]); err != nil {
+				return fmt.Errorf("field %s: %w", f.Name, err)
+			}
+			f.IntKey = true
+		case opt == "optional":
+			f.Optional = true
+		case strings.HasPrefix(opt, "default="):
+			v, err := defaultLiteral(f.Type, f.Pointer, strings.TrimPrefix(opt, "default="))
+			if err != nil {
+				return fmt.Errorf("field %s: %w", f.Name, err)
+			}
+			f.Default = v
+		case strings.HasPrefix(opt, "len="), strings.HasPrefix(opt, "min="),
+			strings.HasPrefix(opt, "max="), strings.HasPrefix(opt, "enum="):
+			c, err := parseConstraint(f.Type, opt)
+			if err != nil {
+				return fmt.Errorf("field %s: %w", f.Name, err)
+			}
+			f.Constraints = append(f.Constraints, c)
+		case opt == "omitdefault":
+			if f.Pointer || scalarKind(f.Type) == reflect.Invalid {
+				return fmt.Errorf("field %s: omitdefault needs a bool, string or integer field", f.Name)
+			}
+			f.OmitDefault = true
+		case opt == "decodeonly":
+			f.DecodeOnly = true
+		case opt == "encodeonly":
+			f.EncodeOnly = true
+		case strings.HasPrefix(opt, "alias="):
+			alias := strings.TrimPrefix(opt, "alias=")
+			if alias == "" {
+				return fmt.Errorf("field %s: empty map key alias", f.Name)
+			}
+			f.Aliases = append(f.Aliases, TextKey(alias))
+		case strings.HasPrefix(opt, "intalias="):
+			v, err := parseIntKey(strings.TrimPrefix(opt, "intalias="))
+			if err != nil {
+				return fmt.Errorf("field %s: %w", f.Name, err)
+			}
+			f.Aliases = append(f.Aliases, IntKey(v))
+		case strings.HasPrefix(opt, "index="):
+			i, err := strconv.ParseUint(strings.TrimPrefix(opt, "index="), 10, 31)
+			if err != nil {
+				return fmt.Errorf("field %s: invalid tuple index %q", f.Name, opt)
+			}
+			f.Index = int(i)
+			f.HasIndex = true
+		default:
+			return fmt.Errorf("field %s: unknown cborgen tag option %q", f.Name, opt)
+		}
+	}
+	if f.DecodeOnly && f.EncodeOnly {
+		return fmt.Errorf("field %s: decodeonly and encodeonly are exclusive", f.Name)
+	}
+	return checkConstraints(f)
+}
+
+// Constraint is a constraint on the value of a field, see parseFieldTag.
+type Constraint struct {
+	// Option is the cborgen tag option, e.g. "max=100".
+	Option string
+	// Length is set if the constraint is on the length of the value.
+	Length bool
+	// Violated is the condition violating the constraint, on the value or its
+	// length written %[1]s, e.g. "%[1]s > 100".
+	Violated string
+}
+
+// parseConstraint parses the constraint option opt of a field of type t.
+func parseConstraint(t reflect.Type, opt string) (Constraint, error) {
+	kv := strings.SplitN(opt, "=", 2)
+	name, v := kv[0], kv[1]
+	c := Constraint{Option: opt}
+
+	k := t.Kind()
+	isInt := k != reflect.Bool && k != reflect.String && scalarKind(t) != reflect.Invalid
+	switch {
+	case isInt && name != "len":
+	case k == reflect.String:
+		c.Length = name != "enum"
+	case k == reflect.Slice || k == reflect.Map:
+		if name == "enum" {
+			return c, fmt.Errorf("enum needs a string or integer field")
+		}
+		c.Length = true
+	default:
+		return c, fmt.Errorf("%s constraints aren't supported on %s fields", name, k)
+	}
+
+	// literal parses a value or length of the constraint.
+	literal := func(s string) (string, error) {
+		if c.Length {
+			n, err := strconv.ParseUint(s, 10, 31)
+			if err != nil {
+				return "", fmt.Errorf("invalid length in %s", opt)
+			}
+			return strconv.FormatUint(n, 10), nil
+		}
+		l, err := defaultLiteral(t, false, s)
+		if err != nil {
+			return "", fmt.Errorf("invalid value in %s", opt)
+		}
+		return l, nil
+	}
+
+	switch name {
+	case "len", "min", "max":
+		l, err := literal(v)
+		if err != nil {
+			return c, err
+		}
+		c.Violated = map[string]string{"len": "%[1]s != ", "min": "%[1]s < ", "max": "%[1]s > "}[name] + l
+	case "enum":
+		var conds []string
+		for _, e := range strings.Split(v, "|") {
+			l, err := literal(e)
+			if err != nil {
+				return c, err
+			}
+			conds = append(conds, "%[1]s != "+l)
+		}
+		c.Violated = strings.Join(conds, " && ")
+	}
+	return c, nil
+}
+
+// checkConstraints rejects constraints of f which can't all be satisfied, or
+// are given twice.
+func checkConstraints(f *Field) error {
+	bounds := map[string]*big.Int{}
+	for _, c := range f.Constraints {
+		kv := strings.SplitN(c.Option, "=", 2)
+		if _, ok := bounds[kv[0]]; ok {
+			return fmt.Errorf("field %s: several %s constraints", f.Name, kv[0])
+		}
+		b, _ := new(big.Int).SetString(kv[1], 10)
+		bounds[kv[0]] = b
+	}
+	if bounds["len"] != nil && (bounds["min"] != nil || bounds["max"] != nil) {
+		return fmt.Errorf("field %s: len excludes min and max", f.Name)
+	}
+	if min, max := bounds["min"], bounds["max"]; min != nil && max != nil && min.Cmp(max) > 0 {
+		return fmt.Errorf("field %s: min is greater than max", f.Name)
+	}
+
+	// Missing optional fields and defaulted fields aren't checked, but then
+	// get encoded with the value they had when missing, which must be valid to
+	// be decoded back.
+	if !(f.Optional || f.Default != "") || f.Pointer {
+		return nil
+	}
+	missing := f.DefaultValue()
+	for _, c := range f.Constraints {
+		if missingViolates(f, c) {
+			return fmt.Errorf("field %s: its value when missing, %s, violates %s", f.Name, missing, c.Option)
+		}
+	}
+	return nil
+}
+
+// missingViolates reports whether the value of the optional or defaulted field
+// f when missing, its default or zero value, violates the constraint c.
+func missingViolates(f *Field, c Constraint) bool {
+	kv := strings.SplitN(c.Option, "=", 2)
+	name, v := kv[0], kv[1]
+
+	var got *big.Int
+	switch s, _ := strconv.Unquote(f.DefaultValue()); {
+	case f.Type.Kind() == reflect.String && name == "enum":
+		for _, e := range strings.Split(v, "|") {
+			if e == s {
+				return false
+			}
+		}
+		return true
+	case f.Type.Kind() == reflect.String:
+		got = big.NewInt(int64(len(s)))
+	case c.Length:
+		got = new(big.Int)
+	default:
+		got, _ = new(big.Int).SetString(f.DefaultValue(), 10)
+	}
+
+	for _, e := range strings.Split(v, "|") {
+		want, _ := new(big.Int).SetString(e, 10)
+		cmp := got.Cmp(want)
+		switch name {
+		case "enum":
+			if cmp == 0 {
+				return false
+			}
+		case "len":
+			return cmp != 0
+		case "min":
+			return cmp < 0
+		case "max":
+			return cmp > 0
+		}
+	}
+	return name == "enum"
+}
+
+// scalarKind returns the kind of t if it's a bool, string or integer kind the
+// generated code supports, or reflect.Invalid.
+func scalarKind(t reflect.Type) reflect.Kind {
+	switch k := t.Kind(); k {
+	case reflect.Bool, reflect.String,
+		reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return k
+	default:
+		return reflect.Invalid
+	}
+}
+
+// defaultLiteral returns the Go literal of the default value v of a field of
+// type t.
+func defaultLiteral(t reflect.Type, pointer bool, v string) (string, error) {
+	if pointer {
+		return "", fmt.Errorf("default values need a bool, string or integer field")
+	}
+	switch k := scalarKind(t); k {
+	case reflect.Bool:
+		b, err := strconv.ParseBool(v)
+		if err != nil {
+			return "", fmt.Errorf("invalid bool default value %q", v)
+		}
+		return strconv.FormatBool(b), nil
+	case reflect.String:
+		return strconv.Quote(v), nil
+	case reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		i, err := strconv.ParseInt(v, 10, t.Bits())
+		if err != nil {
+			return "", fmt.Errorf("invalid %s default value %q", k, v)
+		}
+		return strconv.FormatInt(i, 10), nil
+	case reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		u, err := strconv.ParseUint(v, 10, t.Bits())
+		if err != nil {
+			return "", fmt.Errorf("invalid %s default value %q", k, v)
+		}
+		return strconv.FormatUint(u, 10), nil
+	default:
+		return "", fmt.Errorf("default values need a bool, string or integer field")
+	}
+}
+
+// parseIntKey parses an integer map key of a cborgen tag, which must be
+// written in canonical decimal so that a key has a single spelling.
+func parseIntKey(s string) (int64, error) {
+	v, err := strconv.ParseInt(s, 10, 64)
+	if err != nil || strconv.FormatInt(v, 10) != s {
+		return 0, fmt.Errorf("invalid integer map key %q", s)
+	}
+	return v, nil
+}
+
 func (gti GenTypeInfo) TupleHeader() []byte {
 	return CborEncodeMajorType(MajArray, uint64(len(gti.Fields)))
 }
@@ -326,11 +1064,15 @@ func emitCborMarshalStringField(w io.Writer, f Field) error {
 `)
 }
 func emitCborMarshalStructField(w io.Writer, f Field) error {
+	if f.Pointer && typeParamIndex(f.Type) >= 0 {
+		return fmt.Errorf("pointers to type parameters are not supported, use pointer type arguments")
+	}
+
 	switch f.Type {
 	case bigIntType:
 		return doTemplate(w, f, `
 	{
-		if n_, err := cbg.CborWriteHeader(w, cbg.MajTag, 2); err != nil {
+		if n_, err := cbg.WriteMajorTypeHeaderBuf(scratch, w, cbg.MajTag, 2); err != nil {
 			return n + n_, err
 		} else {
 			n += n_
@@ -340,7 +1082,7 @@ func emitCborMarshalStructField(w io.Writer, f Field) error {
 			b = {{ .Name }}.Bytes()
 		}
 
-		if n_, err := cbg.CborWriteHeader(w, cbg.MajByteString, uint64(len(b))); err != nil {
+		if n_, err := cbg.WriteMajorTypeHeaderBuf(scratch, w, cbg.MajByteString, uint64(len(b))); err != nil {
 			return n + n_, err
 		} else {
 			n += n_
@@ -543,8 +1285,10 @@ func emitCborMarshalSliceField(w io.Writer, f Field) error {
 		switch e {
 		case cidType:
 			err := doTemplate(w, f, `
-		if err := cbg.WriteCidBuf(scratch, w, v); err != nil {
-			return n, xerrors.Errorf("failed writing cid field {{ .Name }}: %w", err)
+		if n_, err := cbg.WriteCidBuf(scratch, w, v); err != nil {
+			return n + n_, xerrors.Errorf("failed writing cid field {{ .Name }}: %w", err)
+		} else {
+			n += n_
 		}
 `)
 			if err != nil {
@@ -607,7 +1351,7 @@ func emitCborMarshalSliceField(w io.Writer, f Field) error {
 func emitCborMarshalStructTuple(w io.Writer, gti *GenTypeInfo, flattenEmbeddedStruct bool) error {
 	// 9 byte buffer to accomodate for the maximum header length (cbor varints are maximum 9 bytes_
 	err := doTemplate(w, gti, `var lengthBuf{{ .Name }} = {{ .TupleHeaderAsByteString }}
-func (t *{{ .Name }}) MarshalCBOR(w io.Writer) (n int, err error) {
+func (t *{{ .Receiver }}) MarshalCBOR(w io.Writer) (n int, err error) {
 	if t == nil {
 		return w.Write(cbg.CborNull)
 	}`)
@@ -623,6 +1367,10 @@ func (t *{{ .Name }}) MarshalCBOR(w io.Writer) (n int, err error) {
 		return err
 	}
 
+	if err := emitCallPreMarshal(w, gti); err != nil {
+		return err
+	}
+
 	err = doTemplate(w, gti, `
 	if n_, err := w.Write(lengthBuf{{ .Name }}); err != nil {
 		return n_, err
@@ -638,7 +1386,7 @@ func (t *{{ .Name }}) MarshalCBOR(w io.Writer) (n int, err error) {
 	}
 
 	for _, f := range gti.Fields {
-		fmt.Fprintf(w, "\n\t// t.%s (%s) (%s)", f.Name, f.Type, f.Type.Kind())
+		fmt.Fprintf(w, "\n\t// %s", f.Comment())
 		f.Name = "t." + f.Name
 
 		switch f.Type.Kind() {
@@ -706,7 +1454,7 @@ func emitCborUnmarshalStringField(w io.Writer, f Field) error {
 	{
 		sval, read, err := cbg.ReadStringBuf(br, scratch)
 		if err != nil {
-			return bytesRead, err
+			return bytesRead, {{ .WrapError }}
 		}
 		bytesRead += read
 
@@ -716,39 +1464,61 @@ func emitCborUnmarshalStringField(w io.Writer, f Field) error {
 }
 
 func emitCborUnmarshalStructField(w io.Writer, f Field) error {
+	if typeParamIndex(f.Type) >= 0 {
+		if f.Pointer {
+			return fmt.Errorf("pointers to type parameters are not supported, use pointer type arguments")
+		}
+		return doTemplate(w, f, `
+	{
+		if read, err := cbg.UnmarshalTypeParam(br, &{{ .Name }}); err != nil {
+			return bytesRead, {{ .WrapError }}
+		} else {
+			bytesRead += read
+		}
+	}
+`)
+	}
+
 	switch f.Type {
 	case bigIntType:
 		return doTemplate(w, f, `
 	maj, extra, read, err = {{ ReadHeader "br" }}
 	if err != nil {
-		return bytesRead, err
+		return bytesRead, {{ .WrapError }}
 	}
 	bytesRead += read
 
-	if maj != cbg.MajTag || extra != 2 {
-		return bytesRead, fmt.Errorf("big ints should be cbor bignums")
+	if maj != cbg.MajTag {
+		return bytesRead, {{ .DecodeError "cbg.ErrUnexpectedType" "cbg.MajTag" }}
+	}
+	if extra != 2 {
+		return bytesRead, {{ .DecodeError "fmt.Errorf(\"big ints should be cbor bignums (tag 2), got tag %d\", extra)" "cbg.MajTag" }}
 	}
 
 	maj, extra, read, err = {{ ReadHeader "br" }}
 	if err != nil {
-		return bytesRead, err
+		return bytesRead, {{ .WrapError }}
 	}
 	bytesRead += read
 
 	if maj != cbg.MajByteString {
-		return bytesRead, fmt.Errorf("big ints should be tagged cbor byte strings")
+		return bytesRead, {{ .DecodeError "cbg.ErrUnexpectedType" "cbg.MajByteString" }}
 	}
 
 	if extra > 256 {
-		return bytesRead, fmt.Errorf("{{ .Name }}: cbor bignum was too large")
+		return bytesRead, {{ .DecodeError "cbg.ErrMaxLength" "cbg.MajByteString" }}
+	}
+	if err := limits.AddBytes(extra); err != nil {
+		return bytesRead, {{ .DecodeError "err" "cbg.MajByteString" }}
 	}
 
 	if extra > 0 {
 		buf := make([]byte, extra)
 		if read, err := io.ReadFull(br, buf); err != nil {
-			return bytesRead, err
-		}
-		bytesRead += read
+			return bytesRead, {{ .WrapError }}
+		} else {
+			bytesRead += read
+		}
 		{{ .Name }} = big.NewInt(0).SetBytes(buf)
 	} else {
 		{{ .Name }} = big.NewInt(0)
@@ -760,18 +1530,18 @@ func emitCborUnmarshalStructField(w io.Writer, f Field) error {
 {{ if .Pointer }}
 		b, err := br.ReadByte()
 		if err != nil {
-			return bytesRead, err
+			return bytesRead, {{ .WrapError }}
 		}
 		bytesRead++
 		if b != cbg.CborNull[0] {
 			if err := br.UnreadByte(); err != nil {
-				return bytesRead, err
+				return bytesRead, {{ .WrapError }}
 			}
 			bytesRead-- 
 {{ end }}
 		c, read, err := cbg.ReadCid(br)
 		if err != nil {
-			return bytesRead, xerrors.Errorf("failed to read cid field {{ .Name }}: %w", err)
+			return bytesRead, {{ .WrapError }}
 		}
 		bytesRead += read
 {{ if .Pointer }}
@@ -789,7 +1559,7 @@ func emitCborUnmarshalStructField(w io.Writer, f Field) error {
 		{{ .Name }} = new(cbg.Deferred)
 {{ end }}
 		if read, err := {{ .Name }}.UnmarshalCBOR(br); err != nil {
-			return bytesRead, xerrors.Errorf("failed to read deferred field: %w", err)
+			return bytesRead, {{ .WrapError }}
 		} else {
 			bytesRead += read
 		}
@@ -802,24 +1572,24 @@ func emitCborUnmarshalStructField(w io.Writer, f Field) error {
 {{ if .Pointer }}
 		b, err := br.ReadByte()
 		if err != nil {
-			return bytesRead, err
+			return bytesRead, {{ .WrapError }}
 		}
 		bytesRead++
 		if b != cbg.CborNull[0] {
 			if err := br.UnreadByte(); err != nil {
-				return bytesRead, err
+				return bytesRead, {{ .WrapError }}
 			}
 			bytesRead--
 			{{ .Name }} = new({{ .TypeName }})
 			if read, err := {{ .Name }}.UnmarshalCBOR(br); err != nil {
-				return bytesRead, xerrors.Errorf("unmarshaling {{ .Name }} pointer: %w", err)
+				return bytesRead, {{ .WrapError }}
 			} else {
 				bytesRead += read
 			}
 		}
 {{ else }}
 		if read, err := {{ .Name }}.UnmarshalCBOR(br); err != nil {
-			return bytesRead, xerrors.Errorf("unmarshaling {{ .Name }}: %w", err)
+			return bytesRead, {{ .WrapError }}
 		} else {
 			bytesRead += read
 		}
@@ -830,32 +1600,40 @@ func emitCborUnmarshalStructField(w io.Writer, f Field) error {
 }
 
 func emitCborUnmarshalIntField(w io.Writer, f Field, len int) error {
-	return doTemplate(w, f, fmt.Sprintf(`{
+	var rangeCheck string
+	if len < 64 {
+		rangeCheck = fmt.Sprintf(`
+	if extraI > math.MaxInt%d || extraI < math.MinInt%d {
+		return bytesRead, {{ .DecodeError "cbg.ErrOverflow" "maj" }}
+	}
+`, len, len)
+	}
+	return doTemplate(w, f, `{
 	maj, extra, read, err := {{ ReadHeader "br" }}
-	var extraI int%d
+	var extraI int64
 	if err != nil {
-		return bytesRead, err
+		return bytesRead, {{ .WrapError }}
 	}
 	bytesRead += read
 	switch maj {
 	case cbg.MajUnsignedInt:
-		extraI = int%d(extra)
+		extraI = int64(extra)
 		if extraI < 0 {
-			return bytesRead, fmt.Errorf("int%d positive overflow")
-	   }
+			return bytesRead, {{ .DecodeError "cbg.ErrOverflow" "maj" }}
+		}
 	case cbg.MajNegativeInt:
-		extraI = int%d(extra)
+		extraI = int64(extra)
 		if extraI < 0 {
-			return bytesRead, fmt.Errorf("int%d negative oveflow")
+			return bytesRead, {{ .DecodeError "cbg.ErrOverflow" "maj" }}
 		}
 		extraI = -1 - extraI
 	default:
-		return bytesRead, fmt.Errorf("wrong type for int%d field: %%d", maj)
+		return bytesRead, {{ .DecodeError "cbg.ErrUnexpectedType" "cbg.MajUnsignedInt" }}
 	}
-
+`+rangeCheck+`
 	{{ .Name }} = {{ .TypeName }}(extraI)
 }
-`, len, len, len, len, len, len))
+`)
 }
 
 func emitCborUnmarshalUint64Field(w io.Writer, f Field) error {
@@ -864,21 +1642,21 @@ func emitCborUnmarshalUint64Field(w io.Writer, f Field) error {
 {{ if .Pointer }}
 	b, err := br.ReadByte()
 	if err != nil {
-		return bytesRead, err
+		return bytesRead, {{ .WrapError }}
 	}
 	bytesRead++
 	if b != cbg.CborNull[0] {
 		if err := br.UnreadByte(); err != nil {
-			return bytesRead, err
+			return bytesRead, {{ .WrapError }}
 		}
 		bytesRead--
 		maj, extra, read, err = {{ ReadHeader "br" }}
 		if err != nil {
-			return bytesRead, err
+			return bytesRead, {{ .WrapError }}
 		}
 		bytesRead += read
 		if maj != cbg.MajUnsignedInt {
-			return bytesRead, fmt.Errorf("wrong type for uint64 field")
+			return bytesRead, {{ .DecodeError "cbg.ErrUnexpectedType" "cbg.MajUnsignedInt" }}
 		}
 		typed := {{ .TypeName }}(extra)
 		{{ .Name }} = &typed
@@ -886,11 +1664,11 @@ func emitCborUnmarshalUint64Field(w io.Writer, f Field) error {
 {{ else }}
 	maj, extra, read, err = {{ ReadHeader "br" }}
 	if err != nil {
-		return bytesRead, err
+		return bytesRead, {{ .WrapError }}
 	}
 	bytesRead += read
 	if maj != cbg.MajUnsignedInt {
-		return bytesRead, fmt.Errorf("wrong type for uint64 field")
+		return bytesRead, {{ .DecodeError "cbg.ErrUnexpectedType" "cbg.MajUnsignedInt" }}
 	}
 	{{ .Name }} = {{ .TypeName }}(extra)
 {{ end }}
@@ -902,28 +1680,28 @@ func emitCborUnmarshalUintField(w io.Writer, f Field, len int) error {
 	return doTemplate(w, f, fmt.Sprintf(`
 	maj, extra, read, err = {{ ReadHeader "br" }}
 	if err != nil {
-		return bytesRead, err
+		return bytesRead, {{ .WrapError }}
 	}
 	bytesRead += read
 	if maj != cbg.MajUnsignedInt {
-		return bytesRead, fmt.Errorf("wrong type for uint%d field")
+		return bytesRead, {{ .DecodeError "cbg.ErrUnexpectedType" "cbg.MajUnsignedInt" }}
 	}
 	if extra > math.MaxUint%d {
-		return bytesRead, fmt.Errorf("integer in input was too large for uint%d field")
+		return bytesRead, {{ .DecodeError "cbg.ErrOverflow" "cbg.MajUnsignedInt" }}
 	}
 	{{ .Name }} = {{ .TypeName }}(extra)
-`, len, len, len))
+`, len))
 }
 
 func emitCborUnmarshalBoolField(w io.Writer, f Field) error {
 	return doTemplate(w, f, `
 	maj, extra, read, err = {{ ReadHeader "br" }}
 	if err != nil {
-		return bytesRead, err
+		return bytesRead, {{ .WrapError }}
 	}
 	bytesRead += read
 	if maj != cbg.MajOther {
-		return bytesRead, fmt.Errorf("booleans must be major type 7")
+		return bytesRead, {{ .DecodeError "cbg.ErrUnexpectedType" "cbg.MajOther" }}
 	}
 	switch extra {
 	case 20:
@@ -931,7 +1709,7 @@ func emitCborUnmarshalBoolField(w io.Writer, f Field) error {
 	case 21:
 		{{ .Name }} = true
 	default:
-		return bytesRead, fmt.Errorf("booleans are either major type 7, value 20 or 21 (got %d)", extra)
+		return bytesRead, {{ .DecodeError "fmt.Errorf(\"booleans are either major type 7, value 20 or 21 (got %d)\", extra)" "cbg.MajOther" }}
 	}
 `)
 }
@@ -940,14 +1718,17 @@ func emitCborUnmarshalMapField(w io.Writer, f Field) error {
 	err := doTemplate(w, f, `
 	maj, extra, read, err = {{ ReadHeader "br" }}
 	if err != nil {
-		return bytesRead, err
+		return bytesRead, {{ .WrapError }}
 	}
 	bytesRead += read
 	if maj != cbg.MajMap {
-		return bytesRead, fmt.Errorf("expected a map (major type 5)")
+		return bytesRead, {{ .DecodeError "cbg.ErrUnexpectedType" "cbg.MajMap" }}
 	}
 	if extra > 4096 {
-		return bytesRead, fmt.Errorf("{{ .Name }}: map too large")
+		return bytesRead, {{ .DecodeError "cbg.ErrMaxLength" "cbg.MajMap" }}
+	}{{ .LengthChecks "cbg.MajMap" }}
+	if err := limits.AddItems(extra); err != nil {
+		return bytesRead, {{ .DecodeError "err" "cbg.MajMap" }}
 	}
 
 	{{ .Name }} = make({{ .TypeName }}, extra)
@@ -966,7 +1747,7 @@ func emitCborUnmarshalMapField(w io.Writer, f Field) error {
 `); err != nil {
 			return err
 		}
-		if err := emitCborUnmarshalStringField(w, Field{Name: "k"}); err != nil {
+		if err := emitCborUnmarshalStringField(w, Field{Name: "k", Struct: f.Struct, Path: f.errPath()}); err != nil {
 			return err
 		}
 	default:
@@ -984,7 +1765,15 @@ func emitCborUnmarshalMapField(w io.Writer, f Field) error {
 		pointer = true
 		fallthrough
 	case reflect.Struct:
-		subf := Field{Name: "v", Pointer: pointer, Type: t, Pkg: f.Pkg}
+		format, args := errPathFormat(f.Name)
+		subf := Field{
+			Name:    "v",
+			Pointer: pointer,
+			Type:    t,
+			Pkg:     f.Pkg,
+			Struct:  f.Struct,
+			Path:    sprintfExpr(format+"[%q]", append(args, "k")),
+		}
 		if err := doTemplate(w, subf, `
 	var v {{ .TypeName }}
 `); err != nil {
@@ -1026,7 +1815,7 @@ func emitCborUnmarshalSliceField(w io.Writer, f Field) error {
 	err := doTemplate(w, f, `
 	maj, extra, read, err = {{ ReadHeader "br" }}
 	if err != nil {
-		return bytesRead, err
+		return bytesRead, {{ .WrapError }}
 	}
 	bytesRead += read
 `)
@@ -1037,14 +1826,17 @@ func emitCborUnmarshalSliceField(w io.Writer, f Field) error {
 	if e.Kind() == reflect.Uint8 || e.Kind() == reflect.Int8 {
 		return doTemplate(w, f, `
 	if extra > cbg.ByteArrayMaxLen {
-		return bytesRead, fmt.Errorf("{{ .Name }}: byte array too large (%d)", extra)
+		return bytesRead, {{ .DecodeError "cbg.ErrMaxLength" "cbg.MajByteString" }}
 	}
 	if maj != cbg.MajByteString {
-		return bytesRead, fmt.Errorf("expected byte array")
+		return bytesRead, {{ .DecodeError "cbg.ErrUnexpectedType" "cbg.MajByteString" }}
+	}{{ .LengthChecks "cbg.MajByteString" }}
+	if err := limits.AddBytes(extra); err != nil {
+		return bytesRead, {{ .DecodeError "err" "cbg.MajByteString" }}
 	}
 	{{if .IsArray}}
 	if extra != {{ .Len }} {
-		return bytesRead, fmt.Errorf("expected array to have {{ .Len }} elements")
+		return bytesRead, {{ .DecodeError (printf "fmt.Errorf(\"expected %d bytes, got %%d\", extra)" .Len) "cbg.MajByteString" }}
 	}
 
 	{{ .Name }} = {{ .TypeName }}{}
@@ -1054,7 +1846,7 @@ func emitCborUnmarshalSliceField(w io.Writer, f Field) error {
 	}
 	{{end}}
 	if read, err := io.ReadFull(br, {{ .Name }}[:]); err != nil {
-		return bytesRead, err
+		return bytesRead, {{ .WrapError }}
 	} else {
 		bytesRead += read
 	}
@@ -1063,7 +1855,7 @@ func emitCborUnmarshalSliceField(w io.Writer, f Field) error {
 
 	if err := doTemplate(w, f, `
 	if extra > cbg.MaxLength {
-		return bytesRead, fmt.Errorf("{{ .Name }}: array too large (%d)", extra)
+		return bytesRead, {{ .DecodeError "cbg.ErrMaxLength" "cbg.MajArray" }}
 	}
 `); err != nil {
 		return err
@@ -1071,11 +1863,14 @@ func emitCborUnmarshalSliceField(w io.Writer, f Field) error {
 
 	err = doTemplate(w, f, `
 	if maj != cbg.MajArray {
-		return bytesRead, fmt.Errorf("expected cbor array")
+		return bytesRead, {{ .DecodeError "cbg.ErrUnexpectedType" "cbg.MajArray" }}
+	}{{ .LengthChecks "cbg.MajArray" }}
+	if err := limits.AddItems(extra); err != nil {
+		return bytesRead, {{ .DecodeError "err" "cbg.MajArray" }}
 	}
 	{{if .IsArray}}
 	if extra != {{ .Len }} {
-		return bytesRead, fmt.Errorf("expected array to have {{ .Len }} elements")
+		return bytesRead, {{ .DecodeError (printf "fmt.Errorf(\"expected %d elements, got %%d\", extra)" .Len) "cbg.MajArray" }}
 	}
 
 	{{ .Name }} = {{ .TypeName }}{}
@@ -1090,35 +1885,36 @@ func emitCborUnmarshalSliceField(w io.Writer, f Field) error {
 		return err
 	}
 
+	elem := Field{
+		Type:    e,
+		Pkg:     f.Pkg,
+		Pointer: pointer,
+		Name:    f.Name + "[" + f.IterLabel + "]",
+		Struct:  f.Struct,
+	}
+
 	len := 0
 	switch e.Kind() {
 	case reflect.Struct:
 		fname := e.PkgPath() + "." + e.Name()
 		switch fname {
 		case "github.com/ipfs/go-cid.Cid":
-			err := doTemplate(w, f, `
+			err := doTemplate(w, elem, `
 		c, read, err := cbg.ReadCid(br)
 		if err != nil {
-			return bytesRead, xerrors.Errorf("reading cid field {{ .Name }} failed: %w", err)
+			return bytesRead, {{ .WrapError }}
 		}
 		bytesRead += read
-		{{ .Name }}[{{ .IterLabel }}] = c
+		{{ .Name }} = c
 `)
 			if err != nil {
 				return err
 			}
 		default:
-			subf := Field{
-				Type:    e,
-				Pkg:     f.Pkg,
-				Pointer: pointer,
-				Name:    f.Name + "[" + f.IterLabel + "]",
-			}
-
-			err := doTemplate(w, subf, `
+			err := doTemplate(w, elem, `
 		var v {{ .TypeName }}
-		if read, err := v.UnmarshalCBOR(br); err != nil {
-			return bytesRead, err
+		if read, err := {{ if .IsTypeParam }}cbg.UnmarshalTypeParam(br, &v){{ else }}v.UnmarshalCBOR(br){{ end }}; err != nil {
+			return bytesRead, {{ .WrapError }}
 		} else {
 			bytesRead += read
 		}
@@ -1129,33 +1925,20 @@ func emitCborUnmarshalSliceField(w io.Writer, f Field) error {
 				return err
 			}
 		}
-	case reflect.Uint16:
-		if len == 0 {
-			len = 16
-		}
-		fallthrough
-	case reflect.Uint32:
-		if len == 0 {
-			len = 32
-		}
-		fallthrough
-	case reflect.Uint64:
-		if len == 0 {
-			len = 64
-		}
-		err := doTemplate(w, f, fmt.Sprintf(`
+	case reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		err := doTemplate(w, elem, `
 		maj, val, read, err := {{ ReadHeader "br" }}
 		if err != nil {
-			return bytesRead, xerrors.Errorf("failed to read uint%d for {{ .Name }} slice: %%w", err)
+			return bytesRead, {{ .WrapError }}
 		}
 		bytesRead += read
 
 		if maj != cbg.MajUnsignedInt {
-			return bytesRead, xerrors.Errorf("value read for array {{ .Name }} was not a uint, instead got %%d", maj)
+			return bytesRead, {{ .DecodeError "cbg.ErrUnexpectedType" "cbg.MajUnsignedInt" }}
 		}
-		
-		{{ .Name }}[{{ .IterLabel}}] = {{ .ElemName }}(val)
-`, len))
+
+		{{ .Name }} = {{ .TypeName }}(val)
+`)
 		if err != nil {
 			return err
 		}
@@ -1178,12 +1961,7 @@ func emitCborUnmarshalSliceField(w io.Writer, f Field) error {
 		if len == 0 {
 			len = 64
 		}
-		subf := Field{
-			Type: e,
-			Pkg:  f.Pkg,
-			Name: f.Name + "[" + f.IterLabel + "]",
-		}
-		err := emitCborUnmarshalIntField(w, subf, len)
+		err := emitCborUnmarshalIntField(w, elem, len)
 		if err != nil {
 			return err
 		}
@@ -1196,6 +1974,7 @@ func emitCborUnmarshalSliceField(w io.Writer, f Field) error {
 			Type:      e,
 			IterLabel: nextIter,
 			Pkg:       f.Pkg,
+			Struct:    f.Struct,
 		}
 		fmt.Fprintf(w, "\t\t{\n\t\t\tvar maj byte\n\t\tvar extra uint64\n\t\tvar err error\n")
 		if err := emitCborUnmarshalSliceField(w, subf); err != nil {
@@ -1214,9 +1993,9 @@ func emitCborUnmarshalSliceField(w io.Writer, f Field) error {
 func emitCborUnmarshalStructTuple(w io.Writer, gti *GenTypeInfo,
 	flattenEmbeddedStruct bool) error {
 	err := doTemplate(w, gti, `
-func (t *{{ .Name}}) UnmarshalCBOR(r io.Reader) (int, error) {
+func (t *{{ .Receiver }}) UnmarshalCBOR(r io.Reader) (int, error) {
 	bytesRead := 0
-	*t = {{.Name}}{}`)
+	*t = {{ .Receiver }}{}`)
 	if err != nil {
 		return err
 	}
@@ -1233,6 +2012,11 @@ func (t *{{ .Name}}) UnmarshalCBOR(r io.Reader) (int, error) {
 
 	br := cbg.GetPeeker(r)
 	scratch := make([]byte, 8)
+	limits := cbg.GetDecodeLimits(br)
+	if err := limits.Enter(); err != nil {
+		return bytesRead, &cbg.DecodeError{Type: "{{ .Name }}", Err: err}
+	}
+	defer limits.Leave()
 
 	maj, extra, read, err := {{ ReadHeader "br" }}
 	if err != nil {
@@ -1240,22 +2024,43 @@ func (t *{{ .Name}}) UnmarshalCBOR(r io.Reader) (int, error) {
 	}
 	bytesRead += read
 	if maj != cbg.MajArray {
-		return bytesRead, fmt.Errorf("cbor input should be of type array")
+		return bytesRead, &cbg.DecodeError{Type: "{{ .Name }}", Expected: cbg.MajArray, Found: maj, Err: cbg.ErrUnexpectedType}
 	}
 
+{{ if .HasOptionalFields }}
+	if extra < {{ .RequiredFields }} || extra > {{ len .Fields }} {
+{{- else }}
 	if extra != {{ len .Fields }} {
-		return bytesRead, fmt.Errorf("cbor input had wrong number of fields")
+{{- end }}
+		return bytesRead, &cbg.DecodeError{Type: "{{ .Name }}", Expected: cbg.MajArray, Found: maj, Err: fmt.Errorf("cbor input had wrong number of fields (%d)", extra)}
 	}
-
+	if err := limits.AddItems(extra); err != nil {
+		return bytesRead, &cbg.DecodeError{Type: "{{ .Name }}", Expected: cbg.MajArray, Found: maj, Err: err}
+	}
+{{ if .HasOptionalFields }}
+	// extra is reused by the field decoders.
+	present := int(extra)
+{{ end }}
 `)
 	if err != nil {
 		return err
 	}
 
-	len := 0
-	for _, f := range gti.Fields {
-		fmt.Fprintf(w, "\t// t.%s (%s) (%s)\n", f.Name, f.Type, f.Type.Kind())
+	required := gti.RequiredFields()
+	for _, f := range gti.Fields[required:] {
+		if !f.Optional {
+			return fmt.Errorf("field %q of %q must be optional, as it follows optional fields", f.Name, gti.Name)
+		}
+	}
+
+	for i, f := range gti.Fields {
+		fmt.Fprintf(w, "\t// %s\n", f.Comment())
 		f.Name = "t." + f.Name
+		f.Struct = gti.Name
+		len := 0
+		if f.Optional {
+			fmt.Fprintf(w, "\tif present > %d {\n", i)
+		}
 
 		switch f.Type.Kind() {
 		case reflect.String:
@@ -1326,6 +2131,23 @@ func (t *{{ .Name}}) UnmarshalCBOR(r io.Reader) (int, error) {
 		default:
 			return fmt.Errorf("field %q of %q has unsupported kind %q", f.Name, gti.Name, f.Type.Kind())
 		}
+		if f.Optional {
+			fmt.Fprintf(w, "\t}\n")
+		}
+	}
+
+	if gti.HasOptionalFields() {
+		if err := emitCallUpgrade(w, gti); err != nil {
+			return err
+		}
+	}
+
+	if err := emitConstraintChecks(w, gti, true); err != nil {
+		return err
+	}
+
+	if err := emitCallPostUnmarshal(w, gti); err != nil {
+		return err
 	}
 
 	fmt.Fprintf(w, "\treturn bytesRead, nil\n}\n\n")
@@ -1333,20 +2155,349 @@ func (t *{{ .Name}}) UnmarshalCBOR(r io.Reader) (int, error) {
 	return nil
 }
 
+// emitCallPreMarshal emits the call to the PreMarshalCBOR method of types
+// implementing cbg.PreMarshaler.
+func emitCallPreMarshal(w io.Writer, gti *GenTypeInfo) error {
+	if !gti.PreMarshal {
+		return nil
+	}
+	return doTemplate(w, gti, `
+	if err := t.PreMarshalCBOR(); err != nil {
+		return 0, err
+	}
+`)
+}
+
+// emitConstraintChecks emits the checks of the constraints of the fields of
+// gti, once decoded. Missing optional fields aren't checked.
+func emitConstraintChecks(w io.Writer, gti *GenTypeInfo, tuple bool) error {
+	for i, f := range gti.Fields {
+		if len(f.Constraints) == 0 {
+			continue
+		}
+
+		var guards []string
+		if f.Optional && tuple {
+			guards = append(guards, fmt.Sprintf("present > %d", i))
+		} else if f.Optional {
+			guards = append(guards, "seen"+f.Name)
+		}
+		v := "t." + f.Name
+		if f.Pointer {
+			guards = append(guards, v+" != nil")
+			v = "*" + v
+		}
+		if len(guards) > 0 {
+			fmt.Fprintf(w, "\tif %s {\n", strings.Join(guards, " && "))
+		}
+
+		for _, c := range f.Constraints {
+			expr := v
+			got := fmt.Sprintf("fmt.Sprint(%s)", v)
+			switch {
+			case c.Length:
+				expr = "len(" + v + ")"
+				got = fmt.Sprintf(`fmt.Sprintf("length %%d", %s)`, expr)
+			case f.Type.Kind() == reflect.String:
+				got = fmt.Sprintf(`fmt.Sprintf("%%q", %s)`, v)
+			}
+			fmt.Fprintf(w, "\tif %s {\n", fmt.Sprintf(c.Violated, expr))
+			fmt.Fprintf(w, "\t\treturn bytesRead, &cbg.DecodeError{Type: %q, Field: %q, Offset: bytesRead, Err: &cbg.ConstraintError{Constraint: %q, Got: %s}}\n",
+				gti.Name, f.Name, c.Option, got)
+			fmt.Fprintf(w, "\t}\n")
+		}
+
+		if len(guards) > 0 {
+			fmt.Fprintf(w, "\t}\n")
+		}
+	}
+	if gti.HasConstraints() {
+		fmt.Fprintf(w, "\n")
+	}
+	return nil
+}
+
+// emitCallPostUnmarshal emits the call to the PostUnmarshalCBOR method of
+// types implementing cbg.PostUnmarshaler.
+func emitCallPostUnmarshal(w io.Writer, gti *GenTypeInfo) error {
+	if !gti.PostUnmarshal {
+		return nil
+	}
+	return doTemplate(w, gti, `
+	if err := t.PostUnmarshalCBOR(); err != nil {
+		return bytesRead, err
+	}
+
+`)
+}
+
+// emitCallUpgrade emits the call to the Upgrade method of types implementing
+// cbg.Upgrader, when the number of fields present in the input is less than
+// the number of fields of gti.
+func emitCallUpgrade(w io.Writer, gti *GenTypeInfo) error {
+	return doTemplate(w, gti, `
+	if present < {{ len .Fields }} {
+		if u, ok := interface{}(t).(cbg.Upgrader); ok {
+			if err := u.Upgrade(present); err != nil {
+				return bytesRead, err
+			}
+		}
+	}
+
+`)
+}
+
+// emitDagJSONMethods emits DAG-JSON methods, which transcode the CBOR encoding
+// so they share its field names and representation.
+func emitDagJSONMethods(w io.Writer, gti *GenTypeInfo) error {
+	return doTemplate(w, gti, `
+func (t *{{ .Receiver }}) MarshalDAGJSON(w io.Writer) error {
+	return cbg.MarshalDAGJSON(w, t)
+}
+
+func (t *{{ .Receiver }}) UnmarshalDAGJSON(r io.Reader) error {
+	return cbg.UnmarshalDAGJSON(r, t)
+}
+
+`)
+}
+
+// emitLinksMethod emits a Links method collecting the links reported by
+// ForEachLink, see emitForEachLinkMethod.
+func emitLinksMethod(w io.Writer, gti *GenTypeInfo) error {
+	return doTemplate(w, gti, `
+func (t *{{ .Receiver }}) Links() []cid.Cid {
+	var links []cid.Cid
+	_ = t.ForEachLink(func(_ string, c cid.Cid) error {
+		links = append(links, c)
+		return nil
+	})
+	return links
+}
+
+`)
+}
+
+// emitForEachLinkMethod emits a ForEachLink method, see cbg.LinkIterator. The
+// paths use the map keys of the fields, or their indices if tuple is set.
+func emitForEachLinkMethod(w io.Writer, gti *GenTypeInfo, tuple bool) error {
+	err := doTemplate(w, gti, `
+func (t *{{ .Receiver }}) ForEachLink(cb func(path string, c cid.Cid) error) error {
+	if t == nil {
+		return nil
+	}`)
+	if err != nil {
+		return err
+	}
+
+	// Decode-only fields aren't encoded, and the tuple indices of the others
+	// are their positions in the encoding.
+	for i, f := range gti.forEncoding().Fields {
+		key := f.MapKey
+		if tuple {
+			key = strconv.Itoa(i)
+		}
+		w := newEmbedGuard(w, f)
+		if err := emitForEachLinkValue(w, "t."+f.Name, f.Type, f.Pointer, strconv.Quote(key), 0); err != nil {
+			return err
+		}
+		if err := w.Close(); err != nil {
+			return err
+		}
+	}
+
+	fmt.Fprintf(w, "\n\treturn nil\n}\n\n")
+	return nil
+}
+
+// embedGuard buffers the code reading a field promoted through pointers to
+// embedded structs, to only run it when they aren't nil. Read-only methods
+// can't call InitNilEmbeddedStruct, which writes to the receiver.
+type embedGuard struct {
+	bytes.Buffer
+	w      io.Writer
+	embeds []string
+}
+
+func newEmbedGuard(w io.Writer, f Field) *embedGuard {
+	return &embedGuard{w: w, embeds: f.Embeds}
+}
+
+// Close writes the buffered code, guarded by nil checks if it needs any.
+func (g *embedGuard) Close() error {
+	if g.Len() == 0 || len(g.embeds) == 0 {
+		_, err := g.WriteTo(g.w)
+		return err
+	}
+	checks := make([]string, len(g.embeds))
+	for i, e := range g.embeds {
+		checks[i] = "t." + e + " != nil"
+	}
+	if _, err := fmt.Fprintf(g.w, "\n\tif %s {", strings.Join(checks, " && ")); err != nil {
+		return err
+	}
+	if _, err := g.WriteTo(g.w); err != nil {
+		return err
+	}
+	_, err := fmt.Fprintf(g.w, "\n\t}")
+	return err
+}
+
+// emitForEachLinkValue emits the code visiting the links held by the Go
+// expression expr of type t, path being the Go expression of its path.
+func emitForEachLinkValue(w io.Writer, expr string, t reflect.Type, pointer bool, path string, depth int) error {
+	if !typeMayHoldLinks(t, map[reflect.Type]bool{}) {
+		return nil
+	}
+
+	switch t.Kind() {
+	case reflect.Struct:
+		switch {
+		case t == cidType && pointer:
+			fmt.Fprintf(w, `
+	if %s != nil {
+		if err := cb(%s, *%s); err != nil {
+			return err
+		}
+	}`, expr, path, expr)
+		case t == cidType:
+			fmt.Fprintf(w, `
+	if %s.Defined() {
+		if err := cb(%s, %s); err != nil {
+			return err
+		}
+	}`, expr, path, expr)
+		default:
+			// Type parameters are pointers to types with their own methods.
+			if !pointer && typeParamIndex(t) < 0 {
+				expr = "&" + expr
+			}
+			fmt.Fprintf(w, `
+	if err := cbg.ForEachLinkIn(%s, %s, cb); err != nil {
+		return err
+	}`, expr, path)
+		}
+	case reflect.Slice, reflect.Array:
+		e := t.Elem()
+		var elemPointer bool
+		if e.Kind() == reflect.Ptr {
+			e = e.Elem()
+			elemPointer = true
+		}
+		iv := string([]byte{'i' + byte(depth)})
+		fmt.Fprintf(w, "\n\tfor %s := range %s {", iv, expr)
+		if err := emitForEachLinkValue(w, fmt.Sprintf("%s[%s]", expr, iv), e, elemPointer,
+			fmt.Sprintf(`fmt.Sprintf("%%s/%%d", %s, %s)`, path, iv), depth+1); err != nil {
+			return err
+		}
+		fmt.Fprintf(w, "\n\t}")
+	case reflect.Map:
+		e := t.Elem()
+		var elemPointer bool
+		if e.Kind() == reflect.Ptr {
+			e = e.Elem()
+			elemPointer = true
+		}
+		kv, vv := fmt.Sprintf("k%d", depth), fmt.Sprintf("v%d", depth)
+		fmt.Fprintf(w, `
+	{
+		keys := make([]string, 0, len(%s))
+		for k := range %s {
+			keys = append(keys, k)
+		}
+		cbg.MapKeySort_RFC7049(keys)
+		for _, %s := range keys {
+			%s := %s[%s]`, expr, expr, kv, vv, expr, kv)
+		if err := emitForEachLinkValue(w, vv, e, elemPointer, fmt.Sprintf(`%s + "/" + %s`, path, kv), depth+1); err != nil {
+			return err
+		}
+		fmt.Fprintf(w, "\n\t\t}\n\t}")
+	default:
+		return fmt.Errorf("cannot visit links in %s", t)
+	}
+	return nil
+}
+
+// typeMayHoldLinks reports whether values of type t may hold links.
+func typeMayHoldLinks(t reflect.Type, visiting map[reflect.Type]bool) bool {
+	switch t.Kind() {
+	case reflect.Ptr, reflect.Slice, reflect.Array:
+		return typeMayHoldLinks(t.Elem(), visiting)
+	case reflect.Map:
+		return typeMayHoldLinks(t.Elem(), visiting)
+	case reflect.Struct:
+		switch {
+		case t == cidType, t == deferredType, typeParamIndex(t) >= 0:
+			return true
+		case t == bigIntType:
+			return false
+		}
+		if visiting[t] {
+			return false
+		}
+		visiting[t] = true
+		defer delete(visiting, t)
+		for i := 0; i < t.NumField(); i++ {
+			f := t.Field(i)
+			if nameIsExported(f.Name) && typeMayHoldLinks(f.Type, visiting) {
+				return true
+			}
+		}
+		return false
+	default:
+		return false
+	}
+}
+
 // Generates 'tuple representation' cbor encoders for the given type
 func GenTupleEncodersForType(gti *GenTypeInfo, flattenEmbeddedStruct bool,
 	embeddedByPointerStructs *[]string, w io.Writer) error {
+	decodeOnly := ""
+	for _, f := range gti.Fields {
+		if f.Default != "" || f.OmitDefault {
+			return fmt.Errorf("%s: field %s: default values are only supported in map representation", gti.Name, f.Name)
+		}
+		// Tuples are positional, so the fields left out of the encoding must
+		// be the trailing ones, which the decoder accepts as missing.
+		switch {
+		case f.EncodeOnly:
+			return fmt.Errorf("%s: field %s: encodeonly is only supported in map representation", gti.Name, f.Name)
+		case f.DecodeOnly && !f.Optional:
+			return fmt.Errorf("%s: field %s: decodeonly fields must be optional in tuple representation", gti.Name, f.Name)
+		case f.DecodeOnly:
+			decodeOnly = f.Name
+		case decodeOnly != "":
+			return fmt.Errorf("%s: field %s: decodeonly field %s must come after all the encoded fields in tuple representation", gti.Name, f.Name, decodeOnly)
+		}
+	}
+
 	if flattenEmbeddedStruct {
 		if err := emitInitNilEmbeddedStructMethod(w, gti, *embeddedByPointerStructs); err != nil {
 			return err
 		}
 	}
 
-	if err := emitCborMarshalStructTuple(w, gti, flattenEmbeddedStruct); err != nil {
+	if err := emitCborMarshalStructTuple(w, gti.forEncoding(), flattenEmbeddedStruct); err != nil {
 		return err
 	}
 
-	if err := emitCborUnmarshalStructTuple(w, gti, flattenEmbeddedStruct); err != nil {
+	if err := emitCborUnmarshalStructTuple(w, gti.forDecoding(), flattenEmbeddedStruct); err != nil {
+		return err
+	}
+
+	// DAG-JSON has no integer map keys or bignums, so such types can't be
+	// transcoded.
+	if gti.fitsIPLD(ReprTuple) {
+		if err := emitDagJSONMethods(w, gti); err != nil {
+			return err
+		}
+	}
+
+	if err := emitLinksMethod(w, gti); err != nil {
+		return err
+	}
+
+	if err := emitForEachLinkMethod(w, gti, true); err != nil {
 		return err
 	}
 
@@ -1354,7 +2505,7 @@ func GenTupleEncodersForType(gti *GenTypeInfo, flattenEmbeddedStruct bool,
 }
 
 func emitCborMarshalStructMap(w io.Writer, gti *GenTypeInfo, flattenEmbeddedStruct bool) error {
-	err := doTemplate(w, gti, `func (t *{{ .Name }}) MarshalCBOR(w io.Writer) (n int, err error) {
+	err := doTemplate(w, gti, `func (t *{{ .Receiver }}) MarshalCBOR(w io.Writer) (n int, err error) {
 	if t == nil {
 		return w.Write(cbg.CborNull)
 	}`)
@@ -1370,7 +2521,22 @@ func emitCborMarshalStructMap(w io.Writer, gti *GenTypeInfo, flattenEmbeddedStru
 		return err
 	}
 
+	if err := emitCallPreMarshal(w, gti); err != nil {
+		return err
+	}
+
 	err = doTemplate(w, gti, `
+{{- if .OmittedFields }}
+	fieldCount := {{ len .Fields }}
+{{ range .OmittedFields }}
+	if {{ .IsDefault }} {
+		fieldCount--
+	}
+{{ end }}
+	scratch := make([]byte, 9)
+
+	{{ MajorType "w" "cbg.MajMap" "fieldCount" }}
+{{- else }}
 	if n_, err := w.Write({{ .MapHeaderAsByteString }}); err != nil {
 		return n + n_, err
 	} else {
@@ -1378,15 +2544,30 @@ func emitCborMarshalStructMap(w io.Writer, gti *GenTypeInfo, flattenEmbeddedStru
 	}
 
 	scratch := make([]byte, 9)
+{{- end }}
 `)
 	if err != nil {
 		return err
 	}
 
 	for _, f := range gti.Fields {
-		fmt.Fprintf(w, "\n\t// t.%s (%s) (%s)", f.Name, f.Type, f.Type.Kind())
+		fmt.Fprintf(w, "\n\t// %s", f.Comment())
+		if f.OmitDefault {
+			fmt.Fprintf(w, "\n\tif %s {\n", f.defaultCond(false))
+		}
 
-		if err := emitCborMarshalStringField(w, Field{
+		if f.IntKey {
+			err := doTemplate(w, f, `
+	if n_, err := w.Write({{ .MapKeyAsByteString }}); err != nil {
+		return n + n_, err
+	} else {
+		n += n_
+	}
+`)
+			if err != nil {
+				return err
+			}
+		} else if err := emitCborMarshalStringField(w, Field{
 			Name: `"` + f.MapKey + `"`,
 		}); err != nil {
 			return err
@@ -1442,6 +2623,9 @@ func emitCborMarshalStructMap(w io.Writer, gti *GenTypeInfo, flattenEmbeddedStru
 		default:
 			return fmt.Errorf("field %q of %q has unsupported kind %q", f.Name, gti.Name, f.Type.Kind())
 		}
+		if f.OmitDefault {
+			fmt.Fprintf(w, "\t}\n")
+		}
 	}
 
 	fmt.Fprintf(w, "\treturn n, nil\n}\n\n")
@@ -1451,9 +2635,9 @@ func emitCborMarshalStructMap(w io.Writer, gti *GenTypeInfo, flattenEmbeddedStru
 func emitCborUnmarshalStructMap(w io.Writer, gti *GenTypeInfo,
 	flattenEmbeddedStruct bool) error {
 	err := doTemplate(w, gti, `
-func (t *{{ .Name}}) UnmarshalCBOR(r io.Reader) (int, error) {
+func (t *{{ .Receiver }}) UnmarshalCBOR(r io.Reader) (int, error) {
 	bytesRead := 0
-	*t = {{.Name}}{}`)
+	*t = {{ .Receiver }}{}`)
 	if err != nil {
 		return err
 	}
@@ -1470,6 +2654,11 @@ func (t *{{ .Name}}) UnmarshalCBOR(r io.Reader) (int, error) {
 
 	br := cbg.GetPeeker(r)
 	scratch := make([]byte, 8)
+	limits := cbg.GetDecodeLimits(br)
+	if err := limits.Enter(); err != nil {
+		return bytesRead, &cbg.DecodeError{Type: "{{ .Name }}", Err: err}
+	}
+	defer limits.Leave()
 
 	maj, extra, read, err := {{ ReadHeader "br" }}
 	if err != nil {
@@ -1477,44 +2666,73 @@ func (t *{{ .Name}}) UnmarshalCBOR(r io.Reader) (int, error) {
 	}
 	bytesRead += read
 	if maj != cbg.MajMap {
-		return bytesRead, fmt.Errorf("cbor input should be of type map")
+		return bytesRead, &cbg.DecodeError{Type: "{{ .Name }}", Expected: cbg.MajMap, Found: maj, Err: cbg.ErrUnexpectedType}
 	}
 
 	if extra > cbg.MaxLength {
-		return bytesRead, fmt.Errorf("{{ .Name }}: map struct too large (%d)", extra)
+		return bytesRead, &cbg.DecodeError{Type: "{{ .Name }}", Expected: cbg.MajMap, Found: maj, Err: cbg.ErrMaxLength}
+	}
+	if err := limits.AddItems(extra); err != nil {
+		return bytesRead, &cbg.DecodeError{Type: "{{ .Name }}", Expected: cbg.MajMap, Found: maj, Err: err}
 	}
 
+{{ if not .HasIntKeys }}
 	var name string
+{{- end }}
 	n := extra
-
+{{ if .HasOptionalFields }}
+	present := 0
+{{ end }}
+{{- range .SeenFields }}
+	var seen{{ .Name }} bool
+{{- end }}
 	for i := uint64(0); i < n; i++ {
 `)
 	if err != nil {
 		return err
 	}
 
-	if err := emitCborUnmarshalStringField(w, Field{Name: "name"}); err != nil {
-		return err
-	}
+	if gti.HasIntKeys() {
+		err = doTemplate(w, gti, `
+		key, read, err := cbg.ReadMapKeyBuf(br, scratch)
+		if err != nil {
+			return bytesRead, cbg.WrapDecodeError(err, "{{ .Name }}", "", bytesRead)
+		}
+		bytesRead += read
 
-	err = doTemplate(w, gti, `
+		switch key {
+`)
+	} else {
+		if err := emitCborUnmarshalStringField(w, Field{Name: "name", Struct: gti.Name, Path: `""`}); err != nil {
+			return err
+		}
+
+		err = doTemplate(w, gti, `
 		switch name {
 `)
+	}
 	if err != nil {
 		return err
 	}
 
 	for _, f := range gti.Fields {
-		fmt.Fprintf(w, "// t.%s (%s) (%s)", f.Name, f.Type, f.Type.Kind())
+		fmt.Fprintf(w, "// %s", f.Comment())
 
-		err := doTemplate(w, f, `
-		case "{{ .MapKey }}":
-`)
-		if err != nil {
-			return err
+		fmt.Fprintf(w, "\n\t\tcase %s:\n", f.mapKeyCases(gti.HasIntKeys()))
+		if len(f.Aliases) > 0 {
+			fmt.Fprintf(w, "\t\t\tif seen%s {\n", f.Name)
+			fmt.Fprintf(w, "\t\t\t\treturn bytesRead, &cbg.DecodeError{Type: %q, Field: %q, Offset: bytesRead, Err: fmt.Errorf(\"several map keys for field\")}\n", gti.Name, f.Name)
+			fmt.Fprintf(w, "\t\t\t}\n")
+		}
+		if f.tracksSeen() {
+			fmt.Fprintf(w, "\t\t\tseen%s = true\n", f.Name)
+		}
+		if gti.HasOptionalFields() {
+			fmt.Fprintf(w, "\t\t\tpresent++\n")
 		}
 
 		f.Name = "t." + f.Name
+		f.Struct = gti.Name
 
 		len := 0
 		switch f.Type.Kind() {
@@ -1588,18 +2806,48 @@ func (t *{{ .Name}}) UnmarshalCBOR(r io.Reader) (int, error) {
 		}
 	}
 
-	return doTemplate(w, gti, `
+	err = doTemplate(w, gti, `
 		default:
 			// Field doesn't exist on this type, so ignore it
-			if read, err := cbg.ScanForLinks(r, func(cid.Cid){}); err == nil {
+			if read, err := cbg.ScanForLinks(br, func(cid.Cid){}); err != nil {
+				return bytesRead, cbg.WrapDecodeError(err, "{{ .Name }}", "", bytesRead)
+			} else {
 				bytesRead += read
 			}
 		}
 	}
 
-	return bytesRead, nil
-}
 `)
+	if err != nil {
+		return err
+	}
+
+	err = doTemplate(w, gti, `
+{{- range .DefaultFields }}
+	if !seen{{ .Name }} {
+		t.{{ .Name }} = {{ .DefaultValue }}
+	}
+{{ end }}`)
+	if err != nil {
+		return err
+	}
+
+	if gti.HasOptionalFields() {
+		if err := emitCallUpgrade(w, gti); err != nil {
+			return err
+		}
+	}
+
+	if err := emitConstraintChecks(w, gti, false); err != nil {
+		return err
+	}
+
+	if err := emitCallPostUnmarshal(w, gti); err != nil {
+		return err
+	}
+
+	fmt.Fprintf(w, "\treturn bytesRead, nil\n}\n")
+	return nil
 }
 
 // Generates 'tuple representation' cbor encoders for the given type
@@ -1611,11 +2859,27 @@ func GenMapEncodersForType(gti *GenTypeInfo, flattenEmbeddedStruct bool,
 		}
 	}
 
-	if err := emitCborMarshalStructMap(w, gti, flattenEmbeddedStruct); err != nil {
+	if err := emitCborMarshalStructMap(w, gti.forEncoding(), flattenEmbeddedStruct); err != nil {
+		return err
+	}
+
+	if err := emitCborUnmarshalStructMap(w, gti.forDecoding(), flattenEmbeddedStruct); err != nil {
+		return err
+	}
+
+	// DAG-JSON has no integer map keys or bignums, so such types can't be
+	// transcoded.
+	if gti.fitsIPLD(ReprMap) {
+		if err := emitDagJSONMethods(w, gti); err != nil {
+			return err
+		}
+	}
+
+	if err := emitLinksMethod(w, gti); err != nil {
 		return err
 	}
 
-	if err := emitCborUnmarshalStructMap(w, gti, flattenEmbeddedStruct); err != nil {
+	if err := emitForEachLinkMethod(w, gti, false); err != nil {
 		return err
 	}
 
@@ -0,0 +1,322 @@
+package typegen
+
+import (
+	"io/ioutil"
+	"testing"
+)
+
+func TestFieldTagOptions(t *testing.T) {
+	type tagged struct {
+		A string `cborgen:"a,optional"`
+		B string `cborgen:",optional"`
+	}
+	gti, _, err := ParseTypeInfo(tagged{}, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if f := gti.Fields[0]; f.MapKey != "a" || !f.Optional {
+		t.Errorf("unexpected field: %+v", f)
+	}
+	if f := gti.Fields[1]; f.MapKey != "B" || !f.Optional {
+		t.Errorf("unexpected field: %+v", f)
+	}
+
+	type intKeys struct {
+		A string `cborgen:"1,intkey"`
+		B string `cborgen:"-2,intkey"`
+		C string `cborgen:"01"`
+	}
+	gti, _, err = ParseTypeInfo(intKeys{}, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if f := gti.Fields[0]; f.MapKey != "1" || !f.IntKey {
+		t.Errorf("unexpected field: %+v", f)
+	}
+	if f := gti.Fields[1]; f.MapKey != "-2" || !f.IntKey {
+		t.Errorf("unexpected field: %+v", f)
+	}
+	if f := gti.Fields[2]; f.MapKey != "01" || f.IntKey {
+		t.Errorf("unexpected field: %+v", f)
+	}
+
+	// Integer keys have a single spelling.
+	for _, v := range []interface{}{
+		struct {
+			A string `cborgen:"01,intkey"`
+		}{},
+		struct {
+			A string `cborgen:"+1,intkey"`
+		}{},
+		struct {
+			A string `cborgen:"-0,intkey"`
+		}{},
+		struct {
+			A string `cborgen:"1a,intkey"`
+		}{},
+		struct {
+			A string `cborgen:",intkey"`
+		}{},
+		struct {
+			A string `cborgen:"a,intalias=01"`
+		}{},
+	} {
+		if _, _, err := ParseTypeInfo(v, false); err == nil {
+			t.Errorf("%T: expected an error", v)
+		}
+	}
+
+	type badTag struct {
+		A string `cborgen:"a,maybe"`
+	}
+	if _, _, err := ParseTypeInfo(badTag{}, false); err == nil {
+		t.Error("expected an error for an unknown tag option")
+	}
+
+	type notTrailing struct {
+		A string `cborgen:",optional"`
+		B string
+	}
+	gti, _, err = ParseTypeInfo(notTrailing{}, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := GenTupleEncodersForType(gti, false, nil, ioutil.Discard); err == nil {
+		t.Error("expected an error for a required field after an optional one")
+	}
+}
+
+func TestTupleIndexTags(t *testing.T) {
+	type indexed struct {
+		A string `cborgen:",index=1"`
+		B string `cborgen:",index=0"`
+		C string `cborgen:",index=2,optional"`
+	}
+	// FieldOrder doesn't apply to types with index tags.
+	s, err := Gen{FieldOrder: []string{"C"}}.TupleSchema(indexed{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var names []string
+	for _, f := range s.Fields {
+		names = append(names, f.Name)
+	}
+	if !equalStrings(names, []string{"B", "A", "C"}) {
+		t.Fatalf("unexpected field order: %q", names)
+	}
+
+	type duplicate struct {
+		A string `cborgen:",index=0"`
+		B string `cborgen:",index=0"`
+	}
+	type missing struct {
+		A string `cborgen:",index=0"`
+		B string
+	}
+	type outOfRange struct {
+		A string `cborgen:",index=0"`
+		B string `cborgen:",index=2"`
+	}
+	type invalid struct {
+		A string `cborgen:",index=-1"`
+	}
+	type decodeOnly struct {
+		A string `cborgen:",index=0,decodeonly"`
+		B string `cborgen:",index=1"`
+	}
+	type encodeOnly struct {
+		A string `cborgen:",index=0"`
+		B string `cborgen:",index=1,encodeonly"`
+	}
+	for _, v := range []interface{}{duplicate{}, missing{}, outOfRange{}, invalid{}, decodeOnly{}, encodeOnly{}} {
+		if _, err := (Gen{}).TupleSchema(v); err == nil {
+			t.Errorf("%T: expected an error", v)
+		}
+	}
+}
+
+func TestMapKeyAliasTags(t *testing.T) {
+	type intAlias struct {
+		A string `cborgen:"a,intalias=-1,alias=b,alias=2"`
+	}
+	gti, _, err := ParseTypeInfo(intAlias{}, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if a := gti.Fields[0].Aliases; len(a) != 3 || a[0] != IntKey(-1) || a[1] != TextKey("b") || a[2] != TextKey("2") {
+		t.Fatalf("unexpected aliases: %v", a)
+	}
+	if !gti.HasIntKeys() {
+		t.Fatal("integer aliases need integer key decoding")
+	}
+
+	type collision struct {
+		A string `cborgen:"a,alias=b"`
+		B string `cborgen:"b"`
+	}
+	if _, err := (Gen{}).MapSchema(collision{}); err == nil {
+		t.Fatal("expected an error for an alias colliding with a map key")
+	}
+	type emptyAlias struct {
+		A string `cborgen:"a,alias="`
+	}
+	if _, err := (Gen{}).MapSchema(emptyAlias{}); err == nil {
+		t.Fatal("expected an error for an empty alias")
+	}
+}
+
+func TestDecodeOnlyEncodeOnlyTags(t *testing.T) {
+	type both struct {
+		A string `cborgen:",decodeonly,encodeonly"`
+	}
+	if _, _, err := ParseTypeInfo(both{}, false); err == nil {
+		t.Error("expected an error for a field both decode-only and encode-only")
+	}
+	type collision struct {
+		A string `cborgen:"a,decodeonly"`
+		B string `cborgen:"a"`
+	}
+	if _, err := (Gen{}).MapSchema(collision{}); err == nil {
+		t.Error("expected an error for decoded fields with the same key")
+	}
+
+	// Tuples are positional: leaving a field out of the encoding or the
+	// decoding only works for trailing optional fields missing from the input.
+	for _, v := range []interface{}{
+		struct {
+			Name string
+			Prev string `cborgen:",decodeonly"`
+		}{},
+		struct {
+			Name string
+			Prev string `cborgen:",decodeonly,optional"`
+			Head string `cborgen:",optional"`
+		}{},
+		struct {
+			Name string
+			Next string `cborgen:",encodeonly,optional"`
+		}{},
+	} {
+		gti, _, err := ParseTypeInfo(v, false)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := GenTupleEncodersForType(gti, false, nil, ioutil.Discard); err == nil {
+			t.Errorf("%T: expected an error in tuple representation", v)
+		}
+	}
+}
+
+func TestDefaultValueTags(t *testing.T) {
+	type defaults struct {
+		S string `cborgen:",default=a,b"`
+	}
+	if _, _, err := ParseTypeInfo(defaults{}, false); err == nil {
+		t.Error("expected an error for a default value holding a comma")
+	}
+
+	for _, v := range []interface{}{
+		struct {
+			U uint8 `cborgen:",default=256"`
+		}{},
+		struct {
+			I int64 `cborgen:",default=x"`
+		}{},
+		struct {
+			B bool `cborgen:",default=maybe"`
+		}{},
+		struct {
+			P *uint64 `cborgen:",default=1"`
+		}{},
+		struct {
+			S []string `cborgen:",omitdefault"`
+		}{},
+	} {
+		if _, _, err := ParseTypeInfo(v, false); err == nil {
+			t.Errorf("%T: expected an error", v)
+		}
+	}
+
+	type tuple struct {
+		N uint64 `cborgen:",default=3"`
+	}
+	gti, _, err := ParseTypeInfo(tuple{}, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := GenTupleEncodersForType(gti, false, nil, ioutil.Discard); err == nil {
+		t.Error("expected an error for default values in tuple representation")
+	}
+}
+
+func TestConstraintTags(t *testing.T) {
+	type constrained struct {
+		A int8   `cborgen:",min=-3,max=3,enum=-3|0|3"`
+		B string `cborgen:",len=2"`
+		// Valid when missing.
+		C string  `cborgen:",enum=|x,optional"`
+		D int64   `cborgen:",min=-1,max=1,optional"`
+		E *uint64 `cborgen:",min=1,optional"`
+		F int64   `cborgen:"f,default=2,max=3"`
+	}
+	gti, _, err := ParseTypeInfo(constrained{}, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if c := gti.Fields[0].Constraints; len(c) != 3 || c[0].Length ||
+		c[2].Violated != "%[1]s != -3 && %[1]s != 0 && %[1]s != 3" {
+		t.Errorf("unexpected constraints: %+v", c)
+	}
+	if c := gti.Fields[1].Constraints; len(c) != 1 || !c[0].Length || c[0].Violated != "%[1]s != 2" {
+		t.Errorf("unexpected constraints: %+v", c)
+	}
+
+	for _, v := range []interface{}{
+		struct {
+			A uint64 `cborgen:",len=3"`
+		}{},
+		struct {
+			A []byte `cborgen:",enum=a"`
+		}{},
+		struct {
+			A bool `cborgen:",min=1"`
+		}{},
+		struct {
+			A uint8 `cborgen:",max=256"`
+		}{},
+		struct {
+			A string `cborgen:",min=-1"`
+		}{},
+		struct {
+			A int64 `cborgen:",min=2,max=1"`
+		}{},
+		struct {
+			A string `cborgen:",len=2,max=3"`
+		}{},
+		struct {
+			A string `cborgen:",max=2,max=3"`
+		}{},
+		struct {
+			A string `cborgen:",len=2,optional"`
+		}{},
+		struct {
+			A []byte `cborgen:",min=1,optional"`
+		}{},
+		struct {
+			A uint64 `cborgen:",enum=1|2,optional"`
+		}{},
+		struct {
+			A string `cborgen:"a,default=abc,max=2,optional"`
+		}{},
+		struct {
+			A int64 `cborgen:"a,default=5,max=3"`
+		}{},
+		struct {
+			A string `cborgen:"a,default=c,enum=a|b"`
+		}{},
+	} {
+		if _, _, err := ParseTypeInfo(v, false); err == nil {
+			t.Errorf("%T: expected an error", v)
+		}
+	}
+}
@@ -0,0 +1,174 @@
+package typegen
+
+import (
+	"fmt"
+	"io"
+	"reflect"
+	"strings"
+)
+
+// CBORMarshalUnmarshaler is the constraint of the type parameters of generic
+// types passed to the generator, e.g.
+//
+//	type Page[T cbg.CBORMarshalUnmarshaler] struct {
+//		Items []T
+//		Next  string
+//	}
+//
+// Type arguments are typically pointers to generated types, e.g.
+// Page[*Block].
+type CBORMarshalUnmarshaler interface {
+	CBORMarshaler
+	CBORUnmarshaler
+}
+
+// TypeParam0 to TypeParam3 stand for the type parameters of a generic type
+// passed to the generator: Page[cbg.TypeParam0]{} generates the methods of
+// Page[T] for every T. They only satisfy CBORMarshalUnmarshaler, and fail to
+// encode or decode.
+type (
+	TypeParam0 struct{ typeParam }
+	TypeParam1 struct{ typeParam }
+	TypeParam2 struct{ typeParam }
+	TypeParam3 struct{ typeParam }
+)
+
+type typeParam struct{}
+
+var errTypeParam = fmt.Errorf("type parameter placeholders can't be encoded")
+
+func (typeParam) MarshalCBOR(io.Writer) (int, error) {
+	return 0, errTypeParam
+}
+
+func (typeParam) UnmarshalCBOR(io.Reader) (int, error) {
+	return 0, errTypeParam
+}
+
+var typeParamTypes = []reflect.Type{
+	reflect.TypeOf(TypeParam0{}),
+	reflect.TypeOf(TypeParam1{}),
+	reflect.TypeOf(TypeParam2{}),
+	reflect.TypeOf(TypeParam3{}),
+}
+
+// typeParamIndex returns N if t is TypeParamN, or -1.
+func typeParamIndex(t reflect.Type) int {
+	for i, tp := range typeParamTypes {
+		if t == tp {
+			return i
+		}
+	}
+	return -1
+}
+
+// typeParamName returns the name of the type parameter of the generated code
+// standing for TypeParamN.
+func typeParamName(i int) string {
+	return fmt.Sprintf("T%d", i)
+}
+
+// typeParamString is t.String(), with the TypeParamN placeholders it refers
+// to replaced by the names of the type parameters.
+func typeParamString(t reflect.Type) string {
+	s := t.String()
+	for i, tp := range typeParamTypes {
+		s = strings.ReplaceAll(s, tp.String(), typeParamName(i))
+	}
+	return s
+}
+
+// parseTypeParams splits the name of a generic type instantiated with
+// TypeParamN placeholders, e.g. "Page[github.com/daotl/cbor-gen.TypeParam0]",
+// into its name and the names of its type parameters.
+func parseTypeParams(t reflect.Type) (string, []string, error) {
+	name := t.Name()
+	open := strings.IndexByte(name, '[')
+	if open < 0 {
+		return name, nil, nil
+	}
+
+	var params []string
+	seen := map[string]bool{}
+	for _, arg := range splitTypeArgs(name[open+1 : len(name)-1]) {
+		i := -1
+		for j, tp := range typeParamTypes {
+			if arg == tp.PkgPath()+"."+tp.Name() {
+				i = j
+			}
+		}
+		if i < 0 {
+			return "", nil, fmt.Errorf("%s: generic types must be instantiated with cbg.TypeParam0 to cbg.TypeParam3, "+
+				"as methods can't be declared on a specific instantiation", name)
+		}
+		param := typeParamName(i)
+		if seen[param] {
+			return "", nil, fmt.Errorf("%s: type parameter placeholders must be distinct", name)
+		}
+		seen[param] = true
+		params = append(params, param)
+	}
+	return name[:open], params, nil
+}
+
+// splitTypeArgs splits the type arguments of an instantiated type name on the
+// commas which aren't nested in brackets.
+func splitTypeArgs(s string) []string {
+	var args []string
+	depth, start := 0, 0
+	for i, c := range s {
+		switch c {
+		case '[':
+			depth++
+		case ']':
+			depth--
+		case ',':
+			if depth == 0 {
+				args = append(args, s[start:i])
+				start = i + 1
+			}
+		}
+	}
+	return append(args, s[start:])
+}
+
+// UnmarshalTypeParam decodes r into *v, where v points to a value of a type
+// parameter of a generic generated type, typically a pointer type. A nil
+// pointer is allocated before decoding, unless the input is null.
+func UnmarshalTypeParam(r io.Reader, v interface{}) (int, error) {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Ptr || rv.IsNil() {
+		return 0, fmt.Errorf("UnmarshalTypeParam needs a non-nil pointer, got %T", v)
+	}
+	e := rv.Elem()
+
+	if e.Kind() == reflect.Ptr {
+		br := GetPeeker(r)
+		b, err := br.ReadByte()
+		if err != nil {
+			return 0, err
+		}
+		if b == CborNull[0] {
+			e.Set(reflect.Zero(e.Type()))
+			return 1, nil
+		}
+		if err := br.UnreadByte(); err != nil {
+			return 0, err
+		}
+		if e.IsNil() {
+			e.Set(reflect.New(e.Type().Elem()))
+		}
+		r = br
+	}
+
+	// Pointers decode into what they point to, other types need their
+	// address.
+	u := rv.Interface()
+	if e.Kind() == reflect.Ptr {
+		u = e.Interface()
+	}
+	if u, ok := u.(CBORUnmarshaler); ok {
+		return u.UnmarshalCBOR(r)
+	}
+	return 0, fmt.Errorf("can't unmarshal into a %s", e.Type())
+}
@@ -0,0 +1,82 @@
+package typegen
+
+import (
+	"bytes"
+	"io"
+	"strings"
+	"testing"
+)
+
+type genericPair[K, V CBORMarshalUnmarshaler] struct {
+	Key    K
+	Values []V
+}
+
+// testUnmarshaler records the bytes it's decoded from.
+type testUnmarshaler struct {
+	data []byte
+}
+
+func (t *testUnmarshaler) MarshalCBOR(w io.Writer) (int, error) {
+	return w.Write(t.data)
+}
+
+func (t *testUnmarshaler) UnmarshalCBOR(r io.Reader) (int, error) {
+	b, err := r.(io.ByteReader).ReadByte()
+	if err != nil {
+		return 0, err
+	}
+	t.data = []byte{b}
+	return 1, nil
+}
+
+func TestParseGenericTypeInfo(t *testing.T) {
+	gti, _, err := ParseTypeInfo(genericPair[TypeParam1, TypeParam0]{}, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if gti.Name != "genericPair" || gti.Receiver() != "genericPair[T1, T0]" {
+		t.Fatalf("unexpected type info: %s, %s", gti.Name, gti.Receiver())
+	}
+	if name := gti.Fields[1].TypeName(); name != "[]T0" {
+		t.Fatalf("unexpected field type %s", name)
+	}
+	if c := gti.Fields[1].Comment(); c != "t."+gti.Fields[1].Name+" ([]T0) (slice)" {
+		t.Fatalf("unexpected field comment %s", c)
+	}
+	if c := gti.Fields[0].Comment(); c != "t."+gti.Fields[0].Name+" (T1) (type parameter)" {
+		t.Fatalf("unexpected field comment %s", c)
+	}
+
+	_, _, err = ParseTypeInfo(genericPair[*testUnmarshaler, TypeParam0]{}, false)
+	if err == nil || !strings.Contains(err.Error(), "must be instantiated with cbg.TypeParam0") {
+		t.Fatalf("expected an error for a concrete instantiation, got %v", err)
+	}
+	_, _, err = ParseTypeInfo(genericPair[TypeParam0, TypeParam0]{}, false)
+	if err == nil || !strings.Contains(err.Error(), "must be distinct") {
+		t.Fatalf("expected an error for repeated placeholders, got %v", err)
+	}
+}
+
+func TestUnmarshalTypeParam(t *testing.T) {
+	var v *testUnmarshaler
+	if _, err := UnmarshalTypeParam(bytes.NewReader([]byte{0x01}), &v); err != nil {
+		t.Fatal(err)
+	}
+	if v == nil || !bytes.Equal(v.data, []byte{0x01}) {
+		t.Fatalf("unexpected value %+v", v)
+	}
+
+	read, err := UnmarshalTypeParam(bytes.NewReader(CborNull), &v)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if read != 1 || v != nil {
+		t.Fatalf("expected null to decode as nil, got %+v", v)
+	}
+
+	var iface CBORMarshalUnmarshaler
+	if _, err := UnmarshalTypeParam(bytes.NewReader([]byte{0x01}), &iface); err == nil {
+		t.Fatal("expected an error for an interface type argument")
+	}
+}
@@ -0,0 +1,200 @@
+package typegen
+
+import (
+	"io"
+	"reflect"
+	"strings"
+)
+
+// testFileName returns the name of the test file generated next to fname.
+func testFileName(fname string) string {
+	return strings.TrimSuffix(fname, ".go") + "_test.go"
+}
+
+// PrintTestHeader writes the header of a generated test file, see
+// Gen.GenerateTests.
+func PrintTestHeader(w io.Writer, pkg string) error {
+	data := struct {
+		Package string
+	}{pkg}
+	return doTemplate(w, data, `// Code generated by github.com/daotl/cbor-gen. DO NOT EDIT.
+
+package {{ .Package }}
+
+import (
+	"bytes"
+	"math/rand"
+	"reflect"
+	"testing"
+	"testing/quick"
+)
+
+var _ = rand.New
+var _ = reflect.TypeOf
+var _ = quick.Value
+
+`)
+}
+
+// GenTestsForType generates a fuzz target checking that malformed input fails
+// to unmarshal into the type t described by gti without panicking, and that
+// whatever unmarshals encodes back in a stable way.
+//
+// If testing/quick can generate values of t, it also generates a property test
+// checking that random values roundtrip, and that their truncated encodings
+// fail to unmarshal. That's not the case of types holding unexported fields,
+// such as links, or Deferred, whose random content wouldn't be valid CBOR.
+// Types with constraints get no property test either, as decoders would
+// reject most random values.
+//
+// Random values rejected by the PreMarshalCBOR method of types implementing
+// PreMarshaler are skipped.
+//
+// Generic types get no tests, as there's no type argument to test them with,
+// and neither do types with decode-only or encode-only fields, which don't
+// roundtrip.
+func GenTestsForType(gti *GenTypeInfo, t reflect.Type, w io.Writer) error {
+	if len(gti.TypeParams) > 0 || gti.IsAsymmetric() {
+		return nil
+	}
+	data := struct {
+		Name       string
+		Quickable  bool
+		PreMarshal bool
+	}{gti.Name, quickable(t, map[reflect.Type]bool{}) && !gti.HasConstraints(), gti.PreMarshal}
+
+	return doTemplate(w, data, `func FuzzUnmarshal{{ .Name }}(f *testing.F) {
+{{- if .Quickable }}
+	r := rand.New(rand.NewSource(56887))
+	for i := 0; i < 10; i++ {
+		val, ok := quick.Value(reflect.TypeOf({{ .Name }}{}), r)
+		if !ok {
+			f.Fatal("failed to generate test value")
+		}
+		buf := new(bytes.Buffer)
+		if _, err := val.Addr().Interface().(*{{ .Name }}).MarshalCBOR(buf); err != nil {
+{{- if .PreMarshal }}
+			continue
+{{- else }}
+			f.Fatal(err)
+{{- end }}
+		}
+		f.Add(buf.Bytes())
+	}
+{{- else }}
+	buf := new(bytes.Buffer)
+	if _, err := new({{ .Name }}).MarshalCBOR(buf); err == nil {
+		f.Add(buf.Bytes())
+	}
+{{- end }}
+
+	f.Fuzz(func(t *testing.T, data []byte) {
+		var obj {{ .Name }}
+		read, err := obj.UnmarshalCBOR(bytes.NewReader(data))
+		if err != nil {
+			return
+		}
+		if read > len(data) {
+			t.Fatalf("read %d bytes out of %d", read, len(data))
+		}
+
+		// Whatever unmarshals must marshal, and roundtrip from there on.
+		buf := new(bytes.Buffer)
+		if _, err := obj.MarshalCBOR(buf); err != nil {
+			t.Fatal("failed to marshal unmarshaled object: ", err)
+		}
+		enc := buf.Bytes()
+
+		var nobj {{ .Name }}
+		if _, err := nobj.UnmarshalCBOR(bytes.NewReader(enc)); err != nil {
+			t.Fatalf("failed to unmarshal %x: %s", enc, err)
+		}
+		nbuf := new(bytes.Buffer)
+		if _, err := nobj.MarshalCBOR(nbuf); err != nil {
+			t.Fatal("failed to remarshal object: ", err)
+		}
+		if !bytes.Equal(nbuf.Bytes(), enc) {
+			t.Fatalf("objects encodings different: %x != %x", nbuf.Bytes(), enc)
+		}
+	})
+}
+
+{{ if .Quickable -}}
+func TestRoundtrip{{ .Name }}(t *testing.T) {
+	r := rand.New(rand.NewSource(56887))
+	for i := 0; i < 100; i++ {
+		val, ok := quick.Value(reflect.TypeOf({{ .Name }}{}), r)
+		if !ok {
+			t.Fatal("failed to generate test value")
+		}
+		obj := val.Addr().Interface().(*{{ .Name }})
+
+		buf := new(bytes.Buffer)
+		if n, err := obj.MarshalCBOR(buf); err != nil {
+{{- if .PreMarshal }}
+			continue
+{{- else }}
+			t.Fatal(err)
+{{- end }}
+		} else if n != buf.Len() {
+			t.Fatal("returned length does not match the byte length")
+		}
+		enc := buf.Bytes()
+
+		var nobj {{ .Name }}
+		if read, err := nobj.UnmarshalCBOR(bytes.NewReader(enc)); err != nil {
+			t.Fatalf("failed to unmarshal %x: %s", enc, err)
+		} else if read != len(enc) {
+			t.Fatalf("wrong bytesRead when unmarshaling: should be %d, actual %d", len(enc), read)
+		}
+
+		nbuf := new(bytes.Buffer)
+		if _, err := nobj.MarshalCBOR(nbuf); err != nil {
+			t.Fatal("failed to remarshal object: ", err)
+		}
+		if !bytes.Equal(nbuf.Bytes(), enc) {
+			t.Fatalf("objects encodings different: %x != %x", nbuf.Bytes(), enc)
+		}
+
+		// Truncated encodings must fail to unmarshal.
+		for _, l := range []int{r.Intn(len(enc)), len(enc) - 1} {
+			var tobj {{ .Name }}
+			if _, err := tobj.UnmarshalCBOR(bytes.NewReader(enc[:l])); err == nil {
+				t.Fatalf("unmarshaled truncated encoding %x", enc[:l])
+			}
+		}
+	}
+}
+
+{{ end -}}
+`)
+}
+
+// quickable reports whether testing/quick can generate meaningful values of t.
+func quickable(t reflect.Type, visiting map[reflect.Type]bool) bool {
+	if t == deferredType {
+		return false
+	}
+	switch t.Kind() {
+	case reflect.Ptr, reflect.Slice, reflect.Array:
+		return quickable(t.Elem(), visiting)
+	case reflect.Map:
+		return quickable(t.Key(), visiting) && quickable(t.Elem(), visiting)
+	case reflect.Struct:
+		if visiting[t] {
+			return true
+		}
+		visiting[t] = true
+		for i := 0; i < t.NumField(); i++ {
+			f := t.Field(i)
+			if f.PkgPath != "" || !quickable(f.Type, visiting) {
+				return false
+			}
+		}
+		return true
+	case reflect.Interface, reflect.Chan, reflect.Func, reflect.UnsafePointer, reflect.Uintptr:
+		return false
+	default:
+		return true
+	}
+}
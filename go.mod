@@ -1,9 +1,23 @@
 module github.com/daotl/cbor-gen
 
-go 1.12
+go 1.18
 
 require (
 	github.com/google/go-cmp v0.4.0
 	github.com/ipfs/go-cid v0.0.6
 	golang.org/x/xerrors v0.0.0-20191204190536-9bdfabe68543
 )
+
+require (
+	github.com/minio/blake2b-simd v0.0.0-20160723061019-3f5f724cb5b1 // indirect
+	github.com/minio/sha256-simd v0.1.1-0.20190913151208-6de447530771 // indirect
+	github.com/mr-tron/base58 v1.1.3 // indirect
+	github.com/multiformats/go-base32 v0.0.3 // indirect
+	github.com/multiformats/go-base36 v0.1.0 // indirect
+	github.com/multiformats/go-multibase v0.0.3 // indirect
+	github.com/multiformats/go-multihash v0.0.13 // indirect
+	github.com/multiformats/go-varint v0.0.5 // indirect
+	github.com/spaolacci/murmur3 v1.1.0 // indirect
+	golang.org/x/crypto v0.0.0-20190611184440-5c40567a22f8 // indirect
+	golang.org/x/sys v0.0.0-20190412213103-97732733099d // indirect
+)
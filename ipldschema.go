@@ -0,0 +1,116 @@
+package typegen
+
+import (
+	"io"
+	"reflect"
+)
+
+var cborTimeType = reflect.TypeOf(CborTime{})
+
+// PrintIpldSchemaHeader writes the header of a generated IPLD Schema file, see
+// Gen.IpldSchemaFile.
+func PrintIpldSchemaHeader(w io.Writer) error {
+	return doTemplate(w, nil, `# Code generated by github.com/daotl/cbor-gen. DO NOT EDIT.
+
+`)
+}
+
+// GenIpldSchemaForType writes the IPLD Schema (DSL) of the type described by
+// gti in the representation repr, ReprTuple or ReprMap. Pointer fields are
+// nullable, optional fields optional, and map keys differing from the field
+// names are renames. Fields omitted when equal to their default value are
+// implicit. As the schema describes the encoded data, decode-only fields are
+// left out.
+//
+// IPLD has no equivalent for the tag of big.Int fields, which are described as
+// Bytes, nor for type parameters of generic types, which are described as Any,
+// nor for integer map keys, which are described as renames to their decimal
+// form followed by a comment.
+func GenIpldSchemaForType(gti *GenTypeInfo, repr string, w io.Writer) error {
+	type ipldField struct {
+		Name string
+		Type string
+		Key  string
+		Int  bool
+		// Implicit is the default value of the field, if omitted when equal
+		// to it.
+		Implicit string
+	}
+	data := struct {
+		Name    string
+		Tuple   bool
+		Fields  []ipldField
+		Renames []ipldField
+	}{Name: gti.Name, Tuple: repr == ReprTuple}
+
+	for _, f := range gti.forEncoding().Fields {
+		typ := ipldTypeName(f.Type)
+		if f.Pointer {
+			typ = "nullable " + typ
+		}
+		if f.Optional {
+			typ = "optional " + typ
+		}
+		field := ipldField{Name: f.Name, Type: typ, Key: f.MapKey, Int: f.IntKey}
+		if f.OmitDefault {
+			field.Implicit = f.DefaultValue()
+		}
+		data.Fields = append(data.Fields, field)
+		if !data.Tuple && (f.MapKey != f.Name || field.Implicit != "") {
+			data.Renames = append(data.Renames, field)
+		}
+	}
+
+	return doTemplate(w, data, `type {{ .Name }} struct {
+{{- range .Fields }}
+	{{ .Name }} {{ .Type }}
+{{- end }}
+}{{ if .Tuple }} representation tuple{{ else if .Renames }} representation map {
+{{- range .Renames }}
+	field {{ .Name }}
+{{- if ne .Key .Name }} rename "{{ .Key }}"{{ end }}
+{{- if .Implicit }} implicit {{ .Implicit }}{{ end }}
+{{- if .Int }} # integer key{{ end }}
+{{- end }}
+}{{ end }}
+
+`)
+}
+
+// ipldTypeName returns the IPLD Schema type of t.
+func ipldTypeName(t reflect.Type) string {
+	if typeParamIndex(t) >= 0 {
+		return "Any"
+	}
+	switch t {
+	case cidType:
+		return "Link"
+	case bigIntType:
+		return "Bytes"
+	case deferredType:
+		return "Any"
+	case cborTimeType:
+		return "Int"
+	}
+
+	switch t.Kind() {
+	case reflect.Ptr:
+		return "nullable " + ipldTypeName(t.Elem())
+	case reflect.Bool:
+		return "Bool"
+	case reflect.String:
+		return "String"
+	case reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uint,
+		reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64, reflect.Int:
+		return "Int"
+	case reflect.Slice, reflect.Array:
+		if t.Elem().Kind() == reflect.Uint8 {
+			return "Bytes"
+		}
+		return "[" + ipldTypeName(t.Elem()) + "]"
+	case reflect.Map:
+		return "{" + ipldTypeName(t.Key()) + ":" + ipldTypeName(t.Elem()) + "}"
+	default:
+		return t.Name()
+	}
+}
@@ -0,0 +1,65 @@
+package typegen
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/ipfs/go-cid"
+)
+
+type ipldSchemaStruct struct {
+	Name    string `cborgen:"name"`
+	Count   uint64
+	Link    cid.Cid
+	Parent  *cid.Cid
+	Items   []*ipldSchemaStruct
+	Entries map[string][]byte
+	Raw     *Deferred
+}
+
+func TestGenIpldSchemaForType(t *testing.T) {
+	gti, _, err := ParseTypeInfo(ipldSchemaStruct{}, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	buf := new(bytes.Buffer)
+	if err := GenIpldSchemaForType(gti, ReprTuple, buf); err != nil {
+		t.Fatal(err)
+	}
+	expected := `type ipldSchemaStruct struct {
+	Name String
+	Count Int
+	Link Link
+	Parent nullable Link
+	Items [nullable ipldSchemaStruct]
+	Entries {String:Bytes}
+	Raw nullable Any
+} representation tuple
+
+`
+	if buf.String() != expected {
+		t.Fatalf("unexpected schema:\n%s", buf.String())
+	}
+
+	buf.Reset()
+	if err := GenIpldSchemaForType(gti, ReprMap, buf); err != nil {
+		t.Fatal(err)
+	}
+	expected = `type ipldSchemaStruct struct {
+	Name String
+	Count Int
+	Link Link
+	Parent nullable Link
+	Items [nullable ipldSchemaStruct]
+	Entries {String:Bytes}
+	Raw nullable Any
+} representation map {
+	field Name rename "name"
+}
+
+`
+	if buf.String() != expected {
+		t.Fatalf("unexpected schema:\n%s", buf.String())
+	}
+}
@@ -0,0 +1,171 @@
+package typegen
+
+import (
+	"errors"
+	"fmt"
+	"io"
+)
+
+// ErrDecodeLimit is returned when decoding goes beyond a DecodeLimits.
+var ErrDecodeLimit = errors.New("decode limit exceeded")
+
+// DecodeLimits caps the resources used to decode untrusted input, beyond the
+// per-item MaxLength and ByteArrayMaxLen limits. Generated decoders, Deferred,
+// ScanForLinks, WalkLinks and DecodeAny honor the limits of the reader returned
+// by Reader, and ValidateCBORWithLimits and DiagnoseWithLimits take them
+// directly. Zero limits aren't enforced.
+//
+// A DecodeLimits accumulates the usage of all the decoding done with it, so a
+// new one is needed for every input. It isn't safe for concurrent use.
+type DecodeLimits struct {
+	// MaxDepth caps the nesting depth of the decoded value. Generated
+	// decoders count a level for every type they decode, the nesting of its
+	// fields being bounded by their Go types, and the other decoders count a
+	// level for every array and map.
+	MaxDepth int
+	// MaxItems caps the total number of array elements and map entries.
+	MaxItems uint64
+	// MaxBytes caps the total length of the byte and text strings.
+	MaxBytes uint64
+
+	depth int
+	items uint64
+	bytes uint64
+}
+
+// DecodeLimiter is implemented by readers carrying DecodeLimits, like the ones
+// returned by DecodeLimits.Reader. Readers wrapping one must implement it too,
+// forwarding its limits, for decoders to honor them, as the reader of a
+// SeqReader does.
+type DecodeLimiter interface {
+	DecodeLimits() *DecodeLimits
+}
+
+// limitedPeeker is the BytePeeker returned by DecodeLimits.Reader. Nested
+// decoders get it back from GetPeeker, so they share its limits.
+type limitedPeeker struct {
+	BytePeeker
+	limits *DecodeLimits
+}
+
+func (lp *limitedPeeker) DecodeLimits() *DecodeLimits {
+	return lp.limits
+}
+
+// Reader returns a reader of r which decoders read with the limits of l. To
+// buffer the input, wrap r in a bufio.Reader rather than the returned reader,
+// which a bufio.Reader would hide the limits of.
+func (l *DecodeLimits) Reader(r io.Reader) BytePeeker {
+	return &limitedPeeker{BytePeeker: GetPeeker(r), limits: l}
+}
+
+// GetDecodeLimits returns the limits of a reader implementing DecodeLimiter,
+// or nil, whose methods don't enforce anything.
+func GetDecodeLimits(r io.Reader) *DecodeLimits {
+	if dl, ok := r.(DecodeLimiter); ok {
+		return dl.DecodeLimits()
+	}
+	return nil
+}
+
+// Enter counts a nesting level, which Leave uncounts.
+func (l *DecodeLimits) Enter() error {
+	if l == nil {
+		return nil
+	}
+	if l.MaxDepth > 0 && l.depth >= l.MaxDepth {
+		return fmt.Errorf("%w: nesting depth beyond %d", ErrDecodeLimit, l.MaxDepth)
+	}
+	l.depth++
+	return nil
+}
+
+func (l *DecodeLimits) Leave() {
+	if l != nil {
+		l.depth--
+	}
+}
+
+// AddItems counts n array elements or map entries.
+func (l *DecodeLimits) AddItems(n uint64) error {
+	if l == nil {
+		return nil
+	}
+	l.items += n
+	if l.MaxItems > 0 && (l.items > l.MaxItems || l.items < n) {
+		return fmt.Errorf("%w: more than %d items", ErrDecodeLimit, l.MaxItems)
+	}
+	return nil
+}
+
+// AddBytes counts a byte or text string of n bytes.
+func (l *DecodeLimits) AddBytes(n uint64) error {
+	if l == nil {
+		return nil
+	}
+	l.bytes += n
+	if l.MaxBytes > 0 && (l.bytes > l.MaxBytes || l.bytes < n) {
+		return fmt.Errorf("%w: more than %d bytes", ErrDecodeLimit, l.MaxBytes)
+	}
+	return nil
+}
+
+// nesting tracks the items left to read at every nesting level of a CBOR
+// value, for the decoders which read it header by header rather than
+// recursively. Levels are only tracked when its limits enforce a MaxDepth, it
+// counts all the items left as one level otherwise.
+type nesting struct {
+	limits *DecodeLimits
+	// left is the number of items left at the current level, and outer those
+	// of the enclosing levels.
+	left  uint64
+	outer []uint64
+}
+
+func newNesting(l *DecodeLimits) nesting {
+	return nesting{limits: l, left: 1}
+}
+
+// next reports whether an item is left to read, and counts it as read.
+func (n *nesting) next() bool {
+	for n.left == 0 {
+		if len(n.outer) == 0 {
+			return false
+		}
+		n.left = n.outer[len(n.outer)-1]
+		n.outer = n.outer[:len(n.outer)-1]
+		n.limits.Leave()
+	}
+	n.left--
+	return true
+}
+
+// tagged counts the item following a tag.
+func (n *nesting) tagged() {
+	n.left++
+}
+
+// open counts an array or map of the given entries, made of items.
+func (n *nesting) open(entries, items uint64) error {
+	if err := n.limits.AddItems(entries); err != nil {
+		return err
+	}
+	if n.limits == nil || n.limits.MaxDepth == 0 {
+		n.left += items
+		return nil
+	}
+	if err := n.limits.Enter(); err != nil {
+		return err
+	}
+	n.outer = append(n.outer, n.left)
+	n.left = items
+	return nil
+}
+
+// close leaves the levels still open when the value wasn't read to its end.
+func (n *nesting) close() {
+	for range n.outer {
+		n.limits.Leave()
+	}
+	n.outer = nil
+}
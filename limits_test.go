@@ -0,0 +1,133 @@
+package typegen
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"io"
+	"testing"
+
+	"github.com/ipfs/go-cid"
+)
+
+func TestValidateCBORWithLimits(t *testing.T) {
+	// [[["abc"]], {"k": [1, 2]}] nests 3 deep, with 7 items and 4 bytes.
+	nested := ListNode{ListNode{ListNode{StringNode("abc")}}, MapNode{{Key: "k", Value: ListNode{NewIntNode(1), NewIntNode(2)}}}}
+	buf := new(bytes.Buffer)
+	if _, err := EncodeAny(buf, nested); err != nil {
+		t.Fatal(err)
+	}
+	b := buf.Bytes()
+
+	walkers := map[string]func(*DecodeLimits) error{
+		"ValidateCBORWithLimits": func(l *DecodeLimits) error {
+			return ValidateCBORWithLimits(b, l)
+		},
+		"DiagnoseWithLimits": func(l *DecodeLimits) error {
+			_, err := DiagnoseWithLimits(b, l)
+			return err
+		},
+		"DecodeAny": func(l *DecodeLimits) error {
+			_, err := DecodeAny(l.Reader(bytes.NewReader(b)))
+			return err
+		},
+		"WalkLinks": func(l *DecodeLimits) error {
+			_, err := WalkLinks(l.Reader(bytes.NewReader(b)), func([]string, cid.Cid) error { return nil })
+			return err
+		},
+	}
+
+	for _, tc := range []struct {
+		limits DecodeLimits
+		ok     bool
+	}{
+		{DecodeLimits{}, true},
+		{DecodeLimits{MaxDepth: 3, MaxItems: 7, MaxBytes: 4}, true},
+		{DecodeLimits{MaxDepth: 2}, false},
+		{DecodeLimits{MaxItems: 6}, false},
+		{DecodeLimits{MaxBytes: 3}, false},
+	} {
+		for name, walk := range walkers {
+			limits := tc.limits
+			err := walk(&limits)
+			if tc.ok && err != nil {
+				t.Errorf("%s %+v: %v", name, tc.limits, err)
+			} else if !tc.ok && !errors.Is(err, ErrDecodeLimit) {
+				t.Errorf("%s %+v: expected a decode limit error, got %v", name, tc.limits, err)
+			}
+			if limits.depth != 0 {
+				t.Errorf("%s %+v: depth left at %d", name, tc.limits, limits.depth)
+			}
+		}
+	}
+}
+
+// limitedReader hides the BytePeeker methods of the reader it wraps, but
+// forwards its limits.
+type limitedReader struct {
+	r io.Reader
+}
+
+func (lr limitedReader) Read(b []byte) (int, error) {
+	return lr.r.Read(b)
+}
+
+func (lr limitedReader) DecodeLimits() *DecodeLimits {
+	return GetDecodeLimits(lr.r)
+}
+
+func TestWrappedLimits(t *testing.T) {
+	b := append(bytes.Repeat([]byte{0x81}, 10), 0x00)
+
+	for name, read := range map[string]func(*DecodeLimits) error{
+		"SeqReader": func(l *DecodeLimits) error {
+			_, err := NewSeqReader(l.Reader(bytes.NewReader(b))).Next()
+			return err
+		},
+		"buffered input": func(l *DecodeLimits) error {
+			var d Deferred
+			_, err := d.UnmarshalCBOR(l.Reader(bufio.NewReader(bytes.NewReader(b))))
+			return err
+		},
+		"forwarding reader": func(l *DecodeLimits) error {
+			var d Deferred
+			_, err := d.UnmarshalCBOR(limitedReader{l.Reader(bytes.NewReader(b))})
+			return err
+		},
+	} {
+		if err := read(&DecodeLimits{MaxDepth: 10}); err != nil {
+			t.Errorf("%s: %v", name, err)
+		}
+		if err := read(&DecodeLimits{MaxDepth: 2}); !errors.Is(err, ErrDecodeLimit) {
+			t.Errorf("%s: expected a decode limit error, got %v", name, err)
+		}
+	}
+}
+
+func TestDeferredLimits(t *testing.T) {
+	c, err := cid.Parse("bafkqaaa")
+	if err != nil {
+		t.Fatal(err)
+	}
+	buf := new(bytes.Buffer)
+	if _, err := EncodeAny(buf, ListNode{ListNode{LinkNode(c)}}); err != nil {
+		t.Fatal(err)
+	}
+	b := buf.Bytes()
+
+	var d Deferred
+	if _, err := d.UnmarshalCBOR((&DecodeLimits{MaxDepth: 2}).Reader(bytes.NewReader(b))); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := d.UnmarshalCBOR((&DecodeLimits{MaxDepth: 1}).Reader(bytes.NewReader(b))); !errors.Is(err, ErrDecodeLimit) {
+		t.Fatalf("expected a decode limit error, got %v", err)
+	}
+
+	var links int
+	if _, err := ScanForLinks((&DecodeLimits{MaxItems: 2}).Reader(bytes.NewReader(b)), func(cid.Cid) { links++ }); err != nil || links != 1 {
+		t.Fatalf("expected a link, got %d and %v", links, err)
+	}
+	if _, err := ScanForLinks((&DecodeLimits{MaxItems: 1}).Reader(bytes.NewReader(b)), func(cid.Cid) {}); !errors.Is(err, ErrDecodeLimit) {
+		t.Fatalf("expected a decode limit error, got %v", err)
+	}
+}
@@ -0,0 +1,223 @@
+package typegen
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/ipfs/go-cid"
+)
+
+// SkipSubtree can be returned by a LinkWalkFunc when called for a map or an
+// array, to skip over its content.
+var SkipSubtree = errors.New("skip this subtree")
+
+// StopWalk can be returned by a LinkWalkFunc to stop the walk early, without
+// WalkLinks returning an error.
+var StopWalk = errors.New("stop the walk")
+
+// LinkWalkFunc is called by WalkLinks for every link, with the path leading to
+// it made of map keys and array indices. It is also called with c set to
+// cid.Undef before descending into every map or array, the root included.
+type LinkWalkFunc func(path []string, c cid.Cid) error
+
+// WalkLinks is like ScanForLinks, but also reports the path of every link in
+// the CBOR object read from br, and lets the callback skip subtrees or stop the
+// walk, see LinkWalkFunc. Map keys that aren't text strings appear in paths in
+// diagnostic notation.
+//
+// The path slice passed to cb is only valid during the call. When the walk is
+// stopped, br is left in the middle of the object. The limits of br are
+// honored, see DecodeLimits.
+func WalkLinks(br io.Reader, cb LinkWalkFunc) (int, error) {
+	scratch := make([]byte, maxCidLength)
+	read, err := walkLinks(br, GetDecodeLimits(br), cb, scratch)
+	if err == StopWalk {
+		err = nil
+	}
+	return read, err
+}
+
+// linkWalkLevel is an array or map being walked by walkLinks.
+type linkWalkLevel struct {
+	isMap bool
+	// next is the index of the next element or entry, out of length.
+	next, length uint64
+}
+
+// walkLinks walks the object header by header, keeping the arrays and maps it
+// is in on a stack rather than recursing, so that deeply nested input can't
+// overflow the goroutine stack.
+func walkLinks(br io.Reader, limits *DecodeLimits, cb LinkWalkFunc, scratch []byte) (int, error) {
+	bytesRead := 0
+	var path []string
+	var levels []linkWalkLevel
+	defer func() {
+		for range levels {
+			limits.Leave()
+		}
+	}()
+
+	for {
+		maj, extra, read, err := CborReadHeaderBuf(br, scratch)
+		bytesRead += read
+		if err != nil {
+			return bytesRead, err
+		}
+		// Tags other than links are transparent.
+		for maj == MajTag && extra != 42 {
+			maj, extra, read, err = CborReadHeaderBuf(br, scratch)
+			bytesRead += read
+			if err != nil {
+				return bytesRead, err
+			}
+		}
+
+		switch maj {
+		case MajUnsignedInt, MajNegativeInt, MajOther:
+		case MajByteString, MajTextString:
+			if err := limits.AddBytes(extra); err != nil {
+				return bytesRead, err
+			}
+			if err := discard(br, int(extra)); err != nil {
+				return bytesRead, err
+			}
+			bytesRead += int(extra)
+		case MajTag:
+			buf, read, err := ReadByteArray(br, maxCidLength)
+			bytesRead += read
+			if err != nil {
+				return bytesRead, err
+			}
+			c, err := bufToCid(buf)
+			if err != nil {
+				return bytesRead, err
+			}
+			if err := cb(path, c); err != nil && err != SkipSubtree {
+				return bytesRead, err
+			}
+		case MajArray, MajMap:
+			if extra > MaxLength {
+				return bytesRead, ErrMaxLength
+			}
+			if err := limits.AddItems(extra); err != nil {
+				return bytesRead, err
+			}
+
+			switch err := cb(path, cid.Undef); err {
+			case nil:
+				if err := limits.Enter(); err != nil {
+					return bytesRead, err
+				}
+				levels = append(levels, linkWalkLevel{isMap: maj == MajMap, length: extra})
+			case SkipSubtree:
+				items := extra
+				if maj == MajMap {
+					items *= 2
+				}
+				for i := uint64(0); i < items; i++ {
+					read, err := ScanForLinks(br, func(cid.Cid) {})
+					bytesRead += read
+					if err != nil {
+						return bytesRead, err
+					}
+				}
+			default:
+				return bytesRead, err
+			}
+		default:
+			return bytesRead, fmt.Errorf("unhandled cbor type: %d", maj)
+		}
+
+		// Move on to the next element or entry, leaving the arrays and maps
+		// read to their end.
+		for len(levels) > 0 && levels[len(levels)-1].next == levels[len(levels)-1].length {
+			levels = levels[:len(levels)-1]
+			limits.Leave()
+		}
+		if len(levels) == 0 {
+			return bytesRead, nil
+		}
+		level := &levels[len(levels)-1]
+
+		var seg string
+		if level.isMap {
+			var key Deferred
+			read, err := key.UnmarshalCBOR(br)
+			bytesRead += read
+			if err != nil {
+				return bytesRead, err
+			}
+			if seg, err = linkPathSegment(key.Raw); err != nil {
+				return bytesRead, err
+			}
+		} else {
+			seg = strconv.FormatUint(level.next, 10)
+		}
+		level.next++
+		path = append(path[:len(levels)-1], seg)
+	}
+}
+
+// linkPathSegment returns the path segment for the encoded map key raw.
+func linkPathSegment(raw []byte) (string, error) {
+	if len(raw) > 0 && raw[0]>>5 == MajTextString {
+		s, _, err := ReadString(bytes.NewReader(raw))
+		return s, err
+	}
+	return Diagnose(raw)
+}
+
+// LinkIterator is implemented by the generated types.
+type LinkIterator interface {
+	// ForEachLink calls cb for every link held by the value, recursing into
+	// nested structs, slices and maps. Paths are made of the map keys and tuple
+	// indices of the CBOR encoding, joined by slashes, so they match the paths
+	// reported by WalkLinks on the encoded value. An error returned by cb stops
+	// the iteration and is returned.
+	ForEachLink(cb func(path string, c cid.Cid) error) error
+}
+
+// ForEachLinkIn calls cb for every link held by v, if it is a LinkIterator or
+// a *Deferred, with prefix prepended to the reported paths. The generated
+// ForEachLink methods use it to recurse into fields.
+func ForEachLinkIn(v interface{}, prefix string, cb func(path string, c cid.Cid) error) error {
+	switch v := v.(type) {
+	case *Deferred:
+		if v == nil || len(v.Raw) == 0 {
+			return nil
+		}
+		var cbErr error
+		_, err := WalkLinks(bytes.NewReader(v.Raw), func(path []string, c cid.Cid) error {
+			if !c.Defined() {
+				return nil
+			}
+			if cbErr = cb(joinLinkPath(prefix, strings.Join(path, "/")), c); cbErr != nil {
+				return StopWalk
+			}
+			return nil
+		})
+		if cbErr != nil {
+			return cbErr
+		}
+		return err
+	case LinkIterator:
+		return v.ForEachLink(func(path string, c cid.Cid) error {
+			return cb(joinLinkPath(prefix, path), c)
+		})
+	}
+	return nil
+}
+
+func joinLinkPath(prefix, path string) string {
+	if path == "" {
+		return prefix
+	}
+	if prefix == "" {
+		return path
+	}
+	return prefix + "/" + path
+}
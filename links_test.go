@@ -0,0 +1,148 @@
+package typegen
+
+import (
+	"bytes"
+	"encoding/hex"
+	"strings"
+	"testing"
+
+	"github.com/ipfs/go-cid"
+)
+
+func TestWalkLinks(t *testing.T) {
+	c, err := cid.Parse("bafkqaaa")
+	if err != nil {
+		t.Fatal(err)
+	}
+	nd := MapNode{
+		{Key: "a", Value: LinkNode(c)},
+		{Key: "b", Value: ListNode{IntNode{}, MapNode{{Key: "c", Value: LinkNode(c)}}}},
+		{Key: "skip", Value: ListNode{LinkNode(c)}},
+	}
+	buf := new(bytes.Buffer)
+	if _, err := EncodeAny(buf, nd); err != nil {
+		t.Fatal(err)
+	}
+	enc := buf.Bytes()
+
+	var links, containers []string
+	n, err := WalkLinks(bytes.NewReader(enc), func(path []string, lc cid.Cid) error {
+		p := strings.Join(path, "/")
+		if !lc.Defined() {
+			containers = append(containers, p)
+			if p == "skip" {
+				return SkipSubtree
+			}
+			return nil
+		}
+		if lc != c {
+			t.Fatalf("unexpected cid %s", lc)
+		}
+		links = append(links, p)
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n != len(enc) {
+		t.Fatal("returned length does not match the byte length")
+	}
+	if !equalStrings(links, []string{"a", "b/1/c"}) {
+		t.Fatalf("unexpected link paths %v", links)
+	}
+	if !equalStrings(containers, []string{"", "b", "b/1", "skip"}) {
+		t.Fatalf("unexpected container paths %v", containers)
+	}
+
+	// Stop at the first link.
+	links = nil
+	if _, err := WalkLinks(bytes.NewReader(enc), func(path []string, lc cid.Cid) error {
+		if lc.Defined() {
+			links = append(links, strings.Join(path, "/"))
+			return StopWalk
+		}
+		return nil
+	}); err != nil {
+		t.Fatal(err)
+	}
+	if !equalStrings(links, []string{"a"}) {
+		t.Fatalf("unexpected link paths %v", links)
+	}
+}
+
+func TestWalkLinksDeep(t *testing.T) {
+	c, err := cid.Parse("bafkqaaa")
+	if err != nil {
+		t.Fatal(err)
+	}
+	buf := new(bytes.Buffer)
+	if _, err := EncodeAny(buf, LinkNode(c)); err != nil {
+		t.Fatal(err)
+	}
+	const depth = 1 << 20
+	enc := append(bytes.Repeat([]byte{0x81}, depth), buf.Bytes()...)
+
+	var links int
+	n, err := WalkLinks(bytes.NewReader(enc), func(path []string, lc cid.Cid) error {
+		if lc.Defined() {
+			if len(path) != depth {
+				t.Fatalf("unexpected path length %d", len(path))
+			}
+			links++
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n != len(enc) || links != 1 {
+		t.Fatalf("read %d bytes and %d links", n, links)
+	}
+
+	d := &Deferred{Raw: enc}
+	if err := ForEachLinkIn(d, "", func(string, cid.Cid) error {
+		links++
+		return nil
+	}); err != nil || links != 2 {
+		t.Fatalf("expected a link, got %v", err)
+	}
+}
+
+func TestWalkLinksMatchesScan(t *testing.T) {
+	inp := "82442847c0498ba16131818242000484d82a57000155001266696c2f312f73746f72616765706f776572d82a5827000171a0e40220740d4196aaaee66d8e9b828bc6f9271662096e36782de248e3b3ed28443dbc810040a16131828242000584d82a5818000155001366696c2f312f73746f726167656d61726b6574d82a5827000171a0e402209e59ceb041921650967e8c77d36269c10049140d28d5015165cc8eb897a2555300408242006684d82a52000155000d66696c2f312f6163636f756e74d82a5827000171a0e40220f9556f0d5a735ff53cc327e85a46c7c094028b9da894de73caa88f162429c29d004b000a968163f0a57b400000a16131818242000084d82a51000155000c66696c2f312f73797374656dd82a5827000171a0e4022045b0cfc220ceec5b7c1c62c4d4193d38e4eba48e8815729ce75f9c0ab0e4c1c00040a16131818242006384d82a52000155000d66696c2f312f6163636f756e74d82a5827000171a0e4022045b0cfc220ceec5b7c1c62c4d4193d38e4eba48e8815729ce75f9c0ab0e4c1c00040a16131818242000184d82a4f000155000a66696c2f312f696e6974d82a5827000171a0e4022050f3c45d0e78f04688c6e8cbdc45f71a5cbcec731519ffdcdd92765fc5ba0da30040a16131818242000684d82a581b000155001666696c2f312f76657269666965647265676973747279d82a5827000171a0e40220fd3fee39acd88c8808110d9741149a79939f52c798b6539048e4835aa4d34fd50040a16131818242006584d82a52000155000d66696c2f312f6163636f756e74d82a5827000171a0e402200293716d8503737644624c102d9ba1514d599044a2e6f2038330124bc6f54361004c002116545850052128000000a16131818242000384d82a4f000155000a66696c2f312f63726f6ed82a5827000171a0e4022065d1dad76492ccd5d010197dc26bd5fb07c0cf85ccdcaff21084c0d47bfd17590040a16131818242005084d82a52000155000d66696c2f312f6163636f756e74d82a5827000171a0e402202e12f4eedaac06c2040df4923656f7f2d6c5991b1874a32e9f52b0e48e61d8410040a16131818242006484d82a52000155000d66696c2f312f6163636f756e74d82a5827000171a0e40220bde06af1782cb302e0973658dcb44b2cbd72891598b7a7b02381b563f1dc9c57004c002116545850052128000000a16131818242000284d82a51000155000c66696c2f312f726577617264d82a5827000171a0e4022083c127ddb0ba85f585b06365346eabe5ef98861d85770670dee402dc3810131e004d0004860d8812f0b38878000000"
+	inpb, err := hex.DecodeString(inp)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var scanned, walked []cid.Cid
+	if _, err := ScanForLinks(bytes.NewReader(inpb), func(c cid.Cid) {
+		scanned = append(scanned, c)
+	}); err != nil {
+		t.Fatal(err)
+	}
+	var paths []string
+	if n, err := WalkLinks(bytes.NewReader(inpb), func(path []string, c cid.Cid) error {
+		if c.Defined() {
+			walked = append(walked, c)
+			paths = append(paths, strings.Join(path, "/"))
+		}
+		return nil
+	}); err != nil {
+		t.Fatal(err)
+	} else if n != len(inpb) {
+		t.Fatal("returned length does not match the byte length")
+	}
+
+	if len(scanned) != len(walked) {
+		t.Fatalf("found %d links while scanning, %d while walking", len(scanned), len(walked))
+	}
+	for i := range scanned {
+		if scanned[i] != walked[i] {
+			t.Fatalf("link %d differs: %s != %s", i, scanned[i], walked[i])
+		}
+	}
+	if !equalStrings(paths[:3], []string{"1/0/1/0/1/0", "1/0/1/0/1/1", "1/1/1/0/1/0"}) {
+		t.Fatalf("unexpected link paths %v", paths)
+	}
+}
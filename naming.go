@@ -0,0 +1,82 @@
+package typegen
+
+import (
+	"reflect"
+	"strings"
+	"unicode"
+)
+
+// SnakeCase is a Gen.KeyNaming strategy turning Go field names into
+// snake_case, e.g. HTTPServerID into http_server_id.
+func SnakeCase(name string) string {
+	words := splitWords(name)
+	for i, w := range words {
+		words[i] = strings.ToLower(w)
+	}
+	return strings.Join(words, "_")
+}
+
+// CamelCase is a Gen.KeyNaming strategy turning Go field names into
+// camelCase, e.g. HTTPServerID into httpServerId.
+func CamelCase(name string) string {
+	words := splitWords(name)
+	for i, w := range words {
+		w = strings.ToLower(w)
+		if i > 0 {
+			r := []rune(w)
+			r[0] = unicode.ToUpper(r[0])
+			w = string(r)
+		}
+		words[i] = w
+	}
+	return strings.Join(words, "")
+}
+
+// LowerCase is a Gen.KeyNaming strategy turning Go field names into lower
+// case, e.g. HTTPServerID into httpserverid.
+func LowerCase(name string) string {
+	return strings.ToLower(name)
+}
+
+// splitWords splits a Go identifier into words, at lower case letters or
+// digits followed by an upper case letter, and before the last upper case
+// letter of an acronym followed by a lower case letter.
+func splitWords(name string) []string {
+	r := []rune(name)
+	var words []string
+	start := 0
+	for i := 1; i < len(r); i++ {
+		if !unicode.IsUpper(r[i]) {
+			continue
+		}
+		prev := r[i-1]
+		acronymEnd := unicode.IsUpper(prev) && i+1 < len(r) && unicode.IsLower(r[i+1])
+		if unicode.IsLower(prev) || unicode.IsDigit(prev) || acronymEnd {
+			words = append(words, string(r[start:i]))
+			start = i
+		}
+	}
+	return append(words, string(r[start:]))
+}
+
+// mapKeyOptions are the options of the generator deriving the map keys of
+// fields without a key in their cborgen tag, see Gen.JSONTagKeys and
+// Gen.KeyNaming.
+type mapKeyOptions struct {
+	jsonTags bool
+	naming   func(string) string
+}
+
+// mapKey returns the map key of f, before its cborgen tag is applied.
+func (o mapKeyOptions) mapKey(f reflect.StructField) string {
+	if o.jsonTags {
+		name := strings.Split(f.Tag.Get("json"), ",")[0]
+		if name != "" && name != "-" {
+			return name
+		}
+	}
+	if o.naming != nil {
+		return o.naming(f.Name)
+	}
+	return f.Name
+}
@@ -0,0 +1,55 @@
+package typegen
+
+import "testing"
+
+func TestKeyNaming(t *testing.T) {
+	for _, tc := range []struct {
+		name, snake, camel, lower string
+	}{
+		{"Name", "name", "name", "name"},
+		{"UserID", "user_id", "userId", "userid"},
+		{"HTTPServerID", "http_server_id", "httpServerId", "httpserverid"},
+		{"Field2Name", "field2_name", "field2Name", "field2name"},
+		{"ID", "id", "id", "id"},
+	} {
+		if got := SnakeCase(tc.name); got != tc.snake {
+			t.Errorf("SnakeCase(%q) = %q, want %q", tc.name, got, tc.snake)
+		}
+		if got := CamelCase(tc.name); got != tc.camel {
+			t.Errorf("CamelCase(%q) = %q, want %q", tc.name, got, tc.camel)
+		}
+		if got := LowerCase(tc.name); got != tc.lower {
+			t.Errorf("LowerCase(%q) = %q, want %q", tc.name, got, tc.lower)
+		}
+	}
+}
+
+func TestJSONTagKeys(t *testing.T) {
+	type named struct {
+		UserID  string `json:"uid,omitempty"`
+		Tagged  string `json:"tagged" cborgen:"t"`
+		Skipped string `json:"-"`
+		Options string `json:",omitempty" cborgen:",optional"`
+	}
+	for _, tc := range []struct {
+		g    Gen
+		keys []string
+	}{
+		{Gen{}, []string{"t", "UserID", "Options", "Skipped"}},
+		{Gen{JSONTagKeys: true}, []string{"t", "uid", "Options", "Skipped"}},
+		{Gen{KeyNaming: SnakeCase}, []string{"t", "user_id", "options", "skipped"}},
+		{Gen{JSONTagKeys: true, KeyNaming: CamelCase}, []string{"t", "uid", "options", "skipped"}},
+	} {
+		s, err := tc.g.MapSchema(named{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		var keys []string
+		for _, f := range s.Fields {
+			keys = append(keys, f.Key)
+		}
+		if !equalStrings(keys, tc.keys) {
+			t.Errorf("unexpected keys %q, want %q", keys, tc.keys)
+		}
+	}
+}
@@ -0,0 +1,452 @@
+package typegen
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"math"
+	"sort"
+	"unicode/utf8"
+
+	"github.com/ipfs/go-cid"
+	"golang.org/x/xerrors"
+)
+
+// Kind is the kind of a Node in a generic data model tree.
+type Kind uint8
+
+const (
+	KindNull Kind = iota
+	KindBool
+	KindInt
+	KindFloat
+	KindString
+	KindBytes
+	KindList
+	KindMap
+	KindLink
+)
+
+func (k Kind) String() string {
+	switch k {
+	case KindNull:
+		return "null"
+	case KindBool:
+		return "bool"
+	case KindInt:
+		return "int"
+	case KindFloat:
+		return "float"
+	case KindString:
+		return "string"
+	case KindBytes:
+		return "bytes"
+	case KindList:
+		return "list"
+	case KindMap:
+		return "map"
+	case KindLink:
+		return "link"
+	default:
+		return fmt.Sprintf("Kind(%d)", uint8(k))
+	}
+}
+
+// Node is a value in a generic data model tree, as produced by DecodeAny and
+// consumed by EncodeAny. It is one of NullNode, BoolNode, IntNode, FloatNode,
+// StringNode, BytesNode, ListNode, MapNode or LinkNode.
+type Node interface {
+	Kind() Kind
+}
+
+type NullNode struct{}
+
+type BoolNode bool
+
+// IntNode is a CBOR integer. CBOR integers range from -2^64 to 2^64-1, so the
+// value is kept as it is on the wire: if Negative is set the integer is
+// -1-Value, otherwise it is Value.
+type IntNode struct {
+	Negative bool
+	Value    uint64
+}
+
+type FloatNode float64
+
+type StringNode string
+
+type BytesNode []byte
+
+type ListNode []Node
+
+// MapNode is a map with string keys. Entries are kept in the order they were
+// decoded, EncodeAny sorts them canonically.
+type MapNode []MapEntry
+
+type MapEntry struct {
+	Key   string
+	Value Node
+}
+
+type LinkNode cid.Cid
+
+func (NullNode) Kind() Kind   { return KindNull }
+func (BoolNode) Kind() Kind   { return KindBool }
+func (IntNode) Kind() Kind    { return KindInt }
+func (FloatNode) Kind() Kind  { return KindFloat }
+func (StringNode) Kind() Kind { return KindString }
+func (BytesNode) Kind() Kind  { return KindBytes }
+func (ListNode) Kind() Kind   { return KindList }
+func (MapNode) Kind() Kind    { return KindMap }
+func (LinkNode) Kind() Kind   { return KindLink }
+
+func NewIntNode(v int64) IntNode {
+	if v < 0 {
+		return IntNode{Negative: true, Value: uint64(-(v + 1))}
+	}
+	return IntNode{Value: uint64(v)}
+}
+
+// Int64 returns the integer as an int64, and false if it doesn't fit.
+func (i IntNode) Int64() (int64, bool) {
+	if i.Value > math.MaxInt64 {
+		return 0, false
+	}
+	if i.Negative {
+		return -1 - int64(i.Value), true
+	}
+	return int64(i.Value), true
+}
+
+// Uint64 returns the integer as an uint64, and false if it is negative.
+func (i IntNode) Uint64() (uint64, bool) {
+	if i.Negative {
+		return 0, false
+	}
+	return i.Value, true
+}
+
+// Lookup returns the value stored under key, if any.
+func (m MapNode) Lookup(key string) (Node, bool) {
+	for _, e := range m {
+		if e.Key == key {
+			return e.Value, true
+		}
+	}
+	return nil, false
+}
+
+// MaxNestingDepth is the maximum nesting depth of the arrays and maps decoded
+// into a generic data model tree, which is built recursively.
+const MaxNestingDepth = 1024
+
+var errNestingDepth = fmt.Errorf("%w: nesting depth beyond %d", ErrDecodeLimit, MaxNestingDepth)
+
+// DecodeAny decodes a single CBOR object into a generic data model tree,
+// without needing a Go type for it. Only tag 42 (links) is supported, map
+// keys must be text strings and text strings must be valid UTF-8. The limits of r are honored, see DecodeLimits.
+func DecodeAny(r io.Reader) (Node, error) {
+	br := GetPeeker(r)
+	scratch := make([]byte, maxHeaderSize)
+	nd, _, err := decodeAny(br, GetDecodeLimits(br), scratch, 0)
+	return nd, err
+}
+
+func decodeAny(br BytePeeker, limits *DecodeLimits, scratch []byte, depth int) (Node, int, error) {
+	bytesRead := 0
+
+	maj, extra, f, isFloat, read, err := readHeaderOrFloat(br, scratch)
+	if err != nil {
+		return nil, bytesRead, err
+	}
+	bytesRead += read
+
+	if isFloat {
+		return FloatNode(f), bytesRead, nil
+	}
+
+	switch maj {
+	case MajUnsignedInt:
+		return IntNode{Value: extra}, bytesRead, nil
+	case MajNegativeInt:
+		return IntNode{Negative: true, Value: extra}, bytesRead, nil
+	case MajByteString, MajTextString:
+		if extra > ByteArrayMaxLen {
+			return nil, bytesRead, ErrMaxLength
+		}
+		if err := limits.AddBytes(extra); err != nil {
+			return nil, bytesRead, err
+		}
+		buf := make([]byte, extra)
+		if read, err := io.ReadFull(br, buf); err != nil {
+			return nil, bytesRead + read, err
+		} else {
+			bytesRead += read
+		}
+		if maj == MajTextString {
+			if !utf8.Valid(buf) {
+				return nil, bytesRead, ErrInvalidUTF8
+			}
+			return StringNode(buf), bytesRead, nil
+		}
+		return BytesNode(buf), bytesRead, nil
+	case MajArray:
+		if extra > MaxLength {
+			return nil, bytesRead, ErrMaxLength
+		}
+		if depth >= MaxNestingDepth {
+			return nil, bytesRead, errNestingDepth
+		}
+		if err := limits.AddItems(extra); err != nil {
+			return nil, bytesRead, err
+		}
+		if err := limits.Enter(); err != nil {
+			return nil, bytesRead, err
+		}
+		defer limits.Leave()
+		l := make(ListNode, 0, extra)
+		for i := uint64(0); i < extra; i++ {
+			nd, read, err := decodeAny(br, limits, scratch, depth+1)
+			bytesRead += read
+			if err != nil {
+				return nil, bytesRead, err
+			}
+			l = append(l, nd)
+		}
+		return l, bytesRead, nil
+	case MajMap:
+		if extra > MaxLength {
+			return nil, bytesRead, ErrMaxLength
+		}
+		if depth >= MaxNestingDepth {
+			return nil, bytesRead, errNestingDepth
+		}
+		if err := limits.AddItems(extra); err != nil {
+			return nil, bytesRead, err
+		}
+		if err := limits.Enter(); err != nil {
+			return nil, bytesRead, err
+		}
+		defer limits.Leave()
+		m := make(MapNode, 0, extra)
+		seen := make(map[string]struct{}, extra)
+		for i := uint64(0); i < extra; i++ {
+			k, read, err := ReadStringBuf(br, scratch)
+			bytesRead += read
+			if err != nil {
+				return nil, bytesRead, xerrors.Errorf("reading map key: %w", err)
+			}
+			if !utf8.ValidString(k) {
+				return nil, bytesRead, xerrors.Errorf("reading map key: %w", ErrInvalidUTF8)
+			}
+			if _, ok := seen[k]; ok {
+				return nil, bytesRead, fmt.Errorf("duplicate map key %q", k)
+			}
+			seen[k] = struct{}{}
+
+			v, read, err := decodeAny(br, limits, scratch, depth+1)
+			bytesRead += read
+			if err != nil {
+				return nil, bytesRead, err
+			}
+			m = append(m, MapEntry{Key: k, Value: v})
+		}
+		return m, bytesRead, nil
+	case MajTag:
+		if extra != 42 {
+			return nil, bytesRead, fmt.Errorf("unsupported cbor tag %d", extra)
+		}
+		buf, read, err := ReadByteArray(br, 512)
+		bytesRead += read
+		if err != nil {
+			return nil, bytesRead, err
+		}
+		c, err := bufToCid(buf)
+		if err != nil {
+			return nil, bytesRead, err
+		}
+		return LinkNode(c), bytesRead, nil
+	case MajOther:
+		switch extra {
+		case 20:
+			return BoolNode(false), bytesRead, nil
+		case 21:
+			return BoolNode(true), bytesRead, nil
+		case 22:
+			return NullNode{}, bytesRead, nil
+		default:
+			return nil, bytesRead, fmt.Errorf("unsupported cbor simple value %d", extra)
+		}
+	default:
+		return nil, bytesRead, fmt.Errorf("unhandled cbor type: %d", maj)
+	}
+}
+
+// readHeaderOrFloat reads a CBOR header like CborReadHeaderBuf does, except
+// that floating point values are decoded and returned in f, with isFloat set.
+// CborReadHeaderBuf can't be used for floats as it rejects most of them as
+// non-canonical integers.
+func readHeaderOrFloat(br BytePeeker, scratch []byte) (maj byte, extra uint64, f float64, isFloat bool, read int, err error) {
+	first, err := br.ReadByte()
+	if err != nil {
+		return 0, 0, 0, false, 0, err
+	}
+
+	var size int
+	switch first {
+	case 0xf9:
+		size = 2
+	case 0xfa:
+		size = 4
+	case 0xfb:
+		size = 8
+	default:
+		if err := br.UnreadByte(); err != nil {
+			return 0, 0, 0, false, 0, err
+		}
+		maj, extra, read, err = CborReadHeaderBuf(br, scratch)
+		return maj, extra, 0, false, read, err
+	}
+
+	read = 1
+	if n, err := io.ReadFull(br, scratch[:size]); err != nil {
+		return 0, 0, 0, false, read + n, err
+	}
+	read += size
+
+	switch size {
+	case 2:
+		f = float64(float16ToFloat32(binary.BigEndian.Uint16(scratch[:2])))
+	case 4:
+		f = float64(math.Float32frombits(binary.BigEndian.Uint32(scratch[:4])))
+	default:
+		f = math.Float64frombits(binary.BigEndian.Uint64(scratch[:8]))
+	}
+	return MajOther, 0, f, true, read, nil
+}
+
+// float16ToFloat32 converts an IEEE 754 half-precision float to a float32.
+func float16ToFloat32(h uint16) float32 {
+	sign := uint32(h>>15) << 31
+	exp := uint32(h>>10) & 0x1f
+	frac := uint32(h) & 0x3ff
+
+	switch exp {
+	case 0:
+		// Zero or subnormal, which is normal as a float32.
+		f := float32(frac) / (1 << 24)
+		if sign != 0 {
+			f = -f
+		}
+		return f
+	case 0x1f:
+		// Infinity or NaN.
+		return math.Float32frombits(sign | 0x7f800000 | frac<<13)
+	default:
+		return math.Float32frombits(sign | (exp+127-15)<<23 | frac<<13)
+	}
+}
+
+// EncodeAny canonically encodes a generic data model tree as produced by
+// DecodeAny: integers and lengths use their shortest form, floats are always
+// 64 bits wide and map keys are sorted according to RFC7049.
+func EncodeAny(w io.Writer, nd Node) (n int, err error) {
+	scratch := make([]byte, maxHeaderSize)
+	return encodeAny(w, nd, scratch)
+}
+
+func encodeAny(w io.Writer, nd Node, scratch []byte) (n int, err error) {
+	switch nd := nd.(type) {
+	case nil, NullNode:
+		return w.Write(CborNull)
+	case BoolNode:
+		return WriteBool(w, bool(nd))
+	case IntNode:
+		if nd.Negative {
+			return WriteMajorTypeHeaderBuf(scratch, w, MajNegativeInt, nd.Value)
+		}
+		return WriteMajorTypeHeaderBuf(scratch, w, MajUnsignedInt, nd.Value)
+	case FloatNode:
+		scratch[0] = 0xfb
+		binary.BigEndian.PutUint64(scratch[1:9], math.Float64bits(float64(nd)))
+		return w.Write(scratch[:9])
+	case StringNode:
+		if n_, err := WriteMajorTypeHeaderBuf(scratch, w, MajTextString, uint64(len(nd))); err != nil {
+			return n + n_, err
+		} else {
+			n += n_
+		}
+		if n_, err := io.WriteString(w, string(nd)); err != nil {
+			return n + n_, err
+		} else {
+			n += n_
+		}
+		return n, nil
+	case BytesNode:
+		if n_, err := WriteMajorTypeHeaderBuf(scratch, w, MajByteString, uint64(len(nd))); err != nil {
+			return n + n_, err
+		} else {
+			n += n_
+		}
+		if n_, err := w.Write(nd); err != nil {
+			return n + n_, err
+		} else {
+			n += n_
+		}
+		return n, nil
+	case ListNode:
+		if n_, err := WriteMajorTypeHeaderBuf(scratch, w, MajArray, uint64(len(nd))); err != nil {
+			return n + n_, err
+		} else {
+			n += n_
+		}
+		for _, v := range nd {
+			if n_, err := encodeAny(w, v, scratch); err != nil {
+				return n + n_, err
+			} else {
+				n += n_
+			}
+		}
+		return n, nil
+	case MapNode:
+		entries := make([]MapEntry, len(nd))
+		copy(entries, nd)
+		sort.Slice(entries, func(i, j int) bool {
+			return mapKeySort_RFC7049Less(entries[i].Key, entries[j].Key)
+		})
+		for i := 1; i < len(entries); i++ {
+			if entries[i].Key == entries[i-1].Key {
+				return n, fmt.Errorf("duplicate map key %q", entries[i].Key)
+			}
+		}
+
+		if n_, err := WriteMajorTypeHeaderBuf(scratch, w, MajMap, uint64(len(entries))); err != nil {
+			return n + n_, err
+		} else {
+			n += n_
+		}
+		for _, e := range entries {
+			if n_, err := encodeAny(w, StringNode(e.Key), scratch); err != nil {
+				return n + n_, err
+			} else {
+				n += n_
+			}
+			if n_, err := encodeAny(w, e.Value, scratch); err != nil {
+				return n + n_, err
+			} else {
+				n += n_
+			}
+		}
+		return n, nil
+	case LinkNode:
+		return WriteCidBuf(scratch, w, cid.Cid(nd))
+	default:
+		return 0, fmt.Errorf("unsupported node type %T", nd)
+	}
+}
+
+// Node decodes the deferred CBOR into a generic data model tree.
+func (d *Deferred) Node() (Node, error) {
+	return DecodeAny(bytes.NewReader(d.Raw))
+}
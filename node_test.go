@@ -0,0 +1,132 @@
+package typegen
+
+import (
+	"bytes"
+	"encoding/hex"
+	"errors"
+	"testing"
+
+	"github.com/ipfs/go-cid"
+)
+
+func TestNodeRoundTrip(t *testing.T) {
+	c, err := cid.Parse("bafkqaaa")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	nd := MapNode{
+		{Key: "list", Value: ListNode{NewIntNode(-3), IntNode{Value: 1 << 63}, FloatNode(1.5)}},
+		{Key: "a", Value: StringNode("hello")},
+		{Key: "bytes", Value: BytesNode{1, 2, 3}},
+		{Key: "link", Value: LinkNode(c)},
+		{Key: "null", Value: NullNode{}},
+		{Key: "yes", Value: BoolNode(true)},
+	}
+
+	buf := new(bytes.Buffer)
+	if n, err := EncodeAny(buf, nd); err != nil {
+		t.Fatal(err)
+	} else if n != buf.Len() {
+		t.Fatal("returned length does not match the byte length")
+	}
+	enc := buf.Bytes()
+
+	if err := ValidateCBOR(enc); err != nil {
+		t.Fatal(err)
+	}
+
+	out, err := DecodeAny(bytes.NewReader(enc))
+	if err != nil {
+		t.Fatal(err)
+	}
+	m, ok := out.(MapNode)
+	if !ok {
+		t.Fatalf("expected a map, got %s", out.Kind())
+	}
+	// Keys come back in canonical order.
+	var keys []string
+	for _, e := range m {
+		keys = append(keys, e.Key)
+	}
+	if want := []string{"a", "yes", "link", "list", "null", "bytes"}; !equalStrings(keys, want) {
+		t.Fatalf("unexpected key order %v, expected %v", keys, want)
+	}
+	if v, _ := m.Lookup("link"); v.(LinkNode) != LinkNode(c) {
+		t.Fatal("link didn't round trip")
+	}
+	if v, _ := m.Lookup("list"); v.(ListNode)[0].(IntNode) != NewIntNode(-3) {
+		t.Fatal("negative int didn't round trip")
+	}
+
+	nbuf := new(bytes.Buffer)
+	if _, err := EncodeAny(nbuf, out); err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(nbuf.Bytes(), enc) {
+		t.Fatalf("encodings different: %x != %x", nbuf.Bytes(), enc)
+	}
+}
+
+func TestDecodeAnyFloats(t *testing.T) {
+	for in, expected := range map[string]float64{
+		"f93e00":             1.5,
+		"f9c400":             -4,
+		"fa47c35000":         100000,
+		"fb3ff199999999999a": 1.1,
+	} {
+		b, _ := hex.DecodeString(in)
+		nd, err := DecodeAny(bytes.NewReader(b))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if f, ok := nd.(FloatNode); !ok || float64(f) != expected {
+			t.Fatalf("decoding %s: expected %v, got %#v", in, expected, nd)
+		}
+	}
+}
+
+func TestDecodeAnyErrors(t *testing.T) {
+	for _, in := range []string{
+		"",               // nothing
+		"a1",             // truncated map
+		"a10102",         // integer map key
+		"c100",           // unsupported tag
+		"a2616100616100", // duplicate key
+		"61d8",           // invalid utf-8
+		"a161d800",       // invalid utf-8 map key
+	} {
+		b, _ := hex.DecodeString(in)
+		if _, err := DecodeAny(bytes.NewReader(b)); err == nil {
+			t.Fatalf("expected an error decoding %q", in)
+		}
+	}
+}
+
+func TestDecodeAnyDepth(t *testing.T) {
+	nested := func(depth int) []byte {
+		return append(bytes.Repeat([]byte{0x81}, depth), 0x00)
+	}
+	if _, err := DecodeAny(bytes.NewReader(nested(MaxNestingDepth))); err != nil {
+		t.Fatal(err)
+	}
+	// Deeper input must fail rather than overflow the stack.
+	if _, err := DecodeAny(bytes.NewReader(nested(1 << 20))); !errors.Is(err, ErrDecodeLimit) {
+		t.Fatalf("expected a decode limit error, got %v", err)
+	}
+	if err := CBORToDagJSON(new(bytes.Buffer), bytes.NewReader(nested(MaxNestingDepth+1))); !errors.Is(err, ErrDecodeLimit) {
+		t.Fatalf("expected a decode limit error, got %v", err)
+	}
+}
+
+func equalStrings(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
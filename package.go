@@ -75,7 +75,7 @@ func ImportsForType(currPkg string, t reflect.Type) []Import {
 		return dedupImports(append(ImportsForType(currPkg, t.Key()), ImportsForType(currPkg, t.Elem())...))
 	default:
 		path := t.PkgPath()
-		if path == "" || path == currPkg {
+		if path == "" || path == currPkg || typeParamIndex(t) >= 0 {
 			// built-in or in current package.
 			return nil
 		}
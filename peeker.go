@@ -2,7 +2,10 @@ package typegen
 
 import (
 	"bufio"
+	"encoding/binary"
+	"fmt"
 	"io"
+	"math"
 )
 
 // BytePeeker combines the Reader and ByteScanner interfaces.
@@ -18,9 +21,17 @@ func GetPeeker(r io.Reader) BytePeeker {
 	return &peeker{reader: r}
 }
 
-// peeker is a non-buffering BytePeeker.
+// peeker is a BytePeeker which buffers just enough to look ahead at a CBOR
+// header. It never reads from the underlying reader past what its callers
+// asked for, so it doesn't over-read past the end of the current item and can
+// be used on a CBOR sequence.
 type peeker struct {
-	reader    io.Reader
+	reader io.Reader
+
+	// buf[start:end] holds the bytes read from reader but not consumed yet.
+	buf        [maxHeaderSize]byte
+	start, end int
+
 	peekState int
 	lastByte  byte
 }
@@ -28,23 +39,38 @@ type peeker struct {
 const (
 	peekEmpty = iota
 	peekSet
-	peekUnread
 )
 
+// Reset discards any buffered data and makes the peeker read from r, so
+// peekers can be pooled.
+func (p *peeker) Reset(r io.Reader) {
+	*p = peeker{reader: r}
+}
+
+func (p *peeker) buffered() int {
+	return p.end - p.start
+}
+
+// DecodeLimits forwards the limits of the underlying reader, see
+// DecodeLimiter.
+func (p *peeker) DecodeLimits() *DecodeLimits {
+	return GetDecodeLimits(p.reader)
+}
+
 func (p *peeker) Read(buf []byte) (n int, err error) {
 	// Read "nothing". I.e., read an error, maybe.
 	if len(buf) == 0 {
-		// There's something pending in the
-		if p.peekState == peekUnread {
+		// There's something pending in the buffer.
+		if p.buffered() > 0 {
 			return 0, nil
 		}
 		return p.reader.Read(nil)
 	}
 
-	if p.peekState == peekUnread {
-		buf[0] = p.lastByte
-		n, err = p.reader.Read(buf[1:])
-		n += 1
+	if p.buffered() > 0 {
+		// Don't read further, which could block while we have data.
+		n = copy(buf, p.buf[p.start:p.end])
+		p.start += n
 	} else {
 		n, err = p.reader.Read(buf)
 	}
@@ -56,25 +82,139 @@ func (p *peeker) Read(buf []byte) (n int, err error) {
 }
 
 func (p *peeker) ReadByte() (byte, error) {
-	if p.peekState == peekUnread {
+	if p.buffered() > 0 {
+		b := p.buf[p.start]
+		p.start++
+		p.lastByte = b
 		p.peekState = peekSet
-		return p.lastByte, nil
+		return b, nil
 	}
 	var buf [1]byte
 	n, err := p.reader.Read(buf[:])
 	if n == 0 {
+		if err == nil {
+			err = io.ErrNoProgress
+		}
 		return 0, err
 	}
 	b := buf[0]
 	p.lastByte = b
 	p.peekState = peekSet
-	return b, err
+	return b, nil
 }
 
 func (p *peeker) UnreadByte() error {
 	if p.peekState != peekSet {
 		return bufio.ErrInvalidUnreadByte
 	}
-	p.peekState = peekUnread
+	if p.start > 0 {
+		p.start--
+	} else {
+		// The buffer is empty, see Peek.
+		p.start, p.end = 0, 1
+	}
+	p.buf[p.start] = p.lastByte
+	p.peekState = peekEmpty
 	return nil
 }
+
+// Peek returns the next n bytes without consuming them, reading exactly the
+// bytes that are missing from the underlying reader. n can't be larger than a
+// CBOR header. Like with bufio.Reader, UnreadByte can't be called right after
+// Peek.
+func (p *peeker) Peek(n int) ([]byte, error) {
+	if n > len(p.buf) {
+		return nil, bufio.ErrBufferFull
+	}
+	if p.buffered() == 0 {
+		p.start, p.end = 0, 0
+	} else if len(p.buf)-p.start < n {
+		p.end = copy(p.buf[:], p.buf[p.start:p.end])
+		p.start = 0
+	}
+	p.peekState = peekEmpty
+
+	if missing := n - p.buffered(); missing > 0 {
+		read, err := io.ReadFull(p.reader, p.buf[p.end:p.end+missing])
+		p.end += read
+		if err != nil {
+			return p.buf[p.start:p.end], err
+		}
+	}
+	return p.buf[p.start : p.start+n], nil
+}
+
+// PeekHeader decodes the next CBOR header without consuming it, and returns
+// its length in bytes along with its content. The header is read from the
+// underlying reader with at most two reads.
+func (p *peeker) PeekHeader() (maj byte, extra uint64, n int, err error) {
+	hdr, err := p.Peek(1)
+	if err != nil {
+		return 0, 0, 0, err
+	}
+	first := hdr[0]
+
+	maj = (first & 0xe0) >> 5
+	low := first & 0x1f
+
+	switch {
+	case low < 24:
+		return maj, uint64(low), 1, nil
+	case low == 24:
+		if hdr, err = p.Peek(2); err != nil {
+			return 0, 0, 0, err
+		}
+		if hdr[1] < 24 {
+			return 0, 0, 0, fmt.Errorf("cbor input was not canonical (lval 24 with value < 24)")
+		}
+		return maj, uint64(hdr[1]), 2, nil
+	case low == 25:
+		if hdr, err = p.Peek(3); err != nil {
+			return 0, 0, 0, err
+		}
+		val := uint64(binary.BigEndian.Uint16(hdr[1:3]))
+		if val <= math.MaxUint8 {
+			return 0, 0, 0, fmt.Errorf("cbor input was not canonical (lval 25 with value <= MaxUint8)")
+		}
+		return maj, val, 3, nil
+	case low == 26:
+		if hdr, err = p.Peek(5); err != nil {
+			return 0, 0, 0, err
+		}
+		val := uint64(binary.BigEndian.Uint32(hdr[1:5]))
+		if val <= math.MaxUint16 {
+			return 0, 0, 0, fmt.Errorf("cbor input was not canonical (lval 26 with value <= MaxUint16)")
+		}
+		return maj, val, 5, nil
+	case low == 27:
+		if hdr, err = p.Peek(9); err != nil {
+			return 0, 0, 0, err
+		}
+		val := binary.BigEndian.Uint64(hdr[1:9])
+		if val <= math.MaxUint32 {
+			return 0, 0, 0, fmt.Errorf("cbor input was not canonical (lval 27 with value <= MaxUint32)")
+		}
+		return maj, val, 9, nil
+	default:
+		return 0, 0, 0, fmt.Errorf("invalid header: (%x)", first)
+	}
+}
+
+// readHeader reads a CBOR header, like CborReadHeader.
+func (p *peeker) readHeader() (byte, uint64, int, error) {
+	maj, extra, n, err := p.PeekHeader()
+	if err != nil {
+		// Consume what was read, to report the same progress as CborReadHeader.
+		n = p.buffered()
+		if n > 0 {
+			p.lastByte = p.buf[p.end-1]
+			p.peekState = peekSet
+		}
+		p.start = p.end
+		return 0, 0, n, err
+	}
+	p.start += n
+	p.lastByte = p.buf[p.start-1]
+	p.peekState = peekSet
+	return maj, extra, n, nil
+}
@@ -49,12 +49,13 @@ func TestPeeker(t *testing.T) {
 		t.Fatal("expected 1")
 	}
 
-	// unread that last byte then read 2
+	// unread that last byte then read 2, which takes two reads as the first
+	// one returns the unread byte only.
 	err = p.UnreadByte()
 	if err != nil {
 		t.Fatal(err)
 	}
-	n, err = p.Read(out[:])
+	n, err = io.ReadFull(&p, out[:])
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -101,3 +102,120 @@ func TestPeeker(t *testing.T) {
 		t.Fatal("expected 1")
 	}
 }
+
+// countingReader counts the reads made on the underlying reader.
+type countingReader struct {
+	r     io.Reader
+	reads int
+}
+
+func (c *countingReader) Read(b []byte) (int, error) {
+	c.reads++
+	return c.r.Read(b)
+}
+
+func TestPeekerHeader(t *testing.T) {
+	// A 4 byte header for a uint64 followed by a second item.
+	src := bytes.NewReader([]byte{0x1a, 0x00, 0x01, 0x00, 0x00, 0x01})
+	cr := &countingReader{r: src}
+	p := &peeker{reader: cr}
+
+	b, err := p.Peek(1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if b[0] != 0x1a {
+		t.Fatalf("unexpected peeked byte %x", b[0])
+	}
+
+	maj, extra, n, err := p.PeekHeader()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if maj != MajUnsignedInt || extra != 0x10000 || n != 5 {
+		t.Fatalf("unexpected header: %d %d %d", maj, extra, n)
+	}
+	if cr.reads != 2 {
+		t.Fatalf("expected 2 reads, got %d", cr.reads)
+	}
+
+	maj, extra, n, err = CborReadHeaderBuf(p, make([]byte, maxHeaderSize))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if maj != MajUnsignedInt || extra != 0x10000 || n != 5 {
+		t.Fatalf("unexpected header: %d %d %d", maj, extra, n)
+	}
+	// Nothing past the header was read.
+	if src.Len() != 1 {
+		t.Fatalf("over-read: %d bytes left", src.Len())
+	}
+
+	if err := p.UnreadByte(); err != nil {
+		t.Fatal(err)
+	}
+	if b, err := p.ReadByte(); err != nil || b != 0x00 {
+		t.Fatalf("unexpected byte %x: %v", b, err)
+	}
+
+	maj, extra, _, err = CborReadHeader(p)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if maj != MajUnsignedInt || extra != 1 {
+		t.Fatalf("unexpected header: %d %d", maj, extra)
+	}
+	if _, err := p.Peek(1); err != io.EOF {
+		t.Fatalf("expected EOF, got %v", err)
+	}
+}
+
+func TestPeekerReadBuffered(t *testing.T) {
+	// A pipe would block on a read while the header is buffered.
+	cr := &countingReader{r: bytes.NewReader([]byte{0x19, 0x01, 0x00, 0xff})}
+	p := &peeker{reader: cr}
+	if _, err := p.Peek(3); err != nil {
+		t.Fatal(err)
+	}
+	reads := cr.reads
+
+	var out [4]byte
+	n, err := p.Read(out[:])
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n != 3 || !bytes.Equal(out[:n], []byte{0x19, 0x01, 0x00}) {
+		t.Fatalf("unexpected read %x", out[:n])
+	}
+	if cr.reads != reads {
+		t.Fatal("buffered bytes were returned along with a read on the underlying reader")
+	}
+
+	// The buffer is empty, so the next read goes to the underlying reader.
+	if n, err := p.Read(out[:]); err != nil || n != 1 || out[0] != 0xff {
+		t.Fatalf("unexpected read %x: %v", out[:n], err)
+	}
+}
+
+func TestPeekerHeaderErrors(t *testing.T) {
+	p := &peeker{reader: bytes.NewReader([]byte{0x19, 0x00})}
+	_, _, n, err := CborReadHeader(p)
+	if err != io.ErrUnexpectedEOF {
+		t.Fatalf("expected unexpected EOF, got %v", err)
+	}
+	if n != 2 {
+		t.Fatalf("expected 2 bytes read, got %d", n)
+	}
+
+	p.Reset(bytes.NewReader([]byte{0x19, 0x00, 0x10}))
+	if _, _, n, err = CborReadHeader(p); err == nil {
+		t.Fatal("expected non-canonical header to fail")
+	}
+	if n != 3 {
+		t.Fatalf("expected 3 bytes read, got %d", n)
+	}
+
+	if _, err := p.Peek(maxHeaderSize + 1); err != bufio.ErrBufferFull {
+		t.Fatalf("expected ErrBufferFull, got %v", err)
+	}
+}
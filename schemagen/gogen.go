@@ -0,0 +1,762 @@
+package schemagen
+
+import (
+	"bytes"
+	"fmt"
+	"go/format"
+	"io"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"text/template"
+	"unicode"
+	"unicode/utf8"
+
+	"golang.org/x/xerrors"
+
+	cbg "github.com/daotl/cbor-gen"
+)
+
+// Names of the generated files.
+const (
+	TypesFile    = "types_gen.go"
+	TupleFile    = "cbor_gen.go"
+	MapFile      = "cbor_map_gen.go"
+	UnionFile    = "cbor_union_gen.go"
+	bootstrapDir = ".schemagen"
+)
+
+var cbgPkgPath = reflect.TypeOf(cbg.Gen{}).PkgPath()
+
+// Options configures Generate.
+type Options struct {
+	// Dir is the directory of the Go package to generate, which must belong
+	// to a module able to import cbor-gen.
+	Dir string
+	// Package is the name of the Go package.
+	Package string
+	// GenerateTests also generates fuzz targets and roundtrip tests for the
+	// structs, see cbg.Gen.
+	GenerateTests bool
+}
+
+// Generate writes the Go types of the schema s and their codecs to the
+// package in opts.Dir:
+//
+//   - types_gen.go holds a Go type for every schema type. Structs become Go
+//     structs, enums named strings or integers with a constant per member,
+//     and unions Go structs with a pointer field per member, exactly one of
+//     which is set.
+//   - cbor_gen.go and cbor_map_gen.go hold the codecs of structs with tuple
+//     and map representations, generated with cbor-gen by a bootstrap
+//     program run with `go run`, as the generator works on Go types.
+//   - cbor_union_gen.go holds the codecs of keyed and kinded unions.
+func Generate(s *Schema, opts Options) error {
+	g := &goGen{schema: s}
+
+	var tuples, maps, unions []*Type
+	for _, t := range s.Types {
+		switch {
+		case t.Kind == KindStruct && t.Repr == ReprTuple:
+			tuples = append(tuples, t)
+		case t.Kind == KindStruct:
+			maps = append(maps, t)
+		case t.Kind == KindUnion:
+			unions = append(unions, t)
+		}
+	}
+
+	buf := new(bytes.Buffer)
+	if err := g.writeTypes(buf, opts.Package); err != nil {
+		return err
+	}
+	if err := writeGoFile(filepath.Join(opts.Dir, TypesFile), buf.Bytes()); err != nil {
+		return err
+	}
+
+	// Remove the codecs generated from a previous version of the schema, as
+	// they may not compile anymore.
+	for _, name := range []string{TupleFile, MapFile, UnionFile} {
+		if err := os.Remove(filepath.Join(opts.Dir, name)); err != nil && !os.IsNotExist(err) {
+			return err
+		}
+	}
+
+	if len(tuples) > 0 || len(maps) > 0 {
+		if err := bootstrap(opts, tuples, maps); err != nil {
+			return err
+		}
+	}
+
+	if len(unions) > 0 {
+		buf.Reset()
+		if err := g.writeUnionCodecs(buf, opts.Package, unions); err != nil {
+			return err
+		}
+		if err := writeGoFile(filepath.Join(opts.Dir, UnionFile), buf.Bytes()); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func writeGoFile(fname string, src []byte) error {
+	data, err := format.Source(src)
+	if err != nil {
+		return xerrors.Errorf("failed to format %s: %w", fname, err)
+	}
+	return ioutil.WriteFile(fname, data, 0644)
+}
+
+// goName returns the exported Go identifier for a schema name.
+func goName(name string) string {
+	r, n := utf8.DecodeRuneInString(name)
+	return string(unicode.ToUpper(r)) + name[n:]
+}
+
+type goGen struct {
+	schema *Schema
+}
+
+// kindOf returns the kind of a type reference, resolving named types.
+func (g *goGen) kindOf(ref *TypeRef) (string, error) {
+	if ref.Kind != "" {
+		return ref.Kind, nil
+	}
+	if kind, ok := preludeKinds[ref.Name]; ok {
+		return kind, nil
+	}
+	if t := g.schema.Lookup(ref.Name); t != nil {
+		return t.Kind, nil
+	}
+	return "", fmt.Errorf("unknown type %s", ref.Name)
+}
+
+// goType returns the Go type of a type reference.
+func (g *goGen) goType(ref *TypeRef) (string, error) {
+	kind, err := g.kindOf(ref)
+	if err != nil {
+		return "", err
+	}
+
+	var typ string
+	switch {
+	case kind == KindFloat:
+		return "", fmt.Errorf("floats are not supported")
+	case kind == KindAny:
+		// Any is always nullable.
+		return "*cbg.Deferred", nil
+	case ref.Kind == KindLink:
+		typ = "cid.Cid"
+	case ref.Kind == KindList:
+		elem, err := g.goType(ref.Elem)
+		if err != nil {
+			return "", err
+		}
+		typ = "[]" + elem
+	case ref.Kind == KindMap:
+		if ref.Key.Name != "String" || ref.Key.Nullable {
+			return "", fmt.Errorf("map keys must be strings")
+		}
+		elem, err := g.goType(ref.Elem)
+		if err != nil {
+			return "", err
+		}
+		typ = "map[string]" + elem
+	default:
+		switch ref.Name {
+		case "Bool":
+			typ = "bool"
+		case "Int":
+			typ = "int64"
+		case "String":
+			typ = "string"
+		case "Bytes":
+			typ = "[]byte"
+		case "Link":
+			typ = "cid.Cid"
+		default:
+			typ = goName(ref.Name)
+		}
+	}
+
+	if ref.Nullable {
+		switch kind {
+		case KindStruct, KindUnion, KindLink:
+			typ = "*" + typ
+		default:
+			return "", fmt.Errorf("nullable %s values are not supported", kind)
+		}
+	}
+	return typ, nil
+}
+
+func (g *goGen) writeTypes(w io.Writer, pkg string) error {
+	fmt.Fprintf(w, `// Code generated by %s/schemagen. DO NOT EDIT.
+
+package %s
+
+import (
+	cid "github.com/ipfs/go-cid"
+
+	cbg %q
+)
+
+var _ = cid.Undef
+var _ = cbg.CborNull
+`, cbgPkgPath, pkg, cbgPkgPath)
+
+	for _, t := range g.schema.Types {
+		if err := g.writeType(w, t); err != nil {
+			return xerrors.Errorf("type %s: %w", t.Name, err)
+		}
+	}
+	return nil
+}
+
+func (g *goGen) writeType(w io.Writer, t *Type) error {
+	name := goName(t.Name)
+	fmt.Fprintln(w)
+
+	switch t.Kind {
+	case KindStruct:
+		fmt.Fprintf(w, "type %s struct {\n", name)
+		for _, f := range t.Fields {
+			typ, err := g.goType(f.Type)
+			if err != nil {
+				return xerrors.Errorf("field %s: %w", f.Name, err)
+			}
+			key := f.Name
+			if f.Rename != "" {
+				key = f.Rename
+			}
+			tag := ""
+			if t.Repr == ReprMap && key != goName(f.Name) {
+				tag = key
+			}
+			if f.Optional {
+				tag += ",optional"
+			}
+			enum, err := g.enumOption(f)
+			if err != nil {
+				return xerrors.Errorf("field %s: %w", f.Name, err)
+			}
+			tag += enum
+			if tag != "" {
+				fmt.Fprintf(w, "\t%s %s `cborgen:%q`\n", goName(f.Name), typ, tag)
+			} else {
+				fmt.Fprintf(w, "\t%s %s\n", goName(f.Name), typ)
+			}
+		}
+		fmt.Fprintln(w, "}")
+	case KindEnum:
+		if t.Repr == ReprInt {
+			fmt.Fprintf(w, "type %s int64\n\nconst (\n", name)
+			for _, m := range t.Members {
+				fmt.Fprintf(w, "\t%s%s %s = %s\n", name, goName(m.Name), name, m.Value)
+			}
+		} else {
+			fmt.Fprintf(w, "type %s string\n\nconst (\n", name)
+			for _, m := range t.Members {
+				v := m.Name
+				if m.Value != "" {
+					v = m.Value
+				}
+				fmt.Fprintf(w, "\t%s%s %s = %q\n", name, goName(m.Name), name, v)
+			}
+		}
+		fmt.Fprintln(w, ")")
+	case KindUnion:
+		fmt.Fprintf(w, "// %s is a %s union, exactly one of its fields must be set.\ntype %s struct {\n",
+			name, t.Repr, name)
+		for _, m := range t.Union {
+			typ, err := g.goType(&TypeRef{Name: m.Type})
+			if err != nil {
+				return xerrors.Errorf("member %s: %w", m.Type, err)
+			}
+			fmt.Fprintf(w, "\t%s *%s\n", goName(m.Type), strings.TrimPrefix(typ, "*"))
+		}
+		fmt.Fprintln(w, "}")
+	case KindFloat:
+		return fmt.Errorf("floats are not supported")
+	case KindLink:
+		fmt.Fprintf(w, "type %s = cid.Cid\n", name)
+	case KindAny:
+		fmt.Fprintf(w, "type %s = cbg.Deferred\n", name)
+	default:
+		typ, err := g.goType(&TypeRef{Kind: kindIfInline(t.Kind), Name: preludeName(t.Kind), Elem: t.Elem, Key: t.Key})
+		if err != nil {
+			return err
+		}
+		fmt.Fprintf(w, "type %s %s\n", name, typ)
+	}
+	return nil
+}
+
+// enumOption returns the cborgen enum option making decoders reject unknown
+// members of the enum type of f, or "" if f isn't of an enum type. As missing
+// optional fields are encoded with their zero value, it's accepted for them.
+func (g *goGen) enumOption(f *Field) (string, error) {
+	if f.Type.Kind != "" {
+		return "", nil
+	}
+	t := g.schema.Lookup(f.Type.Name)
+	if t == nil || t.Kind != KindEnum {
+		return "", nil
+	}
+
+	zero := ""
+	if t.Repr == ReprInt {
+		zero = "0"
+	}
+	hasZero := false
+	var values []string
+	for _, m := range t.Members {
+		v := m.Name
+		if m.Value != "" {
+			v = m.Value
+		}
+		if strings.ContainsAny(v, ",|") {
+			return "", fmt.Errorf("enum member %s: values holding commas or pipes are not supported", m.Name)
+		}
+		hasZero = hasZero || v == zero
+		values = append(values, v)
+	}
+	if f.Optional && !hasZero {
+		values = append(values, zero)
+	}
+	return ",enum=" + strings.Join(values, "|"), nil
+}
+
+// kindIfInline returns kind if it's the kind of an inline type reference.
+func kindIfInline(kind string) string {
+	if kind == KindList || kind == KindMap {
+		return kind
+	}
+	return ""
+}
+
+// preludeName returns the prelude type of a scalar kind.
+func preludeName(kind string) string {
+	for name, k := range preludeKinds {
+		if k == kind {
+			return name
+		}
+	}
+	return ""
+}
+
+// bootstrap generates the codecs of the structs with cbor-gen, by running a
+// program importing the package with the generated types.
+func bootstrap(opts Options, tuples, maps []*Type) error {
+	dir, err := filepath.Abs(opts.Dir)
+	if err != nil {
+		return err
+	}
+
+	out, err := goCommand(dir, "list", "-f", "{{ .ImportPath }}", ".")
+	if err != nil {
+		return xerrors.Errorf("failed to find the import path of %s: %w", dir, err)
+	}
+	importPath := strings.TrimSpace(out)
+
+	tmp, err := ioutil.TempDir(dir, bootstrapDir)
+	if err != nil {
+		return err
+	}
+	defer os.RemoveAll(tmp) //nolint:errcheck
+
+	data := struct {
+		CbgPath    string
+		ImportPath string
+		Package    string
+		Tests      bool
+		TupleFile  string
+		Tuples     []string
+		MapFile    string
+		Maps       []string
+	}{
+		CbgPath:    cbgPkgPath,
+		ImportPath: importPath,
+		Package:    opts.Package,
+		Tests:      opts.GenerateTests,
+		TupleFile:  filepath.Join(dir, TupleFile),
+		MapFile:    filepath.Join(dir, MapFile),
+	}
+	for _, t := range tuples {
+		data.Tuples = append(data.Tuples, goName(t.Name))
+	}
+	for _, t := range maps {
+		data.Maps = append(data.Maps, goName(t.Name))
+	}
+
+	buf := new(bytes.Buffer)
+	if err := bootstrapTemplate.Execute(buf, data); err != nil {
+		return err
+	}
+	if err := writeGoFile(filepath.Join(tmp, "main.go"), buf.Bytes()); err != nil {
+		return err
+	}
+
+	if _, err := goCommand(tmp, "run", "main.go"); err != nil {
+		return xerrors.Errorf("failed to generate codecs: %w", err)
+	}
+	return nil
+}
+
+func goCommand(dir string, args ...string) (string, error) {
+	cmd := exec.Command("go", args...)
+	cmd.Dir = dir
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		return "", fmt.Errorf("go %s: %s: %s", strings.Join(args, " "), err, stderr.String())
+	}
+	return stdout.String(), nil
+}
+
+var bootstrapTemplate = template.Must(template.New("").Parse(`package main
+
+import (
+	"fmt"
+	"os"
+
+	cbg "{{ .CbgPath }}"
+
+	types "{{ .ImportPath }}"
+)
+
+func main() {
+	g := cbg.Gen{GenerateTests: {{ .Tests }}}
+{{- if .Tuples }}
+	if err := g.WriteTupleEncodersToFile({{ printf "%q" .TupleFile }}, {{ printf "%q" .Package }},
+{{- range .Tuples }}
+		types.{{ . }}{},
+{{- end }}
+	); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+{{- end }}
+{{- if .Maps }}
+	if err := g.WriteMapEncodersToFile({{ printf "%q" .MapFile }}, {{ printf "%q" .Package }},
+{{- range .Maps }}
+		types.{{ . }}{},
+{{- end }}
+	); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+{{- end }}
+}
+`))
+
+// unionMember holds what the union codec templates need about a member.
+type unionMember struct {
+	Union string
+	Field string
+	Type  string
+	// Kind is the data model kind of the member.
+	Kind string
+	// Codec is "struct" for members with their own codec, or the kind of
+	// scalar members.
+	Codec string
+	// Key is the key of keyed union members, and Prefix its encoding with
+	// the map header.
+	Key    string
+	Prefix string
+}
+
+// NodeExpr returns the cbg.Node holding the value of a scalar member.
+func (m unionMember) NodeExpr() string {
+	v := "*t." + m.Field
+	switch m.Codec {
+	case KindString:
+		return "cbg.StringNode(" + v + ")"
+	case KindInt:
+		return "cbg.NewIntNode(int64(" + v + "))"
+	case KindBool:
+		return "cbg.BoolNode(" + v + ")"
+	case KindBytes:
+		return "cbg.BytesNode(" + v + ")"
+	default:
+		return "cbg.LinkNode(" + v + ")"
+	}
+}
+
+// NodeType returns the cbg.Node type of a scalar member.
+func (m unionMember) NodeType() string {
+	switch m.Codec {
+	case KindString:
+		return "cbg.StringNode"
+	case KindInt:
+		return "cbg.IntNode"
+	case KindBool:
+		return "cbg.BoolNode"
+	case KindBytes:
+		return "cbg.BytesNode"
+	default:
+		return "cbg.LinkNode"
+	}
+}
+
+// Majors returns the CBOR major types a member of a kinded union starts with.
+func (m unionMember) Majors() string {
+	switch m.Kind {
+	case KindMap:
+		return "cbg.MajMap"
+	case KindList:
+		return "cbg.MajArray"
+	case KindString:
+		return "cbg.MajTextString"
+	case KindBytes:
+		return "cbg.MajByteString"
+	case KindInt:
+		return "cbg.MajUnsignedInt, cbg.MajNegativeInt"
+	case KindLink:
+		return "cbg.MajTag"
+	default:
+		return "cbg.MajOther"
+	}
+}
+
+// dataModelKind returns the kind of the values of a schema type.
+func (g *goGen) dataModelKind(name string) (string, error) {
+	if kind, ok := preludeKinds[name]; ok {
+		return kind, nil
+	}
+	t := g.schema.Lookup(name)
+	if t == nil {
+		return "", fmt.Errorf("unknown type %s", name)
+	}
+	switch t.Kind {
+	case KindStruct:
+		if t.Repr == ReprTuple {
+			return KindList, nil
+		}
+		return KindMap, nil
+	case KindEnum:
+		if t.Repr == ReprInt {
+			return KindInt, nil
+		}
+		return KindString, nil
+	case KindUnion:
+		if t.Repr == ReprKeyed {
+			return KindMap, nil
+		}
+		return "", fmt.Errorf("kinded union %s has no single kind", name)
+	}
+	return t.Kind, nil
+}
+
+func (g *goGen) unionMembers(t *Type) ([]unionMember, error) {
+	seen := map[string]bool{}
+	var members []unionMember
+	for _, m := range t.Union {
+		kind, err := g.dataModelKind(m.Type)
+		if err != nil {
+			return nil, err
+		}
+		typ, err := g.goType(&TypeRef{Name: m.Type})
+		if err != nil {
+			return nil, err
+		}
+
+		um := unionMember{Union: goName(t.Name), Field: goName(m.Type), Type: typ, Kind: kind, Codec: kind}
+		if st := g.schema.Lookup(m.Type); st != nil && (st.Kind == KindStruct || st.Kind == KindUnion) {
+			um.Codec = "struct"
+		}
+		switch um.Codec {
+		case "struct", KindString, KindInt, KindBool, KindBytes, KindLink:
+		default:
+			return nil, fmt.Errorf("member %s: %s members are not supported", m.Type, kind)
+		}
+
+		if t.Repr == ReprKeyed {
+			prefix := append(cbg.CborEncodeMajorType(cbg.MajMap, 1),
+				cbg.CborEncodeMajorType(cbg.MajTextString, uint64(len(m.Key)))...)
+			prefix = append(prefix, m.Key...)
+			um.Key = m.Key
+			um.Prefix = fmt.Sprintf("%#v", prefix)
+		} else {
+			if m.Key != kind {
+				return nil, fmt.Errorf("member %s is a %s, not a %s", m.Type, kind, m.Key)
+			}
+			if seen[kind] {
+				return nil, fmt.Errorf("more than one %s member", kind)
+			}
+			seen[kind] = true
+		}
+		if seen["key:"+m.Key] {
+			return nil, fmt.Errorf("duplicate member key %q", m.Key)
+		}
+		seen["key:"+m.Key] = true
+		members = append(members, um)
+	}
+	return members, nil
+}
+
+func (g *goGen) writeUnionCodecs(w io.Writer, pkg string, unions []*Type) error {
+	fmt.Fprintf(w, `// Code generated by %s/schemagen. DO NOT EDIT.
+
+package %s
+
+import (
+	"fmt"
+	"io"
+
+	cid "github.com/ipfs/go-cid"
+
+	cbg %q
+)
+
+var _ = cid.Undef
+`, cbgPkgPath, pkg, cbgPkgPath)
+
+	for _, t := range unions {
+		members, err := g.unionMembers(t)
+		if err != nil {
+			return xerrors.Errorf("union %s: %w", t.Name, err)
+		}
+		data := struct {
+			Name    string
+			Keyed   bool
+			Members []unionMember
+		}{goName(t.Name), t.Repr == ReprKeyed, members}
+		if err := unionTemplate.Execute(w, data); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+var unionTemplate = template.Must(template.New("").Parse(`
+{{- define "marshalMember" }}
+	{{- if eq .Codec "struct" }}t.{{ .Field }}.MarshalCBOR(w)
+	{{- else }}cbg.EncodeAny(w, {{ .NodeExpr }})
+	{{- end }}
+{{- end }}
+
+{{- define "unmarshalMember" }}
+		{{- if eq .Codec "struct" }}
+		t.{{ .Field }} = new({{ .Type }})
+		read, err := t.{{ .Field }}.UnmarshalCBOR(br)
+		return bytesRead + read, err
+		{{- else }}
+		var d cbg.Deferred
+		read, err := d.UnmarshalCBOR(br)
+		bytesRead += read
+		if err != nil {
+			return bytesRead, err
+		}
+		nd, err := d.Node()
+		if err != nil {
+			return bytesRead, err
+		}
+		v, ok := nd.({{ .NodeType }})
+		if !ok {
+			return bytesRead, fmt.Errorf("{{ .Union }}: expected {{ .Codec }} for member {{ .Field }}, got %s", nd.Kind())
+		}
+		{{- if eq .Codec "int" }}
+		i, ok := v.Int64()
+		if !ok {
+			return bytesRead, &cbg.DecodeError{Type: "{{ .Union }}", Field: "{{ .Field }}", Offset: bytesRead - read, Err: cbg.ErrOverflow}
+		}
+		val := {{ .Type }}(i)
+		{{- else }}
+		val := {{ .Type }}(v)
+		{{- end }}
+		t.{{ .Field }} = &val
+		return bytesRead, nil
+		{{- end }}
+{{- end }}
+
+{{- $name := .Name }}
+{{- if .Keyed }}
+{{ range .Members }}
+var key{{ $name }}{{ .Field }} = {{ .Prefix }}
+{{- end }}
+{{- end }}
+
+func (t *{{ .Name }}) MarshalCBOR(w io.Writer) (int, error) {
+	if t == nil {
+		return w.Write(cbg.CborNull)
+	}
+
+	switch {
+{{- range .Members }}
+	case t.{{ .Field }} != nil:
+		{{- if $.Keyed }}
+		n, err := w.Write(key{{ $name }}{{ .Field }})
+		if err != nil {
+			return n, err
+		}
+		n_, err := {{ template "marshalMember" . }}
+		return n + n_, err
+		{{- else }}
+		return {{ template "marshalMember" . }}
+		{{- end }}
+{{- end }}
+	default:
+		return 0, fmt.Errorf("{{ .Name }}: no member is set")
+	}
+}
+
+func (t *{{ .Name }}) UnmarshalCBOR(r io.Reader) (int, error) {
+	*t = {{ .Name }}{}
+
+	br := cbg.GetPeeker(r)
+{{- if .Keyed }}
+	scratch := make([]byte, 8)
+
+	maj, extra, read, err := cbg.CborReadHeaderBuf(br, scratch)
+	bytesRead := read
+	if err != nil {
+		return bytesRead, err
+	}
+	if maj != cbg.MajMap {
+		return bytesRead, &cbg.DecodeError{Type: "{{ .Name }}", Expected: cbg.MajMap, Found: maj, Err: cbg.ErrUnexpectedType}
+	}
+	if extra != 1 {
+		return bytesRead, &cbg.DecodeError{Type: "{{ .Name }}", Expected: cbg.MajMap, Found: maj, Err: fmt.Errorf("expected a map with a single entry")}
+	}
+
+	key, read, err := cbg.ReadStringBuf(br, scratch)
+	bytesRead += read
+	if err != nil {
+		return bytesRead, err
+	}
+
+	switch key {
+{{- range .Members }}
+	case {{ printf "%q" .Key }}:
+		{{- template "unmarshalMember" . }}
+{{- end }}
+	default:
+		return bytesRead, fmt.Errorf("{{ .Name }}: unknown member %q", key)
+	}
+{{- else }}
+	first, err := br.ReadByte()
+	if err != nil {
+		return 0, err
+	}
+	if err := br.UnreadByte(); err != nil {
+		return 0, err
+	}
+
+	bytesRead := 0
+	switch first >> 5 {
+{{- range .Members }}
+	case {{ .Majors }}:
+		{{- template "unmarshalMember" . }}
+{{- end }}
+	default:
+		return 0, fmt.Errorf("{{ .Name }}: no member of major type %d", first>>5)
+	}
+{{- end }}
+}
+`))
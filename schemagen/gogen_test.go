@@ -0,0 +1,95 @@
+package schemagen
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestWriteTypes(t *testing.T) {
+	s, err := Parse(strings.NewReader(`
+type foo struct {
+	name String
+	Count Int
+	parent nullable &foo
+	children [nullable foo]
+	note optional String
+	kind Kind
+	size optional Kind
+} representation map {
+	field Count rename "c"
+}
+
+type Kind enum {
+	| Small ("s")
+}
+
+type Member union {
+	| foo "f"
+	| String "s"
+} representation keyed
+`))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	buf := new(bytes.Buffer)
+	if err := (&goGen{schema: s}).writeTypes(buf, "pkg"); err != nil {
+		t.Fatal(err)
+	}
+	for _, expected := range []string{
+		"type Foo struct {\n" +
+			"\tName string `cborgen:\"name\"`\n" +
+			"\tCount int64 `cborgen:\"c\"`\n" +
+			"\tParent *cid.Cid `cborgen:\"parent\"`\n" +
+			"\tChildren []*Foo `cborgen:\"children\"`\n" +
+			"\tNote string `cborgen:\"note,optional\"`\n" +
+			// Decoders reject unknown members, except the zero value of
+			// missing optional fields.
+			"\tKind Kind `cborgen:\"kind,enum=s\"`\n" +
+			"\tSize Kind `cborgen:\"size,optional,enum=s|\"`\n}\n",
+		"type Kind string\n\nconst (\n\tKindSmall Kind = \"s\"\n)\n",
+		"type Member struct {\n\tFoo *Foo\n\tString *string\n}\n",
+	} {
+		if !strings.Contains(buf.String(), expected) {
+			t.Errorf("expected %q in:\n%s", expected, buf.String())
+		}
+	}
+}
+
+func TestUnsupportedTypes(t *testing.T) {
+	for _, tc := range []struct {
+		schema string
+		err    string
+	}{
+		{`type Foo struct { a Float }`, "floats are not supported"},
+		{`type Foo struct { a nullable Int }`, "nullable int values are not supported"},
+		{`type Foo struct { a {Int:String} }`, "map keys must be strings"},
+		{`type Foo struct { a Bar }`, "unknown type Bar"},
+	} {
+		s, err := Parse(strings.NewReader(tc.schema))
+		if err != nil {
+			t.Fatal(err)
+		}
+		err = (&goGen{schema: s}).writeTypes(new(bytes.Buffer), "pkg")
+		if err == nil || !strings.Contains(err.Error(), tc.err) {
+			t.Errorf("%s: expected error %q, got %v", tc.schema, tc.err, err)
+		}
+	}
+
+	s, err := Parse(strings.NewReader(`
+type A struct {}
+type B struct {}
+type U union {
+	| A map
+	| B map
+} representation kinded
+`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = (&goGen{schema: s}).writeUnionCodecs(new(bytes.Buffer), "pkg", s.Types[2:])
+	if err == nil || !strings.Contains(err.Error(), "more than one map member") {
+		t.Errorf("expected an error for members of the same kind, got %v", err)
+	}
+}
@@ -0,0 +1,526 @@
+// Package schemagen generates Go types and their cbor-gen codecs from an IPLD
+// Schema (https://ipld.io/docs/schemas/).
+package schemagen
+
+import (
+	"fmt"
+	"io"
+	"io/ioutil"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// Kinds of types and type references.
+const (
+	KindBool   = "bool"
+	KindInt    = "int"
+	KindFloat  = "float"
+	KindString = "string"
+	KindBytes  = "bytes"
+	KindLink   = "link"
+	KindAny    = "any"
+	KindList   = "list"
+	KindMap    = "map"
+	KindStruct = "struct"
+	KindEnum   = "enum"
+	KindUnion  = "union"
+)
+
+// Representations of structs, enums and unions.
+const (
+	ReprMap    = "map"
+	ReprTuple  = "tuple"
+	ReprString = "string"
+	ReprInt    = "int"
+	ReprKeyed  = "keyed"
+	ReprKinded = "kinded"
+)
+
+// preludeKinds maps the types of the IPLD prelude to their kind.
+var preludeKinds = map[string]string{
+	"Bool":   KindBool,
+	"Int":    KindInt,
+	"Float":  KindFloat,
+	"String": KindString,
+	"Bytes":  KindBytes,
+	"Link":   KindLink,
+	"Any":    KindAny,
+}
+
+// Schema is a parsed IPLD Schema.
+type Schema struct {
+	// Types are in declaration order.
+	Types []*Type
+}
+
+// Lookup returns the type named name, or nil.
+func (s *Schema) Lookup(name string) *Type {
+	for _, t := range s.Types {
+		if t.Name == name {
+			return t
+		}
+	}
+	return nil
+}
+
+// Type is a named type of a schema.
+type Type struct {
+	Name string
+	Kind string
+	// Repr is the representation of structs, enums and unions.
+	Repr string
+
+	// Elem is the element type of lists and the value type of maps.
+	Elem *TypeRef
+	// Key is the key type of maps.
+	Key *TypeRef
+
+	Fields  []*Field
+	Members []*EnumMember
+	Union   []*UnionMember
+}
+
+// TypeRef references a type, possibly defined inline.
+type TypeRef struct {
+	Nullable bool
+	// Kind is KindList, KindMap or KindLink for inline lists, maps and typed
+	// links, and "" for named types.
+	Kind string
+	Name string
+	Elem *TypeRef
+	Key  *TypeRef
+}
+
+// Field is a field of a struct.
+type Field struct {
+	Name     string
+	Type     *TypeRef
+	Optional bool
+	// Rename is the map key of the field, if renamed in the representation.
+	Rename string
+}
+
+// EnumMember is a member of an enum.
+type EnumMember struct {
+	Name string
+	// Value is the representation of the member, if not its name.
+	Value string
+}
+
+// UnionMember is a member of a union.
+type UnionMember struct {
+	Type string
+	// Key is the map key of the member in a keyed union, and its kind in a
+	// kinded union.
+	Key string
+}
+
+// ParseFile parses the IPLD Schema file fname.
+func ParseFile(fname string) (*Schema, error) {
+	data, err := ioutil.ReadFile(fname)
+	if err != nil {
+		return nil, err
+	}
+	return Parse(strings.NewReader(string(data)))
+}
+
+// Parse parses an IPLD Schema in its DSL form. Struct representations other
+// than map and tuple, and map representation options other than rename, are
+// not supported.
+func Parse(r io.Reader) (*Schema, error) {
+	data, err := ioutil.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+	p := &parser{toks: tokenize(string(data))}
+
+	s := &Schema{}
+	for !p.done() {
+		t, err := p.parseType()
+		if err != nil {
+			return nil, err
+		}
+		if s.Lookup(t.Name) != nil {
+			return nil, fmt.Errorf("type %s declared twice", t.Name)
+		}
+		s.Types = append(s.Types, t)
+	}
+	return s, nil
+}
+
+type token struct {
+	text string
+	line int
+	// str is set for string literals, text holding their value.
+	str bool
+}
+
+// tokenize splits the DSL into identifiers, punctuation and string literals,
+// dropping comments.
+func tokenize(src string) []token {
+	var toks []token
+	line := 1
+	for i := 0; i < len(src); {
+		c := src[i]
+		switch {
+		case c == '\n':
+			line++
+			i++
+		case unicode.IsSpace(rune(c)):
+			i++
+		case c == '#':
+			for i < len(src) && src[i] != '\n' {
+				i++
+			}
+		case c == '"':
+			j := i + 1
+			for j < len(src) && src[j] != '"' && src[j] != '\n' {
+				if src[j] == '\\' {
+					j++
+				}
+				j++
+			}
+			s, err := strconv.Unquote(src[i:minInt(j+1, len(src))])
+			if err != nil {
+				s = src[i+1 : minInt(j, len(src))]
+			}
+			toks = append(toks, token{text: s, line: line, str: true})
+			i = j + 1
+		case strings.IndexByte("{}[]():|&", c) >= 0:
+			toks = append(toks, token{text: string(c), line: line})
+			i++
+		default:
+			j := i
+			for j < len(src) && !unicode.IsSpace(rune(src[j])) &&
+				strings.IndexByte("{}[]():|&#\"", src[j]) < 0 {
+				j++
+			}
+			toks = append(toks, token{text: src[i:j], line: line})
+			i = j
+		}
+	}
+	return toks
+}
+
+func minInt(a, b int) int {
+	if a < b {
+		return a
+	}
+	return b
+}
+
+type parser struct {
+	toks []token
+	pos  int
+}
+
+func (p *parser) done() bool {
+	return p.pos >= len(p.toks)
+}
+
+func (p *parser) peek() token {
+	if p.done() {
+		return token{}
+	}
+	return p.toks[p.pos]
+}
+
+func (p *parser) next() (token, error) {
+	if p.done() {
+		return token{}, fmt.Errorf("unexpected end of schema")
+	}
+	t := p.toks[p.pos]
+	p.pos++
+	return t, nil
+}
+
+func (p *parser) errorf(t token, format string, args ...interface{}) error {
+	return fmt.Errorf("line %d: %s", t.line, fmt.Sprintf(format, args...))
+}
+
+// expect consumes the next token, which must be the keyword or punctuation s.
+func (p *parser) expect(s string) error {
+	t, err := p.next()
+	if err != nil {
+		return err
+	}
+	if t.str || t.text != s {
+		return p.errorf(t, "expected %q, got %q", s, t.text)
+	}
+	return nil
+}
+
+// accept consumes the next token if it's the keyword or punctuation s.
+func (p *parser) accept(s string) bool {
+	if t := p.peek(); !p.done() && !t.str && t.text == s {
+		p.pos++
+		return true
+	}
+	return false
+}
+
+func (p *parser) ident() (token, error) {
+	t, err := p.next()
+	if err != nil {
+		return t, err
+	}
+	if t.str || !isIdent(t.text) {
+		return t, p.errorf(t, "expected a name, got %q", t.text)
+	}
+	return t, nil
+}
+
+func (p *parser) str() (token, error) {
+	t, err := p.next()
+	if err != nil {
+		return t, err
+	}
+	if !t.str {
+		return t, p.errorf(t, "expected a string, got %q", t.text)
+	}
+	return t, nil
+}
+
+func isIdent(s string) bool {
+	for i, r := range s {
+		if !(r == '_' || unicode.IsLetter(r) || (i > 0 && unicode.IsDigit(r))) {
+			return false
+		}
+	}
+	return s != ""
+}
+
+func (p *parser) parseType() (*Type, error) {
+	if err := p.expect("type"); err != nil {
+		return nil, err
+	}
+	name, err := p.ident()
+	if err != nil {
+		return nil, err
+	}
+	t := &Type{Name: name.text}
+
+	switch tok := p.peek(); {
+	case p.accept("struct"):
+		t.Kind = KindStruct
+		return t, p.parseStruct(t)
+	case p.accept("enum"):
+		t.Kind = KindEnum
+		return t, p.parseEnum(t)
+	case p.accept("union"):
+		t.Kind = KindUnion
+		return t, p.parseUnion(t)
+	case tok.text == "[" || tok.text == "{" || tok.text == "&":
+		ref, err := p.parseTypeRef()
+		if err != nil {
+			return nil, err
+		}
+		t.Kind, t.Elem, t.Key = ref.Kind, ref.Elem, ref.Key
+		return t, nil
+	default:
+		kind, ok := preludeKinds[tok.text]
+		if !ok || tok.str {
+			return nil, p.errorf(tok, "unsupported type definition %q", tok.text)
+		}
+		p.pos++
+		t.Kind = kind
+		return t, nil
+	}
+}
+
+// parseTypeRef parses a type reference, e.g. `nullable Foo`, `&Foo`,
+// `[String]` or `{String:Foo}`.
+func (p *parser) parseTypeRef() (*TypeRef, error) {
+	ref := &TypeRef{Nullable: p.accept("nullable")}
+	switch {
+	case p.accept("&"):
+		name, err := p.ident()
+		if err != nil {
+			return nil, err
+		}
+		ref.Kind, ref.Name = KindLink, name.text
+	case p.accept("["):
+		elem, err := p.parseTypeRef()
+		if err != nil {
+			return nil, err
+		}
+		if err := p.expect("]"); err != nil {
+			return nil, err
+		}
+		ref.Kind, ref.Elem = KindList, elem
+	case p.accept("{"):
+		key, err := p.parseTypeRef()
+		if err != nil {
+			return nil, err
+		}
+		if err := p.expect(":"); err != nil {
+			return nil, err
+		}
+		elem, err := p.parseTypeRef()
+		if err != nil {
+			return nil, err
+		}
+		if err := p.expect("}"); err != nil {
+			return nil, err
+		}
+		ref.Kind, ref.Key, ref.Elem = KindMap, key, elem
+	default:
+		name, err := p.ident()
+		if err != nil {
+			return nil, err
+		}
+		ref.Name = name.text
+	}
+	return ref, nil
+}
+
+func (p *parser) parseStruct(t *Type) error {
+	if err := p.expect("{"); err != nil {
+		return err
+	}
+	for !p.accept("}") {
+		name, err := p.ident()
+		if err != nil {
+			return err
+		}
+		f := &Field{Name: name.text, Optional: p.accept("optional")}
+		if f.Type, err = p.parseTypeRef(); err != nil {
+			return err
+		}
+		t.Fields = append(t.Fields, f)
+	}
+
+	t.Repr = ReprMap
+	if !p.accept("representation") {
+		return nil
+	}
+	repr, err := p.ident()
+	if err != nil {
+		return err
+	}
+	switch repr.text {
+	case ReprTuple:
+		t.Repr = ReprTuple
+	case ReprMap:
+		if p.accept("{") {
+			for !p.accept("}") {
+				if err := p.expect("field"); err != nil {
+					return err
+				}
+				name, err := p.ident()
+				if err != nil {
+					return err
+				}
+				if err := p.expect("rename"); err != nil {
+					return err
+				}
+				rename, err := p.str()
+				if err != nil {
+					return err
+				}
+				f := t.field(name.text)
+				if f == nil {
+					return p.errorf(name, "struct %s has no field %s", t.Name, name.text)
+				}
+				f.Rename = rename.text
+			}
+		}
+	default:
+		return p.errorf(repr, "unsupported struct representation %q", repr.text)
+	}
+	return nil
+}
+
+func (t *Type) field(name string) *Field {
+	for _, f := range t.Fields {
+		if f.Name == name {
+			return f
+		}
+	}
+	return nil
+}
+
+func (p *parser) parseEnum(t *Type) error {
+	if err := p.expect("{"); err != nil {
+		return err
+	}
+	for !p.accept("}") {
+		if err := p.expect("|"); err != nil {
+			return err
+		}
+		name, err := p.ident()
+		if err != nil {
+			return err
+		}
+		m := &EnumMember{Name: name.text}
+		if p.accept("(") {
+			v, err := p.str()
+			if err != nil {
+				return err
+			}
+			m.Value = v.text
+			if err := p.expect(")"); err != nil {
+				return err
+			}
+		}
+		t.Members = append(t.Members, m)
+	}
+
+	t.Repr = ReprString
+	if p.accept("representation") {
+		repr, err := p.ident()
+		if err != nil {
+			return err
+		}
+		switch repr.text {
+		case ReprString, ReprInt:
+			t.Repr = repr.text
+		default:
+			return p.errorf(repr, "unsupported enum representation %q", repr.text)
+		}
+	}
+	if t.Repr == ReprInt {
+		for _, m := range t.Members {
+			if _, err := strconv.ParseInt(m.Value, 10, 64); err != nil {
+				return fmt.Errorf("enum %s: member %s needs an integer value", t.Name, m.Name)
+			}
+		}
+	}
+	return nil
+}
+
+func (p *parser) parseUnion(t *Type) error {
+	if err := p.expect("{"); err != nil {
+		return err
+	}
+	for !p.accept("}") {
+		if err := p.expect("|"); err != nil {
+			return err
+		}
+		name, err := p.ident()
+		if err != nil {
+			return err
+		}
+		key, err := p.next()
+		if err != nil {
+			return err
+		}
+		t.Union = append(t.Union, &UnionMember{Type: name.text, Key: key.text})
+	}
+
+	if err := p.expect("representation"); err != nil {
+		return err
+	}
+	repr, err := p.ident()
+	if err != nil {
+		return err
+	}
+	switch repr.text {
+	case ReprKeyed, ReprKinded:
+		t.Repr = repr.text
+	default:
+		return p.errorf(repr, "unsupported union representation %q", repr.text)
+	}
+	return nil
+}
@@ -0,0 +1,107 @@
+package schemagen
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestParse(t *testing.T) {
+	s, err := Parse(strings.NewReader(`
+# A comment.
+type Foo struct {
+	name String # Another one.
+	links [&Foo]
+	meta nullable {String:Any}
+	maybe optional Int
+} representation map {
+	field name rename "n"
+}
+
+type Pair struct {
+	a Int
+	b Int
+} representation tuple
+
+type Color enum {
+	| Red ("r")
+	| Green
+}
+
+type Level enum {
+	| Low ("1")
+} representation int
+
+type Either union {
+	| Foo "foo"
+	| Pair "pair"
+} representation keyed
+
+type Kinded union {
+	| String string
+	| Pair list
+} representation kinded
+
+type Names [String]
+`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(s.Types) != 7 {
+		t.Fatalf("expected 7 types, got %d", len(s.Types))
+	}
+
+	foo := s.Lookup("Foo")
+	if foo.Kind != KindStruct || foo.Repr != ReprMap || len(foo.Fields) != 4 {
+		t.Fatalf("unexpected struct: %+v", foo)
+	}
+	if f := foo.Fields[0]; f.Name != "name" || f.Type.Name != "String" || f.Rename != "n" {
+		t.Fatalf("unexpected field: %+v", f)
+	}
+	if f := foo.Fields[1].Type; f.Kind != KindList || f.Elem.Kind != KindLink || f.Elem.Name != "Foo" {
+		t.Fatalf("unexpected list: %+v", f)
+	}
+	if f := foo.Fields[2].Type; !f.Nullable || f.Kind != KindMap || f.Key.Name != "String" || f.Elem.Name != "Any" {
+		t.Fatalf("unexpected map: %+v", f)
+	}
+	if !foo.Fields[3].Optional {
+		t.Fatal("expected an optional field")
+	}
+
+	if p := s.Lookup("Pair"); p.Repr != ReprTuple {
+		t.Fatalf("unexpected representation %s", p.Repr)
+	}
+	if c := s.Lookup("Color"); c.Repr != ReprString || c.Members[0].Value != "r" || c.Members[1].Value != "" {
+		t.Fatalf("unexpected enum: %+v", c)
+	}
+	if l := s.Lookup("Level"); l.Repr != ReprInt || l.Members[0].Value != "1" {
+		t.Fatalf("unexpected enum: %+v", l)
+	}
+	if u := s.Lookup("Either"); u.Repr != ReprKeyed || u.Union[1].Type != "Pair" || u.Union[1].Key != "pair" {
+		t.Fatalf("unexpected union: %+v", u)
+	}
+	if u := s.Lookup("Kinded"); u.Repr != ReprKinded || u.Union[0].Key != KindString {
+		t.Fatalf("unexpected union: %+v", u)
+	}
+	if n := s.Lookup("Names"); n.Kind != KindList || n.Elem.Name != "String" {
+		t.Fatalf("unexpected list: %+v", n)
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	for _, tc := range []struct {
+		schema string
+		err    string
+	}{
+		{`type Foo struct {`, "unexpected end of schema"},
+		{`type Foo struct {} representation stringjoin`, `unsupported struct representation "stringjoin"`},
+		{`type Foo struct { a Int } representation map { field b rename "c" }`, "struct Foo has no field b"},
+		{`type Foo enum { | A } representation int`, "member A needs an integer value"},
+		{"type Foo Int\ntype Foo String", "type Foo declared twice"},
+		{"type Foo struct {\n\ta \"b\"\n}", `line 2: expected a name, got "b"`},
+	} {
+		_, err := Parse(strings.NewReader(tc.schema))
+		if err == nil || !strings.Contains(err.Error(), tc.err) {
+			t.Errorf("parsing %q: expected error %q, got %v", tc.schema, tc.err, err)
+		}
+	}
+}
@@ -0,0 +1,122 @@
+package typegen
+
+import (
+	"bytes"
+	"io"
+
+	"golang.org/x/xerrors"
+)
+
+// SeqReader reads the items of a CBOR sequence (RFC 8742), i.e. CBOR objects
+// written back to back, from an io.Reader.
+//
+// SeqReader never reads past the end of the item being read, so the
+// underlying reader can be handed over to something else between two items.
+// Wrap it in a bufio.Reader for speed if that's not needed.
+type SeqReader struct {
+	br seqPeeker
+}
+
+func NewSeqReader(r io.Reader) *SeqReader {
+	return &SeqReader{br: seqPeeker{BytePeeker: GetPeeker(r)}}
+}
+
+// Next reads the next item of the sequence. It returns io.EOF when the
+// sequence ended cleanly, and io.ErrUnexpectedEOF if it ended in the middle of
+// an item.
+func (s *SeqReader) Next() (*Deferred, error) {
+	d := new(Deferred)
+	if err := s.Decode(d); err != nil {
+		return nil, err
+	}
+	return d, nil
+}
+
+// Decode reads the next item of the sequence into u. Like Next, it returns
+// io.EOF when the sequence ended cleanly, and io.ErrUnexpectedEOF if it ended
+// in the middle of an item.
+func (s *SeqReader) Decode(u CBORUnmarshaler) error {
+	start := s.br.read
+	_, err := u.UnmarshalCBOR(&s.br)
+	if err == nil {
+		return nil
+	}
+	if xerrors.Is(err, io.EOF) {
+		if s.br.read == start {
+			return io.EOF
+		}
+		return io.ErrUnexpectedEOF
+	}
+	return err
+}
+
+// seqPeeker counts the bytes read through a BytePeeker, so SeqReader can tell
+// an empty read from a truncated item whatever the unmarshaler reports.
+type seqPeeker struct {
+	BytePeeker
+	read int64
+}
+
+// DecodeLimits forwards the limits of the underlying reader, see
+// DecodeLimits.Reader.
+func (p *seqPeeker) DecodeLimits() *DecodeLimits {
+	return GetDecodeLimits(p.BytePeeker)
+}
+
+func (p *seqPeeker) Read(buf []byte) (int, error) {
+	n, err := p.BytePeeker.Read(buf)
+	p.read += int64(n)
+	return n, err
+}
+
+func (p *seqPeeker) ReadByte() (byte, error) {
+	b, err := p.BytePeeker.ReadByte()
+	if err == nil {
+		p.read++
+	}
+	return b, err
+}
+
+func (p *seqPeeker) UnreadByte() error {
+	err := p.BytePeeker.UnreadByte()
+	if err == nil {
+		p.read--
+	}
+	return err
+}
+
+// SeqWriter writes the items of a CBOR sequence (RFC 8742) to an io.Writer.
+//
+// Each item is marshaled in memory before being written, so that an item
+// failing to marshal doesn't corrupt the sequence. Once writing to the
+// underlying writer failed, all subsequent writes fail with the same error.
+type SeqWriter struct {
+	w   io.Writer
+	buf bytes.Buffer
+	err error
+}
+
+func NewSeqWriter(w io.Writer) *SeqWriter {
+	return &SeqWriter{w: w}
+}
+
+// Write appends m to the sequence.
+func (s *SeqWriter) Write(m CBORMarshaler) (int, error) {
+	if s.err != nil {
+		return 0, s.err
+	}
+
+	s.buf.Reset()
+	if _, err := m.MarshalCBOR(&s.buf); err != nil {
+		return 0, err
+	}
+
+	n, err := s.w.Write(s.buf.Bytes())
+	if err == nil && n < s.buf.Len() {
+		err = io.ErrShortWrite
+	}
+	if err != nil {
+		s.err = err
+	}
+	return n, err
+}
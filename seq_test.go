@@ -0,0 +1,86 @@
+package typegen
+
+import (
+	"bytes"
+	"io"
+	"testing"
+)
+
+func TestSeqRoundTrip(t *testing.T) {
+	buf := new(bytes.Buffer)
+	sw := NewSeqWriter(buf)
+	for _, v := range []CBORMarshaler{CborInt(-5), CborBool(true), &Deferred{Raw: []byte{0x82, 0x01, 0x61, 0x61}}} {
+		if _, err := sw.Write(v); err != nil {
+			t.Fatal(err)
+		}
+	}
+	enc := buf.Bytes()
+	if s, _ := Diagnose(enc); s != `-5, true, [1, "a"]` {
+		t.Fatalf("unexpected sequence %s", s)
+	}
+
+	sr := NewSeqReader(bytes.NewReader(enc))
+	var i CborInt
+	if err := sr.Decode(&i); err != nil {
+		t.Fatal(err)
+	} else if i != -5 {
+		t.Fatalf("expected -5, got %d", i)
+	}
+	if d, err := sr.Next(); err != nil {
+		t.Fatal(err)
+	} else if d.String() != "true" {
+		t.Fatalf("expected true, got %s", d)
+	}
+	if d, err := sr.Next(); err != nil {
+		t.Fatal(err)
+	} else if d.String() != `[1, "a"]` {
+		t.Fatalf("expected [1, \"a\"], got %s", d)
+	}
+	if _, err := sr.Next(); err != io.EOF {
+		t.Fatalf("expected EOF, got %v", err)
+	}
+}
+
+func TestSeqTruncated(t *testing.T) {
+	for _, in := range [][]byte{
+		{0x82, 0x01},       // array missing an item
+		{0x19, 0x01},       // truncated header
+		{0x19},             // truncated header
+		{0x63, 0x61, 0x61}, // truncated string
+	} {
+		sr := NewSeqReader(bytes.NewReader(append([]byte{0x01}, in...)))
+		if _, err := sr.Next(); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := sr.Next(); err != io.ErrUnexpectedEOF {
+			t.Fatalf("reading %x: expected io.ErrUnexpectedEOF, got %v", in, err)
+		}
+
+	}
+
+	// Truncated headers are also caught when unmarshaling into a type, even
+	// though CborInt doesn't report reading them.
+	for _, in := range [][]byte{{0x19, 0x01}, {0x19}} {
+		sr := NewSeqReader(bytes.NewReader(in))
+		var i CborInt
+		if err := sr.Decode(&i); err != io.ErrUnexpectedEOF {
+			t.Fatalf("decoding %x: expected io.ErrUnexpectedEOF, got %v", in, err)
+		}
+	}
+}
+
+type failingWriter struct{}
+
+func (failingWriter) Write([]byte) (int, error) {
+	return 0, io.ErrClosedPipe
+}
+
+func TestSeqWriterStickyError(t *testing.T) {
+	sw := NewSeqWriter(failingWriter{})
+	if _, err := sw.Write(CborInt(1)); err != io.ErrClosedPipe {
+		t.Fatalf("expected io.ErrClosedPipe, got %v", err)
+	}
+	if _, err := sw.Write(CborInt(1)); err != io.ErrClosedPipe {
+		t.Fatalf("expected io.ErrClosedPipe, got %v", err)
+	}
+}
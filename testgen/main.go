@@ -1,6 +1,12 @@
 package main
 
 import (
+	"flag"
+	"fmt"
+	"os"
+
+	"golang.org/x/xerrors"
+
 	cbg "github.com/daotl/cbor-gen"
 	types "github.com/daotl/cbor-gen/testing"
 	"github.com/daotl/cbor-gen/testing/flatten_map"
@@ -9,50 +15,75 @@ import (
 	"github.com/daotl/cbor-gen/testing/noflatten_tuple"
 )
 
+var check = flag.Bool("check", false,
+	"check that the generated files are up to date instead of writing them")
+
+// stale is set when -check found an out of date file.
+var stale bool
+
 func main() {
-	if err := cbg.WriteTupleEncodersToFile("testing/cbor_gen.go",
-		"testing", false, nil,
+	flag.Parse()
+
+	tuple(cbg.Gen{
+		GenerateTests:  true,
+		IpldSchemaFile: "testing/cbor_gen.ipldsch",
+		CddlFile:       "testing/cbor_gen.cddl",
+	},
+		"testing/cbor_gen.go", "testing",
 		types.SignedArray{},
 		types.SimpleTypeOne{},
 		types.SimpleTypeTwo{},
 		types.DeferredContainer{},
 		types.FixedArrays{},
 		types.ThingWithSomeTime{},
-	); err != nil {
-		panic(err)
-	}
+		types.BigIntContainer{},
+		types.BigIntHolder{},
+		types.LinkContainer{},
+		types.TupleV1{},
+		types.TupleV2{},
+		types.MigratingTuple{},
+		types.MigratingLinks{},
+		types.SortedValues{},
+		types.ConstrainedTuple{},
+		types.IntKeyedList{},
+		types.Page[cbg.TypeParam0]{},
+	)
 
-	if err := cbg.WriteMapEncodersToFile("testing/cbor_map_gen.go",
-		"testing", false,
+	maps(cbg.Gen{
+		GenerateTests:  true,
+		IpldSchemaFile: "testing/cbor_map_gen.ipldsch",
+		CddlFile:       "testing/cbor_map_gen.cddl",
+	},
+		"testing/cbor_map_gen.go", "testing",
 		types.SimpleTypeTree{},
 		types.NeedScratchForMap{},
 		types.SimpleStructV1{},
 		types.SimpleStructV2{},
 		types.RenamedFields{},
-	); err != nil {
-		panic(err)
-	}
+		types.AliasedFields{},
+		types.UpgradedMap{},
+		types.MigratingMap{},
+		types.Defaults{},
+		types.ValidatedMap{},
+		types.Constrained{},
+		types.Pair[cbg.TypeParam0, cbg.TypeParam1]{},
+		types.IntKeyed{},
+	)
 
-	if err := cbg.WriteTupleEncodersToFile("testing/noflatten_tuple/cbor_gen.go",
-		"noflatten_tuple", false, nil,
+	tuple(cbg.Gen{}, "testing/noflatten_tuple/cbor_gen.go", "noflatten_tuple",
 		noflatten_tuple.EmbeddingStructOne{},
 		noflatten_tuple.EmbeddingStructTwo{},
 		noflatten_tuple.EmbeddingStructThree{},
-	); err != nil {
-		panic(err)
-	}
+	)
 
-	if err := cbg.WriteMapEncodersToFile("testing/noflatten_map/cbor_gen.go",
-		"noflatten_map", false,
+	maps(cbg.Gen{}, "testing/noflatten_map/cbor_gen.go", "noflatten_map",
 		noflatten_map.EmbeddingStructOne{},
 		noflatten_map.EmbeddingStructTwo{},
 		noflatten_map.EmbeddingStructThree{},
-	); err != nil {
-		panic(err)
-	}
+	)
 
-	if err := cbg.WriteTupleEncodersToFile("testing/flatten_tuple/cbor_gen.go",
-		"flatten_tuple", true, nil,
+	tuple(cbg.Gen{FlattenEmbeddedStruct: true, GenerateTests: true},
+		"testing/flatten_tuple/cbor_gen.go", "flatten_tuple",
 		flatten_tuple.EmbeddingStructOne{},
 		flatten_tuple.EmbeddingStructTwo{},
 		flatten_tuple.EmbeddingStructThree{},
@@ -60,21 +91,21 @@ func main() {
 		flatten_tuple.EmbeddedStruct{},
 		flatten_tuple.EmbedByValueStruct{},
 		flatten_tuple.EmbedByPointerStruct{},
-	); err != nil {
-		panic(err)
-	}
+		flatten_tuple.IndexedFlatStruct{},
+		flatten_tuple.EmbeddedLinks{},
+		flatten_tuple.EmbedLinksByPointer{},
+	)
 
-	if err := cbg.WriteTupleEncodersToFile("testing/flatten_tuple/cbor_gen_reordered.go",
-		"flatten_tuple", true, []string{"Signed", "Foo", "Binary", "NString", "Value"},
+	tuple(cbg.Gen{
+		FlattenEmbeddedStruct: true,
+		FieldOrder:            []string{"Signed", "Foo", "Binary", "NString", "Value"},
+	}, "testing/flatten_tuple/cbor_gen_reordered.go", "flatten_tuple",
 		flatten_tuple.ReorderedFlatStruct{},
 		flatten_tuple.ReorderedEmbedByValueStruct{},
 		flatten_tuple.ReorderedEmbedByPointerStruct{},
-	); err != nil {
-		panic(err)
-	}
+	)
 
-	if err := cbg.WriteMapEncodersToFile("testing/flatten_map/cbor_gen.go",
-		"flatten_map", true,
+	maps(cbg.Gen{FlattenEmbeddedStruct: true}, "testing/flatten_map/cbor_gen.go", "flatten_map",
 		flatten_map.EmbeddingStructOne{},
 		flatten_map.EmbeddingStructTwo{},
 		flatten_map.EmbeddingStructThree{},
@@ -82,7 +113,39 @@ func main() {
 		flatten_tuple.EmbeddedStruct{},
 		flatten_tuple.EmbedByValueStruct{},
 		flatten_tuple.EmbedByPointerStruct{},
-	); err != nil {
+	)
+
+	if stale {
+		os.Exit(1)
+	}
+}
+
+// tuple writes, or checks with -check, the tuple encoders of vals to fname.
+func tuple(g cbg.Gen, fname, pkg string, vals ...interface{}) {
+	gen := g.WriteTupleEncodersToFile
+	if *check {
+		gen = g.VerifyTupleEncodersInFile
+	}
+	handle(gen(fname, pkg, vals...))
+}
+
+// maps writes, or checks with -check, the map encoders of vals to fname.
+func maps(g cbg.Gen, fname, pkg string, vals ...interface{}) {
+	gen := g.WriteMapEncodersToFile
+	if *check {
+		gen = g.VerifyMapEncodersInFile
+	}
+	handle(gen(fname, pkg, vals...))
+}
+
+func handle(err error) {
+	var staleErr *cbg.StaleError
+	if xerrors.As(err, &staleErr) {
+		fmt.Fprintln(os.Stderr, staleErr)
+		stale = true
+		return
+	}
+	if err != nil {
 		panic(err)
 	}
 }
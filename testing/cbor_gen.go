@@ -11,6 +11,7 @@ import (
 	cbg "github.com/daotl/cbor-gen"
 	cid "github.com/ipfs/go-cid"
 	xerrors "golang.org/x/xerrors"
+	big "math/big"
 )
 
 var _ = xerrors.Errorf
@@ -58,6 +59,11 @@ func (t *SignedArray) UnmarshalCBOR(r io.Reader) (int, error) {
 
 	br := cbg.GetPeeker(r)
 	scratch := make([]byte, 8)
+	limits := cbg.GetDecodeLimits(br)
+	if err := limits.Enter(); err != nil {
+		return bytesRead, &cbg.DecodeError{Type: "SignedArray", Err: err}
+	}
+	defer limits.Leave()
 
 	maj, extra, read, err := cbg.CborReadHeaderBuf(br, scratch)
 	if err != nil {
@@ -65,27 +71,33 @@ func (t *SignedArray) UnmarshalCBOR(r io.Reader) (int, error) {
 	}
 	bytesRead += read
 	if maj != cbg.MajArray {
-		return bytesRead, fmt.Errorf("cbor input should be of type array")
+		return bytesRead, &cbg.DecodeError{Type: "SignedArray", Expected: cbg.MajArray, Found: maj, Err: cbg.ErrUnexpectedType}
 	}
 
 	if extra != 1 {
-		return bytesRead, fmt.Errorf("cbor input had wrong number of fields")
+		return bytesRead, &cbg.DecodeError{Type: "SignedArray", Expected: cbg.MajArray, Found: maj, Err: fmt.Errorf("cbor input had wrong number of fields (%d)", extra)}
+	}
+	if err := limits.AddItems(extra); err != nil {
+		return bytesRead, &cbg.DecodeError{Type: "SignedArray", Expected: cbg.MajArray, Found: maj, Err: err}
 	}
 
 	// t.Signed ([]uint64) (slice)
 
 	maj, extra, read, err = cbg.CborReadHeaderBuf(br, scratch)
 	if err != nil {
-		return bytesRead, err
+		return bytesRead, cbg.WrapDecodeError(err, "SignedArray", "Signed", bytesRead)
 	}
 	bytesRead += read
 
 	if extra > cbg.MaxLength {
-		return bytesRead, fmt.Errorf("t.Signed: array too large (%d)", extra)
+		return bytesRead, &cbg.DecodeError{Type: "SignedArray", Field: "Signed", Offset: bytesRead - read, Expected: cbg.MajArray, Found: maj, Err: cbg.ErrMaxLength}
 	}
 
 	if maj != cbg.MajArray {
-		return bytesRead, fmt.Errorf("expected cbor array")
+		return bytesRead, &cbg.DecodeError{Type: "SignedArray", Field: "Signed", Offset: bytesRead - read, Expected: cbg.MajArray, Found: maj, Err: cbg.ErrUnexpectedType}
+	}
+	if err := limits.AddItems(extra); err != nil {
+		return bytesRead, &cbg.DecodeError{Type: "SignedArray", Field: "Signed", Offset: bytesRead - read, Expected: cbg.MajArray, Found: maj, Err: err}
 	}
 
 	if extra > 0 {
@@ -96,12 +108,12 @@ func (t *SignedArray) UnmarshalCBOR(r io.Reader) (int, error) {
 
 		maj, val, read, err := cbg.CborReadHeaderBuf(br, scratch)
 		if err != nil {
-			return bytesRead, xerrors.Errorf("failed to read uint64 for t.Signed slice: %w", err)
+			return bytesRead, cbg.WrapDecodeError(err, "SignedArray", fmt.Sprintf("Signed[%d]", i), bytesRead)
 		}
 		bytesRead += read
 
 		if maj != cbg.MajUnsignedInt {
-			return bytesRead, xerrors.Errorf("value read for array t.Signed was not a uint, instead got %d", maj)
+			return bytesRead, &cbg.DecodeError{Type: "SignedArray", Field: fmt.Sprintf("Signed[%d]", i), Offset: bytesRead - read, Expected: cbg.MajUnsignedInt, Found: maj, Err: cbg.ErrUnexpectedType}
 		}
 
 		t.Signed[i] = uint64(val)
@@ -110,6 +122,30 @@ func (t *SignedArray) UnmarshalCBOR(r io.Reader) (int, error) {
 	return bytesRead, nil
 }
 
+func (t *SignedArray) MarshalDAGJSON(w io.Writer) error {
+	return cbg.MarshalDAGJSON(w, t)
+}
+
+func (t *SignedArray) UnmarshalDAGJSON(r io.Reader) error {
+	return cbg.UnmarshalDAGJSON(r, t)
+}
+
+func (t *SignedArray) Links() []cid.Cid {
+	var links []cid.Cid
+	_ = t.ForEachLink(func(_ string, c cid.Cid) error {
+		links = append(links, c)
+		return nil
+	})
+	return links
+}
+
+func (t *SignedArray) ForEachLink(cb func(path string, c cid.Cid) error) error {
+	if t == nil {
+		return nil
+	}
+	return nil
+}
+
 var lengthBufSimpleTypeOne = []byte{139}
 
 func (t *SimpleTypeOne) MarshalCBOR(w io.Writer) (n int, err error) {
@@ -270,6 +306,11 @@ func (t *SimpleTypeOne) UnmarshalCBOR(r io.Reader) (int, error) {
 
 	br := cbg.GetPeeker(r)
 	scratch := make([]byte, 8)
+	limits := cbg.GetDecodeLimits(br)
+	if err := limits.Enter(); err != nil {
+		return bytesRead, &cbg.DecodeError{Type: "SimpleTypeOne", Err: err}
+	}
+	defer limits.Leave()
 
 	maj, extra, read, err := cbg.CborReadHeaderBuf(br, scratch)
 	if err != nil {
@@ -277,11 +318,14 @@ func (t *SimpleTypeOne) UnmarshalCBOR(r io.Reader) (int, error) {
 	}
 	bytesRead += read
 	if maj != cbg.MajArray {
-		return bytesRead, fmt.Errorf("cbor input should be of type array")
+		return bytesRead, &cbg.DecodeError{Type: "SimpleTypeOne", Expected: cbg.MajArray, Found: maj, Err: cbg.ErrUnexpectedType}
 	}
 
 	if extra != 11 {
-		return bytesRead, fmt.Errorf("cbor input had wrong number of fields")
+		return bytesRead, &cbg.DecodeError{Type: "SimpleTypeOne", Expected: cbg.MajArray, Found: maj, Err: fmt.Errorf("cbor input had wrong number of fields (%d)", extra)}
+	}
+	if err := limits.AddItems(extra); err != nil {
+		return bytesRead, &cbg.DecodeError{Type: "SimpleTypeOne", Expected: cbg.MajArray, Found: maj, Err: err}
 	}
 
 	// t.Foo (string) (string)
@@ -289,7 +333,7 @@ func (t *SimpleTypeOne) UnmarshalCBOR(r io.Reader) (int, error) {
 	{
 		sval, read, err := cbg.ReadStringBuf(br, scratch)
 		if err != nil {
-			return bytesRead, err
+			return bytesRead, cbg.WrapDecodeError(err, "SimpleTypeOne", "Foo", bytesRead)
 		}
 		bytesRead += read
 
@@ -301,11 +345,11 @@ func (t *SimpleTypeOne) UnmarshalCBOR(r io.Reader) (int, error) {
 
 		maj, extra, read, err = cbg.CborReadHeaderBuf(br, scratch)
 		if err != nil {
-			return bytesRead, err
+			return bytesRead, cbg.WrapDecodeError(err, "SimpleTypeOne", "Value", bytesRead)
 		}
 		bytesRead += read
 		if maj != cbg.MajUnsignedInt {
-			return bytesRead, fmt.Errorf("wrong type for uint64 field")
+			return bytesRead, &cbg.DecodeError{Type: "SimpleTypeOne", Field: "Value", Offset: bytesRead - read, Expected: cbg.MajUnsignedInt, Found: maj, Err: cbg.ErrUnexpectedType}
 		}
 		t.Value = uint64(extra)
 
@@ -314,15 +358,18 @@ func (t *SimpleTypeOne) UnmarshalCBOR(r io.Reader) (int, error) {
 
 	maj, extra, read, err = cbg.CborReadHeaderBuf(br, scratch)
 	if err != nil {
-		return bytesRead, err
+		return bytesRead, cbg.WrapDecodeError(err, "SimpleTypeOne", "Binary", bytesRead)
 	}
 	bytesRead += read
 
 	if extra > cbg.ByteArrayMaxLen {
-		return bytesRead, fmt.Errorf("t.Binary: byte array too large (%d)", extra)
+		return bytesRead, &cbg.DecodeError{Type: "SimpleTypeOne", Field: "Binary", Offset: bytesRead - read, Expected: cbg.MajByteString, Found: maj, Err: cbg.ErrMaxLength}
 	}
 	if maj != cbg.MajByteString {
-		return bytesRead, fmt.Errorf("expected byte array")
+		return bytesRead, &cbg.DecodeError{Type: "SimpleTypeOne", Field: "Binary", Offset: bytesRead - read, Expected: cbg.MajByteString, Found: maj, Err: cbg.ErrUnexpectedType}
+	}
+	if err := limits.AddBytes(extra); err != nil {
+		return bytesRead, &cbg.DecodeError{Type: "SimpleTypeOne", Field: "Binary", Offset: bytesRead - read, Expected: cbg.MajByteString, Found: maj, Err: err}
 	}
 
 	if extra > 0 {
@@ -330,7 +377,7 @@ func (t *SimpleTypeOne) UnmarshalCBOR(r io.Reader) (int, error) {
 	}
 
 	if read, err := io.ReadFull(br, t.Binary[:]); err != nil {
-		return bytesRead, err
+		return bytesRead, cbg.WrapDecodeError(err, "SimpleTypeOne", "Binary", bytesRead)
 	} else {
 		bytesRead += read
 	}
@@ -339,23 +386,23 @@ func (t *SimpleTypeOne) UnmarshalCBOR(r io.Reader) (int, error) {
 		maj, extra, read, err := cbg.CborReadHeaderBuf(br, scratch)
 		var extraI int64
 		if err != nil {
-			return bytesRead, err
+			return bytesRead, cbg.WrapDecodeError(err, "SimpleTypeOne", "Signed", bytesRead)
 		}
 		bytesRead += read
 		switch maj {
 		case cbg.MajUnsignedInt:
 			extraI = int64(extra)
 			if extraI < 0 {
-				return bytesRead, fmt.Errorf("int64 positive overflow")
+				return bytesRead, &cbg.DecodeError{Type: "SimpleTypeOne", Field: "Signed", Offset: bytesRead - read, Expected: maj, Found: maj, Err: cbg.ErrOverflow}
 			}
 		case cbg.MajNegativeInt:
 			extraI = int64(extra)
 			if extraI < 0 {
-				return bytesRead, fmt.Errorf("int64 negative oveflow")
+				return bytesRead, &cbg.DecodeError{Type: "SimpleTypeOne", Field: "Signed", Offset: bytesRead - read, Expected: maj, Found: maj, Err: cbg.ErrOverflow}
 			}
 			extraI = -1 - extraI
 		default:
-			return bytesRead, fmt.Errorf("wrong type for int64 field: %d", maj)
+			return bytesRead, &cbg.DecodeError{Type: "SimpleTypeOne", Field: "Signed", Offset: bytesRead - read, Expected: cbg.MajUnsignedInt, Found: maj, Err: cbg.ErrUnexpectedType}
 		}
 
 		t.Signed = int64(extraI)
@@ -365,7 +412,7 @@ func (t *SimpleTypeOne) UnmarshalCBOR(r io.Reader) (int, error) {
 	{
 		sval, read, err := cbg.ReadStringBuf(br, scratch)
 		if err != nil {
-			return bytesRead, err
+			return bytesRead, cbg.WrapDecodeError(err, "SimpleTypeOne", "NString", bytesRead)
 		}
 		bytesRead += read
 
@@ -375,42 +422,42 @@ func (t *SimpleTypeOne) UnmarshalCBOR(r io.Reader) (int, error) {
 
 	maj, extra, read, err = cbg.CborReadHeaderBuf(br, scratch)
 	if err != nil {
-		return bytesRead, err
+		return bytesRead, cbg.WrapDecodeError(err, "SimpleTypeOne", "U8", bytesRead)
 	}
 	bytesRead += read
 	if maj != cbg.MajUnsignedInt {
-		return bytesRead, fmt.Errorf("wrong type for uint64 field")
+		return bytesRead, &cbg.DecodeError{Type: "SimpleTypeOne", Field: "U8", Offset: bytesRead - read, Expected: cbg.MajUnsignedInt, Found: maj, Err: cbg.ErrUnexpectedType}
 	}
-	if extra > math.MaxUint64 {
-		return bytesRead, fmt.Errorf("integer in input was too large for uint64 field")
+	if extra > math.MaxUint8 {
+		return bytesRead, &cbg.DecodeError{Type: "SimpleTypeOne", Field: "U8", Offset: bytesRead - read, Expected: cbg.MajUnsignedInt, Found: maj, Err: cbg.ErrOverflow}
 	}
 	t.U8 = uint8(extra)
 	// t.U16 (uint16) (uint16)
 
 	maj, extra, read, err = cbg.CborReadHeaderBuf(br, scratch)
 	if err != nil {
-		return bytesRead, err
+		return bytesRead, cbg.WrapDecodeError(err, "SimpleTypeOne", "U16", bytesRead)
 	}
 	bytesRead += read
 	if maj != cbg.MajUnsignedInt {
-		return bytesRead, fmt.Errorf("wrong type for uint64 field")
+		return bytesRead, &cbg.DecodeError{Type: "SimpleTypeOne", Field: "U16", Offset: bytesRead - read, Expected: cbg.MajUnsignedInt, Found: maj, Err: cbg.ErrUnexpectedType}
 	}
-	if extra > math.MaxUint64 {
-		return bytesRead, fmt.Errorf("integer in input was too large for uint64 field")
+	if extra > math.MaxUint16 {
+		return bytesRead, &cbg.DecodeError{Type: "SimpleTypeOne", Field: "U16", Offset: bytesRead - read, Expected: cbg.MajUnsignedInt, Found: maj, Err: cbg.ErrOverflow}
 	}
 	t.U16 = uint16(extra)
 	// t.U32 (uint32) (uint32)
 
 	maj, extra, read, err = cbg.CborReadHeaderBuf(br, scratch)
 	if err != nil {
-		return bytesRead, err
+		return bytesRead, cbg.WrapDecodeError(err, "SimpleTypeOne", "U32", bytesRead)
 	}
 	bytesRead += read
 	if maj != cbg.MajUnsignedInt {
-		return bytesRead, fmt.Errorf("wrong type for uint64 field")
+		return bytesRead, &cbg.DecodeError{Type: "SimpleTypeOne", Field: "U32", Offset: bytesRead - read, Expected: cbg.MajUnsignedInt, Found: maj, Err: cbg.ErrUnexpectedType}
 	}
-	if extra > math.MaxUint64 {
-		return bytesRead, fmt.Errorf("integer in input was too large for uint64 field")
+	if extra > math.MaxUint32 {
+		return bytesRead, &cbg.DecodeError{Type: "SimpleTypeOne", Field: "U32", Offset: bytesRead - read, Expected: cbg.MajUnsignedInt, Found: maj, Err: cbg.ErrOverflow}
 	}
 	t.U32 = uint32(extra)
 	// t.I8 (int8) (int8)
@@ -418,23 +465,27 @@ func (t *SimpleTypeOne) UnmarshalCBOR(r io.Reader) (int, error) {
 		maj, extra, read, err := cbg.CborReadHeaderBuf(br, scratch)
 		var extraI int64
 		if err != nil {
-			return bytesRead, err
+			return bytesRead, cbg.WrapDecodeError(err, "SimpleTypeOne", "I8", bytesRead)
 		}
 		bytesRead += read
 		switch maj {
 		case cbg.MajUnsignedInt:
 			extraI = int64(extra)
 			if extraI < 0 {
-				return bytesRead, fmt.Errorf("int64 positive overflow")
+				return bytesRead, &cbg.DecodeError{Type: "SimpleTypeOne", Field: "I8", Offset: bytesRead - read, Expected: maj, Found: maj, Err: cbg.ErrOverflow}
 			}
 		case cbg.MajNegativeInt:
 			extraI = int64(extra)
 			if extraI < 0 {
-				return bytesRead, fmt.Errorf("int64 negative oveflow")
+				return bytesRead, &cbg.DecodeError{Type: "SimpleTypeOne", Field: "I8", Offset: bytesRead - read, Expected: maj, Found: maj, Err: cbg.ErrOverflow}
 			}
 			extraI = -1 - extraI
 		default:
-			return bytesRead, fmt.Errorf("wrong type for int64 field: %d", maj)
+			return bytesRead, &cbg.DecodeError{Type: "SimpleTypeOne", Field: "I8", Offset: bytesRead - read, Expected: cbg.MajUnsignedInt, Found: maj, Err: cbg.ErrUnexpectedType}
+		}
+
+		if extraI > math.MaxInt8 || extraI < math.MinInt8 {
+			return bytesRead, &cbg.DecodeError{Type: "SimpleTypeOne", Field: "I8", Offset: bytesRead - read, Expected: maj, Found: maj, Err: cbg.ErrOverflow}
 		}
 
 		t.I8 = int8(extraI)
@@ -444,23 +495,27 @@ func (t *SimpleTypeOne) UnmarshalCBOR(r io.Reader) (int, error) {
 		maj, extra, read, err := cbg.CborReadHeaderBuf(br, scratch)
 		var extraI int64
 		if err != nil {
-			return bytesRead, err
+			return bytesRead, cbg.WrapDecodeError(err, "SimpleTypeOne", "I16", bytesRead)
 		}
 		bytesRead += read
 		switch maj {
 		case cbg.MajUnsignedInt:
 			extraI = int64(extra)
 			if extraI < 0 {
-				return bytesRead, fmt.Errorf("int64 positive overflow")
+				return bytesRead, &cbg.DecodeError{Type: "SimpleTypeOne", Field: "I16", Offset: bytesRead - read, Expected: maj, Found: maj, Err: cbg.ErrOverflow}
 			}
 		case cbg.MajNegativeInt:
 			extraI = int64(extra)
 			if extraI < 0 {
-				return bytesRead, fmt.Errorf("int64 negative oveflow")
+				return bytesRead, &cbg.DecodeError{Type: "SimpleTypeOne", Field: "I16", Offset: bytesRead - read, Expected: maj, Found: maj, Err: cbg.ErrOverflow}
 			}
 			extraI = -1 - extraI
 		default:
-			return bytesRead, fmt.Errorf("wrong type for int64 field: %d", maj)
+			return bytesRead, &cbg.DecodeError{Type: "SimpleTypeOne", Field: "I16", Offset: bytesRead - read, Expected: cbg.MajUnsignedInt, Found: maj, Err: cbg.ErrUnexpectedType}
+		}
+
+		if extraI > math.MaxInt16 || extraI < math.MinInt16 {
+			return bytesRead, &cbg.DecodeError{Type: "SimpleTypeOne", Field: "I16", Offset: bytesRead - read, Expected: maj, Found: maj, Err: cbg.ErrOverflow}
 		}
 
 		t.I16 = int16(extraI)
@@ -470,23 +525,27 @@ func (t *SimpleTypeOne) UnmarshalCBOR(r io.Reader) (int, error) {
 		maj, extra, read, err := cbg.CborReadHeaderBuf(br, scratch)
 		var extraI int64
 		if err != nil {
-			return bytesRead, err
+			return bytesRead, cbg.WrapDecodeError(err, "SimpleTypeOne", "I32", bytesRead)
 		}
 		bytesRead += read
 		switch maj {
 		case cbg.MajUnsignedInt:
 			extraI = int64(extra)
 			if extraI < 0 {
-				return bytesRead, fmt.Errorf("int64 positive overflow")
+				return bytesRead, &cbg.DecodeError{Type: "SimpleTypeOne", Field: "I32", Offset: bytesRead - read, Expected: maj, Found: maj, Err: cbg.ErrOverflow}
 			}
 		case cbg.MajNegativeInt:
 			extraI = int64(extra)
 			if extraI < 0 {
-				return bytesRead, fmt.Errorf("int64 negative oveflow")
+				return bytesRead, &cbg.DecodeError{Type: "SimpleTypeOne", Field: "I32", Offset: bytesRead - read, Expected: maj, Found: maj, Err: cbg.ErrOverflow}
 			}
 			extraI = -1 - extraI
 		default:
-			return bytesRead, fmt.Errorf("wrong type for int64 field: %d", maj)
+			return bytesRead, &cbg.DecodeError{Type: "SimpleTypeOne", Field: "I32", Offset: bytesRead - read, Expected: cbg.MajUnsignedInt, Found: maj, Err: cbg.ErrUnexpectedType}
+		}
+
+		if extraI > math.MaxInt32 || extraI < math.MinInt32 {
+			return bytesRead, &cbg.DecodeError{Type: "SimpleTypeOne", Field: "I32", Offset: bytesRead - read, Expected: maj, Found: maj, Err: cbg.ErrOverflow}
 		}
 
 		t.I32 = int32(extraI)
@@ -494,6 +553,30 @@ func (t *SimpleTypeOne) UnmarshalCBOR(r io.Reader) (int, error) {
 	return bytesRead, nil
 }
 
+func (t *SimpleTypeOne) MarshalDAGJSON(w io.Writer) error {
+	return cbg.MarshalDAGJSON(w, t)
+}
+
+func (t *SimpleTypeOne) UnmarshalDAGJSON(r io.Reader) error {
+	return cbg.UnmarshalDAGJSON(r, t)
+}
+
+func (t *SimpleTypeOne) Links() []cid.Cid {
+	var links []cid.Cid
+	_ = t.ForEachLink(func(_ string, c cid.Cid) error {
+		links = append(links, c)
+		return nil
+	})
+	return links
+}
+
+func (t *SimpleTypeOne) ForEachLink(cb func(path string, c cid.Cid) error) error {
+	if t == nil {
+		return nil
+	}
+	return nil
+}
+
 var lengthBufSimpleTypeTwo = []byte{137}
 
 func (t *SimpleTypeTwo) MarshalCBOR(w io.Writer) (n int, err error) {
@@ -679,6 +762,11 @@ func (t *SimpleTypeTwo) UnmarshalCBOR(r io.Reader) (int, error) {
 
 	br := cbg.GetPeeker(r)
 	scratch := make([]byte, 8)
+	limits := cbg.GetDecodeLimits(br)
+	if err := limits.Enter(); err != nil {
+		return bytesRead, &cbg.DecodeError{Type: "SimpleTypeTwo", Err: err}
+	}
+	defer limits.Leave()
 
 	maj, extra, read, err := cbg.CborReadHeaderBuf(br, scratch)
 	if err != nil {
@@ -686,11 +774,14 @@ func (t *SimpleTypeTwo) UnmarshalCBOR(r io.Reader) (int, error) {
 	}
 	bytesRead += read
 	if maj != cbg.MajArray {
-		return bytesRead, fmt.Errorf("cbor input should be of type array")
+		return bytesRead, &cbg.DecodeError{Type: "SimpleTypeTwo", Expected: cbg.MajArray, Found: maj, Err: cbg.ErrUnexpectedType}
 	}
 
 	if extra != 9 {
-		return bytesRead, fmt.Errorf("cbor input had wrong number of fields")
+		return bytesRead, &cbg.DecodeError{Type: "SimpleTypeTwo", Expected: cbg.MajArray, Found: maj, Err: fmt.Errorf("cbor input had wrong number of fields (%d)", extra)}
+	}
+	if err := limits.AddItems(extra); err != nil {
+		return bytesRead, &cbg.DecodeError{Type: "SimpleTypeTwo", Expected: cbg.MajArray, Found: maj, Err: err}
 	}
 
 	// t.Stuff (testing.SimpleTypeTwo) (struct)
@@ -699,17 +790,17 @@ func (t *SimpleTypeTwo) UnmarshalCBOR(r io.Reader) (int, error) {
 
 		b, err := br.ReadByte()
 		if err != nil {
-			return bytesRead, err
+			return bytesRead, cbg.WrapDecodeError(err, "SimpleTypeTwo", "Stuff", bytesRead)
 		}
 		bytesRead++
 		if b != cbg.CborNull[0] {
 			if err := br.UnreadByte(); err != nil {
-				return bytesRead, err
+				return bytesRead, cbg.WrapDecodeError(err, "SimpleTypeTwo", "Stuff", bytesRead)
 			}
 			bytesRead--
 			t.Stuff = new(SimpleTypeTwo)
 			if read, err := t.Stuff.UnmarshalCBOR(br); err != nil {
-				return bytesRead, xerrors.Errorf("unmarshaling t.Stuff pointer: %w", err)
+				return bytesRead, cbg.WrapDecodeError(err, "SimpleTypeTwo", "Stuff", bytesRead)
 			} else {
 				bytesRead += read
 			}
@@ -720,16 +811,19 @@ func (t *SimpleTypeTwo) UnmarshalCBOR(r io.Reader) (int, error) {
 
 	maj, extra, read, err = cbg.CborReadHeaderBuf(br, scratch)
 	if err != nil {
-		return bytesRead, err
+		return bytesRead, cbg.WrapDecodeError(err, "SimpleTypeTwo", "Others", bytesRead)
 	}
 	bytesRead += read
 
 	if extra > cbg.MaxLength {
-		return bytesRead, fmt.Errorf("t.Others: array too large (%d)", extra)
+		return bytesRead, &cbg.DecodeError{Type: "SimpleTypeTwo", Field: "Others", Offset: bytesRead - read, Expected: cbg.MajArray, Found: maj, Err: cbg.ErrMaxLength}
 	}
 
 	if maj != cbg.MajArray {
-		return bytesRead, fmt.Errorf("expected cbor array")
+		return bytesRead, &cbg.DecodeError{Type: "SimpleTypeTwo", Field: "Others", Offset: bytesRead - read, Expected: cbg.MajArray, Found: maj, Err: cbg.ErrUnexpectedType}
+	}
+	if err := limits.AddItems(extra); err != nil {
+		return bytesRead, &cbg.DecodeError{Type: "SimpleTypeTwo", Field: "Others", Offset: bytesRead - read, Expected: cbg.MajArray, Found: maj, Err: err}
 	}
 
 	if extra > 0 {
@@ -740,12 +834,12 @@ func (t *SimpleTypeTwo) UnmarshalCBOR(r io.Reader) (int, error) {
 
 		maj, val, read, err := cbg.CborReadHeaderBuf(br, scratch)
 		if err != nil {
-			return bytesRead, xerrors.Errorf("failed to read uint64 for t.Others slice: %w", err)
+			return bytesRead, cbg.WrapDecodeError(err, "SimpleTypeTwo", fmt.Sprintf("Others[%d]", i), bytesRead)
 		}
 		bytesRead += read
 
 		if maj != cbg.MajUnsignedInt {
-			return bytesRead, xerrors.Errorf("value read for array t.Others was not a uint, instead got %d", maj)
+			return bytesRead, &cbg.DecodeError{Type: "SimpleTypeTwo", Field: fmt.Sprintf("Others[%d]", i), Offset: bytesRead - read, Expected: cbg.MajUnsignedInt, Found: maj, Err: cbg.ErrUnexpectedType}
 		}
 
 		t.Others[i] = uint64(val)
@@ -755,16 +849,19 @@ func (t *SimpleTypeTwo) UnmarshalCBOR(r io.Reader) (int, error) {
 
 	maj, extra, read, err = cbg.CborReadHeaderBuf(br, scratch)
 	if err != nil {
-		return bytesRead, err
+		return bytesRead, cbg.WrapDecodeError(err, "SimpleTypeTwo", "SignedOthers", bytesRead)
 	}
 	bytesRead += read
 
 	if extra > cbg.MaxLength {
-		return bytesRead, fmt.Errorf("t.SignedOthers: array too large (%d)", extra)
+		return bytesRead, &cbg.DecodeError{Type: "SimpleTypeTwo", Field: "SignedOthers", Offset: bytesRead - read, Expected: cbg.MajArray, Found: maj, Err: cbg.ErrMaxLength}
 	}
 
 	if maj != cbg.MajArray {
-		return bytesRead, fmt.Errorf("expected cbor array")
+		return bytesRead, &cbg.DecodeError{Type: "SimpleTypeTwo", Field: "SignedOthers", Offset: bytesRead - read, Expected: cbg.MajArray, Found: maj, Err: cbg.ErrUnexpectedType}
+	}
+	if err := limits.AddItems(extra); err != nil {
+		return bytesRead, &cbg.DecodeError{Type: "SimpleTypeTwo", Field: "SignedOthers", Offset: bytesRead - read, Expected: cbg.MajArray, Found: maj, Err: err}
 	}
 
 	if extra > 0 {
@@ -776,23 +873,23 @@ func (t *SimpleTypeTwo) UnmarshalCBOR(r io.Reader) (int, error) {
 			maj, extra, read, err := cbg.CborReadHeaderBuf(br, scratch)
 			var extraI int64
 			if err != nil {
-				return bytesRead, err
+				return bytesRead, cbg.WrapDecodeError(err, "SimpleTypeTwo", fmt.Sprintf("SignedOthers[%d]", i), bytesRead)
 			}
 			bytesRead += read
 			switch maj {
 			case cbg.MajUnsignedInt:
 				extraI = int64(extra)
 				if extraI < 0 {
-					return bytesRead, fmt.Errorf("int64 positive overflow")
+					return bytesRead, &cbg.DecodeError{Type: "SimpleTypeTwo", Field: fmt.Sprintf("SignedOthers[%d]", i), Offset: bytesRead - read, Expected: maj, Found: maj, Err: cbg.ErrOverflow}
 				}
 			case cbg.MajNegativeInt:
 				extraI = int64(extra)
 				if extraI < 0 {
-					return bytesRead, fmt.Errorf("int64 negative oveflow")
+					return bytesRead, &cbg.DecodeError{Type: "SimpleTypeTwo", Field: fmt.Sprintf("SignedOthers[%d]", i), Offset: bytesRead - read, Expected: maj, Found: maj, Err: cbg.ErrOverflow}
 				}
 				extraI = -1 - extraI
 			default:
-				return bytesRead, fmt.Errorf("wrong type for int64 field: %d", maj)
+				return bytesRead, &cbg.DecodeError{Type: "SimpleTypeTwo", Field: fmt.Sprintf("SignedOthers[%d]", i), Offset: bytesRead - read, Expected: cbg.MajUnsignedInt, Found: maj, Err: cbg.ErrUnexpectedType}
 			}
 
 			t.SignedOthers[i] = int64(extraI)
@@ -803,16 +900,19 @@ func (t *SimpleTypeTwo) UnmarshalCBOR(r io.Reader) (int, error) {
 
 	maj, extra, read, err = cbg.CborReadHeaderBuf(br, scratch)
 	if err != nil {
-		return bytesRead, err
+		return bytesRead, cbg.WrapDecodeError(err, "SimpleTypeTwo", "Test", bytesRead)
 	}
 	bytesRead += read
 
 	if extra > cbg.MaxLength {
-		return bytesRead, fmt.Errorf("t.Test: array too large (%d)", extra)
+		return bytesRead, &cbg.DecodeError{Type: "SimpleTypeTwo", Field: "Test", Offset: bytesRead - read, Expected: cbg.MajArray, Found: maj, Err: cbg.ErrMaxLength}
 	}
 
 	if maj != cbg.MajArray {
-		return bytesRead, fmt.Errorf("expected cbor array")
+		return bytesRead, &cbg.DecodeError{Type: "SimpleTypeTwo", Field: "Test", Offset: bytesRead - read, Expected: cbg.MajArray, Found: maj, Err: cbg.ErrUnexpectedType}
+	}
+	if err := limits.AddItems(extra); err != nil {
+		return bytesRead, &cbg.DecodeError{Type: "SimpleTypeTwo", Field: "Test", Offset: bytesRead - read, Expected: cbg.MajArray, Found: maj, Err: err}
 	}
 
 	if extra > 0 {
@@ -827,15 +927,18 @@ func (t *SimpleTypeTwo) UnmarshalCBOR(r io.Reader) (int, error) {
 
 			maj, extra, read, err = cbg.CborReadHeaderBuf(br, scratch)
 			if err != nil {
-				return bytesRead, err
+				return bytesRead, cbg.WrapDecodeError(err, "SimpleTypeTwo", fmt.Sprintf("Test[%d]", i), bytesRead)
 			}
 			bytesRead += read
 
 			if extra > cbg.ByteArrayMaxLen {
-				return bytesRead, fmt.Errorf("t.Test[i]: byte array too large (%d)", extra)
+				return bytesRead, &cbg.DecodeError{Type: "SimpleTypeTwo", Field: fmt.Sprintf("Test[%d]", i), Offset: bytesRead - read, Expected: cbg.MajByteString, Found: maj, Err: cbg.ErrMaxLength}
 			}
 			if maj != cbg.MajByteString {
-				return bytesRead, fmt.Errorf("expected byte array")
+				return bytesRead, &cbg.DecodeError{Type: "SimpleTypeTwo", Field: fmt.Sprintf("Test[%d]", i), Offset: bytesRead - read, Expected: cbg.MajByteString, Found: maj, Err: cbg.ErrUnexpectedType}
+			}
+			if err := limits.AddBytes(extra); err != nil {
+				return bytesRead, &cbg.DecodeError{Type: "SimpleTypeTwo", Field: fmt.Sprintf("Test[%d]", i), Offset: bytesRead - read, Expected: cbg.MajByteString, Found: maj, Err: err}
 			}
 
 			if extra > 0 {
@@ -843,7 +946,7 @@ func (t *SimpleTypeTwo) UnmarshalCBOR(r io.Reader) (int, error) {
 			}
 
 			if read, err := io.ReadFull(br, t.Test[i][:]); err != nil {
-				return bytesRead, err
+				return bytesRead, cbg.WrapDecodeError(err, "SimpleTypeTwo", fmt.Sprintf("Test[%d]", i), bytesRead)
 			} else {
 				bytesRead += read
 			}
@@ -855,7 +958,7 @@ func (t *SimpleTypeTwo) UnmarshalCBOR(r io.Reader) (int, error) {
 	{
 		sval, read, err := cbg.ReadStringBuf(br, scratch)
 		if err != nil {
-			return bytesRead, err
+			return bytesRead, cbg.WrapDecodeError(err, "SimpleTypeTwo", "Dog", bytesRead)
 		}
 		bytesRead += read
 
@@ -865,16 +968,19 @@ func (t *SimpleTypeTwo) UnmarshalCBOR(r io.Reader) (int, error) {
 
 	maj, extra, read, err = cbg.CborReadHeaderBuf(br, scratch)
 	if err != nil {
-		return bytesRead, err
+		return bytesRead, cbg.WrapDecodeError(err, "SimpleTypeTwo", "Numbers", bytesRead)
 	}
 	bytesRead += read
 
 	if extra > cbg.MaxLength {
-		return bytesRead, fmt.Errorf("t.Numbers: array too large (%d)", extra)
+		return bytesRead, &cbg.DecodeError{Type: "SimpleTypeTwo", Field: "Numbers", Offset: bytesRead - read, Expected: cbg.MajArray, Found: maj, Err: cbg.ErrMaxLength}
 	}
 
 	if maj != cbg.MajArray {
-		return bytesRead, fmt.Errorf("expected cbor array")
+		return bytesRead, &cbg.DecodeError{Type: "SimpleTypeTwo", Field: "Numbers", Offset: bytesRead - read, Expected: cbg.MajArray, Found: maj, Err: cbg.ErrUnexpectedType}
+	}
+	if err := limits.AddItems(extra); err != nil {
+		return bytesRead, &cbg.DecodeError{Type: "SimpleTypeTwo", Field: "Numbers", Offset: bytesRead - read, Expected: cbg.MajArray, Found: maj, Err: err}
 	}
 
 	if extra > 0 {
@@ -885,12 +991,12 @@ func (t *SimpleTypeTwo) UnmarshalCBOR(r io.Reader) (int, error) {
 
 		maj, val, read, err := cbg.CborReadHeaderBuf(br, scratch)
 		if err != nil {
-			return bytesRead, xerrors.Errorf("failed to read uint64 for t.Numbers slice: %w", err)
+			return bytesRead, cbg.WrapDecodeError(err, "SimpleTypeTwo", fmt.Sprintf("Numbers[%d]", i), bytesRead)
 		}
 		bytesRead += read
 
 		if maj != cbg.MajUnsignedInt {
-			return bytesRead, xerrors.Errorf("value read for array t.Numbers was not a uint, instead got %d", maj)
+			return bytesRead, &cbg.DecodeError{Type: "SimpleTypeTwo", Field: fmt.Sprintf("Numbers[%d]", i), Offset: bytesRead - read, Expected: cbg.MajUnsignedInt, Found: maj, Err: cbg.ErrUnexpectedType}
 		}
 
 		t.Numbers[i] = NamedNumber(val)
@@ -902,21 +1008,21 @@ func (t *SimpleTypeTwo) UnmarshalCBOR(r io.Reader) (int, error) {
 
 		b, err := br.ReadByte()
 		if err != nil {
-			return bytesRead, err
+			return bytesRead, cbg.WrapDecodeError(err, "SimpleTypeTwo", "Pizza", bytesRead)
 		}
 		bytesRead++
 		if b != cbg.CborNull[0] {
 			if err := br.UnreadByte(); err != nil {
-				return bytesRead, err
+				return bytesRead, cbg.WrapDecodeError(err, "SimpleTypeTwo", "Pizza", bytesRead)
 			}
 			bytesRead--
 			maj, extra, read, err = cbg.CborReadHeaderBuf(br, scratch)
 			if err != nil {
-				return bytesRead, err
+				return bytesRead, cbg.WrapDecodeError(err, "SimpleTypeTwo", "Pizza", bytesRead)
 			}
 			bytesRead += read
 			if maj != cbg.MajUnsignedInt {
-				return bytesRead, fmt.Errorf("wrong type for uint64 field")
+				return bytesRead, &cbg.DecodeError{Type: "SimpleTypeTwo", Field: "Pizza", Offset: bytesRead - read, Expected: cbg.MajUnsignedInt, Found: maj, Err: cbg.ErrUnexpectedType}
 			}
 			typed := uint64(extra)
 			t.Pizza = &typed
@@ -929,21 +1035,21 @@ func (t *SimpleTypeTwo) UnmarshalCBOR(r io.Reader) (int, error) {
 
 		b, err := br.ReadByte()
 		if err != nil {
-			return bytesRead, err
+			return bytesRead, cbg.WrapDecodeError(err, "SimpleTypeTwo", "PointyPizza", bytesRead)
 		}
 		bytesRead++
 		if b != cbg.CborNull[0] {
 			if err := br.UnreadByte(); err != nil {
-				return bytesRead, err
+				return bytesRead, cbg.WrapDecodeError(err, "SimpleTypeTwo", "PointyPizza", bytesRead)
 			}
 			bytesRead--
 			maj, extra, read, err = cbg.CborReadHeaderBuf(br, scratch)
 			if err != nil {
-				return bytesRead, err
+				return bytesRead, cbg.WrapDecodeError(err, "SimpleTypeTwo", "PointyPizza", bytesRead)
 			}
 			bytesRead += read
 			if maj != cbg.MajUnsignedInt {
-				return bytesRead, fmt.Errorf("wrong type for uint64 field")
+				return bytesRead, &cbg.DecodeError{Type: "SimpleTypeTwo", Field: "PointyPizza", Offset: bytesRead - read, Expected: cbg.MajUnsignedInt, Found: maj, Err: cbg.ErrUnexpectedType}
 			}
 			typed := NamedNumber(extra)
 			t.PointyPizza = &typed
@@ -954,20 +1060,23 @@ func (t *SimpleTypeTwo) UnmarshalCBOR(r io.Reader) (int, error) {
 
 	maj, extra, read, err = cbg.CborReadHeaderBuf(br, scratch)
 	if err != nil {
-		return bytesRead, err
+		return bytesRead, cbg.WrapDecodeError(err, "SimpleTypeTwo", "Arrrrrghay", bytesRead)
 	}
 	bytesRead += read
 
 	if extra > cbg.MaxLength {
-		return bytesRead, fmt.Errorf("t.Arrrrrghay: array too large (%d)", extra)
+		return bytesRead, &cbg.DecodeError{Type: "SimpleTypeTwo", Field: "Arrrrrghay", Offset: bytesRead - read, Expected: cbg.MajArray, Found: maj, Err: cbg.ErrMaxLength}
 	}
 
 	if maj != cbg.MajArray {
-		return bytesRead, fmt.Errorf("expected cbor array")
+		return bytesRead, &cbg.DecodeError{Type: "SimpleTypeTwo", Field: "Arrrrrghay", Offset: bytesRead - read, Expected: cbg.MajArray, Found: maj, Err: cbg.ErrUnexpectedType}
+	}
+	if err := limits.AddItems(extra); err != nil {
+		return bytesRead, &cbg.DecodeError{Type: "SimpleTypeTwo", Field: "Arrrrrghay", Offset: bytesRead - read, Expected: cbg.MajArray, Found: maj, Err: err}
 	}
 
 	if extra != 3 {
-		return bytesRead, fmt.Errorf("expected array to have 3 elements")
+		return bytesRead, &cbg.DecodeError{Type: "SimpleTypeTwo", Field: "Arrrrrghay", Offset: bytesRead - read, Expected: cbg.MajArray, Found: maj, Err: fmt.Errorf("expected 3 elements, got %d", extra)}
 	}
 
 	t.Arrrrrghay = [3]SimpleTypeOne{}
@@ -976,7 +1085,7 @@ func (t *SimpleTypeTwo) UnmarshalCBOR(r io.Reader) (int, error) {
 
 		var v SimpleTypeOne
 		if read, err := v.UnmarshalCBOR(br); err != nil {
-			return bytesRead, err
+			return bytesRead, cbg.WrapDecodeError(err, "SimpleTypeTwo", fmt.Sprintf("Arrrrrghay[%d]", i), bytesRead)
 		} else {
 			bytesRead += read
 		}
@@ -987,6 +1096,30 @@ func (t *SimpleTypeTwo) UnmarshalCBOR(r io.Reader) (int, error) {
 	return bytesRead, nil
 }
 
+func (t *SimpleTypeTwo) MarshalDAGJSON(w io.Writer) error {
+	return cbg.MarshalDAGJSON(w, t)
+}
+
+func (t *SimpleTypeTwo) UnmarshalDAGJSON(r io.Reader) error {
+	return cbg.UnmarshalDAGJSON(r, t)
+}
+
+func (t *SimpleTypeTwo) Links() []cid.Cid {
+	var links []cid.Cid
+	_ = t.ForEachLink(func(_ string, c cid.Cid) error {
+		links = append(links, c)
+		return nil
+	})
+	return links
+}
+
+func (t *SimpleTypeTwo) ForEachLink(cb func(path string, c cid.Cid) error) error {
+	if t == nil {
+		return nil
+	}
+	return nil
+}
+
 var lengthBufDeferredContainer = []byte{131}
 
 func (t *DeferredContainer) MarshalCBOR(w io.Writer) (n int, err error) {
@@ -1032,6 +1165,11 @@ func (t *DeferredContainer) UnmarshalCBOR(r io.Reader) (int, error) {
 
 	br := cbg.GetPeeker(r)
 	scratch := make([]byte, 8)
+	limits := cbg.GetDecodeLimits(br)
+	if err := limits.Enter(); err != nil {
+		return bytesRead, &cbg.DecodeError{Type: "DeferredContainer", Err: err}
+	}
+	defer limits.Leave()
 
 	maj, extra, read, err := cbg.CborReadHeaderBuf(br, scratch)
 	if err != nil {
@@ -1039,11 +1177,14 @@ func (t *DeferredContainer) UnmarshalCBOR(r io.Reader) (int, error) {
 	}
 	bytesRead += read
 	if maj != cbg.MajArray {
-		return bytesRead, fmt.Errorf("cbor input should be of type array")
+		return bytesRead, &cbg.DecodeError{Type: "DeferredContainer", Expected: cbg.MajArray, Found: maj, Err: cbg.ErrUnexpectedType}
 	}
 
 	if extra != 3 {
-		return bytesRead, fmt.Errorf("cbor input had wrong number of fields")
+		return bytesRead, &cbg.DecodeError{Type: "DeferredContainer", Expected: cbg.MajArray, Found: maj, Err: fmt.Errorf("cbor input had wrong number of fields (%d)", extra)}
+	}
+	if err := limits.AddItems(extra); err != nil {
+		return bytesRead, &cbg.DecodeError{Type: "DeferredContainer", Expected: cbg.MajArray, Found: maj, Err: err}
 	}
 
 	// t.Stuff (testing.SimpleTypeOne) (struct)
@@ -1052,17 +1193,17 @@ func (t *DeferredContainer) UnmarshalCBOR(r io.Reader) (int, error) {
 
 		b, err := br.ReadByte()
 		if err != nil {
-			return bytesRead, err
+			return bytesRead, cbg.WrapDecodeError(err, "DeferredContainer", "Stuff", bytesRead)
 		}
 		bytesRead++
 		if b != cbg.CborNull[0] {
 			if err := br.UnreadByte(); err != nil {
-				return bytesRead, err
+				return bytesRead, cbg.WrapDecodeError(err, "DeferredContainer", "Stuff", bytesRead)
 			}
 			bytesRead--
 			t.Stuff = new(SimpleTypeOne)
 			if read, err := t.Stuff.UnmarshalCBOR(br); err != nil {
-				return bytesRead, xerrors.Errorf("unmarshaling t.Stuff pointer: %w", err)
+				return bytesRead, cbg.WrapDecodeError(err, "DeferredContainer", "Stuff", bytesRead)
 			} else {
 				bytesRead += read
 			}
@@ -1076,7 +1217,7 @@ func (t *DeferredContainer) UnmarshalCBOR(r io.Reader) (int, error) {
 		t.Deferred = new(cbg.Deferred)
 
 		if read, err := t.Deferred.UnmarshalCBOR(br); err != nil {
-			return bytesRead, xerrors.Errorf("failed to read deferred field: %w", err)
+			return bytesRead, cbg.WrapDecodeError(err, "DeferredContainer", "Deferred", bytesRead)
 		} else {
 			bytesRead += read
 		}
@@ -1087,11 +1228,11 @@ func (t *DeferredContainer) UnmarshalCBOR(r io.Reader) (int, error) {
 
 		maj, extra, read, err = cbg.CborReadHeaderBuf(br, scratch)
 		if err != nil {
-			return bytesRead, err
+			return bytesRead, cbg.WrapDecodeError(err, "DeferredContainer", "Value", bytesRead)
 		}
 		bytesRead += read
 		if maj != cbg.MajUnsignedInt {
-			return bytesRead, fmt.Errorf("wrong type for uint64 field")
+			return bytesRead, &cbg.DecodeError{Type: "DeferredContainer", Field: "Value", Offset: bytesRead - read, Expected: cbg.MajUnsignedInt, Found: maj, Err: cbg.ErrUnexpectedType}
 		}
 		t.Value = uint64(extra)
 
@@ -1099,6 +1240,33 @@ func (t *DeferredContainer) UnmarshalCBOR(r io.Reader) (int, error) {
 	return bytesRead, nil
 }
 
+func (t *DeferredContainer) MarshalDAGJSON(w io.Writer) error {
+	return cbg.MarshalDAGJSON(w, t)
+}
+
+func (t *DeferredContainer) UnmarshalDAGJSON(r io.Reader) error {
+	return cbg.UnmarshalDAGJSON(r, t)
+}
+
+func (t *DeferredContainer) Links() []cid.Cid {
+	var links []cid.Cid
+	_ = t.ForEachLink(func(_ string, c cid.Cid) error {
+		links = append(links, c)
+		return nil
+	})
+	return links
+}
+
+func (t *DeferredContainer) ForEachLink(cb func(path string, c cid.Cid) error) error {
+	if t == nil {
+		return nil
+	}
+	if err := cbg.ForEachLinkIn(t.Deferred, "1", cb); err != nil {
+		return err
+	}
+	return nil
+}
+
 var lengthBufFixedArrays = []byte{131}
 
 func (t *FixedArrays) MarshalCBOR(w io.Writer) (n int, err error) {
@@ -1173,6 +1341,11 @@ func (t *FixedArrays) UnmarshalCBOR(r io.Reader) (int, error) {
 
 	br := cbg.GetPeeker(r)
 	scratch := make([]byte, 8)
+	limits := cbg.GetDecodeLimits(br)
+	if err := limits.Enter(); err != nil {
+		return bytesRead, &cbg.DecodeError{Type: "FixedArrays", Err: err}
+	}
+	defer limits.Leave()
 
 	maj, extra, read, err := cbg.CborReadHeaderBuf(br, scratch)
 	if err != nil {
@@ -1180,36 +1353,42 @@ func (t *FixedArrays) UnmarshalCBOR(r io.Reader) (int, error) {
 	}
 	bytesRead += read
 	if maj != cbg.MajArray {
-		return bytesRead, fmt.Errorf("cbor input should be of type array")
+		return bytesRead, &cbg.DecodeError{Type: "FixedArrays", Expected: cbg.MajArray, Found: maj, Err: cbg.ErrUnexpectedType}
 	}
 
 	if extra != 3 {
-		return bytesRead, fmt.Errorf("cbor input had wrong number of fields")
+		return bytesRead, &cbg.DecodeError{Type: "FixedArrays", Expected: cbg.MajArray, Found: maj, Err: fmt.Errorf("cbor input had wrong number of fields (%d)", extra)}
+	}
+	if err := limits.AddItems(extra); err != nil {
+		return bytesRead, &cbg.DecodeError{Type: "FixedArrays", Expected: cbg.MajArray, Found: maj, Err: err}
 	}
 
 	// t.Bytes ([20]uint8) (array)
 
 	maj, extra, read, err = cbg.CborReadHeaderBuf(br, scratch)
 	if err != nil {
-		return bytesRead, err
+		return bytesRead, cbg.WrapDecodeError(err, "FixedArrays", "Bytes", bytesRead)
 	}
 	bytesRead += read
 
 	if extra > cbg.ByteArrayMaxLen {
-		return bytesRead, fmt.Errorf("t.Bytes: byte array too large (%d)", extra)
+		return bytesRead, &cbg.DecodeError{Type: "FixedArrays", Field: "Bytes", Offset: bytesRead - read, Expected: cbg.MajByteString, Found: maj, Err: cbg.ErrMaxLength}
 	}
 	if maj != cbg.MajByteString {
-		return bytesRead, fmt.Errorf("expected byte array")
+		return bytesRead, &cbg.DecodeError{Type: "FixedArrays", Field: "Bytes", Offset: bytesRead - read, Expected: cbg.MajByteString, Found: maj, Err: cbg.ErrUnexpectedType}
+	}
+	if err := limits.AddBytes(extra); err != nil {
+		return bytesRead, &cbg.DecodeError{Type: "FixedArrays", Field: "Bytes", Offset: bytesRead - read, Expected: cbg.MajByteString, Found: maj, Err: err}
 	}
 
 	if extra != 20 {
-		return bytesRead, fmt.Errorf("expected array to have 20 elements")
+		return bytesRead, &cbg.DecodeError{Type: "FixedArrays", Field: "Bytes", Offset: bytesRead - read, Expected: cbg.MajByteString, Found: maj, Err: fmt.Errorf("expected 20 bytes, got %d", extra)}
 	}
 
 	t.Bytes = [20]uint8{}
 
 	if read, err := io.ReadFull(br, t.Bytes[:]); err != nil {
-		return bytesRead, err
+		return bytesRead, cbg.WrapDecodeError(err, "FixedArrays", "Bytes", bytesRead)
 	} else {
 		bytesRead += read
 	}
@@ -1217,25 +1396,28 @@ func (t *FixedArrays) UnmarshalCBOR(r io.Reader) (int, error) {
 
 	maj, extra, read, err = cbg.CborReadHeaderBuf(br, scratch)
 	if err != nil {
-		return bytesRead, err
+		return bytesRead, cbg.WrapDecodeError(err, "FixedArrays", "Uint8", bytesRead)
 	}
 	bytesRead += read
 
 	if extra > cbg.ByteArrayMaxLen {
-		return bytesRead, fmt.Errorf("t.Uint8: byte array too large (%d)", extra)
+		return bytesRead, &cbg.DecodeError{Type: "FixedArrays", Field: "Uint8", Offset: bytesRead - read, Expected: cbg.MajByteString, Found: maj, Err: cbg.ErrMaxLength}
 	}
 	if maj != cbg.MajByteString {
-		return bytesRead, fmt.Errorf("expected byte array")
+		return bytesRead, &cbg.DecodeError{Type: "FixedArrays", Field: "Uint8", Offset: bytesRead - read, Expected: cbg.MajByteString, Found: maj, Err: cbg.ErrUnexpectedType}
+	}
+	if err := limits.AddBytes(extra); err != nil {
+		return bytesRead, &cbg.DecodeError{Type: "FixedArrays", Field: "Uint8", Offset: bytesRead - read, Expected: cbg.MajByteString, Found: maj, Err: err}
 	}
 
 	if extra != 20 {
-		return bytesRead, fmt.Errorf("expected array to have 20 elements")
+		return bytesRead, &cbg.DecodeError{Type: "FixedArrays", Field: "Uint8", Offset: bytesRead - read, Expected: cbg.MajByteString, Found: maj, Err: fmt.Errorf("expected 20 bytes, got %d", extra)}
 	}
 
 	t.Uint8 = [20]uint8{}
 
 	if read, err := io.ReadFull(br, t.Uint8[:]); err != nil {
-		return bytesRead, err
+		return bytesRead, cbg.WrapDecodeError(err, "FixedArrays", "Uint8", bytesRead)
 	} else {
 		bytesRead += read
 	}
@@ -1243,20 +1425,23 @@ func (t *FixedArrays) UnmarshalCBOR(r io.Reader) (int, error) {
 
 	maj, extra, read, err = cbg.CborReadHeaderBuf(br, scratch)
 	if err != nil {
-		return bytesRead, err
+		return bytesRead, cbg.WrapDecodeError(err, "FixedArrays", "Uint64", bytesRead)
 	}
 	bytesRead += read
 
 	if extra > cbg.MaxLength {
-		return bytesRead, fmt.Errorf("t.Uint64: array too large (%d)", extra)
+		return bytesRead, &cbg.DecodeError{Type: "FixedArrays", Field: "Uint64", Offset: bytesRead - read, Expected: cbg.MajArray, Found: maj, Err: cbg.ErrMaxLength}
 	}
 
 	if maj != cbg.MajArray {
-		return bytesRead, fmt.Errorf("expected cbor array")
+		return bytesRead, &cbg.DecodeError{Type: "FixedArrays", Field: "Uint64", Offset: bytesRead - read, Expected: cbg.MajArray, Found: maj, Err: cbg.ErrUnexpectedType}
+	}
+	if err := limits.AddItems(extra); err != nil {
+		return bytesRead, &cbg.DecodeError{Type: "FixedArrays", Field: "Uint64", Offset: bytesRead - read, Expected: cbg.MajArray, Found: maj, Err: err}
 	}
 
 	if extra != 20 {
-		return bytesRead, fmt.Errorf("expected array to have 20 elements")
+		return bytesRead, &cbg.DecodeError{Type: "FixedArrays", Field: "Uint64", Offset: bytesRead - read, Expected: cbg.MajArray, Found: maj, Err: fmt.Errorf("expected 20 elements, got %d", extra)}
 	}
 
 	t.Uint64 = [20]uint64{}
@@ -1265,12 +1450,12 @@ func (t *FixedArrays) UnmarshalCBOR(r io.Reader) (int, error) {
 
 		maj, val, read, err := cbg.CborReadHeaderBuf(br, scratch)
 		if err != nil {
-			return bytesRead, xerrors.Errorf("failed to read uint64 for t.Uint64 slice: %w", err)
+			return bytesRead, cbg.WrapDecodeError(err, "FixedArrays", fmt.Sprintf("Uint64[%d]", i), bytesRead)
 		}
 		bytesRead += read
 
 		if maj != cbg.MajUnsignedInt {
-			return bytesRead, xerrors.Errorf("value read for array t.Uint64 was not a uint, instead got %d", maj)
+			return bytesRead, &cbg.DecodeError{Type: "FixedArrays", Field: fmt.Sprintf("Uint64[%d]", i), Offset: bytesRead - read, Expected: cbg.MajUnsignedInt, Found: maj, Err: cbg.ErrUnexpectedType}
 		}
 
 		t.Uint64[i] = uint64(val)
@@ -1279,6 +1464,30 @@ func (t *FixedArrays) UnmarshalCBOR(r io.Reader) (int, error) {
 	return bytesRead, nil
 }
 
+func (t *FixedArrays) MarshalDAGJSON(w io.Writer) error {
+	return cbg.MarshalDAGJSON(w, t)
+}
+
+func (t *FixedArrays) UnmarshalDAGJSON(r io.Reader) error {
+	return cbg.UnmarshalDAGJSON(r, t)
+}
+
+func (t *FixedArrays) Links() []cid.Cid {
+	var links []cid.Cid
+	_ = t.ForEachLink(func(_ string, c cid.Cid) error {
+		links = append(links, c)
+		return nil
+	})
+	return links
+}
+
+func (t *FixedArrays) ForEachLink(cb func(path string, c cid.Cid) error) error {
+	if t == nil {
+		return nil
+	}
+	return nil
+}
+
 var lengthBufThingWithSomeTime = []byte{131}
 
 func (t *ThingWithSomeTime) MarshalCBOR(w io.Writer) (n int, err error) {
@@ -1339,6 +1548,11 @@ func (t *ThingWithSomeTime) UnmarshalCBOR(r io.Reader) (int, error) {
 
 	br := cbg.GetPeeker(r)
 	scratch := make([]byte, 8)
+	limits := cbg.GetDecodeLimits(br)
+	if err := limits.Enter(); err != nil {
+		return bytesRead, &cbg.DecodeError{Type: "ThingWithSomeTime", Err: err}
+	}
+	defer limits.Leave()
 
 	maj, extra, read, err := cbg.CborReadHeaderBuf(br, scratch)
 	if err != nil {
@@ -1346,11 +1560,14 @@ func (t *ThingWithSomeTime) UnmarshalCBOR(r io.Reader) (int, error) {
 	}
 	bytesRead += read
 	if maj != cbg.MajArray {
-		return bytesRead, fmt.Errorf("cbor input should be of type array")
+		return bytesRead, &cbg.DecodeError{Type: "ThingWithSomeTime", Expected: cbg.MajArray, Found: maj, Err: cbg.ErrUnexpectedType}
 	}
 
 	if extra != 3 {
-		return bytesRead, fmt.Errorf("cbor input had wrong number of fields")
+		return bytesRead, &cbg.DecodeError{Type: "ThingWithSomeTime", Expected: cbg.MajArray, Found: maj, Err: fmt.Errorf("cbor input had wrong number of fields (%d)", extra)}
+	}
+	if err := limits.AddItems(extra); err != nil {
+		return bytesRead, &cbg.DecodeError{Type: "ThingWithSomeTime", Expected: cbg.MajArray, Found: maj, Err: err}
 	}
 
 	// t.When (typegen.CborTime) (struct)
@@ -1358,7 +1575,7 @@ func (t *ThingWithSomeTime) UnmarshalCBOR(r io.Reader) (int, error) {
 	{
 
 		if read, err := t.When.UnmarshalCBOR(br); err != nil {
-			return bytesRead, xerrors.Errorf("unmarshaling t.When: %w", err)
+			return bytesRead, cbg.WrapDecodeError(err, "ThingWithSomeTime", "When", bytesRead)
 		} else {
 			bytesRead += read
 		}
@@ -1369,23 +1586,23 @@ func (t *ThingWithSomeTime) UnmarshalCBOR(r io.Reader) (int, error) {
 		maj, extra, read, err := cbg.CborReadHeaderBuf(br, scratch)
 		var extraI int64
 		if err != nil {
-			return bytesRead, err
+			return bytesRead, cbg.WrapDecodeError(err, "ThingWithSomeTime", "Stuff", bytesRead)
 		}
 		bytesRead += read
 		switch maj {
 		case cbg.MajUnsignedInt:
 			extraI = int64(extra)
 			if extraI < 0 {
-				return bytesRead, fmt.Errorf("int64 positive overflow")
+				return bytesRead, &cbg.DecodeError{Type: "ThingWithSomeTime", Field: "Stuff", Offset: bytesRead - read, Expected: maj, Found: maj, Err: cbg.ErrOverflow}
 			}
 		case cbg.MajNegativeInt:
 			extraI = int64(extra)
 			if extraI < 0 {
-				return bytesRead, fmt.Errorf("int64 negative oveflow")
+				return bytesRead, &cbg.DecodeError{Type: "ThingWithSomeTime", Field: "Stuff", Offset: bytesRead - read, Expected: maj, Found: maj, Err: cbg.ErrOverflow}
 			}
 			extraI = -1 - extraI
 		default:
-			return bytesRead, fmt.Errorf("wrong type for int64 field: %d", maj)
+			return bytesRead, &cbg.DecodeError{Type: "ThingWithSomeTime", Field: "Stuff", Offset: bytesRead - read, Expected: cbg.MajUnsignedInt, Found: maj, Err: cbg.ErrUnexpectedType}
 		}
 
 		t.Stuff = int64(extraI)
@@ -1395,7 +1612,7 @@ func (t *ThingWithSomeTime) UnmarshalCBOR(r io.Reader) (int, error) {
 	{
 		sval, read, err := cbg.ReadStringBuf(br, scratch)
 		if err != nil {
-			return bytesRead, err
+			return bytesRead, cbg.WrapDecodeError(err, "ThingWithSomeTime", "CatName", bytesRead)
 		}
 		bytesRead += read
 
@@ -1403,3 +1620,1968 @@ func (t *ThingWithSomeTime) UnmarshalCBOR(r io.Reader) (int, error) {
 	}
 	return bytesRead, nil
 }
+
+func (t *ThingWithSomeTime) MarshalDAGJSON(w io.Writer) error {
+	return cbg.MarshalDAGJSON(w, t)
+}
+
+func (t *ThingWithSomeTime) UnmarshalDAGJSON(r io.Reader) error {
+	return cbg.UnmarshalDAGJSON(r, t)
+}
+
+func (t *ThingWithSomeTime) Links() []cid.Cid {
+	var links []cid.Cid
+	_ = t.ForEachLink(func(_ string, c cid.Cid) error {
+		links = append(links, c)
+		return nil
+	})
+	return links
+}
+
+func (t *ThingWithSomeTime) ForEachLink(cb func(path string, c cid.Cid) error) error {
+	if t == nil {
+		return nil
+	}
+	return nil
+}
+
+var lengthBufBigIntContainer = []byte{129}
+
+func (t *BigIntContainer) MarshalCBOR(w io.Writer) (n int, err error) {
+	if t == nil {
+		return w.Write(cbg.CborNull)
+	}
+	if n_, err := w.Write(lengthBufBigIntContainer); err != nil {
+		return n_, err
+	} else {
+		n += n_
+	}
+
+	scratch := make([]byte, 9)
+
+	// t.Int (big.Int) (struct)
+	{
+		if n_, err := cbg.WriteMajorTypeHeaderBuf(scratch, w, cbg.MajTag, 2); err != nil {
+			return n + n_, err
+		} else {
+			n += n_
+		}
+		var b []byte
+		if t.Int != nil {
+			b = t.Int.Bytes()
+		}
+
+		if n_, err := cbg.WriteMajorTypeHeaderBuf(scratch, w, cbg.MajByteString, uint64(len(b))); err != nil {
+			return n + n_, err
+		} else {
+			n += n_
+		}
+		if n_, err := w.Write(b); err != nil {
+			return n + n_, err
+		} else {
+			n += n_
+		}
+	}
+	return n, nil
+}
+
+func (t *BigIntContainer) UnmarshalCBOR(r io.Reader) (int, error) {
+	bytesRead := 0
+	*t = BigIntContainer{}
+
+	br := cbg.GetPeeker(r)
+	scratch := make([]byte, 8)
+	limits := cbg.GetDecodeLimits(br)
+	if err := limits.Enter(); err != nil {
+		return bytesRead, &cbg.DecodeError{Type: "BigIntContainer", Err: err}
+	}
+	defer limits.Leave()
+
+	maj, extra, read, err := cbg.CborReadHeaderBuf(br, scratch)
+	if err != nil {
+		return bytesRead, err
+	}
+	bytesRead += read
+	if maj != cbg.MajArray {
+		return bytesRead, &cbg.DecodeError{Type: "BigIntContainer", Expected: cbg.MajArray, Found: maj, Err: cbg.ErrUnexpectedType}
+	}
+
+	if extra != 1 {
+		return bytesRead, &cbg.DecodeError{Type: "BigIntContainer", Expected: cbg.MajArray, Found: maj, Err: fmt.Errorf("cbor input had wrong number of fields (%d)", extra)}
+	}
+	if err := limits.AddItems(extra); err != nil {
+		return bytesRead, &cbg.DecodeError{Type: "BigIntContainer", Expected: cbg.MajArray, Found: maj, Err: err}
+	}
+
+	// t.Int (big.Int) (struct)
+
+	maj, extra, read, err = cbg.CborReadHeaderBuf(br, scratch)
+	if err != nil {
+		return bytesRead, cbg.WrapDecodeError(err, "BigIntContainer", "Int", bytesRead)
+	}
+	bytesRead += read
+
+	if maj != cbg.MajTag {
+		return bytesRead, &cbg.DecodeError{Type: "BigIntContainer", Field: "Int", Offset: bytesRead - read, Expected: cbg.MajTag, Found: maj, Err: cbg.ErrUnexpectedType}
+	}
+	if extra != 2 {
+		return bytesRead, &cbg.DecodeError{Type: "BigIntContainer", Field: "Int", Offset: bytesRead - read, Expected: cbg.MajTag, Found: maj, Err: fmt.Errorf("big ints should be cbor bignums (tag 2), got tag %d", extra)}
+	}
+
+	maj, extra, read, err = cbg.CborReadHeaderBuf(br, scratch)
+	if err != nil {
+		return bytesRead, cbg.WrapDecodeError(err, "BigIntContainer", "Int", bytesRead)
+	}
+	bytesRead += read
+
+	if maj != cbg.MajByteString {
+		return bytesRead, &cbg.DecodeError{Type: "BigIntContainer", Field: "Int", Offset: bytesRead - read, Expected: cbg.MajByteString, Found: maj, Err: cbg.ErrUnexpectedType}
+	}
+
+	if extra > 256 {
+		return bytesRead, &cbg.DecodeError{Type: "BigIntContainer", Field: "Int", Offset: bytesRead - read, Expected: cbg.MajByteString, Found: maj, Err: cbg.ErrMaxLength}
+	}
+	if err := limits.AddBytes(extra); err != nil {
+		return bytesRead, &cbg.DecodeError{Type: "BigIntContainer", Field: "Int", Offset: bytesRead - read, Expected: cbg.MajByteString, Found: maj, Err: err}
+	}
+
+	if extra > 0 {
+		buf := make([]byte, extra)
+		if read, err := io.ReadFull(br, buf); err != nil {
+			return bytesRead, cbg.WrapDecodeError(err, "BigIntContainer", "Int", bytesRead)
+		} else {
+			bytesRead += read
+		}
+		t.Int = big.NewInt(0).SetBytes(buf)
+	} else {
+		t.Int = big.NewInt(0)
+	}
+	return bytesRead, nil
+}
+
+func (t *BigIntContainer) Links() []cid.Cid {
+	var links []cid.Cid
+	_ = t.ForEachLink(func(_ string, c cid.Cid) error {
+		links = append(links, c)
+		return nil
+	})
+	return links
+}
+
+func (t *BigIntContainer) ForEachLink(cb func(path string, c cid.Cid) error) error {
+	if t == nil {
+		return nil
+	}
+	return nil
+}
+
+var lengthBufBigIntHolder = []byte{129}
+
+func (t *BigIntHolder) MarshalCBOR(w io.Writer) (n int, err error) {
+	if t == nil {
+		return w.Write(cbg.CborNull)
+	}
+	if n_, err := w.Write(lengthBufBigIntHolder); err != nil {
+		return n_, err
+	} else {
+		n += n_
+	}
+
+	scratch := make([]byte, 9)
+
+	// t.Ints ([]testing.BigIntContainer) (slice)
+	if len(t.Ints) > cbg.MaxLength {
+		return n, xerrors.Errorf("Slice value in field t.Ints was too long")
+	}
+
+	if n_, err := cbg.WriteMajorTypeHeaderBuf(scratch, w, cbg.MajArray, uint64(len(t.Ints))); err != nil {
+		return n + n_, err
+	} else {
+		n += n_
+	}
+	for _, v := range t.Ints {
+		if n_, err := v.MarshalCBOR(w); err != nil {
+			return n + n_, err
+		} else {
+			n += n_
+		}
+	}
+	return n, nil
+}
+
+func (t *BigIntHolder) UnmarshalCBOR(r io.Reader) (int, error) {
+	bytesRead := 0
+	*t = BigIntHolder{}
+
+	br := cbg.GetPeeker(r)
+	scratch := make([]byte, 8)
+	limits := cbg.GetDecodeLimits(br)
+	if err := limits.Enter(); err != nil {
+		return bytesRead, &cbg.DecodeError{Type: "BigIntHolder", Err: err}
+	}
+	defer limits.Leave()
+
+	maj, extra, read, err := cbg.CborReadHeaderBuf(br, scratch)
+	if err != nil {
+		return bytesRead, err
+	}
+	bytesRead += read
+	if maj != cbg.MajArray {
+		return bytesRead, &cbg.DecodeError{Type: "BigIntHolder", Expected: cbg.MajArray, Found: maj, Err: cbg.ErrUnexpectedType}
+	}
+
+	if extra != 1 {
+		return bytesRead, &cbg.DecodeError{Type: "BigIntHolder", Expected: cbg.MajArray, Found: maj, Err: fmt.Errorf("cbor input had wrong number of fields (%d)", extra)}
+	}
+	if err := limits.AddItems(extra); err != nil {
+		return bytesRead, &cbg.DecodeError{Type: "BigIntHolder", Expected: cbg.MajArray, Found: maj, Err: err}
+	}
+
+	// t.Ints ([]testing.BigIntContainer) (slice)
+
+	maj, extra, read, err = cbg.CborReadHeaderBuf(br, scratch)
+	if err != nil {
+		return bytesRead, cbg.WrapDecodeError(err, "BigIntHolder", "Ints", bytesRead)
+	}
+	bytesRead += read
+
+	if extra > cbg.MaxLength {
+		return bytesRead, &cbg.DecodeError{Type: "BigIntHolder", Field: "Ints", Offset: bytesRead - read, Expected: cbg.MajArray, Found: maj, Err: cbg.ErrMaxLength}
+	}
+
+	if maj != cbg.MajArray {
+		return bytesRead, &cbg.DecodeError{Type: "BigIntHolder", Field: "Ints", Offset: bytesRead - read, Expected: cbg.MajArray, Found: maj, Err: cbg.ErrUnexpectedType}
+	}
+	if err := limits.AddItems(extra); err != nil {
+		return bytesRead, &cbg.DecodeError{Type: "BigIntHolder", Field: "Ints", Offset: bytesRead - read, Expected: cbg.MajArray, Found: maj, Err: err}
+	}
+
+	if extra > 0 {
+		t.Ints = make([]BigIntContainer, extra)
+	}
+
+	for i := 0; i < int(extra); i++ {
+
+		var v BigIntContainer
+		if read, err := v.UnmarshalCBOR(br); err != nil {
+			return bytesRead, cbg.WrapDecodeError(err, "BigIntHolder", fmt.Sprintf("Ints[%d]", i), bytesRead)
+		} else {
+			bytesRead += read
+		}
+
+		t.Ints[i] = v
+	}
+
+	return bytesRead, nil
+}
+
+func (t *BigIntHolder) Links() []cid.Cid {
+	var links []cid.Cid
+	_ = t.ForEachLink(func(_ string, c cid.Cid) error {
+		links = append(links, c)
+		return nil
+	})
+	return links
+}
+
+func (t *BigIntHolder) ForEachLink(cb func(path string, c cid.Cid) error) error {
+	if t == nil {
+		return nil
+	}
+	return nil
+}
+
+var lengthBufLinkContainer = []byte{135}
+
+func (t *LinkContainer) MarshalCBOR(w io.Writer) (n int, err error) {
+	if t == nil {
+		return w.Write(cbg.CborNull)
+	}
+	if n_, err := w.Write(lengthBufLinkContainer); err != nil {
+		return n_, err
+	} else {
+		n += n_
+	}
+
+	scratch := make([]byte, 9)
+
+	// t.Link (cid.Cid) (struct)
+
+	if n_, err := cbg.WriteCidBuf(scratch, w, t.Link); err != nil {
+		return n + n_, xerrors.Errorf("failed to write cid field t.Link: %w", err)
+	} else {
+		n += n_
+	}
+
+	// t.Ptr (cid.Cid) (struct)
+
+	if t.Ptr == nil {
+		if n_, err := w.Write(cbg.CborNull); err != nil {
+			return n + n_, err
+		} else {
+			n += n_
+		}
+	} else {
+		if n_, err := cbg.WriteCidBuf(scratch, w, *t.Ptr); err != nil {
+			return n + n_, xerrors.Errorf("failed to write cid field t.Ptr: %w", err)
+		} else {
+			n += n_
+		}
+	}
+
+	// t.Cids ([]cid.Cid) (slice)
+	if len(t.Cids) > cbg.MaxLength {
+		return n, xerrors.Errorf("Slice value in field t.Cids was too long")
+	}
+
+	if n_, err := cbg.WriteMajorTypeHeaderBuf(scratch, w, cbg.MajArray, uint64(len(t.Cids))); err != nil {
+		return n + n_, err
+	} else {
+		n += n_
+	}
+	for _, v := range t.Cids {
+		if n_, err := cbg.WriteCidBuf(scratch, w, v); err != nil {
+			return n + n_, xerrors.Errorf("failed writing cid field t.Cids: %w", err)
+		} else {
+			n += n_
+		}
+	}
+
+	// t.Nested (testing.SimpleStructV2) (struct)
+	if n_, err := t.Nested.MarshalCBOR(w); err != nil {
+		return n + n_, err
+	} else {
+		n += n_
+	}
+
+	// t.Structs ([]testing.SimpleStructV2) (slice)
+	if len(t.Structs) > cbg.MaxLength {
+		return n, xerrors.Errorf("Slice value in field t.Structs was too long")
+	}
+
+	if n_, err := cbg.WriteMajorTypeHeaderBuf(scratch, w, cbg.MajArray, uint64(len(t.Structs))); err != nil {
+		return n + n_, err
+	} else {
+		n += n_
+	}
+	for _, v := range t.Structs {
+		if n_, err := v.MarshalCBOR(w); err != nil {
+			return n + n_, err
+		} else {
+			n += n_
+		}
+	}
+
+	// t.Map (map[string]testing.SimpleStructV2) (map)
+	{
+		if len(t.Map) > 4096 {
+			return n, xerrors.Errorf("cannot marshal t.Map map too large")
+		}
+
+		if n_, err := cbg.WriteMajorTypeHeaderBuf(scratch, w, cbg.MajMap, uint64(len(t.Map))); err != nil {
+			return n + n_, err
+		} else {
+			n += n_
+		}
+
+		keys := make([]string, 0, len(t.Map))
+		for k := range t.Map {
+			keys = append(keys, k)
+		}
+		cbg.MapKeySort_RFC7049(keys)
+		for _, k := range keys {
+			v := t.Map[k]
+
+			if len(k) > cbg.MaxLength {
+				return n, xerrors.Errorf("Value in field k was too long")
+			}
+
+			if n_, err := cbg.WriteMajorTypeHeaderBuf(scratch, w, cbg.MajTextString, uint64(len(k))); err != nil {
+				return n + n_, err
+			} else {
+				n += n_
+			}
+			if n_, err := io.WriteString(w, string(k)); err != nil {
+				return n + n_, err
+			} else {
+				n += n_
+			}
+
+			if n_, err := v.MarshalCBOR(w); err != nil {
+				return n + n_, err
+			} else {
+				n += n_
+			}
+
+		}
+	}
+
+	// t.Deferred (typegen.Deferred) (struct)
+	if n_, err := t.Deferred.MarshalCBOR(w); err != nil {
+		return n + n_, err
+	} else {
+		n += n_
+	}
+	return n, nil
+}
+
+func (t *LinkContainer) UnmarshalCBOR(r io.Reader) (int, error) {
+	bytesRead := 0
+	*t = LinkContainer{}
+
+	br := cbg.GetPeeker(r)
+	scratch := make([]byte, 8)
+	limits := cbg.GetDecodeLimits(br)
+	if err := limits.Enter(); err != nil {
+		return bytesRead, &cbg.DecodeError{Type: "LinkContainer", Err: err}
+	}
+	defer limits.Leave()
+
+	maj, extra, read, err := cbg.CborReadHeaderBuf(br, scratch)
+	if err != nil {
+		return bytesRead, err
+	}
+	bytesRead += read
+	if maj != cbg.MajArray {
+		return bytesRead, &cbg.DecodeError{Type: "LinkContainer", Expected: cbg.MajArray, Found: maj, Err: cbg.ErrUnexpectedType}
+	}
+
+	if extra != 7 {
+		return bytesRead, &cbg.DecodeError{Type: "LinkContainer", Expected: cbg.MajArray, Found: maj, Err: fmt.Errorf("cbor input had wrong number of fields (%d)", extra)}
+	}
+	if err := limits.AddItems(extra); err != nil {
+		return bytesRead, &cbg.DecodeError{Type: "LinkContainer", Expected: cbg.MajArray, Found: maj, Err: err}
+	}
+
+	// t.Link (cid.Cid) (struct)
+
+	{
+
+		c, read, err := cbg.ReadCid(br)
+		if err != nil {
+			return bytesRead, cbg.WrapDecodeError(err, "LinkContainer", "Link", bytesRead)
+		}
+		bytesRead += read
+
+		t.Link = c
+
+	}
+	// t.Ptr (cid.Cid) (struct)
+
+	{
+
+		b, err := br.ReadByte()
+		if err != nil {
+			return bytesRead, cbg.WrapDecodeError(err, "LinkContainer", "Ptr", bytesRead)
+		}
+		bytesRead++
+		if b != cbg.CborNull[0] {
+			if err := br.UnreadByte(); err != nil {
+				return bytesRead, cbg.WrapDecodeError(err, "LinkContainer", "Ptr", bytesRead)
+			}
+			bytesRead--
+
+			c, read, err := cbg.ReadCid(br)
+			if err != nil {
+				return bytesRead, cbg.WrapDecodeError(err, "LinkContainer", "Ptr", bytesRead)
+			}
+			bytesRead += read
+
+			t.Ptr = &c
+		}
+
+	}
+	// t.Cids ([]cid.Cid) (slice)
+
+	maj, extra, read, err = cbg.CborReadHeaderBuf(br, scratch)
+	if err != nil {
+		return bytesRead, cbg.WrapDecodeError(err, "LinkContainer", "Cids", bytesRead)
+	}
+	bytesRead += read
+
+	if extra > cbg.MaxLength {
+		return bytesRead, &cbg.DecodeError{Type: "LinkContainer", Field: "Cids", Offset: bytesRead - read, Expected: cbg.MajArray, Found: maj, Err: cbg.ErrMaxLength}
+	}
+
+	if maj != cbg.MajArray {
+		return bytesRead, &cbg.DecodeError{Type: "LinkContainer", Field: "Cids", Offset: bytesRead - read, Expected: cbg.MajArray, Found: maj, Err: cbg.ErrUnexpectedType}
+	}
+	if err := limits.AddItems(extra); err != nil {
+		return bytesRead, &cbg.DecodeError{Type: "LinkContainer", Field: "Cids", Offset: bytesRead - read, Expected: cbg.MajArray, Found: maj, Err: err}
+	}
+
+	if extra > 0 {
+		t.Cids = make([]cid.Cid, extra)
+	}
+
+	for i := 0; i < int(extra); i++ {
+
+		c, read, err := cbg.ReadCid(br)
+		if err != nil {
+			return bytesRead, cbg.WrapDecodeError(err, "LinkContainer", fmt.Sprintf("Cids[%d]", i), bytesRead)
+		}
+		bytesRead += read
+		t.Cids[i] = c
+	}
+
+	// t.Nested (testing.SimpleStructV2) (struct)
+
+	{
+
+		b, err := br.ReadByte()
+		if err != nil {
+			return bytesRead, cbg.WrapDecodeError(err, "LinkContainer", "Nested", bytesRead)
+		}
+		bytesRead++
+		if b != cbg.CborNull[0] {
+			if err := br.UnreadByte(); err != nil {
+				return bytesRead, cbg.WrapDecodeError(err, "LinkContainer", "Nested", bytesRead)
+			}
+			bytesRead--
+			t.Nested = new(SimpleStructV2)
+			if read, err := t.Nested.UnmarshalCBOR(br); err != nil {
+				return bytesRead, cbg.WrapDecodeError(err, "LinkContainer", "Nested", bytesRead)
+			} else {
+				bytesRead += read
+			}
+		}
+
+	}
+	// t.Structs ([]testing.SimpleStructV2) (slice)
+
+	maj, extra, read, err = cbg.CborReadHeaderBuf(br, scratch)
+	if err != nil {
+		return bytesRead, cbg.WrapDecodeError(err, "LinkContainer", "Structs", bytesRead)
+	}
+	bytesRead += read
+
+	if extra > cbg.MaxLength {
+		return bytesRead, &cbg.DecodeError{Type: "LinkContainer", Field: "Structs", Offset: bytesRead - read, Expected: cbg.MajArray, Found: maj, Err: cbg.ErrMaxLength}
+	}
+
+	if maj != cbg.MajArray {
+		return bytesRead, &cbg.DecodeError{Type: "LinkContainer", Field: "Structs", Offset: bytesRead - read, Expected: cbg.MajArray, Found: maj, Err: cbg.ErrUnexpectedType}
+	}
+	if err := limits.AddItems(extra); err != nil {
+		return bytesRead, &cbg.DecodeError{Type: "LinkContainer", Field: "Structs", Offset: bytesRead - read, Expected: cbg.MajArray, Found: maj, Err: err}
+	}
+
+	if extra > 0 {
+		t.Structs = make([]SimpleStructV2, extra)
+	}
+
+	for i := 0; i < int(extra); i++ {
+
+		var v SimpleStructV2
+		if read, err := v.UnmarshalCBOR(br); err != nil {
+			return bytesRead, cbg.WrapDecodeError(err, "LinkContainer", fmt.Sprintf("Structs[%d]", i), bytesRead)
+		} else {
+			bytesRead += read
+		}
+
+		t.Structs[i] = v
+	}
+
+	// t.Map (map[string]testing.SimpleStructV2) (map)
+
+	maj, extra, read, err = cbg.CborReadHeaderBuf(br, scratch)
+	if err != nil {
+		return bytesRead, cbg.WrapDecodeError(err, "LinkContainer", "Map", bytesRead)
+	}
+	bytesRead += read
+	if maj != cbg.MajMap {
+		return bytesRead, &cbg.DecodeError{Type: "LinkContainer", Field: "Map", Offset: bytesRead - read, Expected: cbg.MajMap, Found: maj, Err: cbg.ErrUnexpectedType}
+	}
+	if extra > 4096 {
+		return bytesRead, &cbg.DecodeError{Type: "LinkContainer", Field: "Map", Offset: bytesRead - read, Expected: cbg.MajMap, Found: maj, Err: cbg.ErrMaxLength}
+	}
+	if err := limits.AddItems(extra); err != nil {
+		return bytesRead, &cbg.DecodeError{Type: "LinkContainer", Field: "Map", Offset: bytesRead - read, Expected: cbg.MajMap, Found: maj, Err: err}
+	}
+
+	t.Map = make(map[string]SimpleStructV2, extra)
+
+	for i, l := 0, int(extra); i < l; i++ {
+
+		var k string
+
+		{
+			sval, read, err := cbg.ReadStringBuf(br, scratch)
+			if err != nil {
+				return bytesRead, cbg.WrapDecodeError(err, "LinkContainer", "Map", bytesRead)
+			}
+			bytesRead += read
+
+			k = string(sval)
+		}
+
+		var v SimpleStructV2
+
+		{
+
+			if read, err := v.UnmarshalCBOR(br); err != nil {
+				return bytesRead, cbg.WrapDecodeError(err, "LinkContainer", fmt.Sprintf("Map[%q]", k), bytesRead)
+			} else {
+				bytesRead += read
+			}
+
+		}
+
+		t.Map[k] = v
+
+	}
+	// t.Deferred (typegen.Deferred) (struct)
+
+	{
+
+		t.Deferred = new(cbg.Deferred)
+
+		if read, err := t.Deferred.UnmarshalCBOR(br); err != nil {
+			return bytesRead, cbg.WrapDecodeError(err, "LinkContainer", "Deferred", bytesRead)
+		} else {
+			bytesRead += read
+		}
+	}
+	return bytesRead, nil
+}
+
+func (t *LinkContainer) MarshalDAGJSON(w io.Writer) error {
+	return cbg.MarshalDAGJSON(w, t)
+}
+
+func (t *LinkContainer) UnmarshalDAGJSON(r io.Reader) error {
+	return cbg.UnmarshalDAGJSON(r, t)
+}
+
+func (t *LinkContainer) Links() []cid.Cid {
+	var links []cid.Cid
+	_ = t.ForEachLink(func(_ string, c cid.Cid) error {
+		links = append(links, c)
+		return nil
+	})
+	return links
+}
+
+func (t *LinkContainer) ForEachLink(cb func(path string, c cid.Cid) error) error {
+	if t == nil {
+		return nil
+	}
+	if t.Link.Defined() {
+		if err := cb("0", t.Link); err != nil {
+			return err
+		}
+	}
+	if t.Ptr != nil {
+		if err := cb("1", *t.Ptr); err != nil {
+			return err
+		}
+	}
+	for i := range t.Cids {
+		if t.Cids[i].Defined() {
+			if err := cb(fmt.Sprintf("%s/%d", "2", i), t.Cids[i]); err != nil {
+				return err
+			}
+		}
+	}
+	if err := cbg.ForEachLinkIn(t.Nested, "3", cb); err != nil {
+		return err
+	}
+	for i := range t.Structs {
+		if err := cbg.ForEachLinkIn(&t.Structs[i], fmt.Sprintf("%s/%d", "4", i), cb); err != nil {
+			return err
+		}
+	}
+	{
+		keys := make([]string, 0, len(t.Map))
+		for k := range t.Map {
+			keys = append(keys, k)
+		}
+		cbg.MapKeySort_RFC7049(keys)
+		for _, k0 := range keys {
+			v0 := t.Map[k0]
+			if err := cbg.ForEachLinkIn(&v0, "5"+"/"+k0, cb); err != nil {
+				return err
+			}
+		}
+	}
+	if err := cbg.ForEachLinkIn(t.Deferred, "6", cb); err != nil {
+		return err
+	}
+	return nil
+}
+
+var lengthBufTupleV1 = []byte{130}
+
+func (t *TupleV1) MarshalCBOR(w io.Writer) (n int, err error) {
+	if t == nil {
+		return w.Write(cbg.CborNull)
+	}
+	if n_, err := w.Write(lengthBufTupleV1); err != nil {
+		return n_, err
+	} else {
+		n += n_
+	}
+
+	scratch := make([]byte, 9)
+
+	// t.Name (string) (string)
+	if len(t.Name) > cbg.MaxLength {
+		return n, xerrors.Errorf("Value in field t.Name was too long")
+	}
+
+	if n_, err := cbg.WriteMajorTypeHeaderBuf(scratch, w, cbg.MajTextString, uint64(len(t.Name))); err != nil {
+		return n + n_, err
+	} else {
+		n += n_
+	}
+	if n_, err := io.WriteString(w, string(t.Name)); err != nil {
+		return n + n_, err
+	} else {
+		n += n_
+	}
+
+	// t.Count (uint64) (uint64)
+
+	if n_, err := cbg.WriteMajorTypeHeaderBuf(scratch, w, cbg.MajUnsignedInt, uint64(t.Count)); err != nil {
+		return n + n_, err
+	} else {
+		n += n_
+	}
+
+	return n, nil
+}
+
+func (t *TupleV1) UnmarshalCBOR(r io.Reader) (int, error) {
+	bytesRead := 0
+	*t = TupleV1{}
+
+	br := cbg.GetPeeker(r)
+	scratch := make([]byte, 8)
+	limits := cbg.GetDecodeLimits(br)
+	if err := limits.Enter(); err != nil {
+		return bytesRead, &cbg.DecodeError{Type: "TupleV1", Err: err}
+	}
+	defer limits.Leave()
+
+	maj, extra, read, err := cbg.CborReadHeaderBuf(br, scratch)
+	if err != nil {
+		return bytesRead, err
+	}
+	bytesRead += read
+	if maj != cbg.MajArray {
+		return bytesRead, &cbg.DecodeError{Type: "TupleV1", Expected: cbg.MajArray, Found: maj, Err: cbg.ErrUnexpectedType}
+	}
+
+	if extra != 2 {
+		return bytesRead, &cbg.DecodeError{Type: "TupleV1", Expected: cbg.MajArray, Found: maj, Err: fmt.Errorf("cbor input had wrong number of fields (%d)", extra)}
+	}
+	if err := limits.AddItems(extra); err != nil {
+		return bytesRead, &cbg.DecodeError{Type: "TupleV1", Expected: cbg.MajArray, Found: maj, Err: err}
+	}
+
+	// t.Name (string) (string)
+
+	{
+		sval, read, err := cbg.ReadStringBuf(br, scratch)
+		if err != nil {
+			return bytesRead, cbg.WrapDecodeError(err, "TupleV1", "Name", bytesRead)
+		}
+		bytesRead += read
+
+		t.Name = string(sval)
+	}
+	// t.Count (uint64) (uint64)
+
+	{
+
+		maj, extra, read, err = cbg.CborReadHeaderBuf(br, scratch)
+		if err != nil {
+			return bytesRead, cbg.WrapDecodeError(err, "TupleV1", "Count", bytesRead)
+		}
+		bytesRead += read
+		if maj != cbg.MajUnsignedInt {
+			return bytesRead, &cbg.DecodeError{Type: "TupleV1", Field: "Count", Offset: bytesRead - read, Expected: cbg.MajUnsignedInt, Found: maj, Err: cbg.ErrUnexpectedType}
+		}
+		t.Count = uint64(extra)
+
+	}
+	return bytesRead, nil
+}
+
+func (t *TupleV1) MarshalDAGJSON(w io.Writer) error {
+	return cbg.MarshalDAGJSON(w, t)
+}
+
+func (t *TupleV1) UnmarshalDAGJSON(r io.Reader) error {
+	return cbg.UnmarshalDAGJSON(r, t)
+}
+
+func (t *TupleV1) Links() []cid.Cid {
+	var links []cid.Cid
+	_ = t.ForEachLink(func(_ string, c cid.Cid) error {
+		links = append(links, c)
+		return nil
+	})
+	return links
+}
+
+func (t *TupleV1) ForEachLink(cb func(path string, c cid.Cid) error) error {
+	if t == nil {
+		return nil
+	}
+	return nil
+}
+
+var lengthBufTupleV2 = []byte{132}
+
+func (t *TupleV2) MarshalCBOR(w io.Writer) (n int, err error) {
+	if t == nil {
+		return w.Write(cbg.CborNull)
+	}
+	if n_, err := w.Write(lengthBufTupleV2); err != nil {
+		return n_, err
+	} else {
+		n += n_
+	}
+
+	scratch := make([]byte, 9)
+
+	// t.Name (string) (string)
+	if len(t.Name) > cbg.MaxLength {
+		return n, xerrors.Errorf("Value in field t.Name was too long")
+	}
+
+	if n_, err := cbg.WriteMajorTypeHeaderBuf(scratch, w, cbg.MajTextString, uint64(len(t.Name))); err != nil {
+		return n + n_, err
+	} else {
+		n += n_
+	}
+	if n_, err := io.WriteString(w, string(t.Name)); err != nil {
+		return n + n_, err
+	} else {
+		n += n_
+	}
+
+	// t.Count (uint64) (uint64)
+
+	if n_, err := cbg.WriteMajorTypeHeaderBuf(scratch, w, cbg.MajUnsignedInt, uint64(t.Count)); err != nil {
+		return n + n_, err
+	} else {
+		n += n_
+	}
+
+	// t.Limit (uint64) (uint64)
+
+	if n_, err := cbg.WriteMajorTypeHeaderBuf(scratch, w, cbg.MajUnsignedInt, uint64(t.Limit)); err != nil {
+		return n + n_, err
+	} else {
+		n += n_
+	}
+
+	// t.Note (string) (string)
+	if len(t.Note) > cbg.MaxLength {
+		return n, xerrors.Errorf("Value in field t.Note was too long")
+	}
+
+	if n_, err := cbg.WriteMajorTypeHeaderBuf(scratch, w, cbg.MajTextString, uint64(len(t.Note))); err != nil {
+		return n + n_, err
+	} else {
+		n += n_
+	}
+	if n_, err := io.WriteString(w, string(t.Note)); err != nil {
+		return n + n_, err
+	} else {
+		n += n_
+	}
+	return n, nil
+}
+
+func (t *TupleV2) UnmarshalCBOR(r io.Reader) (int, error) {
+	bytesRead := 0
+	*t = TupleV2{}
+
+	br := cbg.GetPeeker(r)
+	scratch := make([]byte, 8)
+	limits := cbg.GetDecodeLimits(br)
+	if err := limits.Enter(); err != nil {
+		return bytesRead, &cbg.DecodeError{Type: "TupleV2", Err: err}
+	}
+	defer limits.Leave()
+
+	maj, extra, read, err := cbg.CborReadHeaderBuf(br, scratch)
+	if err != nil {
+		return bytesRead, err
+	}
+	bytesRead += read
+	if maj != cbg.MajArray {
+		return bytesRead, &cbg.DecodeError{Type: "TupleV2", Expected: cbg.MajArray, Found: maj, Err: cbg.ErrUnexpectedType}
+	}
+
+	if extra < 2 || extra > 4 {
+		return bytesRead, &cbg.DecodeError{Type: "TupleV2", Expected: cbg.MajArray, Found: maj, Err: fmt.Errorf("cbor input had wrong number of fields (%d)", extra)}
+	}
+	if err := limits.AddItems(extra); err != nil {
+		return bytesRead, &cbg.DecodeError{Type: "TupleV2", Expected: cbg.MajArray, Found: maj, Err: err}
+	}
+
+	// extra is reused by the field decoders.
+	present := int(extra)
+
+	// t.Name (string) (string)
+
+	{
+		sval, read, err := cbg.ReadStringBuf(br, scratch)
+		if err != nil {
+			return bytesRead, cbg.WrapDecodeError(err, "TupleV2", "Name", bytesRead)
+		}
+		bytesRead += read
+
+		t.Name = string(sval)
+	}
+	// t.Count (uint64) (uint64)
+
+	{
+
+		maj, extra, read, err = cbg.CborReadHeaderBuf(br, scratch)
+		if err != nil {
+			return bytesRead, cbg.WrapDecodeError(err, "TupleV2", "Count", bytesRead)
+		}
+		bytesRead += read
+		if maj != cbg.MajUnsignedInt {
+			return bytesRead, &cbg.DecodeError{Type: "TupleV2", Field: "Count", Offset: bytesRead - read, Expected: cbg.MajUnsignedInt, Found: maj, Err: cbg.ErrUnexpectedType}
+		}
+		t.Count = uint64(extra)
+
+	}
+	// t.Limit (uint64) (uint64)
+	if present > 2 {
+
+		{
+
+			maj, extra, read, err = cbg.CborReadHeaderBuf(br, scratch)
+			if err != nil {
+				return bytesRead, cbg.WrapDecodeError(err, "TupleV2", "Limit", bytesRead)
+			}
+			bytesRead += read
+			if maj != cbg.MajUnsignedInt {
+				return bytesRead, &cbg.DecodeError{Type: "TupleV2", Field: "Limit", Offset: bytesRead - read, Expected: cbg.MajUnsignedInt, Found: maj, Err: cbg.ErrUnexpectedType}
+			}
+			t.Limit = uint64(extra)
+
+		}
+	}
+	// t.Note (string) (string)
+	if present > 3 {
+
+		{
+			sval, read, err := cbg.ReadStringBuf(br, scratch)
+			if err != nil {
+				return bytesRead, cbg.WrapDecodeError(err, "TupleV2", "Note", bytesRead)
+			}
+			bytesRead += read
+
+			t.Note = string(sval)
+		}
+	}
+
+	if present < 4 {
+		if u, ok := interface{}(t).(cbg.Upgrader); ok {
+			if err := u.Upgrade(present); err != nil {
+				return bytesRead, err
+			}
+		}
+	}
+
+	return bytesRead, nil
+}
+
+func (t *TupleV2) MarshalDAGJSON(w io.Writer) error {
+	return cbg.MarshalDAGJSON(w, t)
+}
+
+func (t *TupleV2) UnmarshalDAGJSON(r io.Reader) error {
+	return cbg.UnmarshalDAGJSON(r, t)
+}
+
+func (t *TupleV2) Links() []cid.Cid {
+	var links []cid.Cid
+	_ = t.ForEachLink(func(_ string, c cid.Cid) error {
+		links = append(links, c)
+		return nil
+	})
+	return links
+}
+
+func (t *TupleV2) ForEachLink(cb func(path string, c cid.Cid) error) error {
+	if t == nil {
+		return nil
+	}
+	return nil
+}
+
+var lengthBufMigratingTuple = []byte{129}
+
+func (t *MigratingTuple) MarshalCBOR(w io.Writer) (n int, err error) {
+	if t == nil {
+		return w.Write(cbg.CborNull)
+	}
+	if n_, err := w.Write(lengthBufMigratingTuple); err != nil {
+		return n_, err
+	} else {
+		n += n_
+	}
+
+	scratch := make([]byte, 9)
+
+	// t.Name (string) (string)
+	if len(t.Name) > cbg.MaxLength {
+		return n, xerrors.Errorf("Value in field t.Name was too long")
+	}
+
+	if n_, err := cbg.WriteMajorTypeHeaderBuf(scratch, w, cbg.MajTextString, uint64(len(t.Name))); err != nil {
+		return n + n_, err
+	} else {
+		n += n_
+	}
+	if n_, err := io.WriteString(w, string(t.Name)); err != nil {
+		return n + n_, err
+	} else {
+		n += n_
+	}
+	return n, nil
+}
+
+func (t *MigratingTuple) UnmarshalCBOR(r io.Reader) (int, error) {
+	bytesRead := 0
+	*t = MigratingTuple{}
+
+	br := cbg.GetPeeker(r)
+	scratch := make([]byte, 8)
+	limits := cbg.GetDecodeLimits(br)
+	if err := limits.Enter(); err != nil {
+		return bytesRead, &cbg.DecodeError{Type: "MigratingTuple", Err: err}
+	}
+	defer limits.Leave()
+
+	maj, extra, read, err := cbg.CborReadHeaderBuf(br, scratch)
+	if err != nil {
+		return bytesRead, err
+	}
+	bytesRead += read
+	if maj != cbg.MajArray {
+		return bytesRead, &cbg.DecodeError{Type: "MigratingTuple", Expected: cbg.MajArray, Found: maj, Err: cbg.ErrUnexpectedType}
+	}
+
+	if extra < 1 || extra > 2 {
+		return bytesRead, &cbg.DecodeError{Type: "MigratingTuple", Expected: cbg.MajArray, Found: maj, Err: fmt.Errorf("cbor input had wrong number of fields (%d)", extra)}
+	}
+	if err := limits.AddItems(extra); err != nil {
+		return bytesRead, &cbg.DecodeError{Type: "MigratingTuple", Expected: cbg.MajArray, Found: maj, Err: err}
+	}
+
+	// extra is reused by the field decoders.
+	present := int(extra)
+
+	// t.Name (string) (string)
+
+	{
+		sval, read, err := cbg.ReadStringBuf(br, scratch)
+		if err != nil {
+			return bytesRead, cbg.WrapDecodeError(err, "MigratingTuple", "Name", bytesRead)
+		}
+		bytesRead += read
+
+		t.Name = string(sval)
+	}
+	// t.Legacy (string) (string)
+	if present > 1 {
+
+		{
+			sval, read, err := cbg.ReadStringBuf(br, scratch)
+			if err != nil {
+				return bytesRead, cbg.WrapDecodeError(err, "MigratingTuple", "Legacy", bytesRead)
+			}
+			bytesRead += read
+
+			t.Legacy = string(sval)
+		}
+	}
+
+	if present < 2 {
+		if u, ok := interface{}(t).(cbg.Upgrader); ok {
+			if err := u.Upgrade(present); err != nil {
+				return bytesRead, err
+			}
+		}
+	}
+
+	return bytesRead, nil
+}
+
+func (t *MigratingTuple) MarshalDAGJSON(w io.Writer) error {
+	return cbg.MarshalDAGJSON(w, t)
+}
+
+func (t *MigratingTuple) UnmarshalDAGJSON(r io.Reader) error {
+	return cbg.UnmarshalDAGJSON(r, t)
+}
+
+func (t *MigratingTuple) Links() []cid.Cid {
+	var links []cid.Cid
+	_ = t.ForEachLink(func(_ string, c cid.Cid) error {
+		links = append(links, c)
+		return nil
+	})
+	return links
+}
+
+func (t *MigratingTuple) ForEachLink(cb func(path string, c cid.Cid) error) error {
+	if t == nil {
+		return nil
+	}
+	return nil
+}
+
+var lengthBufMigratingLinks = []byte{130}
+
+func (t *MigratingLinks) MarshalCBOR(w io.Writer) (n int, err error) {
+	if t == nil {
+		return w.Write(cbg.CborNull)
+	}
+	if n_, err := w.Write(lengthBufMigratingLinks); err != nil {
+		return n_, err
+	} else {
+		n += n_
+	}
+
+	scratch := make([]byte, 9)
+
+	// t.Name (string) (string)
+	if len(t.Name) > cbg.MaxLength {
+		return n, xerrors.Errorf("Value in field t.Name was too long")
+	}
+
+	if n_, err := cbg.WriteMajorTypeHeaderBuf(scratch, w, cbg.MajTextString, uint64(len(t.Name))); err != nil {
+		return n + n_, err
+	} else {
+		n += n_
+	}
+	if n_, err := io.WriteString(w, string(t.Name)); err != nil {
+		return n + n_, err
+	} else {
+		n += n_
+	}
+
+	// t.Head (cid.Cid) (struct)
+
+	if n_, err := cbg.WriteCidBuf(scratch, w, t.Head); err != nil {
+		return n + n_, xerrors.Errorf("failed to write cid field t.Head: %w", err)
+	} else {
+		n += n_
+	}
+
+	return n, nil
+}
+
+func (t *MigratingLinks) UnmarshalCBOR(r io.Reader) (int, error) {
+	bytesRead := 0
+	*t = MigratingLinks{}
+
+	br := cbg.GetPeeker(r)
+	scratch := make([]byte, 8)
+	limits := cbg.GetDecodeLimits(br)
+	if err := limits.Enter(); err != nil {
+		return bytesRead, &cbg.DecodeError{Type: "MigratingLinks", Err: err}
+	}
+	defer limits.Leave()
+
+	maj, extra, read, err := cbg.CborReadHeaderBuf(br, scratch)
+	if err != nil {
+		return bytesRead, err
+	}
+	bytesRead += read
+	if maj != cbg.MajArray {
+		return bytesRead, &cbg.DecodeError{Type: "MigratingLinks", Expected: cbg.MajArray, Found: maj, Err: cbg.ErrUnexpectedType}
+	}
+
+	if extra < 2 || extra > 3 {
+		return bytesRead, &cbg.DecodeError{Type: "MigratingLinks", Expected: cbg.MajArray, Found: maj, Err: fmt.Errorf("cbor input had wrong number of fields (%d)", extra)}
+	}
+	if err := limits.AddItems(extra); err != nil {
+		return bytesRead, &cbg.DecodeError{Type: "MigratingLinks", Expected: cbg.MajArray, Found: maj, Err: err}
+	}
+
+	// extra is reused by the field decoders.
+	present := int(extra)
+
+	// t.Name (string) (string)
+
+	{
+		sval, read, err := cbg.ReadStringBuf(br, scratch)
+		if err != nil {
+			return bytesRead, cbg.WrapDecodeError(err, "MigratingLinks", "Name", bytesRead)
+		}
+		bytesRead += read
+
+		t.Name = string(sval)
+	}
+	// t.Head (cid.Cid) (struct)
+
+	{
+
+		c, read, err := cbg.ReadCid(br)
+		if err != nil {
+			return bytesRead, cbg.WrapDecodeError(err, "MigratingLinks", "Head", bytesRead)
+		}
+		bytesRead += read
+
+		t.Head = c
+
+	}
+	// t.Prev (cid.Cid) (struct)
+	if present > 2 {
+
+		{
+
+			c, read, err := cbg.ReadCid(br)
+			if err != nil {
+				return bytesRead, cbg.WrapDecodeError(err, "MigratingLinks", "Prev", bytesRead)
+			}
+			bytesRead += read
+
+			t.Prev = c
+
+		}
+	}
+
+	if present < 3 {
+		if u, ok := interface{}(t).(cbg.Upgrader); ok {
+			if err := u.Upgrade(present); err != nil {
+				return bytesRead, err
+			}
+		}
+	}
+
+	return bytesRead, nil
+}
+
+func (t *MigratingLinks) MarshalDAGJSON(w io.Writer) error {
+	return cbg.MarshalDAGJSON(w, t)
+}
+
+func (t *MigratingLinks) UnmarshalDAGJSON(r io.Reader) error {
+	return cbg.UnmarshalDAGJSON(r, t)
+}
+
+func (t *MigratingLinks) Links() []cid.Cid {
+	var links []cid.Cid
+	_ = t.ForEachLink(func(_ string, c cid.Cid) error {
+		links = append(links, c)
+		return nil
+	})
+	return links
+}
+
+func (t *MigratingLinks) ForEachLink(cb func(path string, c cid.Cid) error) error {
+	if t == nil {
+		return nil
+	}
+	if t.Head.Defined() {
+		if err := cb("1", t.Head); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+var lengthBufSortedValues = []byte{130}
+
+func (t *SortedValues) MarshalCBOR(w io.Writer) (n int, err error) {
+	if t == nil {
+		return w.Write(cbg.CborNull)
+	}
+	if err := t.PreMarshalCBOR(); err != nil {
+		return 0, err
+	}
+
+	if n_, err := w.Write(lengthBufSortedValues); err != nil {
+		return n_, err
+	} else {
+		n += n_
+	}
+
+	scratch := make([]byte, 9)
+
+	// t.Name (string) (string)
+	if len(t.Name) > cbg.MaxLength {
+		return n, xerrors.Errorf("Value in field t.Name was too long")
+	}
+
+	if n_, err := cbg.WriteMajorTypeHeaderBuf(scratch, w, cbg.MajTextString, uint64(len(t.Name))); err != nil {
+		return n + n_, err
+	} else {
+		n += n_
+	}
+	if n_, err := io.WriteString(w, string(t.Name)); err != nil {
+		return n + n_, err
+	} else {
+		n += n_
+	}
+
+	// t.Values ([]uint64) (slice)
+	if len(t.Values) > cbg.MaxLength {
+		return n, xerrors.Errorf("Slice value in field t.Values was too long")
+	}
+
+	if n_, err := cbg.WriteMajorTypeHeaderBuf(scratch, w, cbg.MajArray, uint64(len(t.Values))); err != nil {
+		return n + n_, err
+	} else {
+		n += n_
+	}
+	for _, v := range t.Values {
+		if n_, err := cbg.CborWriteHeader(w, cbg.MajUnsignedInt, uint64(v)); err != nil {
+			return n + n_, err
+		} else {
+			n += n_
+		}
+	}
+	return n, nil
+}
+
+func (t *SortedValues) UnmarshalCBOR(r io.Reader) (int, error) {
+	bytesRead := 0
+	*t = SortedValues{}
+
+	br := cbg.GetPeeker(r)
+	scratch := make([]byte, 8)
+	limits := cbg.GetDecodeLimits(br)
+	if err := limits.Enter(); err != nil {
+		return bytesRead, &cbg.DecodeError{Type: "SortedValues", Err: err}
+	}
+	defer limits.Leave()
+
+	maj, extra, read, err := cbg.CborReadHeaderBuf(br, scratch)
+	if err != nil {
+		return bytesRead, err
+	}
+	bytesRead += read
+	if maj != cbg.MajArray {
+		return bytesRead, &cbg.DecodeError{Type: "SortedValues", Expected: cbg.MajArray, Found: maj, Err: cbg.ErrUnexpectedType}
+	}
+
+	if extra != 2 {
+		return bytesRead, &cbg.DecodeError{Type: "SortedValues", Expected: cbg.MajArray, Found: maj, Err: fmt.Errorf("cbor input had wrong number of fields (%d)", extra)}
+	}
+	if err := limits.AddItems(extra); err != nil {
+		return bytesRead, &cbg.DecodeError{Type: "SortedValues", Expected: cbg.MajArray, Found: maj, Err: err}
+	}
+
+	// t.Name (string) (string)
+
+	{
+		sval, read, err := cbg.ReadStringBuf(br, scratch)
+		if err != nil {
+			return bytesRead, cbg.WrapDecodeError(err, "SortedValues", "Name", bytesRead)
+		}
+		bytesRead += read
+
+		t.Name = string(sval)
+	}
+	// t.Values ([]uint64) (slice)
+
+	maj, extra, read, err = cbg.CborReadHeaderBuf(br, scratch)
+	if err != nil {
+		return bytesRead, cbg.WrapDecodeError(err, "SortedValues", "Values", bytesRead)
+	}
+	bytesRead += read
+
+	if extra > cbg.MaxLength {
+		return bytesRead, &cbg.DecodeError{Type: "SortedValues", Field: "Values", Offset: bytesRead - read, Expected: cbg.MajArray, Found: maj, Err: cbg.ErrMaxLength}
+	}
+
+	if maj != cbg.MajArray {
+		return bytesRead, &cbg.DecodeError{Type: "SortedValues", Field: "Values", Offset: bytesRead - read, Expected: cbg.MajArray, Found: maj, Err: cbg.ErrUnexpectedType}
+	}
+	if err := limits.AddItems(extra); err != nil {
+		return bytesRead, &cbg.DecodeError{Type: "SortedValues", Field: "Values", Offset: bytesRead - read, Expected: cbg.MajArray, Found: maj, Err: err}
+	}
+
+	if extra > 0 {
+		t.Values = make([]uint64, extra)
+	}
+
+	for i := 0; i < int(extra); i++ {
+
+		maj, val, read, err := cbg.CborReadHeaderBuf(br, scratch)
+		if err != nil {
+			return bytesRead, cbg.WrapDecodeError(err, "SortedValues", fmt.Sprintf("Values[%d]", i), bytesRead)
+		}
+		bytesRead += read
+
+		if maj != cbg.MajUnsignedInt {
+			return bytesRead, &cbg.DecodeError{Type: "SortedValues", Field: fmt.Sprintf("Values[%d]", i), Offset: bytesRead - read, Expected: cbg.MajUnsignedInt, Found: maj, Err: cbg.ErrUnexpectedType}
+		}
+
+		t.Values[i] = uint64(val)
+	}
+
+	if err := t.PostUnmarshalCBOR(); err != nil {
+		return bytesRead, err
+	}
+
+	return bytesRead, nil
+}
+
+func (t *SortedValues) MarshalDAGJSON(w io.Writer) error {
+	return cbg.MarshalDAGJSON(w, t)
+}
+
+func (t *SortedValues) UnmarshalDAGJSON(r io.Reader) error {
+	return cbg.UnmarshalDAGJSON(r, t)
+}
+
+func (t *SortedValues) Links() []cid.Cid {
+	var links []cid.Cid
+	_ = t.ForEachLink(func(_ string, c cid.Cid) error {
+		links = append(links, c)
+		return nil
+	})
+	return links
+}
+
+func (t *SortedValues) ForEachLink(cb func(path string, c cid.Cid) error) error {
+	if t == nil {
+		return nil
+	}
+	return nil
+}
+
+var lengthBufConstrainedTuple = []byte{130}
+
+func (t *ConstrainedTuple) MarshalCBOR(w io.Writer) (n int, err error) {
+	if t == nil {
+		return w.Write(cbg.CborNull)
+	}
+	if n_, err := w.Write(lengthBufConstrainedTuple); err != nil {
+		return n_, err
+	} else {
+		n += n_
+	}
+
+	scratch := make([]byte, 9)
+
+	// t.Count (uint64) (uint64)
+
+	if n_, err := cbg.WriteMajorTypeHeaderBuf(scratch, w, cbg.MajUnsignedInt, uint64(t.Count)); err != nil {
+		return n + n_, err
+	} else {
+		n += n_
+	}
+
+	// t.Note (string) (string)
+	if len(t.Note) > cbg.MaxLength {
+		return n, xerrors.Errorf("Value in field t.Note was too long")
+	}
+
+	if n_, err := cbg.WriteMajorTypeHeaderBuf(scratch, w, cbg.MajTextString, uint64(len(t.Note))); err != nil {
+		return n + n_, err
+	} else {
+		n += n_
+	}
+	if n_, err := io.WriteString(w, string(t.Note)); err != nil {
+		return n + n_, err
+	} else {
+		n += n_
+	}
+	return n, nil
+}
+
+func (t *ConstrainedTuple) UnmarshalCBOR(r io.Reader) (int, error) {
+	bytesRead := 0
+	*t = ConstrainedTuple{}
+
+	br := cbg.GetPeeker(r)
+	scratch := make([]byte, 8)
+	limits := cbg.GetDecodeLimits(br)
+	if err := limits.Enter(); err != nil {
+		return bytesRead, &cbg.DecodeError{Type: "ConstrainedTuple", Err: err}
+	}
+	defer limits.Leave()
+
+	maj, extra, read, err := cbg.CborReadHeaderBuf(br, scratch)
+	if err != nil {
+		return bytesRead, err
+	}
+	bytesRead += read
+	if maj != cbg.MajArray {
+		return bytesRead, &cbg.DecodeError{Type: "ConstrainedTuple", Expected: cbg.MajArray, Found: maj, Err: cbg.ErrUnexpectedType}
+	}
+
+	if extra < 1 || extra > 2 {
+		return bytesRead, &cbg.DecodeError{Type: "ConstrainedTuple", Expected: cbg.MajArray, Found: maj, Err: fmt.Errorf("cbor input had wrong number of fields (%d)", extra)}
+	}
+	if err := limits.AddItems(extra); err != nil {
+		return bytesRead, &cbg.DecodeError{Type: "ConstrainedTuple", Expected: cbg.MajArray, Found: maj, Err: err}
+	}
+
+	// extra is reused by the field decoders.
+	present := int(extra)
+
+	// t.Count (uint64) (uint64)
+
+	{
+
+		maj, extra, read, err = cbg.CborReadHeaderBuf(br, scratch)
+		if err != nil {
+			return bytesRead, cbg.WrapDecodeError(err, "ConstrainedTuple", "Count", bytesRead)
+		}
+		bytesRead += read
+		if maj != cbg.MajUnsignedInt {
+			return bytesRead, &cbg.DecodeError{Type: "ConstrainedTuple", Field: "Count", Offset: bytesRead - read, Expected: cbg.MajUnsignedInt, Found: maj, Err: cbg.ErrUnexpectedType}
+		}
+		t.Count = uint64(extra)
+
+	}
+	// t.Note (string) (string)
+	if present > 1 {
+
+		{
+			sval, read, err := cbg.ReadStringBuf(br, scratch)
+			if err != nil {
+				return bytesRead, cbg.WrapDecodeError(err, "ConstrainedTuple", "Note", bytesRead)
+			}
+			bytesRead += read
+
+			t.Note = string(sval)
+		}
+	}
+
+	if present < 2 {
+		if u, ok := interface{}(t).(cbg.Upgrader); ok {
+			if err := u.Upgrade(present); err != nil {
+				return bytesRead, err
+			}
+		}
+	}
+
+	if t.Count < 1 {
+		return bytesRead, &cbg.DecodeError{Type: "ConstrainedTuple", Field: "Count", Offset: bytesRead, Err: &cbg.ConstraintError{Constraint: "min=1", Got: fmt.Sprint(t.Count)}}
+	}
+	if present > 1 {
+		if len(t.Note) > 2 {
+			return bytesRead, &cbg.DecodeError{Type: "ConstrainedTuple", Field: "Note", Offset: bytesRead, Err: &cbg.ConstraintError{Constraint: "max=2", Got: fmt.Sprintf("length %d", len(t.Note))}}
+		}
+	}
+
+	return bytesRead, nil
+}
+
+func (t *ConstrainedTuple) MarshalDAGJSON(w io.Writer) error {
+	return cbg.MarshalDAGJSON(w, t)
+}
+
+func (t *ConstrainedTuple) UnmarshalDAGJSON(r io.Reader) error {
+	return cbg.UnmarshalDAGJSON(r, t)
+}
+
+func (t *ConstrainedTuple) Links() []cid.Cid {
+	var links []cid.Cid
+	_ = t.ForEachLink(func(_ string, c cid.Cid) error {
+		links = append(links, c)
+		return nil
+	})
+	return links
+}
+
+func (t *ConstrainedTuple) ForEachLink(cb func(path string, c cid.Cid) error) error {
+	if t == nil {
+		return nil
+	}
+	return nil
+}
+
+var lengthBufIntKeyedList = []byte{129}
+
+func (t *IntKeyedList) MarshalCBOR(w io.Writer) (n int, err error) {
+	if t == nil {
+		return w.Write(cbg.CborNull)
+	}
+	if n_, err := w.Write(lengthBufIntKeyedList); err != nil {
+		return n_, err
+	} else {
+		n += n_
+	}
+
+	scratch := make([]byte, 9)
+
+	// t.Headers ([]testing.IntKeyed) (slice)
+	if len(t.Headers) > cbg.MaxLength {
+		return n, xerrors.Errorf("Slice value in field t.Headers was too long")
+	}
+
+	if n_, err := cbg.WriteMajorTypeHeaderBuf(scratch, w, cbg.MajArray, uint64(len(t.Headers))); err != nil {
+		return n + n_, err
+	} else {
+		n += n_
+	}
+	for _, v := range t.Headers {
+		if n_, err := v.MarshalCBOR(w); err != nil {
+			return n + n_, err
+		} else {
+			n += n_
+		}
+	}
+	return n, nil
+}
+
+func (t *IntKeyedList) UnmarshalCBOR(r io.Reader) (int, error) {
+	bytesRead := 0
+	*t = IntKeyedList{}
+
+	br := cbg.GetPeeker(r)
+	scratch := make([]byte, 8)
+	limits := cbg.GetDecodeLimits(br)
+	if err := limits.Enter(); err != nil {
+		return bytesRead, &cbg.DecodeError{Type: "IntKeyedList", Err: err}
+	}
+	defer limits.Leave()
+
+	maj, extra, read, err := cbg.CborReadHeaderBuf(br, scratch)
+	if err != nil {
+		return bytesRead, err
+	}
+	bytesRead += read
+	if maj != cbg.MajArray {
+		return bytesRead, &cbg.DecodeError{Type: "IntKeyedList", Expected: cbg.MajArray, Found: maj, Err: cbg.ErrUnexpectedType}
+	}
+
+	if extra != 1 {
+		return bytesRead, &cbg.DecodeError{Type: "IntKeyedList", Expected: cbg.MajArray, Found: maj, Err: fmt.Errorf("cbor input had wrong number of fields (%d)", extra)}
+	}
+	if err := limits.AddItems(extra); err != nil {
+		return bytesRead, &cbg.DecodeError{Type: "IntKeyedList", Expected: cbg.MajArray, Found: maj, Err: err}
+	}
+
+	// t.Headers ([]testing.IntKeyed) (slice)
+
+	maj, extra, read, err = cbg.CborReadHeaderBuf(br, scratch)
+	if err != nil {
+		return bytesRead, cbg.WrapDecodeError(err, "IntKeyedList", "Headers", bytesRead)
+	}
+	bytesRead += read
+
+	if extra > cbg.MaxLength {
+		return bytesRead, &cbg.DecodeError{Type: "IntKeyedList", Field: "Headers", Offset: bytesRead - read, Expected: cbg.MajArray, Found: maj, Err: cbg.ErrMaxLength}
+	}
+
+	if maj != cbg.MajArray {
+		return bytesRead, &cbg.DecodeError{Type: "IntKeyedList", Field: "Headers", Offset: bytesRead - read, Expected: cbg.MajArray, Found: maj, Err: cbg.ErrUnexpectedType}
+	}
+	if err := limits.AddItems(extra); err != nil {
+		return bytesRead, &cbg.DecodeError{Type: "IntKeyedList", Field: "Headers", Offset: bytesRead - read, Expected: cbg.MajArray, Found: maj, Err: err}
+	}
+
+	if extra > 0 {
+		t.Headers = make([]IntKeyed, extra)
+	}
+
+	for i := 0; i < int(extra); i++ {
+
+		var v IntKeyed
+		if read, err := v.UnmarshalCBOR(br); err != nil {
+			return bytesRead, cbg.WrapDecodeError(err, "IntKeyedList", fmt.Sprintf("Headers[%d]", i), bytesRead)
+		} else {
+			bytesRead += read
+		}
+
+		t.Headers[i] = v
+	}
+
+	return bytesRead, nil
+}
+
+func (t *IntKeyedList) Links() []cid.Cid {
+	var links []cid.Cid
+	_ = t.ForEachLink(func(_ string, c cid.Cid) error {
+		links = append(links, c)
+		return nil
+	})
+	return links
+}
+
+func (t *IntKeyedList) ForEachLink(cb func(path string, c cid.Cid) error) error {
+	if t == nil {
+		return nil
+	}
+	return nil
+}
+
+var lengthBufPage = []byte{132}
+
+func (t *Page[T0]) MarshalCBOR(w io.Writer) (n int, err error) {
+	if t == nil {
+		return w.Write(cbg.CborNull)
+	}
+	if n_, err := w.Write(lengthBufPage); err != nil {
+		return n_, err
+	} else {
+		n += n_
+	}
+
+	scratch := make([]byte, 9)
+
+	// t.First (T0) (type parameter)
+	if n_, err := t.First.MarshalCBOR(w); err != nil {
+		return n + n_, err
+	} else {
+		n += n_
+	}
+
+	// t.Items ([]T0) (slice)
+	if len(t.Items) > cbg.MaxLength {
+		return n, xerrors.Errorf("Slice value in field t.Items was too long")
+	}
+
+	if n_, err := cbg.WriteMajorTypeHeaderBuf(scratch, w, cbg.MajArray, uint64(len(t.Items))); err != nil {
+		return n + n_, err
+	} else {
+		n += n_
+	}
+	for _, v := range t.Items {
+		if n_, err := v.MarshalCBOR(w); err != nil {
+			return n + n_, err
+		} else {
+			n += n_
+		}
+	}
+
+	// t.ByKey (map[string]T0) (map)
+	{
+		if len(t.ByKey) > 4096 {
+			return n, xerrors.Errorf("cannot marshal t.ByKey map too large")
+		}
+
+		if n_, err := cbg.WriteMajorTypeHeaderBuf(scratch, w, cbg.MajMap, uint64(len(t.ByKey))); err != nil {
+			return n + n_, err
+		} else {
+			n += n_
+		}
+
+		keys := make([]string, 0, len(t.ByKey))
+		for k := range t.ByKey {
+			keys = append(keys, k)
+		}
+		cbg.MapKeySort_RFC7049(keys)
+		for _, k := range keys {
+			v := t.ByKey[k]
+
+			if len(k) > cbg.MaxLength {
+				return n, xerrors.Errorf("Value in field k was too long")
+			}
+
+			if n_, err := cbg.WriteMajorTypeHeaderBuf(scratch, w, cbg.MajTextString, uint64(len(k))); err != nil {
+				return n + n_, err
+			} else {
+				n += n_
+			}
+			if n_, err := io.WriteString(w, string(k)); err != nil {
+				return n + n_, err
+			} else {
+				n += n_
+			}
+
+			if n_, err := v.MarshalCBOR(w); err != nil {
+				return n + n_, err
+			} else {
+				n += n_
+			}
+
+		}
+	}
+
+	// t.Next (string) (string)
+	if len(t.Next) > cbg.MaxLength {
+		return n, xerrors.Errorf("Value in field t.Next was too long")
+	}
+
+	if n_, err := cbg.WriteMajorTypeHeaderBuf(scratch, w, cbg.MajTextString, uint64(len(t.Next))); err != nil {
+		return n + n_, err
+	} else {
+		n += n_
+	}
+	if n_, err := io.WriteString(w, string(t.Next)); err != nil {
+		return n + n_, err
+	} else {
+		n += n_
+	}
+	return n, nil
+}
+
+func (t *Page[T0]) UnmarshalCBOR(r io.Reader) (int, error) {
+	bytesRead := 0
+	*t = Page[T0]{}
+
+	br := cbg.GetPeeker(r)
+	scratch := make([]byte, 8)
+	limits := cbg.GetDecodeLimits(br)
+	if err := limits.Enter(); err != nil {
+		return bytesRead, &cbg.DecodeError{Type: "Page", Err: err}
+	}
+	defer limits.Leave()
+
+	maj, extra, read, err := cbg.CborReadHeaderBuf(br, scratch)
+	if err != nil {
+		return bytesRead, err
+	}
+	bytesRead += read
+	if maj != cbg.MajArray {
+		return bytesRead, &cbg.DecodeError{Type: "Page", Expected: cbg.MajArray, Found: maj, Err: cbg.ErrUnexpectedType}
+	}
+
+	if extra != 4 {
+		return bytesRead, &cbg.DecodeError{Type: "Page", Expected: cbg.MajArray, Found: maj, Err: fmt.Errorf("cbor input had wrong number of fields (%d)", extra)}
+	}
+	if err := limits.AddItems(extra); err != nil {
+		return bytesRead, &cbg.DecodeError{Type: "Page", Expected: cbg.MajArray, Found: maj, Err: err}
+	}
+
+	// t.First (T0) (type parameter)
+
+	{
+		if read, err := cbg.UnmarshalTypeParam(br, &t.First); err != nil {
+			return bytesRead, cbg.WrapDecodeError(err, "Page", "First", bytesRead)
+		} else {
+			bytesRead += read
+		}
+	}
+	// t.Items ([]T0) (slice)
+
+	maj, extra, read, err = cbg.CborReadHeaderBuf(br, scratch)
+	if err != nil {
+		return bytesRead, cbg.WrapDecodeError(err, "Page", "Items", bytesRead)
+	}
+	bytesRead += read
+
+	if extra > cbg.MaxLength {
+		return bytesRead, &cbg.DecodeError{Type: "Page", Field: "Items", Offset: bytesRead - read, Expected: cbg.MajArray, Found: maj, Err: cbg.ErrMaxLength}
+	}
+
+	if maj != cbg.MajArray {
+		return bytesRead, &cbg.DecodeError{Type: "Page", Field: "Items", Offset: bytesRead - read, Expected: cbg.MajArray, Found: maj, Err: cbg.ErrUnexpectedType}
+	}
+	if err := limits.AddItems(extra); err != nil {
+		return bytesRead, &cbg.DecodeError{Type: "Page", Field: "Items", Offset: bytesRead - read, Expected: cbg.MajArray, Found: maj, Err: err}
+	}
+
+	if extra > 0 {
+		t.Items = make([]T0, extra)
+	}
+
+	for i := 0; i < int(extra); i++ {
+
+		var v T0
+		if read, err := cbg.UnmarshalTypeParam(br, &v); err != nil {
+			return bytesRead, cbg.WrapDecodeError(err, "Page", fmt.Sprintf("Items[%d]", i), bytesRead)
+		} else {
+			bytesRead += read
+		}
+
+		t.Items[i] = v
+	}
+
+	// t.ByKey (map[string]T0) (map)
+
+	maj, extra, read, err = cbg.CborReadHeaderBuf(br, scratch)
+	if err != nil {
+		return bytesRead, cbg.WrapDecodeError(err, "Page", "ByKey", bytesRead)
+	}
+	bytesRead += read
+	if maj != cbg.MajMap {
+		return bytesRead, &cbg.DecodeError{Type: "Page", Field: "ByKey", Offset: bytesRead - read, Expected: cbg.MajMap, Found: maj, Err: cbg.ErrUnexpectedType}
+	}
+	if extra > 4096 {
+		return bytesRead, &cbg.DecodeError{Type: "Page", Field: "ByKey", Offset: bytesRead - read, Expected: cbg.MajMap, Found: maj, Err: cbg.ErrMaxLength}
+	}
+	if err := limits.AddItems(extra); err != nil {
+		return bytesRead, &cbg.DecodeError{Type: "Page", Field: "ByKey", Offset: bytesRead - read, Expected: cbg.MajMap, Found: maj, Err: err}
+	}
+
+	t.ByKey = make(map[string]T0, extra)
+
+	for i, l := 0, int(extra); i < l; i++ {
+
+		var k string
+
+		{
+			sval, read, err := cbg.ReadStringBuf(br, scratch)
+			if err != nil {
+				return bytesRead, cbg.WrapDecodeError(err, "Page", "ByKey", bytesRead)
+			}
+			bytesRead += read
+
+			k = string(sval)
+		}
+
+		var v T0
+
+		{
+			if read, err := cbg.UnmarshalTypeParam(br, &v); err != nil {
+				return bytesRead, cbg.WrapDecodeError(err, "Page", fmt.Sprintf("ByKey[%q]", k), bytesRead)
+			} else {
+				bytesRead += read
+			}
+		}
+
+		t.ByKey[k] = v
+
+	}
+	// t.Next (string) (string)
+
+	{
+		sval, read, err := cbg.ReadStringBuf(br, scratch)
+		if err != nil {
+			return bytesRead, cbg.WrapDecodeError(err, "Page", "Next", bytesRead)
+		}
+		bytesRead += read
+
+		t.Next = string(sval)
+	}
+	return bytesRead, nil
+}
+
+func (t *Page[T0]) Links() []cid.Cid {
+	var links []cid.Cid
+	_ = t.ForEachLink(func(_ string, c cid.Cid) error {
+		links = append(links, c)
+		return nil
+	})
+	return links
+}
+
+func (t *Page[T0]) ForEachLink(cb func(path string, c cid.Cid) error) error {
+	if t == nil {
+		return nil
+	}
+	if err := cbg.ForEachLinkIn(t.First, "0", cb); err != nil {
+		return err
+	}
+	for i := range t.Items {
+		if err := cbg.ForEachLinkIn(t.Items[i], fmt.Sprintf("%s/%d", "1", i), cb); err != nil {
+			return err
+		}
+	}
+	{
+		keys := make([]string, 0, len(t.ByKey))
+		for k := range t.ByKey {
+			keys = append(keys, k)
+		}
+		cbg.MapKeySort_RFC7049(keys)
+		for _, k0 := range keys {
+			v0 := t.ByKey[k0]
+			if err := cbg.ForEachLinkIn(v0, "2"+"/"+k0, cb); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
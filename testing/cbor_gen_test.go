@@ -0,0 +1,933 @@
+// Code generated by github.com/daotl/cbor-gen. DO NOT EDIT.
+
+package testing
+
+import (
+	"bytes"
+	"math/rand"
+	"reflect"
+	"testing"
+	"testing/quick"
+)
+
+var _ = rand.New
+var _ = reflect.TypeOf
+var _ = quick.Value
+
+func FuzzUnmarshalSignedArray(f *testing.F) {
+	r := rand.New(rand.NewSource(56887))
+	for i := 0; i < 10; i++ {
+		val, ok := quick.Value(reflect.TypeOf(SignedArray{}), r)
+		if !ok {
+			f.Fatal("failed to generate test value")
+		}
+		buf := new(bytes.Buffer)
+		if _, err := val.Addr().Interface().(*SignedArray).MarshalCBOR(buf); err != nil {
+			f.Fatal(err)
+		}
+		f.Add(buf.Bytes())
+	}
+
+	f.Fuzz(func(t *testing.T, data []byte) {
+		var obj SignedArray
+		read, err := obj.UnmarshalCBOR(bytes.NewReader(data))
+		if err != nil {
+			return
+		}
+		if read > len(data) {
+			t.Fatalf("read %d bytes out of %d", read, len(data))
+		}
+
+		// Whatever unmarshals must marshal, and roundtrip from there on.
+		buf := new(bytes.Buffer)
+		if _, err := obj.MarshalCBOR(buf); err != nil {
+			t.Fatal("failed to marshal unmarshaled object: ", err)
+		}
+		enc := buf.Bytes()
+
+		var nobj SignedArray
+		if _, err := nobj.UnmarshalCBOR(bytes.NewReader(enc)); err != nil {
+			t.Fatalf("failed to unmarshal %x: %s", enc, err)
+		}
+		nbuf := new(bytes.Buffer)
+		if _, err := nobj.MarshalCBOR(nbuf); err != nil {
+			t.Fatal("failed to remarshal object: ", err)
+		}
+		if !bytes.Equal(nbuf.Bytes(), enc) {
+			t.Fatalf("objects encodings different: %x != %x", nbuf.Bytes(), enc)
+		}
+	})
+}
+
+func TestRoundtripSignedArray(t *testing.T) {
+	r := rand.New(rand.NewSource(56887))
+	for i := 0; i < 100; i++ {
+		val, ok := quick.Value(reflect.TypeOf(SignedArray{}), r)
+		if !ok {
+			t.Fatal("failed to generate test value")
+		}
+		obj := val.Addr().Interface().(*SignedArray)
+
+		buf := new(bytes.Buffer)
+		if n, err := obj.MarshalCBOR(buf); err != nil {
+			t.Fatal(err)
+		} else if n != buf.Len() {
+			t.Fatal("returned length does not match the byte length")
+		}
+		enc := buf.Bytes()
+
+		var nobj SignedArray
+		if read, err := nobj.UnmarshalCBOR(bytes.NewReader(enc)); err != nil {
+			t.Fatalf("failed to unmarshal %x: %s", enc, err)
+		} else if read != len(enc) {
+			t.Fatalf("wrong bytesRead when unmarshaling: should be %d, actual %d", len(enc), read)
+		}
+
+		nbuf := new(bytes.Buffer)
+		if _, err := nobj.MarshalCBOR(nbuf); err != nil {
+			t.Fatal("failed to remarshal object: ", err)
+		}
+		if !bytes.Equal(nbuf.Bytes(), enc) {
+			t.Fatalf("objects encodings different: %x != %x", nbuf.Bytes(), enc)
+		}
+
+		// Truncated encodings must fail to unmarshal.
+		for _, l := range []int{r.Intn(len(enc)), len(enc) - 1} {
+			var tobj SignedArray
+			if _, err := tobj.UnmarshalCBOR(bytes.NewReader(enc[:l])); err == nil {
+				t.Fatalf("unmarshaled truncated encoding %x", enc[:l])
+			}
+		}
+	}
+}
+
+func FuzzUnmarshalSimpleTypeOne(f *testing.F) {
+	r := rand.New(rand.NewSource(56887))
+	for i := 0; i < 10; i++ {
+		val, ok := quick.Value(reflect.TypeOf(SimpleTypeOne{}), r)
+		if !ok {
+			f.Fatal("failed to generate test value")
+		}
+		buf := new(bytes.Buffer)
+		if _, err := val.Addr().Interface().(*SimpleTypeOne).MarshalCBOR(buf); err != nil {
+			f.Fatal(err)
+		}
+		f.Add(buf.Bytes())
+	}
+
+	f.Fuzz(func(t *testing.T, data []byte) {
+		var obj SimpleTypeOne
+		read, err := obj.UnmarshalCBOR(bytes.NewReader(data))
+		if err != nil {
+			return
+		}
+		if read > len(data) {
+			t.Fatalf("read %d bytes out of %d", read, len(data))
+		}
+
+		// Whatever unmarshals must marshal, and roundtrip from there on.
+		buf := new(bytes.Buffer)
+		if _, err := obj.MarshalCBOR(buf); err != nil {
+			t.Fatal("failed to marshal unmarshaled object: ", err)
+		}
+		enc := buf.Bytes()
+
+		var nobj SimpleTypeOne
+		if _, err := nobj.UnmarshalCBOR(bytes.NewReader(enc)); err != nil {
+			t.Fatalf("failed to unmarshal %x: %s", enc, err)
+		}
+		nbuf := new(bytes.Buffer)
+		if _, err := nobj.MarshalCBOR(nbuf); err != nil {
+			t.Fatal("failed to remarshal object: ", err)
+		}
+		if !bytes.Equal(nbuf.Bytes(), enc) {
+			t.Fatalf("objects encodings different: %x != %x", nbuf.Bytes(), enc)
+		}
+	})
+}
+
+func TestRoundtripSimpleTypeOne(t *testing.T) {
+	r := rand.New(rand.NewSource(56887))
+	for i := 0; i < 100; i++ {
+		val, ok := quick.Value(reflect.TypeOf(SimpleTypeOne{}), r)
+		if !ok {
+			t.Fatal("failed to generate test value")
+		}
+		obj := val.Addr().Interface().(*SimpleTypeOne)
+
+		buf := new(bytes.Buffer)
+		if n, err := obj.MarshalCBOR(buf); err != nil {
+			t.Fatal(err)
+		} else if n != buf.Len() {
+			t.Fatal("returned length does not match the byte length")
+		}
+		enc := buf.Bytes()
+
+		var nobj SimpleTypeOne
+		if read, err := nobj.UnmarshalCBOR(bytes.NewReader(enc)); err != nil {
+			t.Fatalf("failed to unmarshal %x: %s", enc, err)
+		} else if read != len(enc) {
+			t.Fatalf("wrong bytesRead when unmarshaling: should be %d, actual %d", len(enc), read)
+		}
+
+		nbuf := new(bytes.Buffer)
+		if _, err := nobj.MarshalCBOR(nbuf); err != nil {
+			t.Fatal("failed to remarshal object: ", err)
+		}
+		if !bytes.Equal(nbuf.Bytes(), enc) {
+			t.Fatalf("objects encodings different: %x != %x", nbuf.Bytes(), enc)
+		}
+
+		// Truncated encodings must fail to unmarshal.
+		for _, l := range []int{r.Intn(len(enc)), len(enc) - 1} {
+			var tobj SimpleTypeOne
+			if _, err := tobj.UnmarshalCBOR(bytes.NewReader(enc[:l])); err == nil {
+				t.Fatalf("unmarshaled truncated encoding %x", enc[:l])
+			}
+		}
+	}
+}
+
+func FuzzUnmarshalSimpleTypeTwo(f *testing.F) {
+	r := rand.New(rand.NewSource(56887))
+	for i := 0; i < 10; i++ {
+		val, ok := quick.Value(reflect.TypeOf(SimpleTypeTwo{}), r)
+		if !ok {
+			f.Fatal("failed to generate test value")
+		}
+		buf := new(bytes.Buffer)
+		if _, err := val.Addr().Interface().(*SimpleTypeTwo).MarshalCBOR(buf); err != nil {
+			f.Fatal(err)
+		}
+		f.Add(buf.Bytes())
+	}
+
+	f.Fuzz(func(t *testing.T, data []byte) {
+		var obj SimpleTypeTwo
+		read, err := obj.UnmarshalCBOR(bytes.NewReader(data))
+		if err != nil {
+			return
+		}
+		if read > len(data) {
+			t.Fatalf("read %d bytes out of %d", read, len(data))
+		}
+
+		// Whatever unmarshals must marshal, and roundtrip from there on.
+		buf := new(bytes.Buffer)
+		if _, err := obj.MarshalCBOR(buf); err != nil {
+			t.Fatal("failed to marshal unmarshaled object: ", err)
+		}
+		enc := buf.Bytes()
+
+		var nobj SimpleTypeTwo
+		if _, err := nobj.UnmarshalCBOR(bytes.NewReader(enc)); err != nil {
+			t.Fatalf("failed to unmarshal %x: %s", enc, err)
+		}
+		nbuf := new(bytes.Buffer)
+		if _, err := nobj.MarshalCBOR(nbuf); err != nil {
+			t.Fatal("failed to remarshal object: ", err)
+		}
+		if !bytes.Equal(nbuf.Bytes(), enc) {
+			t.Fatalf("objects encodings different: %x != %x", nbuf.Bytes(), enc)
+		}
+	})
+}
+
+func TestRoundtripSimpleTypeTwo(t *testing.T) {
+	r := rand.New(rand.NewSource(56887))
+	for i := 0; i < 100; i++ {
+		val, ok := quick.Value(reflect.TypeOf(SimpleTypeTwo{}), r)
+		if !ok {
+			t.Fatal("failed to generate test value")
+		}
+		obj := val.Addr().Interface().(*SimpleTypeTwo)
+
+		buf := new(bytes.Buffer)
+		if n, err := obj.MarshalCBOR(buf); err != nil {
+			t.Fatal(err)
+		} else if n != buf.Len() {
+			t.Fatal("returned length does not match the byte length")
+		}
+		enc := buf.Bytes()
+
+		var nobj SimpleTypeTwo
+		if read, err := nobj.UnmarshalCBOR(bytes.NewReader(enc)); err != nil {
+			t.Fatalf("failed to unmarshal %x: %s", enc, err)
+		} else if read != len(enc) {
+			t.Fatalf("wrong bytesRead when unmarshaling: should be %d, actual %d", len(enc), read)
+		}
+
+		nbuf := new(bytes.Buffer)
+		if _, err := nobj.MarshalCBOR(nbuf); err != nil {
+			t.Fatal("failed to remarshal object: ", err)
+		}
+		if !bytes.Equal(nbuf.Bytes(), enc) {
+			t.Fatalf("objects encodings different: %x != %x", nbuf.Bytes(), enc)
+		}
+
+		// Truncated encodings must fail to unmarshal.
+		for _, l := range []int{r.Intn(len(enc)), len(enc) - 1} {
+			var tobj SimpleTypeTwo
+			if _, err := tobj.UnmarshalCBOR(bytes.NewReader(enc[:l])); err == nil {
+				t.Fatalf("unmarshaled truncated encoding %x", enc[:l])
+			}
+		}
+	}
+}
+
+func FuzzUnmarshalDeferredContainer(f *testing.F) {
+	buf := new(bytes.Buffer)
+	if _, err := new(DeferredContainer).MarshalCBOR(buf); err == nil {
+		f.Add(buf.Bytes())
+	}
+
+	f.Fuzz(func(t *testing.T, data []byte) {
+		var obj DeferredContainer
+		read, err := obj.UnmarshalCBOR(bytes.NewReader(data))
+		if err != nil {
+			return
+		}
+		if read > len(data) {
+			t.Fatalf("read %d bytes out of %d", read, len(data))
+		}
+
+		// Whatever unmarshals must marshal, and roundtrip from there on.
+		buf := new(bytes.Buffer)
+		if _, err := obj.MarshalCBOR(buf); err != nil {
+			t.Fatal("failed to marshal unmarshaled object: ", err)
+		}
+		enc := buf.Bytes()
+
+		var nobj DeferredContainer
+		if _, err := nobj.UnmarshalCBOR(bytes.NewReader(enc)); err != nil {
+			t.Fatalf("failed to unmarshal %x: %s", enc, err)
+		}
+		nbuf := new(bytes.Buffer)
+		if _, err := nobj.MarshalCBOR(nbuf); err != nil {
+			t.Fatal("failed to remarshal object: ", err)
+		}
+		if !bytes.Equal(nbuf.Bytes(), enc) {
+			t.Fatalf("objects encodings different: %x != %x", nbuf.Bytes(), enc)
+		}
+	})
+}
+
+func FuzzUnmarshalFixedArrays(f *testing.F) {
+	r := rand.New(rand.NewSource(56887))
+	for i := 0; i < 10; i++ {
+		val, ok := quick.Value(reflect.TypeOf(FixedArrays{}), r)
+		if !ok {
+			f.Fatal("failed to generate test value")
+		}
+		buf := new(bytes.Buffer)
+		if _, err := val.Addr().Interface().(*FixedArrays).MarshalCBOR(buf); err != nil {
+			f.Fatal(err)
+		}
+		f.Add(buf.Bytes())
+	}
+
+	f.Fuzz(func(t *testing.T, data []byte) {
+		var obj FixedArrays
+		read, err := obj.UnmarshalCBOR(bytes.NewReader(data))
+		if err != nil {
+			return
+		}
+		if read > len(data) {
+			t.Fatalf("read %d bytes out of %d", read, len(data))
+		}
+
+		// Whatever unmarshals must marshal, and roundtrip from there on.
+		buf := new(bytes.Buffer)
+		if _, err := obj.MarshalCBOR(buf); err != nil {
+			t.Fatal("failed to marshal unmarshaled object: ", err)
+		}
+		enc := buf.Bytes()
+
+		var nobj FixedArrays
+		if _, err := nobj.UnmarshalCBOR(bytes.NewReader(enc)); err != nil {
+			t.Fatalf("failed to unmarshal %x: %s", enc, err)
+		}
+		nbuf := new(bytes.Buffer)
+		if _, err := nobj.MarshalCBOR(nbuf); err != nil {
+			t.Fatal("failed to remarshal object: ", err)
+		}
+		if !bytes.Equal(nbuf.Bytes(), enc) {
+			t.Fatalf("objects encodings different: %x != %x", nbuf.Bytes(), enc)
+		}
+	})
+}
+
+func TestRoundtripFixedArrays(t *testing.T) {
+	r := rand.New(rand.NewSource(56887))
+	for i := 0; i < 100; i++ {
+		val, ok := quick.Value(reflect.TypeOf(FixedArrays{}), r)
+		if !ok {
+			t.Fatal("failed to generate test value")
+		}
+		obj := val.Addr().Interface().(*FixedArrays)
+
+		buf := new(bytes.Buffer)
+		if n, err := obj.MarshalCBOR(buf); err != nil {
+			t.Fatal(err)
+		} else if n != buf.Len() {
+			t.Fatal("returned length does not match the byte length")
+		}
+		enc := buf.Bytes()
+
+		var nobj FixedArrays
+		if read, err := nobj.UnmarshalCBOR(bytes.NewReader(enc)); err != nil {
+			t.Fatalf("failed to unmarshal %x: %s", enc, err)
+		} else if read != len(enc) {
+			t.Fatalf("wrong bytesRead when unmarshaling: should be %d, actual %d", len(enc), read)
+		}
+
+		nbuf := new(bytes.Buffer)
+		if _, err := nobj.MarshalCBOR(nbuf); err != nil {
+			t.Fatal("failed to remarshal object: ", err)
+		}
+		if !bytes.Equal(nbuf.Bytes(), enc) {
+			t.Fatalf("objects encodings different: %x != %x", nbuf.Bytes(), enc)
+		}
+
+		// Truncated encodings must fail to unmarshal.
+		for _, l := range []int{r.Intn(len(enc)), len(enc) - 1} {
+			var tobj FixedArrays
+			if _, err := tobj.UnmarshalCBOR(bytes.NewReader(enc[:l])); err == nil {
+				t.Fatalf("unmarshaled truncated encoding %x", enc[:l])
+			}
+		}
+	}
+}
+
+func FuzzUnmarshalThingWithSomeTime(f *testing.F) {
+	buf := new(bytes.Buffer)
+	if _, err := new(ThingWithSomeTime).MarshalCBOR(buf); err == nil {
+		f.Add(buf.Bytes())
+	}
+
+	f.Fuzz(func(t *testing.T, data []byte) {
+		var obj ThingWithSomeTime
+		read, err := obj.UnmarshalCBOR(bytes.NewReader(data))
+		if err != nil {
+			return
+		}
+		if read > len(data) {
+			t.Fatalf("read %d bytes out of %d", read, len(data))
+		}
+
+		// Whatever unmarshals must marshal, and roundtrip from there on.
+		buf := new(bytes.Buffer)
+		if _, err := obj.MarshalCBOR(buf); err != nil {
+			t.Fatal("failed to marshal unmarshaled object: ", err)
+		}
+		enc := buf.Bytes()
+
+		var nobj ThingWithSomeTime
+		if _, err := nobj.UnmarshalCBOR(bytes.NewReader(enc)); err != nil {
+			t.Fatalf("failed to unmarshal %x: %s", enc, err)
+		}
+		nbuf := new(bytes.Buffer)
+		if _, err := nobj.MarshalCBOR(nbuf); err != nil {
+			t.Fatal("failed to remarshal object: ", err)
+		}
+		if !bytes.Equal(nbuf.Bytes(), enc) {
+			t.Fatalf("objects encodings different: %x != %x", nbuf.Bytes(), enc)
+		}
+	})
+}
+
+func FuzzUnmarshalBigIntContainer(f *testing.F) {
+	buf := new(bytes.Buffer)
+	if _, err := new(BigIntContainer).MarshalCBOR(buf); err == nil {
+		f.Add(buf.Bytes())
+	}
+
+	f.Fuzz(func(t *testing.T, data []byte) {
+		var obj BigIntContainer
+		read, err := obj.UnmarshalCBOR(bytes.NewReader(data))
+		if err != nil {
+			return
+		}
+		if read > len(data) {
+			t.Fatalf("read %d bytes out of %d", read, len(data))
+		}
+
+		// Whatever unmarshals must marshal, and roundtrip from there on.
+		buf := new(bytes.Buffer)
+		if _, err := obj.MarshalCBOR(buf); err != nil {
+			t.Fatal("failed to marshal unmarshaled object: ", err)
+		}
+		enc := buf.Bytes()
+
+		var nobj BigIntContainer
+		if _, err := nobj.UnmarshalCBOR(bytes.NewReader(enc)); err != nil {
+			t.Fatalf("failed to unmarshal %x: %s", enc, err)
+		}
+		nbuf := new(bytes.Buffer)
+		if _, err := nobj.MarshalCBOR(nbuf); err != nil {
+			t.Fatal("failed to remarshal object: ", err)
+		}
+		if !bytes.Equal(nbuf.Bytes(), enc) {
+			t.Fatalf("objects encodings different: %x != %x", nbuf.Bytes(), enc)
+		}
+	})
+}
+
+func FuzzUnmarshalBigIntHolder(f *testing.F) {
+	buf := new(bytes.Buffer)
+	if _, err := new(BigIntHolder).MarshalCBOR(buf); err == nil {
+		f.Add(buf.Bytes())
+	}
+
+	f.Fuzz(func(t *testing.T, data []byte) {
+		var obj BigIntHolder
+		read, err := obj.UnmarshalCBOR(bytes.NewReader(data))
+		if err != nil {
+			return
+		}
+		if read > len(data) {
+			t.Fatalf("read %d bytes out of %d", read, len(data))
+		}
+
+		// Whatever unmarshals must marshal, and roundtrip from there on.
+		buf := new(bytes.Buffer)
+		if _, err := obj.MarshalCBOR(buf); err != nil {
+			t.Fatal("failed to marshal unmarshaled object: ", err)
+		}
+		enc := buf.Bytes()
+
+		var nobj BigIntHolder
+		if _, err := nobj.UnmarshalCBOR(bytes.NewReader(enc)); err != nil {
+			t.Fatalf("failed to unmarshal %x: %s", enc, err)
+		}
+		nbuf := new(bytes.Buffer)
+		if _, err := nobj.MarshalCBOR(nbuf); err != nil {
+			t.Fatal("failed to remarshal object: ", err)
+		}
+		if !bytes.Equal(nbuf.Bytes(), enc) {
+			t.Fatalf("objects encodings different: %x != %x", nbuf.Bytes(), enc)
+		}
+	})
+}
+
+func FuzzUnmarshalLinkContainer(f *testing.F) {
+	buf := new(bytes.Buffer)
+	if _, err := new(LinkContainer).MarshalCBOR(buf); err == nil {
+		f.Add(buf.Bytes())
+	}
+
+	f.Fuzz(func(t *testing.T, data []byte) {
+		var obj LinkContainer
+		read, err := obj.UnmarshalCBOR(bytes.NewReader(data))
+		if err != nil {
+			return
+		}
+		if read > len(data) {
+			t.Fatalf("read %d bytes out of %d", read, len(data))
+		}
+
+		// Whatever unmarshals must marshal, and roundtrip from there on.
+		buf := new(bytes.Buffer)
+		if _, err := obj.MarshalCBOR(buf); err != nil {
+			t.Fatal("failed to marshal unmarshaled object: ", err)
+		}
+		enc := buf.Bytes()
+
+		var nobj LinkContainer
+		if _, err := nobj.UnmarshalCBOR(bytes.NewReader(enc)); err != nil {
+			t.Fatalf("failed to unmarshal %x: %s", enc, err)
+		}
+		nbuf := new(bytes.Buffer)
+		if _, err := nobj.MarshalCBOR(nbuf); err != nil {
+			t.Fatal("failed to remarshal object: ", err)
+		}
+		if !bytes.Equal(nbuf.Bytes(), enc) {
+			t.Fatalf("objects encodings different: %x != %x", nbuf.Bytes(), enc)
+		}
+	})
+}
+
+func FuzzUnmarshalTupleV1(f *testing.F) {
+	r := rand.New(rand.NewSource(56887))
+	for i := 0; i < 10; i++ {
+		val, ok := quick.Value(reflect.TypeOf(TupleV1{}), r)
+		if !ok {
+			f.Fatal("failed to generate test value")
+		}
+		buf := new(bytes.Buffer)
+		if _, err := val.Addr().Interface().(*TupleV1).MarshalCBOR(buf); err != nil {
+			f.Fatal(err)
+		}
+		f.Add(buf.Bytes())
+	}
+
+	f.Fuzz(func(t *testing.T, data []byte) {
+		var obj TupleV1
+		read, err := obj.UnmarshalCBOR(bytes.NewReader(data))
+		if err != nil {
+			return
+		}
+		if read > len(data) {
+			t.Fatalf("read %d bytes out of %d", read, len(data))
+		}
+
+		// Whatever unmarshals must marshal, and roundtrip from there on.
+		buf := new(bytes.Buffer)
+		if _, err := obj.MarshalCBOR(buf); err != nil {
+			t.Fatal("failed to marshal unmarshaled object: ", err)
+		}
+		enc := buf.Bytes()
+
+		var nobj TupleV1
+		if _, err := nobj.UnmarshalCBOR(bytes.NewReader(enc)); err != nil {
+			t.Fatalf("failed to unmarshal %x: %s", enc, err)
+		}
+		nbuf := new(bytes.Buffer)
+		if _, err := nobj.MarshalCBOR(nbuf); err != nil {
+			t.Fatal("failed to remarshal object: ", err)
+		}
+		if !bytes.Equal(nbuf.Bytes(), enc) {
+			t.Fatalf("objects encodings different: %x != %x", nbuf.Bytes(), enc)
+		}
+	})
+}
+
+func TestRoundtripTupleV1(t *testing.T) {
+	r := rand.New(rand.NewSource(56887))
+	for i := 0; i < 100; i++ {
+		val, ok := quick.Value(reflect.TypeOf(TupleV1{}), r)
+		if !ok {
+			t.Fatal("failed to generate test value")
+		}
+		obj := val.Addr().Interface().(*TupleV1)
+
+		buf := new(bytes.Buffer)
+		if n, err := obj.MarshalCBOR(buf); err != nil {
+			t.Fatal(err)
+		} else if n != buf.Len() {
+			t.Fatal("returned length does not match the byte length")
+		}
+		enc := buf.Bytes()
+
+		var nobj TupleV1
+		if read, err := nobj.UnmarshalCBOR(bytes.NewReader(enc)); err != nil {
+			t.Fatalf("failed to unmarshal %x: %s", enc, err)
+		} else if read != len(enc) {
+			t.Fatalf("wrong bytesRead when unmarshaling: should be %d, actual %d", len(enc), read)
+		}
+
+		nbuf := new(bytes.Buffer)
+		if _, err := nobj.MarshalCBOR(nbuf); err != nil {
+			t.Fatal("failed to remarshal object: ", err)
+		}
+		if !bytes.Equal(nbuf.Bytes(), enc) {
+			t.Fatalf("objects encodings different: %x != %x", nbuf.Bytes(), enc)
+		}
+
+		// Truncated encodings must fail to unmarshal.
+		for _, l := range []int{r.Intn(len(enc)), len(enc) - 1} {
+			var tobj TupleV1
+			if _, err := tobj.UnmarshalCBOR(bytes.NewReader(enc[:l])); err == nil {
+				t.Fatalf("unmarshaled truncated encoding %x", enc[:l])
+			}
+		}
+	}
+}
+
+func FuzzUnmarshalTupleV2(f *testing.F) {
+	r := rand.New(rand.NewSource(56887))
+	for i := 0; i < 10; i++ {
+		val, ok := quick.Value(reflect.TypeOf(TupleV2{}), r)
+		if !ok {
+			f.Fatal("failed to generate test value")
+		}
+		buf := new(bytes.Buffer)
+		if _, err := val.Addr().Interface().(*TupleV2).MarshalCBOR(buf); err != nil {
+			f.Fatal(err)
+		}
+		f.Add(buf.Bytes())
+	}
+
+	f.Fuzz(func(t *testing.T, data []byte) {
+		var obj TupleV2
+		read, err := obj.UnmarshalCBOR(bytes.NewReader(data))
+		if err != nil {
+			return
+		}
+		if read > len(data) {
+			t.Fatalf("read %d bytes out of %d", read, len(data))
+		}
+
+		// Whatever unmarshals must marshal, and roundtrip from there on.
+		buf := new(bytes.Buffer)
+		if _, err := obj.MarshalCBOR(buf); err != nil {
+			t.Fatal("failed to marshal unmarshaled object: ", err)
+		}
+		enc := buf.Bytes()
+
+		var nobj TupleV2
+		if _, err := nobj.UnmarshalCBOR(bytes.NewReader(enc)); err != nil {
+			t.Fatalf("failed to unmarshal %x: %s", enc, err)
+		}
+		nbuf := new(bytes.Buffer)
+		if _, err := nobj.MarshalCBOR(nbuf); err != nil {
+			t.Fatal("failed to remarshal object: ", err)
+		}
+		if !bytes.Equal(nbuf.Bytes(), enc) {
+			t.Fatalf("objects encodings different: %x != %x", nbuf.Bytes(), enc)
+		}
+	})
+}
+
+func TestRoundtripTupleV2(t *testing.T) {
+	r := rand.New(rand.NewSource(56887))
+	for i := 0; i < 100; i++ {
+		val, ok := quick.Value(reflect.TypeOf(TupleV2{}), r)
+		if !ok {
+			t.Fatal("failed to generate test value")
+		}
+		obj := val.Addr().Interface().(*TupleV2)
+
+		buf := new(bytes.Buffer)
+		if n, err := obj.MarshalCBOR(buf); err != nil {
+			t.Fatal(err)
+		} else if n != buf.Len() {
+			t.Fatal("returned length does not match the byte length")
+		}
+		enc := buf.Bytes()
+
+		var nobj TupleV2
+		if read, err := nobj.UnmarshalCBOR(bytes.NewReader(enc)); err != nil {
+			t.Fatalf("failed to unmarshal %x: %s", enc, err)
+		} else if read != len(enc) {
+			t.Fatalf("wrong bytesRead when unmarshaling: should be %d, actual %d", len(enc), read)
+		}
+
+		nbuf := new(bytes.Buffer)
+		if _, err := nobj.MarshalCBOR(nbuf); err != nil {
+			t.Fatal("failed to remarshal object: ", err)
+		}
+		if !bytes.Equal(nbuf.Bytes(), enc) {
+			t.Fatalf("objects encodings different: %x != %x", nbuf.Bytes(), enc)
+		}
+
+		// Truncated encodings must fail to unmarshal.
+		for _, l := range []int{r.Intn(len(enc)), len(enc) - 1} {
+			var tobj TupleV2
+			if _, err := tobj.UnmarshalCBOR(bytes.NewReader(enc[:l])); err == nil {
+				t.Fatalf("unmarshaled truncated encoding %x", enc[:l])
+			}
+		}
+	}
+}
+
+func FuzzUnmarshalSortedValues(f *testing.F) {
+	r := rand.New(rand.NewSource(56887))
+	for i := 0; i < 10; i++ {
+		val, ok := quick.Value(reflect.TypeOf(SortedValues{}), r)
+		if !ok {
+			f.Fatal("failed to generate test value")
+		}
+		buf := new(bytes.Buffer)
+		if _, err := val.Addr().Interface().(*SortedValues).MarshalCBOR(buf); err != nil {
+			continue
+		}
+		f.Add(buf.Bytes())
+	}
+
+	f.Fuzz(func(t *testing.T, data []byte) {
+		var obj SortedValues
+		read, err := obj.UnmarshalCBOR(bytes.NewReader(data))
+		if err != nil {
+			return
+		}
+		if read > len(data) {
+			t.Fatalf("read %d bytes out of %d", read, len(data))
+		}
+
+		// Whatever unmarshals must marshal, and roundtrip from there on.
+		buf := new(bytes.Buffer)
+		if _, err := obj.MarshalCBOR(buf); err != nil {
+			t.Fatal("failed to marshal unmarshaled object: ", err)
+		}
+		enc := buf.Bytes()
+
+		var nobj SortedValues
+		if _, err := nobj.UnmarshalCBOR(bytes.NewReader(enc)); err != nil {
+			t.Fatalf("failed to unmarshal %x: %s", enc, err)
+		}
+		nbuf := new(bytes.Buffer)
+		if _, err := nobj.MarshalCBOR(nbuf); err != nil {
+			t.Fatal("failed to remarshal object: ", err)
+		}
+		if !bytes.Equal(nbuf.Bytes(), enc) {
+			t.Fatalf("objects encodings different: %x != %x", nbuf.Bytes(), enc)
+		}
+	})
+}
+
+func TestRoundtripSortedValues(t *testing.T) {
+	r := rand.New(rand.NewSource(56887))
+	for i := 0; i < 100; i++ {
+		val, ok := quick.Value(reflect.TypeOf(SortedValues{}), r)
+		if !ok {
+			t.Fatal("failed to generate test value")
+		}
+		obj := val.Addr().Interface().(*SortedValues)
+
+		buf := new(bytes.Buffer)
+		if n, err := obj.MarshalCBOR(buf); err != nil {
+			continue
+		} else if n != buf.Len() {
+			t.Fatal("returned length does not match the byte length")
+		}
+		enc := buf.Bytes()
+
+		var nobj SortedValues
+		if read, err := nobj.UnmarshalCBOR(bytes.NewReader(enc)); err != nil {
+			t.Fatalf("failed to unmarshal %x: %s", enc, err)
+		} else if read != len(enc) {
+			t.Fatalf("wrong bytesRead when unmarshaling: should be %d, actual %d", len(enc), read)
+		}
+
+		nbuf := new(bytes.Buffer)
+		if _, err := nobj.MarshalCBOR(nbuf); err != nil {
+			t.Fatal("failed to remarshal object: ", err)
+		}
+		if !bytes.Equal(nbuf.Bytes(), enc) {
+			t.Fatalf("objects encodings different: %x != %x", nbuf.Bytes(), enc)
+		}
+
+		// Truncated encodings must fail to unmarshal.
+		for _, l := range []int{r.Intn(len(enc)), len(enc) - 1} {
+			var tobj SortedValues
+			if _, err := tobj.UnmarshalCBOR(bytes.NewReader(enc[:l])); err == nil {
+				t.Fatalf("unmarshaled truncated encoding %x", enc[:l])
+			}
+		}
+	}
+}
+
+func FuzzUnmarshalConstrainedTuple(f *testing.F) {
+	buf := new(bytes.Buffer)
+	if _, err := new(ConstrainedTuple).MarshalCBOR(buf); err == nil {
+		f.Add(buf.Bytes())
+	}
+
+	f.Fuzz(func(t *testing.T, data []byte) {
+		var obj ConstrainedTuple
+		read, err := obj.UnmarshalCBOR(bytes.NewReader(data))
+		if err != nil {
+			return
+		}
+		if read > len(data) {
+			t.Fatalf("read %d bytes out of %d", read, len(data))
+		}
+
+		// Whatever unmarshals must marshal, and roundtrip from there on.
+		buf := new(bytes.Buffer)
+		if _, err := obj.MarshalCBOR(buf); err != nil {
+			t.Fatal("failed to marshal unmarshaled object: ", err)
+		}
+		enc := buf.Bytes()
+
+		var nobj ConstrainedTuple
+		if _, err := nobj.UnmarshalCBOR(bytes.NewReader(enc)); err != nil {
+			t.Fatalf("failed to unmarshal %x: %s", enc, err)
+		}
+		nbuf := new(bytes.Buffer)
+		if _, err := nobj.MarshalCBOR(nbuf); err != nil {
+			t.Fatal("failed to remarshal object: ", err)
+		}
+		if !bytes.Equal(nbuf.Bytes(), enc) {
+			t.Fatalf("objects encodings different: %x != %x", nbuf.Bytes(), enc)
+		}
+	})
+}
+
+func FuzzUnmarshalIntKeyedList(f *testing.F) {
+	r := rand.New(rand.NewSource(56887))
+	for i := 0; i < 10; i++ {
+		val, ok := quick.Value(reflect.TypeOf(IntKeyedList{}), r)
+		if !ok {
+			f.Fatal("failed to generate test value")
+		}
+		buf := new(bytes.Buffer)
+		if _, err := val.Addr().Interface().(*IntKeyedList).MarshalCBOR(buf); err != nil {
+			f.Fatal(err)
+		}
+		f.Add(buf.Bytes())
+	}
+
+	f.Fuzz(func(t *testing.T, data []byte) {
+		var obj IntKeyedList
+		read, err := obj.UnmarshalCBOR(bytes.NewReader(data))
+		if err != nil {
+			return
+		}
+		if read > len(data) {
+			t.Fatalf("read %d bytes out of %d", read, len(data))
+		}
+
+		// Whatever unmarshals must marshal, and roundtrip from there on.
+		buf := new(bytes.Buffer)
+		if _, err := obj.MarshalCBOR(buf); err != nil {
+			t.Fatal("failed to marshal unmarshaled object: ", err)
+		}
+		enc := buf.Bytes()
+
+		var nobj IntKeyedList
+		if _, err := nobj.UnmarshalCBOR(bytes.NewReader(enc)); err != nil {
+			t.Fatalf("failed to unmarshal %x: %s", enc, err)
+		}
+		nbuf := new(bytes.Buffer)
+		if _, err := nobj.MarshalCBOR(nbuf); err != nil {
+			t.Fatal("failed to remarshal object: ", err)
+		}
+		if !bytes.Equal(nbuf.Bytes(), enc) {
+			t.Fatalf("objects encodings different: %x != %x", nbuf.Bytes(), enc)
+		}
+	})
+}
+
+func TestRoundtripIntKeyedList(t *testing.T) {
+	r := rand.New(rand.NewSource(56887))
+	for i := 0; i < 100; i++ {
+		val, ok := quick.Value(reflect.TypeOf(IntKeyedList{}), r)
+		if !ok {
+			t.Fatal("failed to generate test value")
+		}
+		obj := val.Addr().Interface().(*IntKeyedList)
+
+		buf := new(bytes.Buffer)
+		if n, err := obj.MarshalCBOR(buf); err != nil {
+			t.Fatal(err)
+		} else if n != buf.Len() {
+			t.Fatal("returned length does not match the byte length")
+		}
+		enc := buf.Bytes()
+
+		var nobj IntKeyedList
+		if read, err := nobj.UnmarshalCBOR(bytes.NewReader(enc)); err != nil {
+			t.Fatalf("failed to unmarshal %x: %s", enc, err)
+		} else if read != len(enc) {
+			t.Fatalf("wrong bytesRead when unmarshaling: should be %d, actual %d", len(enc), read)
+		}
+
+		nbuf := new(bytes.Buffer)
+		if _, err := nobj.MarshalCBOR(nbuf); err != nil {
+			t.Fatal("failed to remarshal object: ", err)
+		}
+		if !bytes.Equal(nbuf.Bytes(), enc) {
+			t.Fatalf("objects encodings different: %x != %x", nbuf.Bytes(), enc)
+		}
+
+		// Truncated encodings must fail to unmarshal.
+		for _, l := range []int{r.Intn(len(enc)), len(enc) - 1} {
+			var tobj IntKeyedList
+			if _, err := tobj.UnmarshalCBOR(bytes.NewReader(enc[:l])); err == nil {
+				t.Fatalf("unmarshaled truncated encoding %x", enc[:l])
+			}
+		}
+	}
+}
@@ -249,6 +249,11 @@ func (t *SimpleTypeTree) UnmarshalCBOR(r io.Reader) (int, error) {
 
 	br := cbg.GetPeeker(r)
 	scratch := make([]byte, 8)
+	limits := cbg.GetDecodeLimits(br)
+	if err := limits.Enter(); err != nil {
+		return bytesRead, &cbg.DecodeError{Type: "SimpleTypeTree", Err: err}
+	}
+	defer limits.Leave()
 
 	maj, extra, read, err := cbg.CborReadHeaderBuf(br, scratch)
 	if err != nil {
@@ -256,11 +261,14 @@ func (t *SimpleTypeTree) UnmarshalCBOR(r io.Reader) (int, error) {
 	}
 	bytesRead += read
 	if maj != cbg.MajMap {
-		return bytesRead, fmt.Errorf("cbor input should be of type map")
+		return bytesRead, &cbg.DecodeError{Type: "SimpleTypeTree", Expected: cbg.MajMap, Found: maj, Err: cbg.ErrUnexpectedType}
 	}
 
 	if extra > cbg.MaxLength {
-		return bytesRead, fmt.Errorf("SimpleTypeTree: map struct too large (%d)", extra)
+		return bytesRead, &cbg.DecodeError{Type: "SimpleTypeTree", Expected: cbg.MajMap, Found: maj, Err: cbg.ErrMaxLength}
+	}
+	if err := limits.AddItems(extra); err != nil {
+		return bytesRead, &cbg.DecodeError{Type: "SimpleTypeTree", Expected: cbg.MajMap, Found: maj, Err: err}
 	}
 
 	var name string
@@ -271,7 +279,7 @@ func (t *SimpleTypeTree) UnmarshalCBOR(r io.Reader) (int, error) {
 		{
 			sval, read, err := cbg.ReadStringBuf(br, scratch)
 			if err != nil {
-				return bytesRead, err
+				return bytesRead, cbg.WrapDecodeError(err, "SimpleTypeTree", "", bytesRead)
 			}
 			bytesRead += read
 
@@ -285,7 +293,7 @@ func (t *SimpleTypeTree) UnmarshalCBOR(r io.Reader) (int, error) {
 			{
 				sval, read, err := cbg.ReadStringBuf(br, scratch)
 				if err != nil {
-					return bytesRead, err
+					return bytesRead, cbg.WrapDecodeError(err, "SimpleTypeTree", "Dog", bytesRead)
 				}
 				bytesRead += read
 
@@ -296,16 +304,19 @@ func (t *SimpleTypeTree) UnmarshalCBOR(r io.Reader) (int, error) {
 
 			maj, extra, read, err = cbg.CborReadHeaderBuf(br, scratch)
 			if err != nil {
-				return bytesRead, err
+				return bytesRead, cbg.WrapDecodeError(err, "SimpleTypeTree", "Test", bytesRead)
 			}
 			bytesRead += read
 
 			if extra > cbg.MaxLength {
-				return bytesRead, fmt.Errorf("t.Test: array too large (%d)", extra)
+				return bytesRead, &cbg.DecodeError{Type: "SimpleTypeTree", Field: "Test", Offset: bytesRead - read, Expected: cbg.MajArray, Found: maj, Err: cbg.ErrMaxLength}
 			}
 
 			if maj != cbg.MajArray {
-				return bytesRead, fmt.Errorf("expected cbor array")
+				return bytesRead, &cbg.DecodeError{Type: "SimpleTypeTree", Field: "Test", Offset: bytesRead - read, Expected: cbg.MajArray, Found: maj, Err: cbg.ErrUnexpectedType}
+			}
+			if err := limits.AddItems(extra); err != nil {
+				return bytesRead, &cbg.DecodeError{Type: "SimpleTypeTree", Field: "Test", Offset: bytesRead - read, Expected: cbg.MajArray, Found: maj, Err: err}
 			}
 
 			if extra > 0 {
@@ -320,15 +331,18 @@ func (t *SimpleTypeTree) UnmarshalCBOR(r io.Reader) (int, error) {
 
 					maj, extra, read, err = cbg.CborReadHeaderBuf(br, scratch)
 					if err != nil {
-						return bytesRead, err
+						return bytesRead, cbg.WrapDecodeError(err, "SimpleTypeTree", fmt.Sprintf("Test[%d]", i), bytesRead)
 					}
 					bytesRead += read
 
 					if extra > cbg.ByteArrayMaxLen {
-						return bytesRead, fmt.Errorf("t.Test[i]: byte array too large (%d)", extra)
+						return bytesRead, &cbg.DecodeError{Type: "SimpleTypeTree", Field: fmt.Sprintf("Test[%d]", i), Offset: bytesRead - read, Expected: cbg.MajByteString, Found: maj, Err: cbg.ErrMaxLength}
 					}
 					if maj != cbg.MajByteString {
-						return bytesRead, fmt.Errorf("expected byte array")
+						return bytesRead, &cbg.DecodeError{Type: "SimpleTypeTree", Field: fmt.Sprintf("Test[%d]", i), Offset: bytesRead - read, Expected: cbg.MajByteString, Found: maj, Err: cbg.ErrUnexpectedType}
+					}
+					if err := limits.AddBytes(extra); err != nil {
+						return bytesRead, &cbg.DecodeError{Type: "SimpleTypeTree", Field: fmt.Sprintf("Test[%d]", i), Offset: bytesRead - read, Expected: cbg.MajByteString, Found: maj, Err: err}
 					}
 
 					if extra > 0 {
@@ -336,7 +350,7 @@ func (t *SimpleTypeTree) UnmarshalCBOR(r io.Reader) (int, error) {
 					}
 
 					if read, err := io.ReadFull(br, t.Test[i][:]); err != nil {
-						return bytesRead, err
+						return bytesRead, cbg.WrapDecodeError(err, "SimpleTypeTree", fmt.Sprintf("Test[%d]", i), bytesRead)
 					} else {
 						bytesRead += read
 					}
@@ -350,17 +364,17 @@ func (t *SimpleTypeTree) UnmarshalCBOR(r io.Reader) (int, error) {
 
 				b, err := br.ReadByte()
 				if err != nil {
-					return bytesRead, err
+					return bytesRead, cbg.WrapDecodeError(err, "SimpleTypeTree", "Stuff", bytesRead)
 				}
 				bytesRead++
 				if b != cbg.CborNull[0] {
 					if err := br.UnreadByte(); err != nil {
-						return bytesRead, err
+						return bytesRead, cbg.WrapDecodeError(err, "SimpleTypeTree", "Stuff", bytesRead)
 					}
 					bytesRead--
 					t.Stuff = new(SimpleTypeTree)
 					if read, err := t.Stuff.UnmarshalCBOR(br); err != nil {
-						return bytesRead, xerrors.Errorf("unmarshaling t.Stuff pointer: %w", err)
+						return bytesRead, cbg.WrapDecodeError(err, "SimpleTypeTree", "Stuff", bytesRead)
 					} else {
 						bytesRead += read
 					}
@@ -372,16 +386,19 @@ func (t *SimpleTypeTree) UnmarshalCBOR(r io.Reader) (int, error) {
 
 			maj, extra, read, err = cbg.CborReadHeaderBuf(br, scratch)
 			if err != nil {
-				return bytesRead, err
+				return bytesRead, cbg.WrapDecodeError(err, "SimpleTypeTree", "Others", bytesRead)
 			}
 			bytesRead += read
 
 			if extra > cbg.MaxLength {
-				return bytesRead, fmt.Errorf("t.Others: array too large (%d)", extra)
+				return bytesRead, &cbg.DecodeError{Type: "SimpleTypeTree", Field: "Others", Offset: bytesRead - read, Expected: cbg.MajArray, Found: maj, Err: cbg.ErrMaxLength}
 			}
 
 			if maj != cbg.MajArray {
-				return bytesRead, fmt.Errorf("expected cbor array")
+				return bytesRead, &cbg.DecodeError{Type: "SimpleTypeTree", Field: "Others", Offset: bytesRead - read, Expected: cbg.MajArray, Found: maj, Err: cbg.ErrUnexpectedType}
+			}
+			if err := limits.AddItems(extra); err != nil {
+				return bytesRead, &cbg.DecodeError{Type: "SimpleTypeTree", Field: "Others", Offset: bytesRead - read, Expected: cbg.MajArray, Found: maj, Err: err}
 			}
 
 			if extra > 0 {
@@ -392,12 +409,12 @@ func (t *SimpleTypeTree) UnmarshalCBOR(r io.Reader) (int, error) {
 
 				maj, val, read, err := cbg.CborReadHeaderBuf(br, scratch)
 				if err != nil {
-					return bytesRead, xerrors.Errorf("failed to read uint64 for t.Others slice: %w", err)
+					return bytesRead, cbg.WrapDecodeError(err, "SimpleTypeTree", fmt.Sprintf("Others[%d]", i), bytesRead)
 				}
 				bytesRead += read
 
 				if maj != cbg.MajUnsignedInt {
-					return bytesRead, xerrors.Errorf("value read for array t.Others was not a uint, instead got %d", maj)
+					return bytesRead, &cbg.DecodeError{Type: "SimpleTypeTree", Field: fmt.Sprintf("Others[%d]", i), Offset: bytesRead - read, Expected: cbg.MajUnsignedInt, Found: maj, Err: cbg.ErrUnexpectedType}
 				}
 
 				t.Others[i] = uint64(val)
@@ -410,17 +427,17 @@ func (t *SimpleTypeTree) UnmarshalCBOR(r io.Reader) (int, error) {
 
 				b, err := br.ReadByte()
 				if err != nil {
-					return bytesRead, err
+					return bytesRead, cbg.WrapDecodeError(err, "SimpleTypeTree", "Stufff", bytesRead)
 				}
 				bytesRead++
 				if b != cbg.CborNull[0] {
 					if err := br.UnreadByte(); err != nil {
-						return bytesRead, err
+						return bytesRead, cbg.WrapDecodeError(err, "SimpleTypeTree", "Stufff", bytesRead)
 					}
 					bytesRead--
 					t.Stufff = new(SimpleTypeTwo)
 					if read, err := t.Stufff.UnmarshalCBOR(br); err != nil {
-						return bytesRead, xerrors.Errorf("unmarshaling t.Stufff pointer: %w", err)
+						return bytesRead, cbg.WrapDecodeError(err, "SimpleTypeTree", "Stufff", bytesRead)
 					} else {
 						bytesRead += read
 					}
@@ -434,21 +451,21 @@ func (t *SimpleTypeTree) UnmarshalCBOR(r io.Reader) (int, error) {
 
 				b, err := br.ReadByte()
 				if err != nil {
-					return bytesRead, err
+					return bytesRead, cbg.WrapDecodeError(err, "SimpleTypeTree", "NotPizza", bytesRead)
 				}
 				bytesRead++
 				if b != cbg.CborNull[0] {
 					if err := br.UnreadByte(); err != nil {
-						return bytesRead, err
+						return bytesRead, cbg.WrapDecodeError(err, "SimpleTypeTree", "NotPizza", bytesRead)
 					}
 					bytesRead--
 					maj, extra, read, err = cbg.CborReadHeaderBuf(br, scratch)
 					if err != nil {
-						return bytesRead, err
+						return bytesRead, cbg.WrapDecodeError(err, "SimpleTypeTree", "NotPizza", bytesRead)
 					}
 					bytesRead += read
 					if maj != cbg.MajUnsignedInt {
-						return bytesRead, fmt.Errorf("wrong type for uint64 field")
+						return bytesRead, &cbg.DecodeError{Type: "SimpleTypeTree", Field: "NotPizza", Offset: bytesRead - read, Expected: cbg.MajUnsignedInt, Found: maj, Err: cbg.ErrUnexpectedType}
 					}
 					typed := uint64(extra)
 					t.NotPizza = &typed
@@ -461,23 +478,23 @@ func (t *SimpleTypeTree) UnmarshalCBOR(r io.Reader) (int, error) {
 				maj, extra, read, err := cbg.CborReadHeaderBuf(br, scratch)
 				var extraI int64
 				if err != nil {
-					return bytesRead, err
+					return bytesRead, cbg.WrapDecodeError(err, "SimpleTypeTree", "SixtyThreeBitIntegerWithASignBit", bytesRead)
 				}
 				bytesRead += read
 				switch maj {
 				case cbg.MajUnsignedInt:
 					extraI = int64(extra)
 					if extraI < 0 {
-						return bytesRead, fmt.Errorf("int64 positive overflow")
+						return bytesRead, &cbg.DecodeError{Type: "SimpleTypeTree", Field: "SixtyThreeBitIntegerWithASignBit", Offset: bytesRead - read, Expected: maj, Found: maj, Err: cbg.ErrOverflow}
 					}
 				case cbg.MajNegativeInt:
 					extraI = int64(extra)
 					if extraI < 0 {
-						return bytesRead, fmt.Errorf("int64 negative oveflow")
+						return bytesRead, &cbg.DecodeError{Type: "SimpleTypeTree", Field: "SixtyThreeBitIntegerWithASignBit", Offset: bytesRead - read, Expected: maj, Found: maj, Err: cbg.ErrOverflow}
 					}
 					extraI = -1 - extraI
 				default:
-					return bytesRead, fmt.Errorf("wrong type for int64 field: %d", maj)
+					return bytesRead, &cbg.DecodeError{Type: "SimpleTypeTree", Field: "SixtyThreeBitIntegerWithASignBit", Offset: bytesRead - read, Expected: cbg.MajUnsignedInt, Found: maj, Err: cbg.ErrUnexpectedType}
 				}
 
 				t.SixtyThreeBitIntegerWithASignBit = int64(extraI)
@@ -485,7 +502,9 @@ func (t *SimpleTypeTree) UnmarshalCBOR(r io.Reader) (int, error) {
 
 		default:
 			// Field doesn't exist on this type, so ignore it
-			if read, err := cbg.ScanForLinks(r, func(cid.Cid) {}); err == nil {
+			if read, err := cbg.ScanForLinks(br, func(cid.Cid) {}); err != nil {
+				return bytesRead, cbg.WrapDecodeError(err, "SimpleTypeTree", "", bytesRead)
+			} else {
 				bytesRead += read
 			}
 		}
@@ -493,6 +512,31 @@ func (t *SimpleTypeTree) UnmarshalCBOR(r io.Reader) (int, error) {
 
 	return bytesRead, nil
 }
+
+func (t *SimpleTypeTree) MarshalDAGJSON(w io.Writer) error {
+	return cbg.MarshalDAGJSON(w, t)
+}
+
+func (t *SimpleTypeTree) UnmarshalDAGJSON(r io.Reader) error {
+	return cbg.UnmarshalDAGJSON(r, t)
+}
+
+func (t *SimpleTypeTree) Links() []cid.Cid {
+	var links []cid.Cid
+	_ = t.ForEachLink(func(_ string, c cid.Cid) error {
+		links = append(links, c)
+		return nil
+	})
+	return links
+}
+
+func (t *SimpleTypeTree) ForEachLink(cb func(path string, c cid.Cid) error) error {
+	if t == nil {
+		return nil
+	}
+	return nil
+}
+
 func (t *NeedScratchForMap) MarshalCBOR(w io.Writer) (n int, err error) {
 	if t == nil {
 		return w.Write(cbg.CborNull)
@@ -535,6 +579,11 @@ func (t *NeedScratchForMap) UnmarshalCBOR(r io.Reader) (int, error) {
 
 	br := cbg.GetPeeker(r)
 	scratch := make([]byte, 8)
+	limits := cbg.GetDecodeLimits(br)
+	if err := limits.Enter(); err != nil {
+		return bytesRead, &cbg.DecodeError{Type: "NeedScratchForMap", Err: err}
+	}
+	defer limits.Leave()
 
 	maj, extra, read, err := cbg.CborReadHeaderBuf(br, scratch)
 	if err != nil {
@@ -542,11 +591,14 @@ func (t *NeedScratchForMap) UnmarshalCBOR(r io.Reader) (int, error) {
 	}
 	bytesRead += read
 	if maj != cbg.MajMap {
-		return bytesRead, fmt.Errorf("cbor input should be of type map")
+		return bytesRead, &cbg.DecodeError{Type: "NeedScratchForMap", Expected: cbg.MajMap, Found: maj, Err: cbg.ErrUnexpectedType}
 	}
 
 	if extra > cbg.MaxLength {
-		return bytesRead, fmt.Errorf("NeedScratchForMap: map struct too large (%d)", extra)
+		return bytesRead, &cbg.DecodeError{Type: "NeedScratchForMap", Expected: cbg.MajMap, Found: maj, Err: cbg.ErrMaxLength}
+	}
+	if err := limits.AddItems(extra); err != nil {
+		return bytesRead, &cbg.DecodeError{Type: "NeedScratchForMap", Expected: cbg.MajMap, Found: maj, Err: err}
 	}
 
 	var name string
@@ -557,7 +609,7 @@ func (t *NeedScratchForMap) UnmarshalCBOR(r io.Reader) (int, error) {
 		{
 			sval, read, err := cbg.ReadStringBuf(br, scratch)
 			if err != nil {
-				return bytesRead, err
+				return bytesRead, cbg.WrapDecodeError(err, "NeedScratchForMap", "", bytesRead)
 			}
 			bytesRead += read
 
@@ -570,11 +622,11 @@ func (t *NeedScratchForMap) UnmarshalCBOR(r io.Reader) (int, error) {
 
 			maj, extra, read, err = cbg.CborReadHeaderBuf(br, scratch)
 			if err != nil {
-				return bytesRead, err
+				return bytesRead, cbg.WrapDecodeError(err, "NeedScratchForMap", "Thing", bytesRead)
 			}
 			bytesRead += read
 			if maj != cbg.MajOther {
-				return bytesRead, fmt.Errorf("booleans must be major type 7")
+				return bytesRead, &cbg.DecodeError{Type: "NeedScratchForMap", Field: "Thing", Offset: bytesRead - read, Expected: cbg.MajOther, Found: maj, Err: cbg.ErrUnexpectedType}
 			}
 			switch extra {
 			case 20:
@@ -582,12 +634,14 @@ func (t *NeedScratchForMap) UnmarshalCBOR(r io.Reader) (int, error) {
 			case 21:
 				t.Thing = true
 			default:
-				return bytesRead, fmt.Errorf("booleans are either major type 7, value 20 or 21 (got %d)", extra)
+				return bytesRead, &cbg.DecodeError{Type: "NeedScratchForMap", Field: "Thing", Offset: bytesRead - read, Expected: cbg.MajOther, Found: maj, Err: fmt.Errorf("booleans are either major type 7, value 20 or 21 (got %d)", extra)}
 			}
 
 		default:
 			// Field doesn't exist on this type, so ignore it
-			if read, err := cbg.ScanForLinks(r, func(cid.Cid) {}); err == nil {
+			if read, err := cbg.ScanForLinks(br, func(cid.Cid) {}); err != nil {
+				return bytesRead, cbg.WrapDecodeError(err, "NeedScratchForMap", "", bytesRead)
+			} else {
 				bytesRead += read
 			}
 		}
@@ -595,6 +649,31 @@ func (t *NeedScratchForMap) UnmarshalCBOR(r io.Reader) (int, error) {
 
 	return bytesRead, nil
 }
+
+func (t *NeedScratchForMap) MarshalDAGJSON(w io.Writer) error {
+	return cbg.MarshalDAGJSON(w, t)
+}
+
+func (t *NeedScratchForMap) UnmarshalDAGJSON(r io.Reader) error {
+	return cbg.UnmarshalDAGJSON(r, t)
+}
+
+func (t *NeedScratchForMap) Links() []cid.Cid {
+	var links []cid.Cid
+	_ = t.ForEachLink(func(_ string, c cid.Cid) error {
+		links = append(links, c)
+		return nil
+	})
+	return links
+}
+
+func (t *NeedScratchForMap) ForEachLink(cb func(path string, c cid.Cid) error) error {
+	if t == nil {
+		return nil
+	}
+	return nil
+}
+
 func (t *SimpleStructV1) MarshalCBOR(w io.Writer) (n int, err error) {
 	if t == nil {
 		return w.Write(cbg.CborNull)
@@ -844,6 +923,11 @@ func (t *SimpleStructV1) UnmarshalCBOR(r io.Reader) (int, error) {
 
 	br := cbg.GetPeeker(r)
 	scratch := make([]byte, 8)
+	limits := cbg.GetDecodeLimits(br)
+	if err := limits.Enter(); err != nil {
+		return bytesRead, &cbg.DecodeError{Type: "SimpleStructV1", Err: err}
+	}
+	defer limits.Leave()
 
 	maj, extra, read, err := cbg.CborReadHeaderBuf(br, scratch)
 	if err != nil {
@@ -851,11 +935,14 @@ func (t *SimpleStructV1) UnmarshalCBOR(r io.Reader) (int, error) {
 	}
 	bytesRead += read
 	if maj != cbg.MajMap {
-		return bytesRead, fmt.Errorf("cbor input should be of type map")
+		return bytesRead, &cbg.DecodeError{Type: "SimpleStructV1", Expected: cbg.MajMap, Found: maj, Err: cbg.ErrUnexpectedType}
 	}
 
 	if extra > cbg.MaxLength {
-		return bytesRead, fmt.Errorf("SimpleStructV1: map struct too large (%d)", extra)
+		return bytesRead, &cbg.DecodeError{Type: "SimpleStructV1", Expected: cbg.MajMap, Found: maj, Err: cbg.ErrMaxLength}
+	}
+	if err := limits.AddItems(extra); err != nil {
+		return bytesRead, &cbg.DecodeError{Type: "SimpleStructV1", Expected: cbg.MajMap, Found: maj, Err: err}
 	}
 
 	var name string
@@ -866,7 +953,7 @@ func (t *SimpleStructV1) UnmarshalCBOR(r io.Reader) (int, error) {
 		{
 			sval, read, err := cbg.ReadStringBuf(br, scratch)
 			if err != nil {
-				return bytesRead, err
+				return bytesRead, cbg.WrapDecodeError(err, "SimpleStructV1", "", bytesRead)
 			}
 			bytesRead += read
 
@@ -879,14 +966,17 @@ func (t *SimpleStructV1) UnmarshalCBOR(r io.Reader) (int, error) {
 
 			maj, extra, read, err = cbg.CborReadHeaderBuf(br, scratch)
 			if err != nil {
-				return bytesRead, err
+				return bytesRead, cbg.WrapDecodeError(err, "SimpleStructV1", "OldMap", bytesRead)
 			}
 			bytesRead += read
 			if maj != cbg.MajMap {
-				return bytesRead, fmt.Errorf("expected a map (major type 5)")
+				return bytesRead, &cbg.DecodeError{Type: "SimpleStructV1", Field: "OldMap", Offset: bytesRead - read, Expected: cbg.MajMap, Found: maj, Err: cbg.ErrUnexpectedType}
 			}
 			if extra > 4096 {
-				return bytesRead, fmt.Errorf("t.OldMap: map too large")
+				return bytesRead, &cbg.DecodeError{Type: "SimpleStructV1", Field: "OldMap", Offset: bytesRead - read, Expected: cbg.MajMap, Found: maj, Err: cbg.ErrMaxLength}
+			}
+			if err := limits.AddItems(extra); err != nil {
+				return bytesRead, &cbg.DecodeError{Type: "SimpleStructV1", Field: "OldMap", Offset: bytesRead - read, Expected: cbg.MajMap, Found: maj, Err: err}
 			}
 
 			t.OldMap = make(map[string]SimpleTypeOne, extra)
@@ -898,7 +988,7 @@ func (t *SimpleStructV1) UnmarshalCBOR(r io.Reader) (int, error) {
 				{
 					sval, read, err := cbg.ReadStringBuf(br, scratch)
 					if err != nil {
-						return bytesRead, err
+						return bytesRead, cbg.WrapDecodeError(err, "SimpleStructV1", "OldMap", bytesRead)
 					}
 					bytesRead += read
 
@@ -910,7 +1000,7 @@ func (t *SimpleStructV1) UnmarshalCBOR(r io.Reader) (int, error) {
 				{
 
 					if read, err := v.UnmarshalCBOR(br); err != nil {
-						return bytesRead, xerrors.Errorf("unmarshaling v: %w", err)
+						return bytesRead, cbg.WrapDecodeError(err, "SimpleStructV1", fmt.Sprintf("OldMap[%q]", k), bytesRead)
 					} else {
 						bytesRead += read
 					}
@@ -927,11 +1017,11 @@ func (t *SimpleStructV1) UnmarshalCBOR(r io.Reader) (int, error) {
 
 				maj, extra, read, err = cbg.CborReadHeaderBuf(br, scratch)
 				if err != nil {
-					return bytesRead, err
+					return bytesRead, cbg.WrapDecodeError(err, "SimpleStructV1", "OldNum", bytesRead)
 				}
 				bytesRead += read
 				if maj != cbg.MajUnsignedInt {
-					return bytesRead, fmt.Errorf("wrong type for uint64 field")
+					return bytesRead, &cbg.DecodeError{Type: "SimpleStructV1", Field: "OldNum", Offset: bytesRead - read, Expected: cbg.MajUnsignedInt, Found: maj, Err: cbg.ErrUnexpectedType}
 				}
 				t.OldNum = uint64(extra)
 
@@ -943,18 +1033,18 @@ func (t *SimpleStructV1) UnmarshalCBOR(r io.Reader) (int, error) {
 
 				b, err := br.ReadByte()
 				if err != nil {
-					return bytesRead, err
+					return bytesRead, cbg.WrapDecodeError(err, "SimpleStructV1", "OldPtr", bytesRead)
 				}
 				bytesRead++
 				if b != cbg.CborNull[0] {
 					if err := br.UnreadByte(); err != nil {
-						return bytesRead, err
+						return bytesRead, cbg.WrapDecodeError(err, "SimpleStructV1", "OldPtr", bytesRead)
 					}
 					bytesRead--
 
 					c, read, err := cbg.ReadCid(br)
 					if err != nil {
-						return bytesRead, xerrors.Errorf("failed to read cid field t.OldPtr: %w", err)
+						return bytesRead, cbg.WrapDecodeError(err, "SimpleStructV1", "OldPtr", bytesRead)
 					}
 					bytesRead += read
 
@@ -968,7 +1058,7 @@ func (t *SimpleStructV1) UnmarshalCBOR(r io.Reader) (int, error) {
 			{
 				sval, read, err := cbg.ReadStringBuf(br, scratch)
 				if err != nil {
-					return bytesRead, err
+					return bytesRead, cbg.WrapDecodeError(err, "SimpleStructV1", "OldStr", bytesRead)
 				}
 				bytesRead += read
 
@@ -979,16 +1069,19 @@ func (t *SimpleStructV1) UnmarshalCBOR(r io.Reader) (int, error) {
 
 			maj, extra, read, err = cbg.CborReadHeaderBuf(br, scratch)
 			if err != nil {
-				return bytesRead, err
+				return bytesRead, cbg.WrapDecodeError(err, "SimpleStructV1", "OldArray", bytesRead)
 			}
 			bytesRead += read
 
 			if extra > cbg.MaxLength {
-				return bytesRead, fmt.Errorf("t.OldArray: array too large (%d)", extra)
+				return bytesRead, &cbg.DecodeError{Type: "SimpleStructV1", Field: "OldArray", Offset: bytesRead - read, Expected: cbg.MajArray, Found: maj, Err: cbg.ErrMaxLength}
 			}
 
 			if maj != cbg.MajArray {
-				return bytesRead, fmt.Errorf("expected cbor array")
+				return bytesRead, &cbg.DecodeError{Type: "SimpleStructV1", Field: "OldArray", Offset: bytesRead - read, Expected: cbg.MajArray, Found: maj, Err: cbg.ErrUnexpectedType}
+			}
+			if err := limits.AddItems(extra); err != nil {
+				return bytesRead, &cbg.DecodeError{Type: "SimpleStructV1", Field: "OldArray", Offset: bytesRead - read, Expected: cbg.MajArray, Found: maj, Err: err}
 			}
 
 			if extra > 0 {
@@ -999,7 +1092,7 @@ func (t *SimpleStructV1) UnmarshalCBOR(r io.Reader) (int, error) {
 
 				var v SimpleTypeOne
 				if read, err := v.UnmarshalCBOR(br); err != nil {
-					return bytesRead, err
+					return bytesRead, cbg.WrapDecodeError(err, "SimpleStructV1", fmt.Sprintf("OldArray[%d]", i), bytesRead)
 				} else {
 					bytesRead += read
 				}
@@ -1012,15 +1105,18 @@ func (t *SimpleStructV1) UnmarshalCBOR(r io.Reader) (int, error) {
 
 			maj, extra, read, err = cbg.CborReadHeaderBuf(br, scratch)
 			if err != nil {
-				return bytesRead, err
+				return bytesRead, cbg.WrapDecodeError(err, "SimpleStructV1", "OldBytes", bytesRead)
 			}
 			bytesRead += read
 
 			if extra > cbg.ByteArrayMaxLen {
-				return bytesRead, fmt.Errorf("t.OldBytes: byte array too large (%d)", extra)
+				return bytesRead, &cbg.DecodeError{Type: "SimpleStructV1", Field: "OldBytes", Offset: bytesRead - read, Expected: cbg.MajByteString, Found: maj, Err: cbg.ErrMaxLength}
 			}
 			if maj != cbg.MajByteString {
-				return bytesRead, fmt.Errorf("expected byte array")
+				return bytesRead, &cbg.DecodeError{Type: "SimpleStructV1", Field: "OldBytes", Offset: bytesRead - read, Expected: cbg.MajByteString, Found: maj, Err: cbg.ErrUnexpectedType}
+			}
+			if err := limits.AddBytes(extra); err != nil {
+				return bytesRead, &cbg.DecodeError{Type: "SimpleStructV1", Field: "OldBytes", Offset: bytesRead - read, Expected: cbg.MajByteString, Found: maj, Err: err}
 			}
 
 			if extra > 0 {
@@ -1028,7 +1124,7 @@ func (t *SimpleStructV1) UnmarshalCBOR(r io.Reader) (int, error) {
 			}
 
 			if read, err := io.ReadFull(br, t.OldBytes[:]); err != nil {
-				return bytesRead, err
+				return bytesRead, cbg.WrapDecodeError(err, "SimpleStructV1", "OldBytes", bytesRead)
 			} else {
 				bytesRead += read
 			}
@@ -1038,7 +1134,7 @@ func (t *SimpleStructV1) UnmarshalCBOR(r io.Reader) (int, error) {
 			{
 
 				if read, err := t.OldStruct.UnmarshalCBOR(br); err != nil {
-					return bytesRead, xerrors.Errorf("unmarshaling t.OldStruct: %w", err)
+					return bytesRead, cbg.WrapDecodeError(err, "SimpleStructV1", "OldStruct", bytesRead)
 				} else {
 					bytesRead += read
 				}
@@ -1047,7 +1143,9 @@ func (t *SimpleStructV1) UnmarshalCBOR(r io.Reader) (int, error) {
 
 		default:
 			// Field doesn't exist on this type, so ignore it
-			if read, err := cbg.ScanForLinks(r, func(cid.Cid) {}); err == nil {
+			if read, err := cbg.ScanForLinks(br, func(cid.Cid) {}); err != nil {
+				return bytesRead, cbg.WrapDecodeError(err, "SimpleStructV1", "", bytesRead)
+			} else {
 				bytesRead += read
 			}
 		}
@@ -1055,6 +1153,36 @@ func (t *SimpleStructV1) UnmarshalCBOR(r io.Reader) (int, error) {
 
 	return bytesRead, nil
 }
+
+func (t *SimpleStructV1) MarshalDAGJSON(w io.Writer) error {
+	return cbg.MarshalDAGJSON(w, t)
+}
+
+func (t *SimpleStructV1) UnmarshalDAGJSON(r io.Reader) error {
+	return cbg.UnmarshalDAGJSON(r, t)
+}
+
+func (t *SimpleStructV1) Links() []cid.Cid {
+	var links []cid.Cid
+	_ = t.ForEachLink(func(_ string, c cid.Cid) error {
+		links = append(links, c)
+		return nil
+	})
+	return links
+}
+
+func (t *SimpleStructV1) ForEachLink(cb func(path string, c cid.Cid) error) error {
+	if t == nil {
+		return nil
+	}
+	if t.OldPtr != nil {
+		if err := cb("OldPtr", *t.OldPtr); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 func (t *SimpleStructV2) MarshalCBOR(w io.Writer) (n int, err error) {
 	if t == nil {
 		return w.Write(cbg.CborNull)
@@ -1533,6 +1661,11 @@ func (t *SimpleStructV2) UnmarshalCBOR(r io.Reader) (int, error) {
 
 	br := cbg.GetPeeker(r)
 	scratch := make([]byte, 8)
+	limits := cbg.GetDecodeLimits(br)
+	if err := limits.Enter(); err != nil {
+		return bytesRead, &cbg.DecodeError{Type: "SimpleStructV2", Err: err}
+	}
+	defer limits.Leave()
 
 	maj, extra, read, err := cbg.CborReadHeaderBuf(br, scratch)
 	if err != nil {
@@ -1540,11 +1673,14 @@ func (t *SimpleStructV2) UnmarshalCBOR(r io.Reader) (int, error) {
 	}
 	bytesRead += read
 	if maj != cbg.MajMap {
-		return bytesRead, fmt.Errorf("cbor input should be of type map")
+		return bytesRead, &cbg.DecodeError{Type: "SimpleStructV2", Expected: cbg.MajMap, Found: maj, Err: cbg.ErrUnexpectedType}
 	}
 
 	if extra > cbg.MaxLength {
-		return bytesRead, fmt.Errorf("SimpleStructV2: map struct too large (%d)", extra)
+		return bytesRead, &cbg.DecodeError{Type: "SimpleStructV2", Expected: cbg.MajMap, Found: maj, Err: cbg.ErrMaxLength}
+	}
+	if err := limits.AddItems(extra); err != nil {
+		return bytesRead, &cbg.DecodeError{Type: "SimpleStructV2", Expected: cbg.MajMap, Found: maj, Err: err}
 	}
 
 	var name string
@@ -1555,7 +1691,7 @@ func (t *SimpleStructV2) UnmarshalCBOR(r io.Reader) (int, error) {
 		{
 			sval, read, err := cbg.ReadStringBuf(br, scratch)
 			if err != nil {
-				return bytesRead, err
+				return bytesRead, cbg.WrapDecodeError(err, "SimpleStructV2", "", bytesRead)
 			}
 			bytesRead += read
 
@@ -1568,14 +1704,17 @@ func (t *SimpleStructV2) UnmarshalCBOR(r io.Reader) (int, error) {
 
 			maj, extra, read, err = cbg.CborReadHeaderBuf(br, scratch)
 			if err != nil {
-				return bytesRead, err
+				return bytesRead, cbg.WrapDecodeError(err, "SimpleStructV2", "NewMap", bytesRead)
 			}
 			bytesRead += read
 			if maj != cbg.MajMap {
-				return bytesRead, fmt.Errorf("expected a map (major type 5)")
+				return bytesRead, &cbg.DecodeError{Type: "SimpleStructV2", Field: "NewMap", Offset: bytesRead - read, Expected: cbg.MajMap, Found: maj, Err: cbg.ErrUnexpectedType}
 			}
 			if extra > 4096 {
-				return bytesRead, fmt.Errorf("t.NewMap: map too large")
+				return bytesRead, &cbg.DecodeError{Type: "SimpleStructV2", Field: "NewMap", Offset: bytesRead - read, Expected: cbg.MajMap, Found: maj, Err: cbg.ErrMaxLength}
+			}
+			if err := limits.AddItems(extra); err != nil {
+				return bytesRead, &cbg.DecodeError{Type: "SimpleStructV2", Field: "NewMap", Offset: bytesRead - read, Expected: cbg.MajMap, Found: maj, Err: err}
 			}
 
 			t.NewMap = make(map[string]SimpleTypeOne, extra)
@@ -1587,7 +1726,7 @@ func (t *SimpleStructV2) UnmarshalCBOR(r io.Reader) (int, error) {
 				{
 					sval, read, err := cbg.ReadStringBuf(br, scratch)
 					if err != nil {
-						return bytesRead, err
+						return bytesRead, cbg.WrapDecodeError(err, "SimpleStructV2", "NewMap", bytesRead)
 					}
 					bytesRead += read
 
@@ -1599,7 +1738,7 @@ func (t *SimpleStructV2) UnmarshalCBOR(r io.Reader) (int, error) {
 				{
 
 					if read, err := v.UnmarshalCBOR(br); err != nil {
-						return bytesRead, xerrors.Errorf("unmarshaling v: %w", err)
+						return bytesRead, cbg.WrapDecodeError(err, "SimpleStructV2", fmt.Sprintf("NewMap[%q]", k), bytesRead)
 					} else {
 						bytesRead += read
 					}
@@ -1616,11 +1755,11 @@ func (t *SimpleStructV2) UnmarshalCBOR(r io.Reader) (int, error) {
 
 				maj, extra, read, err = cbg.CborReadHeaderBuf(br, scratch)
 				if err != nil {
-					return bytesRead, err
+					return bytesRead, cbg.WrapDecodeError(err, "SimpleStructV2", "NewNum", bytesRead)
 				}
 				bytesRead += read
 				if maj != cbg.MajUnsignedInt {
-					return bytesRead, fmt.Errorf("wrong type for uint64 field")
+					return bytesRead, &cbg.DecodeError{Type: "SimpleStructV2", Field: "NewNum", Offset: bytesRead - read, Expected: cbg.MajUnsignedInt, Found: maj, Err: cbg.ErrUnexpectedType}
 				}
 				t.NewNum = uint64(extra)
 
@@ -1632,18 +1771,18 @@ func (t *SimpleStructV2) UnmarshalCBOR(r io.Reader) (int, error) {
 
 				b, err := br.ReadByte()
 				if err != nil {
-					return bytesRead, err
+					return bytesRead, cbg.WrapDecodeError(err, "SimpleStructV2", "NewPtr", bytesRead)
 				}
 				bytesRead++
 				if b != cbg.CborNull[0] {
 					if err := br.UnreadByte(); err != nil {
-						return bytesRead, err
+						return bytesRead, cbg.WrapDecodeError(err, "SimpleStructV2", "NewPtr", bytesRead)
 					}
 					bytesRead--
 
 					c, read, err := cbg.ReadCid(br)
 					if err != nil {
-						return bytesRead, xerrors.Errorf("failed to read cid field t.NewPtr: %w", err)
+						return bytesRead, cbg.WrapDecodeError(err, "SimpleStructV2", "NewPtr", bytesRead)
 					}
 					bytesRead += read
 
@@ -1657,7 +1796,7 @@ func (t *SimpleStructV2) UnmarshalCBOR(r io.Reader) (int, error) {
 			{
 				sval, read, err := cbg.ReadStringBuf(br, scratch)
 				if err != nil {
-					return bytesRead, err
+					return bytesRead, cbg.WrapDecodeError(err, "SimpleStructV2", "NewStr", bytesRead)
 				}
 				bytesRead += read
 
@@ -1668,14 +1807,17 @@ func (t *SimpleStructV2) UnmarshalCBOR(r io.Reader) (int, error) {
 
 			maj, extra, read, err = cbg.CborReadHeaderBuf(br, scratch)
 			if err != nil {
-				return bytesRead, err
+				return bytesRead, cbg.WrapDecodeError(err, "SimpleStructV2", "OldMap", bytesRead)
 			}
 			bytesRead += read
 			if maj != cbg.MajMap {
-				return bytesRead, fmt.Errorf("expected a map (major type 5)")
+				return bytesRead, &cbg.DecodeError{Type: "SimpleStructV2", Field: "OldMap", Offset: bytesRead - read, Expected: cbg.MajMap, Found: maj, Err: cbg.ErrUnexpectedType}
 			}
 			if extra > 4096 {
-				return bytesRead, fmt.Errorf("t.OldMap: map too large")
+				return bytesRead, &cbg.DecodeError{Type: "SimpleStructV2", Field: "OldMap", Offset: bytesRead - read, Expected: cbg.MajMap, Found: maj, Err: cbg.ErrMaxLength}
+			}
+			if err := limits.AddItems(extra); err != nil {
+				return bytesRead, &cbg.DecodeError{Type: "SimpleStructV2", Field: "OldMap", Offset: bytesRead - read, Expected: cbg.MajMap, Found: maj, Err: err}
 			}
 
 			t.OldMap = make(map[string]SimpleTypeOne, extra)
@@ -1687,7 +1829,7 @@ func (t *SimpleStructV2) UnmarshalCBOR(r io.Reader) (int, error) {
 				{
 					sval, read, err := cbg.ReadStringBuf(br, scratch)
 					if err != nil {
-						return bytesRead, err
+						return bytesRead, cbg.WrapDecodeError(err, "SimpleStructV2", "OldMap", bytesRead)
 					}
 					bytesRead += read
 
@@ -1699,7 +1841,7 @@ func (t *SimpleStructV2) UnmarshalCBOR(r io.Reader) (int, error) {
 				{
 
 					if read, err := v.UnmarshalCBOR(br); err != nil {
-						return bytesRead, xerrors.Errorf("unmarshaling v: %w", err)
+						return bytesRead, cbg.WrapDecodeError(err, "SimpleStructV2", fmt.Sprintf("OldMap[%q]", k), bytesRead)
 					} else {
 						bytesRead += read
 					}
@@ -1716,11 +1858,11 @@ func (t *SimpleStructV2) UnmarshalCBOR(r io.Reader) (int, error) {
 
 				maj, extra, read, err = cbg.CborReadHeaderBuf(br, scratch)
 				if err != nil {
-					return bytesRead, err
+					return bytesRead, cbg.WrapDecodeError(err, "SimpleStructV2", "OldNum", bytesRead)
 				}
 				bytesRead += read
 				if maj != cbg.MajUnsignedInt {
-					return bytesRead, fmt.Errorf("wrong type for uint64 field")
+					return bytesRead, &cbg.DecodeError{Type: "SimpleStructV2", Field: "OldNum", Offset: bytesRead - read, Expected: cbg.MajUnsignedInt, Found: maj, Err: cbg.ErrUnexpectedType}
 				}
 				t.OldNum = uint64(extra)
 
@@ -1732,18 +1874,18 @@ func (t *SimpleStructV2) UnmarshalCBOR(r io.Reader) (int, error) {
 
 				b, err := br.ReadByte()
 				if err != nil {
-					return bytesRead, err
+					return bytesRead, cbg.WrapDecodeError(err, "SimpleStructV2", "OldPtr", bytesRead)
 				}
 				bytesRead++
 				if b != cbg.CborNull[0] {
 					if err := br.UnreadByte(); err != nil {
-						return bytesRead, err
+						return bytesRead, cbg.WrapDecodeError(err, "SimpleStructV2", "OldPtr", bytesRead)
 					}
 					bytesRead--
 
 					c, read, err := cbg.ReadCid(br)
 					if err != nil {
-						return bytesRead, xerrors.Errorf("failed to read cid field t.OldPtr: %w", err)
+						return bytesRead, cbg.WrapDecodeError(err, "SimpleStructV2", "OldPtr", bytesRead)
 					}
 					bytesRead += read
 
@@ -1757,7 +1899,7 @@ func (t *SimpleStructV2) UnmarshalCBOR(r io.Reader) (int, error) {
 			{
 				sval, read, err := cbg.ReadStringBuf(br, scratch)
 				if err != nil {
-					return bytesRead, err
+					return bytesRead, cbg.WrapDecodeError(err, "SimpleStructV2", "OldStr", bytesRead)
 				}
 				bytesRead += read
 
@@ -1768,16 +1910,19 @@ func (t *SimpleStructV2) UnmarshalCBOR(r io.Reader) (int, error) {
 
 			maj, extra, read, err = cbg.CborReadHeaderBuf(br, scratch)
 			if err != nil {
-				return bytesRead, err
+				return bytesRead, cbg.WrapDecodeError(err, "SimpleStructV2", "NewArray", bytesRead)
 			}
 			bytesRead += read
 
 			if extra > cbg.MaxLength {
-				return bytesRead, fmt.Errorf("t.NewArray: array too large (%d)", extra)
+				return bytesRead, &cbg.DecodeError{Type: "SimpleStructV2", Field: "NewArray", Offset: bytesRead - read, Expected: cbg.MajArray, Found: maj, Err: cbg.ErrMaxLength}
 			}
 
 			if maj != cbg.MajArray {
-				return bytesRead, fmt.Errorf("expected cbor array")
+				return bytesRead, &cbg.DecodeError{Type: "SimpleStructV2", Field: "NewArray", Offset: bytesRead - read, Expected: cbg.MajArray, Found: maj, Err: cbg.ErrUnexpectedType}
+			}
+			if err := limits.AddItems(extra); err != nil {
+				return bytesRead, &cbg.DecodeError{Type: "SimpleStructV2", Field: "NewArray", Offset: bytesRead - read, Expected: cbg.MajArray, Found: maj, Err: err}
 			}
 
 			if extra > 0 {
@@ -1788,7 +1933,7 @@ func (t *SimpleStructV2) UnmarshalCBOR(r io.Reader) (int, error) {
 
 				var v SimpleTypeOne
 				if read, err := v.UnmarshalCBOR(br); err != nil {
-					return bytesRead, err
+					return bytesRead, cbg.WrapDecodeError(err, "SimpleStructV2", fmt.Sprintf("NewArray[%d]", i), bytesRead)
 				} else {
 					bytesRead += read
 				}
@@ -1801,15 +1946,18 @@ func (t *SimpleStructV2) UnmarshalCBOR(r io.Reader) (int, error) {
 
 			maj, extra, read, err = cbg.CborReadHeaderBuf(br, scratch)
 			if err != nil {
-				return bytesRead, err
+				return bytesRead, cbg.WrapDecodeError(err, "SimpleStructV2", "NewBytes", bytesRead)
 			}
 			bytesRead += read
 
 			if extra > cbg.ByteArrayMaxLen {
-				return bytesRead, fmt.Errorf("t.NewBytes: byte array too large (%d)", extra)
+				return bytesRead, &cbg.DecodeError{Type: "SimpleStructV2", Field: "NewBytes", Offset: bytesRead - read, Expected: cbg.MajByteString, Found: maj, Err: cbg.ErrMaxLength}
 			}
 			if maj != cbg.MajByteString {
-				return bytesRead, fmt.Errorf("expected byte array")
+				return bytesRead, &cbg.DecodeError{Type: "SimpleStructV2", Field: "NewBytes", Offset: bytesRead - read, Expected: cbg.MajByteString, Found: maj, Err: cbg.ErrUnexpectedType}
+			}
+			if err := limits.AddBytes(extra); err != nil {
+				return bytesRead, &cbg.DecodeError{Type: "SimpleStructV2", Field: "NewBytes", Offset: bytesRead - read, Expected: cbg.MajByteString, Found: maj, Err: err}
 			}
 
 			if extra > 0 {
@@ -1817,7 +1965,7 @@ func (t *SimpleStructV2) UnmarshalCBOR(r io.Reader) (int, error) {
 			}
 
 			if read, err := io.ReadFull(br, t.NewBytes[:]); err != nil {
-				return bytesRead, err
+				return bytesRead, cbg.WrapDecodeError(err, "SimpleStructV2", "NewBytes", bytesRead)
 			} else {
 				bytesRead += read
 			}
@@ -1826,16 +1974,19 @@ func (t *SimpleStructV2) UnmarshalCBOR(r io.Reader) (int, error) {
 
 			maj, extra, read, err = cbg.CborReadHeaderBuf(br, scratch)
 			if err != nil {
-				return bytesRead, err
+				return bytesRead, cbg.WrapDecodeError(err, "SimpleStructV2", "OldArray", bytesRead)
 			}
 			bytesRead += read
 
 			if extra > cbg.MaxLength {
-				return bytesRead, fmt.Errorf("t.OldArray: array too large (%d)", extra)
+				return bytesRead, &cbg.DecodeError{Type: "SimpleStructV2", Field: "OldArray", Offset: bytesRead - read, Expected: cbg.MajArray, Found: maj, Err: cbg.ErrMaxLength}
 			}
 
 			if maj != cbg.MajArray {
-				return bytesRead, fmt.Errorf("expected cbor array")
+				return bytesRead, &cbg.DecodeError{Type: "SimpleStructV2", Field: "OldArray", Offset: bytesRead - read, Expected: cbg.MajArray, Found: maj, Err: cbg.ErrUnexpectedType}
+			}
+			if err := limits.AddItems(extra); err != nil {
+				return bytesRead, &cbg.DecodeError{Type: "SimpleStructV2", Field: "OldArray", Offset: bytesRead - read, Expected: cbg.MajArray, Found: maj, Err: err}
 			}
 
 			if extra > 0 {
@@ -1846,7 +1997,7 @@ func (t *SimpleStructV2) UnmarshalCBOR(r io.Reader) (int, error) {
 
 				var v SimpleTypeOne
 				if read, err := v.UnmarshalCBOR(br); err != nil {
-					return bytesRead, err
+					return bytesRead, cbg.WrapDecodeError(err, "SimpleStructV2", fmt.Sprintf("OldArray[%d]", i), bytesRead)
 				} else {
 					bytesRead += read
 				}
@@ -1859,15 +2010,18 @@ func (t *SimpleStructV2) UnmarshalCBOR(r io.Reader) (int, error) {
 
 			maj, extra, read, err = cbg.CborReadHeaderBuf(br, scratch)
 			if err != nil {
-				return bytesRead, err
+				return bytesRead, cbg.WrapDecodeError(err, "SimpleStructV2", "OldBytes", bytesRead)
 			}
 			bytesRead += read
 
 			if extra > cbg.ByteArrayMaxLen {
-				return bytesRead, fmt.Errorf("t.OldBytes: byte array too large (%d)", extra)
+				return bytesRead, &cbg.DecodeError{Type: "SimpleStructV2", Field: "OldBytes", Offset: bytesRead - read, Expected: cbg.MajByteString, Found: maj, Err: cbg.ErrMaxLength}
 			}
 			if maj != cbg.MajByteString {
-				return bytesRead, fmt.Errorf("expected byte array")
+				return bytesRead, &cbg.DecodeError{Type: "SimpleStructV2", Field: "OldBytes", Offset: bytesRead - read, Expected: cbg.MajByteString, Found: maj, Err: cbg.ErrUnexpectedType}
+			}
+			if err := limits.AddBytes(extra); err != nil {
+				return bytesRead, &cbg.DecodeError{Type: "SimpleStructV2", Field: "OldBytes", Offset: bytesRead - read, Expected: cbg.MajByteString, Found: maj, Err: err}
 			}
 
 			if extra > 0 {
@@ -1875,7 +2029,7 @@ func (t *SimpleStructV2) UnmarshalCBOR(r io.Reader) (int, error) {
 			}
 
 			if read, err := io.ReadFull(br, t.OldBytes[:]); err != nil {
-				return bytesRead, err
+				return bytesRead, cbg.WrapDecodeError(err, "SimpleStructV2", "OldBytes", bytesRead)
 			} else {
 				bytesRead += read
 			}
@@ -1885,7 +2039,7 @@ func (t *SimpleStructV2) UnmarshalCBOR(r io.Reader) (int, error) {
 			{
 
 				if read, err := t.NewStruct.UnmarshalCBOR(br); err != nil {
-					return bytesRead, xerrors.Errorf("unmarshaling t.NewStruct: %w", err)
+					return bytesRead, cbg.WrapDecodeError(err, "SimpleStructV2", "NewStruct", bytesRead)
 				} else {
 					bytesRead += read
 				}
@@ -1897,7 +2051,7 @@ func (t *SimpleStructV2) UnmarshalCBOR(r io.Reader) (int, error) {
 			{
 
 				if read, err := t.OldStruct.UnmarshalCBOR(br); err != nil {
-					return bytesRead, xerrors.Errorf("unmarshaling t.OldStruct: %w", err)
+					return bytesRead, cbg.WrapDecodeError(err, "SimpleStructV2", "OldStruct", bytesRead)
 				} else {
 					bytesRead += read
 				}
@@ -1906,7 +2060,9 @@ func (t *SimpleStructV2) UnmarshalCBOR(r io.Reader) (int, error) {
 
 		default:
 			// Field doesn't exist on this type, so ignore it
-			if read, err := cbg.ScanForLinks(r, func(cid.Cid) {}); err == nil {
+			if read, err := cbg.ScanForLinks(br, func(cid.Cid) {}); err != nil {
+				return bytesRead, cbg.WrapDecodeError(err, "SimpleStructV2", "", bytesRead)
+			} else {
 				bytesRead += read
 			}
 		}
@@ -1914,6 +2070,41 @@ func (t *SimpleStructV2) UnmarshalCBOR(r io.Reader) (int, error) {
 
 	return bytesRead, nil
 }
+
+func (t *SimpleStructV2) MarshalDAGJSON(w io.Writer) error {
+	return cbg.MarshalDAGJSON(w, t)
+}
+
+func (t *SimpleStructV2) UnmarshalDAGJSON(r io.Reader) error {
+	return cbg.UnmarshalDAGJSON(r, t)
+}
+
+func (t *SimpleStructV2) Links() []cid.Cid {
+	var links []cid.Cid
+	_ = t.ForEachLink(func(_ string, c cid.Cid) error {
+		links = append(links, c)
+		return nil
+	})
+	return links
+}
+
+func (t *SimpleStructV2) ForEachLink(cb func(path string, c cid.Cid) error) error {
+	if t == nil {
+		return nil
+	}
+	if t.NewPtr != nil {
+		if err := cb("NewPtr", *t.NewPtr); err != nil {
+			return err
+		}
+	}
+	if t.OldPtr != nil {
+		if err := cb("OldPtr", *t.OldPtr); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 func (t *RenamedFields) MarshalCBOR(w io.Writer) (n int, err error) {
 	if t == nil {
 		return w.Write(cbg.CborNull)
@@ -1995,6 +2186,11 @@ func (t *RenamedFields) UnmarshalCBOR(r io.Reader) (int, error) {
 
 	br := cbg.GetPeeker(r)
 	scratch := make([]byte, 8)
+	limits := cbg.GetDecodeLimits(br)
+	if err := limits.Enter(); err != nil {
+		return bytesRead, &cbg.DecodeError{Type: "RenamedFields", Err: err}
+	}
+	defer limits.Leave()
 
 	maj, extra, read, err := cbg.CborReadHeaderBuf(br, scratch)
 	if err != nil {
@@ -2002,11 +2198,14 @@ func (t *RenamedFields) UnmarshalCBOR(r io.Reader) (int, error) {
 	}
 	bytesRead += read
 	if maj != cbg.MajMap {
-		return bytesRead, fmt.Errorf("cbor input should be of type map")
+		return bytesRead, &cbg.DecodeError{Type: "RenamedFields", Expected: cbg.MajMap, Found: maj, Err: cbg.ErrUnexpectedType}
 	}
 
 	if extra > cbg.MaxLength {
-		return bytesRead, fmt.Errorf("RenamedFields: map struct too large (%d)", extra)
+		return bytesRead, &cbg.DecodeError{Type: "RenamedFields", Expected: cbg.MajMap, Found: maj, Err: cbg.ErrMaxLength}
+	}
+	if err := limits.AddItems(extra); err != nil {
+		return bytesRead, &cbg.DecodeError{Type: "RenamedFields", Expected: cbg.MajMap, Found: maj, Err: err}
 	}
 
 	var name string
@@ -2017,7 +2216,7 @@ func (t *RenamedFields) UnmarshalCBOR(r io.Reader) (int, error) {
 		{
 			sval, read, err := cbg.ReadStringBuf(br, scratch)
 			if err != nil {
-				return bytesRead, err
+				return bytesRead, cbg.WrapDecodeError(err, "RenamedFields", "", bytesRead)
 			}
 			bytesRead += read
 
@@ -2031,7 +2230,7 @@ func (t *RenamedFields) UnmarshalCBOR(r io.Reader) (int, error) {
 			{
 				sval, read, err := cbg.ReadStringBuf(br, scratch)
 				if err != nil {
-					return bytesRead, err
+					return bytesRead, cbg.WrapDecodeError(err, "RenamedFields", "Bar", bytesRead)
 				}
 				bytesRead += read
 
@@ -2043,31 +2242,234 @@ func (t *RenamedFields) UnmarshalCBOR(r io.Reader) (int, error) {
 				maj, extra, read, err := cbg.CborReadHeaderBuf(br, scratch)
 				var extraI int64
 				if err != nil {
-					return bytesRead, err
+					return bytesRead, cbg.WrapDecodeError(err, "RenamedFields", "Foo", bytesRead)
+				}
+				bytesRead += read
+				switch maj {
+				case cbg.MajUnsignedInt:
+					extraI = int64(extra)
+					if extraI < 0 {
+						return bytesRead, &cbg.DecodeError{Type: "RenamedFields", Field: "Foo", Offset: bytesRead - read, Expected: maj, Found: maj, Err: cbg.ErrOverflow}
+					}
+				case cbg.MajNegativeInt:
+					extraI = int64(extra)
+					if extraI < 0 {
+						return bytesRead, &cbg.DecodeError{Type: "RenamedFields", Field: "Foo", Offset: bytesRead - read, Expected: maj, Found: maj, Err: cbg.ErrOverflow}
+					}
+					extraI = -1 - extraI
+				default:
+					return bytesRead, &cbg.DecodeError{Type: "RenamedFields", Field: "Foo", Offset: bytesRead - read, Expected: cbg.MajUnsignedInt, Found: maj, Err: cbg.ErrUnexpectedType}
+				}
+
+				t.Foo = int64(extraI)
+			}
+
+		default:
+			// Field doesn't exist on this type, so ignore it
+			if read, err := cbg.ScanForLinks(br, func(cid.Cid) {}); err != nil {
+				return bytesRead, cbg.WrapDecodeError(err, "RenamedFields", "", bytesRead)
+			} else {
+				bytesRead += read
+			}
+		}
+	}
+
+	return bytesRead, nil
+}
+
+func (t *RenamedFields) MarshalDAGJSON(w io.Writer) error {
+	return cbg.MarshalDAGJSON(w, t)
+}
+
+func (t *RenamedFields) UnmarshalDAGJSON(r io.Reader) error {
+	return cbg.UnmarshalDAGJSON(r, t)
+}
+
+func (t *RenamedFields) Links() []cid.Cid {
+	var links []cid.Cid
+	_ = t.ForEachLink(func(_ string, c cid.Cid) error {
+		links = append(links, c)
+		return nil
+	})
+	return links
+}
+
+func (t *RenamedFields) ForEachLink(cb func(path string, c cid.Cid) error) error {
+	if t == nil {
+		return nil
+	}
+	return nil
+}
+
+func (t *AliasedFields) MarshalCBOR(w io.Writer) (n int, err error) {
+	if t == nil {
+		return w.Write(cbg.CborNull)
+	}
+	if n_, err := w.Write([]byte{162}); err != nil {
+		return n + n_, err
+	} else {
+		n += n_
+	}
+
+	scratch := make([]byte, 9)
+
+	// t.Foo (int64) (int64)
+	if len("f") > cbg.MaxLength {
+		return n, xerrors.Errorf("Value in field \"f\" was too long")
+	}
+
+	if n_, err := cbg.WriteMajorTypeHeaderBuf(scratch, w, cbg.MajTextString, uint64(len("f"))); err != nil {
+		return n + n_, err
+	} else {
+		n += n_
+	}
+	if n_, err := io.WriteString(w, string("f")); err != nil {
+		return n + n_, err
+	} else {
+		n += n_
+	}
+
+	if t.Foo >= 0 {
+		if n_, err := cbg.WriteMajorTypeHeaderBuf(scratch, w, cbg.MajUnsignedInt, uint64(t.Foo)); err != nil {
+			return n + n_, err
+		} else {
+			n += n_
+		}
+	} else {
+		if n_, err := cbg.WriteMajorTypeHeaderBuf(scratch, w, cbg.MajNegativeInt, uint64(-t.Foo-1)); err != nil {
+			return n + n_, err
+		} else {
+			n += n_
+		}
+	}
+
+	// t.Bar (string) (string)
+	if len("bar") > cbg.MaxLength {
+		return n, xerrors.Errorf("Value in field \"bar\" was too long")
+	}
+
+	if n_, err := cbg.WriteMajorTypeHeaderBuf(scratch, w, cbg.MajTextString, uint64(len("bar"))); err != nil {
+		return n + n_, err
+	} else {
+		n += n_
+	}
+	if n_, err := io.WriteString(w, string("bar")); err != nil {
+		return n + n_, err
+	} else {
+		n += n_
+	}
+
+	if len(t.Bar) > cbg.MaxLength {
+		return n, xerrors.Errorf("Value in field t.Bar was too long")
+	}
+
+	if n_, err := cbg.WriteMajorTypeHeaderBuf(scratch, w, cbg.MajTextString, uint64(len(t.Bar))); err != nil {
+		return n + n_, err
+	} else {
+		n += n_
+	}
+	if n_, err := io.WriteString(w, string(t.Bar)); err != nil {
+		return n + n_, err
+	} else {
+		n += n_
+	}
+	return n, nil
+}
+
+func (t *AliasedFields) UnmarshalCBOR(r io.Reader) (int, error) {
+	bytesRead := 0
+	*t = AliasedFields{}
+
+	br := cbg.GetPeeker(r)
+	scratch := make([]byte, 8)
+	limits := cbg.GetDecodeLimits(br)
+	if err := limits.Enter(); err != nil {
+		return bytesRead, &cbg.DecodeError{Type: "AliasedFields", Err: err}
+	}
+	defer limits.Leave()
+
+	maj, extra, read, err := cbg.CborReadHeaderBuf(br, scratch)
+	if err != nil {
+		return bytesRead, err
+	}
+	bytesRead += read
+	if maj != cbg.MajMap {
+		return bytesRead, &cbg.DecodeError{Type: "AliasedFields", Expected: cbg.MajMap, Found: maj, Err: cbg.ErrUnexpectedType}
+	}
+
+	if extra > cbg.MaxLength {
+		return bytesRead, &cbg.DecodeError{Type: "AliasedFields", Expected: cbg.MajMap, Found: maj, Err: cbg.ErrMaxLength}
+	}
+	if err := limits.AddItems(extra); err != nil {
+		return bytesRead, &cbg.DecodeError{Type: "AliasedFields", Expected: cbg.MajMap, Found: maj, Err: err}
+	}
+
+	n := extra
+
+	var seenFoo bool
+	var seenBar bool
+	for i := uint64(0); i < n; i++ {
+
+		key, read, err := cbg.ReadMapKeyBuf(br, scratch)
+		if err != nil {
+			return bytesRead, cbg.WrapDecodeError(err, "AliasedFields", "", bytesRead)
+		}
+		bytesRead += read
+
+		switch key {
+		// t.Foo (int64) (int64)
+		case cbg.TextKey("f"), cbg.TextKey("foo"):
+			if seenFoo {
+				return bytesRead, &cbg.DecodeError{Type: "AliasedFields", Field: "Foo", Offset: bytesRead, Err: fmt.Errorf("several map keys for field")}
+			}
+			seenFoo = true
+			{
+				maj, extra, read, err := cbg.CborReadHeaderBuf(br, scratch)
+				var extraI int64
+				if err != nil {
+					return bytesRead, cbg.WrapDecodeError(err, "AliasedFields", "Foo", bytesRead)
 				}
 				bytesRead += read
 				switch maj {
 				case cbg.MajUnsignedInt:
 					extraI = int64(extra)
 					if extraI < 0 {
-						return bytesRead, fmt.Errorf("int64 positive overflow")
+						return bytesRead, &cbg.DecodeError{Type: "AliasedFields", Field: "Foo", Offset: bytesRead - read, Expected: maj, Found: maj, Err: cbg.ErrOverflow}
 					}
 				case cbg.MajNegativeInt:
 					extraI = int64(extra)
 					if extraI < 0 {
-						return bytesRead, fmt.Errorf("int64 negative oveflow")
+						return bytesRead, &cbg.DecodeError{Type: "AliasedFields", Field: "Foo", Offset: bytesRead - read, Expected: maj, Found: maj, Err: cbg.ErrOverflow}
 					}
 					extraI = -1 - extraI
 				default:
-					return bytesRead, fmt.Errorf("wrong type for int64 field: %d", maj)
+					return bytesRead, &cbg.DecodeError{Type: "AliasedFields", Field: "Foo", Offset: bytesRead - read, Expected: cbg.MajUnsignedInt, Found: maj, Err: cbg.ErrUnexpectedType}
 				}
 
 				t.Foo = int64(extraI)
 			}
+			// t.Bar (string) (string)
+		case cbg.TextKey("bar"), cbg.TextKey("beep"), cbg.IntKey(2):
+			if seenBar {
+				return bytesRead, &cbg.DecodeError{Type: "AliasedFields", Field: "Bar", Offset: bytesRead, Err: fmt.Errorf("several map keys for field")}
+			}
+			seenBar = true
+
+			{
+				sval, read, err := cbg.ReadStringBuf(br, scratch)
+				if err != nil {
+					return bytesRead, cbg.WrapDecodeError(err, "AliasedFields", "Bar", bytesRead)
+				}
+				bytesRead += read
+
+				t.Bar = string(sval)
+			}
 
 		default:
 			// Field doesn't exist on this type, so ignore it
-			if read, err := cbg.ScanForLinks(r, func(cid.Cid) {}); err == nil {
+			if read, err := cbg.ScanForLinks(br, func(cid.Cid) {}); err != nil {
+				return bytesRead, cbg.WrapDecodeError(err, "AliasedFields", "", bytesRead)
+			} else {
 				bytesRead += read
 			}
 		}
@@ -2075,3 +2477,1904 @@ func (t *RenamedFields) UnmarshalCBOR(r io.Reader) (int, error) {
 
 	return bytesRead, nil
 }
+
+func (t *AliasedFields) MarshalDAGJSON(w io.Writer) error {
+	return cbg.MarshalDAGJSON(w, t)
+}
+
+func (t *AliasedFields) UnmarshalDAGJSON(r io.Reader) error {
+	return cbg.UnmarshalDAGJSON(r, t)
+}
+
+func (t *AliasedFields) Links() []cid.Cid {
+	var links []cid.Cid
+	_ = t.ForEachLink(func(_ string, c cid.Cid) error {
+		links = append(links, c)
+		return nil
+	})
+	return links
+}
+
+func (t *AliasedFields) ForEachLink(cb func(path string, c cid.Cid) error) error {
+	if t == nil {
+		return nil
+	}
+	return nil
+}
+
+func (t *UpgradedMap) MarshalCBOR(w io.Writer) (n int, err error) {
+	if t == nil {
+		return w.Write(cbg.CborNull)
+	}
+	if n_, err := w.Write([]byte{162}); err != nil {
+		return n + n_, err
+	} else {
+		n += n_
+	}
+
+	scratch := make([]byte, 9)
+
+	// t.Name (string) (string)
+	if len("Name") > cbg.MaxLength {
+		return n, xerrors.Errorf("Value in field \"Name\" was too long")
+	}
+
+	if n_, err := cbg.WriteMajorTypeHeaderBuf(scratch, w, cbg.MajTextString, uint64(len("Name"))); err != nil {
+		return n + n_, err
+	} else {
+		n += n_
+	}
+	if n_, err := io.WriteString(w, string("Name")); err != nil {
+		return n + n_, err
+	} else {
+		n += n_
+	}
+
+	if len(t.Name) > cbg.MaxLength {
+		return n, xerrors.Errorf("Value in field t.Name was too long")
+	}
+
+	if n_, err := cbg.WriteMajorTypeHeaderBuf(scratch, w, cbg.MajTextString, uint64(len(t.Name))); err != nil {
+		return n + n_, err
+	} else {
+		n += n_
+	}
+	if n_, err := io.WriteString(w, string(t.Name)); err != nil {
+		return n + n_, err
+	} else {
+		n += n_
+	}
+
+	// t.Limit (uint64) (uint64)
+	if len("limit") > cbg.MaxLength {
+		return n, xerrors.Errorf("Value in field \"limit\" was too long")
+	}
+
+	if n_, err := cbg.WriteMajorTypeHeaderBuf(scratch, w, cbg.MajTextString, uint64(len("limit"))); err != nil {
+		return n + n_, err
+	} else {
+		n += n_
+	}
+	if n_, err := io.WriteString(w, string("limit")); err != nil {
+		return n + n_, err
+	} else {
+		n += n_
+	}
+
+	if n_, err := cbg.WriteMajorTypeHeaderBuf(scratch, w, cbg.MajUnsignedInt, uint64(t.Limit)); err != nil {
+		return n + n_, err
+	} else {
+		n += n_
+	}
+
+	return n, nil
+}
+
+func (t *UpgradedMap) UnmarshalCBOR(r io.Reader) (int, error) {
+	bytesRead := 0
+	*t = UpgradedMap{}
+
+	br := cbg.GetPeeker(r)
+	scratch := make([]byte, 8)
+	limits := cbg.GetDecodeLimits(br)
+	if err := limits.Enter(); err != nil {
+		return bytesRead, &cbg.DecodeError{Type: "UpgradedMap", Err: err}
+	}
+	defer limits.Leave()
+
+	maj, extra, read, err := cbg.CborReadHeaderBuf(br, scratch)
+	if err != nil {
+		return bytesRead, err
+	}
+	bytesRead += read
+	if maj != cbg.MajMap {
+		return bytesRead, &cbg.DecodeError{Type: "UpgradedMap", Expected: cbg.MajMap, Found: maj, Err: cbg.ErrUnexpectedType}
+	}
+
+	if extra > cbg.MaxLength {
+		return bytesRead, &cbg.DecodeError{Type: "UpgradedMap", Expected: cbg.MajMap, Found: maj, Err: cbg.ErrMaxLength}
+	}
+	if err := limits.AddItems(extra); err != nil {
+		return bytesRead, &cbg.DecodeError{Type: "UpgradedMap", Expected: cbg.MajMap, Found: maj, Err: err}
+	}
+
+	var name string
+	n := extra
+
+	present := 0
+
+	for i := uint64(0); i < n; i++ {
+
+		{
+			sval, read, err := cbg.ReadStringBuf(br, scratch)
+			if err != nil {
+				return bytesRead, cbg.WrapDecodeError(err, "UpgradedMap", "", bytesRead)
+			}
+			bytesRead += read
+
+			name = string(sval)
+		}
+
+		switch name {
+		// t.Name (string) (string)
+		case "Name":
+			present++
+
+			{
+				sval, read, err := cbg.ReadStringBuf(br, scratch)
+				if err != nil {
+					return bytesRead, cbg.WrapDecodeError(err, "UpgradedMap", "Name", bytesRead)
+				}
+				bytesRead += read
+
+				t.Name = string(sval)
+			}
+			// t.Limit (uint64) (uint64)
+		case "limit":
+			present++
+
+			{
+
+				maj, extra, read, err = cbg.CborReadHeaderBuf(br, scratch)
+				if err != nil {
+					return bytesRead, cbg.WrapDecodeError(err, "UpgradedMap", "Limit", bytesRead)
+				}
+				bytesRead += read
+				if maj != cbg.MajUnsignedInt {
+					return bytesRead, &cbg.DecodeError{Type: "UpgradedMap", Field: "Limit", Offset: bytesRead - read, Expected: cbg.MajUnsignedInt, Found: maj, Err: cbg.ErrUnexpectedType}
+				}
+				t.Limit = uint64(extra)
+
+			}
+
+		default:
+			// Field doesn't exist on this type, so ignore it
+			if read, err := cbg.ScanForLinks(br, func(cid.Cid) {}); err != nil {
+				return bytesRead, cbg.WrapDecodeError(err, "UpgradedMap", "", bytesRead)
+			} else {
+				bytesRead += read
+			}
+		}
+	}
+
+	if present < 2 {
+		if u, ok := interface{}(t).(cbg.Upgrader); ok {
+			if err := u.Upgrade(present); err != nil {
+				return bytesRead, err
+			}
+		}
+	}
+
+	return bytesRead, nil
+}
+
+func (t *UpgradedMap) MarshalDAGJSON(w io.Writer) error {
+	return cbg.MarshalDAGJSON(w, t)
+}
+
+func (t *UpgradedMap) UnmarshalDAGJSON(r io.Reader) error {
+	return cbg.UnmarshalDAGJSON(r, t)
+}
+
+func (t *UpgradedMap) Links() []cid.Cid {
+	var links []cid.Cid
+	_ = t.ForEachLink(func(_ string, c cid.Cid) error {
+		links = append(links, c)
+		return nil
+	})
+	return links
+}
+
+func (t *UpgradedMap) ForEachLink(cb func(path string, c cid.Cid) error) error {
+	if t == nil {
+		return nil
+	}
+	return nil
+}
+
+func (t *MigratingMap) MarshalCBOR(w io.Writer) (n int, err error) {
+	if t == nil {
+		return w.Write(cbg.CborNull)
+	}
+	if n_, err := w.Write([]byte{162}); err != nil {
+		return n + n_, err
+	} else {
+		n += n_
+	}
+
+	scratch := make([]byte, 9)
+
+	// t.Name (string) (string)
+	if len("Name") > cbg.MaxLength {
+		return n, xerrors.Errorf("Value in field \"Name\" was too long")
+	}
+
+	if n_, err := cbg.WriteMajorTypeHeaderBuf(scratch, w, cbg.MajTextString, uint64(len("Name"))); err != nil {
+		return n + n_, err
+	} else {
+		n += n_
+	}
+	if n_, err := io.WriteString(w, string("Name")); err != nil {
+		return n + n_, err
+	} else {
+		n += n_
+	}
+
+	if len(t.Name) > cbg.MaxLength {
+		return n, xerrors.Errorf("Value in field t.Name was too long")
+	}
+
+	if n_, err := cbg.WriteMajorTypeHeaderBuf(scratch, w, cbg.MajTextString, uint64(len(t.Name))); err != nil {
+		return n + n_, err
+	} else {
+		n += n_
+	}
+	if n_, err := io.WriteString(w, string(t.Name)); err != nil {
+		return n + n_, err
+	} else {
+		n += n_
+	}
+
+	// t.Total (uint64) (uint64)
+	if len("total") > cbg.MaxLength {
+		return n, xerrors.Errorf("Value in field \"total\" was too long")
+	}
+
+	if n_, err := cbg.WriteMajorTypeHeaderBuf(scratch, w, cbg.MajTextString, uint64(len("total"))); err != nil {
+		return n + n_, err
+	} else {
+		n += n_
+	}
+	if n_, err := io.WriteString(w, string("total")); err != nil {
+		return n + n_, err
+	} else {
+		n += n_
+	}
+
+	if n_, err := cbg.WriteMajorTypeHeaderBuf(scratch, w, cbg.MajUnsignedInt, uint64(t.Total)); err != nil {
+		return n + n_, err
+	} else {
+		n += n_
+	}
+
+	return n, nil
+}
+
+func (t *MigratingMap) UnmarshalCBOR(r io.Reader) (int, error) {
+	bytesRead := 0
+	*t = MigratingMap{}
+
+	br := cbg.GetPeeker(r)
+	scratch := make([]byte, 8)
+	limits := cbg.GetDecodeLimits(br)
+	if err := limits.Enter(); err != nil {
+		return bytesRead, &cbg.DecodeError{Type: "MigratingMap", Err: err}
+	}
+	defer limits.Leave()
+
+	maj, extra, read, err := cbg.CborReadHeaderBuf(br, scratch)
+	if err != nil {
+		return bytesRead, err
+	}
+	bytesRead += read
+	if maj != cbg.MajMap {
+		return bytesRead, &cbg.DecodeError{Type: "MigratingMap", Expected: cbg.MajMap, Found: maj, Err: cbg.ErrUnexpectedType}
+	}
+
+	if extra > cbg.MaxLength {
+		return bytesRead, &cbg.DecodeError{Type: "MigratingMap", Expected: cbg.MajMap, Found: maj, Err: cbg.ErrMaxLength}
+	}
+	if err := limits.AddItems(extra); err != nil {
+		return bytesRead, &cbg.DecodeError{Type: "MigratingMap", Expected: cbg.MajMap, Found: maj, Err: err}
+	}
+
+	var name string
+	n := extra
+
+	for i := uint64(0); i < n; i++ {
+
+		{
+			sval, read, err := cbg.ReadStringBuf(br, scratch)
+			if err != nil {
+				return bytesRead, cbg.WrapDecodeError(err, "MigratingMap", "", bytesRead)
+			}
+			bytesRead += read
+
+			name = string(sval)
+		}
+
+		switch name {
+		// t.Name (string) (string)
+		case "Name":
+
+			{
+				sval, read, err := cbg.ReadStringBuf(br, scratch)
+				if err != nil {
+					return bytesRead, cbg.WrapDecodeError(err, "MigratingMap", "Name", bytesRead)
+				}
+				bytesRead += read
+
+				t.Name = string(sval)
+			}
+			// t.Count (uint64) (uint64)
+		case "count":
+
+			{
+
+				maj, extra, read, err = cbg.CborReadHeaderBuf(br, scratch)
+				if err != nil {
+					return bytesRead, cbg.WrapDecodeError(err, "MigratingMap", "Count", bytesRead)
+				}
+				bytesRead += read
+				if maj != cbg.MajUnsignedInt {
+					return bytesRead, &cbg.DecodeError{Type: "MigratingMap", Field: "Count", Offset: bytesRead - read, Expected: cbg.MajUnsignedInt, Found: maj, Err: cbg.ErrUnexpectedType}
+				}
+				t.Count = uint64(extra)
+
+			}
+
+		default:
+			// Field doesn't exist on this type, so ignore it
+			if read, err := cbg.ScanForLinks(br, func(cid.Cid) {}); err != nil {
+				return bytesRead, cbg.WrapDecodeError(err, "MigratingMap", "", bytesRead)
+			} else {
+				bytesRead += read
+			}
+		}
+	}
+
+	return bytesRead, nil
+}
+
+func (t *MigratingMap) MarshalDAGJSON(w io.Writer) error {
+	return cbg.MarshalDAGJSON(w, t)
+}
+
+func (t *MigratingMap) UnmarshalDAGJSON(r io.Reader) error {
+	return cbg.UnmarshalDAGJSON(r, t)
+}
+
+func (t *MigratingMap) Links() []cid.Cid {
+	var links []cid.Cid
+	_ = t.ForEachLink(func(_ string, c cid.Cid) error {
+		links = append(links, c)
+		return nil
+	})
+	return links
+}
+
+func (t *MigratingMap) ForEachLink(cb func(path string, c cid.Cid) error) error {
+	if t == nil {
+		return nil
+	}
+	return nil
+}
+
+func (t *Defaults) MarshalCBOR(w io.Writer) (n int, err error) {
+	if t == nil {
+		return w.Write(cbg.CborNull)
+	}
+	fieldCount := 5
+
+	if t.Note == "" {
+		fieldCount--
+	}
+
+	if t.Enabled {
+		fieldCount--
+	}
+
+	if t.Retries == 3 {
+		fieldCount--
+	}
+
+	scratch := make([]byte, 9)
+
+	if n_, err := cbg.WriteMajorTypeHeaderBuf(scratch, w, cbg.MajMap, uint64(fieldCount)); err != nil {
+		return n + n_, err
+	} else {
+		n += n_
+	}
+
+	// t.Name (string) (string)
+	if len("name") > cbg.MaxLength {
+		return n, xerrors.Errorf("Value in field \"name\" was too long")
+	}
+
+	if n_, err := cbg.WriteMajorTypeHeaderBuf(scratch, w, cbg.MajTextString, uint64(len("name"))); err != nil {
+		return n + n_, err
+	} else {
+		n += n_
+	}
+	if n_, err := io.WriteString(w, string("name")); err != nil {
+		return n + n_, err
+	} else {
+		n += n_
+	}
+
+	if len(t.Name) > cbg.MaxLength {
+		return n, xerrors.Errorf("Value in field t.Name was too long")
+	}
+
+	if n_, err := cbg.WriteMajorTypeHeaderBuf(scratch, w, cbg.MajTextString, uint64(len(t.Name))); err != nil {
+		return n + n_, err
+	} else {
+		n += n_
+	}
+	if n_, err := io.WriteString(w, string(t.Name)); err != nil {
+		return n + n_, err
+	} else {
+		n += n_
+	}
+
+	// t.Note (string) (string)
+	if t.Note != "" {
+
+		if len("note") > cbg.MaxLength {
+			return n, xerrors.Errorf("Value in field \"note\" was too long")
+		}
+
+		if n_, err := cbg.WriteMajorTypeHeaderBuf(scratch, w, cbg.MajTextString, uint64(len("note"))); err != nil {
+			return n + n_, err
+		} else {
+			n += n_
+		}
+		if n_, err := io.WriteString(w, string("note")); err != nil {
+			return n + n_, err
+		} else {
+			n += n_
+		}
+
+		if len(t.Note) > cbg.MaxLength {
+			return n, xerrors.Errorf("Value in field t.Note was too long")
+		}
+
+		if n_, err := cbg.WriteMajorTypeHeaderBuf(scratch, w, cbg.MajTextString, uint64(len(t.Note))); err != nil {
+			return n + n_, err
+		} else {
+			n += n_
+		}
+		if n_, err := io.WriteString(w, string(t.Note)); err != nil {
+			return n + n_, err
+		} else {
+			n += n_
+		}
+	}
+
+	// t.Delta (int32) (int32)
+	if len("delta") > cbg.MaxLength {
+		return n, xerrors.Errorf("Value in field \"delta\" was too long")
+	}
+
+	if n_, err := cbg.WriteMajorTypeHeaderBuf(scratch, w, cbg.MajTextString, uint64(len("delta"))); err != nil {
+		return n + n_, err
+	} else {
+		n += n_
+	}
+	if n_, err := io.WriteString(w, string("delta")); err != nil {
+		return n + n_, err
+	} else {
+		n += n_
+	}
+
+	if t.Delta >= 0 {
+		if n_, err := cbg.WriteMajorTypeHeaderBuf(scratch, w, cbg.MajUnsignedInt, uint64(t.Delta)); err != nil {
+			return n + n_, err
+		} else {
+			n += n_
+		}
+	} else {
+		if n_, err := cbg.WriteMajorTypeHeaderBuf(scratch, w, cbg.MajNegativeInt, uint64(-t.Delta-1)); err != nil {
+			return n + n_, err
+		} else {
+			n += n_
+		}
+	}
+
+	// t.Enabled (bool) (bool)
+	if !t.Enabled {
+
+		if len("enabled") > cbg.MaxLength {
+			return n, xerrors.Errorf("Value in field \"enabled\" was too long")
+		}
+
+		if n_, err := cbg.WriteMajorTypeHeaderBuf(scratch, w, cbg.MajTextString, uint64(len("enabled"))); err != nil {
+			return n + n_, err
+		} else {
+			n += n_
+		}
+		if n_, err := io.WriteString(w, string("enabled")); err != nil {
+			return n + n_, err
+		} else {
+			n += n_
+		}
+
+		if n_, err := cbg.WriteBool(w, t.Enabled); err != nil {
+			return n + n_, err
+		} else {
+			n += n_
+		}
+	}
+
+	// t.Retries (uint64) (uint64)
+	if t.Retries != 3 {
+
+		if len("retries") > cbg.MaxLength {
+			return n, xerrors.Errorf("Value in field \"retries\" was too long")
+		}
+
+		if n_, err := cbg.WriteMajorTypeHeaderBuf(scratch, w, cbg.MajTextString, uint64(len("retries"))); err != nil {
+			return n + n_, err
+		} else {
+			n += n_
+		}
+		if n_, err := io.WriteString(w, string("retries")); err != nil {
+			return n + n_, err
+		} else {
+			n += n_
+		}
+
+		if n_, err := cbg.WriteMajorTypeHeaderBuf(scratch, w, cbg.MajUnsignedInt, uint64(t.Retries)); err != nil {
+			return n + n_, err
+		} else {
+			n += n_
+		}
+
+	}
+	return n, nil
+}
+
+func (t *Defaults) UnmarshalCBOR(r io.Reader) (int, error) {
+	bytesRead := 0
+	*t = Defaults{}
+
+	br := cbg.GetPeeker(r)
+	scratch := make([]byte, 8)
+	limits := cbg.GetDecodeLimits(br)
+	if err := limits.Enter(); err != nil {
+		return bytesRead, &cbg.DecodeError{Type: "Defaults", Err: err}
+	}
+	defer limits.Leave()
+
+	maj, extra, read, err := cbg.CborReadHeaderBuf(br, scratch)
+	if err != nil {
+		return bytesRead, err
+	}
+	bytesRead += read
+	if maj != cbg.MajMap {
+		return bytesRead, &cbg.DecodeError{Type: "Defaults", Expected: cbg.MajMap, Found: maj, Err: cbg.ErrUnexpectedType}
+	}
+
+	if extra > cbg.MaxLength {
+		return bytesRead, &cbg.DecodeError{Type: "Defaults", Expected: cbg.MajMap, Found: maj, Err: cbg.ErrMaxLength}
+	}
+	if err := limits.AddItems(extra); err != nil {
+		return bytesRead, &cbg.DecodeError{Type: "Defaults", Expected: cbg.MajMap, Found: maj, Err: err}
+	}
+
+	var name string
+	n := extra
+
+	var seenName bool
+	var seenDelta bool
+	var seenEnabled bool
+	var seenRetries bool
+	for i := uint64(0); i < n; i++ {
+
+		{
+			sval, read, err := cbg.ReadStringBuf(br, scratch)
+			if err != nil {
+				return bytesRead, cbg.WrapDecodeError(err, "Defaults", "", bytesRead)
+			}
+			bytesRead += read
+
+			name = string(sval)
+		}
+
+		switch name {
+		// t.Name (string) (string)
+		case "name":
+			seenName = true
+
+			{
+				sval, read, err := cbg.ReadStringBuf(br, scratch)
+				if err != nil {
+					return bytesRead, cbg.WrapDecodeError(err, "Defaults", "Name", bytesRead)
+				}
+				bytesRead += read
+
+				t.Name = string(sval)
+			}
+			// t.Note (string) (string)
+		case "note":
+
+			{
+				sval, read, err := cbg.ReadStringBuf(br, scratch)
+				if err != nil {
+					return bytesRead, cbg.WrapDecodeError(err, "Defaults", "Note", bytesRead)
+				}
+				bytesRead += read
+
+				t.Note = string(sval)
+			}
+			// t.Delta (int32) (int32)
+		case "delta":
+			seenDelta = true
+			{
+				maj, extra, read, err := cbg.CborReadHeaderBuf(br, scratch)
+				var extraI int64
+				if err != nil {
+					return bytesRead, cbg.WrapDecodeError(err, "Defaults", "Delta", bytesRead)
+				}
+				bytesRead += read
+				switch maj {
+				case cbg.MajUnsignedInt:
+					extraI = int64(extra)
+					if extraI < 0 {
+						return bytesRead, &cbg.DecodeError{Type: "Defaults", Field: "Delta", Offset: bytesRead - read, Expected: maj, Found: maj, Err: cbg.ErrOverflow}
+					}
+				case cbg.MajNegativeInt:
+					extraI = int64(extra)
+					if extraI < 0 {
+						return bytesRead, &cbg.DecodeError{Type: "Defaults", Field: "Delta", Offset: bytesRead - read, Expected: maj, Found: maj, Err: cbg.ErrOverflow}
+					}
+					extraI = -1 - extraI
+				default:
+					return bytesRead, &cbg.DecodeError{Type: "Defaults", Field: "Delta", Offset: bytesRead - read, Expected: cbg.MajUnsignedInt, Found: maj, Err: cbg.ErrUnexpectedType}
+				}
+
+				if extraI > math.MaxInt32 || extraI < math.MinInt32 {
+					return bytesRead, &cbg.DecodeError{Type: "Defaults", Field: "Delta", Offset: bytesRead - read, Expected: maj, Found: maj, Err: cbg.ErrOverflow}
+				}
+
+				t.Delta = int32(extraI)
+			}
+			// t.Enabled (bool) (bool)
+		case "enabled":
+			seenEnabled = true
+
+			maj, extra, read, err = cbg.CborReadHeaderBuf(br, scratch)
+			if err != nil {
+				return bytesRead, cbg.WrapDecodeError(err, "Defaults", "Enabled", bytesRead)
+			}
+			bytesRead += read
+			if maj != cbg.MajOther {
+				return bytesRead, &cbg.DecodeError{Type: "Defaults", Field: "Enabled", Offset: bytesRead - read, Expected: cbg.MajOther, Found: maj, Err: cbg.ErrUnexpectedType}
+			}
+			switch extra {
+			case 20:
+				t.Enabled = false
+			case 21:
+				t.Enabled = true
+			default:
+				return bytesRead, &cbg.DecodeError{Type: "Defaults", Field: "Enabled", Offset: bytesRead - read, Expected: cbg.MajOther, Found: maj, Err: fmt.Errorf("booleans are either major type 7, value 20 or 21 (got %d)", extra)}
+			}
+			// t.Retries (uint64) (uint64)
+		case "retries":
+			seenRetries = true
+
+			{
+
+				maj, extra, read, err = cbg.CborReadHeaderBuf(br, scratch)
+				if err != nil {
+					return bytesRead, cbg.WrapDecodeError(err, "Defaults", "Retries", bytesRead)
+				}
+				bytesRead += read
+				if maj != cbg.MajUnsignedInt {
+					return bytesRead, &cbg.DecodeError{Type: "Defaults", Field: "Retries", Offset: bytesRead - read, Expected: cbg.MajUnsignedInt, Found: maj, Err: cbg.ErrUnexpectedType}
+				}
+				t.Retries = uint64(extra)
+
+			}
+
+		default:
+			// Field doesn't exist on this type, so ignore it
+			if read, err := cbg.ScanForLinks(br, func(cid.Cid) {}); err != nil {
+				return bytesRead, cbg.WrapDecodeError(err, "Defaults", "", bytesRead)
+			} else {
+				bytesRead += read
+			}
+		}
+	}
+
+	if !seenName {
+		t.Name = "anon"
+	}
+
+	if !seenDelta {
+		t.Delta = -1
+	}
+
+	if !seenEnabled {
+		t.Enabled = true
+	}
+
+	if !seenRetries {
+		t.Retries = 3
+	}
+	return bytesRead, nil
+}
+
+func (t *Defaults) MarshalDAGJSON(w io.Writer) error {
+	return cbg.MarshalDAGJSON(w, t)
+}
+
+func (t *Defaults) UnmarshalDAGJSON(r io.Reader) error {
+	return cbg.UnmarshalDAGJSON(r, t)
+}
+
+func (t *Defaults) Links() []cid.Cid {
+	var links []cid.Cid
+	_ = t.ForEachLink(func(_ string, c cid.Cid) error {
+		links = append(links, c)
+		return nil
+	})
+	return links
+}
+
+func (t *Defaults) ForEachLink(cb func(path string, c cid.Cid) error) error {
+	if t == nil {
+		return nil
+	}
+	return nil
+}
+
+func (t *ValidatedMap) MarshalCBOR(w io.Writer) (n int, err error) {
+	if t == nil {
+		return w.Write(cbg.CborNull)
+	}
+	if err := t.PreMarshalCBOR(); err != nil {
+		return 0, err
+	}
+
+	if n_, err := w.Write([]byte{162}); err != nil {
+		return n + n_, err
+	} else {
+		n += n_
+	}
+
+	scratch := make([]byte, 9)
+
+	// t.Max (uint64) (uint64)
+	if len("Max") > cbg.MaxLength {
+		return n, xerrors.Errorf("Value in field \"Max\" was too long")
+	}
+
+	if n_, err := cbg.WriteMajorTypeHeaderBuf(scratch, w, cbg.MajTextString, uint64(len("Max"))); err != nil {
+		return n + n_, err
+	} else {
+		n += n_
+	}
+	if n_, err := io.WriteString(w, string("Max")); err != nil {
+		return n + n_, err
+	} else {
+		n += n_
+	}
+
+	if n_, err := cbg.WriteMajorTypeHeaderBuf(scratch, w, cbg.MajUnsignedInt, uint64(t.Max)); err != nil {
+		return n + n_, err
+	} else {
+		n += n_
+	}
+
+	// t.Min (uint64) (uint64)
+	if len("Min") > cbg.MaxLength {
+		return n, xerrors.Errorf("Value in field \"Min\" was too long")
+	}
+
+	if n_, err := cbg.WriteMajorTypeHeaderBuf(scratch, w, cbg.MajTextString, uint64(len("Min"))); err != nil {
+		return n + n_, err
+	} else {
+		n += n_
+	}
+	if n_, err := io.WriteString(w, string("Min")); err != nil {
+		return n + n_, err
+	} else {
+		n += n_
+	}
+
+	if n_, err := cbg.WriteMajorTypeHeaderBuf(scratch, w, cbg.MajUnsignedInt, uint64(t.Min)); err != nil {
+		return n + n_, err
+	} else {
+		n += n_
+	}
+
+	return n, nil
+}
+
+func (t *ValidatedMap) UnmarshalCBOR(r io.Reader) (int, error) {
+	bytesRead := 0
+	*t = ValidatedMap{}
+
+	br := cbg.GetPeeker(r)
+	scratch := make([]byte, 8)
+	limits := cbg.GetDecodeLimits(br)
+	if err := limits.Enter(); err != nil {
+		return bytesRead, &cbg.DecodeError{Type: "ValidatedMap", Err: err}
+	}
+	defer limits.Leave()
+
+	maj, extra, read, err := cbg.CborReadHeaderBuf(br, scratch)
+	if err != nil {
+		return bytesRead, err
+	}
+	bytesRead += read
+	if maj != cbg.MajMap {
+		return bytesRead, &cbg.DecodeError{Type: "ValidatedMap", Expected: cbg.MajMap, Found: maj, Err: cbg.ErrUnexpectedType}
+	}
+
+	if extra > cbg.MaxLength {
+		return bytesRead, &cbg.DecodeError{Type: "ValidatedMap", Expected: cbg.MajMap, Found: maj, Err: cbg.ErrMaxLength}
+	}
+	if err := limits.AddItems(extra); err != nil {
+		return bytesRead, &cbg.DecodeError{Type: "ValidatedMap", Expected: cbg.MajMap, Found: maj, Err: err}
+	}
+
+	var name string
+	n := extra
+
+	for i := uint64(0); i < n; i++ {
+
+		{
+			sval, read, err := cbg.ReadStringBuf(br, scratch)
+			if err != nil {
+				return bytesRead, cbg.WrapDecodeError(err, "ValidatedMap", "", bytesRead)
+			}
+			bytesRead += read
+
+			name = string(sval)
+		}
+
+		switch name {
+		// t.Max (uint64) (uint64)
+		case "Max":
+
+			{
+
+				maj, extra, read, err = cbg.CborReadHeaderBuf(br, scratch)
+				if err != nil {
+					return bytesRead, cbg.WrapDecodeError(err, "ValidatedMap", "Max", bytesRead)
+				}
+				bytesRead += read
+				if maj != cbg.MajUnsignedInt {
+					return bytesRead, &cbg.DecodeError{Type: "ValidatedMap", Field: "Max", Offset: bytesRead - read, Expected: cbg.MajUnsignedInt, Found: maj, Err: cbg.ErrUnexpectedType}
+				}
+				t.Max = uint64(extra)
+
+			}
+			// t.Min (uint64) (uint64)
+		case "Min":
+
+			{
+
+				maj, extra, read, err = cbg.CborReadHeaderBuf(br, scratch)
+				if err != nil {
+					return bytesRead, cbg.WrapDecodeError(err, "ValidatedMap", "Min", bytesRead)
+				}
+				bytesRead += read
+				if maj != cbg.MajUnsignedInt {
+					return bytesRead, &cbg.DecodeError{Type: "ValidatedMap", Field: "Min", Offset: bytesRead - read, Expected: cbg.MajUnsignedInt, Found: maj, Err: cbg.ErrUnexpectedType}
+				}
+				t.Min = uint64(extra)
+
+			}
+
+		default:
+			// Field doesn't exist on this type, so ignore it
+			if read, err := cbg.ScanForLinks(br, func(cid.Cid) {}); err != nil {
+				return bytesRead, cbg.WrapDecodeError(err, "ValidatedMap", "", bytesRead)
+			} else {
+				bytesRead += read
+			}
+		}
+	}
+
+	if err := t.PostUnmarshalCBOR(); err != nil {
+		return bytesRead, err
+	}
+
+	return bytesRead, nil
+}
+
+func (t *ValidatedMap) MarshalDAGJSON(w io.Writer) error {
+	return cbg.MarshalDAGJSON(w, t)
+}
+
+func (t *ValidatedMap) UnmarshalDAGJSON(r io.Reader) error {
+	return cbg.UnmarshalDAGJSON(r, t)
+}
+
+func (t *ValidatedMap) Links() []cid.Cid {
+	var links []cid.Cid
+	_ = t.ForEachLink(func(_ string, c cid.Cid) error {
+		links = append(links, c)
+		return nil
+	})
+	return links
+}
+
+func (t *ValidatedMap) ForEachLink(cb func(path string, c cid.Cid) error) error {
+	if t == nil {
+		return nil
+	}
+	return nil
+}
+
+func (t *Constrained) MarshalCBOR(w io.Writer) (n int, err error) {
+	if t == nil {
+		return w.Write(cbg.CborNull)
+	}
+	if n_, err := w.Write([]byte{167}); err != nil {
+		return n + n_, err
+	} else {
+		n += n_
+	}
+
+	scratch := make([]byte, 9)
+
+	// t.Hash ([]uint8) (slice)
+	if len("hash") > cbg.MaxLength {
+		return n, xerrors.Errorf("Value in field \"hash\" was too long")
+	}
+
+	if n_, err := cbg.WriteMajorTypeHeaderBuf(scratch, w, cbg.MajTextString, uint64(len("hash"))); err != nil {
+		return n + n_, err
+	} else {
+		n += n_
+	}
+	if n_, err := io.WriteString(w, string("hash")); err != nil {
+		return n + n_, err
+	} else {
+		n += n_
+	}
+
+	if len(t.Hash) > cbg.ByteArrayMaxLen {
+		return n, xerrors.Errorf("Byte array in field t.Hash was too long")
+	}
+
+	if n_, err := cbg.WriteMajorTypeHeaderBuf(scratch, w, cbg.MajByteString, uint64(len(t.Hash))); err != nil {
+		return n + n_, err
+	} else {
+		n += n_
+	}
+
+	if n_, err := w.Write(t.Hash[:]); err != nil {
+		return n + n_, err
+	} else {
+		n += n_
+	}
+
+	// t.Kind (string) (string)
+	if len("kind") > cbg.MaxLength {
+		return n, xerrors.Errorf("Value in field \"kind\" was too long")
+	}
+
+	if n_, err := cbg.WriteMajorTypeHeaderBuf(scratch, w, cbg.MajTextString, uint64(len("kind"))); err != nil {
+		return n + n_, err
+	} else {
+		n += n_
+	}
+	if n_, err := io.WriteString(w, string("kind")); err != nil {
+		return n + n_, err
+	} else {
+		n += n_
+	}
+
+	if len(t.Kind) > cbg.MaxLength {
+		return n, xerrors.Errorf("Value in field t.Kind was too long")
+	}
+
+	if n_, err := cbg.WriteMajorTypeHeaderBuf(scratch, w, cbg.MajTextString, uint64(len(t.Kind))); err != nil {
+		return n + n_, err
+	} else {
+		n += n_
+	}
+	if n_, err := io.WriteString(w, string(t.Kind)); err != nil {
+		return n + n_, err
+	} else {
+		n += n_
+	}
+
+	// t.Name (string) (string)
+	if len("name") > cbg.MaxLength {
+		return n, xerrors.Errorf("Value in field \"name\" was too long")
+	}
+
+	if n_, err := cbg.WriteMajorTypeHeaderBuf(scratch, w, cbg.MajTextString, uint64(len("name"))); err != nil {
+		return n + n_, err
+	} else {
+		n += n_
+	}
+	if n_, err := io.WriteString(w, string("name")); err != nil {
+		return n + n_, err
+	} else {
+		n += n_
+	}
+
+	if len(t.Name) > cbg.MaxLength {
+		return n, xerrors.Errorf("Value in field t.Name was too long")
+	}
+
+	if n_, err := cbg.WriteMajorTypeHeaderBuf(scratch, w, cbg.MajTextString, uint64(len(t.Name))); err != nil {
+		return n + n_, err
+	} else {
+		n += n_
+	}
+	if n_, err := io.WriteString(w, string(t.Name)); err != nil {
+		return n + n_, err
+	} else {
+		n += n_
+	}
+
+	// t.Port (uint16) (uint16)
+	if len("port") > cbg.MaxLength {
+		return n, xerrors.Errorf("Value in field \"port\" was too long")
+	}
+
+	if n_, err := cbg.WriteMajorTypeHeaderBuf(scratch, w, cbg.MajTextString, uint64(len("port"))); err != nil {
+		return n + n_, err
+	} else {
+		n += n_
+	}
+	if n_, err := io.WriteString(w, string("port")); err != nil {
+		return n + n_, err
+	} else {
+		n += n_
+	}
+
+	if n_, err := cbg.WriteMajorTypeHeaderBuf(scratch, w, cbg.MajUnsignedInt, uint64(t.Port)); err != nil {
+		return n + n_, err
+	} else {
+		n += n_
+	}
+
+	// t.Tags ([]uint64) (slice)
+	if len("tags") > cbg.MaxLength {
+		return n, xerrors.Errorf("Value in field \"tags\" was too long")
+	}
+
+	if n_, err := cbg.WriteMajorTypeHeaderBuf(scratch, w, cbg.MajTextString, uint64(len("tags"))); err != nil {
+		return n + n_, err
+	} else {
+		n += n_
+	}
+	if n_, err := io.WriteString(w, string("tags")); err != nil {
+		return n + n_, err
+	} else {
+		n += n_
+	}
+
+	if len(t.Tags) > cbg.MaxLength {
+		return n, xerrors.Errorf("Slice value in field t.Tags was too long")
+	}
+
+	if n_, err := cbg.WriteMajorTypeHeaderBuf(scratch, w, cbg.MajArray, uint64(len(t.Tags))); err != nil {
+		return n + n_, err
+	} else {
+		n += n_
+	}
+	for _, v := range t.Tags {
+		if n_, err := cbg.CborWriteHeader(w, cbg.MajUnsignedInt, uint64(v)); err != nil {
+			return n + n_, err
+		} else {
+			n += n_
+		}
+	}
+
+	// t.Level (int8) (int8)
+	if len("level") > cbg.MaxLength {
+		return n, xerrors.Errorf("Value in field \"level\" was too long")
+	}
+
+	if n_, err := cbg.WriteMajorTypeHeaderBuf(scratch, w, cbg.MajTextString, uint64(len("level"))); err != nil {
+		return n + n_, err
+	} else {
+		n += n_
+	}
+	if n_, err := io.WriteString(w, string("level")); err != nil {
+		return n + n_, err
+	} else {
+		n += n_
+	}
+
+	if t.Level >= 0 {
+		if n_, err := cbg.WriteMajorTypeHeaderBuf(scratch, w, cbg.MajUnsignedInt, uint64(t.Level)); err != nil {
+			return n + n_, err
+		} else {
+			n += n_
+		}
+	} else {
+		if n_, err := cbg.WriteMajorTypeHeaderBuf(scratch, w, cbg.MajNegativeInt, uint64(-t.Level-1)); err != nil {
+			return n + n_, err
+		} else {
+			n += n_
+		}
+	}
+
+	// t.Parent (uint64) (uint64)
+	if len("parent") > cbg.MaxLength {
+		return n, xerrors.Errorf("Value in field \"parent\" was too long")
+	}
+
+	if n_, err := cbg.WriteMajorTypeHeaderBuf(scratch, w, cbg.MajTextString, uint64(len("parent"))); err != nil {
+		return n + n_, err
+	} else {
+		n += n_
+	}
+	if n_, err := io.WriteString(w, string("parent")); err != nil {
+		return n + n_, err
+	} else {
+		n += n_
+	}
+
+	if t.Parent == nil {
+		if n_, err := w.Write(cbg.CborNull); err != nil {
+			return n + n_, err
+		} else {
+			n += n_
+		}
+	} else {
+		if n_, err := cbg.WriteMajorTypeHeaderBuf(scratch, w, cbg.MajUnsignedInt, uint64(*t.Parent)); err != nil {
+			return n + n_, err
+		} else {
+			n += n_
+		}
+	}
+
+	return n, nil
+}
+
+func (t *Constrained) UnmarshalCBOR(r io.Reader) (int, error) {
+	bytesRead := 0
+	*t = Constrained{}
+
+	br := cbg.GetPeeker(r)
+	scratch := make([]byte, 8)
+	limits := cbg.GetDecodeLimits(br)
+	if err := limits.Enter(); err != nil {
+		return bytesRead, &cbg.DecodeError{Type: "Constrained", Err: err}
+	}
+	defer limits.Leave()
+
+	maj, extra, read, err := cbg.CborReadHeaderBuf(br, scratch)
+	if err != nil {
+		return bytesRead, err
+	}
+	bytesRead += read
+	if maj != cbg.MajMap {
+		return bytesRead, &cbg.DecodeError{Type: "Constrained", Expected: cbg.MajMap, Found: maj, Err: cbg.ErrUnexpectedType}
+	}
+
+	if extra > cbg.MaxLength {
+		return bytesRead, &cbg.DecodeError{Type: "Constrained", Expected: cbg.MajMap, Found: maj, Err: cbg.ErrMaxLength}
+	}
+	if err := limits.AddItems(extra); err != nil {
+		return bytesRead, &cbg.DecodeError{Type: "Constrained", Expected: cbg.MajMap, Found: maj, Err: err}
+	}
+
+	var name string
+	n := extra
+
+	present := 0
+
+	var seenTags bool
+	for i := uint64(0); i < n; i++ {
+
+		{
+			sval, read, err := cbg.ReadStringBuf(br, scratch)
+			if err != nil {
+				return bytesRead, cbg.WrapDecodeError(err, "Constrained", "", bytesRead)
+			}
+			bytesRead += read
+
+			name = string(sval)
+		}
+
+		switch name {
+		// t.Hash ([]uint8) (slice)
+		case "hash":
+			present++
+
+			maj, extra, read, err = cbg.CborReadHeaderBuf(br, scratch)
+			if err != nil {
+				return bytesRead, cbg.WrapDecodeError(err, "Constrained", "Hash", bytesRead)
+			}
+			bytesRead += read
+
+			if extra > cbg.ByteArrayMaxLen {
+				return bytesRead, &cbg.DecodeError{Type: "Constrained", Field: "Hash", Offset: bytesRead - read, Expected: cbg.MajByteString, Found: maj, Err: cbg.ErrMaxLength}
+			}
+			if maj != cbg.MajByteString {
+				return bytesRead, &cbg.DecodeError{Type: "Constrained", Field: "Hash", Offset: bytesRead - read, Expected: cbg.MajByteString, Found: maj, Err: cbg.ErrUnexpectedType}
+			}
+			if extra != 32 {
+				return bytesRead, &cbg.DecodeError{Type: "Constrained", Field: "Hash", Offset: bytesRead - read, Expected: cbg.MajByteString, Found: maj, Err: &cbg.ConstraintError{Constraint: "len=32", Got: fmt.Sprintf("length %d", extra)}}
+			}
+			if err := limits.AddBytes(extra); err != nil {
+				return bytesRead, &cbg.DecodeError{Type: "Constrained", Field: "Hash", Offset: bytesRead - read, Expected: cbg.MajByteString, Found: maj, Err: err}
+			}
+
+			if extra > 0 {
+				t.Hash = make([]uint8, extra)
+			}
+
+			if read, err := io.ReadFull(br, t.Hash[:]); err != nil {
+				return bytesRead, cbg.WrapDecodeError(err, "Constrained", "Hash", bytesRead)
+			} else {
+				bytesRead += read
+			}
+			// t.Kind (string) (string)
+		case "kind":
+			present++
+
+			{
+				sval, read, err := cbg.ReadStringBuf(br, scratch)
+				if err != nil {
+					return bytesRead, cbg.WrapDecodeError(err, "Constrained", "Kind", bytesRead)
+				}
+				bytesRead += read
+
+				t.Kind = string(sval)
+			}
+			// t.Name (string) (string)
+		case "name":
+			present++
+
+			{
+				sval, read, err := cbg.ReadStringBuf(br, scratch)
+				if err != nil {
+					return bytesRead, cbg.WrapDecodeError(err, "Constrained", "Name", bytesRead)
+				}
+				bytesRead += read
+
+				t.Name = string(sval)
+			}
+			// t.Port (uint16) (uint16)
+		case "port":
+			present++
+
+			maj, extra, read, err = cbg.CborReadHeaderBuf(br, scratch)
+			if err != nil {
+				return bytesRead, cbg.WrapDecodeError(err, "Constrained", "Port", bytesRead)
+			}
+			bytesRead += read
+			if maj != cbg.MajUnsignedInt {
+				return bytesRead, &cbg.DecodeError{Type: "Constrained", Field: "Port", Offset: bytesRead - read, Expected: cbg.MajUnsignedInt, Found: maj, Err: cbg.ErrUnexpectedType}
+			}
+			if extra > math.MaxUint16 {
+				return bytesRead, &cbg.DecodeError{Type: "Constrained", Field: "Port", Offset: bytesRead - read, Expected: cbg.MajUnsignedInt, Found: maj, Err: cbg.ErrOverflow}
+			}
+			t.Port = uint16(extra)
+			// t.Tags ([]uint64) (slice)
+		case "tags":
+			seenTags = true
+			present++
+
+			maj, extra, read, err = cbg.CborReadHeaderBuf(br, scratch)
+			if err != nil {
+				return bytesRead, cbg.WrapDecodeError(err, "Constrained", "Tags", bytesRead)
+			}
+			bytesRead += read
+
+			if extra > cbg.MaxLength {
+				return bytesRead, &cbg.DecodeError{Type: "Constrained", Field: "Tags", Offset: bytesRead - read, Expected: cbg.MajArray, Found: maj, Err: cbg.ErrMaxLength}
+			}
+
+			if maj != cbg.MajArray {
+				return bytesRead, &cbg.DecodeError{Type: "Constrained", Field: "Tags", Offset: bytesRead - read, Expected: cbg.MajArray, Found: maj, Err: cbg.ErrUnexpectedType}
+			}
+			if extra > 3 {
+				return bytesRead, &cbg.DecodeError{Type: "Constrained", Field: "Tags", Offset: bytesRead - read, Expected: cbg.MajArray, Found: maj, Err: &cbg.ConstraintError{Constraint: "max=3", Got: fmt.Sprintf("length %d", extra)}}
+			}
+			if err := limits.AddItems(extra); err != nil {
+				return bytesRead, &cbg.DecodeError{Type: "Constrained", Field: "Tags", Offset: bytesRead - read, Expected: cbg.MajArray, Found: maj, Err: err}
+			}
+
+			if extra > 0 {
+				t.Tags = make([]uint64, extra)
+			}
+
+			for i := 0; i < int(extra); i++ {
+
+				maj, val, read, err := cbg.CborReadHeaderBuf(br, scratch)
+				if err != nil {
+					return bytesRead, cbg.WrapDecodeError(err, "Constrained", fmt.Sprintf("Tags[%d]", i), bytesRead)
+				}
+				bytesRead += read
+
+				if maj != cbg.MajUnsignedInt {
+					return bytesRead, &cbg.DecodeError{Type: "Constrained", Field: fmt.Sprintf("Tags[%d]", i), Offset: bytesRead - read, Expected: cbg.MajUnsignedInt, Found: maj, Err: cbg.ErrUnexpectedType}
+				}
+
+				t.Tags[i] = uint64(val)
+			}
+
+			// t.Level (int8) (int8)
+		case "level":
+			present++
+			{
+				maj, extra, read, err := cbg.CborReadHeaderBuf(br, scratch)
+				var extraI int64
+				if err != nil {
+					return bytesRead, cbg.WrapDecodeError(err, "Constrained", "Level", bytesRead)
+				}
+				bytesRead += read
+				switch maj {
+				case cbg.MajUnsignedInt:
+					extraI = int64(extra)
+					if extraI < 0 {
+						return bytesRead, &cbg.DecodeError{Type: "Constrained", Field: "Level", Offset: bytesRead - read, Expected: maj, Found: maj, Err: cbg.ErrOverflow}
+					}
+				case cbg.MajNegativeInt:
+					extraI = int64(extra)
+					if extraI < 0 {
+						return bytesRead, &cbg.DecodeError{Type: "Constrained", Field: "Level", Offset: bytesRead - read, Expected: maj, Found: maj, Err: cbg.ErrOverflow}
+					}
+					extraI = -1 - extraI
+				default:
+					return bytesRead, &cbg.DecodeError{Type: "Constrained", Field: "Level", Offset: bytesRead - read, Expected: cbg.MajUnsignedInt, Found: maj, Err: cbg.ErrUnexpectedType}
+				}
+
+				if extraI > math.MaxInt8 || extraI < math.MinInt8 {
+					return bytesRead, &cbg.DecodeError{Type: "Constrained", Field: "Level", Offset: bytesRead - read, Expected: maj, Found: maj, Err: cbg.ErrOverflow}
+				}
+
+				t.Level = int8(extraI)
+			}
+			// t.Parent (uint64) (uint64)
+		case "parent":
+			present++
+
+			{
+
+				b, err := br.ReadByte()
+				if err != nil {
+					return bytesRead, cbg.WrapDecodeError(err, "Constrained", "Parent", bytesRead)
+				}
+				bytesRead++
+				if b != cbg.CborNull[0] {
+					if err := br.UnreadByte(); err != nil {
+						return bytesRead, cbg.WrapDecodeError(err, "Constrained", "Parent", bytesRead)
+					}
+					bytesRead--
+					maj, extra, read, err = cbg.CborReadHeaderBuf(br, scratch)
+					if err != nil {
+						return bytesRead, cbg.WrapDecodeError(err, "Constrained", "Parent", bytesRead)
+					}
+					bytesRead += read
+					if maj != cbg.MajUnsignedInt {
+						return bytesRead, &cbg.DecodeError{Type: "Constrained", Field: "Parent", Offset: bytesRead - read, Expected: cbg.MajUnsignedInt, Found: maj, Err: cbg.ErrUnexpectedType}
+					}
+					typed := uint64(extra)
+					t.Parent = &typed
+				}
+
+			}
+
+		default:
+			// Field doesn't exist on this type, so ignore it
+			if read, err := cbg.ScanForLinks(br, func(cid.Cid) {}); err != nil {
+				return bytesRead, cbg.WrapDecodeError(err, "Constrained", "", bytesRead)
+			} else {
+				bytesRead += read
+			}
+		}
+	}
+
+	if present < 7 {
+		if u, ok := interface{}(t).(cbg.Upgrader); ok {
+			if err := u.Upgrade(present); err != nil {
+				return bytesRead, err
+			}
+		}
+	}
+
+	if len(t.Hash) != 32 {
+		return bytesRead, &cbg.DecodeError{Type: "Constrained", Field: "Hash", Offset: bytesRead, Err: &cbg.ConstraintError{Constraint: "len=32", Got: fmt.Sprintf("length %d", len(t.Hash))}}
+	}
+	if t.Kind != "block" && t.Kind != "tx" {
+		return bytesRead, &cbg.DecodeError{Type: "Constrained", Field: "Kind", Offset: bytesRead, Err: &cbg.ConstraintError{Constraint: "enum=block|tx", Got: fmt.Sprintf("%q", t.Kind)}}
+	}
+	if len(t.Name) < 1 {
+		return bytesRead, &cbg.DecodeError{Type: "Constrained", Field: "Name", Offset: bytesRead, Err: &cbg.ConstraintError{Constraint: "min=1", Got: fmt.Sprintf("length %d", len(t.Name))}}
+	}
+	if len(t.Name) > 16 {
+		return bytesRead, &cbg.DecodeError{Type: "Constrained", Field: "Name", Offset: bytesRead, Err: &cbg.ConstraintError{Constraint: "max=16", Got: fmt.Sprintf("length %d", len(t.Name))}}
+	}
+	if t.Port != 80 && t.Port != 443 {
+		return bytesRead, &cbg.DecodeError{Type: "Constrained", Field: "Port", Offset: bytesRead, Err: &cbg.ConstraintError{Constraint: "enum=80|443", Got: fmt.Sprint(t.Port)}}
+	}
+	if seenTags {
+		if len(t.Tags) > 3 {
+			return bytesRead, &cbg.DecodeError{Type: "Constrained", Field: "Tags", Offset: bytesRead, Err: &cbg.ConstraintError{Constraint: "max=3", Got: fmt.Sprintf("length %d", len(t.Tags))}}
+		}
+	}
+	if t.Level < -1 {
+		return bytesRead, &cbg.DecodeError{Type: "Constrained", Field: "Level", Offset: bytesRead, Err: &cbg.ConstraintError{Constraint: "min=-1", Got: fmt.Sprint(t.Level)}}
+	}
+	if t.Level > 5 {
+		return bytesRead, &cbg.DecodeError{Type: "Constrained", Field: "Level", Offset: bytesRead, Err: &cbg.ConstraintError{Constraint: "max=5", Got: fmt.Sprint(t.Level)}}
+	}
+	if t.Parent != nil {
+		if *t.Parent > 100 {
+			return bytesRead, &cbg.DecodeError{Type: "Constrained", Field: "Parent", Offset: bytesRead, Err: &cbg.ConstraintError{Constraint: "max=100", Got: fmt.Sprint(*t.Parent)}}
+		}
+	}
+
+	return bytesRead, nil
+}
+
+func (t *Constrained) MarshalDAGJSON(w io.Writer) error {
+	return cbg.MarshalDAGJSON(w, t)
+}
+
+func (t *Constrained) UnmarshalDAGJSON(r io.Reader) error {
+	return cbg.UnmarshalDAGJSON(r, t)
+}
+
+func (t *Constrained) Links() []cid.Cid {
+	var links []cid.Cid
+	_ = t.ForEachLink(func(_ string, c cid.Cid) error {
+		links = append(links, c)
+		return nil
+	})
+	return links
+}
+
+func (t *Constrained) ForEachLink(cb func(path string, c cid.Cid) error) error {
+	if t == nil {
+		return nil
+	}
+	return nil
+}
+
+func (t *Pair[T0, T1]) MarshalCBOR(w io.Writer) (n int, err error) {
+	if t == nil {
+		return w.Write(cbg.CborNull)
+	}
+	if n_, err := w.Write([]byte{162}); err != nil {
+		return n + n_, err
+	} else {
+		n += n_
+	}
+
+	scratch := make([]byte, 9)
+
+	// t.Key (T0) (type parameter)
+	if len("Key") > cbg.MaxLength {
+		return n, xerrors.Errorf("Value in field \"Key\" was too long")
+	}
+
+	if n_, err := cbg.WriteMajorTypeHeaderBuf(scratch, w, cbg.MajTextString, uint64(len("Key"))); err != nil {
+		return n + n_, err
+	} else {
+		n += n_
+	}
+	if n_, err := io.WriteString(w, string("Key")); err != nil {
+		return n + n_, err
+	} else {
+		n += n_
+	}
+
+	if n_, err := t.Key.MarshalCBOR(w); err != nil {
+		return n + n_, err
+	} else {
+		n += n_
+	}
+
+	// t.Value (T1) (type parameter)
+	if len("Value") > cbg.MaxLength {
+		return n, xerrors.Errorf("Value in field \"Value\" was too long")
+	}
+
+	if n_, err := cbg.WriteMajorTypeHeaderBuf(scratch, w, cbg.MajTextString, uint64(len("Value"))); err != nil {
+		return n + n_, err
+	} else {
+		n += n_
+	}
+	if n_, err := io.WriteString(w, string("Value")); err != nil {
+		return n + n_, err
+	} else {
+		n += n_
+	}
+
+	if n_, err := t.Value.MarshalCBOR(w); err != nil {
+		return n + n_, err
+	} else {
+		n += n_
+	}
+	return n, nil
+}
+
+func (t *Pair[T0, T1]) UnmarshalCBOR(r io.Reader) (int, error) {
+	bytesRead := 0
+	*t = Pair[T0, T1]{}
+
+	br := cbg.GetPeeker(r)
+	scratch := make([]byte, 8)
+	limits := cbg.GetDecodeLimits(br)
+	if err := limits.Enter(); err != nil {
+		return bytesRead, &cbg.DecodeError{Type: "Pair", Err: err}
+	}
+	defer limits.Leave()
+
+	maj, extra, read, err := cbg.CborReadHeaderBuf(br, scratch)
+	if err != nil {
+		return bytesRead, err
+	}
+	bytesRead += read
+	if maj != cbg.MajMap {
+		return bytesRead, &cbg.DecodeError{Type: "Pair", Expected: cbg.MajMap, Found: maj, Err: cbg.ErrUnexpectedType}
+	}
+
+	if extra > cbg.MaxLength {
+		return bytesRead, &cbg.DecodeError{Type: "Pair", Expected: cbg.MajMap, Found: maj, Err: cbg.ErrMaxLength}
+	}
+	if err := limits.AddItems(extra); err != nil {
+		return bytesRead, &cbg.DecodeError{Type: "Pair", Expected: cbg.MajMap, Found: maj, Err: err}
+	}
+
+	var name string
+	n := extra
+
+	for i := uint64(0); i < n; i++ {
+
+		{
+			sval, read, err := cbg.ReadStringBuf(br, scratch)
+			if err != nil {
+				return bytesRead, cbg.WrapDecodeError(err, "Pair", "", bytesRead)
+			}
+			bytesRead += read
+
+			name = string(sval)
+		}
+
+		switch name {
+		// t.Key (T0) (type parameter)
+		case "Key":
+
+			{
+				if read, err := cbg.UnmarshalTypeParam(br, &t.Key); err != nil {
+					return bytesRead, cbg.WrapDecodeError(err, "Pair", "Key", bytesRead)
+				} else {
+					bytesRead += read
+				}
+			}
+			// t.Value (T1) (type parameter)
+		case "Value":
+
+			{
+				if read, err := cbg.UnmarshalTypeParam(br, &t.Value); err != nil {
+					return bytesRead, cbg.WrapDecodeError(err, "Pair", "Value", bytesRead)
+				} else {
+					bytesRead += read
+				}
+			}
+
+		default:
+			// Field doesn't exist on this type, so ignore it
+			if read, err := cbg.ScanForLinks(br, func(cid.Cid) {}); err != nil {
+				return bytesRead, cbg.WrapDecodeError(err, "Pair", "", bytesRead)
+			} else {
+				bytesRead += read
+			}
+		}
+	}
+
+	return bytesRead, nil
+}
+
+func (t *Pair[T0, T1]) Links() []cid.Cid {
+	var links []cid.Cid
+	_ = t.ForEachLink(func(_ string, c cid.Cid) error {
+		links = append(links, c)
+		return nil
+	})
+	return links
+}
+
+func (t *Pair[T0, T1]) ForEachLink(cb func(path string, c cid.Cid) error) error {
+	if t == nil {
+		return nil
+	}
+	if err := cbg.ForEachLinkIn(t.Key, "Key", cb); err != nil {
+		return err
+	}
+	if err := cbg.ForEachLinkIn(t.Value, "Value", cb); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (t *IntKeyed) MarshalCBOR(w io.Writer) (n int, err error) {
+	if t == nil {
+		return w.Write(cbg.CborNull)
+	}
+	if n_, err := w.Write([]byte{164}); err != nil {
+		return n + n_, err
+	} else {
+		n += n_
+	}
+
+	scratch := make([]byte, 9)
+
+	// t.Alg (int64) (int64)
+	if n_, err := w.Write([]byte{1}); err != nil {
+		return n + n_, err
+	} else {
+		n += n_
+	}
+
+	if t.Alg >= 0 {
+		if n_, err := cbg.WriteMajorTypeHeaderBuf(scratch, w, cbg.MajUnsignedInt, uint64(t.Alg)); err != nil {
+			return n + n_, err
+		} else {
+			n += n_
+		}
+	} else {
+		if n_, err := cbg.WriteMajorTypeHeaderBuf(scratch, w, cbg.MajNegativeInt, uint64(-t.Alg-1)); err != nil {
+			return n + n_, err
+		} else {
+			n += n_
+		}
+	}
+
+	// t.Kid ([]uint8) (slice)
+	if n_, err := w.Write([]byte{4}); err != nil {
+		return n + n_, err
+	} else {
+		n += n_
+	}
+
+	if len(t.Kid) > cbg.ByteArrayMaxLen {
+		return n, xerrors.Errorf("Byte array in field t.Kid was too long")
+	}
+
+	if n_, err := cbg.WriteMajorTypeHeaderBuf(scratch, w, cbg.MajByteString, uint64(len(t.Kid))); err != nil {
+		return n + n_, err
+	} else {
+		n += n_
+	}
+
+	if n_, err := w.Write(t.Kid[:]); err != nil {
+		return n + n_, err
+	} else {
+		n += n_
+	}
+
+	// t.Payload (string) (string)
+	if n_, err := w.Write([]byte{33}); err != nil {
+		return n + n_, err
+	} else {
+		n += n_
+	}
+
+	if len(t.Payload) > cbg.MaxLength {
+		return n, xerrors.Errorf("Value in field t.Payload was too long")
+	}
+
+	if n_, err := cbg.WriteMajorTypeHeaderBuf(scratch, w, cbg.MajTextString, uint64(len(t.Payload))); err != nil {
+		return n + n_, err
+	} else {
+		n += n_
+	}
+	if n_, err := io.WriteString(w, string(t.Payload)); err != nil {
+		return n + n_, err
+	} else {
+		n += n_
+	}
+
+	// t.Note (string) (string)
+	if len("note") > cbg.MaxLength {
+		return n, xerrors.Errorf("Value in field \"note\" was too long")
+	}
+
+	if n_, err := cbg.WriteMajorTypeHeaderBuf(scratch, w, cbg.MajTextString, uint64(len("note"))); err != nil {
+		return n + n_, err
+	} else {
+		n += n_
+	}
+	if n_, err := io.WriteString(w, string("note")); err != nil {
+		return n + n_, err
+	} else {
+		n += n_
+	}
+
+	if len(t.Note) > cbg.MaxLength {
+		return n, xerrors.Errorf("Value in field t.Note was too long")
+	}
+
+	if n_, err := cbg.WriteMajorTypeHeaderBuf(scratch, w, cbg.MajTextString, uint64(len(t.Note))); err != nil {
+		return n + n_, err
+	} else {
+		n += n_
+	}
+	if n_, err := io.WriteString(w, string(t.Note)); err != nil {
+		return n + n_, err
+	} else {
+		n += n_
+	}
+	return n, nil
+}
+
+func (t *IntKeyed) UnmarshalCBOR(r io.Reader) (int, error) {
+	bytesRead := 0
+	*t = IntKeyed{}
+
+	br := cbg.GetPeeker(r)
+	scratch := make([]byte, 8)
+	limits := cbg.GetDecodeLimits(br)
+	if err := limits.Enter(); err != nil {
+		return bytesRead, &cbg.DecodeError{Type: "IntKeyed", Err: err}
+	}
+	defer limits.Leave()
+
+	maj, extra, read, err := cbg.CborReadHeaderBuf(br, scratch)
+	if err != nil {
+		return bytesRead, err
+	}
+	bytesRead += read
+	if maj != cbg.MajMap {
+		return bytesRead, &cbg.DecodeError{Type: "IntKeyed", Expected: cbg.MajMap, Found: maj, Err: cbg.ErrUnexpectedType}
+	}
+
+	if extra > cbg.MaxLength {
+		return bytesRead, &cbg.DecodeError{Type: "IntKeyed", Expected: cbg.MajMap, Found: maj, Err: cbg.ErrMaxLength}
+	}
+	if err := limits.AddItems(extra); err != nil {
+		return bytesRead, &cbg.DecodeError{Type: "IntKeyed", Expected: cbg.MajMap, Found: maj, Err: err}
+	}
+
+	n := extra
+
+	present := 0
+
+	for i := uint64(0); i < n; i++ {
+
+		key, read, err := cbg.ReadMapKeyBuf(br, scratch)
+		if err != nil {
+			return bytesRead, cbg.WrapDecodeError(err, "IntKeyed", "", bytesRead)
+		}
+		bytesRead += read
+
+		switch key {
+		// t.Alg (int64) (int64)
+		case cbg.IntKey(1):
+			present++
+			{
+				maj, extra, read, err := cbg.CborReadHeaderBuf(br, scratch)
+				var extraI int64
+				if err != nil {
+					return bytesRead, cbg.WrapDecodeError(err, "IntKeyed", "Alg", bytesRead)
+				}
+				bytesRead += read
+				switch maj {
+				case cbg.MajUnsignedInt:
+					extraI = int64(extra)
+					if extraI < 0 {
+						return bytesRead, &cbg.DecodeError{Type: "IntKeyed", Field: "Alg", Offset: bytesRead - read, Expected: maj, Found: maj, Err: cbg.ErrOverflow}
+					}
+				case cbg.MajNegativeInt:
+					extraI = int64(extra)
+					if extraI < 0 {
+						return bytesRead, &cbg.DecodeError{Type: "IntKeyed", Field: "Alg", Offset: bytesRead - read, Expected: maj, Found: maj, Err: cbg.ErrOverflow}
+					}
+					extraI = -1 - extraI
+				default:
+					return bytesRead, &cbg.DecodeError{Type: "IntKeyed", Field: "Alg", Offset: bytesRead - read, Expected: cbg.MajUnsignedInt, Found: maj, Err: cbg.ErrUnexpectedType}
+				}
+
+				t.Alg = int64(extraI)
+			}
+			// t.Kid ([]uint8) (slice)
+		case cbg.IntKey(4):
+			present++
+
+			maj, extra, read, err = cbg.CborReadHeaderBuf(br, scratch)
+			if err != nil {
+				return bytesRead, cbg.WrapDecodeError(err, "IntKeyed", "Kid", bytesRead)
+			}
+			bytesRead += read
+
+			if extra > cbg.ByteArrayMaxLen {
+				return bytesRead, &cbg.DecodeError{Type: "IntKeyed", Field: "Kid", Offset: bytesRead - read, Expected: cbg.MajByteString, Found: maj, Err: cbg.ErrMaxLength}
+			}
+			if maj != cbg.MajByteString {
+				return bytesRead, &cbg.DecodeError{Type: "IntKeyed", Field: "Kid", Offset: bytesRead - read, Expected: cbg.MajByteString, Found: maj, Err: cbg.ErrUnexpectedType}
+			}
+			if err := limits.AddBytes(extra); err != nil {
+				return bytesRead, &cbg.DecodeError{Type: "IntKeyed", Field: "Kid", Offset: bytesRead - read, Expected: cbg.MajByteString, Found: maj, Err: err}
+			}
+
+			if extra > 0 {
+				t.Kid = make([]uint8, extra)
+			}
+
+			if read, err := io.ReadFull(br, t.Kid[:]); err != nil {
+				return bytesRead, cbg.WrapDecodeError(err, "IntKeyed", "Kid", bytesRead)
+			} else {
+				bytesRead += read
+			}
+			// t.Payload (string) (string)
+		case cbg.IntKey(-2):
+			present++
+
+			{
+				sval, read, err := cbg.ReadStringBuf(br, scratch)
+				if err != nil {
+					return bytesRead, cbg.WrapDecodeError(err, "IntKeyed", "Payload", bytesRead)
+				}
+				bytesRead += read
+
+				t.Payload = string(sval)
+			}
+			// t.Note (string) (string)
+		case cbg.TextKey("note"):
+			present++
+
+			{
+				sval, read, err := cbg.ReadStringBuf(br, scratch)
+				if err != nil {
+					return bytesRead, cbg.WrapDecodeError(err, "IntKeyed", "Note", bytesRead)
+				}
+				bytesRead += read
+
+				t.Note = string(sval)
+			}
+
+		default:
+			// Field doesn't exist on this type, so ignore it
+			if read, err := cbg.ScanForLinks(br, func(cid.Cid) {}); err != nil {
+				return bytesRead, cbg.WrapDecodeError(err, "IntKeyed", "", bytesRead)
+			} else {
+				bytesRead += read
+			}
+		}
+	}
+
+	if present < 4 {
+		if u, ok := interface{}(t).(cbg.Upgrader); ok {
+			if err := u.Upgrade(present); err != nil {
+				return bytesRead, err
+			}
+		}
+	}
+
+	return bytesRead, nil
+}
+
+func (t *IntKeyed) Links() []cid.Cid {
+	var links []cid.Cid
+	_ = t.ForEachLink(func(_ string, c cid.Cid) error {
+		links = append(links, c)
+		return nil
+	})
+	return links
+}
+
+func (t *IntKeyed) ForEachLink(cb func(path string, c cid.Cid) error) error {
+	if t == nil {
+		return nil
+	}
+	return nil
+}
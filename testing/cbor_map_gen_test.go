@@ -0,0 +1,822 @@
+// Code generated by github.com/daotl/cbor-gen. DO NOT EDIT.
+
+package testing
+
+import (
+	"bytes"
+	"math/rand"
+	"reflect"
+	"testing"
+	"testing/quick"
+)
+
+var _ = rand.New
+var _ = reflect.TypeOf
+var _ = quick.Value
+
+func FuzzUnmarshalSimpleTypeTree(f *testing.F) {
+	r := rand.New(rand.NewSource(56887))
+	for i := 0; i < 10; i++ {
+		val, ok := quick.Value(reflect.TypeOf(SimpleTypeTree{}), r)
+		if !ok {
+			f.Fatal("failed to generate test value")
+		}
+		buf := new(bytes.Buffer)
+		if _, err := val.Addr().Interface().(*SimpleTypeTree).MarshalCBOR(buf); err != nil {
+			f.Fatal(err)
+		}
+		f.Add(buf.Bytes())
+	}
+
+	f.Fuzz(func(t *testing.T, data []byte) {
+		var obj SimpleTypeTree
+		read, err := obj.UnmarshalCBOR(bytes.NewReader(data))
+		if err != nil {
+			return
+		}
+		if read > len(data) {
+			t.Fatalf("read %d bytes out of %d", read, len(data))
+		}
+
+		// Whatever unmarshals must marshal, and roundtrip from there on.
+		buf := new(bytes.Buffer)
+		if _, err := obj.MarshalCBOR(buf); err != nil {
+			t.Fatal("failed to marshal unmarshaled object: ", err)
+		}
+		enc := buf.Bytes()
+
+		var nobj SimpleTypeTree
+		if _, err := nobj.UnmarshalCBOR(bytes.NewReader(enc)); err != nil {
+			t.Fatalf("failed to unmarshal %x: %s", enc, err)
+		}
+		nbuf := new(bytes.Buffer)
+		if _, err := nobj.MarshalCBOR(nbuf); err != nil {
+			t.Fatal("failed to remarshal object: ", err)
+		}
+		if !bytes.Equal(nbuf.Bytes(), enc) {
+			t.Fatalf("objects encodings different: %x != %x", nbuf.Bytes(), enc)
+		}
+	})
+}
+
+func TestRoundtripSimpleTypeTree(t *testing.T) {
+	r := rand.New(rand.NewSource(56887))
+	for i := 0; i < 100; i++ {
+		val, ok := quick.Value(reflect.TypeOf(SimpleTypeTree{}), r)
+		if !ok {
+			t.Fatal("failed to generate test value")
+		}
+		obj := val.Addr().Interface().(*SimpleTypeTree)
+
+		buf := new(bytes.Buffer)
+		if n, err := obj.MarshalCBOR(buf); err != nil {
+			t.Fatal(err)
+		} else if n != buf.Len() {
+			t.Fatal("returned length does not match the byte length")
+		}
+		enc := buf.Bytes()
+
+		var nobj SimpleTypeTree
+		if read, err := nobj.UnmarshalCBOR(bytes.NewReader(enc)); err != nil {
+			t.Fatalf("failed to unmarshal %x: %s", enc, err)
+		} else if read != len(enc) {
+			t.Fatalf("wrong bytesRead when unmarshaling: should be %d, actual %d", len(enc), read)
+		}
+
+		nbuf := new(bytes.Buffer)
+		if _, err := nobj.MarshalCBOR(nbuf); err != nil {
+			t.Fatal("failed to remarshal object: ", err)
+		}
+		if !bytes.Equal(nbuf.Bytes(), enc) {
+			t.Fatalf("objects encodings different: %x != %x", nbuf.Bytes(), enc)
+		}
+
+		// Truncated encodings must fail to unmarshal.
+		for _, l := range []int{r.Intn(len(enc)), len(enc) - 1} {
+			var tobj SimpleTypeTree
+			if _, err := tobj.UnmarshalCBOR(bytes.NewReader(enc[:l])); err == nil {
+				t.Fatalf("unmarshaled truncated encoding %x", enc[:l])
+			}
+		}
+	}
+}
+
+func FuzzUnmarshalNeedScratchForMap(f *testing.F) {
+	r := rand.New(rand.NewSource(56887))
+	for i := 0; i < 10; i++ {
+		val, ok := quick.Value(reflect.TypeOf(NeedScratchForMap{}), r)
+		if !ok {
+			f.Fatal("failed to generate test value")
+		}
+		buf := new(bytes.Buffer)
+		if _, err := val.Addr().Interface().(*NeedScratchForMap).MarshalCBOR(buf); err != nil {
+			f.Fatal(err)
+		}
+		f.Add(buf.Bytes())
+	}
+
+	f.Fuzz(func(t *testing.T, data []byte) {
+		var obj NeedScratchForMap
+		read, err := obj.UnmarshalCBOR(bytes.NewReader(data))
+		if err != nil {
+			return
+		}
+		if read > len(data) {
+			t.Fatalf("read %d bytes out of %d", read, len(data))
+		}
+
+		// Whatever unmarshals must marshal, and roundtrip from there on.
+		buf := new(bytes.Buffer)
+		if _, err := obj.MarshalCBOR(buf); err != nil {
+			t.Fatal("failed to marshal unmarshaled object: ", err)
+		}
+		enc := buf.Bytes()
+
+		var nobj NeedScratchForMap
+		if _, err := nobj.UnmarshalCBOR(bytes.NewReader(enc)); err != nil {
+			t.Fatalf("failed to unmarshal %x: %s", enc, err)
+		}
+		nbuf := new(bytes.Buffer)
+		if _, err := nobj.MarshalCBOR(nbuf); err != nil {
+			t.Fatal("failed to remarshal object: ", err)
+		}
+		if !bytes.Equal(nbuf.Bytes(), enc) {
+			t.Fatalf("objects encodings different: %x != %x", nbuf.Bytes(), enc)
+		}
+	})
+}
+
+func TestRoundtripNeedScratchForMap(t *testing.T) {
+	r := rand.New(rand.NewSource(56887))
+	for i := 0; i < 100; i++ {
+		val, ok := quick.Value(reflect.TypeOf(NeedScratchForMap{}), r)
+		if !ok {
+			t.Fatal("failed to generate test value")
+		}
+		obj := val.Addr().Interface().(*NeedScratchForMap)
+
+		buf := new(bytes.Buffer)
+		if n, err := obj.MarshalCBOR(buf); err != nil {
+			t.Fatal(err)
+		} else if n != buf.Len() {
+			t.Fatal("returned length does not match the byte length")
+		}
+		enc := buf.Bytes()
+
+		var nobj NeedScratchForMap
+		if read, err := nobj.UnmarshalCBOR(bytes.NewReader(enc)); err != nil {
+			t.Fatalf("failed to unmarshal %x: %s", enc, err)
+		} else if read != len(enc) {
+			t.Fatalf("wrong bytesRead when unmarshaling: should be %d, actual %d", len(enc), read)
+		}
+
+		nbuf := new(bytes.Buffer)
+		if _, err := nobj.MarshalCBOR(nbuf); err != nil {
+			t.Fatal("failed to remarshal object: ", err)
+		}
+		if !bytes.Equal(nbuf.Bytes(), enc) {
+			t.Fatalf("objects encodings different: %x != %x", nbuf.Bytes(), enc)
+		}
+
+		// Truncated encodings must fail to unmarshal.
+		for _, l := range []int{r.Intn(len(enc)), len(enc) - 1} {
+			var tobj NeedScratchForMap
+			if _, err := tobj.UnmarshalCBOR(bytes.NewReader(enc[:l])); err == nil {
+				t.Fatalf("unmarshaled truncated encoding %x", enc[:l])
+			}
+		}
+	}
+}
+
+func FuzzUnmarshalSimpleStructV1(f *testing.F) {
+	buf := new(bytes.Buffer)
+	if _, err := new(SimpleStructV1).MarshalCBOR(buf); err == nil {
+		f.Add(buf.Bytes())
+	}
+
+	f.Fuzz(func(t *testing.T, data []byte) {
+		var obj SimpleStructV1
+		read, err := obj.UnmarshalCBOR(bytes.NewReader(data))
+		if err != nil {
+			return
+		}
+		if read > len(data) {
+			t.Fatalf("read %d bytes out of %d", read, len(data))
+		}
+
+		// Whatever unmarshals must marshal, and roundtrip from there on.
+		buf := new(bytes.Buffer)
+		if _, err := obj.MarshalCBOR(buf); err != nil {
+			t.Fatal("failed to marshal unmarshaled object: ", err)
+		}
+		enc := buf.Bytes()
+
+		var nobj SimpleStructV1
+		if _, err := nobj.UnmarshalCBOR(bytes.NewReader(enc)); err != nil {
+			t.Fatalf("failed to unmarshal %x: %s", enc, err)
+		}
+		nbuf := new(bytes.Buffer)
+		if _, err := nobj.MarshalCBOR(nbuf); err != nil {
+			t.Fatal("failed to remarshal object: ", err)
+		}
+		if !bytes.Equal(nbuf.Bytes(), enc) {
+			t.Fatalf("objects encodings different: %x != %x", nbuf.Bytes(), enc)
+		}
+	})
+}
+
+func FuzzUnmarshalSimpleStructV2(f *testing.F) {
+	buf := new(bytes.Buffer)
+	if _, err := new(SimpleStructV2).MarshalCBOR(buf); err == nil {
+		f.Add(buf.Bytes())
+	}
+
+	f.Fuzz(func(t *testing.T, data []byte) {
+		var obj SimpleStructV2
+		read, err := obj.UnmarshalCBOR(bytes.NewReader(data))
+		if err != nil {
+			return
+		}
+		if read > len(data) {
+			t.Fatalf("read %d bytes out of %d", read, len(data))
+		}
+
+		// Whatever unmarshals must marshal, and roundtrip from there on.
+		buf := new(bytes.Buffer)
+		if _, err := obj.MarshalCBOR(buf); err != nil {
+			t.Fatal("failed to marshal unmarshaled object: ", err)
+		}
+		enc := buf.Bytes()
+
+		var nobj SimpleStructV2
+		if _, err := nobj.UnmarshalCBOR(bytes.NewReader(enc)); err != nil {
+			t.Fatalf("failed to unmarshal %x: %s", enc, err)
+		}
+		nbuf := new(bytes.Buffer)
+		if _, err := nobj.MarshalCBOR(nbuf); err != nil {
+			t.Fatal("failed to remarshal object: ", err)
+		}
+		if !bytes.Equal(nbuf.Bytes(), enc) {
+			t.Fatalf("objects encodings different: %x != %x", nbuf.Bytes(), enc)
+		}
+	})
+}
+
+func FuzzUnmarshalRenamedFields(f *testing.F) {
+	r := rand.New(rand.NewSource(56887))
+	for i := 0; i < 10; i++ {
+		val, ok := quick.Value(reflect.TypeOf(RenamedFields{}), r)
+		if !ok {
+			f.Fatal("failed to generate test value")
+		}
+		buf := new(bytes.Buffer)
+		if _, err := val.Addr().Interface().(*RenamedFields).MarshalCBOR(buf); err != nil {
+			f.Fatal(err)
+		}
+		f.Add(buf.Bytes())
+	}
+
+	f.Fuzz(func(t *testing.T, data []byte) {
+		var obj RenamedFields
+		read, err := obj.UnmarshalCBOR(bytes.NewReader(data))
+		if err != nil {
+			return
+		}
+		if read > len(data) {
+			t.Fatalf("read %d bytes out of %d", read, len(data))
+		}
+
+		// Whatever unmarshals must marshal, and roundtrip from there on.
+		buf := new(bytes.Buffer)
+		if _, err := obj.MarshalCBOR(buf); err != nil {
+			t.Fatal("failed to marshal unmarshaled object: ", err)
+		}
+		enc := buf.Bytes()
+
+		var nobj RenamedFields
+		if _, err := nobj.UnmarshalCBOR(bytes.NewReader(enc)); err != nil {
+			t.Fatalf("failed to unmarshal %x: %s", enc, err)
+		}
+		nbuf := new(bytes.Buffer)
+		if _, err := nobj.MarshalCBOR(nbuf); err != nil {
+			t.Fatal("failed to remarshal object: ", err)
+		}
+		if !bytes.Equal(nbuf.Bytes(), enc) {
+			t.Fatalf("objects encodings different: %x != %x", nbuf.Bytes(), enc)
+		}
+	})
+}
+
+func TestRoundtripRenamedFields(t *testing.T) {
+	r := rand.New(rand.NewSource(56887))
+	for i := 0; i < 100; i++ {
+		val, ok := quick.Value(reflect.TypeOf(RenamedFields{}), r)
+		if !ok {
+			t.Fatal("failed to generate test value")
+		}
+		obj := val.Addr().Interface().(*RenamedFields)
+
+		buf := new(bytes.Buffer)
+		if n, err := obj.MarshalCBOR(buf); err != nil {
+			t.Fatal(err)
+		} else if n != buf.Len() {
+			t.Fatal("returned length does not match the byte length")
+		}
+		enc := buf.Bytes()
+
+		var nobj RenamedFields
+		if read, err := nobj.UnmarshalCBOR(bytes.NewReader(enc)); err != nil {
+			t.Fatalf("failed to unmarshal %x: %s", enc, err)
+		} else if read != len(enc) {
+			t.Fatalf("wrong bytesRead when unmarshaling: should be %d, actual %d", len(enc), read)
+		}
+
+		nbuf := new(bytes.Buffer)
+		if _, err := nobj.MarshalCBOR(nbuf); err != nil {
+			t.Fatal("failed to remarshal object: ", err)
+		}
+		if !bytes.Equal(nbuf.Bytes(), enc) {
+			t.Fatalf("objects encodings different: %x != %x", nbuf.Bytes(), enc)
+		}
+
+		// Truncated encodings must fail to unmarshal.
+		for _, l := range []int{r.Intn(len(enc)), len(enc) - 1} {
+			var tobj RenamedFields
+			if _, err := tobj.UnmarshalCBOR(bytes.NewReader(enc[:l])); err == nil {
+				t.Fatalf("unmarshaled truncated encoding %x", enc[:l])
+			}
+		}
+	}
+}
+
+func FuzzUnmarshalAliasedFields(f *testing.F) {
+	r := rand.New(rand.NewSource(56887))
+	for i := 0; i < 10; i++ {
+		val, ok := quick.Value(reflect.TypeOf(AliasedFields{}), r)
+		if !ok {
+			f.Fatal("failed to generate test value")
+		}
+		buf := new(bytes.Buffer)
+		if _, err := val.Addr().Interface().(*AliasedFields).MarshalCBOR(buf); err != nil {
+			f.Fatal(err)
+		}
+		f.Add(buf.Bytes())
+	}
+
+	f.Fuzz(func(t *testing.T, data []byte) {
+		var obj AliasedFields
+		read, err := obj.UnmarshalCBOR(bytes.NewReader(data))
+		if err != nil {
+			return
+		}
+		if read > len(data) {
+			t.Fatalf("read %d bytes out of %d", read, len(data))
+		}
+
+		// Whatever unmarshals must marshal, and roundtrip from there on.
+		buf := new(bytes.Buffer)
+		if _, err := obj.MarshalCBOR(buf); err != nil {
+			t.Fatal("failed to marshal unmarshaled object: ", err)
+		}
+		enc := buf.Bytes()
+
+		var nobj AliasedFields
+		if _, err := nobj.UnmarshalCBOR(bytes.NewReader(enc)); err != nil {
+			t.Fatalf("failed to unmarshal %x: %s", enc, err)
+		}
+		nbuf := new(bytes.Buffer)
+		if _, err := nobj.MarshalCBOR(nbuf); err != nil {
+			t.Fatal("failed to remarshal object: ", err)
+		}
+		if !bytes.Equal(nbuf.Bytes(), enc) {
+			t.Fatalf("objects encodings different: %x != %x", nbuf.Bytes(), enc)
+		}
+	})
+}
+
+func TestRoundtripAliasedFields(t *testing.T) {
+	r := rand.New(rand.NewSource(56887))
+	for i := 0; i < 100; i++ {
+		val, ok := quick.Value(reflect.TypeOf(AliasedFields{}), r)
+		if !ok {
+			t.Fatal("failed to generate test value")
+		}
+		obj := val.Addr().Interface().(*AliasedFields)
+
+		buf := new(bytes.Buffer)
+		if n, err := obj.MarshalCBOR(buf); err != nil {
+			t.Fatal(err)
+		} else if n != buf.Len() {
+			t.Fatal("returned length does not match the byte length")
+		}
+		enc := buf.Bytes()
+
+		var nobj AliasedFields
+		if read, err := nobj.UnmarshalCBOR(bytes.NewReader(enc)); err != nil {
+			t.Fatalf("failed to unmarshal %x: %s", enc, err)
+		} else if read != len(enc) {
+			t.Fatalf("wrong bytesRead when unmarshaling: should be %d, actual %d", len(enc), read)
+		}
+
+		nbuf := new(bytes.Buffer)
+		if _, err := nobj.MarshalCBOR(nbuf); err != nil {
+			t.Fatal("failed to remarshal object: ", err)
+		}
+		if !bytes.Equal(nbuf.Bytes(), enc) {
+			t.Fatalf("objects encodings different: %x != %x", nbuf.Bytes(), enc)
+		}
+
+		// Truncated encodings must fail to unmarshal.
+		for _, l := range []int{r.Intn(len(enc)), len(enc) - 1} {
+			var tobj AliasedFields
+			if _, err := tobj.UnmarshalCBOR(bytes.NewReader(enc[:l])); err == nil {
+				t.Fatalf("unmarshaled truncated encoding %x", enc[:l])
+			}
+		}
+	}
+}
+
+func FuzzUnmarshalUpgradedMap(f *testing.F) {
+	r := rand.New(rand.NewSource(56887))
+	for i := 0; i < 10; i++ {
+		val, ok := quick.Value(reflect.TypeOf(UpgradedMap{}), r)
+		if !ok {
+			f.Fatal("failed to generate test value")
+		}
+		buf := new(bytes.Buffer)
+		if _, err := val.Addr().Interface().(*UpgradedMap).MarshalCBOR(buf); err != nil {
+			f.Fatal(err)
+		}
+		f.Add(buf.Bytes())
+	}
+
+	f.Fuzz(func(t *testing.T, data []byte) {
+		var obj UpgradedMap
+		read, err := obj.UnmarshalCBOR(bytes.NewReader(data))
+		if err != nil {
+			return
+		}
+		if read > len(data) {
+			t.Fatalf("read %d bytes out of %d", read, len(data))
+		}
+
+		// Whatever unmarshals must marshal, and roundtrip from there on.
+		buf := new(bytes.Buffer)
+		if _, err := obj.MarshalCBOR(buf); err != nil {
+			t.Fatal("failed to marshal unmarshaled object: ", err)
+		}
+		enc := buf.Bytes()
+
+		var nobj UpgradedMap
+		if _, err := nobj.UnmarshalCBOR(bytes.NewReader(enc)); err != nil {
+			t.Fatalf("failed to unmarshal %x: %s", enc, err)
+		}
+		nbuf := new(bytes.Buffer)
+		if _, err := nobj.MarshalCBOR(nbuf); err != nil {
+			t.Fatal("failed to remarshal object: ", err)
+		}
+		if !bytes.Equal(nbuf.Bytes(), enc) {
+			t.Fatalf("objects encodings different: %x != %x", nbuf.Bytes(), enc)
+		}
+	})
+}
+
+func TestRoundtripUpgradedMap(t *testing.T) {
+	r := rand.New(rand.NewSource(56887))
+	for i := 0; i < 100; i++ {
+		val, ok := quick.Value(reflect.TypeOf(UpgradedMap{}), r)
+		if !ok {
+			t.Fatal("failed to generate test value")
+		}
+		obj := val.Addr().Interface().(*UpgradedMap)
+
+		buf := new(bytes.Buffer)
+		if n, err := obj.MarshalCBOR(buf); err != nil {
+			t.Fatal(err)
+		} else if n != buf.Len() {
+			t.Fatal("returned length does not match the byte length")
+		}
+		enc := buf.Bytes()
+
+		var nobj UpgradedMap
+		if read, err := nobj.UnmarshalCBOR(bytes.NewReader(enc)); err != nil {
+			t.Fatalf("failed to unmarshal %x: %s", enc, err)
+		} else if read != len(enc) {
+			t.Fatalf("wrong bytesRead when unmarshaling: should be %d, actual %d", len(enc), read)
+		}
+
+		nbuf := new(bytes.Buffer)
+		if _, err := nobj.MarshalCBOR(nbuf); err != nil {
+			t.Fatal("failed to remarshal object: ", err)
+		}
+		if !bytes.Equal(nbuf.Bytes(), enc) {
+			t.Fatalf("objects encodings different: %x != %x", nbuf.Bytes(), enc)
+		}
+
+		// Truncated encodings must fail to unmarshal.
+		for _, l := range []int{r.Intn(len(enc)), len(enc) - 1} {
+			var tobj UpgradedMap
+			if _, err := tobj.UnmarshalCBOR(bytes.NewReader(enc[:l])); err == nil {
+				t.Fatalf("unmarshaled truncated encoding %x", enc[:l])
+			}
+		}
+	}
+}
+
+func FuzzUnmarshalDefaults(f *testing.F) {
+	r := rand.New(rand.NewSource(56887))
+	for i := 0; i < 10; i++ {
+		val, ok := quick.Value(reflect.TypeOf(Defaults{}), r)
+		if !ok {
+			f.Fatal("failed to generate test value")
+		}
+		buf := new(bytes.Buffer)
+		if _, err := val.Addr().Interface().(*Defaults).MarshalCBOR(buf); err != nil {
+			f.Fatal(err)
+		}
+		f.Add(buf.Bytes())
+	}
+
+	f.Fuzz(func(t *testing.T, data []byte) {
+		var obj Defaults
+		read, err := obj.UnmarshalCBOR(bytes.NewReader(data))
+		if err != nil {
+			return
+		}
+		if read > len(data) {
+			t.Fatalf("read %d bytes out of %d", read, len(data))
+		}
+
+		// Whatever unmarshals must marshal, and roundtrip from there on.
+		buf := new(bytes.Buffer)
+		if _, err := obj.MarshalCBOR(buf); err != nil {
+			t.Fatal("failed to marshal unmarshaled object: ", err)
+		}
+		enc := buf.Bytes()
+
+		var nobj Defaults
+		if _, err := nobj.UnmarshalCBOR(bytes.NewReader(enc)); err != nil {
+			t.Fatalf("failed to unmarshal %x: %s", enc, err)
+		}
+		nbuf := new(bytes.Buffer)
+		if _, err := nobj.MarshalCBOR(nbuf); err != nil {
+			t.Fatal("failed to remarshal object: ", err)
+		}
+		if !bytes.Equal(nbuf.Bytes(), enc) {
+			t.Fatalf("objects encodings different: %x != %x", nbuf.Bytes(), enc)
+		}
+	})
+}
+
+func TestRoundtripDefaults(t *testing.T) {
+	r := rand.New(rand.NewSource(56887))
+	for i := 0; i < 100; i++ {
+		val, ok := quick.Value(reflect.TypeOf(Defaults{}), r)
+		if !ok {
+			t.Fatal("failed to generate test value")
+		}
+		obj := val.Addr().Interface().(*Defaults)
+
+		buf := new(bytes.Buffer)
+		if n, err := obj.MarshalCBOR(buf); err != nil {
+			t.Fatal(err)
+		} else if n != buf.Len() {
+			t.Fatal("returned length does not match the byte length")
+		}
+		enc := buf.Bytes()
+
+		var nobj Defaults
+		if read, err := nobj.UnmarshalCBOR(bytes.NewReader(enc)); err != nil {
+			t.Fatalf("failed to unmarshal %x: %s", enc, err)
+		} else if read != len(enc) {
+			t.Fatalf("wrong bytesRead when unmarshaling: should be %d, actual %d", len(enc), read)
+		}
+
+		nbuf := new(bytes.Buffer)
+		if _, err := nobj.MarshalCBOR(nbuf); err != nil {
+			t.Fatal("failed to remarshal object: ", err)
+		}
+		if !bytes.Equal(nbuf.Bytes(), enc) {
+			t.Fatalf("objects encodings different: %x != %x", nbuf.Bytes(), enc)
+		}
+
+		// Truncated encodings must fail to unmarshal.
+		for _, l := range []int{r.Intn(len(enc)), len(enc) - 1} {
+			var tobj Defaults
+			if _, err := tobj.UnmarshalCBOR(bytes.NewReader(enc[:l])); err == nil {
+				t.Fatalf("unmarshaled truncated encoding %x", enc[:l])
+			}
+		}
+	}
+}
+
+func FuzzUnmarshalValidatedMap(f *testing.F) {
+	r := rand.New(rand.NewSource(56887))
+	for i := 0; i < 10; i++ {
+		val, ok := quick.Value(reflect.TypeOf(ValidatedMap{}), r)
+		if !ok {
+			f.Fatal("failed to generate test value")
+		}
+		buf := new(bytes.Buffer)
+		if _, err := val.Addr().Interface().(*ValidatedMap).MarshalCBOR(buf); err != nil {
+			continue
+		}
+		f.Add(buf.Bytes())
+	}
+
+	f.Fuzz(func(t *testing.T, data []byte) {
+		var obj ValidatedMap
+		read, err := obj.UnmarshalCBOR(bytes.NewReader(data))
+		if err != nil {
+			return
+		}
+		if read > len(data) {
+			t.Fatalf("read %d bytes out of %d", read, len(data))
+		}
+
+		// Whatever unmarshals must marshal, and roundtrip from there on.
+		buf := new(bytes.Buffer)
+		if _, err := obj.MarshalCBOR(buf); err != nil {
+			t.Fatal("failed to marshal unmarshaled object: ", err)
+		}
+		enc := buf.Bytes()
+
+		var nobj ValidatedMap
+		if _, err := nobj.UnmarshalCBOR(bytes.NewReader(enc)); err != nil {
+			t.Fatalf("failed to unmarshal %x: %s", enc, err)
+		}
+		nbuf := new(bytes.Buffer)
+		if _, err := nobj.MarshalCBOR(nbuf); err != nil {
+			t.Fatal("failed to remarshal object: ", err)
+		}
+		if !bytes.Equal(nbuf.Bytes(), enc) {
+			t.Fatalf("objects encodings different: %x != %x", nbuf.Bytes(), enc)
+		}
+	})
+}
+
+func TestRoundtripValidatedMap(t *testing.T) {
+	r := rand.New(rand.NewSource(56887))
+	for i := 0; i < 100; i++ {
+		val, ok := quick.Value(reflect.TypeOf(ValidatedMap{}), r)
+		if !ok {
+			t.Fatal("failed to generate test value")
+		}
+		obj := val.Addr().Interface().(*ValidatedMap)
+
+		buf := new(bytes.Buffer)
+		if n, err := obj.MarshalCBOR(buf); err != nil {
+			continue
+		} else if n != buf.Len() {
+			t.Fatal("returned length does not match the byte length")
+		}
+		enc := buf.Bytes()
+
+		var nobj ValidatedMap
+		if read, err := nobj.UnmarshalCBOR(bytes.NewReader(enc)); err != nil {
+			t.Fatalf("failed to unmarshal %x: %s", enc, err)
+		} else if read != len(enc) {
+			t.Fatalf("wrong bytesRead when unmarshaling: should be %d, actual %d", len(enc), read)
+		}
+
+		nbuf := new(bytes.Buffer)
+		if _, err := nobj.MarshalCBOR(nbuf); err != nil {
+			t.Fatal("failed to remarshal object: ", err)
+		}
+		if !bytes.Equal(nbuf.Bytes(), enc) {
+			t.Fatalf("objects encodings different: %x != %x", nbuf.Bytes(), enc)
+		}
+
+		// Truncated encodings must fail to unmarshal.
+		for _, l := range []int{r.Intn(len(enc)), len(enc) - 1} {
+			var tobj ValidatedMap
+			if _, err := tobj.UnmarshalCBOR(bytes.NewReader(enc[:l])); err == nil {
+				t.Fatalf("unmarshaled truncated encoding %x", enc[:l])
+			}
+		}
+	}
+}
+
+func FuzzUnmarshalConstrained(f *testing.F) {
+	buf := new(bytes.Buffer)
+	if _, err := new(Constrained).MarshalCBOR(buf); err == nil {
+		f.Add(buf.Bytes())
+	}
+
+	f.Fuzz(func(t *testing.T, data []byte) {
+		var obj Constrained
+		read, err := obj.UnmarshalCBOR(bytes.NewReader(data))
+		if err != nil {
+			return
+		}
+		if read > len(data) {
+			t.Fatalf("read %d bytes out of %d", read, len(data))
+		}
+
+		// Whatever unmarshals must marshal, and roundtrip from there on.
+		buf := new(bytes.Buffer)
+		if _, err := obj.MarshalCBOR(buf); err != nil {
+			t.Fatal("failed to marshal unmarshaled object: ", err)
+		}
+		enc := buf.Bytes()
+
+		var nobj Constrained
+		if _, err := nobj.UnmarshalCBOR(bytes.NewReader(enc)); err != nil {
+			t.Fatalf("failed to unmarshal %x: %s", enc, err)
+		}
+		nbuf := new(bytes.Buffer)
+		if _, err := nobj.MarshalCBOR(nbuf); err != nil {
+			t.Fatal("failed to remarshal object: ", err)
+		}
+		if !bytes.Equal(nbuf.Bytes(), enc) {
+			t.Fatalf("objects encodings different: %x != %x", nbuf.Bytes(), enc)
+		}
+	})
+}
+
+func FuzzUnmarshalIntKeyed(f *testing.F) {
+	r := rand.New(rand.NewSource(56887))
+	for i := 0; i < 10; i++ {
+		val, ok := quick.Value(reflect.TypeOf(IntKeyed{}), r)
+		if !ok {
+			f.Fatal("failed to generate test value")
+		}
+		buf := new(bytes.Buffer)
+		if _, err := val.Addr().Interface().(*IntKeyed).MarshalCBOR(buf); err != nil {
+			f.Fatal(err)
+		}
+		f.Add(buf.Bytes())
+	}
+
+	f.Fuzz(func(t *testing.T, data []byte) {
+		var obj IntKeyed
+		read, err := obj.UnmarshalCBOR(bytes.NewReader(data))
+		if err != nil {
+			return
+		}
+		if read > len(data) {
+			t.Fatalf("read %d bytes out of %d", read, len(data))
+		}
+
+		// Whatever unmarshals must marshal, and roundtrip from there on.
+		buf := new(bytes.Buffer)
+		if _, err := obj.MarshalCBOR(buf); err != nil {
+			t.Fatal("failed to marshal unmarshaled object: ", err)
+		}
+		enc := buf.Bytes()
+
+		var nobj IntKeyed
+		if _, err := nobj.UnmarshalCBOR(bytes.NewReader(enc)); err != nil {
+			t.Fatalf("failed to unmarshal %x: %s", enc, err)
+		}
+		nbuf := new(bytes.Buffer)
+		if _, err := nobj.MarshalCBOR(nbuf); err != nil {
+			t.Fatal("failed to remarshal object: ", err)
+		}
+		if !bytes.Equal(nbuf.Bytes(), enc) {
+			t.Fatalf("objects encodings different: %x != %x", nbuf.Bytes(), enc)
+		}
+	})
+}
+
+func TestRoundtripIntKeyed(t *testing.T) {
+	r := rand.New(rand.NewSource(56887))
+	for i := 0; i < 100; i++ {
+		val, ok := quick.Value(reflect.TypeOf(IntKeyed{}), r)
+		if !ok {
+			t.Fatal("failed to generate test value")
+		}
+		obj := val.Addr().Interface().(*IntKeyed)
+
+		buf := new(bytes.Buffer)
+		if n, err := obj.MarshalCBOR(buf); err != nil {
+			t.Fatal(err)
+		} else if n != buf.Len() {
+			t.Fatal("returned length does not match the byte length")
+		}
+		enc := buf.Bytes()
+
+		var nobj IntKeyed
+		if read, err := nobj.UnmarshalCBOR(bytes.NewReader(enc)); err != nil {
+			t.Fatalf("failed to unmarshal %x: %s", enc, err)
+		} else if read != len(enc) {
+			t.Fatalf("wrong bytesRead when unmarshaling: should be %d, actual %d", len(enc), read)
+		}
+
+		nbuf := new(bytes.Buffer)
+		if _, err := nobj.MarshalCBOR(nbuf); err != nil {
+			t.Fatal("failed to remarshal object: ", err)
+		}
+		if !bytes.Equal(nbuf.Bytes(), enc) {
+			t.Fatalf("objects encodings different: %x != %x", nbuf.Bytes(), enc)
+		}
+
+		// Truncated encodings must fail to unmarshal.
+		for _, l := range []int{r.Intn(len(enc)), len(enc) - 1} {
+			var tobj IntKeyed
+			if _, err := tobj.UnmarshalCBOR(bytes.NewReader(enc[:l])); err == nil {
+				t.Fatalf("unmarshaled truncated encoding %x", enc[:l])
+			}
+		}
+	}
+}
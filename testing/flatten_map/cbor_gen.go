@@ -648,6 +648,11 @@ func (t *EmbeddingStructOne) UnmarshalCBOR(r io.Reader) (int, error) {
 
 	br := cbg.GetPeeker(r)
 	scratch := make([]byte, 8)
+	limits := cbg.GetDecodeLimits(br)
+	if err := limits.Enter(); err != nil {
+		return bytesRead, &cbg.DecodeError{Type: "EmbeddingStructOne", Err: err}
+	}
+	defer limits.Leave()
 
 	maj, extra, read, err := cbg.CborReadHeaderBuf(br, scratch)
 	if err != nil {
@@ -655,11 +660,14 @@ func (t *EmbeddingStructOne) UnmarshalCBOR(r io.Reader) (int, error) {
 	}
 	bytesRead += read
 	if maj != cbg.MajMap {
-		return bytesRead, fmt.Errorf("cbor input should be of type map")
+		return bytesRead, &cbg.DecodeError{Type: "EmbeddingStructOne", Expected: cbg.MajMap, Found: maj, Err: cbg.ErrUnexpectedType}
 	}
 
 	if extra > cbg.MaxLength {
-		return bytesRead, fmt.Errorf("EmbeddingStructOne: map struct too large (%d)", extra)
+		return bytesRead, &cbg.DecodeError{Type: "EmbeddingStructOne", Expected: cbg.MajMap, Found: maj, Err: cbg.ErrMaxLength}
+	}
+	if err := limits.AddItems(extra); err != nil {
+		return bytesRead, &cbg.DecodeError{Type: "EmbeddingStructOne", Expected: cbg.MajMap, Found: maj, Err: err}
 	}
 
 	var name string
@@ -670,7 +678,7 @@ func (t *EmbeddingStructOne) UnmarshalCBOR(r io.Reader) (int, error) {
 		{
 			sval, read, err := cbg.ReadStringBuf(br, scratch)
 			if err != nil {
-				return bytesRead, err
+				return bytesRead, cbg.WrapDecodeError(err, "EmbeddingStructOne", "", bytesRead)
 			}
 			bytesRead += read
 
@@ -682,25 +690,29 @@ func (t *EmbeddingStructOne) UnmarshalCBOR(r io.Reader) (int, error) {
 		case "I8":
 			{
 				maj, extra, read, err := cbg.CborReadHeaderBuf(br, scratch)
-				var extraI int8
+				var extraI int64
 				if err != nil {
-					return bytesRead, err
+					return bytesRead, cbg.WrapDecodeError(err, "EmbeddingStructOne", "I8", bytesRead)
 				}
 				bytesRead += read
 				switch maj {
 				case cbg.MajUnsignedInt:
-					extraI = int8(extra)
+					extraI = int64(extra)
 					if extraI < 0 {
-						return bytesRead, fmt.Errorf("int8 positive overflow")
+						return bytesRead, &cbg.DecodeError{Type: "EmbeddingStructOne", Field: "I8", Offset: bytesRead - read, Expected: maj, Found: maj, Err: cbg.ErrOverflow}
 					}
 				case cbg.MajNegativeInt:
-					extraI = int8(extra)
+					extraI = int64(extra)
 					if extraI < 0 {
-						return bytesRead, fmt.Errorf("int8 negative oveflow")
+						return bytesRead, &cbg.DecodeError{Type: "EmbeddingStructOne", Field: "I8", Offset: bytesRead - read, Expected: maj, Found: maj, Err: cbg.ErrOverflow}
 					}
 					extraI = -1 - extraI
 				default:
-					return bytesRead, fmt.Errorf("wrong type for int8 field: %d", maj)
+					return bytesRead, &cbg.DecodeError{Type: "EmbeddingStructOne", Field: "I8", Offset: bytesRead - read, Expected: cbg.MajUnsignedInt, Found: maj, Err: cbg.ErrUnexpectedType}
+				}
+
+				if extraI > math.MaxInt8 || extraI < math.MinInt8 {
+					return bytesRead, &cbg.DecodeError{Type: "EmbeddingStructOne", Field: "I8", Offset: bytesRead - read, Expected: maj, Found: maj, Err: cbg.ErrOverflow}
 				}
 
 				t.I8 = int8(extraI)
@@ -710,14 +722,14 @@ func (t *EmbeddingStructOne) UnmarshalCBOR(r io.Reader) (int, error) {
 
 			maj, extra, read, err = cbg.CborReadHeaderBuf(br, scratch)
 			if err != nil {
-				return bytesRead, err
+				return bytesRead, cbg.WrapDecodeError(err, "EmbeddingStructOne", "U8", bytesRead)
 			}
 			bytesRead += read
 			if maj != cbg.MajUnsignedInt {
-				return bytesRead, fmt.Errorf("wrong type for uint8 field")
+				return bytesRead, &cbg.DecodeError{Type: "EmbeddingStructOne", Field: "U8", Offset: bytesRead - read, Expected: cbg.MajUnsignedInt, Found: maj, Err: cbg.ErrUnexpectedType}
 			}
 			if extra > math.MaxUint8 {
-				return bytesRead, fmt.Errorf("integer in input was too large for uint8 field")
+				return bytesRead, &cbg.DecodeError{Type: "EmbeddingStructOne", Field: "U8", Offset: bytesRead - read, Expected: cbg.MajUnsignedInt, Found: maj, Err: cbg.ErrOverflow}
 			}
 			t.U8 = uint8(extra)
 			// t.Dog (string) (string)
@@ -726,7 +738,7 @@ func (t *EmbeddingStructOne) UnmarshalCBOR(r io.Reader) (int, error) {
 			{
 				sval, read, err := cbg.ReadStringBuf(br, scratch)
 				if err != nil {
-					return bytesRead, err
+					return bytesRead, cbg.WrapDecodeError(err, "EmbeddingStructOne", "Dog", bytesRead)
 				}
 				bytesRead += read
 
@@ -738,7 +750,7 @@ func (t *EmbeddingStructOne) UnmarshalCBOR(r io.Reader) (int, error) {
 			{
 				sval, read, err := cbg.ReadStringBuf(br, scratch)
 				if err != nil {
-					return bytesRead, err
+					return bytesRead, cbg.WrapDecodeError(err, "EmbeddingStructOne", "Foo", bytesRead)
 				}
 				bytesRead += read
 
@@ -748,25 +760,29 @@ func (t *EmbeddingStructOne) UnmarshalCBOR(r io.Reader) (int, error) {
 		case "I16":
 			{
 				maj, extra, read, err := cbg.CborReadHeaderBuf(br, scratch)
-				var extraI int16
+				var extraI int64
 				if err != nil {
-					return bytesRead, err
+					return bytesRead, cbg.WrapDecodeError(err, "EmbeddingStructOne", "I16", bytesRead)
 				}
 				bytesRead += read
 				switch maj {
 				case cbg.MajUnsignedInt:
-					extraI = int16(extra)
+					extraI = int64(extra)
 					if extraI < 0 {
-						return bytesRead, fmt.Errorf("int16 positive overflow")
+						return bytesRead, &cbg.DecodeError{Type: "EmbeddingStructOne", Field: "I16", Offset: bytesRead - read, Expected: maj, Found: maj, Err: cbg.ErrOverflow}
 					}
 				case cbg.MajNegativeInt:
-					extraI = int16(extra)
+					extraI = int64(extra)
 					if extraI < 0 {
-						return bytesRead, fmt.Errorf("int16 negative oveflow")
+						return bytesRead, &cbg.DecodeError{Type: "EmbeddingStructOne", Field: "I16", Offset: bytesRead - read, Expected: maj, Found: maj, Err: cbg.ErrOverflow}
 					}
 					extraI = -1 - extraI
 				default:
-					return bytesRead, fmt.Errorf("wrong type for int16 field: %d", maj)
+					return bytesRead, &cbg.DecodeError{Type: "EmbeddingStructOne", Field: "I16", Offset: bytesRead - read, Expected: cbg.MajUnsignedInt, Found: maj, Err: cbg.ErrUnexpectedType}
+				}
+
+				if extraI > math.MaxInt16 || extraI < math.MinInt16 {
+					return bytesRead, &cbg.DecodeError{Type: "EmbeddingStructOne", Field: "I16", Offset: bytesRead - read, Expected: maj, Found: maj, Err: cbg.ErrOverflow}
 				}
 
 				t.I16 = int16(extraI)
@@ -775,25 +791,29 @@ func (t *EmbeddingStructOne) UnmarshalCBOR(r io.Reader) (int, error) {
 		case "I32":
 			{
 				maj, extra, read, err := cbg.CborReadHeaderBuf(br, scratch)
-				var extraI int32
+				var extraI int64
 				if err != nil {
-					return bytesRead, err
+					return bytesRead, cbg.WrapDecodeError(err, "EmbeddingStructOne", "I32", bytesRead)
 				}
 				bytesRead += read
 				switch maj {
 				case cbg.MajUnsignedInt:
-					extraI = int32(extra)
+					extraI = int64(extra)
 					if extraI < 0 {
-						return bytesRead, fmt.Errorf("int32 positive overflow")
+						return bytesRead, &cbg.DecodeError{Type: "EmbeddingStructOne", Field: "I32", Offset: bytesRead - read, Expected: maj, Found: maj, Err: cbg.ErrOverflow}
 					}
 				case cbg.MajNegativeInt:
-					extraI = int32(extra)
+					extraI = int64(extra)
 					if extraI < 0 {
-						return bytesRead, fmt.Errorf("int32 negative oveflow")
+						return bytesRead, &cbg.DecodeError{Type: "EmbeddingStructOne", Field: "I32", Offset: bytesRead - read, Expected: maj, Found: maj, Err: cbg.ErrOverflow}
 					}
 					extraI = -1 - extraI
 				default:
-					return bytesRead, fmt.Errorf("wrong type for int32 field: %d", maj)
+					return bytesRead, &cbg.DecodeError{Type: "EmbeddingStructOne", Field: "I32", Offset: bytesRead - read, Expected: cbg.MajUnsignedInt, Found: maj, Err: cbg.ErrUnexpectedType}
+				}
+
+				if extraI > math.MaxInt32 || extraI < math.MinInt32 {
+					return bytesRead, &cbg.DecodeError{Type: "EmbeddingStructOne", Field: "I32", Offset: bytesRead - read, Expected: maj, Found: maj, Err: cbg.ErrOverflow}
 				}
 
 				t.I32 = int32(extraI)
@@ -803,14 +823,14 @@ func (t *EmbeddingStructOne) UnmarshalCBOR(r io.Reader) (int, error) {
 
 			maj, extra, read, err = cbg.CborReadHeaderBuf(br, scratch)
 			if err != nil {
-				return bytesRead, err
+				return bytesRead, cbg.WrapDecodeError(err, "EmbeddingStructOne", "U16", bytesRead)
 			}
 			bytesRead += read
 			if maj != cbg.MajUnsignedInt {
-				return bytesRead, fmt.Errorf("wrong type for uint16 field")
+				return bytesRead, &cbg.DecodeError{Type: "EmbeddingStructOne", Field: "U16", Offset: bytesRead - read, Expected: cbg.MajUnsignedInt, Found: maj, Err: cbg.ErrUnexpectedType}
 			}
 			if extra > math.MaxUint16 {
-				return bytesRead, fmt.Errorf("integer in input was too large for uint16 field")
+				return bytesRead, &cbg.DecodeError{Type: "EmbeddingStructOne", Field: "U16", Offset: bytesRead - read, Expected: cbg.MajUnsignedInt, Found: maj, Err: cbg.ErrOverflow}
 			}
 			t.U16 = uint16(extra)
 			// t.U32 (uint32) (uint32)
@@ -818,14 +838,14 @@ func (t *EmbeddingStructOne) UnmarshalCBOR(r io.Reader) (int, error) {
 
 			maj, extra, read, err = cbg.CborReadHeaderBuf(br, scratch)
 			if err != nil {
-				return bytesRead, err
+				return bytesRead, cbg.WrapDecodeError(err, "EmbeddingStructOne", "U32", bytesRead)
 			}
 			bytesRead += read
 			if maj != cbg.MajUnsignedInt {
-				return bytesRead, fmt.Errorf("wrong type for uint32 field")
+				return bytesRead, &cbg.DecodeError{Type: "EmbeddingStructOne", Field: "U32", Offset: bytesRead - read, Expected: cbg.MajUnsignedInt, Found: maj, Err: cbg.ErrUnexpectedType}
 			}
 			if extra > math.MaxUint32 {
-				return bytesRead, fmt.Errorf("integer in input was too large for uint32 field")
+				return bytesRead, &cbg.DecodeError{Type: "EmbeddingStructOne", Field: "U32", Offset: bytesRead - read, Expected: cbg.MajUnsignedInt, Found: maj, Err: cbg.ErrOverflow}
 			}
 			t.U32 = uint32(extra)
 			// t.Test ([][]uint8) (slice)
@@ -833,16 +853,19 @@ func (t *EmbeddingStructOne) UnmarshalCBOR(r io.Reader) (int, error) {
 
 			maj, extra, read, err = cbg.CborReadHeaderBuf(br, scratch)
 			if err != nil {
-				return bytesRead, err
+				return bytesRead, cbg.WrapDecodeError(err, "EmbeddingStructOne", "Test", bytesRead)
 			}
 			bytesRead += read
 
 			if extra > cbg.MaxLength {
-				return bytesRead, fmt.Errorf("t.Test: array too large (%d)", extra)
+				return bytesRead, &cbg.DecodeError{Type: "EmbeddingStructOne", Field: "Test", Offset: bytesRead - read, Expected: cbg.MajArray, Found: maj, Err: cbg.ErrMaxLength}
 			}
 
 			if maj != cbg.MajArray {
-				return bytesRead, fmt.Errorf("expected cbor array")
+				return bytesRead, &cbg.DecodeError{Type: "EmbeddingStructOne", Field: "Test", Offset: bytesRead - read, Expected: cbg.MajArray, Found: maj, Err: cbg.ErrUnexpectedType}
+			}
+			if err := limits.AddItems(extra); err != nil {
+				return bytesRead, &cbg.DecodeError{Type: "EmbeddingStructOne", Field: "Test", Offset: bytesRead - read, Expected: cbg.MajArray, Found: maj, Err: err}
 			}
 
 			if extra > 0 {
@@ -857,15 +880,18 @@ func (t *EmbeddingStructOne) UnmarshalCBOR(r io.Reader) (int, error) {
 
 					maj, extra, read, err = cbg.CborReadHeaderBuf(br, scratch)
 					if err != nil {
-						return bytesRead, err
+						return bytesRead, cbg.WrapDecodeError(err, "EmbeddingStructOne", fmt.Sprintf("Test[%d]", i), bytesRead)
 					}
 					bytesRead += read
 
 					if extra > cbg.ByteArrayMaxLen {
-						return bytesRead, fmt.Errorf("t.Test[i]: byte array too large (%d)", extra)
+						return bytesRead, &cbg.DecodeError{Type: "EmbeddingStructOne", Field: fmt.Sprintf("Test[%d]", i), Offset: bytesRead - read, Expected: cbg.MajByteString, Found: maj, Err: cbg.ErrMaxLength}
 					}
 					if maj != cbg.MajByteString {
-						return bytesRead, fmt.Errorf("expected byte array")
+						return bytesRead, &cbg.DecodeError{Type: "EmbeddingStructOne", Field: fmt.Sprintf("Test[%d]", i), Offset: bytesRead - read, Expected: cbg.MajByteString, Found: maj, Err: cbg.ErrUnexpectedType}
+					}
+					if err := limits.AddBytes(extra); err != nil {
+						return bytesRead, &cbg.DecodeError{Type: "EmbeddingStructOne", Field: fmt.Sprintf("Test[%d]", i), Offset: bytesRead - read, Expected: cbg.MajByteString, Found: maj, Err: err}
 					}
 
 					if extra > 0 {
@@ -873,7 +899,7 @@ func (t *EmbeddingStructOne) UnmarshalCBOR(r io.Reader) (int, error) {
 					}
 
 					if read, err := io.ReadFull(br, t.Test[i][:]); err != nil {
-						return bytesRead, err
+						return bytesRead, cbg.WrapDecodeError(err, "EmbeddingStructOne", fmt.Sprintf("Test[%d]", i), bytesRead)
 					} else {
 						bytesRead += read
 					}
@@ -887,21 +913,21 @@ func (t *EmbeddingStructOne) UnmarshalCBOR(r io.Reader) (int, error) {
 
 				b, err := br.ReadByte()
 				if err != nil {
-					return bytesRead, err
+					return bytesRead, cbg.WrapDecodeError(err, "EmbeddingStructOne", "Pizza", bytesRead)
 				}
 				bytesRead++
 				if b != cbg.CborNull[0] {
 					if err := br.UnreadByte(); err != nil {
-						return bytesRead, err
+						return bytesRead, cbg.WrapDecodeError(err, "EmbeddingStructOne", "Pizza", bytesRead)
 					}
 					bytesRead--
 					maj, extra, read, err = cbg.CborReadHeaderBuf(br, scratch)
 					if err != nil {
-						return bytesRead, err
+						return bytesRead, cbg.WrapDecodeError(err, "EmbeddingStructOne", "Pizza", bytesRead)
 					}
 					bytesRead += read
 					if maj != cbg.MajUnsignedInt {
-						return bytesRead, fmt.Errorf("wrong type for uint64 field")
+						return bytesRead, &cbg.DecodeError{Type: "EmbeddingStructOne", Field: "Pizza", Offset: bytesRead - read, Expected: cbg.MajUnsignedInt, Found: maj, Err: cbg.ErrUnexpectedType}
 					}
 					typed := uint64(extra)
 					t.Pizza = &typed
@@ -915,17 +941,17 @@ func (t *EmbeddingStructOne) UnmarshalCBOR(r io.Reader) (int, error) {
 
 				b, err := br.ReadByte()
 				if err != nil {
-					return bytesRead, err
+					return bytesRead, cbg.WrapDecodeError(err, "EmbeddingStructOne", "Stuff", bytesRead)
 				}
 				bytesRead++
 				if b != cbg.CborNull[0] {
 					if err := br.UnreadByte(); err != nil {
-						return bytesRead, err
+						return bytesRead, cbg.WrapDecodeError(err, "EmbeddingStructOne", "Stuff", bytesRead)
 					}
 					bytesRead--
 					t.Stuff = new(testing.SimpleTypeTwo)
 					if read, err := t.Stuff.UnmarshalCBOR(br); err != nil {
-						return bytesRead, xerrors.Errorf("unmarshaling t.Stuff pointer: %w", err)
+						return bytesRead, cbg.WrapDecodeError(err, "EmbeddingStructOne", "Stuff", bytesRead)
 					} else {
 						bytesRead += read
 					}
@@ -939,11 +965,11 @@ func (t *EmbeddingStructOne) UnmarshalCBOR(r io.Reader) (int, error) {
 
 				maj, extra, read, err = cbg.CborReadHeaderBuf(br, scratch)
 				if err != nil {
-					return bytesRead, err
+					return bytesRead, cbg.WrapDecodeError(err, "EmbeddingStructOne", "Value", bytesRead)
 				}
 				bytesRead += read
 				if maj != cbg.MajUnsignedInt {
-					return bytesRead, fmt.Errorf("wrong type for uint64 field")
+					return bytesRead, &cbg.DecodeError{Type: "EmbeddingStructOne", Field: "Value", Offset: bytesRead - read, Expected: cbg.MajUnsignedInt, Found: maj, Err: cbg.ErrUnexpectedType}
 				}
 				t.Value = uint64(extra)
 
@@ -953,15 +979,18 @@ func (t *EmbeddingStructOne) UnmarshalCBOR(r io.Reader) (int, error) {
 
 			maj, extra, read, err = cbg.CborReadHeaderBuf(br, scratch)
 			if err != nil {
-				return bytesRead, err
+				return bytesRead, cbg.WrapDecodeError(err, "EmbeddingStructOne", "Binary", bytesRead)
 			}
 			bytesRead += read
 
 			if extra > cbg.ByteArrayMaxLen {
-				return bytesRead, fmt.Errorf("t.Binary: byte array too large (%d)", extra)
+				return bytesRead, &cbg.DecodeError{Type: "EmbeddingStructOne", Field: "Binary", Offset: bytesRead - read, Expected: cbg.MajByteString, Found: maj, Err: cbg.ErrMaxLength}
 			}
 			if maj != cbg.MajByteString {
-				return bytesRead, fmt.Errorf("expected byte array")
+				return bytesRead, &cbg.DecodeError{Type: "EmbeddingStructOne", Field: "Binary", Offset: bytesRead - read, Expected: cbg.MajByteString, Found: maj, Err: cbg.ErrUnexpectedType}
+			}
+			if err := limits.AddBytes(extra); err != nil {
+				return bytesRead, &cbg.DecodeError{Type: "EmbeddingStructOne", Field: "Binary", Offset: bytesRead - read, Expected: cbg.MajByteString, Found: maj, Err: err}
 			}
 
 			if extra > 0 {
@@ -969,7 +998,7 @@ func (t *EmbeddingStructOne) UnmarshalCBOR(r io.Reader) (int, error) {
 			}
 
 			if read, err := io.ReadFull(br, t.Binary[:]); err != nil {
-				return bytesRead, err
+				return bytesRead, cbg.WrapDecodeError(err, "EmbeddingStructOne", "Binary", bytesRead)
 			} else {
 				bytesRead += read
 			}
@@ -978,16 +1007,19 @@ func (t *EmbeddingStructOne) UnmarshalCBOR(r io.Reader) (int, error) {
 
 			maj, extra, read, err = cbg.CborReadHeaderBuf(br, scratch)
 			if err != nil {
-				return bytesRead, err
+				return bytesRead, cbg.WrapDecodeError(err, "EmbeddingStructOne", "Others", bytesRead)
 			}
 			bytesRead += read
 
 			if extra > cbg.MaxLength {
-				return bytesRead, fmt.Errorf("t.Others: array too large (%d)", extra)
+				return bytesRead, &cbg.DecodeError{Type: "EmbeddingStructOne", Field: "Others", Offset: bytesRead - read, Expected: cbg.MajArray, Found: maj, Err: cbg.ErrMaxLength}
 			}
 
 			if maj != cbg.MajArray {
-				return bytesRead, fmt.Errorf("expected cbor array")
+				return bytesRead, &cbg.DecodeError{Type: "EmbeddingStructOne", Field: "Others", Offset: bytesRead - read, Expected: cbg.MajArray, Found: maj, Err: cbg.ErrUnexpectedType}
+			}
+			if err := limits.AddItems(extra); err != nil {
+				return bytesRead, &cbg.DecodeError{Type: "EmbeddingStructOne", Field: "Others", Offset: bytesRead - read, Expected: cbg.MajArray, Found: maj, Err: err}
 			}
 
 			if extra > 0 {
@@ -998,12 +1030,12 @@ func (t *EmbeddingStructOne) UnmarshalCBOR(r io.Reader) (int, error) {
 
 				maj, val, read, err := cbg.CborReadHeaderBuf(br, scratch)
 				if err != nil {
-					return bytesRead, xerrors.Errorf("failed to read uint64 for t.Others slice: %w", err)
+					return bytesRead, cbg.WrapDecodeError(err, "EmbeddingStructOne", fmt.Sprintf("Others[%d]", i), bytesRead)
 				}
 				bytesRead += read
 
 				if maj != cbg.MajUnsignedInt {
-					return bytesRead, xerrors.Errorf("value read for array t.Others was not a uint, instead got %d", maj)
+					return bytesRead, &cbg.DecodeError{Type: "EmbeddingStructOne", Field: fmt.Sprintf("Others[%d]", i), Offset: bytesRead - read, Expected: cbg.MajUnsignedInt, Found: maj, Err: cbg.ErrUnexpectedType}
 				}
 
 				t.Others[i] = uint64(val)
@@ -1015,23 +1047,23 @@ func (t *EmbeddingStructOne) UnmarshalCBOR(r io.Reader) (int, error) {
 				maj, extra, read, err := cbg.CborReadHeaderBuf(br, scratch)
 				var extraI int64
 				if err != nil {
-					return bytesRead, err
+					return bytesRead, cbg.WrapDecodeError(err, "EmbeddingStructOne", "Signed", bytesRead)
 				}
 				bytesRead += read
 				switch maj {
 				case cbg.MajUnsignedInt:
 					extraI = int64(extra)
 					if extraI < 0 {
-						return bytesRead, fmt.Errorf("int64 positive overflow")
+						return bytesRead, &cbg.DecodeError{Type: "EmbeddingStructOne", Field: "Signed", Offset: bytesRead - read, Expected: maj, Found: maj, Err: cbg.ErrOverflow}
 					}
 				case cbg.MajNegativeInt:
 					extraI = int64(extra)
 					if extraI < 0 {
-						return bytesRead, fmt.Errorf("int64 negative oveflow")
+						return bytesRead, &cbg.DecodeError{Type: "EmbeddingStructOne", Field: "Signed", Offset: bytesRead - read, Expected: maj, Found: maj, Err: cbg.ErrOverflow}
 					}
 					extraI = -1 - extraI
 				default:
-					return bytesRead, fmt.Errorf("wrong type for int64 field: %d", maj)
+					return bytesRead, &cbg.DecodeError{Type: "EmbeddingStructOne", Field: "Signed", Offset: bytesRead - read, Expected: cbg.MajUnsignedInt, Found: maj, Err: cbg.ErrUnexpectedType}
 				}
 
 				t.Signed = int64(extraI)
@@ -1042,7 +1074,7 @@ func (t *EmbeddingStructOne) UnmarshalCBOR(r io.Reader) (int, error) {
 			{
 				sval, read, err := cbg.ReadStringBuf(br, scratch)
 				if err != nil {
-					return bytesRead, err
+					return bytesRead, cbg.WrapDecodeError(err, "EmbeddingStructOne", "NString", bytesRead)
 				}
 				bytesRead += read
 
@@ -1053,16 +1085,19 @@ func (t *EmbeddingStructOne) UnmarshalCBOR(r io.Reader) (int, error) {
 
 			maj, extra, read, err = cbg.CborReadHeaderBuf(br, scratch)
 			if err != nil {
-				return bytesRead, err
+				return bytesRead, cbg.WrapDecodeError(err, "EmbeddingStructOne", "Numbers", bytesRead)
 			}
 			bytesRead += read
 
 			if extra > cbg.MaxLength {
-				return bytesRead, fmt.Errorf("t.Numbers: array too large (%d)", extra)
+				return bytesRead, &cbg.DecodeError{Type: "EmbeddingStructOne", Field: "Numbers", Offset: bytesRead - read, Expected: cbg.MajArray, Found: maj, Err: cbg.ErrMaxLength}
 			}
 
 			if maj != cbg.MajArray {
-				return bytesRead, fmt.Errorf("expected cbor array")
+				return bytesRead, &cbg.DecodeError{Type: "EmbeddingStructOne", Field: "Numbers", Offset: bytesRead - read, Expected: cbg.MajArray, Found: maj, Err: cbg.ErrUnexpectedType}
+			}
+			if err := limits.AddItems(extra); err != nil {
+				return bytesRead, &cbg.DecodeError{Type: "EmbeddingStructOne", Field: "Numbers", Offset: bytesRead - read, Expected: cbg.MajArray, Found: maj, Err: err}
 			}
 
 			if extra > 0 {
@@ -1073,12 +1108,12 @@ func (t *EmbeddingStructOne) UnmarshalCBOR(r io.Reader) (int, error) {
 
 				maj, val, read, err := cbg.CborReadHeaderBuf(br, scratch)
 				if err != nil {
-					return bytesRead, xerrors.Errorf("failed to read uint64 for t.Numbers slice: %w", err)
+					return bytesRead, cbg.WrapDecodeError(err, "EmbeddingStructOne", fmt.Sprintf("Numbers[%d]", i), bytesRead)
 				}
 				bytesRead += read
 
 				if maj != cbg.MajUnsignedInt {
-					return bytesRead, xerrors.Errorf("value read for array t.Numbers was not a uint, instead got %d", maj)
+					return bytesRead, &cbg.DecodeError{Type: "EmbeddingStructOne", Field: fmt.Sprintf("Numbers[%d]", i), Offset: bytesRead - read, Expected: cbg.MajUnsignedInt, Found: maj, Err: cbg.ErrUnexpectedType}
 				}
 
 				t.Numbers[i] = testing.NamedNumber(val)
@@ -1089,20 +1124,23 @@ func (t *EmbeddingStructOne) UnmarshalCBOR(r io.Reader) (int, error) {
 
 			maj, extra, read, err = cbg.CborReadHeaderBuf(br, scratch)
 			if err != nil {
-				return bytesRead, err
+				return bytesRead, cbg.WrapDecodeError(err, "EmbeddingStructOne", "Arrrrrghay", bytesRead)
 			}
 			bytesRead += read
 
 			if extra > cbg.MaxLength {
-				return bytesRead, fmt.Errorf("t.Arrrrrghay: array too large (%d)", extra)
+				return bytesRead, &cbg.DecodeError{Type: "EmbeddingStructOne", Field: "Arrrrrghay", Offset: bytesRead - read, Expected: cbg.MajArray, Found: maj, Err: cbg.ErrMaxLength}
 			}
 
 			if maj != cbg.MajArray {
-				return bytesRead, fmt.Errorf("expected cbor array")
+				return bytesRead, &cbg.DecodeError{Type: "EmbeddingStructOne", Field: "Arrrrrghay", Offset: bytesRead - read, Expected: cbg.MajArray, Found: maj, Err: cbg.ErrUnexpectedType}
+			}
+			if err := limits.AddItems(extra); err != nil {
+				return bytesRead, &cbg.DecodeError{Type: "EmbeddingStructOne", Field: "Arrrrrghay", Offset: bytesRead - read, Expected: cbg.MajArray, Found: maj, Err: err}
 			}
 
 			if extra != 3 {
-				return bytesRead, fmt.Errorf("expected array to have 3 elements")
+				return bytesRead, &cbg.DecodeError{Type: "EmbeddingStructOne", Field: "Arrrrrghay", Offset: bytesRead - read, Expected: cbg.MajArray, Found: maj, Err: fmt.Errorf("expected 3 elements, got %d", extra)}
 			}
 
 			t.Arrrrrghay = [3]testing.SimpleTypeOne{}
@@ -1111,7 +1149,7 @@ func (t *EmbeddingStructOne) UnmarshalCBOR(r io.Reader) (int, error) {
 
 				var v testing.SimpleTypeOne
 				if read, err := v.UnmarshalCBOR(br); err != nil {
-					return bytesRead, err
+					return bytesRead, cbg.WrapDecodeError(err, "EmbeddingStructOne", fmt.Sprintf("Arrrrrghay[%d]", i), bytesRead)
 				} else {
 					bytesRead += read
 				}
@@ -1126,21 +1164,21 @@ func (t *EmbeddingStructOne) UnmarshalCBOR(r io.Reader) (int, error) {
 
 				b, err := br.ReadByte()
 				if err != nil {
-					return bytesRead, err
+					return bytesRead, cbg.WrapDecodeError(err, "EmbeddingStructOne", "PointyPizza", bytesRead)
 				}
 				bytesRead++
 				if b != cbg.CborNull[0] {
 					if err := br.UnreadByte(); err != nil {
-						return bytesRead, err
+						return bytesRead, cbg.WrapDecodeError(err, "EmbeddingStructOne", "PointyPizza", bytesRead)
 					}
 					bytesRead--
 					maj, extra, read, err = cbg.CborReadHeaderBuf(br, scratch)
 					if err != nil {
-						return bytesRead, err
+						return bytesRead, cbg.WrapDecodeError(err, "EmbeddingStructOne", "PointyPizza", bytesRead)
 					}
 					bytesRead += read
 					if maj != cbg.MajUnsignedInt {
-						return bytesRead, fmt.Errorf("wrong type for uint64 field")
+						return bytesRead, &cbg.DecodeError{Type: "EmbeddingStructOne", Field: "PointyPizza", Offset: bytesRead - read, Expected: cbg.MajUnsignedInt, Found: maj, Err: cbg.ErrUnexpectedType}
 					}
 					typed := testing.NamedNumber(extra)
 					t.PointyPizza = &typed
@@ -1152,16 +1190,19 @@ func (t *EmbeddingStructOne) UnmarshalCBOR(r io.Reader) (int, error) {
 
 			maj, extra, read, err = cbg.CborReadHeaderBuf(br, scratch)
 			if err != nil {
-				return bytesRead, err
+				return bytesRead, cbg.WrapDecodeError(err, "EmbeddingStructOne", "SignedOthers", bytesRead)
 			}
 			bytesRead += read
 
 			if extra > cbg.MaxLength {
-				return bytesRead, fmt.Errorf("t.SignedOthers: array too large (%d)", extra)
+				return bytesRead, &cbg.DecodeError{Type: "EmbeddingStructOne", Field: "SignedOthers", Offset: bytesRead - read, Expected: cbg.MajArray, Found: maj, Err: cbg.ErrMaxLength}
 			}
 
 			if maj != cbg.MajArray {
-				return bytesRead, fmt.Errorf("expected cbor array")
+				return bytesRead, &cbg.DecodeError{Type: "EmbeddingStructOne", Field: "SignedOthers", Offset: bytesRead - read, Expected: cbg.MajArray, Found: maj, Err: cbg.ErrUnexpectedType}
+			}
+			if err := limits.AddItems(extra); err != nil {
+				return bytesRead, &cbg.DecodeError{Type: "EmbeddingStructOne", Field: "SignedOthers", Offset: bytesRead - read, Expected: cbg.MajArray, Found: maj, Err: err}
 			}
 
 			if extra > 0 {
@@ -1173,23 +1214,23 @@ func (t *EmbeddingStructOne) UnmarshalCBOR(r io.Reader) (int, error) {
 					maj, extra, read, err := cbg.CborReadHeaderBuf(br, scratch)
 					var extraI int64
 					if err != nil {
-						return bytesRead, err
+						return bytesRead, cbg.WrapDecodeError(err, "EmbeddingStructOne", fmt.Sprintf("SignedOthers[%d]", i), bytesRead)
 					}
 					bytesRead += read
 					switch maj {
 					case cbg.MajUnsignedInt:
 						extraI = int64(extra)
 						if extraI < 0 {
-							return bytesRead, fmt.Errorf("int64 positive overflow")
+							return bytesRead, &cbg.DecodeError{Type: "EmbeddingStructOne", Field: fmt.Sprintf("SignedOthers[%d]", i), Offset: bytesRead - read, Expected: maj, Found: maj, Err: cbg.ErrOverflow}
 						}
 					case cbg.MajNegativeInt:
 						extraI = int64(extra)
 						if extraI < 0 {
-							return bytesRead, fmt.Errorf("int64 negative oveflow")
+							return bytesRead, &cbg.DecodeError{Type: "EmbeddingStructOne", Field: fmt.Sprintf("SignedOthers[%d]", i), Offset: bytesRead - read, Expected: maj, Found: maj, Err: cbg.ErrOverflow}
 						}
 						extraI = -1 - extraI
 					default:
-						return bytesRead, fmt.Errorf("wrong type for int64 field: %d", maj)
+						return bytesRead, &cbg.DecodeError{Type: "EmbeddingStructOne", Field: fmt.Sprintf("SignedOthers[%d]", i), Offset: bytesRead - read, Expected: cbg.MajUnsignedInt, Found: maj, Err: cbg.ErrUnexpectedType}
 					}
 
 					t.SignedOthers[i] = int64(extraI)
@@ -1198,7 +1239,9 @@ func (t *EmbeddingStructOne) UnmarshalCBOR(r io.Reader) (int, error) {
 
 		default:
 			// Field doesn't exist on this type, so ignore it
-			if read, err := cbg.ScanForLinks(r, func(cid.Cid) {}); err == nil {
+			if read, err := cbg.ScanForLinks(br, func(cid.Cid) {}); err != nil {
+				return bytesRead, cbg.WrapDecodeError(err, "EmbeddingStructOne", "", bytesRead)
+			} else {
 				bytesRead += read
 			}
 		}
@@ -1207,6 +1250,30 @@ func (t *EmbeddingStructOne) UnmarshalCBOR(r io.Reader) (int, error) {
 	return bytesRead, nil
 }
 
+func (t *EmbeddingStructOne) MarshalDAGJSON(w io.Writer) error {
+	return cbg.MarshalDAGJSON(w, t)
+}
+
+func (t *EmbeddingStructOne) UnmarshalDAGJSON(r io.Reader) error {
+	return cbg.UnmarshalDAGJSON(r, t)
+}
+
+func (t *EmbeddingStructOne) Links() []cid.Cid {
+	var links []cid.Cid
+	_ = t.ForEachLink(func(_ string, c cid.Cid) error {
+		links = append(links, c)
+		return nil
+	})
+	return links
+}
+
+func (t *EmbeddingStructOne) ForEachLink(cb func(path string, c cid.Cid) error) error {
+	if t == nil {
+		return nil
+	}
+	return nil
+}
+
 func (t *EmbeddingStructTwo) InitNilEmbeddedStruct() {
 	if t != nil {
 		if t.EmbeddingStructOne == nil {
@@ -1836,6 +1903,11 @@ func (t *EmbeddingStructTwo) UnmarshalCBOR(r io.Reader) (int, error) {
 
 	br := cbg.GetPeeker(r)
 	scratch := make([]byte, 8)
+	limits := cbg.GetDecodeLimits(br)
+	if err := limits.Enter(); err != nil {
+		return bytesRead, &cbg.DecodeError{Type: "EmbeddingStructTwo", Err: err}
+	}
+	defer limits.Leave()
 
 	maj, extra, read, err := cbg.CborReadHeaderBuf(br, scratch)
 	if err != nil {
@@ -1843,11 +1915,14 @@ func (t *EmbeddingStructTwo) UnmarshalCBOR(r io.Reader) (int, error) {
 	}
 	bytesRead += read
 	if maj != cbg.MajMap {
-		return bytesRead, fmt.Errorf("cbor input should be of type map")
+		return bytesRead, &cbg.DecodeError{Type: "EmbeddingStructTwo", Expected: cbg.MajMap, Found: maj, Err: cbg.ErrUnexpectedType}
 	}
 
 	if extra > cbg.MaxLength {
-		return bytesRead, fmt.Errorf("EmbeddingStructTwo: map struct too large (%d)", extra)
+		return bytesRead, &cbg.DecodeError{Type: "EmbeddingStructTwo", Expected: cbg.MajMap, Found: maj, Err: cbg.ErrMaxLength}
+	}
+	if err := limits.AddItems(extra); err != nil {
+		return bytesRead, &cbg.DecodeError{Type: "EmbeddingStructTwo", Expected: cbg.MajMap, Found: maj, Err: err}
 	}
 
 	var name string
@@ -1858,7 +1933,7 @@ func (t *EmbeddingStructTwo) UnmarshalCBOR(r io.Reader) (int, error) {
 		{
 			sval, read, err := cbg.ReadStringBuf(br, scratch)
 			if err != nil {
-				return bytesRead, err
+				return bytesRead, cbg.WrapDecodeError(err, "EmbeddingStructTwo", "", bytesRead)
 			}
 			bytesRead += read
 
@@ -1870,25 +1945,29 @@ func (t *EmbeddingStructTwo) UnmarshalCBOR(r io.Reader) (int, error) {
 		case "I8":
 			{
 				maj, extra, read, err := cbg.CborReadHeaderBuf(br, scratch)
-				var extraI int8
+				var extraI int64
 				if err != nil {
-					return bytesRead, err
+					return bytesRead, cbg.WrapDecodeError(err, "EmbeddingStructTwo", "I8", bytesRead)
 				}
 				bytesRead += read
 				switch maj {
 				case cbg.MajUnsignedInt:
-					extraI = int8(extra)
+					extraI = int64(extra)
 					if extraI < 0 {
-						return bytesRead, fmt.Errorf("int8 positive overflow")
+						return bytesRead, &cbg.DecodeError{Type: "EmbeddingStructTwo", Field: "I8", Offset: bytesRead - read, Expected: maj, Found: maj, Err: cbg.ErrOverflow}
 					}
 				case cbg.MajNegativeInt:
-					extraI = int8(extra)
+					extraI = int64(extra)
 					if extraI < 0 {
-						return bytesRead, fmt.Errorf("int8 negative oveflow")
+						return bytesRead, &cbg.DecodeError{Type: "EmbeddingStructTwo", Field: "I8", Offset: bytesRead - read, Expected: maj, Found: maj, Err: cbg.ErrOverflow}
 					}
 					extraI = -1 - extraI
 				default:
-					return bytesRead, fmt.Errorf("wrong type for int8 field: %d", maj)
+					return bytesRead, &cbg.DecodeError{Type: "EmbeddingStructTwo", Field: "I8", Offset: bytesRead - read, Expected: cbg.MajUnsignedInt, Found: maj, Err: cbg.ErrUnexpectedType}
+				}
+
+				if extraI > math.MaxInt8 || extraI < math.MinInt8 {
+					return bytesRead, &cbg.DecodeError{Type: "EmbeddingStructTwo", Field: "I8", Offset: bytesRead - read, Expected: maj, Found: maj, Err: cbg.ErrOverflow}
 				}
 
 				t.I8 = int8(extraI)
@@ -1898,14 +1977,14 @@ func (t *EmbeddingStructTwo) UnmarshalCBOR(r io.Reader) (int, error) {
 
 			maj, extra, read, err = cbg.CborReadHeaderBuf(br, scratch)
 			if err != nil {
-				return bytesRead, err
+				return bytesRead, cbg.WrapDecodeError(err, "EmbeddingStructTwo", "U8", bytesRead)
 			}
 			bytesRead += read
 			if maj != cbg.MajUnsignedInt {
-				return bytesRead, fmt.Errorf("wrong type for uint8 field")
+				return bytesRead, &cbg.DecodeError{Type: "EmbeddingStructTwo", Field: "U8", Offset: bytesRead - read, Expected: cbg.MajUnsignedInt, Found: maj, Err: cbg.ErrUnexpectedType}
 			}
 			if extra > math.MaxUint8 {
-				return bytesRead, fmt.Errorf("integer in input was too large for uint8 field")
+				return bytesRead, &cbg.DecodeError{Type: "EmbeddingStructTwo", Field: "U8", Offset: bytesRead - read, Expected: cbg.MajUnsignedInt, Found: maj, Err: cbg.ErrOverflow}
 			}
 			t.U8 = uint8(extra)
 			// t.Dog (string) (string)
@@ -1914,7 +1993,7 @@ func (t *EmbeddingStructTwo) UnmarshalCBOR(r io.Reader) (int, error) {
 			{
 				sval, read, err := cbg.ReadStringBuf(br, scratch)
 				if err != nil {
-					return bytesRead, err
+					return bytesRead, cbg.WrapDecodeError(err, "EmbeddingStructTwo", "Dog", bytesRead)
 				}
 				bytesRead += read
 
@@ -1926,7 +2005,7 @@ func (t *EmbeddingStructTwo) UnmarshalCBOR(r io.Reader) (int, error) {
 			{
 				sval, read, err := cbg.ReadStringBuf(br, scratch)
 				if err != nil {
-					return bytesRead, err
+					return bytesRead, cbg.WrapDecodeError(err, "EmbeddingStructTwo", "Foo", bytesRead)
 				}
 				bytesRead += read
 
@@ -1936,25 +2015,29 @@ func (t *EmbeddingStructTwo) UnmarshalCBOR(r io.Reader) (int, error) {
 		case "I16":
 			{
 				maj, extra, read, err := cbg.CborReadHeaderBuf(br, scratch)
-				var extraI int16
+				var extraI int64
 				if err != nil {
-					return bytesRead, err
+					return bytesRead, cbg.WrapDecodeError(err, "EmbeddingStructTwo", "I16", bytesRead)
 				}
 				bytesRead += read
 				switch maj {
 				case cbg.MajUnsignedInt:
-					extraI = int16(extra)
+					extraI = int64(extra)
 					if extraI < 0 {
-						return bytesRead, fmt.Errorf("int16 positive overflow")
+						return bytesRead, &cbg.DecodeError{Type: "EmbeddingStructTwo", Field: "I16", Offset: bytesRead - read, Expected: maj, Found: maj, Err: cbg.ErrOverflow}
 					}
 				case cbg.MajNegativeInt:
-					extraI = int16(extra)
+					extraI = int64(extra)
 					if extraI < 0 {
-						return bytesRead, fmt.Errorf("int16 negative oveflow")
+						return bytesRead, &cbg.DecodeError{Type: "EmbeddingStructTwo", Field: "I16", Offset: bytesRead - read, Expected: maj, Found: maj, Err: cbg.ErrOverflow}
 					}
 					extraI = -1 - extraI
 				default:
-					return bytesRead, fmt.Errorf("wrong type for int16 field: %d", maj)
+					return bytesRead, &cbg.DecodeError{Type: "EmbeddingStructTwo", Field: "I16", Offset: bytesRead - read, Expected: cbg.MajUnsignedInt, Found: maj, Err: cbg.ErrUnexpectedType}
+				}
+
+				if extraI > math.MaxInt16 || extraI < math.MinInt16 {
+					return bytesRead, &cbg.DecodeError{Type: "EmbeddingStructTwo", Field: "I16", Offset: bytesRead - read, Expected: maj, Found: maj, Err: cbg.ErrOverflow}
 				}
 
 				t.I16 = int16(extraI)
@@ -1963,25 +2046,29 @@ func (t *EmbeddingStructTwo) UnmarshalCBOR(r io.Reader) (int, error) {
 		case "I32":
 			{
 				maj, extra, read, err := cbg.CborReadHeaderBuf(br, scratch)
-				var extraI int32
+				var extraI int64
 				if err != nil {
-					return bytesRead, err
+					return bytesRead, cbg.WrapDecodeError(err, "EmbeddingStructTwo", "I32", bytesRead)
 				}
 				bytesRead += read
 				switch maj {
 				case cbg.MajUnsignedInt:
-					extraI = int32(extra)
+					extraI = int64(extra)
 					if extraI < 0 {
-						return bytesRead, fmt.Errorf("int32 positive overflow")
+						return bytesRead, &cbg.DecodeError{Type: "EmbeddingStructTwo", Field: "I32", Offset: bytesRead - read, Expected: maj, Found: maj, Err: cbg.ErrOverflow}
 					}
 				case cbg.MajNegativeInt:
-					extraI = int32(extra)
+					extraI = int64(extra)
 					if extraI < 0 {
-						return bytesRead, fmt.Errorf("int32 negative oveflow")
+						return bytesRead, &cbg.DecodeError{Type: "EmbeddingStructTwo", Field: "I32", Offset: bytesRead - read, Expected: maj, Found: maj, Err: cbg.ErrOverflow}
 					}
 					extraI = -1 - extraI
 				default:
-					return bytesRead, fmt.Errorf("wrong type for int32 field: %d", maj)
+					return bytesRead, &cbg.DecodeError{Type: "EmbeddingStructTwo", Field: "I32", Offset: bytesRead - read, Expected: cbg.MajUnsignedInt, Found: maj, Err: cbg.ErrUnexpectedType}
+				}
+
+				if extraI > math.MaxInt32 || extraI < math.MinInt32 {
+					return bytesRead, &cbg.DecodeError{Type: "EmbeddingStructTwo", Field: "I32", Offset: bytesRead - read, Expected: maj, Found: maj, Err: cbg.ErrOverflow}
 				}
 
 				t.I32 = int32(extraI)
@@ -1991,14 +2078,14 @@ func (t *EmbeddingStructTwo) UnmarshalCBOR(r io.Reader) (int, error) {
 
 			maj, extra, read, err = cbg.CborReadHeaderBuf(br, scratch)
 			if err != nil {
-				return bytesRead, err
+				return bytesRead, cbg.WrapDecodeError(err, "EmbeddingStructTwo", "U16", bytesRead)
 			}
 			bytesRead += read
 			if maj != cbg.MajUnsignedInt {
-				return bytesRead, fmt.Errorf("wrong type for uint16 field")
+				return bytesRead, &cbg.DecodeError{Type: "EmbeddingStructTwo", Field: "U16", Offset: bytesRead - read, Expected: cbg.MajUnsignedInt, Found: maj, Err: cbg.ErrUnexpectedType}
 			}
 			if extra > math.MaxUint16 {
-				return bytesRead, fmt.Errorf("integer in input was too large for uint16 field")
+				return bytesRead, &cbg.DecodeError{Type: "EmbeddingStructTwo", Field: "U16", Offset: bytesRead - read, Expected: cbg.MajUnsignedInt, Found: maj, Err: cbg.ErrOverflow}
 			}
 			t.U16 = uint16(extra)
 			// t.U32 (uint32) (uint32)
@@ -2006,14 +2093,14 @@ func (t *EmbeddingStructTwo) UnmarshalCBOR(r io.Reader) (int, error) {
 
 			maj, extra, read, err = cbg.CborReadHeaderBuf(br, scratch)
 			if err != nil {
-				return bytesRead, err
+				return bytesRead, cbg.WrapDecodeError(err, "EmbeddingStructTwo", "U32", bytesRead)
 			}
 			bytesRead += read
 			if maj != cbg.MajUnsignedInt {
-				return bytesRead, fmt.Errorf("wrong type for uint32 field")
+				return bytesRead, &cbg.DecodeError{Type: "EmbeddingStructTwo", Field: "U32", Offset: bytesRead - read, Expected: cbg.MajUnsignedInt, Found: maj, Err: cbg.ErrUnexpectedType}
 			}
 			if extra > math.MaxUint32 {
-				return bytesRead, fmt.Errorf("integer in input was too large for uint32 field")
+				return bytesRead, &cbg.DecodeError{Type: "EmbeddingStructTwo", Field: "U32", Offset: bytesRead - read, Expected: cbg.MajUnsignedInt, Found: maj, Err: cbg.ErrOverflow}
 			}
 			t.U32 = uint32(extra)
 			// t.Test ([][]uint8) (slice)
@@ -2021,16 +2108,19 @@ func (t *EmbeddingStructTwo) UnmarshalCBOR(r io.Reader) (int, error) {
 
 			maj, extra, read, err = cbg.CborReadHeaderBuf(br, scratch)
 			if err != nil {
-				return bytesRead, err
+				return bytesRead, cbg.WrapDecodeError(err, "EmbeddingStructTwo", "Test", bytesRead)
 			}
 			bytesRead += read
 
 			if extra > cbg.MaxLength {
-				return bytesRead, fmt.Errorf("t.Test: array too large (%d)", extra)
+				return bytesRead, &cbg.DecodeError{Type: "EmbeddingStructTwo", Field: "Test", Offset: bytesRead - read, Expected: cbg.MajArray, Found: maj, Err: cbg.ErrMaxLength}
 			}
 
 			if maj != cbg.MajArray {
-				return bytesRead, fmt.Errorf("expected cbor array")
+				return bytesRead, &cbg.DecodeError{Type: "EmbeddingStructTwo", Field: "Test", Offset: bytesRead - read, Expected: cbg.MajArray, Found: maj, Err: cbg.ErrUnexpectedType}
+			}
+			if err := limits.AddItems(extra); err != nil {
+				return bytesRead, &cbg.DecodeError{Type: "EmbeddingStructTwo", Field: "Test", Offset: bytesRead - read, Expected: cbg.MajArray, Found: maj, Err: err}
 			}
 
 			if extra > 0 {
@@ -2045,15 +2135,18 @@ func (t *EmbeddingStructTwo) UnmarshalCBOR(r io.Reader) (int, error) {
 
 					maj, extra, read, err = cbg.CborReadHeaderBuf(br, scratch)
 					if err != nil {
-						return bytesRead, err
+						return bytesRead, cbg.WrapDecodeError(err, "EmbeddingStructTwo", fmt.Sprintf("Test[%d]", i), bytesRead)
 					}
 					bytesRead += read
 
 					if extra > cbg.ByteArrayMaxLen {
-						return bytesRead, fmt.Errorf("t.Test[i]: byte array too large (%d)", extra)
+						return bytesRead, &cbg.DecodeError{Type: "EmbeddingStructTwo", Field: fmt.Sprintf("Test[%d]", i), Offset: bytesRead - read, Expected: cbg.MajByteString, Found: maj, Err: cbg.ErrMaxLength}
 					}
 					if maj != cbg.MajByteString {
-						return bytesRead, fmt.Errorf("expected byte array")
+						return bytesRead, &cbg.DecodeError{Type: "EmbeddingStructTwo", Field: fmt.Sprintf("Test[%d]", i), Offset: bytesRead - read, Expected: cbg.MajByteString, Found: maj, Err: cbg.ErrUnexpectedType}
+					}
+					if err := limits.AddBytes(extra); err != nil {
+						return bytesRead, &cbg.DecodeError{Type: "EmbeddingStructTwo", Field: fmt.Sprintf("Test[%d]", i), Offset: bytesRead - read, Expected: cbg.MajByteString, Found: maj, Err: err}
 					}
 
 					if extra > 0 {
@@ -2061,7 +2154,7 @@ func (t *EmbeddingStructTwo) UnmarshalCBOR(r io.Reader) (int, error) {
 					}
 
 					if read, err := io.ReadFull(br, t.Test[i][:]); err != nil {
-						return bytesRead, err
+						return bytesRead, cbg.WrapDecodeError(err, "EmbeddingStructTwo", fmt.Sprintf("Test[%d]", i), bytesRead)
 					} else {
 						bytesRead += read
 					}
@@ -2075,21 +2168,21 @@ func (t *EmbeddingStructTwo) UnmarshalCBOR(r io.Reader) (int, error) {
 
 				b, err := br.ReadByte()
 				if err != nil {
-					return bytesRead, err
+					return bytesRead, cbg.WrapDecodeError(err, "EmbeddingStructTwo", "Pizza", bytesRead)
 				}
 				bytesRead++
 				if b != cbg.CborNull[0] {
 					if err := br.UnreadByte(); err != nil {
-						return bytesRead, err
+						return bytesRead, cbg.WrapDecodeError(err, "EmbeddingStructTwo", "Pizza", bytesRead)
 					}
 					bytesRead--
 					maj, extra, read, err = cbg.CborReadHeaderBuf(br, scratch)
 					if err != nil {
-						return bytesRead, err
+						return bytesRead, cbg.WrapDecodeError(err, "EmbeddingStructTwo", "Pizza", bytesRead)
 					}
 					bytesRead += read
 					if maj != cbg.MajUnsignedInt {
-						return bytesRead, fmt.Errorf("wrong type for uint64 field")
+						return bytesRead, &cbg.DecodeError{Type: "EmbeddingStructTwo", Field: "Pizza", Offset: bytesRead - read, Expected: cbg.MajUnsignedInt, Found: maj, Err: cbg.ErrUnexpectedType}
 					}
 					typed := uint64(extra)
 					t.Pizza = &typed
@@ -2103,17 +2196,17 @@ func (t *EmbeddingStructTwo) UnmarshalCBOR(r io.Reader) (int, error) {
 
 				b, err := br.ReadByte()
 				if err != nil {
-					return bytesRead, err
+					return bytesRead, cbg.WrapDecodeError(err, "EmbeddingStructTwo", "Stuff", bytesRead)
 				}
 				bytesRead++
 				if b != cbg.CborNull[0] {
 					if err := br.UnreadByte(); err != nil {
-						return bytesRead, err
+						return bytesRead, cbg.WrapDecodeError(err, "EmbeddingStructTwo", "Stuff", bytesRead)
 					}
 					bytesRead--
 					t.Stuff = new(testing.SimpleTypeTwo)
 					if read, err := t.Stuff.UnmarshalCBOR(br); err != nil {
-						return bytesRead, xerrors.Errorf("unmarshaling t.Stuff pointer: %w", err)
+						return bytesRead, cbg.WrapDecodeError(err, "EmbeddingStructTwo", "Stuff", bytesRead)
 					} else {
 						bytesRead += read
 					}
@@ -2127,11 +2220,11 @@ func (t *EmbeddingStructTwo) UnmarshalCBOR(r io.Reader) (int, error) {
 
 				maj, extra, read, err = cbg.CborReadHeaderBuf(br, scratch)
 				if err != nil {
-					return bytesRead, err
+					return bytesRead, cbg.WrapDecodeError(err, "EmbeddingStructTwo", "Value", bytesRead)
 				}
 				bytesRead += read
 				if maj != cbg.MajUnsignedInt {
-					return bytesRead, fmt.Errorf("wrong type for uint64 field")
+					return bytesRead, &cbg.DecodeError{Type: "EmbeddingStructTwo", Field: "Value", Offset: bytesRead - read, Expected: cbg.MajUnsignedInt, Found: maj, Err: cbg.ErrUnexpectedType}
 				}
 				t.Value = uint64(extra)
 
@@ -2141,15 +2234,18 @@ func (t *EmbeddingStructTwo) UnmarshalCBOR(r io.Reader) (int, error) {
 
 			maj, extra, read, err = cbg.CborReadHeaderBuf(br, scratch)
 			if err != nil {
-				return bytesRead, err
+				return bytesRead, cbg.WrapDecodeError(err, "EmbeddingStructTwo", "Binary", bytesRead)
 			}
 			bytesRead += read
 
 			if extra > cbg.ByteArrayMaxLen {
-				return bytesRead, fmt.Errorf("t.Binary: byte array too large (%d)", extra)
+				return bytesRead, &cbg.DecodeError{Type: "EmbeddingStructTwo", Field: "Binary", Offset: bytesRead - read, Expected: cbg.MajByteString, Found: maj, Err: cbg.ErrMaxLength}
 			}
 			if maj != cbg.MajByteString {
-				return bytesRead, fmt.Errorf("expected byte array")
+				return bytesRead, &cbg.DecodeError{Type: "EmbeddingStructTwo", Field: "Binary", Offset: bytesRead - read, Expected: cbg.MajByteString, Found: maj, Err: cbg.ErrUnexpectedType}
+			}
+			if err := limits.AddBytes(extra); err != nil {
+				return bytesRead, &cbg.DecodeError{Type: "EmbeddingStructTwo", Field: "Binary", Offset: bytesRead - read, Expected: cbg.MajByteString, Found: maj, Err: err}
 			}
 
 			if extra > 0 {
@@ -2157,7 +2253,7 @@ func (t *EmbeddingStructTwo) UnmarshalCBOR(r io.Reader) (int, error) {
 			}
 
 			if read, err := io.ReadFull(br, t.Binary[:]); err != nil {
-				return bytesRead, err
+				return bytesRead, cbg.WrapDecodeError(err, "EmbeddingStructTwo", "Binary", bytesRead)
 			} else {
 				bytesRead += read
 			}
@@ -2166,16 +2262,19 @@ func (t *EmbeddingStructTwo) UnmarshalCBOR(r io.Reader) (int, error) {
 
 			maj, extra, read, err = cbg.CborReadHeaderBuf(br, scratch)
 			if err != nil {
-				return bytesRead, err
+				return bytesRead, cbg.WrapDecodeError(err, "EmbeddingStructTwo", "Others", bytesRead)
 			}
 			bytesRead += read
 
 			if extra > cbg.MaxLength {
-				return bytesRead, fmt.Errorf("t.Others: array too large (%d)", extra)
+				return bytesRead, &cbg.DecodeError{Type: "EmbeddingStructTwo", Field: "Others", Offset: bytesRead - read, Expected: cbg.MajArray, Found: maj, Err: cbg.ErrMaxLength}
 			}
 
 			if maj != cbg.MajArray {
-				return bytesRead, fmt.Errorf("expected cbor array")
+				return bytesRead, &cbg.DecodeError{Type: "EmbeddingStructTwo", Field: "Others", Offset: bytesRead - read, Expected: cbg.MajArray, Found: maj, Err: cbg.ErrUnexpectedType}
+			}
+			if err := limits.AddItems(extra); err != nil {
+				return bytesRead, &cbg.DecodeError{Type: "EmbeddingStructTwo", Field: "Others", Offset: bytesRead - read, Expected: cbg.MajArray, Found: maj, Err: err}
 			}
 
 			if extra > 0 {
@@ -2186,12 +2285,12 @@ func (t *EmbeddingStructTwo) UnmarshalCBOR(r io.Reader) (int, error) {
 
 				maj, val, read, err := cbg.CborReadHeaderBuf(br, scratch)
 				if err != nil {
-					return bytesRead, xerrors.Errorf("failed to read uint64 for t.Others slice: %w", err)
+					return bytesRead, cbg.WrapDecodeError(err, "EmbeddingStructTwo", fmt.Sprintf("Others[%d]", i), bytesRead)
 				}
 				bytesRead += read
 
 				if maj != cbg.MajUnsignedInt {
-					return bytesRead, xerrors.Errorf("value read for array t.Others was not a uint, instead got %d", maj)
+					return bytesRead, &cbg.DecodeError{Type: "EmbeddingStructTwo", Field: fmt.Sprintf("Others[%d]", i), Offset: bytesRead - read, Expected: cbg.MajUnsignedInt, Found: maj, Err: cbg.ErrUnexpectedType}
 				}
 
 				t.Others[i] = uint64(val)
@@ -2203,23 +2302,23 @@ func (t *EmbeddingStructTwo) UnmarshalCBOR(r io.Reader) (int, error) {
 				maj, extra, read, err := cbg.CborReadHeaderBuf(br, scratch)
 				var extraI int64
 				if err != nil {
-					return bytesRead, err
+					return bytesRead, cbg.WrapDecodeError(err, "EmbeddingStructTwo", "Signed", bytesRead)
 				}
 				bytesRead += read
 				switch maj {
 				case cbg.MajUnsignedInt:
 					extraI = int64(extra)
 					if extraI < 0 {
-						return bytesRead, fmt.Errorf("int64 positive overflow")
+						return bytesRead, &cbg.DecodeError{Type: "EmbeddingStructTwo", Field: "Signed", Offset: bytesRead - read, Expected: maj, Found: maj, Err: cbg.ErrOverflow}
 					}
 				case cbg.MajNegativeInt:
 					extraI = int64(extra)
 					if extraI < 0 {
-						return bytesRead, fmt.Errorf("int64 negative oveflow")
+						return bytesRead, &cbg.DecodeError{Type: "EmbeddingStructTwo", Field: "Signed", Offset: bytesRead - read, Expected: maj, Found: maj, Err: cbg.ErrOverflow}
 					}
 					extraI = -1 - extraI
 				default:
-					return bytesRead, fmt.Errorf("wrong type for int64 field: %d", maj)
+					return bytesRead, &cbg.DecodeError{Type: "EmbeddingStructTwo", Field: "Signed", Offset: bytesRead - read, Expected: cbg.MajUnsignedInt, Found: maj, Err: cbg.ErrUnexpectedType}
 				}
 
 				t.Signed = int64(extraI)
@@ -2230,7 +2329,7 @@ func (t *EmbeddingStructTwo) UnmarshalCBOR(r io.Reader) (int, error) {
 			{
 				sval, read, err := cbg.ReadStringBuf(br, scratch)
 				if err != nil {
-					return bytesRead, err
+					return bytesRead, cbg.WrapDecodeError(err, "EmbeddingStructTwo", "NString", bytesRead)
 				}
 				bytesRead += read
 
@@ -2241,16 +2340,19 @@ func (t *EmbeddingStructTwo) UnmarshalCBOR(r io.Reader) (int, error) {
 
 			maj, extra, read, err = cbg.CborReadHeaderBuf(br, scratch)
 			if err != nil {
-				return bytesRead, err
+				return bytesRead, cbg.WrapDecodeError(err, "EmbeddingStructTwo", "Numbers", bytesRead)
 			}
 			bytesRead += read
 
 			if extra > cbg.MaxLength {
-				return bytesRead, fmt.Errorf("t.Numbers: array too large (%d)", extra)
+				return bytesRead, &cbg.DecodeError{Type: "EmbeddingStructTwo", Field: "Numbers", Offset: bytesRead - read, Expected: cbg.MajArray, Found: maj, Err: cbg.ErrMaxLength}
 			}
 
 			if maj != cbg.MajArray {
-				return bytesRead, fmt.Errorf("expected cbor array")
+				return bytesRead, &cbg.DecodeError{Type: "EmbeddingStructTwo", Field: "Numbers", Offset: bytesRead - read, Expected: cbg.MajArray, Found: maj, Err: cbg.ErrUnexpectedType}
+			}
+			if err := limits.AddItems(extra); err != nil {
+				return bytesRead, &cbg.DecodeError{Type: "EmbeddingStructTwo", Field: "Numbers", Offset: bytesRead - read, Expected: cbg.MajArray, Found: maj, Err: err}
 			}
 
 			if extra > 0 {
@@ -2261,12 +2363,12 @@ func (t *EmbeddingStructTwo) UnmarshalCBOR(r io.Reader) (int, error) {
 
 				maj, val, read, err := cbg.CborReadHeaderBuf(br, scratch)
 				if err != nil {
-					return bytesRead, xerrors.Errorf("failed to read uint64 for t.Numbers slice: %w", err)
+					return bytesRead, cbg.WrapDecodeError(err, "EmbeddingStructTwo", fmt.Sprintf("Numbers[%d]", i), bytesRead)
 				}
 				bytesRead += read
 
 				if maj != cbg.MajUnsignedInt {
-					return bytesRead, xerrors.Errorf("value read for array t.Numbers was not a uint, instead got %d", maj)
+					return bytesRead, &cbg.DecodeError{Type: "EmbeddingStructTwo", Field: fmt.Sprintf("Numbers[%d]", i), Offset: bytesRead - read, Expected: cbg.MajUnsignedInt, Found: maj, Err: cbg.ErrUnexpectedType}
 				}
 
 				t.Numbers[i] = testing.NamedNumber(val)
@@ -2277,20 +2379,23 @@ func (t *EmbeddingStructTwo) UnmarshalCBOR(r io.Reader) (int, error) {
 
 			maj, extra, read, err = cbg.CborReadHeaderBuf(br, scratch)
 			if err != nil {
-				return bytesRead, err
+				return bytesRead, cbg.WrapDecodeError(err, "EmbeddingStructTwo", "Arrrrrghay", bytesRead)
 			}
 			bytesRead += read
 
 			if extra > cbg.MaxLength {
-				return bytesRead, fmt.Errorf("t.Arrrrrghay: array too large (%d)", extra)
+				return bytesRead, &cbg.DecodeError{Type: "EmbeddingStructTwo", Field: "Arrrrrghay", Offset: bytesRead - read, Expected: cbg.MajArray, Found: maj, Err: cbg.ErrMaxLength}
 			}
 
 			if maj != cbg.MajArray {
-				return bytesRead, fmt.Errorf("expected cbor array")
+				return bytesRead, &cbg.DecodeError{Type: "EmbeddingStructTwo", Field: "Arrrrrghay", Offset: bytesRead - read, Expected: cbg.MajArray, Found: maj, Err: cbg.ErrUnexpectedType}
+			}
+			if err := limits.AddItems(extra); err != nil {
+				return bytesRead, &cbg.DecodeError{Type: "EmbeddingStructTwo", Field: "Arrrrrghay", Offset: bytesRead - read, Expected: cbg.MajArray, Found: maj, Err: err}
 			}
 
 			if extra != 3 {
-				return bytesRead, fmt.Errorf("expected array to have 3 elements")
+				return bytesRead, &cbg.DecodeError{Type: "EmbeddingStructTwo", Field: "Arrrrrghay", Offset: bytesRead - read, Expected: cbg.MajArray, Found: maj, Err: fmt.Errorf("expected 3 elements, got %d", extra)}
 			}
 
 			t.Arrrrrghay = [3]testing.SimpleTypeOne{}
@@ -2299,7 +2404,7 @@ func (t *EmbeddingStructTwo) UnmarshalCBOR(r io.Reader) (int, error) {
 
 				var v testing.SimpleTypeOne
 				if read, err := v.UnmarshalCBOR(br); err != nil {
-					return bytesRead, err
+					return bytesRead, cbg.WrapDecodeError(err, "EmbeddingStructTwo", fmt.Sprintf("Arrrrrghay[%d]", i), bytesRead)
 				} else {
 					bytesRead += read
 				}
@@ -2314,21 +2419,21 @@ func (t *EmbeddingStructTwo) UnmarshalCBOR(r io.Reader) (int, error) {
 
 				b, err := br.ReadByte()
 				if err != nil {
-					return bytesRead, err
+					return bytesRead, cbg.WrapDecodeError(err, "EmbeddingStructTwo", "PointyPizza", bytesRead)
 				}
 				bytesRead++
 				if b != cbg.CborNull[0] {
 					if err := br.UnreadByte(); err != nil {
-						return bytesRead, err
+						return bytesRead, cbg.WrapDecodeError(err, "EmbeddingStructTwo", "PointyPizza", bytesRead)
 					}
 					bytesRead--
 					maj, extra, read, err = cbg.CborReadHeaderBuf(br, scratch)
 					if err != nil {
-						return bytesRead, err
+						return bytesRead, cbg.WrapDecodeError(err, "EmbeddingStructTwo", "PointyPizza", bytesRead)
 					}
 					bytesRead += read
 					if maj != cbg.MajUnsignedInt {
-						return bytesRead, fmt.Errorf("wrong type for uint64 field")
+						return bytesRead, &cbg.DecodeError{Type: "EmbeddingStructTwo", Field: "PointyPizza", Offset: bytesRead - read, Expected: cbg.MajUnsignedInt, Found: maj, Err: cbg.ErrUnexpectedType}
 					}
 					typed := testing.NamedNumber(extra)
 					t.PointyPizza = &typed
@@ -2340,16 +2445,19 @@ func (t *EmbeddingStructTwo) UnmarshalCBOR(r io.Reader) (int, error) {
 
 			maj, extra, read, err = cbg.CborReadHeaderBuf(br, scratch)
 			if err != nil {
-				return bytesRead, err
+				return bytesRead, cbg.WrapDecodeError(err, "EmbeddingStructTwo", "SignedOthers", bytesRead)
 			}
 			bytesRead += read
 
 			if extra > cbg.MaxLength {
-				return bytesRead, fmt.Errorf("t.SignedOthers: array too large (%d)", extra)
+				return bytesRead, &cbg.DecodeError{Type: "EmbeddingStructTwo", Field: "SignedOthers", Offset: bytesRead - read, Expected: cbg.MajArray, Found: maj, Err: cbg.ErrMaxLength}
 			}
 
 			if maj != cbg.MajArray {
-				return bytesRead, fmt.Errorf("expected cbor array")
+				return bytesRead, &cbg.DecodeError{Type: "EmbeddingStructTwo", Field: "SignedOthers", Offset: bytesRead - read, Expected: cbg.MajArray, Found: maj, Err: cbg.ErrUnexpectedType}
+			}
+			if err := limits.AddItems(extra); err != nil {
+				return bytesRead, &cbg.DecodeError{Type: "EmbeddingStructTwo", Field: "SignedOthers", Offset: bytesRead - read, Expected: cbg.MajArray, Found: maj, Err: err}
 			}
 
 			if extra > 0 {
@@ -2361,23 +2469,23 @@ func (t *EmbeddingStructTwo) UnmarshalCBOR(r io.Reader) (int, error) {
 					maj, extra, read, err := cbg.CborReadHeaderBuf(br, scratch)
 					var extraI int64
 					if err != nil {
-						return bytesRead, err
+						return bytesRead, cbg.WrapDecodeError(err, "EmbeddingStructTwo", fmt.Sprintf("SignedOthers[%d]", i), bytesRead)
 					}
 					bytesRead += read
 					switch maj {
 					case cbg.MajUnsignedInt:
 						extraI = int64(extra)
 						if extraI < 0 {
-							return bytesRead, fmt.Errorf("int64 positive overflow")
+							return bytesRead, &cbg.DecodeError{Type: "EmbeddingStructTwo", Field: fmt.Sprintf("SignedOthers[%d]", i), Offset: bytesRead - read, Expected: maj, Found: maj, Err: cbg.ErrOverflow}
 						}
 					case cbg.MajNegativeInt:
 						extraI = int64(extra)
 						if extraI < 0 {
-							return bytesRead, fmt.Errorf("int64 negative oveflow")
+							return bytesRead, &cbg.DecodeError{Type: "EmbeddingStructTwo", Field: fmt.Sprintf("SignedOthers[%d]", i), Offset: bytesRead - read, Expected: maj, Found: maj, Err: cbg.ErrOverflow}
 						}
 						extraI = -1 - extraI
 					default:
-						return bytesRead, fmt.Errorf("wrong type for int64 field: %d", maj)
+						return bytesRead, &cbg.DecodeError{Type: "EmbeddingStructTwo", Field: fmt.Sprintf("SignedOthers[%d]", i), Offset: bytesRead - read, Expected: cbg.MajUnsignedInt, Found: maj, Err: cbg.ErrUnexpectedType}
 					}
 
 					t.SignedOthers[i] = int64(extraI)
@@ -2386,7 +2494,9 @@ func (t *EmbeddingStructTwo) UnmarshalCBOR(r io.Reader) (int, error) {
 
 		default:
 			// Field doesn't exist on this type, so ignore it
-			if read, err := cbg.ScanForLinks(r, func(cid.Cid) {}); err == nil {
+			if read, err := cbg.ScanForLinks(br, func(cid.Cid) {}); err != nil {
+				return bytesRead, cbg.WrapDecodeError(err, "EmbeddingStructTwo", "", bytesRead)
+			} else {
 				bytesRead += read
 			}
 		}
@@ -2395,6 +2505,30 @@ func (t *EmbeddingStructTwo) UnmarshalCBOR(r io.Reader) (int, error) {
 	return bytesRead, nil
 }
 
+func (t *EmbeddingStructTwo) MarshalDAGJSON(w io.Writer) error {
+	return cbg.MarshalDAGJSON(w, t)
+}
+
+func (t *EmbeddingStructTwo) UnmarshalDAGJSON(r io.Reader) error {
+	return cbg.UnmarshalDAGJSON(r, t)
+}
+
+func (t *EmbeddingStructTwo) Links() []cid.Cid {
+	var links []cid.Cid
+	_ = t.ForEachLink(func(_ string, c cid.Cid) error {
+		links = append(links, c)
+		return nil
+	})
+	return links
+}
+
+func (t *EmbeddingStructTwo) ForEachLink(cb func(path string, c cid.Cid) error) error {
+	if t == nil {
+		return nil
+	}
+	return nil
+}
+
 func (t *EmbeddingStructThree) InitNilEmbeddedStruct() {
 	if t != nil {
 		if t.EmbeddingStructTwo == nil {
@@ -3024,6 +3158,11 @@ func (t *EmbeddingStructThree) UnmarshalCBOR(r io.Reader) (int, error) {
 
 	br := cbg.GetPeeker(r)
 	scratch := make([]byte, 8)
+	limits := cbg.GetDecodeLimits(br)
+	if err := limits.Enter(); err != nil {
+		return bytesRead, &cbg.DecodeError{Type: "EmbeddingStructThree", Err: err}
+	}
+	defer limits.Leave()
 
 	maj, extra, read, err := cbg.CborReadHeaderBuf(br, scratch)
 	if err != nil {
@@ -3031,11 +3170,14 @@ func (t *EmbeddingStructThree) UnmarshalCBOR(r io.Reader) (int, error) {
 	}
 	bytesRead += read
 	if maj != cbg.MajMap {
-		return bytesRead, fmt.Errorf("cbor input should be of type map")
+		return bytesRead, &cbg.DecodeError{Type: "EmbeddingStructThree", Expected: cbg.MajMap, Found: maj, Err: cbg.ErrUnexpectedType}
 	}
 
 	if extra > cbg.MaxLength {
-		return bytesRead, fmt.Errorf("EmbeddingStructThree: map struct too large (%d)", extra)
+		return bytesRead, &cbg.DecodeError{Type: "EmbeddingStructThree", Expected: cbg.MajMap, Found: maj, Err: cbg.ErrMaxLength}
+	}
+	if err := limits.AddItems(extra); err != nil {
+		return bytesRead, &cbg.DecodeError{Type: "EmbeddingStructThree", Expected: cbg.MajMap, Found: maj, Err: err}
 	}
 
 	var name string
@@ -3046,7 +3188,7 @@ func (t *EmbeddingStructThree) UnmarshalCBOR(r io.Reader) (int, error) {
 		{
 			sval, read, err := cbg.ReadStringBuf(br, scratch)
 			if err != nil {
-				return bytesRead, err
+				return bytesRead, cbg.WrapDecodeError(err, "EmbeddingStructThree", "", bytesRead)
 			}
 			bytesRead += read
 
@@ -3058,25 +3200,29 @@ func (t *EmbeddingStructThree) UnmarshalCBOR(r io.Reader) (int, error) {
 		case "I8":
 			{
 				maj, extra, read, err := cbg.CborReadHeaderBuf(br, scratch)
-				var extraI int8
+				var extraI int64
 				if err != nil {
-					return bytesRead, err
+					return bytesRead, cbg.WrapDecodeError(err, "EmbeddingStructThree", "I8", bytesRead)
 				}
 				bytesRead += read
 				switch maj {
 				case cbg.MajUnsignedInt:
-					extraI = int8(extra)
+					extraI = int64(extra)
 					if extraI < 0 {
-						return bytesRead, fmt.Errorf("int8 positive overflow")
+						return bytesRead, &cbg.DecodeError{Type: "EmbeddingStructThree", Field: "I8", Offset: bytesRead - read, Expected: maj, Found: maj, Err: cbg.ErrOverflow}
 					}
 				case cbg.MajNegativeInt:
-					extraI = int8(extra)
+					extraI = int64(extra)
 					if extraI < 0 {
-						return bytesRead, fmt.Errorf("int8 negative oveflow")
+						return bytesRead, &cbg.DecodeError{Type: "EmbeddingStructThree", Field: "I8", Offset: bytesRead - read, Expected: maj, Found: maj, Err: cbg.ErrOverflow}
 					}
 					extraI = -1 - extraI
 				default:
-					return bytesRead, fmt.Errorf("wrong type for int8 field: %d", maj)
+					return bytesRead, &cbg.DecodeError{Type: "EmbeddingStructThree", Field: "I8", Offset: bytesRead - read, Expected: cbg.MajUnsignedInt, Found: maj, Err: cbg.ErrUnexpectedType}
+				}
+
+				if extraI > math.MaxInt8 || extraI < math.MinInt8 {
+					return bytesRead, &cbg.DecodeError{Type: "EmbeddingStructThree", Field: "I8", Offset: bytesRead - read, Expected: maj, Found: maj, Err: cbg.ErrOverflow}
 				}
 
 				t.I8 = int8(extraI)
@@ -3086,14 +3232,14 @@ func (t *EmbeddingStructThree) UnmarshalCBOR(r io.Reader) (int, error) {
 
 			maj, extra, read, err = cbg.CborReadHeaderBuf(br, scratch)
 			if err != nil {
-				return bytesRead, err
+				return bytesRead, cbg.WrapDecodeError(err, "EmbeddingStructThree", "U8", bytesRead)
 			}
 			bytesRead += read
 			if maj != cbg.MajUnsignedInt {
-				return bytesRead, fmt.Errorf("wrong type for uint8 field")
+				return bytesRead, &cbg.DecodeError{Type: "EmbeddingStructThree", Field: "U8", Offset: bytesRead - read, Expected: cbg.MajUnsignedInt, Found: maj, Err: cbg.ErrUnexpectedType}
 			}
 			if extra > math.MaxUint8 {
-				return bytesRead, fmt.Errorf("integer in input was too large for uint8 field")
+				return bytesRead, &cbg.DecodeError{Type: "EmbeddingStructThree", Field: "U8", Offset: bytesRead - read, Expected: cbg.MajUnsignedInt, Found: maj, Err: cbg.ErrOverflow}
 			}
 			t.U8 = uint8(extra)
 			// t.Dog (string) (string)
@@ -3102,7 +3248,7 @@ func (t *EmbeddingStructThree) UnmarshalCBOR(r io.Reader) (int, error) {
 			{
 				sval, read, err := cbg.ReadStringBuf(br, scratch)
 				if err != nil {
-					return bytesRead, err
+					return bytesRead, cbg.WrapDecodeError(err, "EmbeddingStructThree", "Dog", bytesRead)
 				}
 				bytesRead += read
 
@@ -3114,7 +3260,7 @@ func (t *EmbeddingStructThree) UnmarshalCBOR(r io.Reader) (int, error) {
 			{
 				sval, read, err := cbg.ReadStringBuf(br, scratch)
 				if err != nil {
-					return bytesRead, err
+					return bytesRead, cbg.WrapDecodeError(err, "EmbeddingStructThree", "Foo", bytesRead)
 				}
 				bytesRead += read
 
@@ -3124,25 +3270,29 @@ func (t *EmbeddingStructThree) UnmarshalCBOR(r io.Reader) (int, error) {
 		case "I16":
 			{
 				maj, extra, read, err := cbg.CborReadHeaderBuf(br, scratch)
-				var extraI int16
+				var extraI int64
 				if err != nil {
-					return bytesRead, err
+					return bytesRead, cbg.WrapDecodeError(err, "EmbeddingStructThree", "I16", bytesRead)
 				}
 				bytesRead += read
 				switch maj {
 				case cbg.MajUnsignedInt:
-					extraI = int16(extra)
+					extraI = int64(extra)
 					if extraI < 0 {
-						return bytesRead, fmt.Errorf("int16 positive overflow")
+						return bytesRead, &cbg.DecodeError{Type: "EmbeddingStructThree", Field: "I16", Offset: bytesRead - read, Expected: maj, Found: maj, Err: cbg.ErrOverflow}
 					}
 				case cbg.MajNegativeInt:
-					extraI = int16(extra)
+					extraI = int64(extra)
 					if extraI < 0 {
-						return bytesRead, fmt.Errorf("int16 negative oveflow")
+						return bytesRead, &cbg.DecodeError{Type: "EmbeddingStructThree", Field: "I16", Offset: bytesRead - read, Expected: maj, Found: maj, Err: cbg.ErrOverflow}
 					}
 					extraI = -1 - extraI
 				default:
-					return bytesRead, fmt.Errorf("wrong type for int16 field: %d", maj)
+					return bytesRead, &cbg.DecodeError{Type: "EmbeddingStructThree", Field: "I16", Offset: bytesRead - read, Expected: cbg.MajUnsignedInt, Found: maj, Err: cbg.ErrUnexpectedType}
+				}
+
+				if extraI > math.MaxInt16 || extraI < math.MinInt16 {
+					return bytesRead, &cbg.DecodeError{Type: "EmbeddingStructThree", Field: "I16", Offset: bytesRead - read, Expected: maj, Found: maj, Err: cbg.ErrOverflow}
 				}
 
 				t.I16 = int16(extraI)
@@ -3151,25 +3301,29 @@ func (t *EmbeddingStructThree) UnmarshalCBOR(r io.Reader) (int, error) {
 		case "I32":
 			{
 				maj, extra, read, err := cbg.CborReadHeaderBuf(br, scratch)
-				var extraI int32
+				var extraI int64
 				if err != nil {
-					return bytesRead, err
+					return bytesRead, cbg.WrapDecodeError(err, "EmbeddingStructThree", "I32", bytesRead)
 				}
 				bytesRead += read
 				switch maj {
 				case cbg.MajUnsignedInt:
-					extraI = int32(extra)
+					extraI = int64(extra)
 					if extraI < 0 {
-						return bytesRead, fmt.Errorf("int32 positive overflow")
+						return bytesRead, &cbg.DecodeError{Type: "EmbeddingStructThree", Field: "I32", Offset: bytesRead - read, Expected: maj, Found: maj, Err: cbg.ErrOverflow}
 					}
 				case cbg.MajNegativeInt:
-					extraI = int32(extra)
+					extraI = int64(extra)
 					if extraI < 0 {
-						return bytesRead, fmt.Errorf("int32 negative oveflow")
+						return bytesRead, &cbg.DecodeError{Type: "EmbeddingStructThree", Field: "I32", Offset: bytesRead - read, Expected: maj, Found: maj, Err: cbg.ErrOverflow}
 					}
 					extraI = -1 - extraI
 				default:
-					return bytesRead, fmt.Errorf("wrong type for int32 field: %d", maj)
+					return bytesRead, &cbg.DecodeError{Type: "EmbeddingStructThree", Field: "I32", Offset: bytesRead - read, Expected: cbg.MajUnsignedInt, Found: maj, Err: cbg.ErrUnexpectedType}
+				}
+
+				if extraI > math.MaxInt32 || extraI < math.MinInt32 {
+					return bytesRead, &cbg.DecodeError{Type: "EmbeddingStructThree", Field: "I32", Offset: bytesRead - read, Expected: maj, Found: maj, Err: cbg.ErrOverflow}
 				}
 
 				t.I32 = int32(extraI)
@@ -3179,14 +3333,14 @@ func (t *EmbeddingStructThree) UnmarshalCBOR(r io.Reader) (int, error) {
 
 			maj, extra, read, err = cbg.CborReadHeaderBuf(br, scratch)
 			if err != nil {
-				return bytesRead, err
+				return bytesRead, cbg.WrapDecodeError(err, "EmbeddingStructThree", "U16", bytesRead)
 			}
 			bytesRead += read
 			if maj != cbg.MajUnsignedInt {
-				return bytesRead, fmt.Errorf("wrong type for uint16 field")
+				return bytesRead, &cbg.DecodeError{Type: "EmbeddingStructThree", Field: "U16", Offset: bytesRead - read, Expected: cbg.MajUnsignedInt, Found: maj, Err: cbg.ErrUnexpectedType}
 			}
 			if extra > math.MaxUint16 {
-				return bytesRead, fmt.Errorf("integer in input was too large for uint16 field")
+				return bytesRead, &cbg.DecodeError{Type: "EmbeddingStructThree", Field: "U16", Offset: bytesRead - read, Expected: cbg.MajUnsignedInt, Found: maj, Err: cbg.ErrOverflow}
 			}
 			t.U16 = uint16(extra)
 			// t.U32 (uint32) (uint32)
@@ -3194,14 +3348,14 @@ func (t *EmbeddingStructThree) UnmarshalCBOR(r io.Reader) (int, error) {
 
 			maj, extra, read, err = cbg.CborReadHeaderBuf(br, scratch)
 			if err != nil {
-				return bytesRead, err
+				return bytesRead, cbg.WrapDecodeError(err, "EmbeddingStructThree", "U32", bytesRead)
 			}
 			bytesRead += read
 			if maj != cbg.MajUnsignedInt {
-				return bytesRead, fmt.Errorf("wrong type for uint32 field")
+				return bytesRead, &cbg.DecodeError{Type: "EmbeddingStructThree", Field: "U32", Offset: bytesRead - read, Expected: cbg.MajUnsignedInt, Found: maj, Err: cbg.ErrUnexpectedType}
 			}
 			if extra > math.MaxUint32 {
-				return bytesRead, fmt.Errorf("integer in input was too large for uint32 field")
+				return bytesRead, &cbg.DecodeError{Type: "EmbeddingStructThree", Field: "U32", Offset: bytesRead - read, Expected: cbg.MajUnsignedInt, Found: maj, Err: cbg.ErrOverflow}
 			}
 			t.U32 = uint32(extra)
 			// t.Test ([][]uint8) (slice)
@@ -3209,16 +3363,19 @@ func (t *EmbeddingStructThree) UnmarshalCBOR(r io.Reader) (int, error) {
 
 			maj, extra, read, err = cbg.CborReadHeaderBuf(br, scratch)
 			if err != nil {
-				return bytesRead, err
+				return bytesRead, cbg.WrapDecodeError(err, "EmbeddingStructThree", "Test", bytesRead)
 			}
 			bytesRead += read
 
 			if extra > cbg.MaxLength {
-				return bytesRead, fmt.Errorf("t.Test: array too large (%d)", extra)
+				return bytesRead, &cbg.DecodeError{Type: "EmbeddingStructThree", Field: "Test", Offset: bytesRead - read, Expected: cbg.MajArray, Found: maj, Err: cbg.ErrMaxLength}
 			}
 
 			if maj != cbg.MajArray {
-				return bytesRead, fmt.Errorf("expected cbor array")
+				return bytesRead, &cbg.DecodeError{Type: "EmbeddingStructThree", Field: "Test", Offset: bytesRead - read, Expected: cbg.MajArray, Found: maj, Err: cbg.ErrUnexpectedType}
+			}
+			if err := limits.AddItems(extra); err != nil {
+				return bytesRead, &cbg.DecodeError{Type: "EmbeddingStructThree", Field: "Test", Offset: bytesRead - read, Expected: cbg.MajArray, Found: maj, Err: err}
 			}
 
 			if extra > 0 {
@@ -3233,15 +3390,18 @@ func (t *EmbeddingStructThree) UnmarshalCBOR(r io.Reader) (int, error) {
 
 					maj, extra, read, err = cbg.CborReadHeaderBuf(br, scratch)
 					if err != nil {
-						return bytesRead, err
+						return bytesRead, cbg.WrapDecodeError(err, "EmbeddingStructThree", fmt.Sprintf("Test[%d]", i), bytesRead)
 					}
 					bytesRead += read
 
 					if extra > cbg.ByteArrayMaxLen {
-						return bytesRead, fmt.Errorf("t.Test[i]: byte array too large (%d)", extra)
+						return bytesRead, &cbg.DecodeError{Type: "EmbeddingStructThree", Field: fmt.Sprintf("Test[%d]", i), Offset: bytesRead - read, Expected: cbg.MajByteString, Found: maj, Err: cbg.ErrMaxLength}
 					}
 					if maj != cbg.MajByteString {
-						return bytesRead, fmt.Errorf("expected byte array")
+						return bytesRead, &cbg.DecodeError{Type: "EmbeddingStructThree", Field: fmt.Sprintf("Test[%d]", i), Offset: bytesRead - read, Expected: cbg.MajByteString, Found: maj, Err: cbg.ErrUnexpectedType}
+					}
+					if err := limits.AddBytes(extra); err != nil {
+						return bytesRead, &cbg.DecodeError{Type: "EmbeddingStructThree", Field: fmt.Sprintf("Test[%d]", i), Offset: bytesRead - read, Expected: cbg.MajByteString, Found: maj, Err: err}
 					}
 
 					if extra > 0 {
@@ -3249,7 +3409,7 @@ func (t *EmbeddingStructThree) UnmarshalCBOR(r io.Reader) (int, error) {
 					}
 
 					if read, err := io.ReadFull(br, t.Test[i][:]); err != nil {
-						return bytesRead, err
+						return bytesRead, cbg.WrapDecodeError(err, "EmbeddingStructThree", fmt.Sprintf("Test[%d]", i), bytesRead)
 					} else {
 						bytesRead += read
 					}
@@ -3263,21 +3423,21 @@ func (t *EmbeddingStructThree) UnmarshalCBOR(r io.Reader) (int, error) {
 
 				b, err := br.ReadByte()
 				if err != nil {
-					return bytesRead, err
+					return bytesRead, cbg.WrapDecodeError(err, "EmbeddingStructThree", "Pizza", bytesRead)
 				}
 				bytesRead++
 				if b != cbg.CborNull[0] {
 					if err := br.UnreadByte(); err != nil {
-						return bytesRead, err
+						return bytesRead, cbg.WrapDecodeError(err, "EmbeddingStructThree", "Pizza", bytesRead)
 					}
 					bytesRead--
 					maj, extra, read, err = cbg.CborReadHeaderBuf(br, scratch)
 					if err != nil {
-						return bytesRead, err
+						return bytesRead, cbg.WrapDecodeError(err, "EmbeddingStructThree", "Pizza", bytesRead)
 					}
 					bytesRead += read
 					if maj != cbg.MajUnsignedInt {
-						return bytesRead, fmt.Errorf("wrong type for uint64 field")
+						return bytesRead, &cbg.DecodeError{Type: "EmbeddingStructThree", Field: "Pizza", Offset: bytesRead - read, Expected: cbg.MajUnsignedInt, Found: maj, Err: cbg.ErrUnexpectedType}
 					}
 					typed := uint64(extra)
 					t.Pizza = &typed
@@ -3291,17 +3451,17 @@ func (t *EmbeddingStructThree) UnmarshalCBOR(r io.Reader) (int, error) {
 
 				b, err := br.ReadByte()
 				if err != nil {
-					return bytesRead, err
+					return bytesRead, cbg.WrapDecodeError(err, "EmbeddingStructThree", "Stuff", bytesRead)
 				}
 				bytesRead++
 				if b != cbg.CborNull[0] {
 					if err := br.UnreadByte(); err != nil {
-						return bytesRead, err
+						return bytesRead, cbg.WrapDecodeError(err, "EmbeddingStructThree", "Stuff", bytesRead)
 					}
 					bytesRead--
 					t.Stuff = new(testing.SimpleTypeTwo)
 					if read, err := t.Stuff.UnmarshalCBOR(br); err != nil {
-						return bytesRead, xerrors.Errorf("unmarshaling t.Stuff pointer: %w", err)
+						return bytesRead, cbg.WrapDecodeError(err, "EmbeddingStructThree", "Stuff", bytesRead)
 					} else {
 						bytesRead += read
 					}
@@ -3315,11 +3475,11 @@ func (t *EmbeddingStructThree) UnmarshalCBOR(r io.Reader) (int, error) {
 
 				maj, extra, read, err = cbg.CborReadHeaderBuf(br, scratch)
 				if err != nil {
-					return bytesRead, err
+					return bytesRead, cbg.WrapDecodeError(err, "EmbeddingStructThree", "Value", bytesRead)
 				}
 				bytesRead += read
 				if maj != cbg.MajUnsignedInt {
-					return bytesRead, fmt.Errorf("wrong type for uint64 field")
+					return bytesRead, &cbg.DecodeError{Type: "EmbeddingStructThree", Field: "Value", Offset: bytesRead - read, Expected: cbg.MajUnsignedInt, Found: maj, Err: cbg.ErrUnexpectedType}
 				}
 				t.Value = uint64(extra)
 
@@ -3329,15 +3489,18 @@ func (t *EmbeddingStructThree) UnmarshalCBOR(r io.Reader) (int, error) {
 
 			maj, extra, read, err = cbg.CborReadHeaderBuf(br, scratch)
 			if err != nil {
-				return bytesRead, err
+				return bytesRead, cbg.WrapDecodeError(err, "EmbeddingStructThree", "Binary", bytesRead)
 			}
 			bytesRead += read
 
 			if extra > cbg.ByteArrayMaxLen {
-				return bytesRead, fmt.Errorf("t.Binary: byte array too large (%d)", extra)
+				return bytesRead, &cbg.DecodeError{Type: "EmbeddingStructThree", Field: "Binary", Offset: bytesRead - read, Expected: cbg.MajByteString, Found: maj, Err: cbg.ErrMaxLength}
 			}
 			if maj != cbg.MajByteString {
-				return bytesRead, fmt.Errorf("expected byte array")
+				return bytesRead, &cbg.DecodeError{Type: "EmbeddingStructThree", Field: "Binary", Offset: bytesRead - read, Expected: cbg.MajByteString, Found: maj, Err: cbg.ErrUnexpectedType}
+			}
+			if err := limits.AddBytes(extra); err != nil {
+				return bytesRead, &cbg.DecodeError{Type: "EmbeddingStructThree", Field: "Binary", Offset: bytesRead - read, Expected: cbg.MajByteString, Found: maj, Err: err}
 			}
 
 			if extra > 0 {
@@ -3345,7 +3508,7 @@ func (t *EmbeddingStructThree) UnmarshalCBOR(r io.Reader) (int, error) {
 			}
 
 			if read, err := io.ReadFull(br, t.Binary[:]); err != nil {
-				return bytesRead, err
+				return bytesRead, cbg.WrapDecodeError(err, "EmbeddingStructThree", "Binary", bytesRead)
 			} else {
 				bytesRead += read
 			}
@@ -3354,16 +3517,19 @@ func (t *EmbeddingStructThree) UnmarshalCBOR(r io.Reader) (int, error) {
 
 			maj, extra, read, err = cbg.CborReadHeaderBuf(br, scratch)
 			if err != nil {
-				return bytesRead, err
+				return bytesRead, cbg.WrapDecodeError(err, "EmbeddingStructThree", "Others", bytesRead)
 			}
 			bytesRead += read
 
 			if extra > cbg.MaxLength {
-				return bytesRead, fmt.Errorf("t.Others: array too large (%d)", extra)
+				return bytesRead, &cbg.DecodeError{Type: "EmbeddingStructThree", Field: "Others", Offset: bytesRead - read, Expected: cbg.MajArray, Found: maj, Err: cbg.ErrMaxLength}
 			}
 
 			if maj != cbg.MajArray {
-				return bytesRead, fmt.Errorf("expected cbor array")
+				return bytesRead, &cbg.DecodeError{Type: "EmbeddingStructThree", Field: "Others", Offset: bytesRead - read, Expected: cbg.MajArray, Found: maj, Err: cbg.ErrUnexpectedType}
+			}
+			if err := limits.AddItems(extra); err != nil {
+				return bytesRead, &cbg.DecodeError{Type: "EmbeddingStructThree", Field: "Others", Offset: bytesRead - read, Expected: cbg.MajArray, Found: maj, Err: err}
 			}
 
 			if extra > 0 {
@@ -3374,12 +3540,12 @@ func (t *EmbeddingStructThree) UnmarshalCBOR(r io.Reader) (int, error) {
 
 				maj, val, read, err := cbg.CborReadHeaderBuf(br, scratch)
 				if err != nil {
-					return bytesRead, xerrors.Errorf("failed to read uint64 for t.Others slice: %w", err)
+					return bytesRead, cbg.WrapDecodeError(err, "EmbeddingStructThree", fmt.Sprintf("Others[%d]", i), bytesRead)
 				}
 				bytesRead += read
 
 				if maj != cbg.MajUnsignedInt {
-					return bytesRead, xerrors.Errorf("value read for array t.Others was not a uint, instead got %d", maj)
+					return bytesRead, &cbg.DecodeError{Type: "EmbeddingStructThree", Field: fmt.Sprintf("Others[%d]", i), Offset: bytesRead - read, Expected: cbg.MajUnsignedInt, Found: maj, Err: cbg.ErrUnexpectedType}
 				}
 
 				t.Others[i] = uint64(val)
@@ -3391,23 +3557,23 @@ func (t *EmbeddingStructThree) UnmarshalCBOR(r io.Reader) (int, error) {
 				maj, extra, read, err := cbg.CborReadHeaderBuf(br, scratch)
 				var extraI int64
 				if err != nil {
-					return bytesRead, err
+					return bytesRead, cbg.WrapDecodeError(err, "EmbeddingStructThree", "Signed", bytesRead)
 				}
 				bytesRead += read
 				switch maj {
 				case cbg.MajUnsignedInt:
 					extraI = int64(extra)
 					if extraI < 0 {
-						return bytesRead, fmt.Errorf("int64 positive overflow")
+						return bytesRead, &cbg.DecodeError{Type: "EmbeddingStructThree", Field: "Signed", Offset: bytesRead - read, Expected: maj, Found: maj, Err: cbg.ErrOverflow}
 					}
 				case cbg.MajNegativeInt:
 					extraI = int64(extra)
 					if extraI < 0 {
-						return bytesRead, fmt.Errorf("int64 negative oveflow")
+						return bytesRead, &cbg.DecodeError{Type: "EmbeddingStructThree", Field: "Signed", Offset: bytesRead - read, Expected: maj, Found: maj, Err: cbg.ErrOverflow}
 					}
 					extraI = -1 - extraI
 				default:
-					return bytesRead, fmt.Errorf("wrong type for int64 field: %d", maj)
+					return bytesRead, &cbg.DecodeError{Type: "EmbeddingStructThree", Field: "Signed", Offset: bytesRead - read, Expected: cbg.MajUnsignedInt, Found: maj, Err: cbg.ErrUnexpectedType}
 				}
 
 				t.Signed = int64(extraI)
@@ -3418,7 +3584,7 @@ func (t *EmbeddingStructThree) UnmarshalCBOR(r io.Reader) (int, error) {
 			{
 				sval, read, err := cbg.ReadStringBuf(br, scratch)
 				if err != nil {
-					return bytesRead, err
+					return bytesRead, cbg.WrapDecodeError(err, "EmbeddingStructThree", "NString", bytesRead)
 				}
 				bytesRead += read
 
@@ -3429,16 +3595,19 @@ func (t *EmbeddingStructThree) UnmarshalCBOR(r io.Reader) (int, error) {
 
 			maj, extra, read, err = cbg.CborReadHeaderBuf(br, scratch)
 			if err != nil {
-				return bytesRead, err
+				return bytesRead, cbg.WrapDecodeError(err, "EmbeddingStructThree", "Numbers", bytesRead)
 			}
 			bytesRead += read
 
 			if extra > cbg.MaxLength {
-				return bytesRead, fmt.Errorf("t.Numbers: array too large (%d)", extra)
+				return bytesRead, &cbg.DecodeError{Type: "EmbeddingStructThree", Field: "Numbers", Offset: bytesRead - read, Expected: cbg.MajArray, Found: maj, Err: cbg.ErrMaxLength}
 			}
 
 			if maj != cbg.MajArray {
-				return bytesRead, fmt.Errorf("expected cbor array")
+				return bytesRead, &cbg.DecodeError{Type: "EmbeddingStructThree", Field: "Numbers", Offset: bytesRead - read, Expected: cbg.MajArray, Found: maj, Err: cbg.ErrUnexpectedType}
+			}
+			if err := limits.AddItems(extra); err != nil {
+				return bytesRead, &cbg.DecodeError{Type: "EmbeddingStructThree", Field: "Numbers", Offset: bytesRead - read, Expected: cbg.MajArray, Found: maj, Err: err}
 			}
 
 			if extra > 0 {
@@ -3449,12 +3618,12 @@ func (t *EmbeddingStructThree) UnmarshalCBOR(r io.Reader) (int, error) {
 
 				maj, val, read, err := cbg.CborReadHeaderBuf(br, scratch)
 				if err != nil {
-					return bytesRead, xerrors.Errorf("failed to read uint64 for t.Numbers slice: %w", err)
+					return bytesRead, cbg.WrapDecodeError(err, "EmbeddingStructThree", fmt.Sprintf("Numbers[%d]", i), bytesRead)
 				}
 				bytesRead += read
 
 				if maj != cbg.MajUnsignedInt {
-					return bytesRead, xerrors.Errorf("value read for array t.Numbers was not a uint, instead got %d", maj)
+					return bytesRead, &cbg.DecodeError{Type: "EmbeddingStructThree", Field: fmt.Sprintf("Numbers[%d]", i), Offset: bytesRead - read, Expected: cbg.MajUnsignedInt, Found: maj, Err: cbg.ErrUnexpectedType}
 				}
 
 				t.Numbers[i] = testing.NamedNumber(val)
@@ -3465,20 +3634,23 @@ func (t *EmbeddingStructThree) UnmarshalCBOR(r io.Reader) (int, error) {
 
 			maj, extra, read, err = cbg.CborReadHeaderBuf(br, scratch)
 			if err != nil {
-				return bytesRead, err
+				return bytesRead, cbg.WrapDecodeError(err, "EmbeddingStructThree", "Arrrrrghay", bytesRead)
 			}
 			bytesRead += read
 
 			if extra > cbg.MaxLength {
-				return bytesRead, fmt.Errorf("t.Arrrrrghay: array too large (%d)", extra)
+				return bytesRead, &cbg.DecodeError{Type: "EmbeddingStructThree", Field: "Arrrrrghay", Offset: bytesRead - read, Expected: cbg.MajArray, Found: maj, Err: cbg.ErrMaxLength}
 			}
 
 			if maj != cbg.MajArray {
-				return bytesRead, fmt.Errorf("expected cbor array")
+				return bytesRead, &cbg.DecodeError{Type: "EmbeddingStructThree", Field: "Arrrrrghay", Offset: bytesRead - read, Expected: cbg.MajArray, Found: maj, Err: cbg.ErrUnexpectedType}
+			}
+			if err := limits.AddItems(extra); err != nil {
+				return bytesRead, &cbg.DecodeError{Type: "EmbeddingStructThree", Field: "Arrrrrghay", Offset: bytesRead - read, Expected: cbg.MajArray, Found: maj, Err: err}
 			}
 
 			if extra != 3 {
-				return bytesRead, fmt.Errorf("expected array to have 3 elements")
+				return bytesRead, &cbg.DecodeError{Type: "EmbeddingStructThree", Field: "Arrrrrghay", Offset: bytesRead - read, Expected: cbg.MajArray, Found: maj, Err: fmt.Errorf("expected 3 elements, got %d", extra)}
 			}
 
 			t.Arrrrrghay = [3]testing.SimpleTypeOne{}
@@ -3487,7 +3659,7 @@ func (t *EmbeddingStructThree) UnmarshalCBOR(r io.Reader) (int, error) {
 
 				var v testing.SimpleTypeOne
 				if read, err := v.UnmarshalCBOR(br); err != nil {
-					return bytesRead, err
+					return bytesRead, cbg.WrapDecodeError(err, "EmbeddingStructThree", fmt.Sprintf("Arrrrrghay[%d]", i), bytesRead)
 				} else {
 					bytesRead += read
 				}
@@ -3502,21 +3674,21 @@ func (t *EmbeddingStructThree) UnmarshalCBOR(r io.Reader) (int, error) {
 
 				b, err := br.ReadByte()
 				if err != nil {
-					return bytesRead, err
+					return bytesRead, cbg.WrapDecodeError(err, "EmbeddingStructThree", "PointyPizza", bytesRead)
 				}
 				bytesRead++
 				if b != cbg.CborNull[0] {
 					if err := br.UnreadByte(); err != nil {
-						return bytesRead, err
+						return bytesRead, cbg.WrapDecodeError(err, "EmbeddingStructThree", "PointyPizza", bytesRead)
 					}
 					bytesRead--
 					maj, extra, read, err = cbg.CborReadHeaderBuf(br, scratch)
 					if err != nil {
-						return bytesRead, err
+						return bytesRead, cbg.WrapDecodeError(err, "EmbeddingStructThree", "PointyPizza", bytesRead)
 					}
 					bytesRead += read
 					if maj != cbg.MajUnsignedInt {
-						return bytesRead, fmt.Errorf("wrong type for uint64 field")
+						return bytesRead, &cbg.DecodeError{Type: "EmbeddingStructThree", Field: "PointyPizza", Offset: bytesRead - read, Expected: cbg.MajUnsignedInt, Found: maj, Err: cbg.ErrUnexpectedType}
 					}
 					typed := testing.NamedNumber(extra)
 					t.PointyPizza = &typed
@@ -3528,16 +3700,19 @@ func (t *EmbeddingStructThree) UnmarshalCBOR(r io.Reader) (int, error) {
 
 			maj, extra, read, err = cbg.CborReadHeaderBuf(br, scratch)
 			if err != nil {
-				return bytesRead, err
+				return bytesRead, cbg.WrapDecodeError(err, "EmbeddingStructThree", "SignedOthers", bytesRead)
 			}
 			bytesRead += read
 
 			if extra > cbg.MaxLength {
-				return bytesRead, fmt.Errorf("t.SignedOthers: array too large (%d)", extra)
+				return bytesRead, &cbg.DecodeError{Type: "EmbeddingStructThree", Field: "SignedOthers", Offset: bytesRead - read, Expected: cbg.MajArray, Found: maj, Err: cbg.ErrMaxLength}
 			}
 
 			if maj != cbg.MajArray {
-				return bytesRead, fmt.Errorf("expected cbor array")
+				return bytesRead, &cbg.DecodeError{Type: "EmbeddingStructThree", Field: "SignedOthers", Offset: bytesRead - read, Expected: cbg.MajArray, Found: maj, Err: cbg.ErrUnexpectedType}
+			}
+			if err := limits.AddItems(extra); err != nil {
+				return bytesRead, &cbg.DecodeError{Type: "EmbeddingStructThree", Field: "SignedOthers", Offset: bytesRead - read, Expected: cbg.MajArray, Found: maj, Err: err}
 			}
 
 			if extra > 0 {
@@ -3549,23 +3724,23 @@ func (t *EmbeddingStructThree) UnmarshalCBOR(r io.Reader) (int, error) {
 					maj, extra, read, err := cbg.CborReadHeaderBuf(br, scratch)
 					var extraI int64
 					if err != nil {
-						return bytesRead, err
+						return bytesRead, cbg.WrapDecodeError(err, "EmbeddingStructThree", fmt.Sprintf("SignedOthers[%d]", i), bytesRead)
 					}
 					bytesRead += read
 					switch maj {
 					case cbg.MajUnsignedInt:
 						extraI = int64(extra)
 						if extraI < 0 {
-							return bytesRead, fmt.Errorf("int64 positive overflow")
+							return bytesRead, &cbg.DecodeError{Type: "EmbeddingStructThree", Field: fmt.Sprintf("SignedOthers[%d]", i), Offset: bytesRead - read, Expected: maj, Found: maj, Err: cbg.ErrOverflow}
 						}
 					case cbg.MajNegativeInt:
 						extraI = int64(extra)
 						if extraI < 0 {
-							return bytesRead, fmt.Errorf("int64 negative oveflow")
+							return bytesRead, &cbg.DecodeError{Type: "EmbeddingStructThree", Field: fmt.Sprintf("SignedOthers[%d]", i), Offset: bytesRead - read, Expected: maj, Found: maj, Err: cbg.ErrOverflow}
 						}
 						extraI = -1 - extraI
 					default:
-						return bytesRead, fmt.Errorf("wrong type for int64 field: %d", maj)
+						return bytesRead, &cbg.DecodeError{Type: "EmbeddingStructThree", Field: fmt.Sprintf("SignedOthers[%d]", i), Offset: bytesRead - read, Expected: cbg.MajUnsignedInt, Found: maj, Err: cbg.ErrUnexpectedType}
 					}
 
 					t.SignedOthers[i] = int64(extraI)
@@ -3574,7 +3749,9 @@ func (t *EmbeddingStructThree) UnmarshalCBOR(r io.Reader) (int, error) {
 
 		default:
 			// Field doesn't exist on this type, so ignore it
-			if read, err := cbg.ScanForLinks(r, func(cid.Cid) {}); err == nil {
+			if read, err := cbg.ScanForLinks(br, func(cid.Cid) {}); err != nil {
+				return bytesRead, cbg.WrapDecodeError(err, "EmbeddingStructThree", "", bytesRead)
+			} else {
 				bytesRead += read
 			}
 		}
@@ -3583,6 +3760,30 @@ func (t *EmbeddingStructThree) UnmarshalCBOR(r io.Reader) (int, error) {
 	return bytesRead, nil
 }
 
+func (t *EmbeddingStructThree) MarshalDAGJSON(w io.Writer) error {
+	return cbg.MarshalDAGJSON(w, t)
+}
+
+func (t *EmbeddingStructThree) UnmarshalDAGJSON(r io.Reader) error {
+	return cbg.UnmarshalDAGJSON(r, t)
+}
+
+func (t *EmbeddingStructThree) Links() []cid.Cid {
+	var links []cid.Cid
+	_ = t.ForEachLink(func(_ string, c cid.Cid) error {
+		links = append(links, c)
+		return nil
+	})
+	return links
+}
+
+func (t *EmbeddingStructThree) ForEachLink(cb func(path string, c cid.Cid) error) error {
+	if t == nil {
+		return nil
+	}
+	return nil
+}
+
 func (t *FlatStruct) InitNilEmbeddedStruct() {
 	if t != nil {
 	}
@@ -3756,6 +3957,11 @@ func (t *FlatStruct) UnmarshalCBOR(r io.Reader) (int, error) {
 
 	br := cbg.GetPeeker(r)
 	scratch := make([]byte, 8)
+	limits := cbg.GetDecodeLimits(br)
+	if err := limits.Enter(); err != nil {
+		return bytesRead, &cbg.DecodeError{Type: "FlatStruct", Err: err}
+	}
+	defer limits.Leave()
 
 	maj, extra, read, err := cbg.CborReadHeaderBuf(br, scratch)
 	if err != nil {
@@ -3763,11 +3969,14 @@ func (t *FlatStruct) UnmarshalCBOR(r io.Reader) (int, error) {
 	}
 	bytesRead += read
 	if maj != cbg.MajMap {
-		return bytesRead, fmt.Errorf("cbor input should be of type map")
+		return bytesRead, &cbg.DecodeError{Type: "FlatStruct", Expected: cbg.MajMap, Found: maj, Err: cbg.ErrUnexpectedType}
 	}
 
 	if extra > cbg.MaxLength {
-		return bytesRead, fmt.Errorf("FlatStruct: map struct too large (%d)", extra)
+		return bytesRead, &cbg.DecodeError{Type: "FlatStruct", Expected: cbg.MajMap, Found: maj, Err: cbg.ErrMaxLength}
+	}
+	if err := limits.AddItems(extra); err != nil {
+		return bytesRead, &cbg.DecodeError{Type: "FlatStruct", Expected: cbg.MajMap, Found: maj, Err: err}
 	}
 
 	var name string
@@ -3778,7 +3987,7 @@ func (t *FlatStruct) UnmarshalCBOR(r io.Reader) (int, error) {
 		{
 			sval, read, err := cbg.ReadStringBuf(br, scratch)
 			if err != nil {
-				return bytesRead, err
+				return bytesRead, cbg.WrapDecodeError(err, "FlatStruct", "", bytesRead)
 			}
 			bytesRead += read
 
@@ -3792,7 +4001,7 @@ func (t *FlatStruct) UnmarshalCBOR(r io.Reader) (int, error) {
 			{
 				sval, read, err := cbg.ReadStringBuf(br, scratch)
 				if err != nil {
-					return bytesRead, err
+					return bytesRead, cbg.WrapDecodeError(err, "FlatStruct", "Foo", bytesRead)
 				}
 				bytesRead += read
 
@@ -3805,11 +4014,11 @@ func (t *FlatStruct) UnmarshalCBOR(r io.Reader) (int, error) {
 
 				maj, extra, read, err = cbg.CborReadHeaderBuf(br, scratch)
 				if err != nil {
-					return bytesRead, err
+					return bytesRead, cbg.WrapDecodeError(err, "FlatStruct", "Value", bytesRead)
 				}
 				bytesRead += read
 				if maj != cbg.MajUnsignedInt {
-					return bytesRead, fmt.Errorf("wrong type for uint64 field")
+					return bytesRead, &cbg.DecodeError{Type: "FlatStruct", Field: "Value", Offset: bytesRead - read, Expected: cbg.MajUnsignedInt, Found: maj, Err: cbg.ErrUnexpectedType}
 				}
 				t.Value = uint64(extra)
 
@@ -3819,15 +4028,18 @@ func (t *FlatStruct) UnmarshalCBOR(r io.Reader) (int, error) {
 
 			maj, extra, read, err = cbg.CborReadHeaderBuf(br, scratch)
 			if err != nil {
-				return bytesRead, err
+				return bytesRead, cbg.WrapDecodeError(err, "FlatStruct", "Binary", bytesRead)
 			}
 			bytesRead += read
 
 			if extra > cbg.ByteArrayMaxLen {
-				return bytesRead, fmt.Errorf("t.Binary: byte array too large (%d)", extra)
+				return bytesRead, &cbg.DecodeError{Type: "FlatStruct", Field: "Binary", Offset: bytesRead - read, Expected: cbg.MajByteString, Found: maj, Err: cbg.ErrMaxLength}
 			}
 			if maj != cbg.MajByteString {
-				return bytesRead, fmt.Errorf("expected byte array")
+				return bytesRead, &cbg.DecodeError{Type: "FlatStruct", Field: "Binary", Offset: bytesRead - read, Expected: cbg.MajByteString, Found: maj, Err: cbg.ErrUnexpectedType}
+			}
+			if err := limits.AddBytes(extra); err != nil {
+				return bytesRead, &cbg.DecodeError{Type: "FlatStruct", Field: "Binary", Offset: bytesRead - read, Expected: cbg.MajByteString, Found: maj, Err: err}
 			}
 
 			if extra > 0 {
@@ -3835,7 +4047,7 @@ func (t *FlatStruct) UnmarshalCBOR(r io.Reader) (int, error) {
 			}
 
 			if read, err := io.ReadFull(br, t.Binary[:]); err != nil {
-				return bytesRead, err
+				return bytesRead, cbg.WrapDecodeError(err, "FlatStruct", "Binary", bytesRead)
 			} else {
 				bytesRead += read
 			}
@@ -3845,23 +4057,23 @@ func (t *FlatStruct) UnmarshalCBOR(r io.Reader) (int, error) {
 				maj, extra, read, err := cbg.CborReadHeaderBuf(br, scratch)
 				var extraI int64
 				if err != nil {
-					return bytesRead, err
+					return bytesRead, cbg.WrapDecodeError(err, "FlatStruct", "Signed", bytesRead)
 				}
 				bytesRead += read
 				switch maj {
 				case cbg.MajUnsignedInt:
 					extraI = int64(extra)
 					if extraI < 0 {
-						return bytesRead, fmt.Errorf("int64 positive overflow")
+						return bytesRead, &cbg.DecodeError{Type: "FlatStruct", Field: "Signed", Offset: bytesRead - read, Expected: maj, Found: maj, Err: cbg.ErrOverflow}
 					}
 				case cbg.MajNegativeInt:
 					extraI = int64(extra)
 					if extraI < 0 {
-						return bytesRead, fmt.Errorf("int64 negative oveflow")
+						return bytesRead, &cbg.DecodeError{Type: "FlatStruct", Field: "Signed", Offset: bytesRead - read, Expected: maj, Found: maj, Err: cbg.ErrOverflow}
 					}
 					extraI = -1 - extraI
 				default:
-					return bytesRead, fmt.Errorf("wrong type for int64 field: %d", maj)
+					return bytesRead, &cbg.DecodeError{Type: "FlatStruct", Field: "Signed", Offset: bytesRead - read, Expected: cbg.MajUnsignedInt, Found: maj, Err: cbg.ErrUnexpectedType}
 				}
 
 				t.Signed = int64(extraI)
@@ -3872,7 +4084,7 @@ func (t *FlatStruct) UnmarshalCBOR(r io.Reader) (int, error) {
 			{
 				sval, read, err := cbg.ReadStringBuf(br, scratch)
 				if err != nil {
-					return bytesRead, err
+					return bytesRead, cbg.WrapDecodeError(err, "FlatStruct", "NString", bytesRead)
 				}
 				bytesRead += read
 
@@ -3881,7 +4093,9 @@ func (t *FlatStruct) UnmarshalCBOR(r io.Reader) (int, error) {
 
 		default:
 			// Field doesn't exist on this type, so ignore it
-			if read, err := cbg.ScanForLinks(r, func(cid.Cid) {}); err == nil {
+			if read, err := cbg.ScanForLinks(br, func(cid.Cid) {}); err != nil {
+				return bytesRead, cbg.WrapDecodeError(err, "FlatStruct", "", bytesRead)
+			} else {
 				bytesRead += read
 			}
 		}
@@ -3890,6 +4104,30 @@ func (t *FlatStruct) UnmarshalCBOR(r io.Reader) (int, error) {
 	return bytesRead, nil
 }
 
+func (t *FlatStruct) MarshalDAGJSON(w io.Writer) error {
+	return cbg.MarshalDAGJSON(w, t)
+}
+
+func (t *FlatStruct) UnmarshalDAGJSON(r io.Reader) error {
+	return cbg.UnmarshalDAGJSON(r, t)
+}
+
+func (t *FlatStruct) Links() []cid.Cid {
+	var links []cid.Cid
+	_ = t.ForEachLink(func(_ string, c cid.Cid) error {
+		links = append(links, c)
+		return nil
+	})
+	return links
+}
+
+func (t *FlatStruct) ForEachLink(cb func(path string, c cid.Cid) error) error {
+	if t == nil {
+		return nil
+	}
+	return nil
+}
+
 func (t *EmbeddedStruct) InitNilEmbeddedStruct() {
 	if t != nil {
 	}
@@ -4002,6 +4240,11 @@ func (t *EmbeddedStruct) UnmarshalCBOR(r io.Reader) (int, error) {
 
 	br := cbg.GetPeeker(r)
 	scratch := make([]byte, 8)
+	limits := cbg.GetDecodeLimits(br)
+	if err := limits.Enter(); err != nil {
+		return bytesRead, &cbg.DecodeError{Type: "EmbeddedStruct", Err: err}
+	}
+	defer limits.Leave()
 
 	maj, extra, read, err := cbg.CborReadHeaderBuf(br, scratch)
 	if err != nil {
@@ -4009,11 +4252,14 @@ func (t *EmbeddedStruct) UnmarshalCBOR(r io.Reader) (int, error) {
 	}
 	bytesRead += read
 	if maj != cbg.MajMap {
-		return bytesRead, fmt.Errorf("cbor input should be of type map")
+		return bytesRead, &cbg.DecodeError{Type: "EmbeddedStruct", Expected: cbg.MajMap, Found: maj, Err: cbg.ErrUnexpectedType}
 	}
 
 	if extra > cbg.MaxLength {
-		return bytesRead, fmt.Errorf("EmbeddedStruct: map struct too large (%d)", extra)
+		return bytesRead, &cbg.DecodeError{Type: "EmbeddedStruct", Expected: cbg.MajMap, Found: maj, Err: cbg.ErrMaxLength}
+	}
+	if err := limits.AddItems(extra); err != nil {
+		return bytesRead, &cbg.DecodeError{Type: "EmbeddedStruct", Expected: cbg.MajMap, Found: maj, Err: err}
 	}
 
 	var name string
@@ -4024,7 +4270,7 @@ func (t *EmbeddedStruct) UnmarshalCBOR(r io.Reader) (int, error) {
 		{
 			sval, read, err := cbg.ReadStringBuf(br, scratch)
 			if err != nil {
-				return bytesRead, err
+				return bytesRead, cbg.WrapDecodeError(err, "EmbeddedStruct", "", bytesRead)
 			}
 			bytesRead += read
 
@@ -4038,7 +4284,7 @@ func (t *EmbeddedStruct) UnmarshalCBOR(r io.Reader) (int, error) {
 			{
 				sval, read, err := cbg.ReadStringBuf(br, scratch)
 				if err != nil {
-					return bytesRead, err
+					return bytesRead, cbg.WrapDecodeError(err, "EmbeddedStruct", "Foo", bytesRead)
 				}
 				bytesRead += read
 
@@ -4051,11 +4297,11 @@ func (t *EmbeddedStruct) UnmarshalCBOR(r io.Reader) (int, error) {
 
 				maj, extra, read, err = cbg.CborReadHeaderBuf(br, scratch)
 				if err != nil {
-					return bytesRead, err
+					return bytesRead, cbg.WrapDecodeError(err, "EmbeddedStruct", "Value", bytesRead)
 				}
 				bytesRead += read
 				if maj != cbg.MajUnsignedInt {
-					return bytesRead, fmt.Errorf("wrong type for uint64 field")
+					return bytesRead, &cbg.DecodeError{Type: "EmbeddedStruct", Field: "Value", Offset: bytesRead - read, Expected: cbg.MajUnsignedInt, Found: maj, Err: cbg.ErrUnexpectedType}
 				}
 				t.Value = uint64(extra)
 
@@ -4065,15 +4311,18 @@ func (t *EmbeddedStruct) UnmarshalCBOR(r io.Reader) (int, error) {
 
 			maj, extra, read, err = cbg.CborReadHeaderBuf(br, scratch)
 			if err != nil {
-				return bytesRead, err
+				return bytesRead, cbg.WrapDecodeError(err, "EmbeddedStruct", "Binary", bytesRead)
 			}
 			bytesRead += read
 
 			if extra > cbg.ByteArrayMaxLen {
-				return bytesRead, fmt.Errorf("t.Binary: byte array too large (%d)", extra)
+				return bytesRead, &cbg.DecodeError{Type: "EmbeddedStruct", Field: "Binary", Offset: bytesRead - read, Expected: cbg.MajByteString, Found: maj, Err: cbg.ErrMaxLength}
 			}
 			if maj != cbg.MajByteString {
-				return bytesRead, fmt.Errorf("expected byte array")
+				return bytesRead, &cbg.DecodeError{Type: "EmbeddedStruct", Field: "Binary", Offset: bytesRead - read, Expected: cbg.MajByteString, Found: maj, Err: cbg.ErrUnexpectedType}
+			}
+			if err := limits.AddBytes(extra); err != nil {
+				return bytesRead, &cbg.DecodeError{Type: "EmbeddedStruct", Field: "Binary", Offset: bytesRead - read, Expected: cbg.MajByteString, Found: maj, Err: err}
 			}
 
 			if extra > 0 {
@@ -4081,14 +4330,16 @@ func (t *EmbeddedStruct) UnmarshalCBOR(r io.Reader) (int, error) {
 			}
 
 			if read, err := io.ReadFull(br, t.Binary[:]); err != nil {
-				return bytesRead, err
+				return bytesRead, cbg.WrapDecodeError(err, "EmbeddedStruct", "Binary", bytesRead)
 			} else {
 				bytesRead += read
 			}
 
 		default:
 			// Field doesn't exist on this type, so ignore it
-			if read, err := cbg.ScanForLinks(r, func(cid.Cid) {}); err == nil {
+			if read, err := cbg.ScanForLinks(br, func(cid.Cid) {}); err != nil {
+				return bytesRead, cbg.WrapDecodeError(err, "EmbeddedStruct", "", bytesRead)
+			} else {
 				bytesRead += read
 			}
 		}
@@ -4097,6 +4348,30 @@ func (t *EmbeddedStruct) UnmarshalCBOR(r io.Reader) (int, error) {
 	return bytesRead, nil
 }
 
+func (t *EmbeddedStruct) MarshalDAGJSON(w io.Writer) error {
+	return cbg.MarshalDAGJSON(w, t)
+}
+
+func (t *EmbeddedStruct) UnmarshalDAGJSON(r io.Reader) error {
+	return cbg.UnmarshalDAGJSON(r, t)
+}
+
+func (t *EmbeddedStruct) Links() []cid.Cid {
+	var links []cid.Cid
+	_ = t.ForEachLink(func(_ string, c cid.Cid) error {
+		links = append(links, c)
+		return nil
+	})
+	return links
+}
+
+func (t *EmbeddedStruct) ForEachLink(cb func(path string, c cid.Cid) error) error {
+	if t == nil {
+		return nil
+	}
+	return nil
+}
+
 func (t *EmbedByValueStruct) InitNilEmbeddedStruct() {
 	if t != nil {
 	}
@@ -4270,6 +4545,11 @@ func (t *EmbedByValueStruct) UnmarshalCBOR(r io.Reader) (int, error) {
 
 	br := cbg.GetPeeker(r)
 	scratch := make([]byte, 8)
+	limits := cbg.GetDecodeLimits(br)
+	if err := limits.Enter(); err != nil {
+		return bytesRead, &cbg.DecodeError{Type: "EmbedByValueStruct", Err: err}
+	}
+	defer limits.Leave()
 
 	maj, extra, read, err := cbg.CborReadHeaderBuf(br, scratch)
 	if err != nil {
@@ -4277,11 +4557,14 @@ func (t *EmbedByValueStruct) UnmarshalCBOR(r io.Reader) (int, error) {
 	}
 	bytesRead += read
 	if maj != cbg.MajMap {
-		return bytesRead, fmt.Errorf("cbor input should be of type map")
+		return bytesRead, &cbg.DecodeError{Type: "EmbedByValueStruct", Expected: cbg.MajMap, Found: maj, Err: cbg.ErrUnexpectedType}
 	}
 
 	if extra > cbg.MaxLength {
-		return bytesRead, fmt.Errorf("EmbedByValueStruct: map struct too large (%d)", extra)
+		return bytesRead, &cbg.DecodeError{Type: "EmbedByValueStruct", Expected: cbg.MajMap, Found: maj, Err: cbg.ErrMaxLength}
+	}
+	if err := limits.AddItems(extra); err != nil {
+		return bytesRead, &cbg.DecodeError{Type: "EmbedByValueStruct", Expected: cbg.MajMap, Found: maj, Err: err}
 	}
 
 	var name string
@@ -4292,7 +4575,7 @@ func (t *EmbedByValueStruct) UnmarshalCBOR(r io.Reader) (int, error) {
 		{
 			sval, read, err := cbg.ReadStringBuf(br, scratch)
 			if err != nil {
-				return bytesRead, err
+				return bytesRead, cbg.WrapDecodeError(err, "EmbedByValueStruct", "", bytesRead)
 			}
 			bytesRead += read
 
@@ -4306,7 +4589,7 @@ func (t *EmbedByValueStruct) UnmarshalCBOR(r io.Reader) (int, error) {
 			{
 				sval, read, err := cbg.ReadStringBuf(br, scratch)
 				if err != nil {
-					return bytesRead, err
+					return bytesRead, cbg.WrapDecodeError(err, "EmbedByValueStruct", "Foo", bytesRead)
 				}
 				bytesRead += read
 
@@ -4319,11 +4602,11 @@ func (t *EmbedByValueStruct) UnmarshalCBOR(r io.Reader) (int, error) {
 
 				maj, extra, read, err = cbg.CborReadHeaderBuf(br, scratch)
 				if err != nil {
-					return bytesRead, err
+					return bytesRead, cbg.WrapDecodeError(err, "EmbedByValueStruct", "Value", bytesRead)
 				}
 				bytesRead += read
 				if maj != cbg.MajUnsignedInt {
-					return bytesRead, fmt.Errorf("wrong type for uint64 field")
+					return bytesRead, &cbg.DecodeError{Type: "EmbedByValueStruct", Field: "Value", Offset: bytesRead - read, Expected: cbg.MajUnsignedInt, Found: maj, Err: cbg.ErrUnexpectedType}
 				}
 				t.Value = uint64(extra)
 
@@ -4333,15 +4616,18 @@ func (t *EmbedByValueStruct) UnmarshalCBOR(r io.Reader) (int, error) {
 
 			maj, extra, read, err = cbg.CborReadHeaderBuf(br, scratch)
 			if err != nil {
-				return bytesRead, err
+				return bytesRead, cbg.WrapDecodeError(err, "EmbedByValueStruct", "Binary", bytesRead)
 			}
 			bytesRead += read
 
 			if extra > cbg.ByteArrayMaxLen {
-				return bytesRead, fmt.Errorf("t.Binary: byte array too large (%d)", extra)
+				return bytesRead, &cbg.DecodeError{Type: "EmbedByValueStruct", Field: "Binary", Offset: bytesRead - read, Expected: cbg.MajByteString, Found: maj, Err: cbg.ErrMaxLength}
 			}
 			if maj != cbg.MajByteString {
-				return bytesRead, fmt.Errorf("expected byte array")
+				return bytesRead, &cbg.DecodeError{Type: "EmbedByValueStruct", Field: "Binary", Offset: bytesRead - read, Expected: cbg.MajByteString, Found: maj, Err: cbg.ErrUnexpectedType}
+			}
+			if err := limits.AddBytes(extra); err != nil {
+				return bytesRead, &cbg.DecodeError{Type: "EmbedByValueStruct", Field: "Binary", Offset: bytesRead - read, Expected: cbg.MajByteString, Found: maj, Err: err}
 			}
 
 			if extra > 0 {
@@ -4349,7 +4635,7 @@ func (t *EmbedByValueStruct) UnmarshalCBOR(r io.Reader) (int, error) {
 			}
 
 			if read, err := io.ReadFull(br, t.Binary[:]); err != nil {
-				return bytesRead, err
+				return bytesRead, cbg.WrapDecodeError(err, "EmbedByValueStruct", "Binary", bytesRead)
 			} else {
 				bytesRead += read
 			}
@@ -4359,23 +4645,23 @@ func (t *EmbedByValueStruct) UnmarshalCBOR(r io.Reader) (int, error) {
 				maj, extra, read, err := cbg.CborReadHeaderBuf(br, scratch)
 				var extraI int64
 				if err != nil {
-					return bytesRead, err
+					return bytesRead, cbg.WrapDecodeError(err, "EmbedByValueStruct", "Signed", bytesRead)
 				}
 				bytesRead += read
 				switch maj {
 				case cbg.MajUnsignedInt:
 					extraI = int64(extra)
 					if extraI < 0 {
-						return bytesRead, fmt.Errorf("int64 positive overflow")
+						return bytesRead, &cbg.DecodeError{Type: "EmbedByValueStruct", Field: "Signed", Offset: bytesRead - read, Expected: maj, Found: maj, Err: cbg.ErrOverflow}
 					}
 				case cbg.MajNegativeInt:
 					extraI = int64(extra)
 					if extraI < 0 {
-						return bytesRead, fmt.Errorf("int64 negative oveflow")
+						return bytesRead, &cbg.DecodeError{Type: "EmbedByValueStruct", Field: "Signed", Offset: bytesRead - read, Expected: maj, Found: maj, Err: cbg.ErrOverflow}
 					}
 					extraI = -1 - extraI
 				default:
-					return bytesRead, fmt.Errorf("wrong type for int64 field: %d", maj)
+					return bytesRead, &cbg.DecodeError{Type: "EmbedByValueStruct", Field: "Signed", Offset: bytesRead - read, Expected: cbg.MajUnsignedInt, Found: maj, Err: cbg.ErrUnexpectedType}
 				}
 
 				t.Signed = int64(extraI)
@@ -4386,7 +4672,7 @@ func (t *EmbedByValueStruct) UnmarshalCBOR(r io.Reader) (int, error) {
 			{
 				sval, read, err := cbg.ReadStringBuf(br, scratch)
 				if err != nil {
-					return bytesRead, err
+					return bytesRead, cbg.WrapDecodeError(err, "EmbedByValueStruct", "NString", bytesRead)
 				}
 				bytesRead += read
 
@@ -4395,7 +4681,9 @@ func (t *EmbedByValueStruct) UnmarshalCBOR(r io.Reader) (int, error) {
 
 		default:
 			// Field doesn't exist on this type, so ignore it
-			if read, err := cbg.ScanForLinks(r, func(cid.Cid) {}); err == nil {
+			if read, err := cbg.ScanForLinks(br, func(cid.Cid) {}); err != nil {
+				return bytesRead, cbg.WrapDecodeError(err, "EmbedByValueStruct", "", bytesRead)
+			} else {
 				bytesRead += read
 			}
 		}
@@ -4404,6 +4692,30 @@ func (t *EmbedByValueStruct) UnmarshalCBOR(r io.Reader) (int, error) {
 	return bytesRead, nil
 }
 
+func (t *EmbedByValueStruct) MarshalDAGJSON(w io.Writer) error {
+	return cbg.MarshalDAGJSON(w, t)
+}
+
+func (t *EmbedByValueStruct) UnmarshalDAGJSON(r io.Reader) error {
+	return cbg.UnmarshalDAGJSON(r, t)
+}
+
+func (t *EmbedByValueStruct) Links() []cid.Cid {
+	var links []cid.Cid
+	_ = t.ForEachLink(func(_ string, c cid.Cid) error {
+		links = append(links, c)
+		return nil
+	})
+	return links
+}
+
+func (t *EmbedByValueStruct) ForEachLink(cb func(path string, c cid.Cid) error) error {
+	if t == nil {
+		return nil
+	}
+	return nil
+}
+
 func (t *EmbedByPointerStruct) InitNilEmbeddedStruct() {
 	if t != nil {
 		if t.EmbeddedStruct == nil {
@@ -4581,6 +4893,11 @@ func (t *EmbedByPointerStruct) UnmarshalCBOR(r io.Reader) (int, error) {
 
 	br := cbg.GetPeeker(r)
 	scratch := make([]byte, 8)
+	limits := cbg.GetDecodeLimits(br)
+	if err := limits.Enter(); err != nil {
+		return bytesRead, &cbg.DecodeError{Type: "EmbedByPointerStruct", Err: err}
+	}
+	defer limits.Leave()
 
 	maj, extra, read, err := cbg.CborReadHeaderBuf(br, scratch)
 	if err != nil {
@@ -4588,11 +4905,14 @@ func (t *EmbedByPointerStruct) UnmarshalCBOR(r io.Reader) (int, error) {
 	}
 	bytesRead += read
 	if maj != cbg.MajMap {
-		return bytesRead, fmt.Errorf("cbor input should be of type map")
+		return bytesRead, &cbg.DecodeError{Type: "EmbedByPointerStruct", Expected: cbg.MajMap, Found: maj, Err: cbg.ErrUnexpectedType}
 	}
 
 	if extra > cbg.MaxLength {
-		return bytesRead, fmt.Errorf("EmbedByPointerStruct: map struct too large (%d)", extra)
+		return bytesRead, &cbg.DecodeError{Type: "EmbedByPointerStruct", Expected: cbg.MajMap, Found: maj, Err: cbg.ErrMaxLength}
+	}
+	if err := limits.AddItems(extra); err != nil {
+		return bytesRead, &cbg.DecodeError{Type: "EmbedByPointerStruct", Expected: cbg.MajMap, Found: maj, Err: err}
 	}
 
 	var name string
@@ -4603,7 +4923,7 @@ func (t *EmbedByPointerStruct) UnmarshalCBOR(r io.Reader) (int, error) {
 		{
 			sval, read, err := cbg.ReadStringBuf(br, scratch)
 			if err != nil {
-				return bytesRead, err
+				return bytesRead, cbg.WrapDecodeError(err, "EmbedByPointerStruct", "", bytesRead)
 			}
 			bytesRead += read
 
@@ -4617,7 +4937,7 @@ func (t *EmbedByPointerStruct) UnmarshalCBOR(r io.Reader) (int, error) {
 			{
 				sval, read, err := cbg.ReadStringBuf(br, scratch)
 				if err != nil {
-					return bytesRead, err
+					return bytesRead, cbg.WrapDecodeError(err, "EmbedByPointerStruct", "Foo", bytesRead)
 				}
 				bytesRead += read
 
@@ -4630,11 +4950,11 @@ func (t *EmbedByPointerStruct) UnmarshalCBOR(r io.Reader) (int, error) {
 
 				maj, extra, read, err = cbg.CborReadHeaderBuf(br, scratch)
 				if err != nil {
-					return bytesRead, err
+					return bytesRead, cbg.WrapDecodeError(err, "EmbedByPointerStruct", "Value", bytesRead)
 				}
 				bytesRead += read
 				if maj != cbg.MajUnsignedInt {
-					return bytesRead, fmt.Errorf("wrong type for uint64 field")
+					return bytesRead, &cbg.DecodeError{Type: "EmbedByPointerStruct", Field: "Value", Offset: bytesRead - read, Expected: cbg.MajUnsignedInt, Found: maj, Err: cbg.ErrUnexpectedType}
 				}
 				t.Value = uint64(extra)
 
@@ -4644,15 +4964,18 @@ func (t *EmbedByPointerStruct) UnmarshalCBOR(r io.Reader) (int, error) {
 
 			maj, extra, read, err = cbg.CborReadHeaderBuf(br, scratch)
 			if err != nil {
-				return bytesRead, err
+				return bytesRead, cbg.WrapDecodeError(err, "EmbedByPointerStruct", "Binary", bytesRead)
 			}
 			bytesRead += read
 
 			if extra > cbg.ByteArrayMaxLen {
-				return bytesRead, fmt.Errorf("t.Binary: byte array too large (%d)", extra)
+				return bytesRead, &cbg.DecodeError{Type: "EmbedByPointerStruct", Field: "Binary", Offset: bytesRead - read, Expected: cbg.MajByteString, Found: maj, Err: cbg.ErrMaxLength}
 			}
 			if maj != cbg.MajByteString {
-				return bytesRead, fmt.Errorf("expected byte array")
+				return bytesRead, &cbg.DecodeError{Type: "EmbedByPointerStruct", Field: "Binary", Offset: bytesRead - read, Expected: cbg.MajByteString, Found: maj, Err: cbg.ErrUnexpectedType}
+			}
+			if err := limits.AddBytes(extra); err != nil {
+				return bytesRead, &cbg.DecodeError{Type: "EmbedByPointerStruct", Field: "Binary", Offset: bytesRead - read, Expected: cbg.MajByteString, Found: maj, Err: err}
 			}
 
 			if extra > 0 {
@@ -4660,7 +4983,7 @@ func (t *EmbedByPointerStruct) UnmarshalCBOR(r io.Reader) (int, error) {
 			}
 
 			if read, err := io.ReadFull(br, t.Binary[:]); err != nil {
-				return bytesRead, err
+				return bytesRead, cbg.WrapDecodeError(err, "EmbedByPointerStruct", "Binary", bytesRead)
 			} else {
 				bytesRead += read
 			}
@@ -4670,23 +4993,23 @@ func (t *EmbedByPointerStruct) UnmarshalCBOR(r io.Reader) (int, error) {
 				maj, extra, read, err := cbg.CborReadHeaderBuf(br, scratch)
 				var extraI int64
 				if err != nil {
-					return bytesRead, err
+					return bytesRead, cbg.WrapDecodeError(err, "EmbedByPointerStruct", "Signed", bytesRead)
 				}
 				bytesRead += read
 				switch maj {
 				case cbg.MajUnsignedInt:
 					extraI = int64(extra)
 					if extraI < 0 {
-						return bytesRead, fmt.Errorf("int64 positive overflow")
+						return bytesRead, &cbg.DecodeError{Type: "EmbedByPointerStruct", Field: "Signed", Offset: bytesRead - read, Expected: maj, Found: maj, Err: cbg.ErrOverflow}
 					}
 				case cbg.MajNegativeInt:
 					extraI = int64(extra)
 					if extraI < 0 {
-						return bytesRead, fmt.Errorf("int64 negative oveflow")
+						return bytesRead, &cbg.DecodeError{Type: "EmbedByPointerStruct", Field: "Signed", Offset: bytesRead - read, Expected: maj, Found: maj, Err: cbg.ErrOverflow}
 					}
 					extraI = -1 - extraI
 				default:
-					return bytesRead, fmt.Errorf("wrong type for int64 field: %d", maj)
+					return bytesRead, &cbg.DecodeError{Type: "EmbedByPointerStruct", Field: "Signed", Offset: bytesRead - read, Expected: cbg.MajUnsignedInt, Found: maj, Err: cbg.ErrUnexpectedType}
 				}
 
 				t.Signed = int64(extraI)
@@ -4697,7 +5020,7 @@ func (t *EmbedByPointerStruct) UnmarshalCBOR(r io.Reader) (int, error) {
 			{
 				sval, read, err := cbg.ReadStringBuf(br, scratch)
 				if err != nil {
-					return bytesRead, err
+					return bytesRead, cbg.WrapDecodeError(err, "EmbedByPointerStruct", "NString", bytesRead)
 				}
 				bytesRead += read
 
@@ -4706,7 +5029,9 @@ func (t *EmbedByPointerStruct) UnmarshalCBOR(r io.Reader) (int, error) {
 
 		default:
 			// Field doesn't exist on this type, so ignore it
-			if read, err := cbg.ScanForLinks(r, func(cid.Cid) {}); err == nil {
+			if read, err := cbg.ScanForLinks(br, func(cid.Cid) {}); err != nil {
+				return bytesRead, cbg.WrapDecodeError(err, "EmbedByPointerStruct", "", bytesRead)
+			} else {
 				bytesRead += read
 			}
 		}
@@ -4714,3 +5039,27 @@ func (t *EmbedByPointerStruct) UnmarshalCBOR(r io.Reader) (int, error) {
 
 	return bytesRead, nil
 }
+
+func (t *EmbedByPointerStruct) MarshalDAGJSON(w io.Writer) error {
+	return cbg.MarshalDAGJSON(w, t)
+}
+
+func (t *EmbedByPointerStruct) UnmarshalDAGJSON(r io.Reader) error {
+	return cbg.UnmarshalDAGJSON(r, t)
+}
+
+func (t *EmbedByPointerStruct) Links() []cid.Cid {
+	var links []cid.Cid
+	_ = t.ForEachLink(func(_ string, c cid.Cid) error {
+		links = append(links, c)
+		return nil
+	})
+	return links
+}
+
+func (t *EmbedByPointerStruct) ForEachLink(cb func(path string, c cid.Cid) error) error {
+	if t == nil {
+		return nil
+	}
+	return nil
+}
@@ -353,6 +353,11 @@ func (t *EmbeddingStructOne) UnmarshalCBOR(r io.Reader) (int, error) {
 
 	br := cbg.GetPeeker(r)
 	scratch := make([]byte, 8)
+	limits := cbg.GetDecodeLimits(br)
+	if err := limits.Enter(); err != nil {
+		return bytesRead, &cbg.DecodeError{Type: "EmbeddingStructOne", Err: err}
+	}
+	defer limits.Leave()
 
 	maj, extra, read, err := cbg.CborReadHeaderBuf(br, scratch)
 	if err != nil {
@@ -360,11 +365,14 @@ func (t *EmbeddingStructOne) UnmarshalCBOR(r io.Reader) (int, error) {
 	}
 	bytesRead += read
 	if maj != cbg.MajArray {
-		return bytesRead, fmt.Errorf("cbor input should be of type array")
+		return bytesRead, &cbg.DecodeError{Type: "EmbeddingStructOne", Expected: cbg.MajArray, Found: maj, Err: cbg.ErrUnexpectedType}
 	}
 
 	if extra != 20 {
-		return bytesRead, fmt.Errorf("cbor input had wrong number of fields")
+		return bytesRead, &cbg.DecodeError{Type: "EmbeddingStructOne", Expected: cbg.MajArray, Found: maj, Err: fmt.Errorf("cbor input had wrong number of fields (%d)", extra)}
+	}
+	if err := limits.AddItems(extra); err != nil {
+		return bytesRead, &cbg.DecodeError{Type: "EmbeddingStructOne", Expected: cbg.MajArray, Found: maj, Err: err}
 	}
 
 	// t.Value (uint64) (uint64)
@@ -373,11 +381,11 @@ func (t *EmbeddingStructOne) UnmarshalCBOR(r io.Reader) (int, error) {
 
 		maj, extra, read, err = cbg.CborReadHeaderBuf(br, scratch)
 		if err != nil {
-			return bytesRead, err
+			return bytesRead, cbg.WrapDecodeError(err, "EmbeddingStructOne", "Value", bytesRead)
 		}
 		bytesRead += read
 		if maj != cbg.MajUnsignedInt {
-			return bytesRead, fmt.Errorf("wrong type for uint64 field")
+			return bytesRead, &cbg.DecodeError{Type: "EmbeddingStructOne", Field: "Value", Offset: bytesRead - read, Expected: cbg.MajUnsignedInt, Found: maj, Err: cbg.ErrUnexpectedType}
 		}
 		t.Value = uint64(extra)
 
@@ -386,15 +394,18 @@ func (t *EmbeddingStructOne) UnmarshalCBOR(r io.Reader) (int, error) {
 
 	maj, extra, read, err = cbg.CborReadHeaderBuf(br, scratch)
 	if err != nil {
-		return bytesRead, err
+		return bytesRead, cbg.WrapDecodeError(err, "EmbeddingStructOne", "Binary", bytesRead)
 	}
 	bytesRead += read
 
 	if extra > cbg.ByteArrayMaxLen {
-		return bytesRead, fmt.Errorf("t.Binary: byte array too large (%d)", extra)
+		return bytesRead, &cbg.DecodeError{Type: "EmbeddingStructOne", Field: "Binary", Offset: bytesRead - read, Expected: cbg.MajByteString, Found: maj, Err: cbg.ErrMaxLength}
 	}
 	if maj != cbg.MajByteString {
-		return bytesRead, fmt.Errorf("expected byte array")
+		return bytesRead, &cbg.DecodeError{Type: "EmbeddingStructOne", Field: "Binary", Offset: bytesRead - read, Expected: cbg.MajByteString, Found: maj, Err: cbg.ErrUnexpectedType}
+	}
+	if err := limits.AddBytes(extra); err != nil {
+		return bytesRead, &cbg.DecodeError{Type: "EmbeddingStructOne", Field: "Binary", Offset: bytesRead - read, Expected: cbg.MajByteString, Found: maj, Err: err}
 	}
 
 	if extra > 0 {
@@ -402,7 +413,7 @@ func (t *EmbeddingStructOne) UnmarshalCBOR(r io.Reader) (int, error) {
 	}
 
 	if read, err := io.ReadFull(br, t.Binary[:]); err != nil {
-		return bytesRead, err
+		return bytesRead, cbg.WrapDecodeError(err, "EmbeddingStructOne", "Binary", bytesRead)
 	} else {
 		bytesRead += read
 	}
@@ -411,23 +422,23 @@ func (t *EmbeddingStructOne) UnmarshalCBOR(r io.Reader) (int, error) {
 		maj, extra, read, err := cbg.CborReadHeaderBuf(br, scratch)
 		var extraI int64
 		if err != nil {
-			return bytesRead, err
+			return bytesRead, cbg.WrapDecodeError(err, "EmbeddingStructOne", "Signed", bytesRead)
 		}
 		bytesRead += read
 		switch maj {
 		case cbg.MajUnsignedInt:
 			extraI = int64(extra)
 			if extraI < 0 {
-				return bytesRead, fmt.Errorf("int64 positive overflow")
+				return bytesRead, &cbg.DecodeError{Type: "EmbeddingStructOne", Field: "Signed", Offset: bytesRead - read, Expected: maj, Found: maj, Err: cbg.ErrOverflow}
 			}
 		case cbg.MajNegativeInt:
 			extraI = int64(extra)
 			if extraI < 0 {
-				return bytesRead, fmt.Errorf("int64 negative oveflow")
+				return bytesRead, &cbg.DecodeError{Type: "EmbeddingStructOne", Field: "Signed", Offset: bytesRead - read, Expected: maj, Found: maj, Err: cbg.ErrOverflow}
 			}
 			extraI = -1 - extraI
 		default:
-			return bytesRead, fmt.Errorf("wrong type for int64 field: %d", maj)
+			return bytesRead, &cbg.DecodeError{Type: "EmbeddingStructOne", Field: "Signed", Offset: bytesRead - read, Expected: cbg.MajUnsignedInt, Found: maj, Err: cbg.ErrUnexpectedType}
 		}
 
 		t.Signed = int64(extraI)
@@ -437,7 +448,7 @@ func (t *EmbeddingStructOne) UnmarshalCBOR(r io.Reader) (int, error) {
 	{
 		sval, read, err := cbg.ReadStringBuf(br, scratch)
 		if err != nil {
-			return bytesRead, err
+			return bytesRead, cbg.WrapDecodeError(err, "EmbeddingStructOne", "NString", bytesRead)
 		}
 		bytesRead += read
 
@@ -447,42 +458,42 @@ func (t *EmbeddingStructOne) UnmarshalCBOR(r io.Reader) (int, error) {
 
 	maj, extra, read, err = cbg.CborReadHeaderBuf(br, scratch)
 	if err != nil {
-		return bytesRead, err
+		return bytesRead, cbg.WrapDecodeError(err, "EmbeddingStructOne", "U8", bytesRead)
 	}
 	bytesRead += read
 	if maj != cbg.MajUnsignedInt {
-		return bytesRead, fmt.Errorf("wrong type for uint64 field")
+		return bytesRead, &cbg.DecodeError{Type: "EmbeddingStructOne", Field: "U8", Offset: bytesRead - read, Expected: cbg.MajUnsignedInt, Found: maj, Err: cbg.ErrUnexpectedType}
 	}
-	if extra > math.MaxUint64 {
-		return bytesRead, fmt.Errorf("integer in input was too large for uint64 field")
+	if extra > math.MaxUint8 {
+		return bytesRead, &cbg.DecodeError{Type: "EmbeddingStructOne", Field: "U8", Offset: bytesRead - read, Expected: cbg.MajUnsignedInt, Found: maj, Err: cbg.ErrOverflow}
 	}
 	t.U8 = uint8(extra)
 	// t.U16 (uint16) (uint16)
 
 	maj, extra, read, err = cbg.CborReadHeaderBuf(br, scratch)
 	if err != nil {
-		return bytesRead, err
+		return bytesRead, cbg.WrapDecodeError(err, "EmbeddingStructOne", "U16", bytesRead)
 	}
 	bytesRead += read
 	if maj != cbg.MajUnsignedInt {
-		return bytesRead, fmt.Errorf("wrong type for uint64 field")
+		return bytesRead, &cbg.DecodeError{Type: "EmbeddingStructOne", Field: "U16", Offset: bytesRead - read, Expected: cbg.MajUnsignedInt, Found: maj, Err: cbg.ErrUnexpectedType}
 	}
-	if extra > math.MaxUint64 {
-		return bytesRead, fmt.Errorf("integer in input was too large for uint64 field")
+	if extra > math.MaxUint16 {
+		return bytesRead, &cbg.DecodeError{Type: "EmbeddingStructOne", Field: "U16", Offset: bytesRead - read, Expected: cbg.MajUnsignedInt, Found: maj, Err: cbg.ErrOverflow}
 	}
 	t.U16 = uint16(extra)
 	// t.U32 (uint32) (uint32)
 
 	maj, extra, read, err = cbg.CborReadHeaderBuf(br, scratch)
 	if err != nil {
-		return bytesRead, err
+		return bytesRead, cbg.WrapDecodeError(err, "EmbeddingStructOne", "U32", bytesRead)
 	}
 	bytesRead += read
 	if maj != cbg.MajUnsignedInt {
-		return bytesRead, fmt.Errorf("wrong type for uint64 field")
+		return bytesRead, &cbg.DecodeError{Type: "EmbeddingStructOne", Field: "U32", Offset: bytesRead - read, Expected: cbg.MajUnsignedInt, Found: maj, Err: cbg.ErrUnexpectedType}
 	}
-	if extra > math.MaxUint64 {
-		return bytesRead, fmt.Errorf("integer in input was too large for uint64 field")
+	if extra > math.MaxUint32 {
+		return bytesRead, &cbg.DecodeError{Type: "EmbeddingStructOne", Field: "U32", Offset: bytesRead - read, Expected: cbg.MajUnsignedInt, Found: maj, Err: cbg.ErrOverflow}
 	}
 	t.U32 = uint32(extra)
 	// t.I8 (int8) (int8)
@@ -490,23 +501,27 @@ func (t *EmbeddingStructOne) UnmarshalCBOR(r io.Reader) (int, error) {
 		maj, extra, read, err := cbg.CborReadHeaderBuf(br, scratch)
 		var extraI int64
 		if err != nil {
-			return bytesRead, err
+			return bytesRead, cbg.WrapDecodeError(err, "EmbeddingStructOne", "I8", bytesRead)
 		}
 		bytesRead += read
 		switch maj {
 		case cbg.MajUnsignedInt:
 			extraI = int64(extra)
 			if extraI < 0 {
-				return bytesRead, fmt.Errorf("int64 positive overflow")
+				return bytesRead, &cbg.DecodeError{Type: "EmbeddingStructOne", Field: "I8", Offset: bytesRead - read, Expected: maj, Found: maj, Err: cbg.ErrOverflow}
 			}
 		case cbg.MajNegativeInt:
 			extraI = int64(extra)
 			if extraI < 0 {
-				return bytesRead, fmt.Errorf("int64 negative oveflow")
+				return bytesRead, &cbg.DecodeError{Type: "EmbeddingStructOne", Field: "I8", Offset: bytesRead - read, Expected: maj, Found: maj, Err: cbg.ErrOverflow}
 			}
 			extraI = -1 - extraI
 		default:
-			return bytesRead, fmt.Errorf("wrong type for int64 field: %d", maj)
+			return bytesRead, &cbg.DecodeError{Type: "EmbeddingStructOne", Field: "I8", Offset: bytesRead - read, Expected: cbg.MajUnsignedInt, Found: maj, Err: cbg.ErrUnexpectedType}
+		}
+
+		if extraI > math.MaxInt8 || extraI < math.MinInt8 {
+			return bytesRead, &cbg.DecodeError{Type: "EmbeddingStructOne", Field: "I8", Offset: bytesRead - read, Expected: maj, Found: maj, Err: cbg.ErrOverflow}
 		}
 
 		t.I8 = int8(extraI)
@@ -516,23 +531,27 @@ func (t *EmbeddingStructOne) UnmarshalCBOR(r io.Reader) (int, error) {
 		maj, extra, read, err := cbg.CborReadHeaderBuf(br, scratch)
 		var extraI int64
 		if err != nil {
-			return bytesRead, err
+			return bytesRead, cbg.WrapDecodeError(err, "EmbeddingStructOne", "I16", bytesRead)
 		}
 		bytesRead += read
 		switch maj {
 		case cbg.MajUnsignedInt:
 			extraI = int64(extra)
 			if extraI < 0 {
-				return bytesRead, fmt.Errorf("int64 positive overflow")
+				return bytesRead, &cbg.DecodeError{Type: "EmbeddingStructOne", Field: "I16", Offset: bytesRead - read, Expected: maj, Found: maj, Err: cbg.ErrOverflow}
 			}
 		case cbg.MajNegativeInt:
 			extraI = int64(extra)
 			if extraI < 0 {
-				return bytesRead, fmt.Errorf("int64 negative oveflow")
+				return bytesRead, &cbg.DecodeError{Type: "EmbeddingStructOne", Field: "I16", Offset: bytesRead - read, Expected: maj, Found: maj, Err: cbg.ErrOverflow}
 			}
 			extraI = -1 - extraI
 		default:
-			return bytesRead, fmt.Errorf("wrong type for int64 field: %d", maj)
+			return bytesRead, &cbg.DecodeError{Type: "EmbeddingStructOne", Field: "I16", Offset: bytesRead - read, Expected: cbg.MajUnsignedInt, Found: maj, Err: cbg.ErrUnexpectedType}
+		}
+
+		if extraI > math.MaxInt16 || extraI < math.MinInt16 {
+			return bytesRead, &cbg.DecodeError{Type: "EmbeddingStructOne", Field: "I16", Offset: bytesRead - read, Expected: maj, Found: maj, Err: cbg.ErrOverflow}
 		}
 
 		t.I16 = int16(extraI)
@@ -542,23 +561,27 @@ func (t *EmbeddingStructOne) UnmarshalCBOR(r io.Reader) (int, error) {
 		maj, extra, read, err := cbg.CborReadHeaderBuf(br, scratch)
 		var extraI int64
 		if err != nil {
-			return bytesRead, err
+			return bytesRead, cbg.WrapDecodeError(err, "EmbeddingStructOne", "I32", bytesRead)
 		}
 		bytesRead += read
 		switch maj {
 		case cbg.MajUnsignedInt:
 			extraI = int64(extra)
 			if extraI < 0 {
-				return bytesRead, fmt.Errorf("int64 positive overflow")
+				return bytesRead, &cbg.DecodeError{Type: "EmbeddingStructOne", Field: "I32", Offset: bytesRead - read, Expected: maj, Found: maj, Err: cbg.ErrOverflow}
 			}
 		case cbg.MajNegativeInt:
 			extraI = int64(extra)
 			if extraI < 0 {
-				return bytesRead, fmt.Errorf("int64 negative oveflow")
+				return bytesRead, &cbg.DecodeError{Type: "EmbeddingStructOne", Field: "I32", Offset: bytesRead - read, Expected: maj, Found: maj, Err: cbg.ErrOverflow}
 			}
 			extraI = -1 - extraI
 		default:
-			return bytesRead, fmt.Errorf("wrong type for int64 field: %d", maj)
+			return bytesRead, &cbg.DecodeError{Type: "EmbeddingStructOne", Field: "I32", Offset: bytesRead - read, Expected: cbg.MajUnsignedInt, Found: maj, Err: cbg.ErrUnexpectedType}
+		}
+
+		if extraI > math.MaxInt32 || extraI < math.MinInt32 {
+			return bytesRead, &cbg.DecodeError{Type: "EmbeddingStructOne", Field: "I32", Offset: bytesRead - read, Expected: maj, Found: maj, Err: cbg.ErrOverflow}
 		}
 
 		t.I32 = int32(extraI)
@@ -567,16 +590,19 @@ func (t *EmbeddingStructOne) UnmarshalCBOR(r io.Reader) (int, error) {
 
 	maj, extra, read, err = cbg.CborReadHeaderBuf(br, scratch)
 	if err != nil {
-		return bytesRead, err
+		return bytesRead, cbg.WrapDecodeError(err, "EmbeddingStructOne", "Test", bytesRead)
 	}
 	bytesRead += read
 
 	if extra > cbg.MaxLength {
-		return bytesRead, fmt.Errorf("t.Test: array too large (%d)", extra)
+		return bytesRead, &cbg.DecodeError{Type: "EmbeddingStructOne", Field: "Test", Offset: bytesRead - read, Expected: cbg.MajArray, Found: maj, Err: cbg.ErrMaxLength}
 	}
 
 	if maj != cbg.MajArray {
-		return bytesRead, fmt.Errorf("expected cbor array")
+		return bytesRead, &cbg.DecodeError{Type: "EmbeddingStructOne", Field: "Test", Offset: bytesRead - read, Expected: cbg.MajArray, Found: maj, Err: cbg.ErrUnexpectedType}
+	}
+	if err := limits.AddItems(extra); err != nil {
+		return bytesRead, &cbg.DecodeError{Type: "EmbeddingStructOne", Field: "Test", Offset: bytesRead - read, Expected: cbg.MajArray, Found: maj, Err: err}
 	}
 
 	if extra > 0 {
@@ -591,15 +617,18 @@ func (t *EmbeddingStructOne) UnmarshalCBOR(r io.Reader) (int, error) {
 
 			maj, extra, read, err = cbg.CborReadHeaderBuf(br, scratch)
 			if err != nil {
-				return bytesRead, err
+				return bytesRead, cbg.WrapDecodeError(err, "EmbeddingStructOne", fmt.Sprintf("Test[%d]", i), bytesRead)
 			}
 			bytesRead += read
 
 			if extra > cbg.ByteArrayMaxLen {
-				return bytesRead, fmt.Errorf("t.Test[i]: byte array too large (%d)", extra)
+				return bytesRead, &cbg.DecodeError{Type: "EmbeddingStructOne", Field: fmt.Sprintf("Test[%d]", i), Offset: bytesRead - read, Expected: cbg.MajByteString, Found: maj, Err: cbg.ErrMaxLength}
 			}
 			if maj != cbg.MajByteString {
-				return bytesRead, fmt.Errorf("expected byte array")
+				return bytesRead, &cbg.DecodeError{Type: "EmbeddingStructOne", Field: fmt.Sprintf("Test[%d]", i), Offset: bytesRead - read, Expected: cbg.MajByteString, Found: maj, Err: cbg.ErrUnexpectedType}
+			}
+			if err := limits.AddBytes(extra); err != nil {
+				return bytesRead, &cbg.DecodeError{Type: "EmbeddingStructOne", Field: fmt.Sprintf("Test[%d]", i), Offset: bytesRead - read, Expected: cbg.MajByteString, Found: maj, Err: err}
 			}
 
 			if extra > 0 {
@@ -607,7 +636,7 @@ func (t *EmbeddingStructOne) UnmarshalCBOR(r io.Reader) (int, error) {
 			}
 
 			if read, err := io.ReadFull(br, t.Test[i][:]); err != nil {
-				return bytesRead, err
+				return bytesRead, cbg.WrapDecodeError(err, "EmbeddingStructOne", fmt.Sprintf("Test[%d]", i), bytesRead)
 			} else {
 				bytesRead += read
 			}
@@ -619,7 +648,7 @@ func (t *EmbeddingStructOne) UnmarshalCBOR(r io.Reader) (int, error) {
 	{
 		sval, read, err := cbg.ReadStringBuf(br, scratch)
 		if err != nil {
-			return bytesRead, err
+			return bytesRead, cbg.WrapDecodeError(err, "EmbeddingStructOne", "Dog", bytesRead)
 		}
 		bytesRead += read
 
@@ -629,16 +658,19 @@ func (t *EmbeddingStructOne) UnmarshalCBOR(r io.Reader) (int, error) {
 
 	maj, extra, read, err = cbg.CborReadHeaderBuf(br, scratch)
 	if err != nil {
-		return bytesRead, err
+		return bytesRead, cbg.WrapDecodeError(err, "EmbeddingStructOne", "Numbers", bytesRead)
 	}
 	bytesRead += read
 
 	if extra > cbg.MaxLength {
-		return bytesRead, fmt.Errorf("t.Numbers: array too large (%d)", extra)
+		return bytesRead, &cbg.DecodeError{Type: "EmbeddingStructOne", Field: "Numbers", Offset: bytesRead - read, Expected: cbg.MajArray, Found: maj, Err: cbg.ErrMaxLength}
 	}
 
 	if maj != cbg.MajArray {
-		return bytesRead, fmt.Errorf("expected cbor array")
+		return bytesRead, &cbg.DecodeError{Type: "EmbeddingStructOne", Field: "Numbers", Offset: bytesRead - read, Expected: cbg.MajArray, Found: maj, Err: cbg.ErrUnexpectedType}
+	}
+	if err := limits.AddItems(extra); err != nil {
+		return bytesRead, &cbg.DecodeError{Type: "EmbeddingStructOne", Field: "Numbers", Offset: bytesRead - read, Expected: cbg.MajArray, Found: maj, Err: err}
 	}
 
 	if extra > 0 {
@@ -649,12 +681,12 @@ func (t *EmbeddingStructOne) UnmarshalCBOR(r io.Reader) (int, error) {
 
 		maj, val, read, err := cbg.CborReadHeaderBuf(br, scratch)
 		if err != nil {
-			return bytesRead, xerrors.Errorf("failed to read uint64 for t.Numbers slice: %w", err)
+			return bytesRead, cbg.WrapDecodeError(err, "EmbeddingStructOne", fmt.Sprintf("Numbers[%d]", i), bytesRead)
 		}
 		bytesRead += read
 
 		if maj != cbg.MajUnsignedInt {
-			return bytesRead, xerrors.Errorf("value read for array t.Numbers was not a uint, instead got %d", maj)
+			return bytesRead, &cbg.DecodeError{Type: "EmbeddingStructOne", Field: fmt.Sprintf("Numbers[%d]", i), Offset: bytesRead - read, Expected: cbg.MajUnsignedInt, Found: maj, Err: cbg.ErrUnexpectedType}
 		}
 
 		t.Numbers[i] = testing.NamedNumber(val)
@@ -666,21 +698,21 @@ func (t *EmbeddingStructOne) UnmarshalCBOR(r io.Reader) (int, error) {
 
 		b, err := br.ReadByte()
 		if err != nil {
-			return bytesRead, err
+			return bytesRead, cbg.WrapDecodeError(err, "EmbeddingStructOne", "Pizza", bytesRead)
 		}
 		bytesRead++
 		if b != cbg.CborNull[0] {
 			if err := br.UnreadByte(); err != nil {
-				return bytesRead, err
+				return bytesRead, cbg.WrapDecodeError(err, "EmbeddingStructOne", "Pizza", bytesRead)
 			}
 			bytesRead--
 			maj, extra, read, err = cbg.CborReadHeaderBuf(br, scratch)
 			if err != nil {
-				return bytesRead, err
+				return bytesRead, cbg.WrapDecodeError(err, "EmbeddingStructOne", "Pizza", bytesRead)
 			}
 			bytesRead += read
 			if maj != cbg.MajUnsignedInt {
-				return bytesRead, fmt.Errorf("wrong type for uint64 field")
+				return bytesRead, &cbg.DecodeError{Type: "EmbeddingStructOne", Field: "Pizza", Offset: bytesRead - read, Expected: cbg.MajUnsignedInt, Found: maj, Err: cbg.ErrUnexpectedType}
 			}
 			typed := uint64(extra)
 			t.Pizza = &typed
@@ -693,21 +725,21 @@ func (t *EmbeddingStructOne) UnmarshalCBOR(r io.Reader) (int, error) {
 
 		b, err := br.ReadByte()
 		if err != nil {
-			return bytesRead, err
+			return bytesRead, cbg.WrapDecodeError(err, "EmbeddingStructOne", "PointyPizza", bytesRead)
 		}
 		bytesRead++
 		if b != cbg.CborNull[0] {
 			if err := br.UnreadByte(); err != nil {
-				return bytesRead, err
+				return bytesRead, cbg.WrapDecodeError(err, "EmbeddingStructOne", "PointyPizza", bytesRead)
 			}
 			bytesRead--
 			maj, extra, read, err = cbg.CborReadHeaderBuf(br, scratch)
 			if err != nil {
-				return bytesRead, err
+				return bytesRead, cbg.WrapDecodeError(err, "EmbeddingStructOne", "PointyPizza", bytesRead)
 			}
 			bytesRead += read
 			if maj != cbg.MajUnsignedInt {
-				return bytesRead, fmt.Errorf("wrong type for uint64 field")
+				return bytesRead, &cbg.DecodeError{Type: "EmbeddingStructOne", Field: "PointyPizza", Offset: bytesRead - read, Expected: cbg.MajUnsignedInt, Found: maj, Err: cbg.ErrUnexpectedType}
 			}
 			typed := testing.NamedNumber(extra)
 			t.PointyPizza = &typed
@@ -718,20 +750,23 @@ func (t *EmbeddingStructOne) UnmarshalCBOR(r io.Reader) (int, error) {
 
 	maj, extra, read, err = cbg.CborReadHeaderBuf(br, scratch)
 	if err != nil {
-		return bytesRead, err
+		return bytesRead, cbg.WrapDecodeError(err, "EmbeddingStructOne", "Arrrrrghay", bytesRead)
 	}
 	bytesRead += read
 
 	if extra > cbg.MaxLength {
-		return bytesRead, fmt.Errorf("t.Arrrrrghay: array too large (%d)", extra)
+		return bytesRead, &cbg.DecodeError{Type: "EmbeddingStructOne", Field: "Arrrrrghay", Offset: bytesRead - read, Expected: cbg.MajArray, Found: maj, Err: cbg.ErrMaxLength}
 	}
 
 	if maj != cbg.MajArray {
-		return bytesRead, fmt.Errorf("expected cbor array")
+		return bytesRead, &cbg.DecodeError{Type: "EmbeddingStructOne", Field: "Arrrrrghay", Offset: bytesRead - read, Expected: cbg.MajArray, Found: maj, Err: cbg.ErrUnexpectedType}
+	}
+	if err := limits.AddItems(extra); err != nil {
+		return bytesRead, &cbg.DecodeError{Type: "EmbeddingStructOne", Field: "Arrrrrghay", Offset: bytesRead - read, Expected: cbg.MajArray, Found: maj, Err: err}
 	}
 
 	if extra != 3 {
-		return bytesRead, fmt.Errorf("expected array to have 3 elements")
+		return bytesRead, &cbg.DecodeError{Type: "EmbeddingStructOne", Field: "Arrrrrghay", Offset: bytesRead - read, Expected: cbg.MajArray, Found: maj, Err: fmt.Errorf("expected 3 elements, got %d", extra)}
 	}
 
 	t.Arrrrrghay = [3]testing.SimpleTypeOne{}
@@ -740,7 +775,7 @@ func (t *EmbeddingStructOne) UnmarshalCBOR(r io.Reader) (int, error) {
 
 		var v testing.SimpleTypeOne
 		if read, err := v.UnmarshalCBOR(br); err != nil {
-			return bytesRead, err
+			return bytesRead, cbg.WrapDecodeError(err, "EmbeddingStructOne", fmt.Sprintf("Arrrrrghay[%d]", i), bytesRead)
 		} else {
 			bytesRead += read
 		}
@@ -753,7 +788,7 @@ func (t *EmbeddingStructOne) UnmarshalCBOR(r io.Reader) (int, error) {
 	{
 		sval, read, err := cbg.ReadStringBuf(br, scratch)
 		if err != nil {
-			return bytesRead, err
+			return bytesRead, cbg.WrapDecodeError(err, "EmbeddingStructOne", "Foo", bytesRead)
 		}
 		bytesRead += read
 
@@ -765,17 +800,17 @@ func (t *EmbeddingStructOne) UnmarshalCBOR(r io.Reader) (int, error) {
 
 		b, err := br.ReadByte()
 		if err != nil {
-			return bytesRead, err
+			return bytesRead, cbg.WrapDecodeError(err, "EmbeddingStructOne", "Stuff", bytesRead)
 		}
 		bytesRead++
 		if b != cbg.CborNull[0] {
 			if err := br.UnreadByte(); err != nil {
-				return bytesRead, err
+				return bytesRead, cbg.WrapDecodeError(err, "EmbeddingStructOne", "Stuff", bytesRead)
 			}
 			bytesRead--
 			t.Stuff = new(testing.SimpleTypeTwo)
 			if read, err := t.Stuff.UnmarshalCBOR(br); err != nil {
-				return bytesRead, xerrors.Errorf("unmarshaling t.Stuff pointer: %w", err)
+				return bytesRead, cbg.WrapDecodeError(err, "EmbeddingStructOne", "Stuff", bytesRead)
 			} else {
 				bytesRead += read
 			}
@@ -786,16 +821,19 @@ func (t *EmbeddingStructOne) UnmarshalCBOR(r io.Reader) (int, error) {
 
 	maj, extra, read, err = cbg.CborReadHeaderBuf(br, scratch)
 	if err != nil {
-		return bytesRead, err
+		return bytesRead, cbg.WrapDecodeError(err, "EmbeddingStructOne", "Others", bytesRead)
 	}
 	bytesRead += read
 
 	if extra > cbg.MaxLength {
-		return bytesRead, fmt.Errorf("t.Others: array too large (%d)", extra)
+		return bytesRead, &cbg.DecodeError{Type: "EmbeddingStructOne", Field: "Others", Offset: bytesRead - read, Expected: cbg.MajArray, Found: maj, Err: cbg.ErrMaxLength}
 	}
 
 	if maj != cbg.MajArray {
-		return bytesRead, fmt.Errorf("expected cbor array")
+		return bytesRead, &cbg.DecodeError{Type: "EmbeddingStructOne", Field: "Others", Offset: bytesRead - read, Expected: cbg.MajArray, Found: maj, Err: cbg.ErrUnexpectedType}
+	}
+	if err := limits.AddItems(extra); err != nil {
+		return bytesRead, &cbg.DecodeError{Type: "EmbeddingStructOne", Field: "Others", Offset: bytesRead - read, Expected: cbg.MajArray, Found: maj, Err: err}
 	}
 
 	if extra > 0 {
@@ -806,12 +844,12 @@ func (t *EmbeddingStructOne) UnmarshalCBOR(r io.Reader) (int, error) {
 
 		maj, val, read, err := cbg.CborReadHeaderBuf(br, scratch)
 		if err != nil {
-			return bytesRead, xerrors.Errorf("failed to read uint64 for t.Others slice: %w", err)
+			return bytesRead, cbg.WrapDecodeError(err, "EmbeddingStructOne", fmt.Sprintf("Others[%d]", i), bytesRead)
 		}
 		bytesRead += read
 
 		if maj != cbg.MajUnsignedInt {
-			return bytesRead, xerrors.Errorf("value read for array t.Others was not a uint, instead got %d", maj)
+			return bytesRead, &cbg.DecodeError{Type: "EmbeddingStructOne", Field: fmt.Sprintf("Others[%d]", i), Offset: bytesRead - read, Expected: cbg.MajUnsignedInt, Found: maj, Err: cbg.ErrUnexpectedType}
 		}
 
 		t.Others[i] = uint64(val)
@@ -821,16 +859,19 @@ func (t *EmbeddingStructOne) UnmarshalCBOR(r io.Reader) (int, error) {
 
 	maj, extra, read, err = cbg.CborReadHeaderBuf(br, scratch)
 	if err != nil {
-		return bytesRead, err
+		return bytesRead, cbg.WrapDecodeError(err, "EmbeddingStructOne", "SignedOthers", bytesRead)
 	}
 	bytesRead += read
 
 	if extra > cbg.MaxLength {
-		return bytesRead, fmt.Errorf("t.SignedOthers: array too large (%d)", extra)
+		return bytesRead, &cbg.DecodeError{Type: "EmbeddingStructOne", Field: "SignedOthers", Offset: bytesRead - read, Expected: cbg.MajArray, Found: maj, Err: cbg.ErrMaxLength}
 	}
 
 	if maj != cbg.MajArray {
-		return bytesRead, fmt.Errorf("expected cbor array")
+		return bytesRead, &cbg.DecodeError{Type: "EmbeddingStructOne", Field: "SignedOthers", Offset: bytesRead - read, Expected: cbg.MajArray, Found: maj, Err: cbg.ErrUnexpectedType}
+	}
+	if err := limits.AddItems(extra); err != nil {
+		return bytesRead, &cbg.DecodeError{Type: "EmbeddingStructOne", Field: "SignedOthers", Offset: bytesRead - read, Expected: cbg.MajArray, Found: maj, Err: err}
 	}
 
 	if extra > 0 {
@@ -842,23 +883,23 @@ func (t *EmbeddingStructOne) UnmarshalCBOR(r io.Reader) (int, error) {
 			maj, extra, read, err := cbg.CborReadHeaderBuf(br, scratch)
 			var extraI int64
 			if err != nil {
-				return bytesRead, err
+				return bytesRead, cbg.WrapDecodeError(err, "EmbeddingStructOne", fmt.Sprintf("SignedOthers[%d]", i), bytesRead)
 			}
 			bytesRead += read
 			switch maj {
 			case cbg.MajUnsignedInt:
 				extraI = int64(extra)
 				if extraI < 0 {
-					return bytesRead, fmt.Errorf("int64 positive overflow")
+					return bytesRead, &cbg.DecodeError{Type: "EmbeddingStructOne", Field: fmt.Sprintf("SignedOthers[%d]", i), Offset: bytesRead - read, Expected: maj, Found: maj, Err: cbg.ErrOverflow}
 				}
 			case cbg.MajNegativeInt:
 				extraI = int64(extra)
 				if extraI < 0 {
-					return bytesRead, fmt.Errorf("int64 negative oveflow")
+					return bytesRead, &cbg.DecodeError{Type: "EmbeddingStructOne", Field: fmt.Sprintf("SignedOthers[%d]", i), Offset: bytesRead - read, Expected: maj, Found: maj, Err: cbg.ErrOverflow}
 				}
 				extraI = -1 - extraI
 			default:
-				return bytesRead, fmt.Errorf("wrong type for int64 field: %d", maj)
+				return bytesRead, &cbg.DecodeError{Type: "EmbeddingStructOne", Field: fmt.Sprintf("SignedOthers[%d]", i), Offset: bytesRead - read, Expected: cbg.MajUnsignedInt, Found: maj, Err: cbg.ErrUnexpectedType}
 			}
 
 			t.SignedOthers[i] = int64(extraI)
@@ -868,6 +909,30 @@ func (t *EmbeddingStructOne) UnmarshalCBOR(r io.Reader) (int, error) {
 	return bytesRead, nil
 }
 
+func (t *EmbeddingStructOne) MarshalDAGJSON(w io.Writer) error {
+	return cbg.MarshalDAGJSON(w, t)
+}
+
+func (t *EmbeddingStructOne) UnmarshalDAGJSON(r io.Reader) error {
+	return cbg.UnmarshalDAGJSON(r, t)
+}
+
+func (t *EmbeddingStructOne) Links() []cid.Cid {
+	var links []cid.Cid
+	_ = t.ForEachLink(func(_ string, c cid.Cid) error {
+		links = append(links, c)
+		return nil
+	})
+	return links
+}
+
+func (t *EmbeddingStructOne) ForEachLink(cb func(path string, c cid.Cid) error) error {
+	if t == nil {
+		return nil
+	}
+	return nil
+}
+
 func (t *EmbeddingStructTwo) InitNilEmbeddedStruct() {
 	if t != nil {
 		if t.EmbeddingStructOne == nil {
@@ -1202,6 +1267,11 @@ func (t *EmbeddingStructTwo) UnmarshalCBOR(r io.Reader) (int, error) {
 
 	br := cbg.GetPeeker(r)
 	scratch := make([]byte, 8)
+	limits := cbg.GetDecodeLimits(br)
+	if err := limits.Enter(); err != nil {
+		return bytesRead, &cbg.DecodeError{Type: "EmbeddingStructTwo", Err: err}
+	}
+	defer limits.Leave()
 
 	maj, extra, read, err := cbg.CborReadHeaderBuf(br, scratch)
 	if err != nil {
@@ -1209,26 +1279,32 @@ func (t *EmbeddingStructTwo) UnmarshalCBOR(r io.Reader) (int, error) {
 	}
 	bytesRead += read
 	if maj != cbg.MajArray {
-		return bytesRead, fmt.Errorf("cbor input should be of type array")
+		return bytesRead, &cbg.DecodeError{Type: "EmbeddingStructTwo", Expected: cbg.MajArray, Found: maj, Err: cbg.ErrUnexpectedType}
 	}
 
 	if extra != 20 {
-		return bytesRead, fmt.Errorf("cbor input had wrong number of fields")
+		return bytesRead, &cbg.DecodeError{Type: "EmbeddingStructTwo", Expected: cbg.MajArray, Found: maj, Err: fmt.Errorf("cbor input had wrong number of fields (%d)", extra)}
+	}
+	if err := limits.AddItems(extra); err != nil {
+		return bytesRead, &cbg.DecodeError{Type: "EmbeddingStructTwo", Expected: cbg.MajArray, Found: maj, Err: err}
 	}
 
 	// t.Binary ([]uint8) (slice)
 
 	maj, extra, read, err = cbg.CborReadHeaderBuf(br, scratch)
 	if err != nil {
-		return bytesRead, err
+		return bytesRead, cbg.WrapDecodeError(err, "EmbeddingStructTwo", "Binary", bytesRead)
 	}
 	bytesRead += read
 
 	if extra > cbg.ByteArrayMaxLen {
-		return bytesRead, fmt.Errorf("t.Binary: byte array too large (%d)", extra)
+		return bytesRead, &cbg.DecodeError{Type: "EmbeddingStructTwo", Field: "Binary", Offset: bytesRead - read, Expected: cbg.MajByteString, Found: maj, Err: cbg.ErrMaxLength}
 	}
 	if maj != cbg.MajByteString {
-		return bytesRead, fmt.Errorf("expected byte array")
+		return bytesRead, &cbg.DecodeError{Type: "EmbeddingStructTwo", Field: "Binary", Offset: bytesRead - read, Expected: cbg.MajByteString, Found: maj, Err: cbg.ErrUnexpectedType}
+	}
+	if err := limits.AddBytes(extra); err != nil {
+		return bytesRead, &cbg.DecodeError{Type: "EmbeddingStructTwo", Field: "Binary", Offset: bytesRead - read, Expected: cbg.MajByteString, Found: maj, Err: err}
 	}
 
 	if extra > 0 {
@@ -1236,7 +1312,7 @@ func (t *EmbeddingStructTwo) UnmarshalCBOR(r io.Reader) (int, error) {
 	}
 
 	if read, err := io.ReadFull(br, t.Binary[:]); err != nil {
-		return bytesRead, err
+		return bytesRead, cbg.WrapDecodeError(err, "EmbeddingStructTwo", "Binary", bytesRead)
 	} else {
 		bytesRead += read
 	}
@@ -1245,23 +1321,23 @@ func (t *EmbeddingStructTwo) UnmarshalCBOR(r io.Reader) (int, error) {
 		maj, extra, read, err := cbg.CborReadHeaderBuf(br, scratch)
 		var extraI int64
 		if err != nil {
-			return bytesRead, err
+			return bytesRead, cbg.WrapDecodeError(err, "EmbeddingStructTwo", "Signed", bytesRead)
 		}
 		bytesRead += read
 		switch maj {
 		case cbg.MajUnsignedInt:
 			extraI = int64(extra)
 			if extraI < 0 {
-				return bytesRead, fmt.Errorf("int64 positive overflow")
+				return bytesRead, &cbg.DecodeError{Type: "EmbeddingStructTwo", Field: "Signed", Offset: bytesRead - read, Expected: maj, Found: maj, Err: cbg.ErrOverflow}
 			}
 		case cbg.MajNegativeInt:
 			extraI = int64(extra)
 			if extraI < 0 {
-				return bytesRead, fmt.Errorf("int64 negative oveflow")
+				return bytesRead, &cbg.DecodeError{Type: "EmbeddingStructTwo", Field: "Signed", Offset: bytesRead - read, Expected: maj, Found: maj, Err: cbg.ErrOverflow}
 			}
 			extraI = -1 - extraI
 		default:
-			return bytesRead, fmt.Errorf("wrong type for int64 field: %d", maj)
+			return bytesRead, &cbg.DecodeError{Type: "EmbeddingStructTwo", Field: "Signed", Offset: bytesRead - read, Expected: cbg.MajUnsignedInt, Found: maj, Err: cbg.ErrUnexpectedType}
 		}
 
 		t.Signed = int64(extraI)
@@ -1271,7 +1347,7 @@ func (t *EmbeddingStructTwo) UnmarshalCBOR(r io.Reader) (int, error) {
 	{
 		sval, read, err := cbg.ReadStringBuf(br, scratch)
 		if err != nil {
-			return bytesRead, err
+			return bytesRead, cbg.WrapDecodeError(err, "EmbeddingStructTwo", "NString", bytesRead)
 		}
 		bytesRead += read
 
@@ -1281,42 +1357,42 @@ func (t *EmbeddingStructTwo) UnmarshalCBOR(r io.Reader) (int, error) {
 
 	maj, extra, read, err = cbg.CborReadHeaderBuf(br, scratch)
 	if err != nil {
-		return bytesRead, err
+		return bytesRead, cbg.WrapDecodeError(err, "EmbeddingStructTwo", "U8", bytesRead)
 	}
 	bytesRead += read
 	if maj != cbg.MajUnsignedInt {
-		return bytesRead, fmt.Errorf("wrong type for uint64 field")
+		return bytesRead, &cbg.DecodeError{Type: "EmbeddingStructTwo", Field: "U8", Offset: bytesRead - read, Expected: cbg.MajUnsignedInt, Found: maj, Err: cbg.ErrUnexpectedType}
 	}
-	if extra > math.MaxUint64 {
-		return bytesRead, fmt.Errorf("integer in input was too large for uint64 field")
+	if extra > math.MaxUint8 {
+		return bytesRead, &cbg.DecodeError{Type: "EmbeddingStructTwo", Field: "U8", Offset: bytesRead - read, Expected: cbg.MajUnsignedInt, Found: maj, Err: cbg.ErrOverflow}
 	}
 	t.U8 = uint8(extra)
 	// t.U16 (uint16) (uint16)
 
 	maj, extra, read, err = cbg.CborReadHeaderBuf(br, scratch)
 	if err != nil {
-		return bytesRead, err
+		return bytesRead, cbg.WrapDecodeError(err, "EmbeddingStructTwo", "U16", bytesRead)
 	}
 	bytesRead += read
 	if maj != cbg.MajUnsignedInt {
-		return bytesRead, fmt.Errorf("wrong type for uint64 field")
+		return bytesRead, &cbg.DecodeError{Type: "EmbeddingStructTwo", Field: "U16", Offset: bytesRead - read, Expected: cbg.MajUnsignedInt, Found: maj, Err: cbg.ErrUnexpectedType}
 	}
-	if extra > math.MaxUint64 {
-		return bytesRead, fmt.Errorf("integer in input was too large for uint64 field")
+	if extra > math.MaxUint16 {
+		return bytesRead, &cbg.DecodeError{Type: "EmbeddingStructTwo", Field: "U16", Offset: bytesRead - read, Expected: cbg.MajUnsignedInt, Found: maj, Err: cbg.ErrOverflow}
 	}
 	t.U16 = uint16(extra)
 	// t.U32 (uint32) (uint32)
 
 	maj, extra, read, err = cbg.CborReadHeaderBuf(br, scratch)
 	if err != nil {
-		return bytesRead, err
+		return bytesRead, cbg.WrapDecodeError(err, "EmbeddingStructTwo", "U32", bytesRead)
 	}
 	bytesRead += read
 	if maj != cbg.MajUnsignedInt {
-		return bytesRead, fmt.Errorf("wrong type for uint64 field")
+		return bytesRead, &cbg.DecodeError{Type: "EmbeddingStructTwo", Field: "U32", Offset: bytesRead - read, Expected: cbg.MajUnsignedInt, Found: maj, Err: cbg.ErrUnexpectedType}
 	}
-	if extra > math.MaxUint64 {
-		return bytesRead, fmt.Errorf("integer in input was too large for uint64 field")
+	if extra > math.MaxUint32 {
+		return bytesRead, &cbg.DecodeError{Type: "EmbeddingStructTwo", Field: "U32", Offset: bytesRead - read, Expected: cbg.MajUnsignedInt, Found: maj, Err: cbg.ErrOverflow}
 	}
 	t.U32 = uint32(extra)
 	// t.I8 (int8) (int8)
@@ -1324,23 +1400,27 @@ func (t *EmbeddingStructTwo) UnmarshalCBOR(r io.Reader) (int, error) {
 		maj, extra, read, err := cbg.CborReadHeaderBuf(br, scratch)
 		var extraI int64
 		if err != nil {
-			return bytesRead, err
+			return bytesRead, cbg.WrapDecodeError(err, "EmbeddingStructTwo", "I8", bytesRead)
 		}
 		bytesRead += read
 		switch maj {
 		case cbg.MajUnsignedInt:
 			extraI = int64(extra)
 			if extraI < 0 {
-				return bytesRead, fmt.Errorf("int64 positive overflow")
+				return bytesRead, &cbg.DecodeError{Type: "EmbeddingStructTwo", Field: "I8", Offset: bytesRead - read, Expected: maj, Found: maj, Err: cbg.ErrOverflow}
 			}
 		case cbg.MajNegativeInt:
 			extraI = int64(extra)
 			if extraI < 0 {
-				return bytesRead, fmt.Errorf("int64 negative oveflow")
+				return bytesRead, &cbg.DecodeError{Type: "EmbeddingStructTwo", Field: "I8", Offset: bytesRead - read, Expected: maj, Found: maj, Err: cbg.ErrOverflow}
 			}
 			extraI = -1 - extraI
 		default:
-			return bytesRead, fmt.Errorf("wrong type for int64 field: %d", maj)
+			return bytesRead, &cbg.DecodeError{Type: "EmbeddingStructTwo", Field: "I8", Offset: bytesRead - read, Expected: cbg.MajUnsignedInt, Found: maj, Err: cbg.ErrUnexpectedType}
+		}
+
+		if extraI > math.MaxInt8 || extraI < math.MinInt8 {
+			return bytesRead, &cbg.DecodeError{Type: "EmbeddingStructTwo", Field: "I8", Offset: bytesRead - read, Expected: maj, Found: maj, Err: cbg.ErrOverflow}
 		}
 
 		t.I8 = int8(extraI)
@@ -1350,23 +1430,27 @@ func (t *EmbeddingStructTwo) UnmarshalCBOR(r io.Reader) (int, error) {
 		maj, extra, read, err := cbg.CborReadHeaderBuf(br, scratch)
 		var extraI int64
 		if err != nil {
-			return bytesRead, err
+			return bytesRead, cbg.WrapDecodeError(err, "EmbeddingStructTwo", "I16", bytesRead)
 		}
 		bytesRead += read
 		switch maj {
 		case cbg.MajUnsignedInt:
 			extraI = int64(extra)
 			if extraI < 0 {
-				return bytesRead, fmt.Errorf("int64 positive overflow")
+				return bytesRead, &cbg.DecodeError{Type: "EmbeddingStructTwo", Field: "I16", Offset: bytesRead - read, Expected: maj, Found: maj, Err: cbg.ErrOverflow}
 			}
 		case cbg.MajNegativeInt:
 			extraI = int64(extra)
 			if extraI < 0 {
-				return bytesRead, fmt.Errorf("int64 negative oveflow")
+				return bytesRead, &cbg.DecodeError{Type: "EmbeddingStructTwo", Field: "I16", Offset: bytesRead - read, Expected: maj, Found: maj, Err: cbg.ErrOverflow}
 			}
 			extraI = -1 - extraI
 		default:
-			return bytesRead, fmt.Errorf("wrong type for int64 field: %d", maj)
+			return bytesRead, &cbg.DecodeError{Type: "EmbeddingStructTwo", Field: "I16", Offset: bytesRead - read, Expected: cbg.MajUnsignedInt, Found: maj, Err: cbg.ErrUnexpectedType}
+		}
+
+		if extraI > math.MaxInt16 || extraI < math.MinInt16 {
+			return bytesRead, &cbg.DecodeError{Type: "EmbeddingStructTwo", Field: "I16", Offset: bytesRead - read, Expected: maj, Found: maj, Err: cbg.ErrOverflow}
 		}
 
 		t.I16 = int16(extraI)
@@ -1376,23 +1460,27 @@ func (t *EmbeddingStructTwo) UnmarshalCBOR(r io.Reader) (int, error) {
 		maj, extra, read, err := cbg.CborReadHeaderBuf(br, scratch)
 		var extraI int64
 		if err != nil {
-			return bytesRead, err
+			return bytesRead, cbg.WrapDecodeError(err, "EmbeddingStructTwo", "I32", bytesRead)
 		}
 		bytesRead += read
 		switch maj {
 		case cbg.MajUnsignedInt:
 			extraI = int64(extra)
 			if extraI < 0 {
-				return bytesRead, fmt.Errorf("int64 positive overflow")
+				return bytesRead, &cbg.DecodeError{Type: "EmbeddingStructTwo", Field: "I32", Offset: bytesRead - read, Expected: maj, Found: maj, Err: cbg.ErrOverflow}
 			}
 		case cbg.MajNegativeInt:
 			extraI = int64(extra)
 			if extraI < 0 {
-				return bytesRead, fmt.Errorf("int64 negative oveflow")
+				return bytesRead, &cbg.DecodeError{Type: "EmbeddingStructTwo", Field: "I32", Offset: bytesRead - read, Expected: maj, Found: maj, Err: cbg.ErrOverflow}
 			}
 			extraI = -1 - extraI
 		default:
-			return bytesRead, fmt.Errorf("wrong type for int64 field: %d", maj)
+			return bytesRead, &cbg.DecodeError{Type: "EmbeddingStructTwo", Field: "I32", Offset: bytesRead - read, Expected: cbg.MajUnsignedInt, Found: maj, Err: cbg.ErrUnexpectedType}
+		}
+
+		if extraI > math.MaxInt32 || extraI < math.MinInt32 {
+			return bytesRead, &cbg.DecodeError{Type: "EmbeddingStructTwo", Field: "I32", Offset: bytesRead - read, Expected: maj, Found: maj, Err: cbg.ErrOverflow}
 		}
 
 		t.I32 = int32(extraI)
@@ -1403,21 +1491,21 @@ func (t *EmbeddingStructTwo) UnmarshalCBOR(r io.Reader) (int, error) {
 
 		b, err := br.ReadByte()
 		if err != nil {
-			return bytesRead, err
+			return bytesRead, cbg.WrapDecodeError(err, "EmbeddingStructTwo", "Pizza", bytesRead)
 		}
 		bytesRead++
 		if b != cbg.CborNull[0] {
 			if err := br.UnreadByte(); err != nil {
-				return bytesRead, err
+				return bytesRead, cbg.WrapDecodeError(err, "EmbeddingStructTwo", "Pizza", bytesRead)
 			}
 			bytesRead--
 			maj, extra, read, err = cbg.CborReadHeaderBuf(br, scratch)
 			if err != nil {
-				return bytesRead, err
+				return bytesRead, cbg.WrapDecodeError(err, "EmbeddingStructTwo", "Pizza", bytesRead)
 			}
 			bytesRead += read
 			if maj != cbg.MajUnsignedInt {
-				return bytesRead, fmt.Errorf("wrong type for uint64 field")
+				return bytesRead, &cbg.DecodeError{Type: "EmbeddingStructTwo", Field: "Pizza", Offset: bytesRead - read, Expected: cbg.MajUnsignedInt, Found: maj, Err: cbg.ErrUnexpectedType}
 			}
 			typed := uint64(extra)
 			t.Pizza = &typed
@@ -1430,21 +1518,21 @@ func (t *EmbeddingStructTwo) UnmarshalCBOR(r io.Reader) (int, error) {
 
 		b, err := br.ReadByte()
 		if err != nil {
-			return bytesRead, err
+			return bytesRead, cbg.WrapDecodeError(err, "EmbeddingStructTwo", "PointyPizza", bytesRead)
 		}
 		bytesRead++
 		if b != cbg.CborNull[0] {
 			if err := br.UnreadByte(); err != nil {
-				return bytesRead, err
+				return bytesRead, cbg.WrapDecodeError(err, "EmbeddingStructTwo", "PointyPizza", bytesRead)
 			}
 			bytesRead--
 			maj, extra, read, err = cbg.CborReadHeaderBuf(br, scratch)
 			if err != nil {
-				return bytesRead, err
+				return bytesRead, cbg.WrapDecodeError(err, "EmbeddingStructTwo", "PointyPizza", bytesRead)
 			}
 			bytesRead += read
 			if maj != cbg.MajUnsignedInt {
-				return bytesRead, fmt.Errorf("wrong type for uint64 field")
+				return bytesRead, &cbg.DecodeError{Type: "EmbeddingStructTwo", Field: "PointyPizza", Offset: bytesRead - read, Expected: cbg.MajUnsignedInt, Found: maj, Err: cbg.ErrUnexpectedType}
 			}
 			typed := testing.NamedNumber(extra)
 			t.PointyPizza = &typed
@@ -1455,20 +1543,23 @@ func (t *EmbeddingStructTwo) UnmarshalCBOR(r io.Reader) (int, error) {
 
 	maj, extra, read, err = cbg.CborReadHeaderBuf(br, scratch)
 	if err != nil {
-		return bytesRead, err
+		return bytesRead, cbg.WrapDecodeError(err, "EmbeddingStructTwo", "Arrrrrghay", bytesRead)
 	}
 	bytesRead += read
 
 	if extra > cbg.MaxLength {
-		return bytesRead, fmt.Errorf("t.Arrrrrghay: array too large (%d)", extra)
+		return bytesRead, &cbg.DecodeError{Type: "EmbeddingStructTwo", Field: "Arrrrrghay", Offset: bytesRead - read, Expected: cbg.MajArray, Found: maj, Err: cbg.ErrMaxLength}
 	}
 
 	if maj != cbg.MajArray {
-		return bytesRead, fmt.Errorf("expected cbor array")
+		return bytesRead, &cbg.DecodeError{Type: "EmbeddingStructTwo", Field: "Arrrrrghay", Offset: bytesRead - read, Expected: cbg.MajArray, Found: maj, Err: cbg.ErrUnexpectedType}
+	}
+	if err := limits.AddItems(extra); err != nil {
+		return bytesRead, &cbg.DecodeError{Type: "EmbeddingStructTwo", Field: "Arrrrrghay", Offset: bytesRead - read, Expected: cbg.MajArray, Found: maj, Err: err}
 	}
 
 	if extra != 3 {
-		return bytesRead, fmt.Errorf("expected array to have 3 elements")
+		return bytesRead, &cbg.DecodeError{Type: "EmbeddingStructTwo", Field: "Arrrrrghay", Offset: bytesRead - read, Expected: cbg.MajArray, Found: maj, Err: fmt.Errorf("expected 3 elements, got %d", extra)}
 	}
 
 	t.Arrrrrghay = [3]testing.SimpleTypeOne{}
@@ -1477,7 +1568,7 @@ func (t *EmbeddingStructTwo) UnmarshalCBOR(r io.Reader) (int, error) {
 
 		var v testing.SimpleTypeOne
 		if read, err := v.UnmarshalCBOR(br); err != nil {
-			return bytesRead, err
+			return bytesRead, cbg.WrapDecodeError(err, "EmbeddingStructTwo", fmt.Sprintf("Arrrrrghay[%d]", i), bytesRead)
 		} else {
 			bytesRead += read
 		}
@@ -1490,7 +1581,7 @@ func (t *EmbeddingStructTwo) UnmarshalCBOR(r io.Reader) (int, error) {
 	{
 		sval, read, err := cbg.ReadStringBuf(br, scratch)
 		if err != nil {
-			return bytesRead, err
+			return bytesRead, cbg.WrapDecodeError(err, "EmbeddingStructTwo", "Foo", bytesRead)
 		}
 		bytesRead += read
 
@@ -1502,11 +1593,11 @@ func (t *EmbeddingStructTwo) UnmarshalCBOR(r io.Reader) (int, error) {
 
 		maj, extra, read, err = cbg.CborReadHeaderBuf(br, scratch)
 		if err != nil {
-			return bytesRead, err
+			return bytesRead, cbg.WrapDecodeError(err, "EmbeddingStructTwo", "Value", bytesRead)
 		}
 		bytesRead += read
 		if maj != cbg.MajUnsignedInt {
-			return bytesRead, fmt.Errorf("wrong type for uint64 field")
+			return bytesRead, &cbg.DecodeError{Type: "EmbeddingStructTwo", Field: "Value", Offset: bytesRead - read, Expected: cbg.MajUnsignedInt, Found: maj, Err: cbg.ErrUnexpectedType}
 		}
 		t.Value = uint64(extra)
 
@@ -1517,17 +1608,17 @@ func (t *EmbeddingStructTwo) UnmarshalCBOR(r io.Reader) (int, error) {
 
 		b, err := br.ReadByte()
 		if err != nil {
-			return bytesRead, err
+			return bytesRead, cbg.WrapDecodeError(err, "EmbeddingStructTwo", "Stuff", bytesRead)
 		}
 		bytesRead++
 		if b != cbg.CborNull[0] {
 			if err := br.UnreadByte(); err != nil {
-				return bytesRead, err
+				return bytesRead, cbg.WrapDecodeError(err, "EmbeddingStructTwo", "Stuff", bytesRead)
 			}
 			bytesRead--
 			t.Stuff = new(testing.SimpleTypeTwo)
 			if read, err := t.Stuff.UnmarshalCBOR(br); err != nil {
-				return bytesRead, xerrors.Errorf("unmarshaling t.Stuff pointer: %w", err)
+				return bytesRead, cbg.WrapDecodeError(err, "EmbeddingStructTwo", "Stuff", bytesRead)
 			} else {
 				bytesRead += read
 			}
@@ -1538,16 +1629,19 @@ func (t *EmbeddingStructTwo) UnmarshalCBOR(r io.Reader) (int, error) {
 
 	maj, extra, read, err = cbg.CborReadHeaderBuf(br, scratch)
 	if err != nil {
-		return bytesRead, err
+		return bytesRead, cbg.WrapDecodeError(err, "EmbeddingStructTwo", "Others", bytesRead)
 	}
 	bytesRead += read
 
 	if extra > cbg.MaxLength {
-		return bytesRead, fmt.Errorf("t.Others: array too large (%d)", extra)
+		return bytesRead, &cbg.DecodeError{Type: "EmbeddingStructTwo", Field: "Others", Offset: bytesRead - read, Expected: cbg.MajArray, Found: maj, Err: cbg.ErrMaxLength}
 	}
 
 	if maj != cbg.MajArray {
-		return bytesRead, fmt.Errorf("expected cbor array")
+		return bytesRead, &cbg.DecodeError{Type: "EmbeddingStructTwo", Field: "Others", Offset: bytesRead - read, Expected: cbg.MajArray, Found: maj, Err: cbg.ErrUnexpectedType}
+	}
+	if err := limits.AddItems(extra); err != nil {
+		return bytesRead, &cbg.DecodeError{Type: "EmbeddingStructTwo", Field: "Others", Offset: bytesRead - read, Expected: cbg.MajArray, Found: maj, Err: err}
 	}
 
 	if extra > 0 {
@@ -1558,12 +1652,12 @@ func (t *EmbeddingStructTwo) UnmarshalCBOR(r io.Reader) (int, error) {
 
 		maj, val, read, err := cbg.CborReadHeaderBuf(br, scratch)
 		if err != nil {
-			return bytesRead, xerrors.Errorf("failed to read uint64 for t.Others slice: %w", err)
+			return bytesRead, cbg.WrapDecodeError(err, "EmbeddingStructTwo", fmt.Sprintf("Others[%d]", i), bytesRead)
 		}
 		bytesRead += read
 
 		if maj != cbg.MajUnsignedInt {
-			return bytesRead, xerrors.Errorf("value read for array t.Others was not a uint, instead got %d", maj)
+			return bytesRead, &cbg.DecodeError{Type: "EmbeddingStructTwo", Field: fmt.Sprintf("Others[%d]", i), Offset: bytesRead - read, Expected: cbg.MajUnsignedInt, Found: maj, Err: cbg.ErrUnexpectedType}
 		}
 
 		t.Others[i] = uint64(val)
@@ -1573,16 +1667,19 @@ func (t *EmbeddingStructTwo) UnmarshalCBOR(r io.Reader) (int, error) {
 
 	maj, extra, read, err = cbg.CborReadHeaderBuf(br, scratch)
 	if err != nil {
-		return bytesRead, err
+		return bytesRead, cbg.WrapDecodeError(err, "EmbeddingStructTwo", "SignedOthers", bytesRead)
 	}
 	bytesRead += read
 
 	if extra > cbg.MaxLength {
-		return bytesRead, fmt.Errorf("t.SignedOthers: array too large (%d)", extra)
+		return bytesRead, &cbg.DecodeError{Type: "EmbeddingStructTwo", Field: "SignedOthers", Offset: bytesRead - read, Expected: cbg.MajArray, Found: maj, Err: cbg.ErrMaxLength}
 	}
 
 	if maj != cbg.MajArray {
-		return bytesRead, fmt.Errorf("expected cbor array")
+		return bytesRead, &cbg.DecodeError{Type: "EmbeddingStructTwo", Field: "SignedOthers", Offset: bytesRead - read, Expected: cbg.MajArray, Found: maj, Err: cbg.ErrUnexpectedType}
+	}
+	if err := limits.AddItems(extra); err != nil {
+		return bytesRead, &cbg.DecodeError{Type: "EmbeddingStructTwo", Field: "SignedOthers", Offset: bytesRead - read, Expected: cbg.MajArray, Found: maj, Err: err}
 	}
 
 	if extra > 0 {
@@ -1594,23 +1691,23 @@ func (t *EmbeddingStructTwo) UnmarshalCBOR(r io.Reader) (int, error) {
 			maj, extra, read, err := cbg.CborReadHeaderBuf(br, scratch)
 			var extraI int64
 			if err != nil {
-				return bytesRead, err
+				return bytesRead, cbg.WrapDecodeError(err, "EmbeddingStructTwo", fmt.Sprintf("SignedOthers[%d]", i), bytesRead)
 			}
 			bytesRead += read
 			switch maj {
 			case cbg.MajUnsignedInt:
 				extraI = int64(extra)
 				if extraI < 0 {
-					return bytesRead, fmt.Errorf("int64 positive overflow")
+					return bytesRead, &cbg.DecodeError{Type: "EmbeddingStructTwo", Field: fmt.Sprintf("SignedOthers[%d]", i), Offset: bytesRead - read, Expected: maj, Found: maj, Err: cbg.ErrOverflow}
 				}
 			case cbg.MajNegativeInt:
 				extraI = int64(extra)
 				if extraI < 0 {
-					return bytesRead, fmt.Errorf("int64 negative oveflow")
+					return bytesRead, &cbg.DecodeError{Type: "EmbeddingStructTwo", Field: fmt.Sprintf("SignedOthers[%d]", i), Offset: bytesRead - read, Expected: maj, Found: maj, Err: cbg.ErrOverflow}
 				}
 				extraI = -1 - extraI
 			default:
-				return bytesRead, fmt.Errorf("wrong type for int64 field: %d", maj)
+				return bytesRead, &cbg.DecodeError{Type: "EmbeddingStructTwo", Field: fmt.Sprintf("SignedOthers[%d]", i), Offset: bytesRead - read, Expected: cbg.MajUnsignedInt, Found: maj, Err: cbg.ErrUnexpectedType}
 			}
 
 			t.SignedOthers[i] = int64(extraI)
@@ -1621,16 +1718,19 @@ func (t *EmbeddingStructTwo) UnmarshalCBOR(r io.Reader) (int, error) {
 
 	maj, extra, read, err = cbg.CborReadHeaderBuf(br, scratch)
 	if err != nil {
-		return bytesRead, err
+		return bytesRead, cbg.WrapDecodeError(err, "EmbeddingStructTwo", "Test", bytesRead)
 	}
 	bytesRead += read
 
 	if extra > cbg.MaxLength {
-		return bytesRead, fmt.Errorf("t.Test: array too large (%d)", extra)
+		return bytesRead, &cbg.DecodeError{Type: "EmbeddingStructTwo", Field: "Test", Offset: bytesRead - read, Expected: cbg.MajArray, Found: maj, Err: cbg.ErrMaxLength}
 	}
 
 	if maj != cbg.MajArray {
-		return bytesRead, fmt.Errorf("expected cbor array")
+		return bytesRead, &cbg.DecodeError{Type: "EmbeddingStructTwo", Field: "Test", Offset: bytesRead - read, Expected: cbg.MajArray, Found: maj, Err: cbg.ErrUnexpectedType}
+	}
+	if err := limits.AddItems(extra); err != nil {
+		return bytesRead, &cbg.DecodeError{Type: "EmbeddingStructTwo", Field: "Test", Offset: bytesRead - read, Expected: cbg.MajArray, Found: maj, Err: err}
 	}
 
 	if extra > 0 {
@@ -1645,15 +1745,18 @@ func (t *EmbeddingStructTwo) UnmarshalCBOR(r io.Reader) (int, error) {
 
 			maj, extra, read, err = cbg.CborReadHeaderBuf(br, scratch)
 			if err != nil {
-				return bytesRead, err
+				return bytesRead, cbg.WrapDecodeError(err, "EmbeddingStructTwo", fmt.Sprintf("Test[%d]", i), bytesRead)
 			}
 			bytesRead += read
 
 			if extra > cbg.ByteArrayMaxLen {
-				return bytesRead, fmt.Errorf("t.Test[i]: byte array too large (%d)", extra)
+				return bytesRead, &cbg.DecodeError{Type: "EmbeddingStructTwo", Field: fmt.Sprintf("Test[%d]", i), Offset: bytesRead - read, Expected: cbg.MajByteString, Found: maj, Err: cbg.ErrMaxLength}
 			}
 			if maj != cbg.MajByteString {
-				return bytesRead, fmt.Errorf("expected byte array")
+				return bytesRead, &cbg.DecodeError{Type: "EmbeddingStructTwo", Field: fmt.Sprintf("Test[%d]", i), Offset: bytesRead - read, Expected: cbg.MajByteString, Found: maj, Err: cbg.ErrUnexpectedType}
+			}
+			if err := limits.AddBytes(extra); err != nil {
+				return bytesRead, &cbg.DecodeError{Type: "EmbeddingStructTwo", Field: fmt.Sprintf("Test[%d]", i), Offset: bytesRead - read, Expected: cbg.MajByteString, Found: maj, Err: err}
 			}
 
 			if extra > 0 {
@@ -1661,7 +1764,7 @@ func (t *EmbeddingStructTwo) UnmarshalCBOR(r io.Reader) (int, error) {
 			}
 
 			if read, err := io.ReadFull(br, t.Test[i][:]); err != nil {
-				return bytesRead, err
+				return bytesRead, cbg.WrapDecodeError(err, "EmbeddingStructTwo", fmt.Sprintf("Test[%d]", i), bytesRead)
 			} else {
 				bytesRead += read
 			}
@@ -1673,7 +1776,7 @@ func (t *EmbeddingStructTwo) UnmarshalCBOR(r io.Reader) (int, error) {
 	{
 		sval, read, err := cbg.ReadStringBuf(br, scratch)
 		if err != nil {
-			return bytesRead, err
+			return bytesRead, cbg.WrapDecodeError(err, "EmbeddingStructTwo", "Dog", bytesRead)
 		}
 		bytesRead += read
 
@@ -1683,16 +1786,19 @@ func (t *EmbeddingStructTwo) UnmarshalCBOR(r io.Reader) (int, error) {
 
 	maj, extra, read, err = cbg.CborReadHeaderBuf(br, scratch)
 	if err != nil {
-		return bytesRead, err
+		return bytesRead, cbg.WrapDecodeError(err, "EmbeddingStructTwo", "Numbers", bytesRead)
 	}
 	bytesRead += read
 
 	if extra > cbg.MaxLength {
-		return bytesRead, fmt.Errorf("t.Numbers: array too large (%d)", extra)
+		return bytesRead, &cbg.DecodeError{Type: "EmbeddingStructTwo", Field: "Numbers", Offset: bytesRead - read, Expected: cbg.MajArray, Found: maj, Err: cbg.ErrMaxLength}
 	}
 
 	if maj != cbg.MajArray {
-		return bytesRead, fmt.Errorf("expected cbor array")
+		return bytesRead, &cbg.DecodeError{Type: "EmbeddingStructTwo", Field: "Numbers", Offset: bytesRead - read, Expected: cbg.MajArray, Found: maj, Err: cbg.ErrUnexpectedType}
+	}
+	if err := limits.AddItems(extra); err != nil {
+		return bytesRead, &cbg.DecodeError{Type: "EmbeddingStructTwo", Field: "Numbers", Offset: bytesRead - read, Expected: cbg.MajArray, Found: maj, Err: err}
 	}
 
 	if extra > 0 {
@@ -1703,12 +1809,12 @@ func (t *EmbeddingStructTwo) UnmarshalCBOR(r io.Reader) (int, error) {
 
 		maj, val, read, err := cbg.CborReadHeaderBuf(br, scratch)
 		if err != nil {
-			return bytesRead, xerrors.Errorf("failed to read uint64 for t.Numbers slice: %w", err)
+			return bytesRead, cbg.WrapDecodeError(err, "EmbeddingStructTwo", fmt.Sprintf("Numbers[%d]", i), bytesRead)
 		}
 		bytesRead += read
 
 		if maj != cbg.MajUnsignedInt {
-			return bytesRead, xerrors.Errorf("value read for array t.Numbers was not a uint, instead got %d", maj)
+			return bytesRead, &cbg.DecodeError{Type: "EmbeddingStructTwo", Field: fmt.Sprintf("Numbers[%d]", i), Offset: bytesRead - read, Expected: cbg.MajUnsignedInt, Found: maj, Err: cbg.ErrUnexpectedType}
 		}
 
 		t.Numbers[i] = testing.NamedNumber(val)
@@ -1717,6 +1823,30 @@ func (t *EmbeddingStructTwo) UnmarshalCBOR(r io.Reader) (int, error) {
 	return bytesRead, nil
 }
 
+func (t *EmbeddingStructTwo) MarshalDAGJSON(w io.Writer) error {
+	return cbg.MarshalDAGJSON(w, t)
+}
+
+func (t *EmbeddingStructTwo) UnmarshalDAGJSON(r io.Reader) error {
+	return cbg.UnmarshalDAGJSON(r, t)
+}
+
+func (t *EmbeddingStructTwo) Links() []cid.Cid {
+	var links []cid.Cid
+	_ = t.ForEachLink(func(_ string, c cid.Cid) error {
+		links = append(links, c)
+		return nil
+	})
+	return links
+}
+
+func (t *EmbeddingStructTwo) ForEachLink(cb func(path string, c cid.Cid) error) error {
+	if t == nil {
+		return nil
+	}
+	return nil
+}
+
 func (t *EmbeddingStructThree) InitNilEmbeddedStruct() {
 	if t != nil {
 		if t.EmbeddingStructTwo == nil {
@@ -2051,6 +2181,11 @@ func (t *EmbeddingStructThree) UnmarshalCBOR(r io.Reader) (int, error) {
 
 	br := cbg.GetPeeker(r)
 	scratch := make([]byte, 8)
+	limits := cbg.GetDecodeLimits(br)
+	if err := limits.Enter(); err != nil {
+		return bytesRead, &cbg.DecodeError{Type: "EmbeddingStructThree", Err: err}
+	}
+	defer limits.Leave()
 
 	maj, extra, read, err := cbg.CborReadHeaderBuf(br, scratch)
 	if err != nil {
@@ -2058,11 +2193,14 @@ func (t *EmbeddingStructThree) UnmarshalCBOR(r io.Reader) (int, error) {
 	}
 	bytesRead += read
 	if maj != cbg.MajArray {
-		return bytesRead, fmt.Errorf("cbor input should be of type array")
+		return bytesRead, &cbg.DecodeError{Type: "EmbeddingStructThree", Expected: cbg.MajArray, Found: maj, Err: cbg.ErrUnexpectedType}
 	}
 
 	if extra != 20 {
-		return bytesRead, fmt.Errorf("cbor input had wrong number of fields")
+		return bytesRead, &cbg.DecodeError{Type: "EmbeddingStructThree", Expected: cbg.MajArray, Found: maj, Err: fmt.Errorf("cbor input had wrong number of fields (%d)", extra)}
+	}
+	if err := limits.AddItems(extra); err != nil {
+		return bytesRead, &cbg.DecodeError{Type: "EmbeddingStructThree", Expected: cbg.MajArray, Found: maj, Err: err}
 	}
 
 	// t.Signed (int64) (int64)
@@ -2070,23 +2208,23 @@ func (t *EmbeddingStructThree) UnmarshalCBOR(r io.Reader) (int, error) {
 		maj, extra, read, err := cbg.CborReadHeaderBuf(br, scratch)
 		var extraI int64
 		if err != nil {
-			return bytesRead, err
+			return bytesRead, cbg.WrapDecodeError(err, "EmbeddingStructThree", "Signed", bytesRead)
 		}
 		bytesRead += read
 		switch maj {
 		case cbg.MajUnsignedInt:
 			extraI = int64(extra)
 			if extraI < 0 {
-				return bytesRead, fmt.Errorf("int64 positive overflow")
+				return bytesRead, &cbg.DecodeError{Type: "EmbeddingStructThree", Field: "Signed", Offset: bytesRead - read, Expected: maj, Found: maj, Err: cbg.ErrOverflow}
 			}
 		case cbg.MajNegativeInt:
 			extraI = int64(extra)
 			if extraI < 0 {
-				return bytesRead, fmt.Errorf("int64 negative oveflow")
+				return bytesRead, &cbg.DecodeError{Type: "EmbeddingStructThree", Field: "Signed", Offset: bytesRead - read, Expected: maj, Found: maj, Err: cbg.ErrOverflow}
 			}
 			extraI = -1 - extraI
 		default:
-			return bytesRead, fmt.Errorf("wrong type for int64 field: %d", maj)
+			return bytesRead, &cbg.DecodeError{Type: "EmbeddingStructThree", Field: "Signed", Offset: bytesRead - read, Expected: cbg.MajUnsignedInt, Found: maj, Err: cbg.ErrUnexpectedType}
 		}
 
 		t.Signed = int64(extraI)
@@ -2096,7 +2234,7 @@ func (t *EmbeddingStructThree) UnmarshalCBOR(r io.Reader) (int, error) {
 	{
 		sval, read, err := cbg.ReadStringBuf(br, scratch)
 		if err != nil {
-			return bytesRead, err
+			return bytesRead, cbg.WrapDecodeError(err, "EmbeddingStructThree", "NString", bytesRead)
 		}
 		bytesRead += read
 
@@ -2106,42 +2244,42 @@ func (t *EmbeddingStructThree) UnmarshalCBOR(r io.Reader) (int, error) {
 
 	maj, extra, read, err = cbg.CborReadHeaderBuf(br, scratch)
 	if err != nil {
-		return bytesRead, err
+		return bytesRead, cbg.WrapDecodeError(err, "EmbeddingStructThree", "U8", bytesRead)
 	}
 	bytesRead += read
 	if maj != cbg.MajUnsignedInt {
-		return bytesRead, fmt.Errorf("wrong type for uint64 field")
+		return bytesRead, &cbg.DecodeError{Type: "EmbeddingStructThree", Field: "U8", Offset: bytesRead - read, Expected: cbg.MajUnsignedInt, Found: maj, Err: cbg.ErrUnexpectedType}
 	}
-	if extra > math.MaxUint64 {
-		return bytesRead, fmt.Errorf("integer in input was too large for uint64 field")
+	if extra > math.MaxUint8 {
+		return bytesRead, &cbg.DecodeError{Type: "EmbeddingStructThree", Field: "U8", Offset: bytesRead - read, Expected: cbg.MajUnsignedInt, Found: maj, Err: cbg.ErrOverflow}
 	}
 	t.U8 = uint8(extra)
 	// t.U16 (uint16) (uint16)
 
 	maj, extra, read, err = cbg.CborReadHeaderBuf(br, scratch)
 	if err != nil {
-		return bytesRead, err
+		return bytesRead, cbg.WrapDecodeError(err, "EmbeddingStructThree", "U16", bytesRead)
 	}
 	bytesRead += read
 	if maj != cbg.MajUnsignedInt {
-		return bytesRead, fmt.Errorf("wrong type for uint64 field")
+		return bytesRead, &cbg.DecodeError{Type: "EmbeddingStructThree", Field: "U16", Offset: bytesRead - read, Expected: cbg.MajUnsignedInt, Found: maj, Err: cbg.ErrUnexpectedType}
 	}
-	if extra > math.MaxUint64 {
-		return bytesRead, fmt.Errorf("integer in input was too large for uint64 field")
+	if extra > math.MaxUint16 {
+		return bytesRead, &cbg.DecodeError{Type: "EmbeddingStructThree", Field: "U16", Offset: bytesRead - read, Expected: cbg.MajUnsignedInt, Found: maj, Err: cbg.ErrOverflow}
 	}
 	t.U16 = uint16(extra)
 	// t.U32 (uint32) (uint32)
 
 	maj, extra, read, err = cbg.CborReadHeaderBuf(br, scratch)
 	if err != nil {
-		return bytesRead, err
+		return bytesRead, cbg.WrapDecodeError(err, "EmbeddingStructThree", "U32", bytesRead)
 	}
 	bytesRead += read
 	if maj != cbg.MajUnsignedInt {
-		return bytesRead, fmt.Errorf("wrong type for uint64 field")
+		return bytesRead, &cbg.DecodeError{Type: "EmbeddingStructThree", Field: "U32", Offset: bytesRead - read, Expected: cbg.MajUnsignedInt, Found: maj, Err: cbg.ErrUnexpectedType}
 	}
-	if extra > math.MaxUint64 {
-		return bytesRead, fmt.Errorf("integer in input was too large for uint64 field")
+	if extra > math.MaxUint32 {
+		return bytesRead, &cbg.DecodeError{Type: "EmbeddingStructThree", Field: "U32", Offset: bytesRead - read, Expected: cbg.MajUnsignedInt, Found: maj, Err: cbg.ErrOverflow}
 	}
 	t.U32 = uint32(extra)
 	// t.I8 (int8) (int8)
@@ -2149,23 +2287,27 @@ func (t *EmbeddingStructThree) UnmarshalCBOR(r io.Reader) (int, error) {
 		maj, extra, read, err := cbg.CborReadHeaderBuf(br, scratch)
 		var extraI int64
 		if err != nil {
-			return bytesRead, err
+			return bytesRead, cbg.WrapDecodeError(err, "EmbeddingStructThree", "I8", bytesRead)
 		}
 		bytesRead += read
 		switch maj {
 		case cbg.MajUnsignedInt:
 			extraI = int64(extra)
 			if extraI < 0 {
-				return bytesRead, fmt.Errorf("int64 positive overflow")
+				return bytesRead, &cbg.DecodeError{Type: "EmbeddingStructThree", Field: "I8", Offset: bytesRead - read, Expected: maj, Found: maj, Err: cbg.ErrOverflow}
 			}
 		case cbg.MajNegativeInt:
 			extraI = int64(extra)
 			if extraI < 0 {
-				return bytesRead, fmt.Errorf("int64 negative oveflow")
+				return bytesRead, &cbg.DecodeError{Type: "EmbeddingStructThree", Field: "I8", Offset: bytesRead - read, Expected: maj, Found: maj, Err: cbg.ErrOverflow}
 			}
 			extraI = -1 - extraI
 		default:
-			return bytesRead, fmt.Errorf("wrong type for int64 field: %d", maj)
+			return bytesRead, &cbg.DecodeError{Type: "EmbeddingStructThree", Field: "I8", Offset: bytesRead - read, Expected: cbg.MajUnsignedInt, Found: maj, Err: cbg.ErrUnexpectedType}
+		}
+
+		if extraI > math.MaxInt8 || extraI < math.MinInt8 {
+			return bytesRead, &cbg.DecodeError{Type: "EmbeddingStructThree", Field: "I8", Offset: bytesRead - read, Expected: maj, Found: maj, Err: cbg.ErrOverflow}
 		}
 
 		t.I8 = int8(extraI)
@@ -2175,23 +2317,27 @@ func (t *EmbeddingStructThree) UnmarshalCBOR(r io.Reader) (int, error) {
 		maj, extra, read, err := cbg.CborReadHeaderBuf(br, scratch)
 		var extraI int64
 		if err != nil {
-			return bytesRead, err
+			return bytesRead, cbg.WrapDecodeError(err, "EmbeddingStructThree", "I16", bytesRead)
 		}
 		bytesRead += read
 		switch maj {
 		case cbg.MajUnsignedInt:
 			extraI = int64(extra)
 			if extraI < 0 {
-				return bytesRead, fmt.Errorf("int64 positive overflow")
+				return bytesRead, &cbg.DecodeError{Type: "EmbeddingStructThree", Field: "I16", Offset: bytesRead - read, Expected: maj, Found: maj, Err: cbg.ErrOverflow}
 			}
 		case cbg.MajNegativeInt:
 			extraI = int64(extra)
 			if extraI < 0 {
-				return bytesRead, fmt.Errorf("int64 negative oveflow")
+				return bytesRead, &cbg.DecodeError{Type: "EmbeddingStructThree", Field: "I16", Offset: bytesRead - read, Expected: maj, Found: maj, Err: cbg.ErrOverflow}
 			}
 			extraI = -1 - extraI
 		default:
-			return bytesRead, fmt.Errorf("wrong type for int64 field: %d", maj)
+			return bytesRead, &cbg.DecodeError{Type: "EmbeddingStructThree", Field: "I16", Offset: bytesRead - read, Expected: cbg.MajUnsignedInt, Found: maj, Err: cbg.ErrUnexpectedType}
+		}
+
+		if extraI > math.MaxInt16 || extraI < math.MinInt16 {
+			return bytesRead, &cbg.DecodeError{Type: "EmbeddingStructThree", Field: "I16", Offset: bytesRead - read, Expected: maj, Found: maj, Err: cbg.ErrOverflow}
 		}
 
 		t.I16 = int16(extraI)
@@ -2201,23 +2347,27 @@ func (t *EmbeddingStructThree) UnmarshalCBOR(r io.Reader) (int, error) {
 		maj, extra, read, err := cbg.CborReadHeaderBuf(br, scratch)
 		var extraI int64
 		if err != nil {
-			return bytesRead, err
+			return bytesRead, cbg.WrapDecodeError(err, "EmbeddingStructThree", "I32", bytesRead)
 		}
 		bytesRead += read
 		switch maj {
 		case cbg.MajUnsignedInt:
 			extraI = int64(extra)
 			if extraI < 0 {
-				return bytesRead, fmt.Errorf("int64 positive overflow")
+				return bytesRead, &cbg.DecodeError{Type: "EmbeddingStructThree", Field: "I32", Offset: bytesRead - read, Expected: maj, Found: maj, Err: cbg.ErrOverflow}
 			}
 		case cbg.MajNegativeInt:
 			extraI = int64(extra)
 			if extraI < 0 {
-				return bytesRead, fmt.Errorf("int64 negative oveflow")
+				return bytesRead, &cbg.DecodeError{Type: "EmbeddingStructThree", Field: "I32", Offset: bytesRead - read, Expected: maj, Found: maj, Err: cbg.ErrOverflow}
 			}
 			extraI = -1 - extraI
 		default:
-			return bytesRead, fmt.Errorf("wrong type for int64 field: %d", maj)
+			return bytesRead, &cbg.DecodeError{Type: "EmbeddingStructThree", Field: "I32", Offset: bytesRead - read, Expected: cbg.MajUnsignedInt, Found: maj, Err: cbg.ErrUnexpectedType}
+		}
+
+		if extraI > math.MaxInt32 || extraI < math.MinInt32 {
+			return bytesRead, &cbg.DecodeError{Type: "EmbeddingStructThree", Field: "I32", Offset: bytesRead - read, Expected: maj, Found: maj, Err: cbg.ErrOverflow}
 		}
 
 		t.I32 = int32(extraI)
@@ -2227,7 +2377,7 @@ func (t *EmbeddingStructThree) UnmarshalCBOR(r io.Reader) (int, error) {
 	{
 		sval, read, err := cbg.ReadStringBuf(br, scratch)
 		if err != nil {
-			return bytesRead, err
+			return bytesRead, cbg.WrapDecodeError(err, "EmbeddingStructThree", "Foo", bytesRead)
 		}
 		bytesRead += read
 
@@ -2239,11 +2389,11 @@ func (t *EmbeddingStructThree) UnmarshalCBOR(r io.Reader) (int, error) {
 
 		maj, extra, read, err = cbg.CborReadHeaderBuf(br, scratch)
 		if err != nil {
-			return bytesRead, err
+			return bytesRead, cbg.WrapDecodeError(err, "EmbeddingStructThree", "Value", bytesRead)
 		}
 		bytesRead += read
 		if maj != cbg.MajUnsignedInt {
-			return bytesRead, fmt.Errorf("wrong type for uint64 field")
+			return bytesRead, &cbg.DecodeError{Type: "EmbeddingStructThree", Field: "Value", Offset: bytesRead - read, Expected: cbg.MajUnsignedInt, Found: maj, Err: cbg.ErrUnexpectedType}
 		}
 		t.Value = uint64(extra)
 
@@ -2252,15 +2402,18 @@ func (t *EmbeddingStructThree) UnmarshalCBOR(r io.Reader) (int, error) {
 
 	maj, extra, read, err = cbg.CborReadHeaderBuf(br, scratch)
 	if err != nil {
-		return bytesRead, err
+		return bytesRead, cbg.WrapDecodeError(err, "EmbeddingStructThree", "Binary", bytesRead)
 	}
 	bytesRead += read
 
 	if extra > cbg.ByteArrayMaxLen {
-		return bytesRead, fmt.Errorf("t.Binary: byte array too large (%d)", extra)
+		return bytesRead, &cbg.DecodeError{Type: "EmbeddingStructThree", Field: "Binary", Offset: bytesRead - read, Expected: cbg.MajByteString, Found: maj, Err: cbg.ErrMaxLength}
 	}
 	if maj != cbg.MajByteString {
-		return bytesRead, fmt.Errorf("expected byte array")
+		return bytesRead, &cbg.DecodeError{Type: "EmbeddingStructThree", Field: "Binary", Offset: bytesRead - read, Expected: cbg.MajByteString, Found: maj, Err: cbg.ErrUnexpectedType}
+	}
+	if err := limits.AddBytes(extra); err != nil {
+		return bytesRead, &cbg.DecodeError{Type: "EmbeddingStructThree", Field: "Binary", Offset: bytesRead - read, Expected: cbg.MajByteString, Found: maj, Err: err}
 	}
 
 	if extra > 0 {
@@ -2268,7 +2421,7 @@ func (t *EmbeddingStructThree) UnmarshalCBOR(r io.Reader) (int, error) {
 	}
 
 	if read, err := io.ReadFull(br, t.Binary[:]); err != nil {
-		return bytesRead, err
+		return bytesRead, cbg.WrapDecodeError(err, "EmbeddingStructThree", "Binary", bytesRead)
 	} else {
 		bytesRead += read
 	}
@@ -2278,17 +2431,17 @@ func (t *EmbeddingStructThree) UnmarshalCBOR(r io.Reader) (int, error) {
 
 		b, err := br.ReadByte()
 		if err != nil {
-			return bytesRead, err
+			return bytesRead, cbg.WrapDecodeError(err, "EmbeddingStructThree", "Stuff", bytesRead)
 		}
 		bytesRead++
 		if b != cbg.CborNull[0] {
 			if err := br.UnreadByte(); err != nil {
-				return bytesRead, err
+				return bytesRead, cbg.WrapDecodeError(err, "EmbeddingStructThree", "Stuff", bytesRead)
 			}
 			bytesRead--
 			t.Stuff = new(testing.SimpleTypeTwo)
 			if read, err := t.Stuff.UnmarshalCBOR(br); err != nil {
-				return bytesRead, xerrors.Errorf("unmarshaling t.Stuff pointer: %w", err)
+				return bytesRead, cbg.WrapDecodeError(err, "EmbeddingStructThree", "Stuff", bytesRead)
 			} else {
 				bytesRead += read
 			}
@@ -2299,16 +2452,19 @@ func (t *EmbeddingStructThree) UnmarshalCBOR(r io.Reader) (int, error) {
 
 	maj, extra, read, err = cbg.CborReadHeaderBuf(br, scratch)
 	if err != nil {
-		return bytesRead, err
+		return bytesRead, cbg.WrapDecodeError(err, "EmbeddingStructThree", "Others", bytesRead)
 	}
 	bytesRead += read
 
 	if extra > cbg.MaxLength {
-		return bytesRead, fmt.Errorf("t.Others: array too large (%d)", extra)
+		return bytesRead, &cbg.DecodeError{Type: "EmbeddingStructThree", Field: "Others", Offset: bytesRead - read, Expected: cbg.MajArray, Found: maj, Err: cbg.ErrMaxLength}
 	}
 
 	if maj != cbg.MajArray {
-		return bytesRead, fmt.Errorf("expected cbor array")
+		return bytesRead, &cbg.DecodeError{Type: "EmbeddingStructThree", Field: "Others", Offset: bytesRead - read, Expected: cbg.MajArray, Found: maj, Err: cbg.ErrUnexpectedType}
+	}
+	if err := limits.AddItems(extra); err != nil {
+		return bytesRead, &cbg.DecodeError{Type: "EmbeddingStructThree", Field: "Others", Offset: bytesRead - read, Expected: cbg.MajArray, Found: maj, Err: err}
 	}
 
 	if extra > 0 {
@@ -2319,12 +2475,12 @@ func (t *EmbeddingStructThree) UnmarshalCBOR(r io.Reader) (int, error) {
 
 		maj, val, read, err := cbg.CborReadHeaderBuf(br, scratch)
 		if err != nil {
-			return bytesRead, xerrors.Errorf("failed to read uint64 for t.Others slice: %w", err)
+			return bytesRead, cbg.WrapDecodeError(err, "EmbeddingStructThree", fmt.Sprintf("Others[%d]", i), bytesRead)
 		}
 		bytesRead += read
 
 		if maj != cbg.MajUnsignedInt {
-			return bytesRead, xerrors.Errorf("value read for array t.Others was not a uint, instead got %d", maj)
+			return bytesRead, &cbg.DecodeError{Type: "EmbeddingStructThree", Field: fmt.Sprintf("Others[%d]", i), Offset: bytesRead - read, Expected: cbg.MajUnsignedInt, Found: maj, Err: cbg.ErrUnexpectedType}
 		}
 
 		t.Others[i] = uint64(val)
@@ -2334,16 +2490,19 @@ func (t *EmbeddingStructThree) UnmarshalCBOR(r io.Reader) (int, error) {
 
 	maj, extra, read, err = cbg.CborReadHeaderBuf(br, scratch)
 	if err != nil {
-		return bytesRead, err
+		return bytesRead, cbg.WrapDecodeError(err, "EmbeddingStructThree", "SignedOthers", bytesRead)
 	}
 	bytesRead += read
 
 	if extra > cbg.MaxLength {
-		return bytesRead, fmt.Errorf("t.SignedOthers: array too large (%d)", extra)
+		return bytesRead, &cbg.DecodeError{Type: "EmbeddingStructThree", Field: "SignedOthers", Offset: bytesRead - read, Expected: cbg.MajArray, Found: maj, Err: cbg.ErrMaxLength}
 	}
 
 	if maj != cbg.MajArray {
-		return bytesRead, fmt.Errorf("expected cbor array")
+		return bytesRead, &cbg.DecodeError{Type: "EmbeddingStructThree", Field: "SignedOthers", Offset: bytesRead - read, Expected: cbg.MajArray, Found: maj, Err: cbg.ErrUnexpectedType}
+	}
+	if err := limits.AddItems(extra); err != nil {
+		return bytesRead, &cbg.DecodeError{Type: "EmbeddingStructThree", Field: "SignedOthers", Offset: bytesRead - read, Expected: cbg.MajArray, Found: maj, Err: err}
 	}
 
 	if extra > 0 {
@@ -2355,23 +2514,23 @@ func (t *EmbeddingStructThree) UnmarshalCBOR(r io.Reader) (int, error) {
 			maj, extra, read, err := cbg.CborReadHeaderBuf(br, scratch)
 			var extraI int64
 			if err != nil {
-				return bytesRead, err
+				return bytesRead, cbg.WrapDecodeError(err, "EmbeddingStructThree", fmt.Sprintf("SignedOthers[%d]", i), bytesRead)
 			}
 			bytesRead += read
 			switch maj {
 			case cbg.MajUnsignedInt:
 				extraI = int64(extra)
 				if extraI < 0 {
-					return bytesRead, fmt.Errorf("int64 positive overflow")
+					return bytesRead, &cbg.DecodeError{Type: "EmbeddingStructThree", Field: fmt.Sprintf("SignedOthers[%d]", i), Offset: bytesRead - read, Expected: maj, Found: maj, Err: cbg.ErrOverflow}
 				}
 			case cbg.MajNegativeInt:
 				extraI = int64(extra)
 				if extraI < 0 {
-					return bytesRead, fmt.Errorf("int64 negative oveflow")
+					return bytesRead, &cbg.DecodeError{Type: "EmbeddingStructThree", Field: fmt.Sprintf("SignedOthers[%d]", i), Offset: bytesRead - read, Expected: maj, Found: maj, Err: cbg.ErrOverflow}
 				}
 				extraI = -1 - extraI
 			default:
-				return bytesRead, fmt.Errorf("wrong type for int64 field: %d", maj)
+				return bytesRead, &cbg.DecodeError{Type: "EmbeddingStructThree", Field: fmt.Sprintf("SignedOthers[%d]", i), Offset: bytesRead - read, Expected: cbg.MajUnsignedInt, Found: maj, Err: cbg.ErrUnexpectedType}
 			}
 
 			t.SignedOthers[i] = int64(extraI)
@@ -2382,16 +2541,19 @@ func (t *EmbeddingStructThree) UnmarshalCBOR(r io.Reader) (int, error) {
 
 	maj, extra, read, err = cbg.CborReadHeaderBuf(br, scratch)
 	if err != nil {
-		return bytesRead, err
+		return bytesRead, cbg.WrapDecodeError(err, "EmbeddingStructThree", "Test", bytesRead)
 	}
 	bytesRead += read
 
 	if extra > cbg.MaxLength {
-		return bytesRead, fmt.Errorf("t.Test: array too large (%d)", extra)
+		return bytesRead, &cbg.DecodeError{Type: "EmbeddingStructThree", Field: "Test", Offset: bytesRead - read, Expected: cbg.MajArray, Found: maj, Err: cbg.ErrMaxLength}
 	}
 
 	if maj != cbg.MajArray {
-		return bytesRead, fmt.Errorf("expected cbor array")
+		return bytesRead, &cbg.DecodeError{Type: "EmbeddingStructThree", Field: "Test", Offset: bytesRead - read, Expected: cbg.MajArray, Found: maj, Err: cbg.ErrUnexpectedType}
+	}
+	if err := limits.AddItems(extra); err != nil {
+		return bytesRead, &cbg.DecodeError{Type: "EmbeddingStructThree", Field: "Test", Offset: bytesRead - read, Expected: cbg.MajArray, Found: maj, Err: err}
 	}
 
 	if extra > 0 {
@@ -2406,15 +2568,18 @@ func (t *EmbeddingStructThree) UnmarshalCBOR(r io.Reader) (int, error) {
 
 			maj, extra, read, err = cbg.CborReadHeaderBuf(br, scratch)
 			if err != nil {
-				return bytesRead, err
+				return bytesRead, cbg.WrapDecodeError(err, "EmbeddingStructThree", fmt.Sprintf("Test[%d]", i), bytesRead)
 			}
 			bytesRead += read
 
 			if extra > cbg.ByteArrayMaxLen {
-				return bytesRead, fmt.Errorf("t.Test[i]: byte array too large (%d)", extra)
+				return bytesRead, &cbg.DecodeError{Type: "EmbeddingStructThree", Field: fmt.Sprintf("Test[%d]", i), Offset: bytesRead - read, Expected: cbg.MajByteString, Found: maj, Err: cbg.ErrMaxLength}
 			}
 			if maj != cbg.MajByteString {
-				return bytesRead, fmt.Errorf("expected byte array")
+				return bytesRead, &cbg.DecodeError{Type: "EmbeddingStructThree", Field: fmt.Sprintf("Test[%d]", i), Offset: bytesRead - read, Expected: cbg.MajByteString, Found: maj, Err: cbg.ErrUnexpectedType}
+			}
+			if err := limits.AddBytes(extra); err != nil {
+				return bytesRead, &cbg.DecodeError{Type: "EmbeddingStructThree", Field: fmt.Sprintf("Test[%d]", i), Offset: bytesRead - read, Expected: cbg.MajByteString, Found: maj, Err: err}
 			}
 
 			if extra > 0 {
@@ -2422,7 +2587,7 @@ func (t *EmbeddingStructThree) UnmarshalCBOR(r io.Reader) (int, error) {
 			}
 
 			if read, err := io.ReadFull(br, t.Test[i][:]); err != nil {
-				return bytesRead, err
+				return bytesRead, cbg.WrapDecodeError(err, "EmbeddingStructThree", fmt.Sprintf("Test[%d]", i), bytesRead)
 			} else {
 				bytesRead += read
 			}
@@ -2434,7 +2599,7 @@ func (t *EmbeddingStructThree) UnmarshalCBOR(r io.Reader) (int, error) {
 	{
 		sval, read, err := cbg.ReadStringBuf(br, scratch)
 		if err != nil {
-			return bytesRead, err
+			return bytesRead, cbg.WrapDecodeError(err, "EmbeddingStructThree", "Dog", bytesRead)
 		}
 		bytesRead += read
 
@@ -2444,16 +2609,19 @@ func (t *EmbeddingStructThree) UnmarshalCBOR(r io.Reader) (int, error) {
 
 	maj, extra, read, err = cbg.CborReadHeaderBuf(br, scratch)
 	if err != nil {
-		return bytesRead, err
+		return bytesRead, cbg.WrapDecodeError(err, "EmbeddingStructThree", "Numbers", bytesRead)
 	}
 	bytesRead += read
 
 	if extra > cbg.MaxLength {
-		return bytesRead, fmt.Errorf("t.Numbers: array too large (%d)", extra)
+		return bytesRead, &cbg.DecodeError{Type: "EmbeddingStructThree", Field: "Numbers", Offset: bytesRead - read, Expected: cbg.MajArray, Found: maj, Err: cbg.ErrMaxLength}
 	}
 
 	if maj != cbg.MajArray {
-		return bytesRead, fmt.Errorf("expected cbor array")
+		return bytesRead, &cbg.DecodeError{Type: "EmbeddingStructThree", Field: "Numbers", Offset: bytesRead - read, Expected: cbg.MajArray, Found: maj, Err: cbg.ErrUnexpectedType}
+	}
+	if err := limits.AddItems(extra); err != nil {
+		return bytesRead, &cbg.DecodeError{Type: "EmbeddingStructThree", Field: "Numbers", Offset: bytesRead - read, Expected: cbg.MajArray, Found: maj, Err: err}
 	}
 
 	if extra > 0 {
@@ -2464,12 +2632,12 @@ func (t *EmbeddingStructThree) UnmarshalCBOR(r io.Reader) (int, error) {
 
 		maj, val, read, err := cbg.CborReadHeaderBuf(br, scratch)
 		if err != nil {
-			return bytesRead, xerrors.Errorf("failed to read uint64 for t.Numbers slice: %w", err)
+			return bytesRead, cbg.WrapDecodeError(err, "EmbeddingStructThree", fmt.Sprintf("Numbers[%d]", i), bytesRead)
 		}
 		bytesRead += read
 
 		if maj != cbg.MajUnsignedInt {
-			return bytesRead, xerrors.Errorf("value read for array t.Numbers was not a uint, instead got %d", maj)
+			return bytesRead, &cbg.DecodeError{Type: "EmbeddingStructThree", Field: fmt.Sprintf("Numbers[%d]", i), Offset: bytesRead - read, Expected: cbg.MajUnsignedInt, Found: maj, Err: cbg.ErrUnexpectedType}
 		}
 
 		t.Numbers[i] = testing.NamedNumber(val)
@@ -2481,21 +2649,21 @@ func (t *EmbeddingStructThree) UnmarshalCBOR(r io.Reader) (int, error) {
 
 		b, err := br.ReadByte()
 		if err != nil {
-			return bytesRead, err
+			return bytesRead, cbg.WrapDecodeError(err, "EmbeddingStructThree", "Pizza", bytesRead)
 		}
 		bytesRead++
 		if b != cbg.CborNull[0] {
 			if err := br.UnreadByte(); err != nil {
-				return bytesRead, err
+				return bytesRead, cbg.WrapDecodeError(err, "EmbeddingStructThree", "Pizza", bytesRead)
 			}
 			bytesRead--
 			maj, extra, read, err = cbg.CborReadHeaderBuf(br, scratch)
 			if err != nil {
-				return bytesRead, err
+				return bytesRead, cbg.WrapDecodeError(err, "EmbeddingStructThree", "Pizza", bytesRead)
 			}
 			bytesRead += read
 			if maj != cbg.MajUnsignedInt {
-				return bytesRead, fmt.Errorf("wrong type for uint64 field")
+				return bytesRead, &cbg.DecodeError{Type: "EmbeddingStructThree", Field: "Pizza", Offset: bytesRead - read, Expected: cbg.MajUnsignedInt, Found: maj, Err: cbg.ErrUnexpectedType}
 			}
 			typed := uint64(extra)
 			t.Pizza = &typed
@@ -2508,21 +2676,21 @@ func (t *EmbeddingStructThree) UnmarshalCBOR(r io.Reader) (int, error) {
 
 		b, err := br.ReadByte()
 		if err != nil {
-			return bytesRead, err
+			return bytesRead, cbg.WrapDecodeError(err, "EmbeddingStructThree", "PointyPizza", bytesRead)
 		}
 		bytesRead++
 		if b != cbg.CborNull[0] {
 			if err := br.UnreadByte(); err != nil {
-				return bytesRead, err
+				return bytesRead, cbg.WrapDecodeError(err, "EmbeddingStructThree", "PointyPizza", bytesRead)
 			}
 			bytesRead--
 			maj, extra, read, err = cbg.CborReadHeaderBuf(br, scratch)
 			if err != nil {
-				return bytesRead, err
+				return bytesRead, cbg.WrapDecodeError(err, "EmbeddingStructThree", "PointyPizza", bytesRead)
 			}
 			bytesRead += read
 			if maj != cbg.MajUnsignedInt {
-				return bytesRead, fmt.Errorf("wrong type for uint64 field")
+				return bytesRead, &cbg.DecodeError{Type: "EmbeddingStructThree", Field: "PointyPizza", Offset: bytesRead - read, Expected: cbg.MajUnsignedInt, Found: maj, Err: cbg.ErrUnexpectedType}
 			}
 			typed := testing.NamedNumber(extra)
 			t.PointyPizza = &typed
@@ -2533,20 +2701,23 @@ func (t *EmbeddingStructThree) UnmarshalCBOR(r io.Reader) (int, error) {
 
 	maj, extra, read, err = cbg.CborReadHeaderBuf(br, scratch)
 	if err != nil {
-		return bytesRead, err
+		return bytesRead, cbg.WrapDecodeError(err, "EmbeddingStructThree", "Arrrrrghay", bytesRead)
 	}
 	bytesRead += read
 
 	if extra > cbg.MaxLength {
-		return bytesRead, fmt.Errorf("t.Arrrrrghay: array too large (%d)", extra)
+		return bytesRead, &cbg.DecodeError{Type: "EmbeddingStructThree", Field: "Arrrrrghay", Offset: bytesRead - read, Expected: cbg.MajArray, Found: maj, Err: cbg.ErrMaxLength}
 	}
 
 	if maj != cbg.MajArray {
-		return bytesRead, fmt.Errorf("expected cbor array")
+		return bytesRead, &cbg.DecodeError{Type: "EmbeddingStructThree", Field: "Arrrrrghay", Offset: bytesRead - read, Expected: cbg.MajArray, Found: maj, Err: cbg.ErrUnexpectedType}
+	}
+	if err := limits.AddItems(extra); err != nil {
+		return bytesRead, &cbg.DecodeError{Type: "EmbeddingStructThree", Field: "Arrrrrghay", Offset: bytesRead - read, Expected: cbg.MajArray, Found: maj, Err: err}
 	}
 
 	if extra != 3 {
-		return bytesRead, fmt.Errorf("expected array to have 3 elements")
+		return bytesRead, &cbg.DecodeError{Type: "EmbeddingStructThree", Field: "Arrrrrghay", Offset: bytesRead - read, Expected: cbg.MajArray, Found: maj, Err: fmt.Errorf("expected 3 elements, got %d", extra)}
 	}
 
 	t.Arrrrrghay = [3]testing.SimpleTypeOne{}
@@ -2555,7 +2726,7 @@ func (t *EmbeddingStructThree) UnmarshalCBOR(r io.Reader) (int, error) {
 
 		var v testing.SimpleTypeOne
 		if read, err := v.UnmarshalCBOR(br); err != nil {
-			return bytesRead, err
+			return bytesRead, cbg.WrapDecodeError(err, "EmbeddingStructThree", fmt.Sprintf("Arrrrrghay[%d]", i), bytesRead)
 		} else {
 			bytesRead += read
 		}
@@ -2566,6 +2737,30 @@ func (t *EmbeddingStructThree) UnmarshalCBOR(r io.Reader) (int, error) {
 	return bytesRead, nil
 }
 
+func (t *EmbeddingStructThree) MarshalDAGJSON(w io.Writer) error {
+	return cbg.MarshalDAGJSON(w, t)
+}
+
+func (t *EmbeddingStructThree) UnmarshalDAGJSON(r io.Reader) error {
+	return cbg.UnmarshalDAGJSON(r, t)
+}
+
+func (t *EmbeddingStructThree) Links() []cid.Cid {
+	var links []cid.Cid
+	_ = t.ForEachLink(func(_ string, c cid.Cid) error {
+		links = append(links, c)
+		return nil
+	})
+	return links
+}
+
+func (t *EmbeddingStructThree) ForEachLink(cb func(path string, c cid.Cid) error) error {
+	if t == nil {
+		return nil
+	}
+	return nil
+}
+
 func (t *FlatStruct) InitNilEmbeddedStruct() {
 	if t != nil {
 	}
@@ -2668,6 +2863,11 @@ func (t *FlatStruct) UnmarshalCBOR(r io.Reader) (int, error) {
 
 	br := cbg.GetPeeker(r)
 	scratch := make([]byte, 8)
+	limits := cbg.GetDecodeLimits(br)
+	if err := limits.Enter(); err != nil {
+		return bytesRead, &cbg.DecodeError{Type: "FlatStruct", Err: err}
+	}
+	defer limits.Leave()
 
 	maj, extra, read, err := cbg.CborReadHeaderBuf(br, scratch)
 	if err != nil {
@@ -2675,11 +2875,14 @@ func (t *FlatStruct) UnmarshalCBOR(r io.Reader) (int, error) {
 	}
 	bytesRead += read
 	if maj != cbg.MajArray {
-		return bytesRead, fmt.Errorf("cbor input should be of type array")
+		return bytesRead, &cbg.DecodeError{Type: "FlatStruct", Expected: cbg.MajArray, Found: maj, Err: cbg.ErrUnexpectedType}
 	}
 
 	if extra != 5 {
-		return bytesRead, fmt.Errorf("cbor input had wrong number of fields")
+		return bytesRead, &cbg.DecodeError{Type: "FlatStruct", Expected: cbg.MajArray, Found: maj, Err: fmt.Errorf("cbor input had wrong number of fields (%d)", extra)}
+	}
+	if err := limits.AddItems(extra); err != nil {
+		return bytesRead, &cbg.DecodeError{Type: "FlatStruct", Expected: cbg.MajArray, Found: maj, Err: err}
 	}
 
 	// t.Signed (int64) (int64)
@@ -2687,23 +2890,23 @@ func (t *FlatStruct) UnmarshalCBOR(r io.Reader) (int, error) {
 		maj, extra, read, err := cbg.CborReadHeaderBuf(br, scratch)
 		var extraI int64
 		if err != nil {
-			return bytesRead, err
+			return bytesRead, cbg.WrapDecodeError(err, "FlatStruct", "Signed", bytesRead)
 		}
 		bytesRead += read
 		switch maj {
 		case cbg.MajUnsignedInt:
 			extraI = int64(extra)
 			if extraI < 0 {
-				return bytesRead, fmt.Errorf("int64 positive overflow")
+				return bytesRead, &cbg.DecodeError{Type: "FlatStruct", Field: "Signed", Offset: bytesRead - read, Expected: maj, Found: maj, Err: cbg.ErrOverflow}
 			}
 		case cbg.MajNegativeInt:
 			extraI = int64(extra)
 			if extraI < 0 {
-				return bytesRead, fmt.Errorf("int64 negative oveflow")
+				return bytesRead, &cbg.DecodeError{Type: "FlatStruct", Field: "Signed", Offset: bytesRead - read, Expected: maj, Found: maj, Err: cbg.ErrOverflow}
 			}
 			extraI = -1 - extraI
 		default:
-			return bytesRead, fmt.Errorf("wrong type for int64 field: %d", maj)
+			return bytesRead, &cbg.DecodeError{Type: "FlatStruct", Field: "Signed", Offset: bytesRead - read, Expected: cbg.MajUnsignedInt, Found: maj, Err: cbg.ErrUnexpectedType}
 		}
 
 		t.Signed = int64(extraI)
@@ -2713,7 +2916,7 @@ func (t *FlatStruct) UnmarshalCBOR(r io.Reader) (int, error) {
 	{
 		sval, read, err := cbg.ReadStringBuf(br, scratch)
 		if err != nil {
-			return bytesRead, err
+			return bytesRead, cbg.WrapDecodeError(err, "FlatStruct", "Foo", bytesRead)
 		}
 		bytesRead += read
 
@@ -2723,15 +2926,18 @@ func (t *FlatStruct) UnmarshalCBOR(r io.Reader) (int, error) {
 
 	maj, extra, read, err = cbg.CborReadHeaderBuf(br, scratch)
 	if err != nil {
-		return bytesRead, err
+		return bytesRead, cbg.WrapDecodeError(err, "FlatStruct", "Binary", bytesRead)
 	}
 	bytesRead += read
 
 	if extra > cbg.ByteArrayMaxLen {
-		return bytesRead, fmt.Errorf("t.Binary: byte array too large (%d)", extra)
+		return bytesRead, &cbg.DecodeError{Type: "FlatStruct", Field: "Binary", Offset: bytesRead - read, Expected: cbg.MajByteString, Found: maj, Err: cbg.ErrMaxLength}
 	}
 	if maj != cbg.MajByteString {
-		return bytesRead, fmt.Errorf("expected byte array")
+		return bytesRead, &cbg.DecodeError{Type: "FlatStruct", Field: "Binary", Offset: bytesRead - read, Expected: cbg.MajByteString, Found: maj, Err: cbg.ErrUnexpectedType}
+	}
+	if err := limits.AddBytes(extra); err != nil {
+		return bytesRead, &cbg.DecodeError{Type: "FlatStruct", Field: "Binary", Offset: bytesRead - read, Expected: cbg.MajByteString, Found: maj, Err: err}
 	}
 
 	if extra > 0 {
@@ -2739,7 +2945,7 @@ func (t *FlatStruct) UnmarshalCBOR(r io.Reader) (int, error) {
 	}
 
 	if read, err := io.ReadFull(br, t.Binary[:]); err != nil {
-		return bytesRead, err
+		return bytesRead, cbg.WrapDecodeError(err, "FlatStruct", "Binary", bytesRead)
 	} else {
 		bytesRead += read
 	}
@@ -2748,7 +2954,7 @@ func (t *FlatStruct) UnmarshalCBOR(r io.Reader) (int, error) {
 	{
 		sval, read, err := cbg.ReadStringBuf(br, scratch)
 		if err != nil {
-			return bytesRead, err
+			return bytesRead, cbg.WrapDecodeError(err, "FlatStruct", "NString", bytesRead)
 		}
 		bytesRead += read
 
@@ -2760,11 +2966,11 @@ func (t *FlatStruct) UnmarshalCBOR(r io.Reader) (int, error) {
 
 		maj, extra, read, err = cbg.CborReadHeaderBuf(br, scratch)
 		if err != nil {
-			return bytesRead, err
+			return bytesRead, cbg.WrapDecodeError(err, "FlatStruct", "Value", bytesRead)
 		}
 		bytesRead += read
 		if maj != cbg.MajUnsignedInt {
-			return bytesRead, fmt.Errorf("wrong type for uint64 field")
+			return bytesRead, &cbg.DecodeError{Type: "FlatStruct", Field: "Value", Offset: bytesRead - read, Expected: cbg.MajUnsignedInt, Found: maj, Err: cbg.ErrUnexpectedType}
 		}
 		t.Value = uint64(extra)
 
@@ -2772,6 +2978,30 @@ func (t *FlatStruct) UnmarshalCBOR(r io.Reader) (int, error) {
 	return bytesRead, nil
 }
 
+func (t *FlatStruct) MarshalDAGJSON(w io.Writer) error {
+	return cbg.MarshalDAGJSON(w, t)
+}
+
+func (t *FlatStruct) UnmarshalDAGJSON(r io.Reader) error {
+	return cbg.UnmarshalDAGJSON(r, t)
+}
+
+func (t *FlatStruct) Links() []cid.Cid {
+	var links []cid.Cid
+	_ = t.ForEachLink(func(_ string, c cid.Cid) error {
+		links = append(links, c)
+		return nil
+	})
+	return links
+}
+
+func (t *FlatStruct) ForEachLink(cb func(path string, c cid.Cid) error) error {
+	if t == nil {
+		return nil
+	}
+	return nil
+}
+
 func (t *EmbeddedStruct) InitNilEmbeddedStruct() {
 	if t != nil {
 	}
@@ -2842,6 +3072,11 @@ func (t *EmbeddedStruct) UnmarshalCBOR(r io.Reader) (int, error) {
 
 	br := cbg.GetPeeker(r)
 	scratch := make([]byte, 8)
+	limits := cbg.GetDecodeLimits(br)
+	if err := limits.Enter(); err != nil {
+		return bytesRead, &cbg.DecodeError{Type: "EmbeddedStruct", Err: err}
+	}
+	defer limits.Leave()
 
 	maj, extra, read, err := cbg.CborReadHeaderBuf(br, scratch)
 	if err != nil {
@@ -2849,11 +3084,14 @@ func (t *EmbeddedStruct) UnmarshalCBOR(r io.Reader) (int, error) {
 	}
 	bytesRead += read
 	if maj != cbg.MajArray {
-		return bytesRead, fmt.Errorf("cbor input should be of type array")
+		return bytesRead, &cbg.DecodeError{Type: "EmbeddedStruct", Expected: cbg.MajArray, Found: maj, Err: cbg.ErrUnexpectedType}
 	}
 
 	if extra != 3 {
-		return bytesRead, fmt.Errorf("cbor input had wrong number of fields")
+		return bytesRead, &cbg.DecodeError{Type: "EmbeddedStruct", Expected: cbg.MajArray, Found: maj, Err: fmt.Errorf("cbor input had wrong number of fields (%d)", extra)}
+	}
+	if err := limits.AddItems(extra); err != nil {
+		return bytesRead, &cbg.DecodeError{Type: "EmbeddedStruct", Expected: cbg.MajArray, Found: maj, Err: err}
 	}
 
 	// t.Value (uint64) (uint64)
@@ -2862,11 +3100,11 @@ func (t *EmbeddedStruct) UnmarshalCBOR(r io.Reader) (int, error) {
 
 		maj, extra, read, err = cbg.CborReadHeaderBuf(br, scratch)
 		if err != nil {
-			return bytesRead, err
+			return bytesRead, cbg.WrapDecodeError(err, "EmbeddedStruct", "Value", bytesRead)
 		}
 		bytesRead += read
 		if maj != cbg.MajUnsignedInt {
-			return bytesRead, fmt.Errorf("wrong type for uint64 field")
+			return bytesRead, &cbg.DecodeError{Type: "EmbeddedStruct", Field: "Value", Offset: bytesRead - read, Expected: cbg.MajUnsignedInt, Found: maj, Err: cbg.ErrUnexpectedType}
 		}
 		t.Value = uint64(extra)
 
@@ -2876,7 +3114,7 @@ func (t *EmbeddedStruct) UnmarshalCBOR(r io.Reader) (int, error) {
 	{
 		sval, read, err := cbg.ReadStringBuf(br, scratch)
 		if err != nil {
-			return bytesRead, err
+			return bytesRead, cbg.WrapDecodeError(err, "EmbeddedStruct", "Foo", bytesRead)
 		}
 		bytesRead += read
 
@@ -2886,15 +3124,18 @@ func (t *EmbeddedStruct) UnmarshalCBOR(r io.Reader) (int, error) {
 
 	maj, extra, read, err = cbg.CborReadHeaderBuf(br, scratch)
 	if err != nil {
-		return bytesRead, err
+		return bytesRead, cbg.WrapDecodeError(err, "EmbeddedStruct", "Binary", bytesRead)
 	}
 	bytesRead += read
 
 	if extra > cbg.ByteArrayMaxLen {
-		return bytesRead, fmt.Errorf("t.Binary: byte array too large (%d)", extra)
+		return bytesRead, &cbg.DecodeError{Type: "EmbeddedStruct", Field: "Binary", Offset: bytesRead - read, Expected: cbg.MajByteString, Found: maj, Err: cbg.ErrMaxLength}
 	}
 	if maj != cbg.MajByteString {
-		return bytesRead, fmt.Errorf("expected byte array")
+		return bytesRead, &cbg.DecodeError{Type: "EmbeddedStruct", Field: "Binary", Offset: bytesRead - read, Expected: cbg.MajByteString, Found: maj, Err: cbg.ErrUnexpectedType}
+	}
+	if err := limits.AddBytes(extra); err != nil {
+		return bytesRead, &cbg.DecodeError{Type: "EmbeddedStruct", Field: "Binary", Offset: bytesRead - read, Expected: cbg.MajByteString, Found: maj, Err: err}
 	}
 
 	if extra > 0 {
@@ -2902,13 +3143,37 @@ func (t *EmbeddedStruct) UnmarshalCBOR(r io.Reader) (int, error) {
 	}
 
 	if read, err := io.ReadFull(br, t.Binary[:]); err != nil {
-		return bytesRead, err
+		return bytesRead, cbg.WrapDecodeError(err, "EmbeddedStruct", "Binary", bytesRead)
 	} else {
 		bytesRead += read
 	}
 	return bytesRead, nil
 }
 
+func (t *EmbeddedStruct) MarshalDAGJSON(w io.Writer) error {
+	return cbg.MarshalDAGJSON(w, t)
+}
+
+func (t *EmbeddedStruct) UnmarshalDAGJSON(r io.Reader) error {
+	return cbg.UnmarshalDAGJSON(r, t)
+}
+
+func (t *EmbeddedStruct) Links() []cid.Cid {
+	var links []cid.Cid
+	_ = t.ForEachLink(func(_ string, c cid.Cid) error {
+		links = append(links, c)
+		return nil
+	})
+	return links
+}
+
+func (t *EmbeddedStruct) ForEachLink(cb func(path string, c cid.Cid) error) error {
+	if t == nil {
+		return nil
+	}
+	return nil
+}
+
 func (t *EmbedByValueStruct) InitNilEmbeddedStruct() {
 	if t != nil {
 	}
@@ -3011,6 +3276,11 @@ func (t *EmbedByValueStruct) UnmarshalCBOR(r io.Reader) (int, error) {
 
 	br := cbg.GetPeeker(r)
 	scratch := make([]byte, 8)
+	limits := cbg.GetDecodeLimits(br)
+	if err := limits.Enter(); err != nil {
+		return bytesRead, &cbg.DecodeError{Type: "EmbedByValueStruct", Err: err}
+	}
+	defer limits.Leave()
 
 	maj, extra, read, err := cbg.CborReadHeaderBuf(br, scratch)
 	if err != nil {
@@ -3018,11 +3288,14 @@ func (t *EmbedByValueStruct) UnmarshalCBOR(r io.Reader) (int, error) {
 	}
 	bytesRead += read
 	if maj != cbg.MajArray {
-		return bytesRead, fmt.Errorf("cbor input should be of type array")
+		return bytesRead, &cbg.DecodeError{Type: "EmbedByValueStruct", Expected: cbg.MajArray, Found: maj, Err: cbg.ErrUnexpectedType}
 	}
 
 	if extra != 5 {
-		return bytesRead, fmt.Errorf("cbor input had wrong number of fields")
+		return bytesRead, &cbg.DecodeError{Type: "EmbedByValueStruct", Expected: cbg.MajArray, Found: maj, Err: fmt.Errorf("cbor input had wrong number of fields (%d)", extra)}
+	}
+	if err := limits.AddItems(extra); err != nil {
+		return bytesRead, &cbg.DecodeError{Type: "EmbedByValueStruct", Expected: cbg.MajArray, Found: maj, Err: err}
 	}
 
 	// t.Signed (int64) (int64)
@@ -3030,23 +3303,23 @@ func (t *EmbedByValueStruct) UnmarshalCBOR(r io.Reader) (int, error) {
 		maj, extra, read, err := cbg.CborReadHeaderBuf(br, scratch)
 		var extraI int64
 		if err != nil {
-			return bytesRead, err
+			return bytesRead, cbg.WrapDecodeError(err, "EmbedByValueStruct", "Signed", bytesRead)
 		}
 		bytesRead += read
 		switch maj {
 		case cbg.MajUnsignedInt:
 			extraI = int64(extra)
 			if extraI < 0 {
-				return bytesRead, fmt.Errorf("int64 positive overflow")
+				return bytesRead, &cbg.DecodeError{Type: "EmbedByValueStruct", Field: "Signed", Offset: bytesRead - read, Expected: maj, Found: maj, Err: cbg.ErrOverflow}
 			}
 		case cbg.MajNegativeInt:
 			extraI = int64(extra)
 			if extraI < 0 {
-				return bytesRead, fmt.Errorf("int64 negative oveflow")
+				return bytesRead, &cbg.DecodeError{Type: "EmbedByValueStruct", Field: "Signed", Offset: bytesRead - read, Expected: maj, Found: maj, Err: cbg.ErrOverflow}
 			}
 			extraI = -1 - extraI
 		default:
-			return bytesRead, fmt.Errorf("wrong type for int64 field: %d", maj)
+			return bytesRead, &cbg.DecodeError{Type: "EmbedByValueStruct", Field: "Signed", Offset: bytesRead - read, Expected: cbg.MajUnsignedInt, Found: maj, Err: cbg.ErrUnexpectedType}
 		}
 
 		t.Signed = int64(extraI)
@@ -3056,7 +3329,7 @@ func (t *EmbedByValueStruct) UnmarshalCBOR(r io.Reader) (int, error) {
 	{
 		sval, read, err := cbg.ReadStringBuf(br, scratch)
 		if err != nil {
-			return bytesRead, err
+			return bytesRead, cbg.WrapDecodeError(err, "EmbedByValueStruct", "Foo", bytesRead)
 		}
 		bytesRead += read
 
@@ -3066,15 +3339,18 @@ func (t *EmbedByValueStruct) UnmarshalCBOR(r io.Reader) (int, error) {
 
 	maj, extra, read, err = cbg.CborReadHeaderBuf(br, scratch)
 	if err != nil {
-		return bytesRead, err
+		return bytesRead, cbg.WrapDecodeError(err, "EmbedByValueStruct", "Binary", bytesRead)
 	}
 	bytesRead += read
 
 	if extra > cbg.ByteArrayMaxLen {
-		return bytesRead, fmt.Errorf("t.Binary: byte array too large (%d)", extra)
+		return bytesRead, &cbg.DecodeError{Type: "EmbedByValueStruct", Field: "Binary", Offset: bytesRead - read, Expected: cbg.MajByteString, Found: maj, Err: cbg.ErrMaxLength}
 	}
 	if maj != cbg.MajByteString {
-		return bytesRead, fmt.Errorf("expected byte array")
+		return bytesRead, &cbg.DecodeError{Type: "EmbedByValueStruct", Field: "Binary", Offset: bytesRead - read, Expected: cbg.MajByteString, Found: maj, Err: cbg.ErrUnexpectedType}
+	}
+	if err := limits.AddBytes(extra); err != nil {
+		return bytesRead, &cbg.DecodeError{Type: "EmbedByValueStruct", Field: "Binary", Offset: bytesRead - read, Expected: cbg.MajByteString, Found: maj, Err: err}
 	}
 
 	if extra > 0 {
@@ -3082,7 +3358,7 @@ func (t *EmbedByValueStruct) UnmarshalCBOR(r io.Reader) (int, error) {
 	}
 
 	if read, err := io.ReadFull(br, t.Binary[:]); err != nil {
-		return bytesRead, err
+		return bytesRead, cbg.WrapDecodeError(err, "EmbedByValueStruct", "Binary", bytesRead)
 	} else {
 		bytesRead += read
 	}
@@ -3091,7 +3367,7 @@ func (t *EmbedByValueStruct) UnmarshalCBOR(r io.Reader) (int, error) {
 	{
 		sval, read, err := cbg.ReadStringBuf(br, scratch)
 		if err != nil {
-			return bytesRead, err
+			return bytesRead, cbg.WrapDecodeError(err, "EmbedByValueStruct", "NString", bytesRead)
 		}
 		bytesRead += read
 
@@ -3103,11 +3379,11 @@ func (t *EmbedByValueStruct) UnmarshalCBOR(r io.Reader) (int, error) {
 
 		maj, extra, read, err = cbg.CborReadHeaderBuf(br, scratch)
 		if err != nil {
-			return bytesRead, err
+			return bytesRead, cbg.WrapDecodeError(err, "EmbedByValueStruct", "Value", bytesRead)
 		}
 		bytesRead += read
 		if maj != cbg.MajUnsignedInt {
-			return bytesRead, fmt.Errorf("wrong type for uint64 field")
+			return bytesRead, &cbg.DecodeError{Type: "EmbedByValueStruct", Field: "Value", Offset: bytesRead - read, Expected: cbg.MajUnsignedInt, Found: maj, Err: cbg.ErrUnexpectedType}
 		}
 		t.Value = uint64(extra)
 
@@ -3115,6 +3391,30 @@ func (t *EmbedByValueStruct) UnmarshalCBOR(r io.Reader) (int, error) {
 	return bytesRead, nil
 }
 
+func (t *EmbedByValueStruct) MarshalDAGJSON(w io.Writer) error {
+	return cbg.MarshalDAGJSON(w, t)
+}
+
+func (t *EmbedByValueStruct) UnmarshalDAGJSON(r io.Reader) error {
+	return cbg.UnmarshalDAGJSON(r, t)
+}
+
+func (t *EmbedByValueStruct) Links() []cid.Cid {
+	var links []cid.Cid
+	_ = t.ForEachLink(func(_ string, c cid.Cid) error {
+		links = append(links, c)
+		return nil
+	})
+	return links
+}
+
+func (t *EmbedByValueStruct) ForEachLink(cb func(path string, c cid.Cid) error) error {
+	if t == nil {
+		return nil
+	}
+	return nil
+}
+
 func (t *EmbedByPointerStruct) InitNilEmbeddedStruct() {
 	if t != nil {
 		if t.EmbeddedStruct == nil {
@@ -3221,6 +3521,11 @@ func (t *EmbedByPointerStruct) UnmarshalCBOR(r io.Reader) (int, error) {
 
 	br := cbg.GetPeeker(r)
 	scratch := make([]byte, 8)
+	limits := cbg.GetDecodeLimits(br)
+	if err := limits.Enter(); err != nil {
+		return bytesRead, &cbg.DecodeError{Type: "EmbedByPointerStruct", Err: err}
+	}
+	defer limits.Leave()
 
 	maj, extra, read, err := cbg.CborReadHeaderBuf(br, scratch)
 	if err != nil {
@@ -3228,11 +3533,14 @@ func (t *EmbedByPointerStruct) UnmarshalCBOR(r io.Reader) (int, error) {
 	}
 	bytesRead += read
 	if maj != cbg.MajArray {
-		return bytesRead, fmt.Errorf("cbor input should be of type array")
+		return bytesRead, &cbg.DecodeError{Type: "EmbedByPointerStruct", Expected: cbg.MajArray, Found: maj, Err: cbg.ErrUnexpectedType}
 	}
 
 	if extra != 5 {
-		return bytesRead, fmt.Errorf("cbor input had wrong number of fields")
+		return bytesRead, &cbg.DecodeError{Type: "EmbedByPointerStruct", Expected: cbg.MajArray, Found: maj, Err: fmt.Errorf("cbor input had wrong number of fields (%d)", extra)}
+	}
+	if err := limits.AddItems(extra); err != nil {
+		return bytesRead, &cbg.DecodeError{Type: "EmbedByPointerStruct", Expected: cbg.MajArray, Found: maj, Err: err}
 	}
 
 	// t.Signed (int64) (int64)
@@ -3240,23 +3548,23 @@ func (t *EmbedByPointerStruct) UnmarshalCBOR(r io.Reader) (int, error) {
 		maj, extra, read, err := cbg.CborReadHeaderBuf(br, scratch)
 		var extraI int64
 		if err != nil {
-			return bytesRead, err
+			return bytesRead, cbg.WrapDecodeError(err, "EmbedByPointerStruct", "Signed", bytesRead)
 		}
 		bytesRead += read
 		switch maj {
 		case cbg.MajUnsignedInt:
 			extraI = int64(extra)
 			if extraI < 0 {
-				return bytesRead, fmt.Errorf("int64 positive overflow")
+				return bytesRead, &cbg.DecodeError{Type: "EmbedByPointerStruct", Field: "Signed", Offset: bytesRead - read, Expected: maj, Found: maj, Err: cbg.ErrOverflow}
 			}
 		case cbg.MajNegativeInt:
 			extraI = int64(extra)
 			if extraI < 0 {
-				return bytesRead, fmt.Errorf("int64 negative oveflow")
+				return bytesRead, &cbg.DecodeError{Type: "EmbedByPointerStruct", Field: "Signed", Offset: bytesRead - read, Expected: maj, Found: maj, Err: cbg.ErrOverflow}
 			}
 			extraI = -1 - extraI
 		default:
-			return bytesRead, fmt.Errorf("wrong type for int64 field: %d", maj)
+			return bytesRead, &cbg.DecodeError{Type: "EmbedByPointerStruct", Field: "Signed", Offset: bytesRead - read, Expected: cbg.MajUnsignedInt, Found: maj, Err: cbg.ErrUnexpectedType}
 		}
 
 		t.Signed = int64(extraI)
@@ -3266,7 +3574,7 @@ func (t *EmbedByPointerStruct) UnmarshalCBOR(r io.Reader) (int, error) {
 	{
 		sval, read, err := cbg.ReadStringBuf(br, scratch)
 		if err != nil {
-			return bytesRead, err
+			return bytesRead, cbg.WrapDecodeError(err, "EmbedByPointerStruct", "Foo", bytesRead)
 		}
 		bytesRead += read
 
@@ -3276,15 +3584,18 @@ func (t *EmbedByPointerStruct) UnmarshalCBOR(r io.Reader) (int, error) {
 
 	maj, extra, read, err = cbg.CborReadHeaderBuf(br, scratch)
 	if err != nil {
-		return bytesRead, err
+		return bytesRead, cbg.WrapDecodeError(err, "EmbedByPointerStruct", "Binary", bytesRead)
 	}
 	bytesRead += read
 
 	if extra > cbg.ByteArrayMaxLen {
-		return bytesRead, fmt.Errorf("t.Binary: byte array too large (%d)", extra)
+		return bytesRead, &cbg.DecodeError{Type: "EmbedByPointerStruct", Field: "Binary", Offset: bytesRead - read, Expected: cbg.MajByteString, Found: maj, Err: cbg.ErrMaxLength}
 	}
 	if maj != cbg.MajByteString {
-		return bytesRead, fmt.Errorf("expected byte array")
+		return bytesRead, &cbg.DecodeError{Type: "EmbedByPointerStruct", Field: "Binary", Offset: bytesRead - read, Expected: cbg.MajByteString, Found: maj, Err: cbg.ErrUnexpectedType}
+	}
+	if err := limits.AddBytes(extra); err != nil {
+		return bytesRead, &cbg.DecodeError{Type: "EmbedByPointerStruct", Field: "Binary", Offset: bytesRead - read, Expected: cbg.MajByteString, Found: maj, Err: err}
 	}
 
 	if extra > 0 {
@@ -3292,7 +3603,7 @@ func (t *EmbedByPointerStruct) UnmarshalCBOR(r io.Reader) (int, error) {
 	}
 
 	if read, err := io.ReadFull(br, t.Binary[:]); err != nil {
-		return bytesRead, err
+		return bytesRead, cbg.WrapDecodeError(err, "EmbedByPointerStruct", "Binary", bytesRead)
 	} else {
 		bytesRead += read
 	}
@@ -3301,7 +3612,7 @@ func (t *EmbedByPointerStruct) UnmarshalCBOR(r io.Reader) (int, error) {
 	{
 		sval, read, err := cbg.ReadStringBuf(br, scratch)
 		if err != nil {
-			return bytesRead, err
+			return bytesRead, cbg.WrapDecodeError(err, "EmbedByPointerStruct", "NString", bytesRead)
 		}
 		bytesRead += read
 
@@ -3313,14 +3624,634 @@ func (t *EmbedByPointerStruct) UnmarshalCBOR(r io.Reader) (int, error) {
 
 		maj, extra, read, err = cbg.CborReadHeaderBuf(br, scratch)
 		if err != nil {
-			return bytesRead, err
+			return bytesRead, cbg.WrapDecodeError(err, "EmbedByPointerStruct", "Value", bytesRead)
 		}
 		bytesRead += read
 		if maj != cbg.MajUnsignedInt {
-			return bytesRead, fmt.Errorf("wrong type for uint64 field")
+			return bytesRead, &cbg.DecodeError{Type: "EmbedByPointerStruct", Field: "Value", Offset: bytesRead - read, Expected: cbg.MajUnsignedInt, Found: maj, Err: cbg.ErrUnexpectedType}
 		}
 		t.Value = uint64(extra)
 
 	}
 	return bytesRead, nil
 }
+
+func (t *EmbedByPointerStruct) MarshalDAGJSON(w io.Writer) error {
+	return cbg.MarshalDAGJSON(w, t)
+}
+
+func (t *EmbedByPointerStruct) UnmarshalDAGJSON(r io.Reader) error {
+	return cbg.UnmarshalDAGJSON(r, t)
+}
+
+func (t *EmbedByPointerStruct) Links() []cid.Cid {
+	var links []cid.Cid
+	_ = t.ForEachLink(func(_ string, c cid.Cid) error {
+		links = append(links, c)
+		return nil
+	})
+	return links
+}
+
+func (t *EmbedByPointerStruct) ForEachLink(cb func(path string, c cid.Cid) error) error {
+	if t == nil {
+		return nil
+	}
+	return nil
+}
+
+func (t *IndexedFlatStruct) InitNilEmbeddedStruct() {
+	if t != nil {
+	}
+}
+
+var lengthBufIndexedFlatStruct = []byte{133}
+
+func (t *IndexedFlatStruct) MarshalCBOR(w io.Writer) (n int, err error) {
+	if t == nil {
+		return w.Write(cbg.CborNull)
+	}
+	t.InitNilEmbeddedStruct()
+	if n_, err := w.Write(lengthBufIndexedFlatStruct); err != nil {
+		return n_, err
+	} else {
+		n += n_
+	}
+
+	scratch := make([]byte, 9)
+
+	// t.Signed (int64) (int64)
+	if t.Signed >= 0 {
+		if n_, err := cbg.WriteMajorTypeHeaderBuf(scratch, w, cbg.MajUnsignedInt, uint64(t.Signed)); err != nil {
+			return n + n_, err
+		} else {
+			n += n_
+		}
+	} else {
+		if n_, err := cbg.WriteMajorTypeHeaderBuf(scratch, w, cbg.MajNegativeInt, uint64(-t.Signed-1)); err != nil {
+			return n + n_, err
+		} else {
+			n += n_
+		}
+	}
+
+	// t.Foo (string) (string)
+	if len(t.Foo) > cbg.MaxLength {
+		return n, xerrors.Errorf("Value in field t.Foo was too long")
+	}
+
+	if n_, err := cbg.WriteMajorTypeHeaderBuf(scratch, w, cbg.MajTextString, uint64(len(t.Foo))); err != nil {
+		return n + n_, err
+	} else {
+		n += n_
+	}
+	if n_, err := io.WriteString(w, string(t.Foo)); err != nil {
+		return n + n_, err
+	} else {
+		n += n_
+	}
+
+	// t.Binary ([]uint8) (slice)
+	if len(t.Binary) > cbg.ByteArrayMaxLen {
+		return n, xerrors.Errorf("Byte array in field t.Binary was too long")
+	}
+
+	if n_, err := cbg.WriteMajorTypeHeaderBuf(scratch, w, cbg.MajByteString, uint64(len(t.Binary))); err != nil {
+		return n + n_, err
+	} else {
+		n += n_
+	}
+
+	if n_, err := w.Write(t.Binary[:]); err != nil {
+		return n + n_, err
+	} else {
+		n += n_
+	}
+
+	// t.NString (testing.NamedString) (string)
+	if len(t.NString) > cbg.MaxLength {
+		return n, xerrors.Errorf("Value in field t.NString was too long")
+	}
+
+	if n_, err := cbg.WriteMajorTypeHeaderBuf(scratch, w, cbg.MajTextString, uint64(len(t.NString))); err != nil {
+		return n + n_, err
+	} else {
+		n += n_
+	}
+	if n_, err := io.WriteString(w, string(t.NString)); err != nil {
+		return n + n_, err
+	} else {
+		n += n_
+	}
+
+	// t.Value (uint64) (uint64)
+
+	if n_, err := cbg.WriteMajorTypeHeaderBuf(scratch, w, cbg.MajUnsignedInt, uint64(t.Value)); err != nil {
+		return n + n_, err
+	} else {
+		n += n_
+	}
+
+	return n, nil
+}
+
+func (t *IndexedFlatStruct) UnmarshalCBOR(r io.Reader) (int, error) {
+	bytesRead := 0
+	*t = IndexedFlatStruct{}
+	t.InitNilEmbeddedStruct()
+
+	br := cbg.GetPeeker(r)
+	scratch := make([]byte, 8)
+	limits := cbg.GetDecodeLimits(br)
+	if err := limits.Enter(); err != nil {
+		return bytesRead, &cbg.DecodeError{Type: "IndexedFlatStruct", Err: err}
+	}
+	defer limits.Leave()
+
+	maj, extra, read, err := cbg.CborReadHeaderBuf(br, scratch)
+	if err != nil {
+		return bytesRead, err
+	}
+	bytesRead += read
+	if maj != cbg.MajArray {
+		return bytesRead, &cbg.DecodeError{Type: "IndexedFlatStruct", Expected: cbg.MajArray, Found: maj, Err: cbg.ErrUnexpectedType}
+	}
+
+	if extra != 5 {
+		return bytesRead, &cbg.DecodeError{Type: "IndexedFlatStruct", Expected: cbg.MajArray, Found: maj, Err: fmt.Errorf("cbor input had wrong number of fields (%d)", extra)}
+	}
+	if err := limits.AddItems(extra); err != nil {
+		return bytesRead, &cbg.DecodeError{Type: "IndexedFlatStruct", Expected: cbg.MajArray, Found: maj, Err: err}
+	}
+
+	// t.Signed (int64) (int64)
+	{
+		maj, extra, read, err := cbg.CborReadHeaderBuf(br, scratch)
+		var extraI int64
+		if err != nil {
+			return bytesRead, cbg.WrapDecodeError(err, "IndexedFlatStruct", "Signed", bytesRead)
+		}
+		bytesRead += read
+		switch maj {
+		case cbg.MajUnsignedInt:
+			extraI = int64(extra)
+			if extraI < 0 {
+				return bytesRead, &cbg.DecodeError{Type: "IndexedFlatStruct", Field: "Signed", Offset: bytesRead - read, Expected: maj, Found: maj, Err: cbg.ErrOverflow}
+			}
+		case cbg.MajNegativeInt:
+			extraI = int64(extra)
+			if extraI < 0 {
+				return bytesRead, &cbg.DecodeError{Type: "IndexedFlatStruct", Field: "Signed", Offset: bytesRead - read, Expected: maj, Found: maj, Err: cbg.ErrOverflow}
+			}
+			extraI = -1 - extraI
+		default:
+			return bytesRead, &cbg.DecodeError{Type: "IndexedFlatStruct", Field: "Signed", Offset: bytesRead - read, Expected: cbg.MajUnsignedInt, Found: maj, Err: cbg.ErrUnexpectedType}
+		}
+
+		t.Signed = int64(extraI)
+	}
+	// t.Foo (string) (string)
+
+	{
+		sval, read, err := cbg.ReadStringBuf(br, scratch)
+		if err != nil {
+			return bytesRead, cbg.WrapDecodeError(err, "IndexedFlatStruct", "Foo", bytesRead)
+		}
+		bytesRead += read
+
+		t.Foo = string(sval)
+	}
+	// t.Binary ([]uint8) (slice)
+
+	maj, extra, read, err = cbg.CborReadHeaderBuf(br, scratch)
+	if err != nil {
+		return bytesRead, cbg.WrapDecodeError(err, "IndexedFlatStruct", "Binary", bytesRead)
+	}
+	bytesRead += read
+
+	if extra > cbg.ByteArrayMaxLen {
+		return bytesRead, &cbg.DecodeError{Type: "IndexedFlatStruct", Field: "Binary", Offset: bytesRead - read, Expected: cbg.MajByteString, Found: maj, Err: cbg.ErrMaxLength}
+	}
+	if maj != cbg.MajByteString {
+		return bytesRead, &cbg.DecodeError{Type: "IndexedFlatStruct", Field: "Binary", Offset: bytesRead - read, Expected: cbg.MajByteString, Found: maj, Err: cbg.ErrUnexpectedType}
+	}
+	if err := limits.AddBytes(extra); err != nil {
+		return bytesRead, &cbg.DecodeError{Type: "IndexedFlatStruct", Field: "Binary", Offset: bytesRead - read, Expected: cbg.MajByteString, Found: maj, Err: err}
+	}
+
+	if extra > 0 {
+		t.Binary = make([]uint8, extra)
+	}
+
+	if read, err := io.ReadFull(br, t.Binary[:]); err != nil {
+		return bytesRead, cbg.WrapDecodeError(err, "IndexedFlatStruct", "Binary", bytesRead)
+	} else {
+		bytesRead += read
+	}
+	// t.NString (testing.NamedString) (string)
+
+	{
+		sval, read, err := cbg.ReadStringBuf(br, scratch)
+		if err != nil {
+			return bytesRead, cbg.WrapDecodeError(err, "IndexedFlatStruct", "NString", bytesRead)
+		}
+		bytesRead += read
+
+		t.NString = testing.NamedString(sval)
+	}
+	// t.Value (uint64) (uint64)
+
+	{
+
+		maj, extra, read, err = cbg.CborReadHeaderBuf(br, scratch)
+		if err != nil {
+			return bytesRead, cbg.WrapDecodeError(err, "IndexedFlatStruct", "Value", bytesRead)
+		}
+		bytesRead += read
+		if maj != cbg.MajUnsignedInt {
+			return bytesRead, &cbg.DecodeError{Type: "IndexedFlatStruct", Field: "Value", Offset: bytesRead - read, Expected: cbg.MajUnsignedInt, Found: maj, Err: cbg.ErrUnexpectedType}
+		}
+		t.Value = uint64(extra)
+
+	}
+	return bytesRead, nil
+}
+
+func (t *IndexedFlatStruct) MarshalDAGJSON(w io.Writer) error {
+	return cbg.MarshalDAGJSON(w, t)
+}
+
+func (t *IndexedFlatStruct) UnmarshalDAGJSON(r io.Reader) error {
+	return cbg.UnmarshalDAGJSON(r, t)
+}
+
+func (t *IndexedFlatStruct) Links() []cid.Cid {
+	var links []cid.Cid
+	_ = t.ForEachLink(func(_ string, c cid.Cid) error {
+		links = append(links, c)
+		return nil
+	})
+	return links
+}
+
+func (t *IndexedFlatStruct) ForEachLink(cb func(path string, c cid.Cid) error) error {
+	if t == nil {
+		return nil
+	}
+	return nil
+}
+
+func (t *EmbeddedLinks) InitNilEmbeddedStruct() {
+	if t != nil {
+	}
+}
+
+var lengthBufEmbeddedLinks = []byte{130}
+
+func (t *EmbeddedLinks) MarshalCBOR(w io.Writer) (n int, err error) {
+	if t == nil {
+		return w.Write(cbg.CborNull)
+	}
+	t.InitNilEmbeddedStruct()
+	if n_, err := w.Write(lengthBufEmbeddedLinks); err != nil {
+		return n_, err
+	} else {
+		n += n_
+	}
+
+	scratch := make([]byte, 9)
+
+	// t.Link (cid.Cid) (struct)
+
+	if n_, err := cbg.WriteCidBuf(scratch, w, t.Link); err != nil {
+		return n + n_, xerrors.Errorf("failed to write cid field t.Link: %w", err)
+	} else {
+		n += n_
+	}
+
+	// t.Cids ([]cid.Cid) (slice)
+	if len(t.Cids) > cbg.MaxLength {
+		return n, xerrors.Errorf("Slice value in field t.Cids was too long")
+	}
+
+	if n_, err := cbg.WriteMajorTypeHeaderBuf(scratch, w, cbg.MajArray, uint64(len(t.Cids))); err != nil {
+		return n + n_, err
+	} else {
+		n += n_
+	}
+	for _, v := range t.Cids {
+		if n_, err := cbg.WriteCidBuf(scratch, w, v); err != nil {
+			return n + n_, xerrors.Errorf("failed writing cid field t.Cids: %w", err)
+		} else {
+			n += n_
+		}
+	}
+	return n, nil
+}
+
+func (t *EmbeddedLinks) UnmarshalCBOR(r io.Reader) (int, error) {
+	bytesRead := 0
+	*t = EmbeddedLinks{}
+	t.InitNilEmbeddedStruct()
+
+	br := cbg.GetPeeker(r)
+	scratch := make([]byte, 8)
+	limits := cbg.GetDecodeLimits(br)
+	if err := limits.Enter(); err != nil {
+		return bytesRead, &cbg.DecodeError{Type: "EmbeddedLinks", Err: err}
+	}
+	defer limits.Leave()
+
+	maj, extra, read, err := cbg.CborReadHeaderBuf(br, scratch)
+	if err != nil {
+		return bytesRead, err
+	}
+	bytesRead += read
+	if maj != cbg.MajArray {
+		return bytesRead, &cbg.DecodeError{Type: "EmbeddedLinks", Expected: cbg.MajArray, Found: maj, Err: cbg.ErrUnexpectedType}
+	}
+
+	if extra != 2 {
+		return bytesRead, &cbg.DecodeError{Type: "EmbeddedLinks", Expected: cbg.MajArray, Found: maj, Err: fmt.Errorf("cbor input had wrong number of fields (%d)", extra)}
+	}
+	if err := limits.AddItems(extra); err != nil {
+		return bytesRead, &cbg.DecodeError{Type: "EmbeddedLinks", Expected: cbg.MajArray, Found: maj, Err: err}
+	}
+
+	// t.Link (cid.Cid) (struct)
+
+	{
+
+		c, read, err := cbg.ReadCid(br)
+		if err != nil {
+			return bytesRead, cbg.WrapDecodeError(err, "EmbeddedLinks", "Link", bytesRead)
+		}
+		bytesRead += read
+
+		t.Link = c
+
+	}
+	// t.Cids ([]cid.Cid) (slice)
+
+	maj, extra, read, err = cbg.CborReadHeaderBuf(br, scratch)
+	if err != nil {
+		return bytesRead, cbg.WrapDecodeError(err, "EmbeddedLinks", "Cids", bytesRead)
+	}
+	bytesRead += read
+
+	if extra > cbg.MaxLength {
+		return bytesRead, &cbg.DecodeError{Type: "EmbeddedLinks", Field: "Cids", Offset: bytesRead - read, Expected: cbg.MajArray, Found: maj, Err: cbg.ErrMaxLength}
+	}
+
+	if maj != cbg.MajArray {
+		return bytesRead, &cbg.DecodeError{Type: "EmbeddedLinks", Field: "Cids", Offset: bytesRead - read, Expected: cbg.MajArray, Found: maj, Err: cbg.ErrUnexpectedType}
+	}
+	if err := limits.AddItems(extra); err != nil {
+		return bytesRead, &cbg.DecodeError{Type: "EmbeddedLinks", Field: "Cids", Offset: bytesRead - read, Expected: cbg.MajArray, Found: maj, Err: err}
+	}
+
+	if extra > 0 {
+		t.Cids = make([]cid.Cid, extra)
+	}
+
+	for i := 0; i < int(extra); i++ {
+
+		c, read, err := cbg.ReadCid(br)
+		if err != nil {
+			return bytesRead, cbg.WrapDecodeError(err, "EmbeddedLinks", fmt.Sprintf("Cids[%d]", i), bytesRead)
+		}
+		bytesRead += read
+		t.Cids[i] = c
+	}
+
+	return bytesRead, nil
+}
+
+func (t *EmbeddedLinks) MarshalDAGJSON(w io.Writer) error {
+	return cbg.MarshalDAGJSON(w, t)
+}
+
+func (t *EmbeddedLinks) UnmarshalDAGJSON(r io.Reader) error {
+	return cbg.UnmarshalDAGJSON(r, t)
+}
+
+func (t *EmbeddedLinks) Links() []cid.Cid {
+	var links []cid.Cid
+	_ = t.ForEachLink(func(_ string, c cid.Cid) error {
+		links = append(links, c)
+		return nil
+	})
+	return links
+}
+
+func (t *EmbeddedLinks) ForEachLink(cb func(path string, c cid.Cid) error) error {
+	if t == nil {
+		return nil
+	}
+	if t.Link.Defined() {
+		if err := cb("0", t.Link); err != nil {
+			return err
+		}
+	}
+	for i := range t.Cids {
+		if t.Cids[i].Defined() {
+			if err := cb(fmt.Sprintf("%s/%d", "1", i), t.Cids[i]); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func (t *EmbedLinksByPointer) InitNilEmbeddedStruct() {
+	if t != nil {
+		if t.EmbeddedLinks == nil {
+			t.EmbeddedLinks = &EmbeddedLinks{}
+		}
+		t.EmbeddedLinks.InitNilEmbeddedStruct()
+	}
+}
+
+var lengthBufEmbedLinksByPointer = []byte{131}
+
+func (t *EmbedLinksByPointer) MarshalCBOR(w io.Writer) (n int, err error) {
+	if t == nil {
+		return w.Write(cbg.CborNull)
+	}
+	t.InitNilEmbeddedStruct()
+	if n_, err := w.Write(lengthBufEmbedLinksByPointer); err != nil {
+		return n_, err
+	} else {
+		n += n_
+	}
+
+	scratch := make([]byte, 9)
+
+	// t.Value (uint64) (uint64)
+
+	if n_, err := cbg.WriteMajorTypeHeaderBuf(scratch, w, cbg.MajUnsignedInt, uint64(t.Value)); err != nil {
+		return n + n_, err
+	} else {
+		n += n_
+	}
+
+	// t.Link (cid.Cid) (struct)
+
+	if n_, err := cbg.WriteCidBuf(scratch, w, t.Link); err != nil {
+		return n + n_, xerrors.Errorf("failed to write cid field t.Link: %w", err)
+	} else {
+		n += n_
+	}
+
+	// t.Cids ([]cid.Cid) (slice)
+	if len(t.Cids) > cbg.MaxLength {
+		return n, xerrors.Errorf("Slice value in field t.Cids was too long")
+	}
+
+	if n_, err := cbg.WriteMajorTypeHeaderBuf(scratch, w, cbg.MajArray, uint64(len(t.Cids))); err != nil {
+		return n + n_, err
+	} else {
+		n += n_
+	}
+	for _, v := range t.Cids {
+		if n_, err := cbg.WriteCidBuf(scratch, w, v); err != nil {
+			return n + n_, xerrors.Errorf("failed writing cid field t.Cids: %w", err)
+		} else {
+			n += n_
+		}
+	}
+	return n, nil
+}
+
+func (t *EmbedLinksByPointer) UnmarshalCBOR(r io.Reader) (int, error) {
+	bytesRead := 0
+	*t = EmbedLinksByPointer{}
+	t.InitNilEmbeddedStruct()
+
+	br := cbg.GetPeeker(r)
+	scratch := make([]byte, 8)
+	limits := cbg.GetDecodeLimits(br)
+	if err := limits.Enter(); err != nil {
+		return bytesRead, &cbg.DecodeError{Type: "EmbedLinksByPointer", Err: err}
+	}
+	defer limits.Leave()
+
+	maj, extra, read, err := cbg.CborReadHeaderBuf(br, scratch)
+	if err != nil {
+		return bytesRead, err
+	}
+	bytesRead += read
+	if maj != cbg.MajArray {
+		return bytesRead, &cbg.DecodeError{Type: "EmbedLinksByPointer", Expected: cbg.MajArray, Found: maj, Err: cbg.ErrUnexpectedType}
+	}
+
+	if extra != 3 {
+		return bytesRead, &cbg.DecodeError{Type: "EmbedLinksByPointer", Expected: cbg.MajArray, Found: maj, Err: fmt.Errorf("cbor input had wrong number of fields (%d)", extra)}
+	}
+	if err := limits.AddItems(extra); err != nil {
+		return bytesRead, &cbg.DecodeError{Type: "EmbedLinksByPointer", Expected: cbg.MajArray, Found: maj, Err: err}
+	}
+
+	// t.Value (uint64) (uint64)
+
+	{
+
+		maj, extra, read, err = cbg.CborReadHeaderBuf(br, scratch)
+		if err != nil {
+			return bytesRead, cbg.WrapDecodeError(err, "EmbedLinksByPointer", "Value", bytesRead)
+		}
+		bytesRead += read
+		if maj != cbg.MajUnsignedInt {
+			return bytesRead, &cbg.DecodeError{Type: "EmbedLinksByPointer", Field: "Value", Offset: bytesRead - read, Expected: cbg.MajUnsignedInt, Found: maj, Err: cbg.ErrUnexpectedType}
+		}
+		t.Value = uint64(extra)
+
+	}
+	// t.Link (cid.Cid) (struct)
+
+	{
+
+		c, read, err := cbg.ReadCid(br)
+		if err != nil {
+			return bytesRead, cbg.WrapDecodeError(err, "EmbedLinksByPointer", "Link", bytesRead)
+		}
+		bytesRead += read
+
+		t.Link = c
+
+	}
+	// t.Cids ([]cid.Cid) (slice)
+
+	maj, extra, read, err = cbg.CborReadHeaderBuf(br, scratch)
+	if err != nil {
+		return bytesRead, cbg.WrapDecodeError(err, "EmbedLinksByPointer", "Cids", bytesRead)
+	}
+	bytesRead += read
+
+	if extra > cbg.MaxLength {
+		return bytesRead, &cbg.DecodeError{Type: "EmbedLinksByPointer", Field: "Cids", Offset: bytesRead - read, Expected: cbg.MajArray, Found: maj, Err: cbg.ErrMaxLength}
+	}
+
+	if maj != cbg.MajArray {
+		return bytesRead, &cbg.DecodeError{Type: "EmbedLinksByPointer", Field: "Cids", Offset: bytesRead - read, Expected: cbg.MajArray, Found: maj, Err: cbg.ErrUnexpectedType}
+	}
+	if err := limits.AddItems(extra); err != nil {
+		return bytesRead, &cbg.DecodeError{Type: "EmbedLinksByPointer", Field: "Cids", Offset: bytesRead - read, Expected: cbg.MajArray, Found: maj, Err: err}
+	}
+
+	if extra > 0 {
+		t.Cids = make([]cid.Cid, extra)
+	}
+
+	for i := 0; i < int(extra); i++ {
+
+		c, read, err := cbg.ReadCid(br)
+		if err != nil {
+			return bytesRead, cbg.WrapDecodeError(err, "EmbedLinksByPointer", fmt.Sprintf("Cids[%d]", i), bytesRead)
+		}
+		bytesRead += read
+		t.Cids[i] = c
+	}
+
+	return bytesRead, nil
+}
+
+func (t *EmbedLinksByPointer) MarshalDAGJSON(w io.Writer) error {
+	return cbg.MarshalDAGJSON(w, t)
+}
+
+func (t *EmbedLinksByPointer) UnmarshalDAGJSON(r io.Reader) error {
+	return cbg.UnmarshalDAGJSON(r, t)
+}
+
+func (t *EmbedLinksByPointer) Links() []cid.Cid {
+	var links []cid.Cid
+	_ = t.ForEachLink(func(_ string, c cid.Cid) error {
+		links = append(links, c)
+		return nil
+	})
+	return links
+}
+
+func (t *EmbedLinksByPointer) ForEachLink(cb func(path string, c cid.Cid) error) error {
+	if t == nil {
+		return nil
+	}
+	if t.EmbeddedLinks != nil {
+		if t.Link.Defined() {
+			if err := cb("1", t.Link); err != nil {
+				return err
+			}
+		}
+	}
+	if t.EmbeddedLinks != nil {
+		for i := range t.Cids {
+			if t.Cids[i].Defined() {
+				if err := cb(fmt.Sprintf("%s/%d", "2", i), t.Cids[i]); err != nil {
+					return err
+				}
+			}
+		}
+	}
+	return nil
+}
@@ -121,6 +121,11 @@ func (t *ReorderedFlatStruct) UnmarshalCBOR(r io.Reader) (int, error) {
 
 	br := cbg.GetPeeker(r)
 	scratch := make([]byte, 8)
+	limits := cbg.GetDecodeLimits(br)
+	if err := limits.Enter(); err != nil {
+		return bytesRead, &cbg.DecodeError{Type: "ReorderedFlatStruct", Err: err}
+	}
+	defer limits.Leave()
 
 	maj, extra, read, err := cbg.CborReadHeaderBuf(br, scratch)
 	if err != nil {
@@ -128,11 +133,14 @@ func (t *ReorderedFlatStruct) UnmarshalCBOR(r io.Reader) (int, error) {
 	}
 	bytesRead += read
 	if maj != cbg.MajArray {
-		return bytesRead, fmt.Errorf("cbor input should be of type array")
+		return bytesRead, &cbg.DecodeError{Type: "ReorderedFlatStruct", Expected: cbg.MajArray, Found: maj, Err: cbg.ErrUnexpectedType}
 	}
 
 	if extra != 5 {
-		return bytesRead, fmt.Errorf("cbor input had wrong number of fields")
+		return bytesRead, &cbg.DecodeError{Type: "ReorderedFlatStruct", Expected: cbg.MajArray, Found: maj, Err: fmt.Errorf("cbor input had wrong number of fields (%d)", extra)}
+	}
+	if err := limits.AddItems(extra); err != nil {
+		return bytesRead, &cbg.DecodeError{Type: "ReorderedFlatStruct", Expected: cbg.MajArray, Found: maj, Err: err}
 	}
 
 	// t.Signed (int64) (int64)
@@ -140,23 +148,23 @@ func (t *ReorderedFlatStruct) UnmarshalCBOR(r io.Reader) (int, error) {
 		maj, extra, read, err := cbg.CborReadHeaderBuf(br, scratch)
 		var extraI int64
 		if err != nil {
-			return bytesRead, err
+			return bytesRead, cbg.WrapDecodeError(err, "ReorderedFlatStruct", "Signed", bytesRead)
 		}
 		bytesRead += read
 		switch maj {
 		case cbg.MajUnsignedInt:
 			extraI = int64(extra)
 			if extraI < 0 {
-				return bytesRead, fmt.Errorf("int64 positive overflow")
+				return bytesRead, &cbg.DecodeError{Type: "ReorderedFlatStruct", Field: "Signed", Offset: bytesRead - read, Expected: maj, Found: maj, Err: cbg.ErrOverflow}
 			}
 		case cbg.MajNegativeInt:
 			extraI = int64(extra)
 			if extraI < 0 {
-				return bytesRead, fmt.Errorf("int64 negative oveflow")
+				return bytesRead, &cbg.DecodeError{Type: "ReorderedFlatStruct", Field: "Signed", Offset: bytesRead - read, Expected: maj, Found: maj, Err: cbg.ErrOverflow}
 			}
 			extraI = -1 - extraI
 		default:
-			return bytesRead, fmt.Errorf("wrong type for int64 field: %d", maj)
+			return bytesRead, &cbg.DecodeError{Type: "ReorderedFlatStruct", Field: "Signed", Offset: bytesRead - read, Expected: cbg.MajUnsignedInt, Found: maj, Err: cbg.ErrUnexpectedType}
 		}
 
 		t.Signed = int64(extraI)
@@ -166,7 +174,7 @@ func (t *ReorderedFlatStruct) UnmarshalCBOR(r io.Reader) (int, error) {
 	{
 		sval, read, err := cbg.ReadStringBuf(br, scratch)
 		if err != nil {
-			return bytesRead, err
+			return bytesRead, cbg.WrapDecodeError(err, "ReorderedFlatStruct", "Foo", bytesRead)
 		}
 		bytesRead += read
 
@@ -176,15 +184,18 @@ func (t *ReorderedFlatStruct) UnmarshalCBOR(r io.Reader) (int, error) {
 
 	maj, extra, read, err = cbg.CborReadHeaderBuf(br, scratch)
 	if err != nil {
-		return bytesRead, err
+		return bytesRead, cbg.WrapDecodeError(err, "ReorderedFlatStruct", "Binary", bytesRead)
 	}
 	bytesRead += read
 
 	if extra > cbg.ByteArrayMaxLen {
-		return bytesRead, fmt.Errorf("t.Binary: byte array too large (%d)", extra)
+		return bytesRead, &cbg.DecodeError{Type: "ReorderedFlatStruct", Field: "Binary", Offset: bytesRead - read, Expected: cbg.MajByteString, Found: maj, Err: cbg.ErrMaxLength}
 	}
 	if maj != cbg.MajByteString {
-		return bytesRead, fmt.Errorf("expected byte array")
+		return bytesRead, &cbg.DecodeError{Type: "ReorderedFlatStruct", Field: "Binary", Offset: bytesRead - read, Expected: cbg.MajByteString, Found: maj, Err: cbg.ErrUnexpectedType}
+	}
+	if err := limits.AddBytes(extra); err != nil {
+		return bytesRead, &cbg.DecodeError{Type: "ReorderedFlatStruct", Field: "Binary", Offset: bytesRead - read, Expected: cbg.MajByteString, Found: maj, Err: err}
 	}
 
 	if extra > 0 {
@@ -192,7 +203,7 @@ func (t *ReorderedFlatStruct) UnmarshalCBOR(r io.Reader) (int, error) {
 	}
 
 	if read, err := io.ReadFull(br, t.Binary[:]); err != nil {
-		return bytesRead, err
+		return bytesRead, cbg.WrapDecodeError(err, "ReorderedFlatStruct", "Binary", bytesRead)
 	} else {
 		bytesRead += read
 	}
@@ -201,7 +212,7 @@ func (t *ReorderedFlatStruct) UnmarshalCBOR(r io.Reader) (int, error) {
 	{
 		sval, read, err := cbg.ReadStringBuf(br, scratch)
 		if err != nil {
-			return bytesRead, err
+			return bytesRead, cbg.WrapDecodeError(err, "ReorderedFlatStruct", "NString", bytesRead)
 		}
 		bytesRead += read
 
@@ -213,11 +224,11 @@ func (t *ReorderedFlatStruct) UnmarshalCBOR(r io.Reader) (int, error) {
 
 		maj, extra, read, err = cbg.CborReadHeaderBuf(br, scratch)
 		if err != nil {
-			return bytesRead, err
+			return bytesRead, cbg.WrapDecodeError(err, "ReorderedFlatStruct", "Value", bytesRead)
 		}
 		bytesRead += read
 		if maj != cbg.MajUnsignedInt {
-			return bytesRead, fmt.Errorf("wrong type for uint64 field")
+			return bytesRead, &cbg.DecodeError{Type: "ReorderedFlatStruct", Field: "Value", Offset: bytesRead - read, Expected: cbg.MajUnsignedInt, Found: maj, Err: cbg.ErrUnexpectedType}
 		}
 		t.Value = uint64(extra)
 
@@ -225,6 +236,30 @@ func (t *ReorderedFlatStruct) UnmarshalCBOR(r io.Reader) (int, error) {
 	return bytesRead, nil
 }
 
+func (t *ReorderedFlatStruct) MarshalDAGJSON(w io.Writer) error {
+	return cbg.MarshalDAGJSON(w, t)
+}
+
+func (t *ReorderedFlatStruct) UnmarshalDAGJSON(r io.Reader) error {
+	return cbg.UnmarshalDAGJSON(r, t)
+}
+
+func (t *ReorderedFlatStruct) Links() []cid.Cid {
+	var links []cid.Cid
+	_ = t.ForEachLink(func(_ string, c cid.Cid) error {
+		links = append(links, c)
+		return nil
+	})
+	return links
+}
+
+func (t *ReorderedFlatStruct) ForEachLink(cb func(path string, c cid.Cid) error) error {
+	if t == nil {
+		return nil
+	}
+	return nil
+}
+
 func (t *ReorderedEmbedByValueStruct) InitNilEmbeddedStruct() {
 	if t != nil {
 	}
@@ -327,6 +362,11 @@ func (t *ReorderedEmbedByValueStruct) UnmarshalCBOR(r io.Reader) (int, error) {
 
 	br := cbg.GetPeeker(r)
 	scratch := make([]byte, 8)
+	limits := cbg.GetDecodeLimits(br)
+	if err := limits.Enter(); err != nil {
+		return bytesRead, &cbg.DecodeError{Type: "ReorderedEmbedByValueStruct", Err: err}
+	}
+	defer limits.Leave()
 
 	maj, extra, read, err := cbg.CborReadHeaderBuf(br, scratch)
 	if err != nil {
@@ -334,11 +374,14 @@ func (t *ReorderedEmbedByValueStruct) UnmarshalCBOR(r io.Reader) (int, error) {
 	}
 	bytesRead += read
 	if maj != cbg.MajArray {
-		return bytesRead, fmt.Errorf("cbor input should be of type array")
+		return bytesRead, &cbg.DecodeError{Type: "ReorderedEmbedByValueStruct", Expected: cbg.MajArray, Found: maj, Err: cbg.ErrUnexpectedType}
 	}
 
 	if extra != 5 {
-		return bytesRead, fmt.Errorf("cbor input had wrong number of fields")
+		return bytesRead, &cbg.DecodeError{Type: "ReorderedEmbedByValueStruct", Expected: cbg.MajArray, Found: maj, Err: fmt.Errorf("cbor input had wrong number of fields (%d)", extra)}
+	}
+	if err := limits.AddItems(extra); err != nil {
+		return bytesRead, &cbg.DecodeError{Type: "ReorderedEmbedByValueStruct", Expected: cbg.MajArray, Found: maj, Err: err}
 	}
 
 	// t.Signed (int64) (int64)
@@ -346,23 +389,23 @@ func (t *ReorderedEmbedByValueStruct) UnmarshalCBOR(r io.Reader) (int, error) {
 		maj, extra, read, err := cbg.CborReadHeaderBuf(br, scratch)
 		var extraI int64
 		if err != nil {
-			return bytesRead, err
+			return bytesRead, cbg.WrapDecodeError(err, "ReorderedEmbedByValueStruct", "Signed", bytesRead)
 		}
 		bytesRead += read
 		switch maj {
 		case cbg.MajUnsignedInt:
 			extraI = int64(extra)
 			if extraI < 0 {
-				return bytesRead, fmt.Errorf("int64 positive overflow")
+				return bytesRead, &cbg.DecodeError{Type: "ReorderedEmbedByValueStruct", Field: "Signed", Offset: bytesRead - read, Expected: maj, Found: maj, Err: cbg.ErrOverflow}
 			}
 		case cbg.MajNegativeInt:
 			extraI = int64(extra)
 			if extraI < 0 {
-				return bytesRead, fmt.Errorf("int64 negative oveflow")
+				return bytesRead, &cbg.DecodeError{Type: "ReorderedEmbedByValueStruct", Field: "Signed", Offset: bytesRead - read, Expected: maj, Found: maj, Err: cbg.ErrOverflow}
 			}
 			extraI = -1 - extraI
 		default:
-			return bytesRead, fmt.Errorf("wrong type for int64 field: %d", maj)
+			return bytesRead, &cbg.DecodeError{Type: "ReorderedEmbedByValueStruct", Field: "Signed", Offset: bytesRead - read, Expected: cbg.MajUnsignedInt, Found: maj, Err: cbg.ErrUnexpectedType}
 		}
 
 		t.Signed = int64(extraI)
@@ -372,7 +415,7 @@ func (t *ReorderedEmbedByValueStruct) UnmarshalCBOR(r io.Reader) (int, error) {
 	{
 		sval, read, err := cbg.ReadStringBuf(br, scratch)
 		if err != nil {
-			return bytesRead, err
+			return bytesRead, cbg.WrapDecodeError(err, "ReorderedEmbedByValueStruct", "Foo", bytesRead)
 		}
 		bytesRead += read
 
@@ -382,15 +425,18 @@ func (t *ReorderedEmbedByValueStruct) UnmarshalCBOR(r io.Reader) (int, error) {
 
 	maj, extra, read, err = cbg.CborReadHeaderBuf(br, scratch)
 	if err != nil {
-		return bytesRead, err
+		return bytesRead, cbg.WrapDecodeError(err, "ReorderedEmbedByValueStruct", "Binary", bytesRead)
 	}
 	bytesRead += read
 
 	if extra > cbg.ByteArrayMaxLen {
-		return bytesRead, fmt.Errorf("t.Binary: byte array too large (%d)", extra)
+		return bytesRead, &cbg.DecodeError{Type: "ReorderedEmbedByValueStruct", Field: "Binary", Offset: bytesRead - read, Expected: cbg.MajByteString, Found: maj, Err: cbg.ErrMaxLength}
 	}
 	if maj != cbg.MajByteString {
-		return bytesRead, fmt.Errorf("expected byte array")
+		return bytesRead, &cbg.DecodeError{Type: "ReorderedEmbedByValueStruct", Field: "Binary", Offset: bytesRead - read, Expected: cbg.MajByteString, Found: maj, Err: cbg.ErrUnexpectedType}
+	}
+	if err := limits.AddBytes(extra); err != nil {
+		return bytesRead, &cbg.DecodeError{Type: "ReorderedEmbedByValueStruct", Field: "Binary", Offset: bytesRead - read, Expected: cbg.MajByteString, Found: maj, Err: err}
 	}
 
 	if extra > 0 {
@@ -398,7 +444,7 @@ func (t *ReorderedEmbedByValueStruct) UnmarshalCBOR(r io.Reader) (int, error) {
 	}
 
 	if read, err := io.ReadFull(br, t.Binary[:]); err != nil {
-		return bytesRead, err
+		return bytesRead, cbg.WrapDecodeError(err, "ReorderedEmbedByValueStruct", "Binary", bytesRead)
 	} else {
 		bytesRead += read
 	}
@@ -407,7 +453,7 @@ func (t *ReorderedEmbedByValueStruct) UnmarshalCBOR(r io.Reader) (int, error) {
 	{
 		sval, read, err := cbg.ReadStringBuf(br, scratch)
 		if err != nil {
-			return bytesRead, err
+			return bytesRead, cbg.WrapDecodeError(err, "ReorderedEmbedByValueStruct", "NString", bytesRead)
 		}
 		bytesRead += read
 
@@ -419,11 +465,11 @@ func (t *ReorderedEmbedByValueStruct) UnmarshalCBOR(r io.Reader) (int, error) {
 
 		maj, extra, read, err = cbg.CborReadHeaderBuf(br, scratch)
 		if err != nil {
-			return bytesRead, err
+			return bytesRead, cbg.WrapDecodeError(err, "ReorderedEmbedByValueStruct", "Value", bytesRead)
 		}
 		bytesRead += read
 		if maj != cbg.MajUnsignedInt {
-			return bytesRead, fmt.Errorf("wrong type for uint64 field")
+			return bytesRead, &cbg.DecodeError{Type: "ReorderedEmbedByValueStruct", Field: "Value", Offset: bytesRead - read, Expected: cbg.MajUnsignedInt, Found: maj, Err: cbg.ErrUnexpectedType}
 		}
 		t.Value = uint64(extra)
 
@@ -431,6 +477,30 @@ func (t *ReorderedEmbedByValueStruct) UnmarshalCBOR(r io.Reader) (int, error) {
 	return bytesRead, nil
 }
 
+func (t *ReorderedEmbedByValueStruct) MarshalDAGJSON(w io.Writer) error {
+	return cbg.MarshalDAGJSON(w, t)
+}
+
+func (t *ReorderedEmbedByValueStruct) UnmarshalDAGJSON(r io.Reader) error {
+	return cbg.UnmarshalDAGJSON(r, t)
+}
+
+func (t *ReorderedEmbedByValueStruct) Links() []cid.Cid {
+	var links []cid.Cid
+	_ = t.ForEachLink(func(_ string, c cid.Cid) error {
+		links = append(links, c)
+		return nil
+	})
+	return links
+}
+
+func (t *ReorderedEmbedByValueStruct) ForEachLink(cb func(path string, c cid.Cid) error) error {
+	if t == nil {
+		return nil
+	}
+	return nil
+}
+
 func (t *ReorderedEmbedByPointerStruct) InitNilEmbeddedStruct() {
 	if t != nil {
 		if t.EmbeddedStruct == nil {
@@ -537,6 +607,11 @@ func (t *ReorderedEmbedByPointerStruct) UnmarshalCBOR(r io.Reader) (int, error)
 
 	br := cbg.GetPeeker(r)
 	scratch := make([]byte, 8)
+	limits := cbg.GetDecodeLimits(br)
+	if err := limits.Enter(); err != nil {
+		return bytesRead, &cbg.DecodeError{Type: "ReorderedEmbedByPointerStruct", Err: err}
+	}
+	defer limits.Leave()
 
 	maj, extra, read, err := cbg.CborReadHeaderBuf(br, scratch)
 	if err != nil {
@@ -544,11 +619,14 @@ func (t *ReorderedEmbedByPointerStruct) UnmarshalCBOR(r io.Reader) (int, error)
 	}
 	bytesRead += read
 	if maj != cbg.MajArray {
-		return bytesRead, fmt.Errorf("cbor input should be of type array")
+		return bytesRead, &cbg.DecodeError{Type: "ReorderedEmbedByPointerStruct", Expected: cbg.MajArray, Found: maj, Err: cbg.ErrUnexpectedType}
 	}
 
 	if extra != 5 {
-		return bytesRead, fmt.Errorf("cbor input had wrong number of fields")
+		return bytesRead, &cbg.DecodeError{Type: "ReorderedEmbedByPointerStruct", Expected: cbg.MajArray, Found: maj, Err: fmt.Errorf("cbor input had wrong number of fields (%d)", extra)}
+	}
+	if err := limits.AddItems(extra); err != nil {
+		return bytesRead, &cbg.DecodeError{Type: "ReorderedEmbedByPointerStruct", Expected: cbg.MajArray, Found: maj, Err: err}
 	}
 
 	// t.Signed (int64) (int64)
@@ -556,23 +634,23 @@ func (t *ReorderedEmbedByPointerStruct) UnmarshalCBOR(r io.Reader) (int, error)
 		maj, extra, read, err := cbg.CborReadHeaderBuf(br, scratch)
 		var extraI int64
 		if err != nil {
-			return bytesRead, err
+			return bytesRead, cbg.WrapDecodeError(err, "ReorderedEmbedByPointerStruct", "Signed", bytesRead)
 		}
 		bytesRead += read
 		switch maj {
 		case cbg.MajUnsignedInt:
 			extraI = int64(extra)
 			if extraI < 0 {
-				return bytesRead, fmt.Errorf("int64 positive overflow")
+				return bytesRead, &cbg.DecodeError{Type: "ReorderedEmbedByPointerStruct", Field: "Signed", Offset: bytesRead - read, Expected: maj, Found: maj, Err: cbg.ErrOverflow}
 			}
 		case cbg.MajNegativeInt:
 			extraI = int64(extra)
 			if extraI < 0 {
-				return bytesRead, fmt.Errorf("int64 negative oveflow")
+				return bytesRead, &cbg.DecodeError{Type: "ReorderedEmbedByPointerStruct", Field: "Signed", Offset: bytesRead - read, Expected: maj, Found: maj, Err: cbg.ErrOverflow}
 			}
 			extraI = -1 - extraI
 		default:
-			return bytesRead, fmt.Errorf("wrong type for int64 field: %d", maj)
+			return bytesRead, &cbg.DecodeError{Type: "ReorderedEmbedByPointerStruct", Field: "Signed", Offset: bytesRead - read, Expected: cbg.MajUnsignedInt, Found: maj, Err: cbg.ErrUnexpectedType}
 		}
 
 		t.Signed = int64(extraI)
@@ -582,7 +660,7 @@ func (t *ReorderedEmbedByPointerStruct) UnmarshalCBOR(r io.Reader) (int, error)
 	{
 		sval, read, err := cbg.ReadStringBuf(br, scratch)
 		if err != nil {
-			return bytesRead, err
+			return bytesRead, cbg.WrapDecodeError(err, "ReorderedEmbedByPointerStruct", "Foo", bytesRead)
 		}
 		bytesRead += read
 
@@ -592,15 +670,18 @@ func (t *ReorderedEmbedByPointerStruct) UnmarshalCBOR(r io.Reader) (int, error)
 
 	maj, extra, read, err = cbg.CborReadHeaderBuf(br, scratch)
 	if err != nil {
-		return bytesRead, err
+		return bytesRead, cbg.WrapDecodeError(err, "ReorderedEmbedByPointerStruct", "Binary", bytesRead)
 	}
 	bytesRead += read
 
 	if extra > cbg.ByteArrayMaxLen {
-		return bytesRead, fmt.Errorf("t.Binary: byte array too large (%d)", extra)
+		return bytesRead, &cbg.DecodeError{Type: "ReorderedEmbedByPointerStruct", Field: "Binary", Offset: bytesRead - read, Expected: cbg.MajByteString, Found: maj, Err: cbg.ErrMaxLength}
 	}
 	if maj != cbg.MajByteString {
-		return bytesRead, fmt.Errorf("expected byte array")
+		return bytesRead, &cbg.DecodeError{Type: "ReorderedEmbedByPointerStruct", Field: "Binary", Offset: bytesRead - read, Expected: cbg.MajByteString, Found: maj, Err: cbg.ErrUnexpectedType}
+	}
+	if err := limits.AddBytes(extra); err != nil {
+		return bytesRead, &cbg.DecodeError{Type: "ReorderedEmbedByPointerStruct", Field: "Binary", Offset: bytesRead - read, Expected: cbg.MajByteString, Found: maj, Err: err}
 	}
 
 	if extra > 0 {
@@ -608,7 +689,7 @@ func (t *ReorderedEmbedByPointerStruct) UnmarshalCBOR(r io.Reader) (int, error)
 	}
 
 	if read, err := io.ReadFull(br, t.Binary[:]); err != nil {
-		return bytesRead, err
+		return bytesRead, cbg.WrapDecodeError(err, "ReorderedEmbedByPointerStruct", "Binary", bytesRead)
 	} else {
 		bytesRead += read
 	}
@@ -617,7 +698,7 @@ func (t *ReorderedEmbedByPointerStruct) UnmarshalCBOR(r io.Reader) (int, error)
 	{
 		sval, read, err := cbg.ReadStringBuf(br, scratch)
 		if err != nil {
-			return bytesRead, err
+			return bytesRead, cbg.WrapDecodeError(err, "ReorderedEmbedByPointerStruct", "NString", bytesRead)
 		}
 		bytesRead += read
 
@@ -629,14 +710,38 @@ func (t *ReorderedEmbedByPointerStruct) UnmarshalCBOR(r io.Reader) (int, error)
 
 		maj, extra, read, err = cbg.CborReadHeaderBuf(br, scratch)
 		if err != nil {
-			return bytesRead, err
+			return bytesRead, cbg.WrapDecodeError(err, "ReorderedEmbedByPointerStruct", "Value", bytesRead)
 		}
 		bytesRead += read
 		if maj != cbg.MajUnsignedInt {
-			return bytesRead, fmt.Errorf("wrong type for uint64 field")
+			return bytesRead, &cbg.DecodeError{Type: "ReorderedEmbedByPointerStruct", Field: "Value", Offset: bytesRead - read, Expected: cbg.MajUnsignedInt, Found: maj, Err: cbg.ErrUnexpectedType}
 		}
 		t.Value = uint64(extra)
 
 	}
 	return bytesRead, nil
 }
+
+func (t *ReorderedEmbedByPointerStruct) MarshalDAGJSON(w io.Writer) error {
+	return cbg.MarshalDAGJSON(w, t)
+}
+
+func (t *ReorderedEmbedByPointerStruct) UnmarshalDAGJSON(r io.Reader) error {
+	return cbg.UnmarshalDAGJSON(r, t)
+}
+
+func (t *ReorderedEmbedByPointerStruct) Links() []cid.Cid {
+	var links []cid.Cid
+	_ = t.ForEachLink(func(_ string, c cid.Cid) error {
+		links = append(links, c)
+		return nil
+	})
+	return links
+}
+
+func (t *ReorderedEmbedByPointerStruct) ForEachLink(cb func(path string, c cid.Cid) error) error {
+	if t == nil {
+		return nil
+	}
+	return nil
+}
@@ -0,0 +1,785 @@
+// Code generated by github.com/daotl/cbor-gen. DO NOT EDIT.
+
+package flatten_tuple
+
+import (
+	"bytes"
+	"math/rand"
+	"reflect"
+	"testing"
+	"testing/quick"
+)
+
+var _ = rand.New
+var _ = reflect.TypeOf
+var _ = quick.Value
+
+func FuzzUnmarshalEmbeddingStructOne(f *testing.F) {
+	r := rand.New(rand.NewSource(56887))
+	for i := 0; i < 10; i++ {
+		val, ok := quick.Value(reflect.TypeOf(EmbeddingStructOne{}), r)
+		if !ok {
+			f.Fatal("failed to generate test value")
+		}
+		buf := new(bytes.Buffer)
+		if _, err := val.Addr().Interface().(*EmbeddingStructOne).MarshalCBOR(buf); err != nil {
+			f.Fatal(err)
+		}
+		f.Add(buf.Bytes())
+	}
+
+	f.Fuzz(func(t *testing.T, data []byte) {
+		var obj EmbeddingStructOne
+		read, err := obj.UnmarshalCBOR(bytes.NewReader(data))
+		if err != nil {
+			return
+		}
+		if read > len(data) {
+			t.Fatalf("read %d bytes out of %d", read, len(data))
+		}
+
+		// Whatever unmarshals must marshal, and roundtrip from there on.
+		buf := new(bytes.Buffer)
+		if _, err := obj.MarshalCBOR(buf); err != nil {
+			t.Fatal("failed to marshal unmarshaled object: ", err)
+		}
+		enc := buf.Bytes()
+
+		var nobj EmbeddingStructOne
+		if _, err := nobj.UnmarshalCBOR(bytes.NewReader(enc)); err != nil {
+			t.Fatalf("failed to unmarshal %x: %s", enc, err)
+		}
+		nbuf := new(bytes.Buffer)
+		if _, err := nobj.MarshalCBOR(nbuf); err != nil {
+			t.Fatal("failed to remarshal object: ", err)
+		}
+		if !bytes.Equal(nbuf.Bytes(), enc) {
+			t.Fatalf("objects encodings different: %x != %x", nbuf.Bytes(), enc)
+		}
+	})
+}
+
+func TestRoundtripEmbeddingStructOne(t *testing.T) {
+	r := rand.New(rand.NewSource(56887))
+	for i := 0; i < 100; i++ {
+		val, ok := quick.Value(reflect.TypeOf(EmbeddingStructOne{}), r)
+		if !ok {
+			t.Fatal("failed to generate test value")
+		}
+		obj := val.Addr().Interface().(*EmbeddingStructOne)
+
+		buf := new(bytes.Buffer)
+		if n, err := obj.MarshalCBOR(buf); err != nil {
+			t.Fatal(err)
+		} else if n != buf.Len() {
+			t.Fatal("returned length does not match the byte length")
+		}
+		enc := buf.Bytes()
+
+		var nobj EmbeddingStructOne
+		if read, err := nobj.UnmarshalCBOR(bytes.NewReader(enc)); err != nil {
+			t.Fatalf("failed to unmarshal %x: %s", enc, err)
+		} else if read != len(enc) {
+			t.Fatalf("wrong bytesRead when unmarshaling: should be %d, actual %d", len(enc), read)
+		}
+
+		nbuf := new(bytes.Buffer)
+		if _, err := nobj.MarshalCBOR(nbuf); err != nil {
+			t.Fatal("failed to remarshal object: ", err)
+		}
+		if !bytes.Equal(nbuf.Bytes(), enc) {
+			t.Fatalf("objects encodings different: %x != %x", nbuf.Bytes(), enc)
+		}
+
+		// Truncated encodings must fail to unmarshal.
+		for _, l := range []int{r.Intn(len(enc)), len(enc) - 1} {
+			var tobj EmbeddingStructOne
+			if _, err := tobj.UnmarshalCBOR(bytes.NewReader(enc[:l])); err == nil {
+				t.Fatalf("unmarshaled truncated encoding %x", enc[:l])
+			}
+		}
+	}
+}
+
+func FuzzUnmarshalEmbeddingStructTwo(f *testing.F) {
+	r := rand.New(rand.NewSource(56887))
+	for i := 0; i < 10; i++ {
+		val, ok := quick.Value(reflect.TypeOf(EmbeddingStructTwo{}), r)
+		if !ok {
+			f.Fatal("failed to generate test value")
+		}
+		buf := new(bytes.Buffer)
+		if _, err := val.Addr().Interface().(*EmbeddingStructTwo).MarshalCBOR(buf); err != nil {
+			f.Fatal(err)
+		}
+		f.Add(buf.Bytes())
+	}
+
+	f.Fuzz(func(t *testing.T, data []byte) {
+		var obj EmbeddingStructTwo
+		read, err := obj.UnmarshalCBOR(bytes.NewReader(data))
+		if err != nil {
+			return
+		}
+		if read > len(data) {
+			t.Fatalf("read %d bytes out of %d", read, len(data))
+		}
+
+		// Whatever unmarshals must marshal, and roundtrip from there on.
+		buf := new(bytes.Buffer)
+		if _, err := obj.MarshalCBOR(buf); err != nil {
+			t.Fatal("failed to marshal unmarshaled object: ", err)
+		}
+		enc := buf.Bytes()
+
+		var nobj EmbeddingStructTwo
+		if _, err := nobj.UnmarshalCBOR(bytes.NewReader(enc)); err != nil {
+			t.Fatalf("failed to unmarshal %x: %s", enc, err)
+		}
+		nbuf := new(bytes.Buffer)
+		if _, err := nobj.MarshalCBOR(nbuf); err != nil {
+			t.Fatal("failed to remarshal object: ", err)
+		}
+		if !bytes.Equal(nbuf.Bytes(), enc) {
+			t.Fatalf("objects encodings different: %x != %x", nbuf.Bytes(), enc)
+		}
+	})
+}
+
+func TestRoundtripEmbeddingStructTwo(t *testing.T) {
+	r := rand.New(rand.NewSource(56887))
+	for i := 0; i < 100; i++ {
+		val, ok := quick.Value(reflect.TypeOf(EmbeddingStructTwo{}), r)
+		if !ok {
+			t.Fatal("failed to generate test value")
+		}
+		obj := val.Addr().Interface().(*EmbeddingStructTwo)
+
+		buf := new(bytes.Buffer)
+		if n, err := obj.MarshalCBOR(buf); err != nil {
+			t.Fatal(err)
+		} else if n != buf.Len() {
+			t.Fatal("returned length does not match the byte length")
+		}
+		enc := buf.Bytes()
+
+		var nobj EmbeddingStructTwo
+		if read, err := nobj.UnmarshalCBOR(bytes.NewReader(enc)); err != nil {
+			t.Fatalf("failed to unmarshal %x: %s", enc, err)
+		} else if read != len(enc) {
+			t.Fatalf("wrong bytesRead when unmarshaling: should be %d, actual %d", len(enc), read)
+		}
+
+		nbuf := new(bytes.Buffer)
+		if _, err := nobj.MarshalCBOR(nbuf); err != nil {
+			t.Fatal("failed to remarshal object: ", err)
+		}
+		if !bytes.Equal(nbuf.Bytes(), enc) {
+			t.Fatalf("objects encodings different: %x != %x", nbuf.Bytes(), enc)
+		}
+
+		// Truncated encodings must fail to unmarshal.
+		for _, l := range []int{r.Intn(len(enc)), len(enc) - 1} {
+			var tobj EmbeddingStructTwo
+			if _, err := tobj.UnmarshalCBOR(bytes.NewReader(enc[:l])); err == nil {
+				t.Fatalf("unmarshaled truncated encoding %x", enc[:l])
+			}
+		}
+	}
+}
+
+func FuzzUnmarshalEmbeddingStructThree(f *testing.F) {
+	r := rand.New(rand.NewSource(56887))
+	for i := 0; i < 10; i++ {
+		val, ok := quick.Value(reflect.TypeOf(EmbeddingStructThree{}), r)
+		if !ok {
+			f.Fatal("failed to generate test value")
+		}
+		buf := new(bytes.Buffer)
+		if _, err := val.Addr().Interface().(*EmbeddingStructThree).MarshalCBOR(buf); err != nil {
+			f.Fatal(err)
+		}
+		f.Add(buf.Bytes())
+	}
+
+	f.Fuzz(func(t *testing.T, data []byte) {
+		var obj EmbeddingStructThree
+		read, err := obj.UnmarshalCBOR(bytes.NewReader(data))
+		if err != nil {
+			return
+		}
+		if read > len(data) {
+			t.Fatalf("read %d bytes out of %d", read, len(data))
+		}
+
+		// Whatever unmarshals must marshal, and roundtrip from there on.
+		buf := new(bytes.Buffer)
+		if _, err := obj.MarshalCBOR(buf); err != nil {
+			t.Fatal("failed to marshal unmarshaled object: ", err)
+		}
+		enc := buf.Bytes()
+
+		var nobj EmbeddingStructThree
+		if _, err := nobj.UnmarshalCBOR(bytes.NewReader(enc)); err != nil {
+			t.Fatalf("failed to unmarshal %x: %s", enc, err)
+		}
+		nbuf := new(bytes.Buffer)
+		if _, err := nobj.MarshalCBOR(nbuf); err != nil {
+			t.Fatal("failed to remarshal object: ", err)
+		}
+		if !bytes.Equal(nbuf.Bytes(), enc) {
+			t.Fatalf("objects encodings different: %x != %x", nbuf.Bytes(), enc)
+		}
+	})
+}
+
+func TestRoundtripEmbeddingStructThree(t *testing.T) {
+	r := rand.New(rand.NewSource(56887))
+	for i := 0; i < 100; i++ {
+		val, ok := quick.Value(reflect.TypeOf(EmbeddingStructThree{}), r)
+		if !ok {
+			t.Fatal("failed to generate test value")
+		}
+		obj := val.Addr().Interface().(*EmbeddingStructThree)
+
+		buf := new(bytes.Buffer)
+		if n, err := obj.MarshalCBOR(buf); err != nil {
+			t.Fatal(err)
+		} else if n != buf.Len() {
+			t.Fatal("returned length does not match the byte length")
+		}
+		enc := buf.Bytes()
+
+		var nobj EmbeddingStructThree
+		if read, err := nobj.UnmarshalCBOR(bytes.NewReader(enc)); err != nil {
+			t.Fatalf("failed to unmarshal %x: %s", enc, err)
+		} else if read != len(enc) {
+			t.Fatalf("wrong bytesRead when unmarshaling: should be %d, actual %d", len(enc), read)
+		}
+
+		nbuf := new(bytes.Buffer)
+		if _, err := nobj.MarshalCBOR(nbuf); err != nil {
+			t.Fatal("failed to remarshal object: ", err)
+		}
+		if !bytes.Equal(nbuf.Bytes(), enc) {
+			t.Fatalf("objects encodings different: %x != %x", nbuf.Bytes(), enc)
+		}
+
+		// Truncated encodings must fail to unmarshal.
+		for _, l := range []int{r.Intn(len(enc)), len(enc) - 1} {
+			var tobj EmbeddingStructThree
+			if _, err := tobj.UnmarshalCBOR(bytes.NewReader(enc[:l])); err == nil {
+				t.Fatalf("unmarshaled truncated encoding %x", enc[:l])
+			}
+		}
+	}
+}
+
+func FuzzUnmarshalFlatStruct(f *testing.F) {
+	r := rand.New(rand.NewSource(56887))
+	for i := 0; i < 10; i++ {
+		val, ok := quick.Value(reflect.TypeOf(FlatStruct{}), r)
+		if !ok {
+			f.Fatal("failed to generate test value")
+		}
+		buf := new(bytes.Buffer)
+		if _, err := val.Addr().Interface().(*FlatStruct).MarshalCBOR(buf); err != nil {
+			f.Fatal(err)
+		}
+		f.Add(buf.Bytes())
+	}
+
+	f.Fuzz(func(t *testing.T, data []byte) {
+		var obj FlatStruct
+		read, err := obj.UnmarshalCBOR(bytes.NewReader(data))
+		if err != nil {
+			return
+		}
+		if read > len(data) {
+			t.Fatalf("read %d bytes out of %d", read, len(data))
+		}
+
+		// Whatever unmarshals must marshal, and roundtrip from there on.
+		buf := new(bytes.Buffer)
+		if _, err := obj.MarshalCBOR(buf); err != nil {
+			t.Fatal("failed to marshal unmarshaled object: ", err)
+		}
+		enc := buf.Bytes()
+
+		var nobj FlatStruct
+		if _, err := nobj.UnmarshalCBOR(bytes.NewReader(enc)); err != nil {
+			t.Fatalf("failed to unmarshal %x: %s", enc, err)
+		}
+		nbuf := new(bytes.Buffer)
+		if _, err := nobj.MarshalCBOR(nbuf); err != nil {
+			t.Fatal("failed to remarshal object: ", err)
+		}
+		if !bytes.Equal(nbuf.Bytes(), enc) {
+			t.Fatalf("objects encodings different: %x != %x", nbuf.Bytes(), enc)
+		}
+	})
+}
+
+func TestRoundtripFlatStruct(t *testing.T) {
+	r := rand.New(rand.NewSource(56887))
+	for i := 0; i < 100; i++ {
+		val, ok := quick.Value(reflect.TypeOf(FlatStruct{}), r)
+		if !ok {
+			t.Fatal("failed to generate test value")
+		}
+		obj := val.Addr().Interface().(*FlatStruct)
+
+		buf := new(bytes.Buffer)
+		if n, err := obj.MarshalCBOR(buf); err != nil {
+			t.Fatal(err)
+		} else if n != buf.Len() {
+			t.Fatal("returned length does not match the byte length")
+		}
+		enc := buf.Bytes()
+
+		var nobj FlatStruct
+		if read, err := nobj.UnmarshalCBOR(bytes.NewReader(enc)); err != nil {
+			t.Fatalf("failed to unmarshal %x: %s", enc, err)
+		} else if read != len(enc) {
+			t.Fatalf("wrong bytesRead when unmarshaling: should be %d, actual %d", len(enc), read)
+		}
+
+		nbuf := new(bytes.Buffer)
+		if _, err := nobj.MarshalCBOR(nbuf); err != nil {
+			t.Fatal("failed to remarshal object: ", err)
+		}
+		if !bytes.Equal(nbuf.Bytes(), enc) {
+			t.Fatalf("objects encodings different: %x != %x", nbuf.Bytes(), enc)
+		}
+
+		// Truncated encodings must fail to unmarshal.
+		for _, l := range []int{r.Intn(len(enc)), len(enc) - 1} {
+			var tobj FlatStruct
+			if _, err := tobj.UnmarshalCBOR(bytes.NewReader(enc[:l])); err == nil {
+				t.Fatalf("unmarshaled truncated encoding %x", enc[:l])
+			}
+		}
+	}
+}
+
+func FuzzUnmarshalEmbeddedStruct(f *testing.F) {
+	r := rand.New(rand.NewSource(56887))
+	for i := 0; i < 10; i++ {
+		val, ok := quick.Value(reflect.TypeOf(EmbeddedStruct{}), r)
+		if !ok {
+			f.Fatal("failed to generate test value")
+		}
+		buf := new(bytes.Buffer)
+		if _, err := val.Addr().Interface().(*EmbeddedStruct).MarshalCBOR(buf); err != nil {
+			f.Fatal(err)
+		}
+		f.Add(buf.Bytes())
+	}
+
+	f.Fuzz(func(t *testing.T, data []byte) {
+		var obj EmbeddedStruct
+		read, err := obj.UnmarshalCBOR(bytes.NewReader(data))
+		if err != nil {
+			return
+		}
+		if read > len(data) {
+			t.Fatalf("read %d bytes out of %d", read, len(data))
+		}
+
+		// Whatever unmarshals must marshal, and roundtrip from there on.
+		buf := new(bytes.Buffer)
+		if _, err := obj.MarshalCBOR(buf); err != nil {
+			t.Fatal("failed to marshal unmarshaled object: ", err)
+		}
+		enc := buf.Bytes()
+
+		var nobj EmbeddedStruct
+		if _, err := nobj.UnmarshalCBOR(bytes.NewReader(enc)); err != nil {
+			t.Fatalf("failed to unmarshal %x: %s", enc, err)
+		}
+		nbuf := new(bytes.Buffer)
+		if _, err := nobj.MarshalCBOR(nbuf); err != nil {
+			t.Fatal("failed to remarshal object: ", err)
+		}
+		if !bytes.Equal(nbuf.Bytes(), enc) {
+			t.Fatalf("objects encodings different: %x != %x", nbuf.Bytes(), enc)
+		}
+	})
+}
+
+func TestRoundtripEmbeddedStruct(t *testing.T) {
+	r := rand.New(rand.NewSource(56887))
+	for i := 0; i < 100; i++ {
+		val, ok := quick.Value(reflect.TypeOf(EmbeddedStruct{}), r)
+		if !ok {
+			t.Fatal("failed to generate test value")
+		}
+		obj := val.Addr().Interface().(*EmbeddedStruct)
+
+		buf := new(bytes.Buffer)
+		if n, err := obj.MarshalCBOR(buf); err != nil {
+			t.Fatal(err)
+		} else if n != buf.Len() {
+			t.Fatal("returned length does not match the byte length")
+		}
+		enc := buf.Bytes()
+
+		var nobj EmbeddedStruct
+		if read, err := nobj.UnmarshalCBOR(bytes.NewReader(enc)); err != nil {
+			t.Fatalf("failed to unmarshal %x: %s", enc, err)
+		} else if read != len(enc) {
+			t.Fatalf("wrong bytesRead when unmarshaling: should be %d, actual %d", len(enc), read)
+		}
+
+		nbuf := new(bytes.Buffer)
+		if _, err := nobj.MarshalCBOR(nbuf); err != nil {
+			t.Fatal("failed to remarshal object: ", err)
+		}
+		if !bytes.Equal(nbuf.Bytes(), enc) {
+			t.Fatalf("objects encodings different: %x != %x", nbuf.Bytes(), enc)
+		}
+
+		// Truncated encodings must fail to unmarshal.
+		for _, l := range []int{r.Intn(len(enc)), len(enc) - 1} {
+			var tobj EmbeddedStruct
+			if _, err := tobj.UnmarshalCBOR(bytes.NewReader(enc[:l])); err == nil {
+				t.Fatalf("unmarshaled truncated encoding %x", enc[:l])
+			}
+		}
+	}
+}
+
+func FuzzUnmarshalEmbedByValueStruct(f *testing.F) {
+	r := rand.New(rand.NewSource(56887))
+	for i := 0; i < 10; i++ {
+		val, ok := quick.Value(reflect.TypeOf(EmbedByValueStruct{}), r)
+		if !ok {
+			f.Fatal("failed to generate test value")
+		}
+		buf := new(bytes.Buffer)
+		if _, err := val.Addr().Interface().(*EmbedByValueStruct).MarshalCBOR(buf); err != nil {
+			f.Fatal(err)
+		}
+		f.Add(buf.Bytes())
+	}
+
+	f.Fuzz(func(t *testing.T, data []byte) {
+		var obj EmbedByValueStruct
+		read, err := obj.UnmarshalCBOR(bytes.NewReader(data))
+		if err != nil {
+			return
+		}
+		if read > len(data) {
+			t.Fatalf("read %d bytes out of %d", read, len(data))
+		}
+
+		// Whatever unmarshals must marshal, and roundtrip from there on.
+		buf := new(bytes.Buffer)
+		if _, err := obj.MarshalCBOR(buf); err != nil {
+			t.Fatal("failed to marshal unmarshaled object: ", err)
+		}
+		enc := buf.Bytes()
+
+		var nobj EmbedByValueStruct
+		if _, err := nobj.UnmarshalCBOR(bytes.NewReader(enc)); err != nil {
+			t.Fatalf("failed to unmarshal %x: %s", enc, err)
+		}
+		nbuf := new(bytes.Buffer)
+		if _, err := nobj.MarshalCBOR(nbuf); err != nil {
+			t.Fatal("failed to remarshal object: ", err)
+		}
+		if !bytes.Equal(nbuf.Bytes(), enc) {
+			t.Fatalf("objects encodings different: %x != %x", nbuf.Bytes(), enc)
+		}
+	})
+}
+
+func TestRoundtripEmbedByValueStruct(t *testing.T) {
+	r := rand.New(rand.NewSource(56887))
+	for i := 0; i < 100; i++ {
+		val, ok := quick.Value(reflect.TypeOf(EmbedByValueStruct{}), r)
+		if !ok {
+			t.Fatal("failed to generate test value")
+		}
+		obj := val.Addr().Interface().(*EmbedByValueStruct)
+
+		buf := new(bytes.Buffer)
+		if n, err := obj.MarshalCBOR(buf); err != nil {
+			t.Fatal(err)
+		} else if n != buf.Len() {
+			t.Fatal("returned length does not match the byte length")
+		}
+		enc := buf.Bytes()
+
+		var nobj EmbedByValueStruct
+		if read, err := nobj.UnmarshalCBOR(bytes.NewReader(enc)); err != nil {
+			t.Fatalf("failed to unmarshal %x: %s", enc, err)
+		} else if read != len(enc) {
+			t.Fatalf("wrong bytesRead when unmarshaling: should be %d, actual %d", len(enc), read)
+		}
+
+		nbuf := new(bytes.Buffer)
+		if _, err := nobj.MarshalCBOR(nbuf); err != nil {
+			t.Fatal("failed to remarshal object: ", err)
+		}
+		if !bytes.Equal(nbuf.Bytes(), enc) {
+			t.Fatalf("objects encodings different: %x != %x", nbuf.Bytes(), enc)
+		}
+
+		// Truncated encodings must fail to unmarshal.
+		for _, l := range []int{r.Intn(len(enc)), len(enc) - 1} {
+			var tobj EmbedByValueStruct
+			if _, err := tobj.UnmarshalCBOR(bytes.NewReader(enc[:l])); err == nil {
+				t.Fatalf("unmarshaled truncated encoding %x", enc[:l])
+			}
+		}
+	}
+}
+
+func FuzzUnmarshalEmbedByPointerStruct(f *testing.F) {
+	r := rand.New(rand.NewSource(56887))
+	for i := 0; i < 10; i++ {
+		val, ok := quick.Value(reflect.TypeOf(EmbedByPointerStruct{}), r)
+		if !ok {
+			f.Fatal("failed to generate test value")
+		}
+		buf := new(bytes.Buffer)
+		if _, err := val.Addr().Interface().(*EmbedByPointerStruct).MarshalCBOR(buf); err != nil {
+			f.Fatal(err)
+		}
+		f.Add(buf.Bytes())
+	}
+
+	f.Fuzz(func(t *testing.T, data []byte) {
+		var obj EmbedByPointerStruct
+		read, err := obj.UnmarshalCBOR(bytes.NewReader(data))
+		if err != nil {
+			return
+		}
+		if read > len(data) {
+			t.Fatalf("read %d bytes out of %d", read, len(data))
+		}
+
+		// Whatever unmarshals must marshal, and roundtrip from there on.
+		buf := new(bytes.Buffer)
+		if _, err := obj.MarshalCBOR(buf); err != nil {
+			t.Fatal("failed to marshal unmarshaled object: ", err)
+		}
+		enc := buf.Bytes()
+
+		var nobj EmbedByPointerStruct
+		if _, err := nobj.UnmarshalCBOR(bytes.NewReader(enc)); err != nil {
+			t.Fatalf("failed to unmarshal %x: %s", enc, err)
+		}
+		nbuf := new(bytes.Buffer)
+		if _, err := nobj.MarshalCBOR(nbuf); err != nil {
+			t.Fatal("failed to remarshal object: ", err)
+		}
+		if !bytes.Equal(nbuf.Bytes(), enc) {
+			t.Fatalf("objects encodings different: %x != %x", nbuf.Bytes(), enc)
+		}
+	})
+}
+
+func TestRoundtripEmbedByPointerStruct(t *testing.T) {
+	r := rand.New(rand.NewSource(56887))
+	for i := 0; i < 100; i++ {
+		val, ok := quick.Value(reflect.TypeOf(EmbedByPointerStruct{}), r)
+		if !ok {
+			t.Fatal("failed to generate test value")
+		}
+		obj := val.Addr().Interface().(*EmbedByPointerStruct)
+
+		buf := new(bytes.Buffer)
+		if n, err := obj.MarshalCBOR(buf); err != nil {
+			t.Fatal(err)
+		} else if n != buf.Len() {
+			t.Fatal("returned length does not match the byte length")
+		}
+		enc := buf.Bytes()
+
+		var nobj EmbedByPointerStruct
+		if read, err := nobj.UnmarshalCBOR(bytes.NewReader(enc)); err != nil {
+			t.Fatalf("failed to unmarshal %x: %s", enc, err)
+		} else if read != len(enc) {
+			t.Fatalf("wrong bytesRead when unmarshaling: should be %d, actual %d", len(enc), read)
+		}
+
+		nbuf := new(bytes.Buffer)
+		if _, err := nobj.MarshalCBOR(nbuf); err != nil {
+			t.Fatal("failed to remarshal object: ", err)
+		}
+		if !bytes.Equal(nbuf.Bytes(), enc) {
+			t.Fatalf("objects encodings different: %x != %x", nbuf.Bytes(), enc)
+		}
+
+		// Truncated encodings must fail to unmarshal.
+		for _, l := range []int{r.Intn(len(enc)), len(enc) - 1} {
+			var tobj EmbedByPointerStruct
+			if _, err := tobj.UnmarshalCBOR(bytes.NewReader(enc[:l])); err == nil {
+				t.Fatalf("unmarshaled truncated encoding %x", enc[:l])
+			}
+		}
+	}
+}
+
+func FuzzUnmarshalIndexedFlatStruct(f *testing.F) {
+	r := rand.New(rand.NewSource(56887))
+	for i := 0; i < 10; i++ {
+		val, ok := quick.Value(reflect.TypeOf(IndexedFlatStruct{}), r)
+		if !ok {
+			f.Fatal("failed to generate test value")
+		}
+		buf := new(bytes.Buffer)
+		if _, err := val.Addr().Interface().(*IndexedFlatStruct).MarshalCBOR(buf); err != nil {
+			f.Fatal(err)
+		}
+		f.Add(buf.Bytes())
+	}
+
+	f.Fuzz(func(t *testing.T, data []byte) {
+		var obj IndexedFlatStruct
+		read, err := obj.UnmarshalCBOR(bytes.NewReader(data))
+		if err != nil {
+			return
+		}
+		if read > len(data) {
+			t.Fatalf("read %d bytes out of %d", read, len(data))
+		}
+
+		// Whatever unmarshals must marshal, and roundtrip from there on.
+		buf := new(bytes.Buffer)
+		if _, err := obj.MarshalCBOR(buf); err != nil {
+			t.Fatal("failed to marshal unmarshaled object: ", err)
+		}
+		enc := buf.Bytes()
+
+		var nobj IndexedFlatStruct
+		if _, err := nobj.UnmarshalCBOR(bytes.NewReader(enc)); err != nil {
+			t.Fatalf("failed to unmarshal %x: %s", enc, err)
+		}
+		nbuf := new(bytes.Buffer)
+		if _, err := nobj.MarshalCBOR(nbuf); err != nil {
+			t.Fatal("failed to remarshal object: ", err)
+		}
+		if !bytes.Equal(nbuf.Bytes(), enc) {
+			t.Fatalf("objects encodings different: %x != %x", nbuf.Bytes(), enc)
+		}
+	})
+}
+
+func TestRoundtripIndexedFlatStruct(t *testing.T) {
+	r := rand.New(rand.NewSource(56887))
+	for i := 0; i < 100; i++ {
+		val, ok := quick.Value(reflect.TypeOf(IndexedFlatStruct{}), r)
+		if !ok {
+			t.Fatal("failed to generate test value")
+		}
+		obj := val.Addr().Interface().(*IndexedFlatStruct)
+
+		buf := new(bytes.Buffer)
+		if n, err := obj.MarshalCBOR(buf); err != nil {
+			t.Fatal(err)
+		} else if n != buf.Len() {
+			t.Fatal("returned length does not match the byte length")
+		}
+		enc := buf.Bytes()
+
+		var nobj IndexedFlatStruct
+		if read, err := nobj.UnmarshalCBOR(bytes.NewReader(enc)); err != nil {
+			t.Fatalf("failed to unmarshal %x: %s", enc, err)
+		} else if read != len(enc) {
+			t.Fatalf("wrong bytesRead when unmarshaling: should be %d, actual %d", len(enc), read)
+		}
+
+		nbuf := new(bytes.Buffer)
+		if _, err := nobj.MarshalCBOR(nbuf); err != nil {
+			t.Fatal("failed to remarshal object: ", err)
+		}
+		if !bytes.Equal(nbuf.Bytes(), enc) {
+			t.Fatalf("objects encodings different: %x != %x", nbuf.Bytes(), enc)
+		}
+
+		// Truncated encodings must fail to unmarshal.
+		for _, l := range []int{r.Intn(len(enc)), len(enc) - 1} {
+			var tobj IndexedFlatStruct
+			if _, err := tobj.UnmarshalCBOR(bytes.NewReader(enc[:l])); err == nil {
+				t.Fatalf("unmarshaled truncated encoding %x", enc[:l])
+			}
+		}
+	}
+}
+
+func FuzzUnmarshalEmbeddedLinks(f *testing.F) {
+	buf := new(bytes.Buffer)
+	if _, err := new(EmbeddedLinks).MarshalCBOR(buf); err == nil {
+		f.Add(buf.Bytes())
+	}
+
+	f.Fuzz(func(t *testing.T, data []byte) {
+		var obj EmbeddedLinks
+		read, err := obj.UnmarshalCBOR(bytes.NewReader(data))
+		if err != nil {
+			return
+		}
+		if read > len(data) {
+			t.Fatalf("read %d bytes out of %d", read, len(data))
+		}
+
+		// Whatever unmarshals must marshal, and roundtrip from there on.
+		buf := new(bytes.Buffer)
+		if _, err := obj.MarshalCBOR(buf); err != nil {
+			t.Fatal("failed to marshal unmarshaled object: ", err)
+		}
+		enc := buf.Bytes()
+
+		var nobj EmbeddedLinks
+		if _, err := nobj.UnmarshalCBOR(bytes.NewReader(enc)); err != nil {
+			t.Fatalf("failed to unmarshal %x: %s", enc, err)
+		}
+		nbuf := new(bytes.Buffer)
+		if _, err := nobj.MarshalCBOR(nbuf); err != nil {
+			t.Fatal("failed to remarshal object: ", err)
+		}
+		if !bytes.Equal(nbuf.Bytes(), enc) {
+			t.Fatalf("objects encodings different: %x != %x", nbuf.Bytes(), enc)
+		}
+	})
+}
+
+func FuzzUnmarshalEmbedLinksByPointer(f *testing.F) {
+	buf := new(bytes.Buffer)
+	if _, err := new(EmbedLinksByPointer).MarshalCBOR(buf); err == nil {
+		f.Add(buf.Bytes())
+	}
+
+	f.Fuzz(func(t *testing.T, data []byte) {
+		var obj EmbedLinksByPointer
+		read, err := obj.UnmarshalCBOR(bytes.NewReader(data))
+		if err != nil {
+			return
+		}
+		if read > len(data) {
+			t.Fatalf("read %d bytes out of %d", read, len(data))
+		}
+
+		// Whatever unmarshals must marshal, and roundtrip from there on.
+		buf := new(bytes.Buffer)
+		if _, err := obj.MarshalCBOR(buf); err != nil {
+			t.Fatal("failed to marshal unmarshaled object: ", err)
+		}
+		enc := buf.Bytes()
+
+		var nobj EmbedLinksByPointer
+		if _, err := nobj.UnmarshalCBOR(bytes.NewReader(enc)); err != nil {
+			t.Fatalf("failed to unmarshal %x: %s", enc, err)
+		}
+		nbuf := new(bytes.Buffer)
+		if _, err := nobj.MarshalCBOR(nbuf); err != nil {
+			t.Fatal("failed to remarshal object: ", err)
+		}
+		if !bytes.Equal(nbuf.Bytes(), enc) {
+			t.Fatalf("objects encodings different: %x != %x", nbuf.Bytes(), enc)
+		}
+	})
+}
@@ -1,6 +1,8 @@
 package flatten_tuple
 
 import (
+	"github.com/ipfs/go-cid"
+
 	"github.com/daotl/cbor-gen/testing"
 )
 
@@ -87,6 +89,15 @@ type ReorderedFlatStruct struct {
 	NString NamedString
 }
 
+// IndexedFlatStruct is encoded like ReorderedFlatStruct, without FieldOrder.
+type IndexedFlatStruct struct {
+	Foo     string      `cborgen:",index=1"`
+	Value   uint64      `cborgen:",index=4"`
+	Binary  []byte      `cborgen:",index=2"`
+	Signed  int64       `cborgen:",index=0"`
+	NString NamedString `cborgen:",index=3"`
+}
+
 type ReorderedEmbedByValueStruct struct {
 	EmbeddedStruct
 	Value   uint64
@@ -102,3 +113,13 @@ type ReorderedEmbedByPointerStruct struct {
 	Signed  int64
 	NString NamedString
 }
+
+type EmbeddedLinks struct {
+	Link cid.Cid
+	Cids []cid.Cid
+}
+
+type EmbedLinksByPointer struct {
+	Value uint64
+	*EmbeddedLinks
+}
@@ -0,0 +1,172 @@
+// Code generated by github.com/daotl/cbor-gen. DO NOT EDIT.
+
+package ipldschema
+
+import (
+	"fmt"
+	"io"
+	"math"
+	"sort"
+
+	cbg "github.com/daotl/cbor-gen"
+	cid "github.com/ipfs/go-cid"
+	xerrors "golang.org/x/xerrors"
+)
+
+var _ = xerrors.Errorf
+var _ = cid.Undef
+var _ = math.E
+var _ = sort.Sort
+
+var lengthBufPoint = []byte{130}
+
+func (t *Point) MarshalCBOR(w io.Writer) (n int, err error) {
+	if t == nil {
+		return w.Write(cbg.CborNull)
+	}
+	if n_, err := w.Write(lengthBufPoint); err != nil {
+		return n_, err
+	} else {
+		n += n_
+	}
+
+	scratch := make([]byte, 9)
+
+	// t.X (int64) (int64)
+	if t.X >= 0 {
+		if n_, err := cbg.WriteMajorTypeHeaderBuf(scratch, w, cbg.MajUnsignedInt, uint64(t.X)); err != nil {
+			return n + n_, err
+		} else {
+			n += n_
+		}
+	} else {
+		if n_, err := cbg.WriteMajorTypeHeaderBuf(scratch, w, cbg.MajNegativeInt, uint64(-t.X-1)); err != nil {
+			return n + n_, err
+		} else {
+			n += n_
+		}
+	}
+
+	// t.Y (int64) (int64)
+	if t.Y >= 0 {
+		if n_, err := cbg.WriteMajorTypeHeaderBuf(scratch, w, cbg.MajUnsignedInt, uint64(t.Y)); err != nil {
+			return n + n_, err
+		} else {
+			n += n_
+		}
+	} else {
+		if n_, err := cbg.WriteMajorTypeHeaderBuf(scratch, w, cbg.MajNegativeInt, uint64(-t.Y-1)); err != nil {
+			return n + n_, err
+		} else {
+			n += n_
+		}
+	}
+	return n, nil
+}
+
+func (t *Point) UnmarshalCBOR(r io.Reader) (int, error) {
+	bytesRead := 0
+	*t = Point{}
+
+	br := cbg.GetPeeker(r)
+	scratch := make([]byte, 8)
+	limits := cbg.GetDecodeLimits(br)
+	if err := limits.Enter(); err != nil {
+		return bytesRead, &cbg.DecodeError{Type: "Point", Err: err}
+	}
+	defer limits.Leave()
+
+	maj, extra, read, err := cbg.CborReadHeaderBuf(br, scratch)
+	if err != nil {
+		return bytesRead, err
+	}
+	bytesRead += read
+	if maj != cbg.MajArray {
+		return bytesRead, &cbg.DecodeError{Type: "Point", Expected: cbg.MajArray, Found: maj, Err: cbg.ErrUnexpectedType}
+	}
+
+	if extra != 2 {
+		return bytesRead, &cbg.DecodeError{Type: "Point", Expected: cbg.MajArray, Found: maj, Err: fmt.Errorf("cbor input had wrong number of fields (%d)", extra)}
+	}
+	if err := limits.AddItems(extra); err != nil {
+		return bytesRead, &cbg.DecodeError{Type: "Point", Expected: cbg.MajArray, Found: maj, Err: err}
+	}
+
+	// t.X (int64) (int64)
+	{
+		maj, extra, read, err := cbg.CborReadHeaderBuf(br, scratch)
+		var extraI int64
+		if err != nil {
+			return bytesRead, cbg.WrapDecodeError(err, "Point", "X", bytesRead)
+		}
+		bytesRead += read
+		switch maj {
+		case cbg.MajUnsignedInt:
+			extraI = int64(extra)
+			if extraI < 0 {
+				return bytesRead, &cbg.DecodeError{Type: "Point", Field: "X", Offset: bytesRead - read, Expected: maj, Found: maj, Err: cbg.ErrOverflow}
+			}
+		case cbg.MajNegativeInt:
+			extraI = int64(extra)
+			if extraI < 0 {
+				return bytesRead, &cbg.DecodeError{Type: "Point", Field: "X", Offset: bytesRead - read, Expected: maj, Found: maj, Err: cbg.ErrOverflow}
+			}
+			extraI = -1 - extraI
+		default:
+			return bytesRead, &cbg.DecodeError{Type: "Point", Field: "X", Offset: bytesRead - read, Expected: cbg.MajUnsignedInt, Found: maj, Err: cbg.ErrUnexpectedType}
+		}
+
+		t.X = int64(extraI)
+	}
+	// t.Y (int64) (int64)
+	{
+		maj, extra, read, err := cbg.CborReadHeaderBuf(br, scratch)
+		var extraI int64
+		if err != nil {
+			return bytesRead, cbg.WrapDecodeError(err, "Point", "Y", bytesRead)
+		}
+		bytesRead += read
+		switch maj {
+		case cbg.MajUnsignedInt:
+			extraI = int64(extra)
+			if extraI < 0 {
+				return bytesRead, &cbg.DecodeError{Type: "Point", Field: "Y", Offset: bytesRead - read, Expected: maj, Found: maj, Err: cbg.ErrOverflow}
+			}
+		case cbg.MajNegativeInt:
+			extraI = int64(extra)
+			if extraI < 0 {
+				return bytesRead, &cbg.DecodeError{Type: "Point", Field: "Y", Offset: bytesRead - read, Expected: maj, Found: maj, Err: cbg.ErrOverflow}
+			}
+			extraI = -1 - extraI
+		default:
+			return bytesRead, &cbg.DecodeError{Type: "Point", Field: "Y", Offset: bytesRead - read, Expected: cbg.MajUnsignedInt, Found: maj, Err: cbg.ErrUnexpectedType}
+		}
+
+		t.Y = int64(extraI)
+	}
+	return bytesRead, nil
+}
+
+func (t *Point) MarshalDAGJSON(w io.Writer) error {
+	return cbg.MarshalDAGJSON(w, t)
+}
+
+func (t *Point) UnmarshalDAGJSON(r io.Reader) error {
+	return cbg.UnmarshalDAGJSON(r, t)
+}
+
+func (t *Point) Links() []cid.Cid {
+	if t == nil {
+		return nil
+	}
+	var links []cid.Cid
+
+	return links
+}
+
+func (t *Point) ForEachLink(cb func(path string, c cid.Cid) error) error {
+	if t == nil {
+		return nil
+	}
+	return nil
+}
@@ -0,0 +1,864 @@
+// Code generated by github.com/daotl/cbor-gen. DO NOT EDIT.
+
+package ipldschema
+
+import (
+	"fmt"
+	"io"
+	"math"
+	"sort"
+
+	cbg "github.com/daotl/cbor-gen"
+	cid "github.com/ipfs/go-cid"
+	xerrors "golang.org/x/xerrors"
+)
+
+var _ = xerrors.Errorf
+var _ = cid.Undef
+var _ = math.E
+var _ = sort.Sort
+
+func (t *Label) MarshalCBOR(w io.Writer) (n int, err error) {
+	if t == nil {
+		return w.Write(cbg.CborNull)
+	}
+	if n_, err := w.Write([]byte{162}); err != nil {
+		return n + n_, err
+	} else {
+		n += n_
+	}
+
+	scratch := make([]byte, 9)
+
+	// t.Text (string) (string)
+	if len("t") > cbg.MaxLength {
+		return n, xerrors.Errorf("Value in field \"t\" was too long")
+	}
+
+	if n_, err := cbg.WriteMajorTypeHeaderBuf(scratch, w, cbg.MajTextString, uint64(len("t"))); err != nil {
+		return n + n_, err
+	} else {
+		n += n_
+	}
+	if n_, err := io.WriteString(w, string("t")); err != nil {
+		return n + n_, err
+	} else {
+		n += n_
+	}
+
+	if len(t.Text) > cbg.MaxLength {
+		return n, xerrors.Errorf("Value in field t.Text was too long")
+	}
+
+	if n_, err := cbg.WriteMajorTypeHeaderBuf(scratch, w, cbg.MajTextString, uint64(len(t.Text))); err != nil {
+		return n + n_, err
+	} else {
+		n += n_
+	}
+	if n_, err := io.WriteString(w, string(t.Text)); err != nil {
+		return n + n_, err
+	} else {
+		n += n_
+	}
+
+	// t.Color (string) (string)
+	if len("color") > cbg.MaxLength {
+		return n, xerrors.Errorf("Value in field \"color\" was too long")
+	}
+
+	if n_, err := cbg.WriteMajorTypeHeaderBuf(scratch, w, cbg.MajTextString, uint64(len("color"))); err != nil {
+		return n + n_, err
+	} else {
+		n += n_
+	}
+	if n_, err := io.WriteString(w, string("color")); err != nil {
+		return n + n_, err
+	} else {
+		n += n_
+	}
+
+	if len(t.Color) > cbg.MaxLength {
+		return n, xerrors.Errorf("Value in field t.Color was too long")
+	}
+
+	if n_, err := cbg.WriteMajorTypeHeaderBuf(scratch, w, cbg.MajTextString, uint64(len(t.Color))); err != nil {
+		return n + n_, err
+	} else {
+		n += n_
+	}
+	if n_, err := io.WriteString(w, string(t.Color)); err != nil {
+		return n + n_, err
+	} else {
+		n += n_
+	}
+	return n, nil
+}
+
+func (t *Label) UnmarshalCBOR(r io.Reader) (int, error) {
+	bytesRead := 0
+	*t = Label{}
+
+	br := cbg.GetPeeker(r)
+	scratch := make([]byte, 8)
+	limits := cbg.GetDecodeLimits(br)
+	if err := limits.Enter(); err != nil {
+		return bytesRead, &cbg.DecodeError{Type: "Label", Err: err}
+	}
+	defer limits.Leave()
+
+	maj, extra, read, err := cbg.CborReadHeaderBuf(br, scratch)
+	if err != nil {
+		return bytesRead, err
+	}
+	bytesRead += read
+	if maj != cbg.MajMap {
+		return bytesRead, &cbg.DecodeError{Type: "Label", Expected: cbg.MajMap, Found: maj, Err: cbg.ErrUnexpectedType}
+	}
+
+	if extra > cbg.MaxLength {
+		return bytesRead, &cbg.DecodeError{Type: "Label", Expected: cbg.MajMap, Found: maj, Err: cbg.ErrMaxLength}
+	}
+	if err := limits.AddItems(extra); err != nil {
+		return bytesRead, &cbg.DecodeError{Type: "Label", Expected: cbg.MajMap, Found: maj, Err: err}
+	}
+
+	var name string
+	n := extra
+
+	for i := uint64(0); i < n; i++ {
+
+		{
+			sval, read, err := cbg.ReadStringBuf(br, scratch)
+			if err != nil {
+				return bytesRead, cbg.WrapDecodeError(err, "Label", "", bytesRead)
+			}
+			bytesRead += read
+
+			name = string(sval)
+		}
+
+		switch name {
+		// t.Text (string) (string)
+		case "t":
+
+			{
+				sval, read, err := cbg.ReadStringBuf(br, scratch)
+				if err != nil {
+					return bytesRead, cbg.WrapDecodeError(err, "Label", "Text", bytesRead)
+				}
+				bytesRead += read
+
+				t.Text = string(sval)
+			}
+			// t.Color (string) (string)
+		case "color":
+
+			{
+				sval, read, err := cbg.ReadStringBuf(br, scratch)
+				if err != nil {
+					return bytesRead, cbg.WrapDecodeError(err, "Label", "Color", bytesRead)
+				}
+				bytesRead += read
+
+				t.Color = string(sval)
+			}
+
+		default:
+			// Field doesn't exist on this type, so ignore it
+			if read, err := cbg.ScanForLinks(br, func(cid.Cid) {}); err != nil {
+				return bytesRead, cbg.WrapDecodeError(err, "Label", "", bytesRead)
+			} else {
+				bytesRead += read
+			}
+		}
+	}
+
+	return bytesRead, nil
+}
+
+func (t *Label) MarshalDAGJSON(w io.Writer) error {
+	return cbg.MarshalDAGJSON(w, t)
+}
+
+func (t *Label) UnmarshalDAGJSON(r io.Reader) error {
+	return cbg.UnmarshalDAGJSON(r, t)
+}
+
+func (t *Label) Links() []cid.Cid {
+	if t == nil {
+		return nil
+	}
+	var links []cid.Cid
+
+	return links
+}
+
+func (t *Label) ForEachLink(cb func(path string, c cid.Cid) error) error {
+	if t == nil {
+		return nil
+	}
+	return nil
+}
+
+func (t *Drawing) MarshalCBOR(w io.Writer) (n int, err error) {
+	if t == nil {
+		return w.Write(cbg.CborNull)
+	}
+	if n_, err := w.Write([]byte{170}); err != nil {
+		return n + n_, err
+	} else {
+		n += n_
+	}
+
+	scratch := make([]byte, 9)
+
+	// t.Data ([]uint8) (slice)
+	if len("data") > cbg.MaxLength {
+		return n, xerrors.Errorf("Value in field \"data\" was too long")
+	}
+
+	if n_, err := cbg.WriteMajorTypeHeaderBuf(scratch, w, cbg.MajTextString, uint64(len("data"))); err != nil {
+		return n + n_, err
+	} else {
+		n += n_
+	}
+	if n_, err := io.WriteString(w, string("data")); err != nil {
+		return n + n_, err
+	} else {
+		n += n_
+	}
+
+	if len(t.Data) > cbg.ByteArrayMaxLen {
+		return n, xerrors.Errorf("Byte array in field t.Data was too long")
+	}
+
+	if n_, err := cbg.WriteMajorTypeHeaderBuf(scratch, w, cbg.MajByteString, uint64(len(t.Data))); err != nil {
+		return n + n_, err
+	} else {
+		n += n_
+	}
+
+	if n_, err := w.Write(t.Data[:]); err != nil {
+		return n + n_, err
+	} else {
+		n += n_
+	}
+
+	// t.Name (string) (string)
+	if len("n") > cbg.MaxLength {
+		return n, xerrors.Errorf("Value in field \"n\" was too long")
+	}
+
+	if n_, err := cbg.WriteMajorTypeHeaderBuf(scratch, w, cbg.MajTextString, uint64(len("n"))); err != nil {
+		return n + n_, err
+	} else {
+		n += n_
+	}
+	if n_, err := io.WriteString(w, string("n")); err != nil {
+		return n + n_, err
+	} else {
+		n += n_
+	}
+
+	if len(t.Name) > cbg.MaxLength {
+		return n, xerrors.Errorf("Value in field t.Name was too long")
+	}
+
+	if n_, err := cbg.WriteMajorTypeHeaderBuf(scratch, w, cbg.MajTextString, uint64(len(t.Name))); err != nil {
+		return n + n_, err
+	} else {
+		n += n_
+	}
+	if n_, err := io.WriteString(w, string(t.Name)); err != nil {
+		return n + n_, err
+	} else {
+		n += n_
+	}
+
+	// t.Extra (typegen.Deferred) (struct)
+	if len("extra") > cbg.MaxLength {
+		return n, xerrors.Errorf("Value in field \"extra\" was too long")
+	}
+
+	if n_, err := cbg.WriteMajorTypeHeaderBuf(scratch, w, cbg.MajTextString, uint64(len("extra"))); err != nil {
+		return n + n_, err
+	} else {
+		n += n_
+	}
+	if n_, err := io.WriteString(w, string("extra")); err != nil {
+		return n + n_, err
+	} else {
+		n += n_
+	}
+
+	if n_, err := t.Extra.MarshalCBOR(w); err != nil {
+		return n + n_, err
+	} else {
+		n += n_
+	}
+
+	// t.Value (ipldschema.Value) (struct)
+	if len("value") > cbg.MaxLength {
+		return n, xerrors.Errorf("Value in field \"value\" was too long")
+	}
+
+	if n_, err := cbg.WriteMajorTypeHeaderBuf(scratch, w, cbg.MajTextString, uint64(len("value"))); err != nil {
+		return n + n_, err
+	} else {
+		n += n_
+	}
+	if n_, err := io.WriteString(w, string("value")); err != nil {
+		return n + n_, err
+	} else {
+		n += n_
+	}
+
+	if n_, err := t.Value.MarshalCBOR(w); err != nil {
+		return n + n_, err
+	} else {
+		n += n_
+	}
+
+	// t.Labels (map[string]ipldschema.Label) (map)
+	if len("labels") > cbg.MaxLength {
+		return n, xerrors.Errorf("Value in field \"labels\" was too long")
+	}
+
+	if n_, err := cbg.WriteMajorTypeHeaderBuf(scratch, w, cbg.MajTextString, uint64(len("labels"))); err != nil {
+		return n + n_, err
+	} else {
+		n += n_
+	}
+	if n_, err := io.WriteString(w, string("labels")); err != nil {
+		return n + n_, err
+	} else {
+		n += n_
+	}
+
+	{
+		if len(t.Labels) > 4096 {
+			return n, xerrors.Errorf("cannot marshal t.Labels map too large")
+		}
+
+		if n_, err := cbg.WriteMajorTypeHeaderBuf(scratch, w, cbg.MajMap, uint64(len(t.Labels))); err != nil {
+			return n + n_, err
+		} else {
+			n += n_
+		}
+
+		keys := make([]string, 0, len(t.Labels))
+		for k := range t.Labels {
+			keys = append(keys, k)
+		}
+		cbg.MapKeySort_RFC7049(keys)
+		for _, k := range keys {
+			v := t.Labels[k]
+
+			if len(k) > cbg.MaxLength {
+				return n, xerrors.Errorf("Value in field k was too long")
+			}
+
+			if n_, err := cbg.WriteMajorTypeHeaderBuf(scratch, w, cbg.MajTextString, uint64(len(k))); err != nil {
+				return n + n_, err
+			} else {
+				n += n_
+			}
+			if n_, err := io.WriteString(w, string(k)); err != nil {
+				return n + n_, err
+			} else {
+				n += n_
+			}
+
+			if n_, err := v.MarshalCBOR(w); err != nil {
+				return n + n_, err
+			} else {
+				n += n_
+			}
+
+		}
+	}
+
+	// t.Parent (cid.Cid) (struct)
+	if len("parent") > cbg.MaxLength {
+		return n, xerrors.Errorf("Value in field \"parent\" was too long")
+	}
+
+	if n_, err := cbg.WriteMajorTypeHeaderBuf(scratch, w, cbg.MajTextString, uint64(len("parent"))); err != nil {
+		return n + n_, err
+	} else {
+		n += n_
+	}
+	if n_, err := io.WriteString(w, string("parent")); err != nil {
+		return n + n_, err
+	} else {
+		n += n_
+	}
+
+	if t.Parent == nil {
+		if n_, err := w.Write(cbg.CborNull); err != nil {
+			return n + n_, err
+		} else {
+			n += n_
+		}
+	} else {
+		if n_, err := cbg.WriteCidBuf(scratch, w, *t.Parent); err != nil {
+			return n + n_, xerrors.Errorf("failed to write cid field t.Parent: %w", err)
+		} else {
+			n += n_
+		}
+	}
+
+	// t.Shapes ([]ipldschema.Shape) (slice)
+	if len("shapes") > cbg.MaxLength {
+		return n, xerrors.Errorf("Value in field \"shapes\" was too long")
+	}
+
+	if n_, err := cbg.WriteMajorTypeHeaderBuf(scratch, w, cbg.MajTextString, uint64(len("shapes"))); err != nil {
+		return n + n_, err
+	} else {
+		n += n_
+	}
+	if n_, err := io.WriteString(w, string("shapes")); err != nil {
+		return n + n_, err
+	} else {
+		n += n_
+	}
+
+	if len(t.Shapes) > cbg.MaxLength {
+		return n, xerrors.Errorf("Slice value in field t.Shapes was too long")
+	}
+
+	if n_, err := cbg.WriteMajorTypeHeaderBuf(scratch, w, cbg.MajArray, uint64(len(t.Shapes))); err != nil {
+		return n + n_, err
+	} else {
+		n += n_
+	}
+	for _, v := range t.Shapes {
+		if n_, err := v.MarshalCBOR(w); err != nil {
+			return n + n_, err
+		} else {
+			n += n_
+		}
+	}
+
+	// t.Status (ipldschema.Status) (string)
+	if len("status") > cbg.MaxLength {
+		return n, xerrors.Errorf("Value in field \"status\" was too long")
+	}
+
+	if n_, err := cbg.WriteMajorTypeHeaderBuf(scratch, w, cbg.MajTextString, uint64(len("status"))); err != nil {
+		return n + n_, err
+	} else {
+		n += n_
+	}
+	if n_, err := io.WriteString(w, string("status")); err != nil {
+		return n + n_, err
+	} else {
+		n += n_
+	}
+
+	if len(t.Status) > cbg.MaxLength {
+		return n, xerrors.Errorf("Value in field t.Status was too long")
+	}
+
+	if n_, err := cbg.WriteMajorTypeHeaderBuf(scratch, w, cbg.MajTextString, uint64(len(t.Status))); err != nil {
+		return n + n_, err
+	} else {
+		n += n_
+	}
+	if n_, err := io.WriteString(w, string(t.Status)); err != nil {
+		return n + n_, err
+	} else {
+		n += n_
+	}
+
+	// t.Visible (bool) (bool)
+	if len("visible") > cbg.MaxLength {
+		return n, xerrors.Errorf("Value in field \"visible\" was too long")
+	}
+
+	if n_, err := cbg.WriteMajorTypeHeaderBuf(scratch, w, cbg.MajTextString, uint64(len("visible"))); err != nil {
+		return n + n_, err
+	} else {
+		n += n_
+	}
+	if n_, err := io.WriteString(w, string("visible")); err != nil {
+		return n + n_, err
+	} else {
+		n += n_
+	}
+
+	if n_, err := cbg.WriteBool(w, t.Visible); err != nil {
+		return n + n_, err
+	} else {
+		n += n_
+	}
+
+	// t.Priority (ipldschema.Priority) (int64)
+	if len("priority") > cbg.MaxLength {
+		return n, xerrors.Errorf("Value in field \"priority\" was too long")
+	}
+
+	if n_, err := cbg.WriteMajorTypeHeaderBuf(scratch, w, cbg.MajTextString, uint64(len("priority"))); err != nil {
+		return n + n_, err
+	} else {
+		n += n_
+	}
+	if n_, err := io.WriteString(w, string("priority")); err != nil {
+		return n + n_, err
+	} else {
+		n += n_
+	}
+
+	if t.Priority >= 0 {
+		if n_, err := cbg.WriteMajorTypeHeaderBuf(scratch, w, cbg.MajUnsignedInt, uint64(t.Priority)); err != nil {
+			return n + n_, err
+		} else {
+			n += n_
+		}
+	} else {
+		if n_, err := cbg.WriteMajorTypeHeaderBuf(scratch, w, cbg.MajNegativeInt, uint64(-t.Priority-1)); err != nil {
+			return n + n_, err
+		} else {
+			n += n_
+		}
+	}
+	return n, nil
+}
+
+func (t *Drawing) UnmarshalCBOR(r io.Reader) (int, error) {
+	bytesRead := 0
+	*t = Drawing{}
+
+	br := cbg.GetPeeker(r)
+	scratch := make([]byte, 8)
+	limits := cbg.GetDecodeLimits(br)
+	if err := limits.Enter(); err != nil {
+		return bytesRead, &cbg.DecodeError{Type: "Drawing", Err: err}
+	}
+	defer limits.Leave()
+
+	maj, extra, read, err := cbg.CborReadHeaderBuf(br, scratch)
+	if err != nil {
+		return bytesRead, err
+	}
+	bytesRead += read
+	if maj != cbg.MajMap {
+		return bytesRead, &cbg.DecodeError{Type: "Drawing", Expected: cbg.MajMap, Found: maj, Err: cbg.ErrUnexpectedType}
+	}
+
+	if extra > cbg.MaxLength {
+		return bytesRead, &cbg.DecodeError{Type: "Drawing", Expected: cbg.MajMap, Found: maj, Err: cbg.ErrMaxLength}
+	}
+	if err := limits.AddItems(extra); err != nil {
+		return bytesRead, &cbg.DecodeError{Type: "Drawing", Expected: cbg.MajMap, Found: maj, Err: err}
+	}
+
+	var name string
+	n := extra
+
+	for i := uint64(0); i < n; i++ {
+
+		{
+			sval, read, err := cbg.ReadStringBuf(br, scratch)
+			if err != nil {
+				return bytesRead, cbg.WrapDecodeError(err, "Drawing", "", bytesRead)
+			}
+			bytesRead += read
+
+			name = string(sval)
+		}
+
+		switch name {
+		// t.Data ([]uint8) (slice)
+		case "data":
+
+			maj, extra, read, err = cbg.CborReadHeaderBuf(br, scratch)
+			if err != nil {
+				return bytesRead, cbg.WrapDecodeError(err, "Drawing", "Data", bytesRead)
+			}
+			bytesRead += read
+
+			if extra > cbg.ByteArrayMaxLen {
+				return bytesRead, &cbg.DecodeError{Type: "Drawing", Field: "Data", Offset: bytesRead - read, Expected: cbg.MajByteString, Found: maj, Err: cbg.ErrMaxLength}
+			}
+			if maj != cbg.MajByteString {
+				return bytesRead, &cbg.DecodeError{Type: "Drawing", Field: "Data", Offset: bytesRead - read, Expected: cbg.MajByteString, Found: maj, Err: cbg.ErrUnexpectedType}
+			}
+			if err := limits.AddBytes(extra); err != nil {
+				return bytesRead, &cbg.DecodeError{Type: "Drawing", Field: "Data", Offset: bytesRead - read, Expected: cbg.MajByteString, Found: maj, Err: err}
+			}
+
+			if extra > 0 {
+				t.Data = make([]uint8, extra)
+			}
+
+			if read, err := io.ReadFull(br, t.Data[:]); err != nil {
+				return bytesRead, cbg.WrapDecodeError(err, "Drawing", "Data", bytesRead)
+			} else {
+				bytesRead += read
+			}
+			// t.Name (string) (string)
+		case "n":
+
+			{
+				sval, read, err := cbg.ReadStringBuf(br, scratch)
+				if err != nil {
+					return bytesRead, cbg.WrapDecodeError(err, "Drawing", "Name", bytesRead)
+				}
+				bytesRead += read
+
+				t.Name = string(sval)
+			}
+			// t.Extra (typegen.Deferred) (struct)
+		case "extra":
+
+			{
+
+				t.Extra = new(cbg.Deferred)
+
+				if read, err := t.Extra.UnmarshalCBOR(br); err != nil {
+					return bytesRead, cbg.WrapDecodeError(err, "Drawing", "Extra", bytesRead)
+				} else {
+					bytesRead += read
+				}
+			}
+			// t.Value (ipldschema.Value) (struct)
+		case "value":
+
+			{
+
+				if read, err := t.Value.UnmarshalCBOR(br); err != nil {
+					return bytesRead, cbg.WrapDecodeError(err, "Drawing", "Value", bytesRead)
+				} else {
+					bytesRead += read
+				}
+
+			}
+			// t.Labels (map[string]ipldschema.Label) (map)
+		case "labels":
+
+			maj, extra, read, err = cbg.CborReadHeaderBuf(br, scratch)
+			if err != nil {
+				return bytesRead, cbg.WrapDecodeError(err, "Drawing", "Labels", bytesRead)
+			}
+			bytesRead += read
+			if maj != cbg.MajMap {
+				return bytesRead, &cbg.DecodeError{Type: "Drawing", Field: "Labels", Offset: bytesRead - read, Expected: cbg.MajMap, Found: maj, Err: cbg.ErrUnexpectedType}
+			}
+			if extra > 4096 {
+				return bytesRead, &cbg.DecodeError{Type: "Drawing", Field: "Labels", Offset: bytesRead - read, Expected: cbg.MajMap, Found: maj, Err: cbg.ErrMaxLength}
+			}
+			if err := limits.AddItems(extra); err != nil {
+				return bytesRead, &cbg.DecodeError{Type: "Drawing", Field: "Labels", Offset: bytesRead - read, Expected: cbg.MajMap, Found: maj, Err: err}
+			}
+
+			t.Labels = make(map[string]Label, extra)
+
+			for i, l := 0, int(extra); i < l; i++ {
+
+				var k string
+
+				{
+					sval, read, err := cbg.ReadStringBuf(br, scratch)
+					if err != nil {
+						return bytesRead, cbg.WrapDecodeError(err, "Drawing", "Labels", bytesRead)
+					}
+					bytesRead += read
+
+					k = string(sval)
+				}
+
+				var v Label
+
+				{
+
+					if read, err := v.UnmarshalCBOR(br); err != nil {
+						return bytesRead, cbg.WrapDecodeError(err, "Drawing", fmt.Sprintf("Labels[%q]", k), bytesRead)
+					} else {
+						bytesRead += read
+					}
+
+				}
+
+				t.Labels[k] = v
+
+			}
+			// t.Parent (cid.Cid) (struct)
+		case "parent":
+
+			{
+
+				b, err := br.ReadByte()
+				if err != nil {
+					return bytesRead, cbg.WrapDecodeError(err, "Drawing", "Parent", bytesRead)
+				}
+				bytesRead++
+				if b != cbg.CborNull[0] {
+					if err := br.UnreadByte(); err != nil {
+						return bytesRead, cbg.WrapDecodeError(err, "Drawing", "Parent", bytesRead)
+					}
+					bytesRead--
+
+					c, read, err := cbg.ReadCid(br)
+					if err != nil {
+						return bytesRead, cbg.WrapDecodeError(err, "Drawing", "Parent", bytesRead)
+					}
+					bytesRead += read
+
+					t.Parent = &c
+				}
+
+			}
+			// t.Shapes ([]ipldschema.Shape) (slice)
+		case "shapes":
+
+			maj, extra, read, err = cbg.CborReadHeaderBuf(br, scratch)
+			if err != nil {
+				return bytesRead, cbg.WrapDecodeError(err, "Drawing", "Shapes", bytesRead)
+			}
+			bytesRead += read
+
+			if extra > cbg.MaxLength {
+				return bytesRead, &cbg.DecodeError{Type: "Drawing", Field: "Shapes", Offset: bytesRead - read, Expected: cbg.MajArray, Found: maj, Err: cbg.ErrMaxLength}
+			}
+
+			if maj != cbg.MajArray {
+				return bytesRead, &cbg.DecodeError{Type: "Drawing", Field: "Shapes", Offset: bytesRead - read, Expected: cbg.MajArray, Found: maj, Err: cbg.ErrUnexpectedType}
+			}
+			if err := limits.AddItems(extra); err != nil {
+				return bytesRead, &cbg.DecodeError{Type: "Drawing", Field: "Shapes", Offset: bytesRead - read, Expected: cbg.MajArray, Found: maj, Err: err}
+			}
+
+			if extra > 0 {
+				t.Shapes = make([]Shape, extra)
+			}
+
+			for i := 0; i < int(extra); i++ {
+
+				var v Shape
+				if read, err := v.UnmarshalCBOR(br); err != nil {
+					return bytesRead, cbg.WrapDecodeError(err, "Drawing", fmt.Sprintf("Shapes[%d]", i), bytesRead)
+				} else {
+					bytesRead += read
+				}
+
+				t.Shapes[i] = v
+			}
+
+			// t.Status (ipldschema.Status) (string)
+		case "status":
+
+			{
+				sval, read, err := cbg.ReadStringBuf(br, scratch)
+				if err != nil {
+					return bytesRead, cbg.WrapDecodeError(err, "Drawing", "Status", bytesRead)
+				}
+				bytesRead += read
+
+				t.Status = Status(sval)
+			}
+			// t.Visible (bool) (bool)
+		case "visible":
+
+			maj, extra, read, err = cbg.CborReadHeaderBuf(br, scratch)
+			if err != nil {
+				return bytesRead, cbg.WrapDecodeError(err, "Drawing", "Visible", bytesRead)
+			}
+			bytesRead += read
+			if maj != cbg.MajOther {
+				return bytesRead, &cbg.DecodeError{Type: "Drawing", Field: "Visible", Offset: bytesRead - read, Expected: cbg.MajOther, Found: maj, Err: cbg.ErrUnexpectedType}
+			}
+			switch extra {
+			case 20:
+				t.Visible = false
+			case 21:
+				t.Visible = true
+			default:
+				return bytesRead, &cbg.DecodeError{Type: "Drawing", Field: "Visible", Offset: bytesRead - read, Expected: cbg.MajOther, Found: maj, Err: fmt.Errorf("booleans are either major type 7, value 20 or 21 (got %d)", extra)}
+			}
+			// t.Priority (ipldschema.Priority) (int64)
+		case "priority":
+			{
+				maj, extra, read, err := cbg.CborReadHeaderBuf(br, scratch)
+				var extraI int64
+				if err != nil {
+					return bytesRead, cbg.WrapDecodeError(err, "Drawing", "Priority", bytesRead)
+				}
+				bytesRead += read
+				switch maj {
+				case cbg.MajUnsignedInt:
+					extraI = int64(extra)
+					if extraI < 0 {
+						return bytesRead, &cbg.DecodeError{Type: "Drawing", Field: "Priority", Offset: bytesRead - read, Expected: maj, Found: maj, Err: cbg.ErrOverflow}
+					}
+				case cbg.MajNegativeInt:
+					extraI = int64(extra)
+					if extraI < 0 {
+						return bytesRead, &cbg.DecodeError{Type: "Drawing", Field: "Priority", Offset: bytesRead - read, Expected: maj, Found: maj, Err: cbg.ErrOverflow}
+					}
+					extraI = -1 - extraI
+				default:
+					return bytesRead, &cbg.DecodeError{Type: "Drawing", Field: "Priority", Offset: bytesRead - read, Expected: cbg.MajUnsignedInt, Found: maj, Err: cbg.ErrUnexpectedType}
+				}
+
+				t.Priority = Priority(extraI)
+			}
+
+		default:
+			// Field doesn't exist on this type, so ignore it
+			if read, err := cbg.ScanForLinks(br, func(cid.Cid) {}); err != nil {
+				return bytesRead, cbg.WrapDecodeError(err, "Drawing", "", bytesRead)
+			} else {
+				bytesRead += read
+			}
+		}
+	}
+
+	if t.Status != "active" && t.Status != "Retired" {
+		return bytesRead, &cbg.DecodeError{Type: "Drawing", Field: "Status", Offset: bytesRead, Err: &cbg.ConstraintError{Constraint: "enum=active|Retired", Got: fmt.Sprintf("%q", t.Status)}}
+	}
+	if t.Priority != 1 && t.Priority != 2 {
+		return bytesRead, &cbg.DecodeError{Type: "Drawing", Field: "Priority", Offset: bytesRead, Err: &cbg.ConstraintError{Constraint: "enum=1|2", Got: fmt.Sprint(t.Priority)}}
+	}
+
+	return bytesRead, nil
+}
+
+func (t *Drawing) MarshalDAGJSON(w io.Writer) error {
+	return cbg.MarshalDAGJSON(w, t)
+}
+
+func (t *Drawing) UnmarshalDAGJSON(r io.Reader) error {
+	return cbg.UnmarshalDAGJSON(r, t)
+}
+
+func (t *Drawing) Links() []cid.Cid {
+	if t == nil {
+		return nil
+	}
+	var links []cid.Cid
+
+	if t.Parent != nil {
+		links = append(links, *t.Parent)
+	}
+	return links
+}
+
+func (t *Drawing) ForEachLink(cb func(path string, c cid.Cid) error) error {
+	if t == nil {
+		return nil
+	}
+	if err := cbg.ForEachLinkIn(t.Extra, "extra", cb); err != nil {
+		return err
+	}
+	if err := cbg.ForEachLinkIn(&t.Value, "value", cb); err != nil {
+		return err
+	}
+	if t.Parent != nil {
+		if err := cb("parent", *t.Parent); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,180 @@
+// Code generated by github.com/daotl/cbor-gen/schemagen. DO NOT EDIT.
+
+package ipldschema
+
+import (
+	"fmt"
+	"io"
+
+	cid "github.com/ipfs/go-cid"
+
+	cbg "github.com/daotl/cbor-gen"
+)
+
+var _ = cid.Undef
+
+var keyShapePoint = []byte{0xa1, 0x65, 0x70, 0x6f, 0x69, 0x6e, 0x74}
+var keyShapeLabel = []byte{0xa1, 0x65, 0x6c, 0x61, 0x62, 0x65, 0x6c}
+
+func (t *Shape) MarshalCBOR(w io.Writer) (int, error) {
+	if t == nil {
+		return w.Write(cbg.CborNull)
+	}
+
+	switch {
+	case t.Point != nil:
+		n, err := w.Write(keyShapePoint)
+		if err != nil {
+			return n, err
+		}
+		n_, err := t.Point.MarshalCBOR(w)
+		return n + n_, err
+	case t.Label != nil:
+		n, err := w.Write(keyShapeLabel)
+		if err != nil {
+			return n, err
+		}
+		n_, err := t.Label.MarshalCBOR(w)
+		return n + n_, err
+	default:
+		return 0, fmt.Errorf("Shape: no member is set")
+	}
+}
+
+func (t *Shape) UnmarshalCBOR(r io.Reader) (int, error) {
+	*t = Shape{}
+
+	br := cbg.GetPeeker(r)
+	scratch := make([]byte, 8)
+
+	maj, extra, read, err := cbg.CborReadHeaderBuf(br, scratch)
+	bytesRead := read
+	if err != nil {
+		return bytesRead, err
+	}
+	if maj != cbg.MajMap {
+		return bytesRead, &cbg.DecodeError{Type: "Shape", Expected: cbg.MajMap, Found: maj, Err: cbg.ErrUnexpectedType}
+	}
+	if extra != 1 {
+		return bytesRead, &cbg.DecodeError{Type: "Shape", Expected: cbg.MajMap, Found: maj, Err: fmt.Errorf("expected a map with a single entry")}
+	}
+
+	key, read, err := cbg.ReadStringBuf(br, scratch)
+	bytesRead += read
+	if err != nil {
+		return bytesRead, err
+	}
+
+	switch key {
+	case "point":
+		t.Point = new(Point)
+		read, err := t.Point.UnmarshalCBOR(br)
+		return bytesRead + read, err
+	case "label":
+		t.Label = new(Label)
+		read, err := t.Label.UnmarshalCBOR(br)
+		return bytesRead + read, err
+	default:
+		return bytesRead, fmt.Errorf("Shape: unknown member %q", key)
+	}
+}
+
+func (t *Value) MarshalCBOR(w io.Writer) (int, error) {
+	if t == nil {
+		return w.Write(cbg.CborNull)
+	}
+
+	switch {
+	case t.String != nil:
+		return cbg.EncodeAny(w, cbg.StringNode(*t.String))
+	case t.Int != nil:
+		return cbg.EncodeAny(w, cbg.NewIntNode(int64(*t.Int)))
+	case t.Point != nil:
+		return t.Point.MarshalCBOR(w)
+	case t.Link != nil:
+		return cbg.EncodeAny(w, cbg.LinkNode(*t.Link))
+	default:
+		return 0, fmt.Errorf("Value: no member is set")
+	}
+}
+
+func (t *Value) UnmarshalCBOR(r io.Reader) (int, error) {
+	*t = Value{}
+
+	br := cbg.GetPeeker(r)
+	first, err := br.ReadByte()
+	if err != nil {
+		return 0, err
+	}
+	if err := br.UnreadByte(); err != nil {
+		return 0, err
+	}
+
+	bytesRead := 0
+	switch first >> 5 {
+	case cbg.MajTextString:
+		var d cbg.Deferred
+		read, err := d.UnmarshalCBOR(br)
+		bytesRead += read
+		if err != nil {
+			return bytesRead, err
+		}
+		nd, err := d.Node()
+		if err != nil {
+			return bytesRead, err
+		}
+		v, ok := nd.(cbg.StringNode)
+		if !ok {
+			return bytesRead, fmt.Errorf("Value: expected string for member String, got %s", nd.Kind())
+		}
+		val := string(v)
+		t.String = &val
+		return bytesRead, nil
+	case cbg.MajUnsignedInt, cbg.MajNegativeInt:
+		var d cbg.Deferred
+		read, err := d.UnmarshalCBOR(br)
+		bytesRead += read
+		if err != nil {
+			return bytesRead, err
+		}
+		nd, err := d.Node()
+		if err != nil {
+			return bytesRead, err
+		}
+		v, ok := nd.(cbg.IntNode)
+		if !ok {
+			return bytesRead, fmt.Errorf("Value: expected int for member Int, got %s", nd.Kind())
+		}
+		i, ok := v.Int64()
+		if !ok {
+			return bytesRead, &cbg.DecodeError{Type: "Value", Field: "Int", Offset: bytesRead - read, Err: cbg.ErrOverflow}
+		}
+		val := int64(i)
+		t.Int = &val
+		return bytesRead, nil
+	case cbg.MajArray:
+		t.Point = new(Point)
+		read, err := t.Point.UnmarshalCBOR(br)
+		return bytesRead + read, err
+	case cbg.MajTag:
+		var d cbg.Deferred
+		read, err := d.UnmarshalCBOR(br)
+		bytesRead += read
+		if err != nil {
+			return bytesRead, err
+		}
+		nd, err := d.Node()
+		if err != nil {
+			return bytesRead, err
+		}
+		v, ok := nd.(cbg.LinkNode)
+		if !ok {
+			return bytesRead, fmt.Errorf("Value: expected link for member Link, got %s", nd.Kind())
+		}
+		val := cid.Cid(v)
+		t.Link = &val
+		return bytesRead, nil
+	default:
+		return 0, fmt.Errorf("Value: no member of major type %d", first>>5)
+	}
+}
@@ -0,0 +1,63 @@
+// Code generated by github.com/daotl/cbor-gen/schemagen. DO NOT EDIT.
+
+package ipldschema
+
+import (
+	cid "github.com/ipfs/go-cid"
+
+	cbg "github.com/daotl/cbor-gen"
+)
+
+var _ = cid.Undef
+var _ = cbg.CborNull
+
+type Status string
+
+const (
+	StatusActive  Status = "active"
+	StatusRetired Status = "Retired"
+)
+
+type Priority int64
+
+const (
+	PriorityLow  Priority = 1
+	PriorityHigh Priority = 2
+)
+
+type Point struct {
+	X int64
+	Y int64
+}
+
+type Label struct {
+	Text  string `cborgen:"t"`
+	Color string `cborgen:"color"`
+}
+
+// Shape is a keyed union, exactly one of its fields must be set.
+type Shape struct {
+	Point *Point
+	Label *Label
+}
+
+// Value is a kinded union, exactly one of its fields must be set.
+type Value struct {
+	String *string
+	Int    *int64
+	Point  *Point
+	Link   *cid.Cid
+}
+
+type Drawing struct {
+	Name     string           `cborgen:"n"`
+	Status   Status           `cborgen:"status,enum=active|Retired"`
+	Priority Priority         `cborgen:"priority,enum=1|2"`
+	Shapes   []Shape          `cborgen:"shapes"`
+	Labels   map[string]Label `cborgen:"labels"`
+	Parent   *cid.Cid         `cborgen:"parent"`
+	Value    Value            `cborgen:"value"`
+	Extra    *cbg.Deferred    `cborgen:"extra"`
+	Data     []byte           `cborgen:"data"`
+	Visible  bool             `cborgen:"visible"`
+}
@@ -210,6 +210,11 @@ func (t *EmbeddingStructOne) UnmarshalCBOR(r io.Reader) (int, error) {
 
 	br := cbg.GetPeeker(r)
 	scratch := make([]byte, 8)
+	limits := cbg.GetDecodeLimits(br)
+	if err := limits.Enter(); err != nil {
+		return bytesRead, &cbg.DecodeError{Type: "EmbeddingStructOne", Err: err}
+	}
+	defer limits.Leave()
 
 	maj, extra, read, err := cbg.CborReadHeaderBuf(br, scratch)
 	if err != nil {
@@ -217,11 +222,14 @@ func (t *EmbeddingStructOne) UnmarshalCBOR(r io.Reader) (int, error) {
 	}
 	bytesRead += read
 	if maj != cbg.MajMap {
-		return bytesRead, fmt.Errorf("cbor input should be of type map")
+		return bytesRead, &cbg.DecodeError{Type: "EmbeddingStructOne", Expected: cbg.MajMap, Found: maj, Err: cbg.ErrUnexpectedType}
 	}
 
 	if extra > cbg.MaxLength {
-		return bytesRead, fmt.Errorf("EmbeddingStructOne: map struct too large (%d)", extra)
+		return bytesRead, &cbg.DecodeError{Type: "EmbeddingStructOne", Expected: cbg.MajMap, Found: maj, Err: cbg.ErrMaxLength}
+	}
+	if err := limits.AddItems(extra); err != nil {
+		return bytesRead, &cbg.DecodeError{Type: "EmbeddingStructOne", Expected: cbg.MajMap, Found: maj, Err: err}
 	}
 
 	var name string
@@ -232,7 +240,7 @@ func (t *EmbeddingStructOne) UnmarshalCBOR(r io.Reader) (int, error) {
 		{
 			sval, read, err := cbg.ReadStringBuf(br, scratch)
 			if err != nil {
-				return bytesRead, err
+				return bytesRead, cbg.WrapDecodeError(err, "EmbeddingStructOne", "", bytesRead)
 			}
 			bytesRead += read
 
@@ -246,7 +254,7 @@ func (t *EmbeddingStructOne) UnmarshalCBOR(r io.Reader) (int, error) {
 			{
 				sval, read, err := cbg.ReadStringBuf(br, scratch)
 				if err != nil {
-					return bytesRead, err
+					return bytesRead, cbg.WrapDecodeError(err, "EmbeddingStructOne", "Foo", bytesRead)
 				}
 				bytesRead += read
 
@@ -259,17 +267,17 @@ func (t *EmbeddingStructOne) UnmarshalCBOR(r io.Reader) (int, error) {
 
 				b, err := br.ReadByte()
 				if err != nil {
-					return bytesRead, err
+					return bytesRead, cbg.WrapDecodeError(err, "EmbeddingStructOne", "Stuff", bytesRead)
 				}
 				bytesRead++
 				if b != cbg.CborNull[0] {
 					if err := br.UnreadByte(); err != nil {
-						return bytesRead, err
+						return bytesRead, cbg.WrapDecodeError(err, "EmbeddingStructOne", "Stuff", bytesRead)
 					}
 					bytesRead--
 					t.Stuff = new(testing.SimpleTypeTwo)
 					if read, err := t.Stuff.UnmarshalCBOR(br); err != nil {
-						return bytesRead, xerrors.Errorf("unmarshaling t.Stuff pointer: %w", err)
+						return bytesRead, cbg.WrapDecodeError(err, "EmbeddingStructOne", "Stuff", bytesRead)
 					} else {
 						bytesRead += read
 					}
@@ -281,16 +289,19 @@ func (t *EmbeddingStructOne) UnmarshalCBOR(r io.Reader) (int, error) {
 
 			maj, extra, read, err = cbg.CborReadHeaderBuf(br, scratch)
 			if err != nil {
-				return bytesRead, err
+				return bytesRead, cbg.WrapDecodeError(err, "EmbeddingStructOne", "Others", bytesRead)
 			}
 			bytesRead += read
 
 			if extra > cbg.MaxLength {
-				return bytesRead, fmt.Errorf("t.Others: array too large (%d)", extra)
+				return bytesRead, &cbg.DecodeError{Type: "EmbeddingStructOne", Field: "Others", Offset: bytesRead - read, Expected: cbg.MajArray, Found: maj, Err: cbg.ErrMaxLength}
 			}
 
 			if maj != cbg.MajArray {
-				return bytesRead, fmt.Errorf("expected cbor array")
+				return bytesRead, &cbg.DecodeError{Type: "EmbeddingStructOne", Field: "Others", Offset: bytesRead - read, Expected: cbg.MajArray, Found: maj, Err: cbg.ErrUnexpectedType}
+			}
+			if err := limits.AddItems(extra); err != nil {
+				return bytesRead, &cbg.DecodeError{Type: "EmbeddingStructOne", Field: "Others", Offset: bytesRead - read, Expected: cbg.MajArray, Found: maj, Err: err}
 			}
 
 			if extra > 0 {
@@ -301,12 +312,12 @@ func (t *EmbeddingStructOne) UnmarshalCBOR(r io.Reader) (int, error) {
 
 				maj, val, read, err := cbg.CborReadHeaderBuf(br, scratch)
 				if err != nil {
-					return bytesRead, xerrors.Errorf("failed to read uint64 for t.Others slice: %w", err)
+					return bytesRead, cbg.WrapDecodeError(err, "EmbeddingStructOne", fmt.Sprintf("Others[%d]", i), bytesRead)
 				}
 				bytesRead += read
 
 				if maj != cbg.MajUnsignedInt {
-					return bytesRead, xerrors.Errorf("value read for array t.Others was not a uint, instead got %d", maj)
+					return bytesRead, &cbg.DecodeError{Type: "EmbeddingStructOne", Field: fmt.Sprintf("Others[%d]", i), Offset: bytesRead - read, Expected: cbg.MajUnsignedInt, Found: maj, Err: cbg.ErrUnexpectedType}
 				}
 
 				t.Others[i] = uint64(val)
@@ -317,16 +328,19 @@ func (t *EmbeddingStructOne) UnmarshalCBOR(r io.Reader) (int, error) {
 
 			maj, extra, read, err = cbg.CborReadHeaderBuf(br, scratch)
 			if err != nil {
-				return bytesRead, err
+				return bytesRead, cbg.WrapDecodeError(err, "EmbeddingStructOne", "SignedOthers", bytesRead)
 			}
 			bytesRead += read
 
 			if extra > cbg.MaxLength {
-				return bytesRead, fmt.Errorf("t.SignedOthers: array too large (%d)", extra)
+				return bytesRead, &cbg.DecodeError{Type: "EmbeddingStructOne", Field: "SignedOthers", Offset: bytesRead - read, Expected: cbg.MajArray, Found: maj, Err: cbg.ErrMaxLength}
 			}
 
 			if maj != cbg.MajArray {
-				return bytesRead, fmt.Errorf("expected cbor array")
+				return bytesRead, &cbg.DecodeError{Type: "EmbeddingStructOne", Field: "SignedOthers", Offset: bytesRead - read, Expected: cbg.MajArray, Found: maj, Err: cbg.ErrUnexpectedType}
+			}
+			if err := limits.AddItems(extra); err != nil {
+				return bytesRead, &cbg.DecodeError{Type: "EmbeddingStructOne", Field: "SignedOthers", Offset: bytesRead - read, Expected: cbg.MajArray, Found: maj, Err: err}
 			}
 
 			if extra > 0 {
@@ -338,23 +352,23 @@ func (t *EmbeddingStructOne) UnmarshalCBOR(r io.Reader) (int, error) {
 					maj, extra, read, err := cbg.CborReadHeaderBuf(br, scratch)
 					var extraI int64
 					if err != nil {
-						return bytesRead, err
+						return bytesRead, cbg.WrapDecodeError(err, "EmbeddingStructOne", fmt.Sprintf("SignedOthers[%d]", i), bytesRead)
 					}
 					bytesRead += read
 					switch maj {
 					case cbg.MajUnsignedInt:
 						extraI = int64(extra)
 						if extraI < 0 {
-							return bytesRead, fmt.Errorf("int64 positive overflow")
+							return bytesRead, &cbg.DecodeError{Type: "EmbeddingStructOne", Field: fmt.Sprintf("SignedOthers[%d]", i), Offset: bytesRead - read, Expected: maj, Found: maj, Err: cbg.ErrOverflow}
 						}
 					case cbg.MajNegativeInt:
 						extraI = int64(extra)
 						if extraI < 0 {
-							return bytesRead, fmt.Errorf("int64 negative oveflow")
+							return bytesRead, &cbg.DecodeError{Type: "EmbeddingStructOne", Field: fmt.Sprintf("SignedOthers[%d]", i), Offset: bytesRead - read, Expected: maj, Found: maj, Err: cbg.ErrOverflow}
 						}
 						extraI = -1 - extraI
 					default:
-						return bytesRead, fmt.Errorf("wrong type for int64 field: %d", maj)
+						return bytesRead, &cbg.DecodeError{Type: "EmbeddingStructOne", Field: fmt.Sprintf("SignedOthers[%d]", i), Offset: bytesRead - read, Expected: cbg.MajUnsignedInt, Found: maj, Err: cbg.ErrUnexpectedType}
 					}
 
 					t.SignedOthers[i] = int64(extraI)
@@ -367,7 +381,7 @@ func (t *EmbeddingStructOne) UnmarshalCBOR(r io.Reader) (int, error) {
 			{
 
 				if read, err := t.SimpleTypeOne.UnmarshalCBOR(br); err != nil {
-					return bytesRead, xerrors.Errorf("unmarshaling t.SimpleTypeOne: %w", err)
+					return bytesRead, cbg.WrapDecodeError(err, "EmbeddingStructOne", "SimpleTypeOne", bytesRead)
 				} else {
 					bytesRead += read
 				}
@@ -380,17 +394,17 @@ func (t *EmbeddingStructOne) UnmarshalCBOR(r io.Reader) (int, error) {
 
 				b, err := br.ReadByte()
 				if err != nil {
-					return bytesRead, err
+					return bytesRead, cbg.WrapDecodeError(err, "EmbeddingStructOne", "SimpleTypeTwo", bytesRead)
 				}
 				bytesRead++
 				if b != cbg.CborNull[0] {
 					if err := br.UnreadByte(); err != nil {
-						return bytesRead, err
+						return bytesRead, cbg.WrapDecodeError(err, "EmbeddingStructOne", "SimpleTypeTwo", bytesRead)
 					}
 					bytesRead--
 					t.SimpleTypeTwo = new(testing.SimpleTypeTwo)
 					if read, err := t.SimpleTypeTwo.UnmarshalCBOR(br); err != nil {
-						return bytesRead, xerrors.Errorf("unmarshaling t.SimpleTypeTwo pointer: %w", err)
+						return bytesRead, cbg.WrapDecodeError(err, "EmbeddingStructOne", "SimpleTypeTwo", bytesRead)
 					} else {
 						bytesRead += read
 					}
@@ -400,7 +414,9 @@ func (t *EmbeddingStructOne) UnmarshalCBOR(r io.Reader) (int, error) {
 
 		default:
 			// Field doesn't exist on this type, so ignore it
-			if read, err := cbg.ScanForLinks(r, func(cid.Cid) {}); err == nil {
+			if read, err := cbg.ScanForLinks(br, func(cid.Cid) {}); err != nil {
+				return bytesRead, cbg.WrapDecodeError(err, "EmbeddingStructOne", "", bytesRead)
+			} else {
 				bytesRead += read
 			}
 		}
@@ -408,6 +424,31 @@ func (t *EmbeddingStructOne) UnmarshalCBOR(r io.Reader) (int, error) {
 
 	return bytesRead, nil
 }
+
+func (t *EmbeddingStructOne) MarshalDAGJSON(w io.Writer) error {
+	return cbg.MarshalDAGJSON(w, t)
+}
+
+func (t *EmbeddingStructOne) UnmarshalDAGJSON(r io.Reader) error {
+	return cbg.UnmarshalDAGJSON(r, t)
+}
+
+func (t *EmbeddingStructOne) Links() []cid.Cid {
+	var links []cid.Cid
+	_ = t.ForEachLink(func(_ string, c cid.Cid) error {
+		links = append(links, c)
+		return nil
+	})
+	return links
+}
+
+func (t *EmbeddingStructOne) ForEachLink(cb func(path string, c cid.Cid) error) error {
+	if t == nil {
+		return nil
+	}
+	return nil
+}
+
 func (t *EmbeddingStructTwo) MarshalCBOR(w io.Writer) (n int, err error) {
 	if t == nil {
 		return w.Write(cbg.CborNull)
@@ -728,6 +769,11 @@ func (t *EmbeddingStructTwo) UnmarshalCBOR(r io.Reader) (int, error) {
 
 	br := cbg.GetPeeker(r)
 	scratch := make([]byte, 8)
+	limits := cbg.GetDecodeLimits(br)
+	if err := limits.Enter(); err != nil {
+		return bytesRead, &cbg.DecodeError{Type: "EmbeddingStructTwo", Err: err}
+	}
+	defer limits.Leave()
 
 	maj, extra, read, err := cbg.CborReadHeaderBuf(br, scratch)
 	if err != nil {
@@ -735,11 +781,14 @@ func (t *EmbeddingStructTwo) UnmarshalCBOR(r io.Reader) (int, error) {
 	}
 	bytesRead += read
 	if maj != cbg.MajMap {
-		return bytesRead, fmt.Errorf("cbor input should be of type map")
+		return bytesRead, &cbg.DecodeError{Type: "EmbeddingStructTwo", Expected: cbg.MajMap, Found: maj, Err: cbg.ErrUnexpectedType}
 	}
 
 	if extra > cbg.MaxLength {
-		return bytesRead, fmt.Errorf("EmbeddingStructTwo: map struct too large (%d)", extra)
+		return bytesRead, &cbg.DecodeError{Type: "EmbeddingStructTwo", Expected: cbg.MajMap, Found: maj, Err: cbg.ErrMaxLength}
+	}
+	if err := limits.AddItems(extra); err != nil {
+		return bytesRead, &cbg.DecodeError{Type: "EmbeddingStructTwo", Expected: cbg.MajMap, Found: maj, Err: err}
 	}
 
 	var name string
@@ -750,7 +799,7 @@ func (t *EmbeddingStructTwo) UnmarshalCBOR(r io.Reader) (int, error) {
 		{
 			sval, read, err := cbg.ReadStringBuf(br, scratch)
 			if err != nil {
-				return bytesRead, err
+				return bytesRead, cbg.WrapDecodeError(err, "EmbeddingStructTwo", "", bytesRead)
 			}
 			bytesRead += read
 
@@ -764,7 +813,7 @@ func (t *EmbeddingStructTwo) UnmarshalCBOR(r io.Reader) (int, error) {
 			{
 				sval, read, err := cbg.ReadStringBuf(br, scratch)
 				if err != nil {
-					return bytesRead, err
+					return bytesRead, cbg.WrapDecodeError(err, "EmbeddingStructTwo", "Dog", bytesRead)
 				}
 				bytesRead += read
 
@@ -776,7 +825,7 @@ func (t *EmbeddingStructTwo) UnmarshalCBOR(r io.Reader) (int, error) {
 			{
 				sval, read, err := cbg.ReadStringBuf(br, scratch)
 				if err != nil {
-					return bytesRead, err
+					return bytesRead, cbg.WrapDecodeError(err, "EmbeddingStructTwo", "Foo", bytesRead)
 				}
 				bytesRead += read
 
@@ -787,16 +836,19 @@ func (t *EmbeddingStructTwo) UnmarshalCBOR(r io.Reader) (int, error) {
 
 			maj, extra, read, err = cbg.CborReadHeaderBuf(br, scratch)
 			if err != nil {
-				return bytesRead, err
+				return bytesRead, cbg.WrapDecodeError(err, "EmbeddingStructTwo", "Test", bytesRead)
 			}
 			bytesRead += read
 
 			if extra > cbg.MaxLength {
-				return bytesRead, fmt.Errorf("t.Test: array too large (%d)", extra)
+				return bytesRead, &cbg.DecodeError{Type: "EmbeddingStructTwo", Field: "Test", Offset: bytesRead - read, Expected: cbg.MajArray, Found: maj, Err: cbg.ErrMaxLength}
 			}
 
 			if maj != cbg.MajArray {
-				return bytesRead, fmt.Errorf("expected cbor array")
+				return bytesRead, &cbg.DecodeError{Type: "EmbeddingStructTwo", Field: "Test", Offset: bytesRead - read, Expected: cbg.MajArray, Found: maj, Err: cbg.ErrUnexpectedType}
+			}
+			if err := limits.AddItems(extra); err != nil {
+				return bytesRead, &cbg.DecodeError{Type: "EmbeddingStructTwo", Field: "Test", Offset: bytesRead - read, Expected: cbg.MajArray, Found: maj, Err: err}
 			}
 
 			if extra > 0 {
@@ -811,15 +863,18 @@ func (t *EmbeddingStructTwo) UnmarshalCBOR(r io.Reader) (int, error) {
 
 					maj, extra, read, err = cbg.CborReadHeaderBuf(br, scratch)
 					if err != nil {
-						return bytesRead, err
+						return bytesRead, cbg.WrapDecodeError(err, "EmbeddingStructTwo", fmt.Sprintf("Test[%d]", i), bytesRead)
 					}
 					bytesRead += read
 
 					if extra > cbg.ByteArrayMaxLen {
-						return bytesRead, fmt.Errorf("t.Test[i]: byte array too large (%d)", extra)
+						return bytesRead, &cbg.DecodeError{Type: "EmbeddingStructTwo", Field: fmt.Sprintf("Test[%d]", i), Offset: bytesRead - read, Expected: cbg.MajByteString, Found: maj, Err: cbg.ErrMaxLength}
 					}
 					if maj != cbg.MajByteString {
-						return bytesRead, fmt.Errorf("expected byte array")
+						return bytesRead, &cbg.DecodeError{Type: "EmbeddingStructTwo", Field: fmt.Sprintf("Test[%d]", i), Offset: bytesRead - read, Expected: cbg.MajByteString, Found: maj, Err: cbg.ErrUnexpectedType}
+					}
+					if err := limits.AddBytes(extra); err != nil {
+						return bytesRead, &cbg.DecodeError{Type: "EmbeddingStructTwo", Field: fmt.Sprintf("Test[%d]", i), Offset: bytesRead - read, Expected: cbg.MajByteString, Found: maj, Err: err}
 					}
 
 					if extra > 0 {
@@ -827,7 +882,7 @@ func (t *EmbeddingStructTwo) UnmarshalCBOR(r io.Reader) (int, error) {
 					}
 
 					if read, err := io.ReadFull(br, t.Test[i][:]); err != nil {
-						return bytesRead, err
+						return bytesRead, cbg.WrapDecodeError(err, "EmbeddingStructTwo", fmt.Sprintf("Test[%d]", i), bytesRead)
 					} else {
 						bytesRead += read
 					}
@@ -841,17 +896,17 @@ func (t *EmbeddingStructTwo) UnmarshalCBOR(r io.Reader) (int, error) {
 
 				b, err := br.ReadByte()
 				if err != nil {
-					return bytesRead, err
+					return bytesRead, cbg.WrapDecodeError(err, "EmbeddingStructTwo", "Stuff", bytesRead)
 				}
 				bytesRead++
 				if b != cbg.CborNull[0] {
 					if err := br.UnreadByte(); err != nil {
-						return bytesRead, err
+						return bytesRead, cbg.WrapDecodeError(err, "EmbeddingStructTwo", "Stuff", bytesRead)
 					}
 					bytesRead--
 					t.Stuff = new(testing.SimpleTypeTwo)
 					if read, err := t.Stuff.UnmarshalCBOR(br); err != nil {
-						return bytesRead, xerrors.Errorf("unmarshaling t.Stuff pointer: %w", err)
+						return bytesRead, cbg.WrapDecodeError(err, "EmbeddingStructTwo", "Stuff", bytesRead)
 					} else {
 						bytesRead += read
 					}
@@ -865,11 +920,11 @@ func (t *EmbeddingStructTwo) UnmarshalCBOR(r io.Reader) (int, error) {
 
 				maj, extra, read, err = cbg.CborReadHeaderBuf(br, scratch)
 				if err != nil {
-					return bytesRead, err
+					return bytesRead, cbg.WrapDecodeError(err, "EmbeddingStructTwo", "Value", bytesRead)
 				}
 				bytesRead += read
 				if maj != cbg.MajUnsignedInt {
-					return bytesRead, fmt.Errorf("wrong type for uint64 field")
+					return bytesRead, &cbg.DecodeError{Type: "EmbeddingStructTwo", Field: "Value", Offset: bytesRead - read, Expected: cbg.MajUnsignedInt, Found: maj, Err: cbg.ErrUnexpectedType}
 				}
 				t.Value = uint64(extra)
 
@@ -879,16 +934,19 @@ func (t *EmbeddingStructTwo) UnmarshalCBOR(r io.Reader) (int, error) {
 
 			maj, extra, read, err = cbg.CborReadHeaderBuf(br, scratch)
 			if err != nil {
-				return bytesRead, err
+				return bytesRead, cbg.WrapDecodeError(err, "EmbeddingStructTwo", "Others", bytesRead)
 			}
 			bytesRead += read
 
 			if extra > cbg.MaxLength {
-				return bytesRead, fmt.Errorf("t.Others: array too large (%d)", extra)
+				return bytesRead, &cbg.DecodeError{Type: "EmbeddingStructTwo", Field: "Others", Offset: bytesRead - read, Expected: cbg.MajArray, Found: maj, Err: cbg.ErrMaxLength}
 			}
 
 			if maj != cbg.MajArray {
-				return bytesRead, fmt.Errorf("expected cbor array")
+				return bytesRead, &cbg.DecodeError{Type: "EmbeddingStructTwo", Field: "Others", Offset: bytesRead - read, Expected: cbg.MajArray, Found: maj, Err: cbg.ErrUnexpectedType}
+			}
+			if err := limits.AddItems(extra); err != nil {
+				return bytesRead, &cbg.DecodeError{Type: "EmbeddingStructTwo", Field: "Others", Offset: bytesRead - read, Expected: cbg.MajArray, Found: maj, Err: err}
 			}
 
 			if extra > 0 {
@@ -899,12 +957,12 @@ func (t *EmbeddingStructTwo) UnmarshalCBOR(r io.Reader) (int, error) {
 
 				maj, val, read, err := cbg.CborReadHeaderBuf(br, scratch)
 				if err != nil {
-					return bytesRead, xerrors.Errorf("failed to read uint64 for t.Others slice: %w", err)
+					return bytesRead, cbg.WrapDecodeError(err, "EmbeddingStructTwo", fmt.Sprintf("Others[%d]", i), bytesRead)
 				}
 				bytesRead += read
 
 				if maj != cbg.MajUnsignedInt {
-					return bytesRead, xerrors.Errorf("value read for array t.Others was not a uint, instead got %d", maj)
+					return bytesRead, &cbg.DecodeError{Type: "EmbeddingStructTwo", Field: fmt.Sprintf("Others[%d]", i), Offset: bytesRead - read, Expected: cbg.MajUnsignedInt, Found: maj, Err: cbg.ErrUnexpectedType}
 				}
 
 				t.Others[i] = uint64(val)
@@ -915,16 +973,19 @@ func (t *EmbeddingStructTwo) UnmarshalCBOR(r io.Reader) (int, error) {
 
 			maj, extra, read, err = cbg.CborReadHeaderBuf(br, scratch)
 			if err != nil {
-				return bytesRead, err
+				return bytesRead, cbg.WrapDecodeError(err, "EmbeddingStructTwo", "Numbers", bytesRead)
 			}
 			bytesRead += read
 
 			if extra > cbg.MaxLength {
-				return bytesRead, fmt.Errorf("t.Numbers: array too large (%d)", extra)
+				return bytesRead, &cbg.DecodeError{Type: "EmbeddingStructTwo", Field: "Numbers", Offset: bytesRead - read, Expected: cbg.MajArray, Found: maj, Err: cbg.ErrMaxLength}
 			}
 
 			if maj != cbg.MajArray {
-				return bytesRead, fmt.Errorf("expected cbor array")
+				return bytesRead, &cbg.DecodeError{Type: "EmbeddingStructTwo", Field: "Numbers", Offset: bytesRead - read, Expected: cbg.MajArray, Found: maj, Err: cbg.ErrUnexpectedType}
+			}
+			if err := limits.AddItems(extra); err != nil {
+				return bytesRead, &cbg.DecodeError{Type: "EmbeddingStructTwo", Field: "Numbers", Offset: bytesRead - read, Expected: cbg.MajArray, Found: maj, Err: err}
 			}
 
 			if extra > 0 {
@@ -935,12 +996,12 @@ func (t *EmbeddingStructTwo) UnmarshalCBOR(r io.Reader) (int, error) {
 
 				maj, val, read, err := cbg.CborReadHeaderBuf(br, scratch)
 				if err != nil {
-					return bytesRead, xerrors.Errorf("failed to read uint64 for t.Numbers slice: %w", err)
+					return bytesRead, cbg.WrapDecodeError(err, "EmbeddingStructTwo", fmt.Sprintf("Numbers[%d]", i), bytesRead)
 				}
 				bytesRead += read
 
 				if maj != cbg.MajUnsignedInt {
-					return bytesRead, xerrors.Errorf("value read for array t.Numbers was not a uint, instead got %d", maj)
+					return bytesRead, &cbg.DecodeError{Type: "EmbeddingStructTwo", Field: fmt.Sprintf("Numbers[%d]", i), Offset: bytesRead - read, Expected: cbg.MajUnsignedInt, Found: maj, Err: cbg.ErrUnexpectedType}
 				}
 
 				t.Numbers[i] = testing.NamedNumber(val)
@@ -951,16 +1012,19 @@ func (t *EmbeddingStructTwo) UnmarshalCBOR(r io.Reader) (int, error) {
 
 			maj, extra, read, err = cbg.CborReadHeaderBuf(br, scratch)
 			if err != nil {
-				return bytesRead, err
+				return bytesRead, cbg.WrapDecodeError(err, "EmbeddingStructTwo", "SignedOthers", bytesRead)
 			}
 			bytesRead += read
 
 			if extra > cbg.MaxLength {
-				return bytesRead, fmt.Errorf("t.SignedOthers: array too large (%d)", extra)
+				return bytesRead, &cbg.DecodeError{Type: "EmbeddingStructTwo", Field: "SignedOthers", Offset: bytesRead - read, Expected: cbg.MajArray, Found: maj, Err: cbg.ErrMaxLength}
 			}
 
 			if maj != cbg.MajArray {
-				return bytesRead, fmt.Errorf("expected cbor array")
+				return bytesRead, &cbg.DecodeError{Type: "EmbeddingStructTwo", Field: "SignedOthers", Offset: bytesRead - read, Expected: cbg.MajArray, Found: maj, Err: cbg.ErrUnexpectedType}
+			}
+			if err := limits.AddItems(extra); err != nil {
+				return bytesRead, &cbg.DecodeError{Type: "EmbeddingStructTwo", Field: "SignedOthers", Offset: bytesRead - read, Expected: cbg.MajArray, Found: maj, Err: err}
 			}
 
 			if extra > 0 {
@@ -972,23 +1036,23 @@ func (t *EmbeddingStructTwo) UnmarshalCBOR(r io.Reader) (int, error) {
 					maj, extra, read, err := cbg.CborReadHeaderBuf(br, scratch)
 					var extraI int64
 					if err != nil {
-						return bytesRead, err
+						return bytesRead, cbg.WrapDecodeError(err, "EmbeddingStructTwo", fmt.Sprintf("SignedOthers[%d]", i), bytesRead)
 					}
 					bytesRead += read
 					switch maj {
 					case cbg.MajUnsignedInt:
 						extraI = int64(extra)
 						if extraI < 0 {
-							return bytesRead, fmt.Errorf("int64 positive overflow")
+							return bytesRead, &cbg.DecodeError{Type: "EmbeddingStructTwo", Field: fmt.Sprintf("SignedOthers[%d]", i), Offset: bytesRead - read, Expected: maj, Found: maj, Err: cbg.ErrOverflow}
 						}
 					case cbg.MajNegativeInt:
 						extraI = int64(extra)
 						if extraI < 0 {
-							return bytesRead, fmt.Errorf("int64 negative oveflow")
+							return bytesRead, &cbg.DecodeError{Type: "EmbeddingStructTwo", Field: fmt.Sprintf("SignedOthers[%d]", i), Offset: bytesRead - read, Expected: maj, Found: maj, Err: cbg.ErrOverflow}
 						}
 						extraI = -1 - extraI
 					default:
-						return bytesRead, fmt.Errorf("wrong type for int64 field: %d", maj)
+						return bytesRead, &cbg.DecodeError{Type: "EmbeddingStructTwo", Field: fmt.Sprintf("SignedOthers[%d]", i), Offset: bytesRead - read, Expected: cbg.MajUnsignedInt, Found: maj, Err: cbg.ErrUnexpectedType}
 					}
 
 					t.SignedOthers[i] = int64(extraI)
@@ -1001,7 +1065,7 @@ func (t *EmbeddingStructTwo) UnmarshalCBOR(r io.Reader) (int, error) {
 			{
 
 				if read, err := t.SimpleTypeOne.UnmarshalCBOR(br); err != nil {
-					return bytesRead, xerrors.Errorf("unmarshaling t.SimpleTypeOne: %w", err)
+					return bytesRead, cbg.WrapDecodeError(err, "EmbeddingStructTwo", "SimpleTypeOne", bytesRead)
 				} else {
 					bytesRead += read
 				}
@@ -1014,17 +1078,17 @@ func (t *EmbeddingStructTwo) UnmarshalCBOR(r io.Reader) (int, error) {
 
 				b, err := br.ReadByte()
 				if err != nil {
-					return bytesRead, err
+					return bytesRead, cbg.WrapDecodeError(err, "EmbeddingStructTwo", "EmbeddingStructOne", bytesRead)
 				}
 				bytesRead++
 				if b != cbg.CborNull[0] {
 					if err := br.UnreadByte(); err != nil {
-						return bytesRead, err
+						return bytesRead, cbg.WrapDecodeError(err, "EmbeddingStructTwo", "EmbeddingStructOne", bytesRead)
 					}
 					bytesRead--
 					t.EmbeddingStructOne = new(EmbeddingStructOne)
 					if read, err := t.EmbeddingStructOne.UnmarshalCBOR(br); err != nil {
-						return bytesRead, xerrors.Errorf("unmarshaling t.EmbeddingStructOne pointer: %w", err)
+						return bytesRead, cbg.WrapDecodeError(err, "EmbeddingStructTwo", "EmbeddingStructOne", bytesRead)
 					} else {
 						bytesRead += read
 					}
@@ -1034,7 +1098,9 @@ func (t *EmbeddingStructTwo) UnmarshalCBOR(r io.Reader) (int, error) {
 
 		default:
 			// Field doesn't exist on this type, so ignore it
-			if read, err := cbg.ScanForLinks(r, func(cid.Cid) {}); err == nil {
+			if read, err := cbg.ScanForLinks(br, func(cid.Cid) {}); err != nil {
+				return bytesRead, cbg.WrapDecodeError(err, "EmbeddingStructTwo", "", bytesRead)
+			} else {
 				bytesRead += read
 			}
 		}
@@ -1042,6 +1108,31 @@ func (t *EmbeddingStructTwo) UnmarshalCBOR(r io.Reader) (int, error) {
 
 	return bytesRead, nil
 }
+
+func (t *EmbeddingStructTwo) MarshalDAGJSON(w io.Writer) error {
+	return cbg.MarshalDAGJSON(w, t)
+}
+
+func (t *EmbeddingStructTwo) UnmarshalDAGJSON(r io.Reader) error {
+	return cbg.UnmarshalDAGJSON(r, t)
+}
+
+func (t *EmbeddingStructTwo) Links() []cid.Cid {
+	var links []cid.Cid
+	_ = t.ForEachLink(func(_ string, c cid.Cid) error {
+		links = append(links, c)
+		return nil
+	})
+	return links
+}
+
+func (t *EmbeddingStructTwo) ForEachLink(cb func(path string, c cid.Cid) error) error {
+	if t == nil {
+		return nil
+	}
+	return nil
+}
+
 func (t *EmbeddingStructThree) MarshalCBOR(w io.Writer) (n int, err error) {
 	if t == nil {
 		return w.Write(cbg.CborNull)
@@ -1465,6 +1556,11 @@ func (t *EmbeddingStructThree) UnmarshalCBOR(r io.Reader) (int, error) {
 
 	br := cbg.GetPeeker(r)
 	scratch := make([]byte, 8)
+	limits := cbg.GetDecodeLimits(br)
+	if err := limits.Enter(); err != nil {
+		return bytesRead, &cbg.DecodeError{Type: "EmbeddingStructThree", Err: err}
+	}
+	defer limits.Leave()
 
 	maj, extra, read, err := cbg.CborReadHeaderBuf(br, scratch)
 	if err != nil {
@@ -1472,11 +1568,14 @@ func (t *EmbeddingStructThree) UnmarshalCBOR(r io.Reader) (int, error) {
 	}
 	bytesRead += read
 	if maj != cbg.MajMap {
-		return bytesRead, fmt.Errorf("cbor input should be of type map")
+		return bytesRead, &cbg.DecodeError{Type: "EmbeddingStructThree", Expected: cbg.MajMap, Found: maj, Err: cbg.ErrUnexpectedType}
 	}
 
 	if extra > cbg.MaxLength {
-		return bytesRead, fmt.Errorf("EmbeddingStructThree: map struct too large (%d)", extra)
+		return bytesRead, &cbg.DecodeError{Type: "EmbeddingStructThree", Expected: cbg.MajMap, Found: maj, Err: cbg.ErrMaxLength}
+	}
+	if err := limits.AddItems(extra); err != nil {
+		return bytesRead, &cbg.DecodeError{Type: "EmbeddingStructThree", Expected: cbg.MajMap, Found: maj, Err: err}
 	}
 
 	var name string
@@ -1487,7 +1586,7 @@ func (t *EmbeddingStructThree) UnmarshalCBOR(r io.Reader) (int, error) {
 		{
 			sval, read, err := cbg.ReadStringBuf(br, scratch)
 			if err != nil {
-				return bytesRead, err
+				return bytesRead, cbg.WrapDecodeError(err, "EmbeddingStructThree", "", bytesRead)
 			}
 			bytesRead += read
 
@@ -1501,7 +1600,7 @@ func (t *EmbeddingStructThree) UnmarshalCBOR(r io.Reader) (int, error) {
 			{
 				sval, read, err := cbg.ReadStringBuf(br, scratch)
 				if err != nil {
-					return bytesRead, err
+					return bytesRead, cbg.WrapDecodeError(err, "EmbeddingStructThree", "Dog", bytesRead)
 				}
 				bytesRead += read
 
@@ -1513,7 +1612,7 @@ func (t *EmbeddingStructThree) UnmarshalCBOR(r io.Reader) (int, error) {
 			{
 				sval, read, err := cbg.ReadStringBuf(br, scratch)
 				if err != nil {
-					return bytesRead, err
+					return bytesRead, cbg.WrapDecodeError(err, "EmbeddingStructThree", "Foo", bytesRead)
 				}
 				bytesRead += read
 
@@ -1524,16 +1623,19 @@ func (t *EmbeddingStructThree) UnmarshalCBOR(r io.Reader) (int, error) {
 
 			maj, extra, read, err = cbg.CborReadHeaderBuf(br, scratch)
 			if err != nil {
-				return bytesRead, err
+				return bytesRead, cbg.WrapDecodeError(err, "EmbeddingStructThree", "Test", bytesRead)
 			}
 			bytesRead += read
 
 			if extra > cbg.MaxLength {
-				return bytesRead, fmt.Errorf("t.Test: array too large (%d)", extra)
+				return bytesRead, &cbg.DecodeError{Type: "EmbeddingStructThree", Field: "Test", Offset: bytesRead - read, Expected: cbg.MajArray, Found: maj, Err: cbg.ErrMaxLength}
 			}
 
 			if maj != cbg.MajArray {
-				return bytesRead, fmt.Errorf("expected cbor array")
+				return bytesRead, &cbg.DecodeError{Type: "EmbeddingStructThree", Field: "Test", Offset: bytesRead - read, Expected: cbg.MajArray, Found: maj, Err: cbg.ErrUnexpectedType}
+			}
+			if err := limits.AddItems(extra); err != nil {
+				return bytesRead, &cbg.DecodeError{Type: "EmbeddingStructThree", Field: "Test", Offset: bytesRead - read, Expected: cbg.MajArray, Found: maj, Err: err}
 			}
 
 			if extra > 0 {
@@ -1548,15 +1650,18 @@ func (t *EmbeddingStructThree) UnmarshalCBOR(r io.Reader) (int, error) {
 
 					maj, extra, read, err = cbg.CborReadHeaderBuf(br, scratch)
 					if err != nil {
-						return bytesRead, err
+						return bytesRead, cbg.WrapDecodeError(err, "EmbeddingStructThree", fmt.Sprintf("Test[%d]", i), bytesRead)
 					}
 					bytesRead += read
 
 					if extra > cbg.ByteArrayMaxLen {
-						return bytesRead, fmt.Errorf("t.Test[i]: byte array too large (%d)", extra)
+						return bytesRead, &cbg.DecodeError{Type: "EmbeddingStructThree", Field: fmt.Sprintf("Test[%d]", i), Offset: bytesRead - read, Expected: cbg.MajByteString, Found: maj, Err: cbg.ErrMaxLength}
 					}
 					if maj != cbg.MajByteString {
-						return bytesRead, fmt.Errorf("expected byte array")
+						return bytesRead, &cbg.DecodeError{Type: "EmbeddingStructThree", Field: fmt.Sprintf("Test[%d]", i), Offset: bytesRead - read, Expected: cbg.MajByteString, Found: maj, Err: cbg.ErrUnexpectedType}
+					}
+					if err := limits.AddBytes(extra); err != nil {
+						return bytesRead, &cbg.DecodeError{Type: "EmbeddingStructThree", Field: fmt.Sprintf("Test[%d]", i), Offset: bytesRead - read, Expected: cbg.MajByteString, Found: maj, Err: err}
 					}
 
 					if extra > 0 {
@@ -1564,7 +1669,7 @@ func (t *EmbeddingStructThree) UnmarshalCBOR(r io.Reader) (int, error) {
 					}
 
 					if read, err := io.ReadFull(br, t.Test[i][:]); err != nil {
-						return bytesRead, err
+						return bytesRead, cbg.WrapDecodeError(err, "EmbeddingStructThree", fmt.Sprintf("Test[%d]", i), bytesRead)
 					} else {
 						bytesRead += read
 					}
@@ -1578,21 +1683,21 @@ func (t *EmbeddingStructThree) UnmarshalCBOR(r io.Reader) (int, error) {
 
 				b, err := br.ReadByte()
 				if err != nil {
-					return bytesRead, err
+					return bytesRead, cbg.WrapDecodeError(err, "EmbeddingStructThree", "Pizza", bytesRead)
 				}
 				bytesRead++
 				if b != cbg.CborNull[0] {
 					if err := br.UnreadByte(); err != nil {
-						return bytesRead, err
+						return bytesRead, cbg.WrapDecodeError(err, "EmbeddingStructThree", "Pizza", bytesRead)
 					}
 					bytesRead--
 					maj, extra, read, err = cbg.CborReadHeaderBuf(br, scratch)
 					if err != nil {
-						return bytesRead, err
+						return bytesRead, cbg.WrapDecodeError(err, "EmbeddingStructThree", "Pizza", bytesRead)
 					}
 					bytesRead += read
 					if maj != cbg.MajUnsignedInt {
-						return bytesRead, fmt.Errorf("wrong type for uint64 field")
+						return bytesRead, &cbg.DecodeError{Type: "EmbeddingStructThree", Field: "Pizza", Offset: bytesRead - read, Expected: cbg.MajUnsignedInt, Found: maj, Err: cbg.ErrUnexpectedType}
 					}
 					typed := uint64(extra)
 					t.Pizza = &typed
@@ -1606,17 +1711,17 @@ func (t *EmbeddingStructThree) UnmarshalCBOR(r io.Reader) (int, error) {
 
 				b, err := br.ReadByte()
 				if err != nil {
-					return bytesRead, err
+					return bytesRead, cbg.WrapDecodeError(err, "EmbeddingStructThree", "Stuff", bytesRead)
 				}
 				bytesRead++
 				if b != cbg.CborNull[0] {
 					if err := br.UnreadByte(); err != nil {
-						return bytesRead, err
+						return bytesRead, cbg.WrapDecodeError(err, "EmbeddingStructThree", "Stuff", bytesRead)
 					}
 					bytesRead--
 					t.Stuff = new(testing.SimpleTypeTwo)
 					if read, err := t.Stuff.UnmarshalCBOR(br); err != nil {
-						return bytesRead, xerrors.Errorf("unmarshaling t.Stuff pointer: %w", err)
+						return bytesRead, cbg.WrapDecodeError(err, "EmbeddingStructThree", "Stuff", bytesRead)
 					} else {
 						bytesRead += read
 					}
@@ -1630,11 +1735,11 @@ func (t *EmbeddingStructThree) UnmarshalCBOR(r io.Reader) (int, error) {
 
 				maj, extra, read, err = cbg.CborReadHeaderBuf(br, scratch)
 				if err != nil {
-					return bytesRead, err
+					return bytesRead, cbg.WrapDecodeError(err, "EmbeddingStructThree", "Value", bytesRead)
 				}
 				bytesRead += read
 				if maj != cbg.MajUnsignedInt {
-					return bytesRead, fmt.Errorf("wrong type for uint64 field")
+					return bytesRead, &cbg.DecodeError{Type: "EmbeddingStructThree", Field: "Value", Offset: bytesRead - read, Expected: cbg.MajUnsignedInt, Found: maj, Err: cbg.ErrUnexpectedType}
 				}
 				t.Value = uint64(extra)
 
@@ -1644,15 +1749,18 @@ func (t *EmbeddingStructThree) UnmarshalCBOR(r io.Reader) (int, error) {
 
 			maj, extra, read, err = cbg.CborReadHeaderBuf(br, scratch)
 			if err != nil {
-				return bytesRead, err
+				return bytesRead, cbg.WrapDecodeError(err, "EmbeddingStructThree", "Binary", bytesRead)
 			}
 			bytesRead += read
 
 			if extra > cbg.ByteArrayMaxLen {
-				return bytesRead, fmt.Errorf("t.Binary: byte array too large (%d)", extra)
+				return bytesRead, &cbg.DecodeError{Type: "EmbeddingStructThree", Field: "Binary", Offset: bytesRead - read, Expected: cbg.MajByteString, Found: maj, Err: cbg.ErrMaxLength}
 			}
 			if maj != cbg.MajByteString {
-				return bytesRead, fmt.Errorf("expected byte array")
+				return bytesRead, &cbg.DecodeError{Type: "EmbeddingStructThree", Field: "Binary", Offset: bytesRead - read, Expected: cbg.MajByteString, Found: maj, Err: cbg.ErrUnexpectedType}
+			}
+			if err := limits.AddBytes(extra); err != nil {
+				return bytesRead, &cbg.DecodeError{Type: "EmbeddingStructThree", Field: "Binary", Offset: bytesRead - read, Expected: cbg.MajByteString, Found: maj, Err: err}
 			}
 
 			if extra > 0 {
@@ -1660,7 +1768,7 @@ func (t *EmbeddingStructThree) UnmarshalCBOR(r io.Reader) (int, error) {
 			}
 
 			if read, err := io.ReadFull(br, t.Binary[:]); err != nil {
-				return bytesRead, err
+				return bytesRead, cbg.WrapDecodeError(err, "EmbeddingStructThree", "Binary", bytesRead)
 			} else {
 				bytesRead += read
 			}
@@ -1669,16 +1777,19 @@ func (t *EmbeddingStructThree) UnmarshalCBOR(r io.Reader) (int, error) {
 
 			maj, extra, read, err = cbg.CborReadHeaderBuf(br, scratch)
 			if err != nil {
-				return bytesRead, err
+				return bytesRead, cbg.WrapDecodeError(err, "EmbeddingStructThree", "Others", bytesRead)
 			}
 			bytesRead += read
 
 			if extra > cbg.MaxLength {
-				return bytesRead, fmt.Errorf("t.Others: array too large (%d)", extra)
+				return bytesRead, &cbg.DecodeError{Type: "EmbeddingStructThree", Field: "Others", Offset: bytesRead - read, Expected: cbg.MajArray, Found: maj, Err: cbg.ErrMaxLength}
 			}
 
 			if maj != cbg.MajArray {
-				return bytesRead, fmt.Errorf("expected cbor array")
+				return bytesRead, &cbg.DecodeError{Type: "EmbeddingStructThree", Field: "Others", Offset: bytesRead - read, Expected: cbg.MajArray, Found: maj, Err: cbg.ErrUnexpectedType}
+			}
+			if err := limits.AddItems(extra); err != nil {
+				return bytesRead, &cbg.DecodeError{Type: "EmbeddingStructThree", Field: "Others", Offset: bytesRead - read, Expected: cbg.MajArray, Found: maj, Err: err}
 			}
 
 			if extra > 0 {
@@ -1689,12 +1800,12 @@ func (t *EmbeddingStructThree) UnmarshalCBOR(r io.Reader) (int, error) {
 
 				maj, val, read, err := cbg.CborReadHeaderBuf(br, scratch)
 				if err != nil {
-					return bytesRead, xerrors.Errorf("failed to read uint64 for t.Others slice: %w", err)
+					return bytesRead, cbg.WrapDecodeError(err, "EmbeddingStructThree", fmt.Sprintf("Others[%d]", i), bytesRead)
 				}
 				bytesRead += read
 
 				if maj != cbg.MajUnsignedInt {
-					return bytesRead, xerrors.Errorf("value read for array t.Others was not a uint, instead got %d", maj)
+					return bytesRead, &cbg.DecodeError{Type: "EmbeddingStructThree", Field: fmt.Sprintf("Others[%d]", i), Offset: bytesRead - read, Expected: cbg.MajUnsignedInt, Found: maj, Err: cbg.ErrUnexpectedType}
 				}
 
 				t.Others[i] = uint64(val)
@@ -1705,16 +1816,19 @@ func (t *EmbeddingStructThree) UnmarshalCBOR(r io.Reader) (int, error) {
 
 			maj, extra, read, err = cbg.CborReadHeaderBuf(br, scratch)
 			if err != nil {
-				return bytesRead, err
+				return bytesRead, cbg.WrapDecodeError(err, "EmbeddingStructThree", "Numbers", bytesRead)
 			}
 			bytesRead += read
 
 			if extra > cbg.MaxLength {
-				return bytesRead, fmt.Errorf("t.Numbers: array too large (%d)", extra)
+				return bytesRead, &cbg.DecodeError{Type: "EmbeddingStructThree", Field: "Numbers", Offset: bytesRead - read, Expected: cbg.MajArray, Found: maj, Err: cbg.ErrMaxLength}
 			}
 
 			if maj != cbg.MajArray {
-				return bytesRead, fmt.Errorf("expected cbor array")
+				return bytesRead, &cbg.DecodeError{Type: "EmbeddingStructThree", Field: "Numbers", Offset: bytesRead - read, Expected: cbg.MajArray, Found: maj, Err: cbg.ErrUnexpectedType}
+			}
+			if err := limits.AddItems(extra); err != nil {
+				return bytesRead, &cbg.DecodeError{Type: "EmbeddingStructThree", Field: "Numbers", Offset: bytesRead - read, Expected: cbg.MajArray, Found: maj, Err: err}
 			}
 
 			if extra > 0 {
@@ -1725,12 +1839,12 @@ func (t *EmbeddingStructThree) UnmarshalCBOR(r io.Reader) (int, error) {
 
 				maj, val, read, err := cbg.CborReadHeaderBuf(br, scratch)
 				if err != nil {
-					return bytesRead, xerrors.Errorf("failed to read uint64 for t.Numbers slice: %w", err)
+					return bytesRead, cbg.WrapDecodeError(err, "EmbeddingStructThree", fmt.Sprintf("Numbers[%d]", i), bytesRead)
 				}
 				bytesRead += read
 
 				if maj != cbg.MajUnsignedInt {
-					return bytesRead, xerrors.Errorf("value read for array t.Numbers was not a uint, instead got %d", maj)
+					return bytesRead, &cbg.DecodeError{Type: "EmbeddingStructThree", Field: fmt.Sprintf("Numbers[%d]", i), Offset: bytesRead - read, Expected: cbg.MajUnsignedInt, Found: maj, Err: cbg.ErrUnexpectedType}
 				}
 
 				t.Numbers[i] = testing.NamedNumber(val)
@@ -1741,20 +1855,23 @@ func (t *EmbeddingStructThree) UnmarshalCBOR(r io.Reader) (int, error) {
 
 			maj, extra, read, err = cbg.CborReadHeaderBuf(br, scratch)
 			if err != nil {
-				return bytesRead, err
+				return bytesRead, cbg.WrapDecodeError(err, "EmbeddingStructThree", "Arrrrrghay", bytesRead)
 			}
 			bytesRead += read
 
 			if extra > cbg.MaxLength {
-				return bytesRead, fmt.Errorf("t.Arrrrrghay: array too large (%d)", extra)
+				return bytesRead, &cbg.DecodeError{Type: "EmbeddingStructThree", Field: "Arrrrrghay", Offset: bytesRead - read, Expected: cbg.MajArray, Found: maj, Err: cbg.ErrMaxLength}
 			}
 
 			if maj != cbg.MajArray {
-				return bytesRead, fmt.Errorf("expected cbor array")
+				return bytesRead, &cbg.DecodeError{Type: "EmbeddingStructThree", Field: "Arrrrrghay", Offset: bytesRead - read, Expected: cbg.MajArray, Found: maj, Err: cbg.ErrUnexpectedType}
+			}
+			if err := limits.AddItems(extra); err != nil {
+				return bytesRead, &cbg.DecodeError{Type: "EmbeddingStructThree", Field: "Arrrrrghay", Offset: bytesRead - read, Expected: cbg.MajArray, Found: maj, Err: err}
 			}
 
 			if extra != 3 {
-				return bytesRead, fmt.Errorf("expected array to have 3 elements")
+				return bytesRead, &cbg.DecodeError{Type: "EmbeddingStructThree", Field: "Arrrrrghay", Offset: bytesRead - read, Expected: cbg.MajArray, Found: maj, Err: fmt.Errorf("expected 3 elements, got %d", extra)}
 			}
 
 			t.Arrrrrghay = [3]testing.SimpleTypeOne{}
@@ -1763,7 +1880,7 @@ func (t *EmbeddingStructThree) UnmarshalCBOR(r io.Reader) (int, error) {
 
 				var v testing.SimpleTypeOne
 				if read, err := v.UnmarshalCBOR(br); err != nil {
-					return bytesRead, err
+					return bytesRead, cbg.WrapDecodeError(err, "EmbeddingStructThree", fmt.Sprintf("Arrrrrghay[%d]", i), bytesRead)
 				} else {
 					bytesRead += read
 				}
@@ -1778,21 +1895,21 @@ func (t *EmbeddingStructThree) UnmarshalCBOR(r io.Reader) (int, error) {
 
 				b, err := br.ReadByte()
 				if err != nil {
-					return bytesRead, err
+					return bytesRead, cbg.WrapDecodeError(err, "EmbeddingStructThree", "PointyPizza", bytesRead)
 				}
 				bytesRead++
 				if b != cbg.CborNull[0] {
 					if err := br.UnreadByte(); err != nil {
-						return bytesRead, err
+						return bytesRead, cbg.WrapDecodeError(err, "EmbeddingStructThree", "PointyPizza", bytesRead)
 					}
 					bytesRead--
 					maj, extra, read, err = cbg.CborReadHeaderBuf(br, scratch)
 					if err != nil {
-						return bytesRead, err
+						return bytesRead, cbg.WrapDecodeError(err, "EmbeddingStructThree", "PointyPizza", bytesRead)
 					}
 					bytesRead += read
 					if maj != cbg.MajUnsignedInt {
-						return bytesRead, fmt.Errorf("wrong type for uint64 field")
+						return bytesRead, &cbg.DecodeError{Type: "EmbeddingStructThree", Field: "PointyPizza", Offset: bytesRead - read, Expected: cbg.MajUnsignedInt, Found: maj, Err: cbg.ErrUnexpectedType}
 					}
 					typed := testing.NamedNumber(extra)
 					t.PointyPizza = &typed
@@ -1804,16 +1921,19 @@ func (t *EmbeddingStructThree) UnmarshalCBOR(r io.Reader) (int, error) {
 
 			maj, extra, read, err = cbg.CborReadHeaderBuf(br, scratch)
 			if err != nil {
-				return bytesRead, err
+				return bytesRead, cbg.WrapDecodeError(err, "EmbeddingStructThree", "SignedOthers", bytesRead)
 			}
 			bytesRead += read
 
 			if extra > cbg.MaxLength {
-				return bytesRead, fmt.Errorf("t.SignedOthers: array too large (%d)", extra)
+				return bytesRead, &cbg.DecodeError{Type: "EmbeddingStructThree", Field: "SignedOthers", Offset: bytesRead - read, Expected: cbg.MajArray, Found: maj, Err: cbg.ErrMaxLength}
 			}
 
 			if maj != cbg.MajArray {
-				return bytesRead, fmt.Errorf("expected cbor array")
+				return bytesRead, &cbg.DecodeError{Type: "EmbeddingStructThree", Field: "SignedOthers", Offset: bytesRead - read, Expected: cbg.MajArray, Found: maj, Err: cbg.ErrUnexpectedType}
+			}
+			if err := limits.AddItems(extra); err != nil {
+				return bytesRead, &cbg.DecodeError{Type: "EmbeddingStructThree", Field: "SignedOthers", Offset: bytesRead - read, Expected: cbg.MajArray, Found: maj, Err: err}
 			}
 
 			if extra > 0 {
@@ -1825,23 +1945,23 @@ func (t *EmbeddingStructThree) UnmarshalCBOR(r io.Reader) (int, error) {
 					maj, extra, read, err := cbg.CborReadHeaderBuf(br, scratch)
 					var extraI int64
 					if err != nil {
-						return bytesRead, err
+						return bytesRead, cbg.WrapDecodeError(err, "EmbeddingStructThree", fmt.Sprintf("SignedOthers[%d]", i), bytesRead)
 					}
 					bytesRead += read
 					switch maj {
 					case cbg.MajUnsignedInt:
 						extraI = int64(extra)
 						if extraI < 0 {
-							return bytesRead, fmt.Errorf("int64 positive overflow")
+							return bytesRead, &cbg.DecodeError{Type: "EmbeddingStructThree", Field: fmt.Sprintf("SignedOthers[%d]", i), Offset: bytesRead - read, Expected: maj, Found: maj, Err: cbg.ErrOverflow}
 						}
 					case cbg.MajNegativeInt:
 						extraI = int64(extra)
 						if extraI < 0 {
-							return bytesRead, fmt.Errorf("int64 negative oveflow")
+							return bytesRead, &cbg.DecodeError{Type: "EmbeddingStructThree", Field: fmt.Sprintf("SignedOthers[%d]", i), Offset: bytesRead - read, Expected: maj, Found: maj, Err: cbg.ErrOverflow}
 						}
 						extraI = -1 - extraI
 					default:
-						return bytesRead, fmt.Errorf("wrong type for int64 field: %d", maj)
+						return bytesRead, &cbg.DecodeError{Type: "EmbeddingStructThree", Field: fmt.Sprintf("SignedOthers[%d]", i), Offset: bytesRead - read, Expected: cbg.MajUnsignedInt, Found: maj, Err: cbg.ErrUnexpectedType}
 					}
 
 					t.SignedOthers[i] = int64(extraI)
@@ -1855,17 +1975,17 @@ func (t *EmbeddingStructThree) UnmarshalCBOR(r io.Reader) (int, error) {
 
 				b, err := br.ReadByte()
 				if err != nil {
-					return bytesRead, err
+					return bytesRead, cbg.WrapDecodeError(err, "EmbeddingStructThree", "EmbeddingStructTwo", bytesRead)
 				}
 				bytesRead++
 				if b != cbg.CborNull[0] {
 					if err := br.UnreadByte(); err != nil {
-						return bytesRead, err
+						return bytesRead, cbg.WrapDecodeError(err, "EmbeddingStructThree", "EmbeddingStructTwo", bytesRead)
 					}
 					bytesRead--
 					t.EmbeddingStructTwo = new(EmbeddingStructTwo)
 					if read, err := t.EmbeddingStructTwo.UnmarshalCBOR(br); err != nil {
-						return bytesRead, xerrors.Errorf("unmarshaling t.EmbeddingStructTwo pointer: %w", err)
+						return bytesRead, cbg.WrapDecodeError(err, "EmbeddingStructThree", "EmbeddingStructTwo", bytesRead)
 					} else {
 						bytesRead += read
 					}
@@ -1875,7 +1995,9 @@ func (t *EmbeddingStructThree) UnmarshalCBOR(r io.Reader) (int, error) {
 
 		default:
 			// Field doesn't exist on this type, so ignore it
-			if read, err := cbg.ScanForLinks(r, func(cid.Cid) {}); err == nil {
+			if read, err := cbg.ScanForLinks(br, func(cid.Cid) {}); err != nil {
+				return bytesRead, cbg.WrapDecodeError(err, "EmbeddingStructThree", "", bytesRead)
+			} else {
 				bytesRead += read
 			}
 		}
@@ -1883,3 +2005,27 @@ func (t *EmbeddingStructThree) UnmarshalCBOR(r io.Reader) (int, error) {
 
 	return bytesRead, nil
 }
+
+func (t *EmbeddingStructThree) MarshalDAGJSON(w io.Writer) error {
+	return cbg.MarshalDAGJSON(w, t)
+}
+
+func (t *EmbeddingStructThree) UnmarshalDAGJSON(r io.Reader) error {
+	return cbg.UnmarshalDAGJSON(r, t)
+}
+
+func (t *EmbeddingStructThree) Links() []cid.Cid {
+	var links []cid.Cid
+	_ = t.ForEachLink(func(_ string, c cid.Cid) error {
+		links = append(links, c)
+		return nil
+	})
+	return links
+}
+
+func (t *EmbeddingStructThree) ForEachLink(cb func(path string, c cid.Cid) error) error {
+	if t == nil {
+		return nil
+	}
+	return nil
+}
@@ -122,6 +122,11 @@ func (t *EmbeddingStructOne) UnmarshalCBOR(r io.Reader) (int, error) {
 
 	br := cbg.GetPeeker(r)
 	scratch := make([]byte, 8)
+	limits := cbg.GetDecodeLimits(br)
+	if err := limits.Enter(); err != nil {
+		return bytesRead, &cbg.DecodeError{Type: "EmbeddingStructOne", Err: err}
+	}
+	defer limits.Leave()
 
 	maj, extra, read, err := cbg.CborReadHeaderBuf(br, scratch)
 	if err != nil {
@@ -129,11 +134,14 @@ func (t *EmbeddingStructOne) UnmarshalCBOR(r io.Reader) (int, error) {
 	}
 	bytesRead += read
 	if maj != cbg.MajArray {
-		return bytesRead, fmt.Errorf("cbor input should be of type array")
+		return bytesRead, &cbg.DecodeError{Type: "EmbeddingStructOne", Expected: cbg.MajArray, Found: maj, Err: cbg.ErrUnexpectedType}
 	}
 
 	if extra != 6 {
-		return bytesRead, fmt.Errorf("cbor input had wrong number of fields")
+		return bytesRead, &cbg.DecodeError{Type: "EmbeddingStructOne", Expected: cbg.MajArray, Found: maj, Err: fmt.Errorf("cbor input had wrong number of fields (%d)", extra)}
+	}
+	if err := limits.AddItems(extra); err != nil {
+		return bytesRead, &cbg.DecodeError{Type: "EmbeddingStructOne", Expected: cbg.MajArray, Found: maj, Err: err}
 	}
 
 	// t.SimpleTypeOne (testing.SimpleTypeOne) (struct)
@@ -141,7 +149,7 @@ func (t *EmbeddingStructOne) UnmarshalCBOR(r io.Reader) (int, error) {
 	{
 
 		if read, err := t.SimpleTypeOne.UnmarshalCBOR(br); err != nil {
-			return bytesRead, xerrors.Errorf("unmarshaling t.SimpleTypeOne: %w", err)
+			return bytesRead, cbg.WrapDecodeError(err, "EmbeddingStructOne", "SimpleTypeOne", bytesRead)
 		} else {
 			bytesRead += read
 		}
@@ -153,17 +161,17 @@ func (t *EmbeddingStructOne) UnmarshalCBOR(r io.Reader) (int, error) {
 
 		b, err := br.ReadByte()
 		if err != nil {
-			return bytesRead, err
+			return bytesRead, cbg.WrapDecodeError(err, "EmbeddingStructOne", "SimpleTypeTwo", bytesRead)
 		}
 		bytesRead++
 		if b != cbg.CborNull[0] {
 			if err := br.UnreadByte(); err != nil {
-				return bytesRead, err
+				return bytesRead, cbg.WrapDecodeError(err, "EmbeddingStructOne", "SimpleTypeTwo", bytesRead)
 			}
 			bytesRead--
 			t.SimpleTypeTwo = new(testing.SimpleTypeTwo)
 			if read, err := t.SimpleTypeTwo.UnmarshalCBOR(br); err != nil {
-				return bytesRead, xerrors.Errorf("unmarshaling t.SimpleTypeTwo pointer: %w", err)
+				return bytesRead, cbg.WrapDecodeError(err, "EmbeddingStructOne", "SimpleTypeTwo", bytesRead)
 			} else {
 				bytesRead += read
 			}
@@ -175,7 +183,7 @@ func (t *EmbeddingStructOne) UnmarshalCBOR(r io.Reader) (int, error) {
 	{
 		sval, read, err := cbg.ReadStringBuf(br, scratch)
 		if err != nil {
-			return bytesRead, err
+			return bytesRead, cbg.WrapDecodeError(err, "EmbeddingStructOne", "Foo", bytesRead)
 		}
 		bytesRead += read
 
@@ -187,17 +195,17 @@ func (t *EmbeddingStructOne) UnmarshalCBOR(r io.Reader) (int, error) {
 
 		b, err := br.ReadByte()
 		if err != nil {
-			return bytesRead, err
+			return bytesRead, cbg.WrapDecodeError(err, "EmbeddingStructOne", "Stuff", bytesRead)
 		}
 		bytesRead++
 		if b != cbg.CborNull[0] {
 			if err := br.UnreadByte(); err != nil {
-				return bytesRead, err
+				return bytesRead, cbg.WrapDecodeError(err, "EmbeddingStructOne", "Stuff", bytesRead)
 			}
 			bytesRead--
 			t.Stuff = new(testing.SimpleTypeTwo)
 			if read, err := t.Stuff.UnmarshalCBOR(br); err != nil {
-				return bytesRead, xerrors.Errorf("unmarshaling t.Stuff pointer: %w", err)
+				return bytesRead, cbg.WrapDecodeError(err, "EmbeddingStructOne", "Stuff", bytesRead)
 			} else {
 				bytesRead += read
 			}
@@ -208,16 +216,19 @@ func (t *EmbeddingStructOne) UnmarshalCBOR(r io.Reader) (int, error) {
 
 	maj, extra, read, err = cbg.CborReadHeaderBuf(br, scratch)
 	if err != nil {
-		return bytesRead, err
+		return bytesRead, cbg.WrapDecodeError(err, "EmbeddingStructOne", "Others", bytesRead)
 	}
 	bytesRead += read
 
 	if extra > cbg.MaxLength {
-		return bytesRead, fmt.Errorf("t.Others: array too large (%d)", extra)
+		return bytesRead, &cbg.DecodeError{Type: "EmbeddingStructOne", Field: "Others", Offset: bytesRead - read, Expected: cbg.MajArray, Found: maj, Err: cbg.ErrMaxLength}
 	}
 
 	if maj != cbg.MajArray {
-		return bytesRead, fmt.Errorf("expected cbor array")
+		return bytesRead, &cbg.DecodeError{Type: "EmbeddingStructOne", Field: "Others", Offset: bytesRead - read, Expected: cbg.MajArray, Found: maj, Err: cbg.ErrUnexpectedType}
+	}
+	if err := limits.AddItems(extra); err != nil {
+		return bytesRead, &cbg.DecodeError{Type: "EmbeddingStructOne", Field: "Others", Offset: bytesRead - read, Expected: cbg.MajArray, Found: maj, Err: err}
 	}
 
 	if extra > 0 {
@@ -228,12 +239,12 @@ func (t *EmbeddingStructOne) UnmarshalCBOR(r io.Reader) (int, error) {
 
 		maj, val, read, err := cbg.CborReadHeaderBuf(br, scratch)
 		if err != nil {
-			return bytesRead, xerrors.Errorf("failed to read uint64 for t.Others slice: %w", err)
+			return bytesRead, cbg.WrapDecodeError(err, "EmbeddingStructOne", fmt.Sprintf("Others[%d]", i), bytesRead)
 		}
 		bytesRead += read
 
 		if maj != cbg.MajUnsignedInt {
-			return bytesRead, xerrors.Errorf("value read for array t.Others was not a uint, instead got %d", maj)
+			return bytesRead, &cbg.DecodeError{Type: "EmbeddingStructOne", Field: fmt.Sprintf("Others[%d]", i), Offset: bytesRead - read, Expected: cbg.MajUnsignedInt, Found: maj, Err: cbg.ErrUnexpectedType}
 		}
 
 		t.Others[i] = uint64(val)
@@ -243,16 +254,19 @@ func (t *EmbeddingStructOne) UnmarshalCBOR(r io.Reader) (int, error) {
 
 	maj, extra, read, err = cbg.CborReadHeaderBuf(br, scratch)
 	if err != nil {
-		return bytesRead, err
+		return bytesRead, cbg.WrapDecodeError(err, "EmbeddingStructOne", "SignedOthers", bytesRead)
 	}
 	bytesRead += read
 
 	if extra > cbg.MaxLength {
-		return bytesRead, fmt.Errorf("t.SignedOthers: array too large (%d)", extra)
+		return bytesRead, &cbg.DecodeError{Type: "EmbeddingStructOne", Field: "SignedOthers", Offset: bytesRead - read, Expected: cbg.MajArray, Found: maj, Err: cbg.ErrMaxLength}
 	}
 
 	if maj != cbg.MajArray {
-		return bytesRead, fmt.Errorf("expected cbor array")
+		return bytesRead, &cbg.DecodeError{Type: "EmbeddingStructOne", Field: "SignedOthers", Offset: bytesRead - read, Expected: cbg.MajArray, Found: maj, Err: cbg.ErrUnexpectedType}
+	}
+	if err := limits.AddItems(extra); err != nil {
+		return bytesRead, &cbg.DecodeError{Type: "EmbeddingStructOne", Field: "SignedOthers", Offset: bytesRead - read, Expected: cbg.MajArray, Found: maj, Err: err}
 	}
 
 	if extra > 0 {
@@ -264,23 +278,23 @@ func (t *EmbeddingStructOne) UnmarshalCBOR(r io.Reader) (int, error) {
 			maj, extra, read, err := cbg.CborReadHeaderBuf(br, scratch)
 			var extraI int64
 			if err != nil {
-				return bytesRead, err
+				return bytesRead, cbg.WrapDecodeError(err, "EmbeddingStructOne", fmt.Sprintf("SignedOthers[%d]", i), bytesRead)
 			}
 			bytesRead += read
 			switch maj {
 			case cbg.MajUnsignedInt:
 				extraI = int64(extra)
 				if extraI < 0 {
-					return bytesRead, fmt.Errorf("int64 positive overflow")
+					return bytesRead, &cbg.DecodeError{Type: "EmbeddingStructOne", Field: fmt.Sprintf("SignedOthers[%d]", i), Offset: bytesRead - read, Expected: maj, Found: maj, Err: cbg.ErrOverflow}
 				}
 			case cbg.MajNegativeInt:
 				extraI = int64(extra)
 				if extraI < 0 {
-					return bytesRead, fmt.Errorf("int64 negative oveflow")
+					return bytesRead, &cbg.DecodeError{Type: "EmbeddingStructOne", Field: fmt.Sprintf("SignedOthers[%d]", i), Offset: bytesRead - read, Expected: maj, Found: maj, Err: cbg.ErrOverflow}
 				}
 				extraI = -1 - extraI
 			default:
-				return bytesRead, fmt.Errorf("wrong type for int64 field: %d", maj)
+				return bytesRead, &cbg.DecodeError{Type: "EmbeddingStructOne", Field: fmt.Sprintf("SignedOthers[%d]", i), Offset: bytesRead - read, Expected: cbg.MajUnsignedInt, Found: maj, Err: cbg.ErrUnexpectedType}
 			}
 
 			t.SignedOthers[i] = int64(extraI)
@@ -290,6 +304,30 @@ func (t *EmbeddingStructOne) UnmarshalCBOR(r io.Reader) (int, error) {
 	return bytesRead, nil
 }
 
+func (t *EmbeddingStructOne) MarshalDAGJSON(w io.Writer) error {
+	return cbg.MarshalDAGJSON(w, t)
+}
+
+func (t *EmbeddingStructOne) UnmarshalDAGJSON(r io.Reader) error {
+	return cbg.UnmarshalDAGJSON(r, t)
+}
+
+func (t *EmbeddingStructOne) Links() []cid.Cid {
+	var links []cid.Cid
+	_ = t.ForEachLink(func(_ string, c cid.Cid) error {
+		links = append(links, c)
+		return nil
+	})
+	return links
+}
+
+func (t *EmbeddingStructOne) ForEachLink(cb func(path string, c cid.Cid) error) error {
+	if t == nil {
+		return nil
+	}
+	return nil
+}
+
 var lengthBufEmbeddingStructTwo = []byte{138}
 
 func (t *EmbeddingStructTwo) MarshalCBOR(w io.Writer) (n int, err error) {
@@ -463,6 +501,11 @@ func (t *EmbeddingStructTwo) UnmarshalCBOR(r io.Reader) (int, error) {
 
 	br := cbg.GetPeeker(r)
 	scratch := make([]byte, 8)
+	limits := cbg.GetDecodeLimits(br)
+	if err := limits.Enter(); err != nil {
+		return bytesRead, &cbg.DecodeError{Type: "EmbeddingStructTwo", Err: err}
+	}
+	defer limits.Leave()
 
 	maj, extra, read, err := cbg.CborReadHeaderBuf(br, scratch)
 	if err != nil {
@@ -470,11 +513,14 @@ func (t *EmbeddingStructTwo) UnmarshalCBOR(r io.Reader) (int, error) {
 	}
 	bytesRead += read
 	if maj != cbg.MajArray {
-		return bytesRead, fmt.Errorf("cbor input should be of type array")
+		return bytesRead, &cbg.DecodeError{Type: "EmbeddingStructTwo", Expected: cbg.MajArray, Found: maj, Err: cbg.ErrUnexpectedType}
 	}
 
 	if extra != 10 {
-		return bytesRead, fmt.Errorf("cbor input had wrong number of fields")
+		return bytesRead, &cbg.DecodeError{Type: "EmbeddingStructTwo", Expected: cbg.MajArray, Found: maj, Err: fmt.Errorf("cbor input had wrong number of fields (%d)", extra)}
+	}
+	if err := limits.AddItems(extra); err != nil {
+		return bytesRead, &cbg.DecodeError{Type: "EmbeddingStructTwo", Expected: cbg.MajArray, Found: maj, Err: err}
 	}
 
 	// t.SimpleTypeOne (testing.SimpleTypeOne) (struct)
@@ -482,7 +528,7 @@ func (t *EmbeddingStructTwo) UnmarshalCBOR(r io.Reader) (int, error) {
 	{
 
 		if read, err := t.SimpleTypeOne.UnmarshalCBOR(br); err != nil {
-			return bytesRead, xerrors.Errorf("unmarshaling t.SimpleTypeOne: %w", err)
+			return bytesRead, cbg.WrapDecodeError(err, "EmbeddingStructTwo", "SimpleTypeOne", bytesRead)
 		} else {
 			bytesRead += read
 		}
@@ -494,17 +540,17 @@ func (t *EmbeddingStructTwo) UnmarshalCBOR(r io.Reader) (int, error) {
 
 		b, err := br.ReadByte()
 		if err != nil {
-			return bytesRead, err
+			return bytesRead, cbg.WrapDecodeError(err, "EmbeddingStructTwo", "EmbeddingStructOne", bytesRead)
 		}
 		bytesRead++
 		if b != cbg.CborNull[0] {
 			if err := br.UnreadByte(); err != nil {
-				return bytesRead, err
+				return bytesRead, cbg.WrapDecodeError(err, "EmbeddingStructTwo", "EmbeddingStructOne", bytesRead)
 			}
 			bytesRead--
 			t.EmbeddingStructOne = new(EmbeddingStructOne)
 			if read, err := t.EmbeddingStructOne.UnmarshalCBOR(br); err != nil {
-				return bytesRead, xerrors.Errorf("unmarshaling t.EmbeddingStructOne pointer: %w", err)
+				return bytesRead, cbg.WrapDecodeError(err, "EmbeddingStructTwo", "EmbeddingStructOne", bytesRead)
 			} else {
 				bytesRead += read
 			}
@@ -516,7 +562,7 @@ func (t *EmbeddingStructTwo) UnmarshalCBOR(r io.Reader) (int, error) {
 	{
 		sval, read, err := cbg.ReadStringBuf(br, scratch)
 		if err != nil {
-			return bytesRead, err
+			return bytesRead, cbg.WrapDecodeError(err, "EmbeddingStructTwo", "Foo", bytesRead)
 		}
 		bytesRead += read
 
@@ -528,11 +574,11 @@ func (t *EmbeddingStructTwo) UnmarshalCBOR(r io.Reader) (int, error) {
 
 		maj, extra, read, err = cbg.CborReadHeaderBuf(br, scratch)
 		if err != nil {
-			return bytesRead, err
+			return bytesRead, cbg.WrapDecodeError(err, "EmbeddingStructTwo", "Value", bytesRead)
 		}
 		bytesRead += read
 		if maj != cbg.MajUnsignedInt {
-			return bytesRead, fmt.Errorf("wrong type for uint64 field")
+			return bytesRead, &cbg.DecodeError{Type: "EmbeddingStructTwo", Field: "Value", Offset: bytesRead - read, Expected: cbg.MajUnsignedInt, Found: maj, Err: cbg.ErrUnexpectedType}
 		}
 		t.Value = uint64(extra)
 
@@ -543,17 +589,17 @@ func (t *EmbeddingStructTwo) UnmarshalCBOR(r io.Reader) (int, error) {
 
 		b, err := br.ReadByte()
 		if err != nil {
-			return bytesRead, err
+			return bytesRead, cbg.WrapDecodeError(err, "EmbeddingStructTwo", "Stuff", bytesRead)
 		}
 		bytesRead++
 		if b != cbg.CborNull[0] {
 			if err := br.UnreadByte(); err != nil {
-				return bytesRead, err
+				return bytesRead, cbg.WrapDecodeError(err, "EmbeddingStructTwo", "Stuff", bytesRead)
 			}
 			bytesRead--
 			t.Stuff = new(testing.SimpleTypeTwo)
 			if read, err := t.Stuff.UnmarshalCBOR(br); err != nil {
-				return bytesRead, xerrors.Errorf("unmarshaling t.Stuff pointer: %w", err)
+				return bytesRead, cbg.WrapDecodeError(err, "EmbeddingStructTwo", "Stuff", bytesRead)
 			} else {
 				bytesRead += read
 			}
@@ -564,16 +610,19 @@ func (t *EmbeddingStructTwo) UnmarshalCBOR(r io.Reader) (int, error) {
 
 	maj, extra, read, err = cbg.CborReadHeaderBuf(br, scratch)
 	if err != nil {
-		return bytesRead, err
+		return bytesRead, cbg.WrapDecodeError(err, "EmbeddingStructTwo", "Others", bytesRead)
 	}
 	bytesRead += read
 
 	if extra > cbg.MaxLength {
-		return bytesRead, fmt.Errorf("t.Others: array too large (%d)", extra)
+		return bytesRead, &cbg.DecodeError{Type: "EmbeddingStructTwo", Field: "Others", Offset: bytesRead - read, Expected: cbg.MajArray, Found: maj, Err: cbg.ErrMaxLength}
 	}
 
 	if maj != cbg.MajArray {
-		return bytesRead, fmt.Errorf("expected cbor array")
+		return bytesRead, &cbg.DecodeError{Type: "EmbeddingStructTwo", Field: "Others", Offset: bytesRead - read, Expected: cbg.MajArray, Found: maj, Err: cbg.ErrUnexpectedType}
+	}
+	if err := limits.AddItems(extra); err != nil {
+		return bytesRead, &cbg.DecodeError{Type: "EmbeddingStructTwo", Field: "Others", Offset: bytesRead - read, Expected: cbg.MajArray, Found: maj, Err: err}
 	}
 
 	if extra > 0 {
@@ -584,12 +633,12 @@ func (t *EmbeddingStructTwo) UnmarshalCBOR(r io.Reader) (int, error) {
 
 		maj, val, read, err := cbg.CborReadHeaderBuf(br, scratch)
 		if err != nil {
-			return bytesRead, xerrors.Errorf("failed to read uint64 for t.Others slice: %w", err)
+			return bytesRead, cbg.WrapDecodeError(err, "EmbeddingStructTwo", fmt.Sprintf("Others[%d]", i), bytesRead)
 		}
 		bytesRead += read
 
 		if maj != cbg.MajUnsignedInt {
-			return bytesRead, xerrors.Errorf("value read for array t.Others was not a uint, instead got %d", maj)
+			return bytesRead, &cbg.DecodeError{Type: "EmbeddingStructTwo", Field: fmt.Sprintf("Others[%d]", i), Offset: bytesRead - read, Expected: cbg.MajUnsignedInt, Found: maj, Err: cbg.ErrUnexpectedType}
 		}
 
 		t.Others[i] = uint64(val)
@@ -599,16 +648,19 @@ func (t *EmbeddingStructTwo) UnmarshalCBOR(r io.Reader) (int, error) {
 
 	maj, extra, read, err = cbg.CborReadHeaderBuf(br, scratch)
 	if err != nil {
-		return bytesRead, err
+		return bytesRead, cbg.WrapDecodeError(err, "EmbeddingStructTwo", "SignedOthers", bytesRead)
 	}
 	bytesRead += read
 
 	if extra > cbg.MaxLength {
-		return bytesRead, fmt.Errorf("t.SignedOthers: array too large (%d)", extra)
+		return bytesRead, &cbg.DecodeError{Type: "EmbeddingStructTwo", Field: "SignedOthers", Offset: bytesRead - read, Expected: cbg.MajArray, Found: maj, Err: cbg.ErrMaxLength}
 	}
 
 	if maj != cbg.MajArray {
-		return bytesRead, fmt.Errorf("expected cbor array")
+		return bytesRead, &cbg.DecodeError{Type: "EmbeddingStructTwo", Field: "SignedOthers", Offset: bytesRead - read, Expected: cbg.MajArray, Found: maj, Err: cbg.ErrUnexpectedType}
+	}
+	if err := limits.AddItems(extra); err != nil {
+		return bytesRead, &cbg.DecodeError{Type: "EmbeddingStructTwo", Field: "SignedOthers", Offset: bytesRead - read, Expected: cbg.MajArray, Found: maj, Err: err}
 	}
 
 	if extra > 0 {
@@ -620,23 +672,23 @@ func (t *EmbeddingStructTwo) UnmarshalCBOR(r io.Reader) (int, error) {
 			maj, extra, read, err := cbg.CborReadHeaderBuf(br, scratch)
 			var extraI int64
 			if err != nil {
-				return bytesRead, err
+				return bytesRead, cbg.WrapDecodeError(err, "EmbeddingStructTwo", fmt.Sprintf("SignedOthers[%d]", i), bytesRead)
 			}
 			bytesRead += read
 			switch maj {
 			case cbg.MajUnsignedInt:
 				extraI = int64(extra)
 				if extraI < 0 {
-					return bytesRead, fmt.Errorf("int64 positive overflow")
+					return bytesRead, &cbg.DecodeError{Type: "EmbeddingStructTwo", Field: fmt.Sprintf("SignedOthers[%d]", i), Offset: bytesRead - read, Expected: maj, Found: maj, Err: cbg.ErrOverflow}
 				}
 			case cbg.MajNegativeInt:
 				extraI = int64(extra)
 				if extraI < 0 {
-					return bytesRead, fmt.Errorf("int64 negative oveflow")
+					return bytesRead, &cbg.DecodeError{Type: "EmbeddingStructTwo", Field: fmt.Sprintf("SignedOthers[%d]", i), Offset: bytesRead - read, Expected: maj, Found: maj, Err: cbg.ErrOverflow}
 				}
 				extraI = -1 - extraI
 			default:
-				return bytesRead, fmt.Errorf("wrong type for int64 field: %d", maj)
+				return bytesRead, &cbg.DecodeError{Type: "EmbeddingStructTwo", Field: fmt.Sprintf("SignedOthers[%d]", i), Offset: bytesRead - read, Expected: cbg.MajUnsignedInt, Found: maj, Err: cbg.ErrUnexpectedType}
 			}
 
 			t.SignedOthers[i] = int64(extraI)
@@ -647,16 +699,19 @@ func (t *EmbeddingStructTwo) UnmarshalCBOR(r io.Reader) (int, error) {
 
 	maj, extra, read, err = cbg.CborReadHeaderBuf(br, scratch)
 	if err != nil {
-		return bytesRead, err
+		return bytesRead, cbg.WrapDecodeError(err, "EmbeddingStructTwo", "Test", bytesRead)
 	}
 	bytesRead += read
 
 	if extra > cbg.MaxLength {
-		return bytesRead, fmt.Errorf("t.Test: array too large (%d)", extra)
+		return bytesRead, &cbg.DecodeError{Type: "EmbeddingStructTwo", Field: "Test", Offset: bytesRead - read, Expected: cbg.MajArray, Found: maj, Err: cbg.ErrMaxLength}
 	}
 
 	if maj != cbg.MajArray {
-		return bytesRead, fmt.Errorf("expected cbor array")
+		return bytesRead, &cbg.DecodeError{Type: "EmbeddingStructTwo", Field: "Test", Offset: bytesRead - read, Expected: cbg.MajArray, Found: maj, Err: cbg.ErrUnexpectedType}
+	}
+	if err := limits.AddItems(extra); err != nil {
+		return bytesRead, &cbg.DecodeError{Type: "EmbeddingStructTwo", Field: "Test", Offset: bytesRead - read, Expected: cbg.MajArray, Found: maj, Err: err}
 	}
 
 	if extra > 0 {
@@ -671,15 +726,18 @@ func (t *EmbeddingStructTwo) UnmarshalCBOR(r io.Reader) (int, error) {
 
 			maj, extra, read, err = cbg.CborReadHeaderBuf(br, scratch)
 			if err != nil {
-				return bytesRead, err
+				return bytesRead, cbg.WrapDecodeError(err, "EmbeddingStructTwo", fmt.Sprintf("Test[%d]", i), bytesRead)
 			}
 			bytesRead += read
 
 			if extra > cbg.ByteArrayMaxLen {
-				return bytesRead, fmt.Errorf("t.Test[i]: byte array too large (%d)", extra)
+				return bytesRead, &cbg.DecodeError{Type: "EmbeddingStructTwo", Field: fmt.Sprintf("Test[%d]", i), Offset: bytesRead - read, Expected: cbg.MajByteString, Found: maj, Err: cbg.ErrMaxLength}
 			}
 			if maj != cbg.MajByteString {
-				return bytesRead, fmt.Errorf("expected byte array")
+				return bytesRead, &cbg.DecodeError{Type: "EmbeddingStructTwo", Field: fmt.Sprintf("Test[%d]", i), Offset: bytesRead - read, Expected: cbg.MajByteString, Found: maj, Err: cbg.ErrUnexpectedType}
+			}
+			if err := limits.AddBytes(extra); err != nil {
+				return bytesRead, &cbg.DecodeError{Type: "EmbeddingStructTwo", Field: fmt.Sprintf("Test[%d]", i), Offset: bytesRead - read, Expected: cbg.MajByteString, Found: maj, Err: err}
 			}
 
 			if extra > 0 {
@@ -687,7 +745,7 @@ func (t *EmbeddingStructTwo) UnmarshalCBOR(r io.Reader) (int, error) {
 			}
 
 			if read, err := io.ReadFull(br, t.Test[i][:]); err != nil {
-				return bytesRead, err
+				return bytesRead, cbg.WrapDecodeError(err, "EmbeddingStructTwo", fmt.Sprintf("Test[%d]", i), bytesRead)
 			} else {
 				bytesRead += read
 			}
@@ -699,7 +757,7 @@ func (t *EmbeddingStructTwo) UnmarshalCBOR(r io.Reader) (int, error) {
 	{
 		sval, read, err := cbg.ReadStringBuf(br, scratch)
 		if err != nil {
-			return bytesRead, err
+			return bytesRead, cbg.WrapDecodeError(err, "EmbeddingStructTwo", "Dog", bytesRead)
 		}
 		bytesRead += read
 
@@ -709,16 +767,19 @@ func (t *EmbeddingStructTwo) UnmarshalCBOR(r io.Reader) (int, error) {
 
 	maj, extra, read, err = cbg.CborReadHeaderBuf(br, scratch)
 	if err != nil {
-		return bytesRead, err
+		return bytesRead, cbg.WrapDecodeError(err, "EmbeddingStructTwo", "Numbers", bytesRead)
 	}
 	bytesRead += read
 
 	if extra > cbg.MaxLength {
-		return bytesRead, fmt.Errorf("t.Numbers: array too large (%d)", extra)
+		return bytesRead, &cbg.DecodeError{Type: "EmbeddingStructTwo", Field: "Numbers", Offset: bytesRead - read, Expected: cbg.MajArray, Found: maj, Err: cbg.ErrMaxLength}
 	}
 
 	if maj != cbg.MajArray {
-		return bytesRead, fmt.Errorf("expected cbor array")
+		return bytesRead, &cbg.DecodeError{Type: "EmbeddingStructTwo", Field: "Numbers", Offset: bytesRead - read, Expected: cbg.MajArray, Found: maj, Err: cbg.ErrUnexpectedType}
+	}
+	if err := limits.AddItems(extra); err != nil {
+		return bytesRead, &cbg.DecodeError{Type: "EmbeddingStructTwo", Field: "Numbers", Offset: bytesRead - read, Expected: cbg.MajArray, Found: maj, Err: err}
 	}
 
 	if extra > 0 {
@@ -729,12 +790,12 @@ func (t *EmbeddingStructTwo) UnmarshalCBOR(r io.Reader) (int, error) {
 
 		maj, val, read, err := cbg.CborReadHeaderBuf(br, scratch)
 		if err != nil {
-			return bytesRead, xerrors.Errorf("failed to read uint64 for t.Numbers slice: %w", err)
+			return bytesRead, cbg.WrapDecodeError(err, "EmbeddingStructTwo", fmt.Sprintf("Numbers[%d]", i), bytesRead)
 		}
 		bytesRead += read
 
 		if maj != cbg.MajUnsignedInt {
-			return bytesRead, xerrors.Errorf("value read for array t.Numbers was not a uint, instead got %d", maj)
+			return bytesRead, &cbg.DecodeError{Type: "EmbeddingStructTwo", Field: fmt.Sprintf("Numbers[%d]", i), Offset: bytesRead - read, Expected: cbg.MajUnsignedInt, Found: maj, Err: cbg.ErrUnexpectedType}
 		}
 
 		t.Numbers[i] = testing.NamedNumber(val)
@@ -743,6 +804,30 @@ func (t *EmbeddingStructTwo) UnmarshalCBOR(r io.Reader) (int, error) {
 	return bytesRead, nil
 }
 
+func (t *EmbeddingStructTwo) MarshalDAGJSON(w io.Writer) error {
+	return cbg.MarshalDAGJSON(w, t)
+}
+
+func (t *EmbeddingStructTwo) UnmarshalDAGJSON(r io.Reader) error {
+	return cbg.UnmarshalDAGJSON(r, t)
+}
+
+func (t *EmbeddingStructTwo) Links() []cid.Cid {
+	var links []cid.Cid
+	_ = t.ForEachLink(func(_ string, c cid.Cid) error {
+		links = append(links, c)
+		return nil
+	})
+	return links
+}
+
+func (t *EmbeddingStructTwo) ForEachLink(cb func(path string, c cid.Cid) error) error {
+	if t == nil {
+		return nil
+	}
+	return nil
+}
+
 var lengthBufEmbeddingStructThree = []byte{141}
 
 func (t *EmbeddingStructThree) MarshalCBOR(w io.Writer) (n int, err error) {
@@ -976,6 +1061,11 @@ func (t *EmbeddingStructThree) UnmarshalCBOR(r io.Reader) (int, error) {
 
 	br := cbg.GetPeeker(r)
 	scratch := make([]byte, 8)
+	limits := cbg.GetDecodeLimits(br)
+	if err := limits.Enter(); err != nil {
+		return bytesRead, &cbg.DecodeError{Type: "EmbeddingStructThree", Err: err}
+	}
+	defer limits.Leave()
 
 	maj, extra, read, err := cbg.CborReadHeaderBuf(br, scratch)
 	if err != nil {
@@ -983,11 +1073,14 @@ func (t *EmbeddingStructThree) UnmarshalCBOR(r io.Reader) (int, error) {
 	}
 	bytesRead += read
 	if maj != cbg.MajArray {
-		return bytesRead, fmt.Errorf("cbor input should be of type array")
+		return bytesRead, &cbg.DecodeError{Type: "EmbeddingStructThree", Expected: cbg.MajArray, Found: maj, Err: cbg.ErrUnexpectedType}
 	}
 
 	if extra != 13 {
-		return bytesRead, fmt.Errorf("cbor input had wrong number of fields")
+		return bytesRead, &cbg.DecodeError{Type: "EmbeddingStructThree", Expected: cbg.MajArray, Found: maj, Err: fmt.Errorf("cbor input had wrong number of fields (%d)", extra)}
+	}
+	if err := limits.AddItems(extra); err != nil {
+		return bytesRead, &cbg.DecodeError{Type: "EmbeddingStructThree", Expected: cbg.MajArray, Found: maj, Err: err}
 	}
 
 	// t.EmbeddingStructTwo (noflatten_tuple.EmbeddingStructTwo) (struct)
@@ -996,17 +1089,17 @@ func (t *EmbeddingStructThree) UnmarshalCBOR(r io.Reader) (int, error) {
 
 		b, err := br.ReadByte()
 		if err != nil {
-			return bytesRead, err
+			return bytesRead, cbg.WrapDecodeError(err, "EmbeddingStructThree", "EmbeddingStructTwo", bytesRead)
 		}
 		bytesRead++
 		if b != cbg.CborNull[0] {
 			if err := br.UnreadByte(); err != nil {
-				return bytesRead, err
+				return bytesRead, cbg.WrapDecodeError(err, "EmbeddingStructThree", "EmbeddingStructTwo", bytesRead)
 			}
 			bytesRead--
 			t.EmbeddingStructTwo = new(EmbeddingStructTwo)
 			if read, err := t.EmbeddingStructTwo.UnmarshalCBOR(br); err != nil {
-				return bytesRead, xerrors.Errorf("unmarshaling t.EmbeddingStructTwo pointer: %w", err)
+				return bytesRead, cbg.WrapDecodeError(err, "EmbeddingStructThree", "EmbeddingStructTwo", bytesRead)
 			} else {
 				bytesRead += read
 			}
@@ -1018,7 +1111,7 @@ func (t *EmbeddingStructThree) UnmarshalCBOR(r io.Reader) (int, error) {
 	{
 		sval, read, err := cbg.ReadStringBuf(br, scratch)
 		if err != nil {
-			return bytesRead, err
+			return bytesRead, cbg.WrapDecodeError(err, "EmbeddingStructThree", "Foo", bytesRead)
 		}
 		bytesRead += read
 
@@ -1030,11 +1123,11 @@ func (t *EmbeddingStructThree) UnmarshalCBOR(r io.Reader) (int, error) {
 
 		maj, extra, read, err = cbg.CborReadHeaderBuf(br, scratch)
 		if err != nil {
-			return bytesRead, err
+			return bytesRead, cbg.WrapDecodeError(err, "EmbeddingStructThree", "Value", bytesRead)
 		}
 		bytesRead += read
 		if maj != cbg.MajUnsignedInt {
-			return bytesRead, fmt.Errorf("wrong type for uint64 field")
+			return bytesRead, &cbg.DecodeError{Type: "EmbeddingStructThree", Field: "Value", Offset: bytesRead - read, Expected: cbg.MajUnsignedInt, Found: maj, Err: cbg.ErrUnexpectedType}
 		}
 		t.Value = uint64(extra)
 
@@ -1043,15 +1136,18 @@ func (t *EmbeddingStructThree) UnmarshalCBOR(r io.Reader) (int, error) {
 
 	maj, extra, read, err = cbg.CborReadHeaderBuf(br, scratch)
 	if err != nil {
-		return bytesRead, err
+		return bytesRead, cbg.WrapDecodeError(err, "EmbeddingStructThree", "Binary", bytesRead)
 	}
 	bytesRead += read
 
 	if extra > cbg.ByteArrayMaxLen {
-		return bytesRead, fmt.Errorf("t.Binary: byte array too large (%d)", extra)
+		return bytesRead, &cbg.DecodeError{Type: "EmbeddingStructThree", Field: "Binary", Offset: bytesRead - read, Expected: cbg.MajByteString, Found: maj, Err: cbg.ErrMaxLength}
 	}
 	if maj != cbg.MajByteString {
-		return bytesRead, fmt.Errorf("expected byte array")
+		return bytesRead, &cbg.DecodeError{Type: "EmbeddingStructThree", Field: "Binary", Offset: bytesRead - read, Expected: cbg.MajByteString, Found: maj, Err: cbg.ErrUnexpectedType}
+	}
+	if err := limits.AddBytes(extra); err != nil {
+		return bytesRead, &cbg.DecodeError{Type: "EmbeddingStructThree", Field: "Binary", Offset: bytesRead - read, Expected: cbg.MajByteString, Found: maj, Err: err}
 	}
 
 	if extra > 0 {
@@ -1059,7 +1155,7 @@ func (t *EmbeddingStructThree) UnmarshalCBOR(r io.Reader) (int, error) {
 	}
 
 	if read, err := io.ReadFull(br, t.Binary[:]); err != nil {
-		return bytesRead, err
+		return bytesRead, cbg.WrapDecodeError(err, "EmbeddingStructThree", "Binary", bytesRead)
 	} else {
 		bytesRead += read
 	}
@@ -1069,17 +1165,17 @@ func (t *EmbeddingStructThree) UnmarshalCBOR(r io.Reader) (int, error) {
 
 		b, err := br.ReadByte()
 		if err != nil {
-			return bytesRead, err
+			return bytesRead, cbg.WrapDecodeError(err, "EmbeddingStructThree", "Stuff", bytesRead)
 		}
 		bytesRead++
 		if b != cbg.CborNull[0] {
 			if err := br.UnreadByte(); err != nil {
-				return bytesRead, err
+				return bytesRead, cbg.WrapDecodeError(err, "EmbeddingStructThree", "Stuff", bytesRead)
 			}
 			bytesRead--
 			t.Stuff = new(testing.SimpleTypeTwo)
 			if read, err := t.Stuff.UnmarshalCBOR(br); err != nil {
-				return bytesRead, xerrors.Errorf("unmarshaling t.Stuff pointer: %w", err)
+				return bytesRead, cbg.WrapDecodeError(err, "EmbeddingStructThree", "Stuff", bytesRead)
 			} else {
 				bytesRead += read
 			}
@@ -1090,16 +1186,19 @@ func (t *EmbeddingStructThree) UnmarshalCBOR(r io.Reader) (int, error) {
 
 	maj, extra, read, err = cbg.CborReadHeaderBuf(br, scratch)
 	if err != nil {
-		return bytesRead, err
+		return bytesRead, cbg.WrapDecodeError(err, "EmbeddingStructThree", "Others", bytesRead)
 	}
 	bytesRead += read
 
 	if extra > cbg.MaxLength {
-		return bytesRead, fmt.Errorf("t.Others: array too large (%d)", extra)
+		return bytesRead, &cbg.DecodeError{Type: "EmbeddingStructThree", Field: "Others", Offset: bytesRead - read, Expected: cbg.MajArray, Found: maj, Err: cbg.ErrMaxLength}
 	}
 
 	if maj != cbg.MajArray {
-		return bytesRead, fmt.Errorf("expected cbor array")
+		return bytesRead, &cbg.DecodeError{Type: "EmbeddingStructThree", Field: "Others", Offset: bytesRead - read, Expected: cbg.MajArray, Found: maj, Err: cbg.ErrUnexpectedType}
+	}
+	if err := limits.AddItems(extra); err != nil {
+		return bytesRead, &cbg.DecodeError{Type: "EmbeddingStructThree", Field: "Others", Offset: bytesRead - read, Expected: cbg.MajArray, Found: maj, Err: err}
 	}
 
 	if extra > 0 {
@@ -1110,12 +1209,12 @@ func (t *EmbeddingStructThree) UnmarshalCBOR(r io.Reader) (int, error) {
 
 		maj, val, read, err := cbg.CborReadHeaderBuf(br, scratch)
 		if err != nil {
-			return bytesRead, xerrors.Errorf("failed to read uint64 for t.Others slice: %w", err)
+			return bytesRead, cbg.WrapDecodeError(err, "EmbeddingStructThree", fmt.Sprintf("Others[%d]", i), bytesRead)
 		}
 		bytesRead += read
 
 		if maj != cbg.MajUnsignedInt {
-			return bytesRead, xerrors.Errorf("value read for array t.Others was not a uint, instead got %d", maj)
+			return bytesRead, &cbg.DecodeError{Type: "EmbeddingStructThree", Field: fmt.Sprintf("Others[%d]", i), Offset: bytesRead - read, Expected: cbg.MajUnsignedInt, Found: maj, Err: cbg.ErrUnexpectedType}
 		}
 
 		t.Others[i] = uint64(val)
@@ -1125,16 +1224,19 @@ func (t *EmbeddingStructThree) UnmarshalCBOR(r io.Reader) (int, error) {
 
 	maj, extra, read, err = cbg.CborReadHeaderBuf(br, scratch)
 	if err != nil {
-		return bytesRead, err
+		return bytesRead, cbg.WrapDecodeError(err, "EmbeddingStructThree", "SignedOthers", bytesRead)
 	}
 	bytesRead += read
 
 	if extra > cbg.MaxLength {
-		return bytesRead, fmt.Errorf("t.SignedOthers: array too large (%d)", extra)
+		return bytesRead, &cbg.DecodeError{Type: "EmbeddingStructThree", Field: "SignedOthers", Offset: bytesRead - read, Expected: cbg.MajArray, Found: maj, Err: cbg.ErrMaxLength}
 	}
 
 	if maj != cbg.MajArray {
-		return bytesRead, fmt.Errorf("expected cbor array")
+		return bytesRead, &cbg.DecodeError{Type: "EmbeddingStructThree", Field: "SignedOthers", Offset: bytesRead - read, Expected: cbg.MajArray, Found: maj, Err: cbg.ErrUnexpectedType}
+	}
+	if err := limits.AddItems(extra); err != nil {
+		return bytesRead, &cbg.DecodeError{Type: "EmbeddingStructThree", Field: "SignedOthers", Offset: bytesRead - read, Expected: cbg.MajArray, Found: maj, Err: err}
 	}
 
 	if extra > 0 {
@@ -1146,23 +1248,23 @@ func (t *EmbeddingStructThree) UnmarshalCBOR(r io.Reader) (int, error) {
 			maj, extra, read, err := cbg.CborReadHeaderBuf(br, scratch)
 			var extraI int64
 			if err != nil {
-				return bytesRead, err
+				return bytesRead, cbg.WrapDecodeError(err, "EmbeddingStructThree", fmt.Sprintf("SignedOthers[%d]", i), bytesRead)
 			}
 			bytesRead += read
 			switch maj {
 			case cbg.MajUnsignedInt:
 				extraI = int64(extra)
 				if extraI < 0 {
-					return bytesRead, fmt.Errorf("int64 positive overflow")
+					return bytesRead, &cbg.DecodeError{Type: "EmbeddingStructThree", Field: fmt.Sprintf("SignedOthers[%d]", i), Offset: bytesRead - read, Expected: maj, Found: maj, Err: cbg.ErrOverflow}
 				}
 			case cbg.MajNegativeInt:
 				extraI = int64(extra)
 				if extraI < 0 {
-					return bytesRead, fmt.Errorf("int64 negative oveflow")
+					return bytesRead, &cbg.DecodeError{Type: "EmbeddingStructThree", Field: fmt.Sprintf("SignedOthers[%d]", i), Offset: bytesRead - read, Expected: maj, Found: maj, Err: cbg.ErrOverflow}
 				}
 				extraI = -1 - extraI
 			default:
-				return bytesRead, fmt.Errorf("wrong type for int64 field: %d", maj)
+				return bytesRead, &cbg.DecodeError{Type: "EmbeddingStructThree", Field: fmt.Sprintf("SignedOthers[%d]", i), Offset: bytesRead - read, Expected: cbg.MajUnsignedInt, Found: maj, Err: cbg.ErrUnexpectedType}
 			}
 
 			t.SignedOthers[i] = int64(extraI)
@@ -1173,16 +1275,19 @@ func (t *EmbeddingStructThree) UnmarshalCBOR(r io.Reader) (int, error) {
 
 	maj, extra, read, err = cbg.CborReadHeaderBuf(br, scratch)
 	if err != nil {
-		return bytesRead, err
+		return bytesRead, cbg.WrapDecodeError(err, "EmbeddingStructThree", "Test", bytesRead)
 	}
 	bytesRead += read
 
 	if extra > cbg.MaxLength {
-		return bytesRead, fmt.Errorf("t.Test: array too large (%d)", extra)
+		return bytesRead, &cbg.DecodeError{Type: "EmbeddingStructThree", Field: "Test", Offset: bytesRead - read, Expected: cbg.MajArray, Found: maj, Err: cbg.ErrMaxLength}
 	}
 
 	if maj != cbg.MajArray {
-		return bytesRead, fmt.Errorf("expected cbor array")
+		return bytesRead, &cbg.DecodeError{Type: "EmbeddingStructThree", Field: "Test", Offset: bytesRead - read, Expected: cbg.MajArray, Found: maj, Err: cbg.ErrUnexpectedType}
+	}
+	if err := limits.AddItems(extra); err != nil {
+		return bytesRead, &cbg.DecodeError{Type: "EmbeddingStructThree", Field: "Test", Offset: bytesRead - read, Expected: cbg.MajArray, Found: maj, Err: err}
 	}
 
 	if extra > 0 {
@@ -1197,15 +1302,18 @@ func (t *EmbeddingStructThree) UnmarshalCBOR(r io.Reader) (int, error) {
 
 			maj, extra, read, err = cbg.CborReadHeaderBuf(br, scratch)
 			if err != nil {
-				return bytesRead, err
+				return bytesRead, cbg.WrapDecodeError(err, "EmbeddingStructThree", fmt.Sprintf("Test[%d]", i), bytesRead)
 			}
 			bytesRead += read
 
 			if extra > cbg.ByteArrayMaxLen {
-				return bytesRead, fmt.Errorf("t.Test[i]: byte array too large (%d)", extra)
+				return bytesRead, &cbg.DecodeError{Type: "EmbeddingStructThree", Field: fmt.Sprintf("Test[%d]", i), Offset: bytesRead - read, Expected: cbg.MajByteString, Found: maj, Err: cbg.ErrMaxLength}
 			}
 			if maj != cbg.MajByteString {
-				return bytesRead, fmt.Errorf("expected byte array")
+				return bytesRead, &cbg.DecodeError{Type: "EmbeddingStructThree", Field: fmt.Sprintf("Test[%d]", i), Offset: bytesRead - read, Expected: cbg.MajByteString, Found: maj, Err: cbg.ErrUnexpectedType}
+			}
+			if err := limits.AddBytes(extra); err != nil {
+				return bytesRead, &cbg.DecodeError{Type: "EmbeddingStructThree", Field: fmt.Sprintf("Test[%d]", i), Offset: bytesRead - read, Expected: cbg.MajByteString, Found: maj, Err: err}
 			}
 
 			if extra > 0 {
@@ -1213,7 +1321,7 @@ func (t *EmbeddingStructThree) UnmarshalCBOR(r io.Reader) (int, error) {
 			}
 
 			if read, err := io.ReadFull(br, t.Test[i][:]); err != nil {
-				return bytesRead, err
+				return bytesRead, cbg.WrapDecodeError(err, "EmbeddingStructThree", fmt.Sprintf("Test[%d]", i), bytesRead)
 			} else {
 				bytesRead += read
 			}
@@ -1225,7 +1333,7 @@ func (t *EmbeddingStructThree) UnmarshalCBOR(r io.Reader) (int, error) {
 	{
 		sval, read, err := cbg.ReadStringBuf(br, scratch)
 		if err != nil {
-			return bytesRead, err
+			return bytesRead, cbg.WrapDecodeError(err, "EmbeddingStructThree", "Dog", bytesRead)
 		}
 		bytesRead += read
 
@@ -1235,16 +1343,19 @@ func (t *EmbeddingStructThree) UnmarshalCBOR(r io.Reader) (int, error) {
 
 	maj, extra, read, err = cbg.CborReadHeaderBuf(br, scratch)
 	if err != nil {
-		return bytesRead, err
+		return bytesRead, cbg.WrapDecodeError(err, "EmbeddingStructThree", "Numbers", bytesRead)
 	}
 	bytesRead += read
 
 	if extra > cbg.MaxLength {
-		return bytesRead, fmt.Errorf("t.Numbers: array too large (%d)", extra)
+		return bytesRead, &cbg.DecodeError{Type: "EmbeddingStructThree", Field: "Numbers", Offset: bytesRead - read, Expected: cbg.MajArray, Found: maj, Err: cbg.ErrMaxLength}
 	}
 
 	if maj != cbg.MajArray {
-		return bytesRead, fmt.Errorf("expected cbor array")
+		return bytesRead, &cbg.DecodeError{Type: "EmbeddingStructThree", Field: "Numbers", Offset: bytesRead - read, Expected: cbg.MajArray, Found: maj, Err: cbg.ErrUnexpectedType}
+	}
+	if err := limits.AddItems(extra); err != nil {
+		return bytesRead, &cbg.DecodeError{Type: "EmbeddingStructThree", Field: "Numbers", Offset: bytesRead - read, Expected: cbg.MajArray, Found: maj, Err: err}
 	}
 
 	if extra > 0 {
@@ -1255,12 +1366,12 @@ func (t *EmbeddingStructThree) UnmarshalCBOR(r io.Reader) (int, error) {
 
 		maj, val, read, err := cbg.CborReadHeaderBuf(br, scratch)
 		if err != nil {
-			return bytesRead, xerrors.Errorf("failed to read uint64 for t.Numbers slice: %w", err)
+			return bytesRead, cbg.WrapDecodeError(err, "EmbeddingStructThree", fmt.Sprintf("Numbers[%d]", i), bytesRead)
 		}
 		bytesRead += read
 
 		if maj != cbg.MajUnsignedInt {
-			return bytesRead, xerrors.Errorf("value read for array t.Numbers was not a uint, instead got %d", maj)
+			return bytesRead, &cbg.DecodeError{Type: "EmbeddingStructThree", Field: fmt.Sprintf("Numbers[%d]", i), Offset: bytesRead - read, Expected: cbg.MajUnsignedInt, Found: maj, Err: cbg.ErrUnexpectedType}
 		}
 
 		t.Numbers[i] = testing.NamedNumber(val)
@@ -1272,21 +1383,21 @@ func (t *EmbeddingStructThree) UnmarshalCBOR(r io.Reader) (int, error) {
 
 		b, err := br.ReadByte()
 		if err != nil {
-			return bytesRead, err
+			return bytesRead, cbg.WrapDecodeError(err, "EmbeddingStructThree", "Pizza", bytesRead)
 		}
 		bytesRead++
 		if b != cbg.CborNull[0] {
 			if err := br.UnreadByte(); err != nil {
-				return bytesRead, err
+				return bytesRead, cbg.WrapDecodeError(err, "EmbeddingStructThree", "Pizza", bytesRead)
 			}
 			bytesRead--
 			maj, extra, read, err = cbg.CborReadHeaderBuf(br, scratch)
 			if err != nil {
-				return bytesRead, err
+				return bytesRead, cbg.WrapDecodeError(err, "EmbeddingStructThree", "Pizza", bytesRead)
 			}
 			bytesRead += read
 			if maj != cbg.MajUnsignedInt {
-				return bytesRead, fmt.Errorf("wrong type for uint64 field")
+				return bytesRead, &cbg.DecodeError{Type: "EmbeddingStructThree", Field: "Pizza", Offset: bytesRead - read, Expected: cbg.MajUnsignedInt, Found: maj, Err: cbg.ErrUnexpectedType}
 			}
 			typed := uint64(extra)
 			t.Pizza = &typed
@@ -1299,21 +1410,21 @@ func (t *EmbeddingStructThree) UnmarshalCBOR(r io.Reader) (int, error) {
 
 		b, err := br.ReadByte()
 		if err != nil {
-			return bytesRead, err
+			return bytesRead, cbg.WrapDecodeError(err, "EmbeddingStructThree", "PointyPizza", bytesRead)
 		}
 		bytesRead++
 		if b != cbg.CborNull[0] {
 			if err := br.UnreadByte(); err != nil {
-				return bytesRead, err
+				return bytesRead, cbg.WrapDecodeError(err, "EmbeddingStructThree", "PointyPizza", bytesRead)
 			}
 			bytesRead--
 			maj, extra, read, err = cbg.CborReadHeaderBuf(br, scratch)
 			if err != nil {
-				return bytesRead, err
+				return bytesRead, cbg.WrapDecodeError(err, "EmbeddingStructThree", "PointyPizza", bytesRead)
 			}
 			bytesRead += read
 			if maj != cbg.MajUnsignedInt {
-				return bytesRead, fmt.Errorf("wrong type for uint64 field")
+				return bytesRead, &cbg.DecodeError{Type: "EmbeddingStructThree", Field: "PointyPizza", Offset: bytesRead - read, Expected: cbg.MajUnsignedInt, Found: maj, Err: cbg.ErrUnexpectedType}
 			}
 			typed := testing.NamedNumber(extra)
 			t.PointyPizza = &typed
@@ -1324,20 +1435,23 @@ func (t *EmbeddingStructThree) UnmarshalCBOR(r io.Reader) (int, error) {
 
 	maj, extra, read, err = cbg.CborReadHeaderBuf(br, scratch)
 	if err != nil {
-		return bytesRead, err
+		return bytesRead, cbg.WrapDecodeError(err, "EmbeddingStructThree", "Arrrrrghay", bytesRead)
 	}
 	bytesRead += read
 
 	if extra > cbg.MaxLength {
-		return bytesRead, fmt.Errorf("t.Arrrrrghay: array too large (%d)", extra)
+		return bytesRead, &cbg.DecodeError{Type: "EmbeddingStructThree", Field: "Arrrrrghay", Offset: bytesRead - read, Expected: cbg.MajArray, Found: maj, Err: cbg.ErrMaxLength}
 	}
 
 	if maj != cbg.MajArray {
-		return bytesRead, fmt.Errorf("expected cbor array")
+		return bytesRead, &cbg.DecodeError{Type: "EmbeddingStructThree", Field: "Arrrrrghay", Offset: bytesRead - read, Expected: cbg.MajArray, Found: maj, Err: cbg.ErrUnexpectedType}
+	}
+	if err := limits.AddItems(extra); err != nil {
+		return bytesRead, &cbg.DecodeError{Type: "EmbeddingStructThree", Field: "Arrrrrghay", Offset: bytesRead - read, Expected: cbg.MajArray, Found: maj, Err: err}
 	}
 
 	if extra != 3 {
-		return bytesRead, fmt.Errorf("expected array to have 3 elements")
+		return bytesRead, &cbg.DecodeError{Type: "EmbeddingStructThree", Field: "Arrrrrghay", Offset: bytesRead - read, Expected: cbg.MajArray, Found: maj, Err: fmt.Errorf("expected 3 elements, got %d", extra)}
 	}
 
 	t.Arrrrrghay = [3]testing.SimpleTypeOne{}
@@ -1346,7 +1460,7 @@ func (t *EmbeddingStructThree) UnmarshalCBOR(r io.Reader) (int, error) {
 
 		var v testing.SimpleTypeOne
 		if read, err := v.UnmarshalCBOR(br); err != nil {
-			return bytesRead, err
+			return bytesRead, cbg.WrapDecodeError(err, "EmbeddingStructThree", fmt.Sprintf("Arrrrrghay[%d]", i), bytesRead)
 		} else {
 			bytesRead += read
 		}
@@ -1356,3 +1470,27 @@ func (t *EmbeddingStructThree) UnmarshalCBOR(r io.Reader) (int, error) {
 
 	return bytesRead, nil
 }
+
+func (t *EmbeddingStructThree) MarshalDAGJSON(w io.Writer) error {
+	return cbg.MarshalDAGJSON(w, t)
+}
+
+func (t *EmbeddingStructThree) UnmarshalDAGJSON(r io.Reader) error {
+	return cbg.UnmarshalDAGJSON(r, t)
+}
+
+func (t *EmbeddingStructThree) Links() []cid.Cid {
+	var links []cid.Cid
+	_ = t.ForEachLink(func(_ string, c cid.Cid) error {
+		links = append(links, c)
+		return nil
+	})
+	return links
+}
+
+func (t *EmbeddingStructThree) ForEachLink(cb func(path string, c cid.Cid) error) error {
+	if t == nil {
+		return nil
+	}
+	return nil
+}
@@ -3,8 +3,13 @@ package testing_test
 import (
 	"bytes"
 	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
 	"math/rand"
 	"reflect"
+	"strings"
 	"testing"
 	"testing/quick"
 	"time"
@@ -16,6 +21,7 @@ import (
 	types "github.com/daotl/cbor-gen/testing"
 	"github.com/daotl/cbor-gen/testing/flatten_map"
 	"github.com/daotl/cbor-gen/testing/flatten_tuple"
+	"github.com/daotl/cbor-gen/testing/ipldschema"
 	"github.com/daotl/cbor-gen/testing/noflatten_map"
 	"github.com/daotl/cbor-gen/testing/noflatten_tuple"
 )
@@ -150,6 +156,25 @@ func TestFlattenEmbeddedStruct(t *testing.T) {
 			t.Fatalf("objects encodings different: %x != %x", enc, flatenc)
 		}
 
+		si := &flatten_tuple.IndexedFlatStruct{}
+		if read, err := si.UnmarshalCBOR(bytes.NewReader(flatenc)); err != nil {
+			t.Logf("got bad bytes: %x", flatenc)
+			t.Fatal("failed to unmarshal object: ", err)
+		} else if read != len(flatenc) {
+			t.Fatalf("wrong bytesRead when unmarhaling: should be %d, actual %d", len(flatenc), read)
+		}
+		buf = new(bytes.Buffer)
+		if n, err := si.MarshalCBOR(buf); err != nil {
+			t.Fatal("i guess its fine to fail marshaling")
+		} else if n != buf.Len() {
+			t.Fatal("returned length does not match the byte length")
+		}
+		enc = buf.Bytes()
+
+		if !bytes.Equal(enc, flatenc) {
+			t.Fatalf("objects encodings different: %x != %x", enc, flatenc)
+		}
+
 		srv := &flatten_tuple.ReorderedEmbedByValueStruct{}
 		if read, err := srv.UnmarshalCBOR(bytes.NewReader(flatenc)); err != nil {
 			t.Logf("got bad bytes: %x", flatenc)
@@ -335,6 +360,25 @@ func TestFixedArrays(t *testing.T) {
 	testValueRoundtrip(t, zero, recepticle, false)
 }
 
+func TestBigIntContainer(t *testing.T) {
+	i, _ := new(big.Int).SetString("123456789012345678901234567890", 10)
+	obj := &types.BigIntHolder{Ints: []types.BigIntContainer{{Int: i}, {Int: big.NewInt(5)}}}
+	nobj := &types.BigIntHolder{}
+	testValueRoundtrip(t, obj, nobj, true)
+	for j, c := range nobj.Ints {
+		if c.Int.Cmp(obj.Ints[j].Int) != 0 {
+			t.Fatalf("expected %s, got %s", obj.Ints[j].Int, c.Int)
+		}
+	}
+
+	// DAG-JSON has no bignums, including in fields of other types.
+	for _, v := range []interface{}{&types.BigIntContainer{}, &types.BigIntHolder{}} {
+		if _, ok := v.(cbg.DAGJSONMarshaler); ok {
+			t.Fatalf("%T holds big ints, which can't be transcoded to DAG-JSON", v)
+		}
+	}
+}
+
 func TestTimeIsh(t *testing.T) {
 	val := &types.ThingWithSomeTime{
 		When:    cbg.CborTime(time.Now()),
@@ -562,3 +606,860 @@ func TestMoreToLessFieldsRoundTrip(t *testing.T) {
 		t.Fatal("mismatch struct marshal / unmarshal")
 	}
 }
+
+func TestDagJSON(t *testing.T) {
+	c, _ := cid.Parse("bafkqaaa")
+	renamed := &types.RenamedFields{Foo: -3, Bar: "bar"}
+	one := &types.SimpleTypeOne{Foo: "foo", Value: 1, Binary: []byte{1, 2}, Signed: -1}
+	v1 := &types.SimpleStructV1{OldStr: "str", OldPtr: &c}
+
+	for _, tc := range []struct {
+		obj      cbg.DAGJSONMarshaler
+		nobj     cbg.DAGJSONUnmarshaler
+		expected string
+	}{
+		{renamed, &types.RenamedFields{}, `{"beep":"bar","foo":-3}`},
+		{one, &types.SimpleTypeOne{}, `["foo",1,{"/":{"bytes":"AQI"}},-1,"",0,0,0,0,0,0]`},
+		{v1, &types.SimpleStructV1{}, `{"OldArray":[],"OldBytes":{"/":{"bytes":""}},"OldMap":{},"OldNum":0,"OldPtr":{"/":"bafkqaaa"},"OldStr":"str","OldStruct":["",0,{"/":{"bytes":""}},0,"",0,0,0,0,0,0]}`},
+	} {
+		buf := new(bytes.Buffer)
+		if err := tc.obj.MarshalDAGJSON(buf); err != nil {
+			t.Fatal(err)
+		}
+		if buf.String() != tc.expected {
+			t.Fatalf("expected %s, got %s", tc.expected, buf.String())
+		}
+
+		if err := tc.nobj.UnmarshalDAGJSON(buf); err != nil {
+			t.Fatal(err)
+		}
+		nbuf := new(bytes.Buffer)
+		if err := tc.nobj.(cbg.DAGJSONMarshaler).MarshalDAGJSON(nbuf); err != nil {
+			t.Fatal(err)
+		}
+		if nbuf.String() != tc.expected {
+			t.Fatalf("expected %s after round trip, got %s", tc.expected, nbuf.String())
+		}
+	}
+
+	// DAG-JSON has no integer map keys, including in fields of other types,
+	// and type arguments may hold some.
+	for _, v := range []interface{}{
+		&types.IntKeyed{},
+		&types.IntKeyedList{},
+		&types.Page[*types.SimpleTypeOne]{},
+		&types.Pair[*types.SimpleTypeOne, *types.SimpleTypeTwo]{},
+	} {
+		if _, ok := v.(cbg.DAGJSONMarshaler); ok {
+			t.Fatalf("%T may hold integer map keys, which can't be transcoded to DAG-JSON", v)
+		}
+	}
+	// Integer aliases are only decoded.
+	if _, ok := interface{}(&types.AliasedFields{}).(cbg.DAGJSONMarshaler); !ok {
+		t.Fatal("types with integer aliases encode text keys, which can be transcoded to DAG-JSON")
+	}
+}
+
+func TestRenamedFieldsOrder(t *testing.T) {
+	// Fields are encoded in the order of their names, not of their map keys,
+	// which existing encodings depend on.
+	buf := new(bytes.Buffer)
+	if _, err := (&types.RenamedFields{Foo: 1, Bar: "x"}).MarshalCBOR(buf); err != nil {
+		t.Fatal(err)
+	}
+	if enc := fmt.Sprintf("%x", buf.Bytes()); enc != "a26462656570617863666f6f01" {
+		t.Fatalf("unexpected encoding %s", enc)
+	}
+}
+
+func TestLinks(t *testing.T) {
+	c1, _ := cid.Parse("bafkqaaa")
+	c2, _ := cid.Parse("bafkqaab")
+	obj := &types.SimpleStructV2{OldPtr: &c1, NewPtr: &c2}
+
+	links := obj.Links()
+	if len(links) != 2 || links[0] != c2 || links[1] != c1 {
+		t.Fatalf("unexpected links %v", links)
+	}
+	if links := (&types.SimpleStructV1{}).Links(); len(links) != 0 {
+		t.Fatalf("unexpected links %v", links)
+	}
+
+	buf := new(bytes.Buffer)
+	if _, err := obj.MarshalCBOR(buf); err != nil {
+		t.Fatal(err)
+	}
+	var paths []string
+	if _, err := cbg.WalkLinks(buf, func(path []string, c cid.Cid) error {
+		if c.Defined() {
+			paths = append(paths, strings.Join(path, "/"))
+		}
+		return nil
+	}); err != nil {
+		t.Fatal(err)
+	}
+	if len(paths) != 2 || paths[0] != "NewPtr" || paths[1] != "OldPtr" {
+		t.Fatalf("unexpected link paths %v", paths)
+	}
+}
+
+func TestForEachLink(t *testing.T) {
+	c1, _ := cid.Parse("bafkqaaa")
+	c2, _ := cid.Parse("bafkqaab")
+	deferred := new(bytes.Buffer)
+	if _, err := (&types.SimpleStructV2{NewPtr: &c1}).MarshalCBOR(deferred); err != nil {
+		t.Fatal(err)
+	}
+	obj := &types.LinkContainer{
+		Link:     c1,
+		Cids:     []cid.Cid{c2, c1},
+		Nested:   &types.SimpleStructV2{OldPtr: &c2},
+		Structs:  []types.SimpleStructV2{{}, {NewPtr: &c1}},
+		Map:      map[string]types.SimpleStructV2{"b": {OldPtr: &c1}, "a": {NewPtr: &c2}},
+		Deferred: &cbg.Deferred{Raw: deferred.Bytes()},
+	}
+
+	var paths []string
+	var links []cid.Cid
+	if err := obj.ForEachLink(func(path string, c cid.Cid) error {
+		paths = append(paths, path)
+		links = append(links, c)
+		return nil
+	}); err != nil {
+		t.Fatal(err)
+	}
+	expected := []string{"0", "2/0", "2/1", "3/OldPtr", "4/1/NewPtr", "5/a/NewPtr", "5/b/OldPtr", "6/NewPtr"}
+	if strings.Join(paths, " ") != strings.Join(expected, " ") {
+		t.Fatalf("unexpected paths %v", paths)
+	}
+	if links[1] != c2 || links[3] != c2 || links[7] != c1 {
+		t.Fatalf("unexpected links %v", links)
+	}
+	if !reflect.DeepEqual(obj.Links(), links) {
+		t.Fatalf("unexpected links %v", obj.Links())
+	}
+
+	// The paths are the same as those found in the encoded object.
+	buf := new(bytes.Buffer)
+	if _, err := obj.MarshalCBOR(buf); err != nil {
+		t.Fatal(err)
+	}
+	var walked []string
+	if _, err := cbg.WalkLinks(buf, func(path []string, c cid.Cid) error {
+		if c.Defined() {
+			walked = append(walked, strings.Join(path, "/"))
+		}
+		return nil
+	}); err != nil {
+		t.Fatal(err)
+	}
+	if strings.Join(walked, " ") != strings.Join(expected, " ") {
+		t.Fatalf("unexpected walked paths %v", walked)
+	}
+
+	// Errors stop the iteration.
+	calls := 0
+	stop := fmt.Errorf("stop")
+	if err := obj.ForEachLink(func(string, cid.Cid) error {
+		calls++
+		return stop
+	}); err != stop || calls != 1 {
+		t.Fatalf("expected the iteration to stop, got %v after %d calls", err, calls)
+	}
+}
+
+func TestForEachLinkDecodeOnly(t *testing.T) {
+	c1, _ := cid.Parse("bafkqaaa")
+	c2, _ := cid.Parse("bafkqaab")
+	obj := &types.MigratingLinks{Name: "a", Prev: c1, Head: c2}
+
+	// Prev isn't encoded, so only Head is reported.
+	var paths []string
+	if err := obj.ForEachLink(func(path string, c cid.Cid) error {
+		if c != c2 {
+			t.Fatalf("unexpected link %s", c)
+		}
+		paths = append(paths, path)
+		return nil
+	}); err != nil {
+		t.Fatal(err)
+	}
+	if strings.Join(paths, " ") != "1" {
+		t.Fatalf("unexpected paths %v", paths)
+	}
+	if links := obj.Links(); len(links) != 1 || links[0] != c2 {
+		t.Fatalf("unexpected links %v", links)
+	}
+
+	buf := new(bytes.Buffer)
+	if _, err := obj.MarshalCBOR(buf); err != nil {
+		t.Fatal(err)
+	}
+	var nobj types.MigratingLinks
+	if _, err := nobj.UnmarshalCBOR(bytes.NewReader(buf.Bytes())); err != nil {
+		t.Fatal(err)
+	}
+	if nobj != (types.MigratingLinks{Name: "a", Head: c2}) {
+		t.Fatalf("unexpected decoded value: %+v", nobj)
+	}
+	var walked []string
+	if _, err := cbg.WalkLinks(buf, func(path []string, c cid.Cid) error {
+		if c.Defined() {
+			walked = append(walked, strings.Join(path, "/"))
+		}
+		return nil
+	}); err != nil {
+		t.Fatal(err)
+	}
+	if strings.Join(walked, " ") != strings.Join(paths, " ") {
+		t.Fatalf("walked paths %v differ from %v", walked, paths)
+	}
+}
+
+func TestLinksThroughNilEmbeds(t *testing.T) {
+	// Link accessors must not initialize the nil embedded struct.
+	obj := &flatten_tuple.EmbedLinksByPointer{Value: 1}
+	if links := obj.Links(); len(links) != 0 {
+		t.Fatalf("unexpected links %v", links)
+	}
+	if err := obj.ForEachLink(func(path string, c cid.Cid) error {
+		return fmt.Errorf("unexpected link %s at %s", c, path)
+	}); err != nil {
+		t.Fatal(err)
+	}
+	if obj.EmbeddedLinks != nil {
+		t.Fatal("link accessors modified the object")
+	}
+
+	c, _ := cid.Parse("bafkqaaa")
+	obj.EmbeddedLinks = &flatten_tuple.EmbeddedLinks{Link: c, Cids: []cid.Cid{c}}
+	var paths []string
+	if err := obj.ForEachLink(func(path string, _ cid.Cid) error {
+		paths = append(paths, path)
+		return nil
+	}); err != nil {
+		t.Fatal(err)
+	}
+	if links := obj.Links(); len(links) != 2 || strings.Join(paths, " ") != "1 2/0" {
+		t.Fatalf("unexpected links %v at %v", links, paths)
+	}
+}
+
+func TestIpldSchemaTypes(t *testing.T) {
+	c, err := cid.Parse("bafkqaaa")
+	if err != nil {
+		t.Fatal(err)
+	}
+	name := "hello"
+	num := int64(-7)
+
+	in := ipldschema.Drawing{
+		Name:     "drawing",
+		Status:   ipldschema.StatusRetired,
+		Priority: ipldschema.PriorityHigh,
+		Shapes: []ipldschema.Shape{
+			{Point: &ipldschema.Point{X: 1, Y: -2}},
+			{Label: &ipldschema.Label{Text: "a", Color: "red"}},
+		},
+		Labels: map[string]ipldschema.Label{"b": {Text: "b"}},
+		Parent: &c,
+		Value:  ipldschema.Value{String: &name},
+		Data:   []byte{1, 2},
+	}
+
+	buf := new(bytes.Buffer)
+	if _, err := in.MarshalCBOR(buf); err != nil {
+		t.Fatal(err)
+	}
+	enc := buf.Bytes()
+
+	var out ipldschema.Drawing
+	if _, err := out.UnmarshalCBOR(bytes.NewReader(enc)); err != nil {
+		t.Fatal(err)
+	}
+	if !out.Parent.Equals(c) || *out.Value.String != name || out.Shapes[1].Label.Color != "red" {
+		t.Fatalf("unexpected decoded value: %+v", out)
+	}
+	buf = new(bytes.Buffer)
+	if _, err := out.MarshalCBOR(buf); err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(buf.Bytes(), enc) {
+		t.Fatal("reencoding gave different bytes")
+	}
+
+	for _, v := range []ipldschema.Value{
+		{Int: &num},
+		{Point: &ipldschema.Point{X: 3}},
+		{Link: &c},
+	} {
+		buf.Reset()
+		if _, err := v.MarshalCBOR(buf); err != nil {
+			t.Fatal(err)
+		}
+		enc := append([]byte(nil), buf.Bytes()...)
+		var out ipldschema.Value
+		if _, err := out.UnmarshalCBOR(bytes.NewReader(enc)); err != nil {
+			t.Fatal(err)
+		}
+		buf.Reset()
+		if _, err := out.MarshalCBOR(buf); err != nil {
+			t.Fatal(err)
+		}
+		if !bytes.Equal(buf.Bytes(), enc) || (v.Int != nil) != (out.Int != nil) ||
+			(v.Point != nil) != (out.Point != nil) || (v.Link != nil) != (out.Link != nil) {
+			t.Fatalf("unexpected decoded value: %+v", out)
+		}
+	}
+
+	shape := ipldschema.Shape{Point: &ipldschema.Point{X: 1, Y: 2}}
+	buf.Reset()
+	if _, err := shape.MarshalCBOR(buf); err != nil {
+		t.Fatal(err)
+	}
+	diag, err := cbg.Diagnose(buf.Bytes())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diag != `{"point": [1, 2]}` {
+		t.Fatalf("unexpected encoding of a keyed union: %s", diag)
+	}
+
+	if _, err := (&ipldschema.Shape{}).MarshalCBOR(buf); err == nil {
+		t.Fatal("expected an error encoding an empty union")
+	}
+	var bad ipldschema.Shape
+	if _, err := bad.UnmarshalCBOR(bytes.NewReader([]byte{0xa1, 0x61, 0x78, 0x01})); err == nil {
+		t.Fatal("expected an error decoding an unknown union member")
+	}
+
+	// Enums only accept their members.
+	for _, d := range []ipldschema.Drawing{
+		{Status: "deleted", Priority: ipldschema.PriorityLow},
+		{Status: ipldschema.StatusActive, Priority: 3},
+	} {
+		d.Shapes, d.Labels, d.Value = in.Shapes, in.Labels, in.Value
+		buf.Reset()
+		if _, err := d.MarshalCBOR(buf); err != nil {
+			t.Fatal(err)
+		}
+		var out ipldschema.Drawing
+		var cerr *cbg.ConstraintError
+		if _, err := out.UnmarshalCBOR(bytes.NewReader(buf.Bytes())); !errors.As(err, &cerr) {
+			t.Fatalf("expected a constraint error decoding an unknown enum member, got %v", err)
+		}
+	}
+}
+
+func TestOptionalTupleFields(t *testing.T) {
+	buf := new(bytes.Buffer)
+	if _, err := (&types.TupleV1{Name: "a", Count: 3}).MarshalCBOR(buf); err != nil {
+		t.Fatal(err)
+	}
+
+	var v2 types.TupleV2
+	if _, err := v2.UnmarshalCBOR(bytes.NewReader(buf.Bytes())); err != nil {
+		t.Fatal(err)
+	}
+	if v2 != (types.TupleV2{Name: "a", Count: 3, Limit: 3}) {
+		t.Fatalf("unexpected upgraded value: %+v", v2)
+	}
+
+	// Only the last optional field is missing.
+	enc := encodeNode(t, cbg.ListNode{cbg.StringNode("b"), cbg.NewIntNode(1), cbg.NewIntNode(5)})
+	if _, err := v2.UnmarshalCBOR(bytes.NewReader(enc)); err != nil {
+		t.Fatal(err)
+	}
+	if v2 != (types.TupleV2{Name: "b", Count: 1, Limit: 5}) {
+		t.Fatalf("unexpected value: %+v", v2)
+	}
+
+	for _, nd := range []cbg.ListNode{
+		{cbg.StringNode("c")},
+		{cbg.StringNode("c"), cbg.NewIntNode(1), cbg.NewIntNode(5), cbg.StringNode(""), cbg.NewIntNode(0)},
+	} {
+		_, err := v2.UnmarshalCBOR(bytes.NewReader(encodeNode(t, nd)))
+		if err == nil || !strings.Contains(err.Error(), "wrong number of fields") {
+			t.Fatalf("expected a wrong number of fields error for %d fields, got %v", len(nd), err)
+		}
+	}
+}
+
+func TestUpgradedMap(t *testing.T) {
+	enc := encodeNode(t, cbg.MapNode{{Key: "Name", Value: cbg.StringNode("a")}})
+	var m types.UpgradedMap
+	if _, err := m.UnmarshalCBOR(bytes.NewReader(enc)); err != nil {
+		t.Fatal(err)
+	}
+	if m != (types.UpgradedMap{Name: "a", Limit: 10}) {
+		t.Fatalf("unexpected upgraded value: %+v", m)
+	}
+}
+
+func TestIntMapKeys(t *testing.T) {
+	v := types.IntKeyed{Alg: -7, Kid: []byte{0xaa}, Payload: "x", Note: "n"}
+	buf := new(bytes.Buffer)
+	if _, err := v.MarshalCBOR(buf); err != nil {
+		t.Fatal(err)
+	}
+	diag, err := cbg.Diagnose(buf.Bytes())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diag != `{1: -7, 4: h'aa', -2: "x", "note": "n"}` {
+		t.Fatalf("unexpected encoding: %s", diag)
+	}
+
+	var out types.IntKeyed
+	if _, err := out.UnmarshalCBOR(bytes.NewReader(buf.Bytes())); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(out, v) {
+		t.Fatalf("unexpected decoded value: %+v", out)
+	}
+
+	// {1: 1, 7: 0, -2: "x", "1": 2}: unknown keys are skipped, and the text
+	// key "1" isn't the integer key 1.
+	enc := []byte{0xa4, 0x01, 0x01, 0x07, 0x00, 0x21, 0x61, 'x', 0x61, '1', 0x02}
+	out = types.IntKeyed{}
+	if _, err := out.UnmarshalCBOR(bytes.NewReader(enc)); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(out, types.IntKeyed{Alg: 1, Payload: "x"}) {
+		t.Fatalf("unexpected decoded value: %+v", out)
+	}
+}
+
+func TestAliasedKeys(t *testing.T) {
+	buf := new(bytes.Buffer)
+	if _, err := (&types.RenamedFields{Foo: 1, Bar: "x"}).MarshalCBOR(buf); err != nil {
+		t.Fatal(err)
+	}
+	var v types.AliasedFields
+	if _, err := v.UnmarshalCBOR(bytes.NewReader(buf.Bytes())); err != nil {
+		t.Fatal(err)
+	}
+	if v != (types.AliasedFields{Foo: 1, Bar: "x"}) {
+		t.Fatalf("unexpected decoded value: %+v", v)
+	}
+
+	// Encoding uses the new keys.
+	buf.Reset()
+	if _, err := v.MarshalCBOR(buf); err != nil {
+		t.Fatal(err)
+	}
+	diag, err := cbg.Diagnose(buf.Bytes())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diag != `{"f": 1, "bar": "x"}` {
+		t.Fatalf("unexpected encoding: %s", diag)
+	}
+
+	// {2: "y"}
+	v = types.AliasedFields{}
+	if _, err := v.UnmarshalCBOR(bytes.NewReader([]byte{0xa1, 0x02, 0x61, 'y'})); err != nil {
+		t.Fatal(err)
+	}
+	if v != (types.AliasedFields{Bar: "y"}) {
+		t.Fatalf("unexpected decoded value: %+v", v)
+	}
+
+	enc := encodeNode(t, cbg.MapNode{
+		{Key: "f", Value: cbg.NewIntNode(1)},
+		{Key: "foo", Value: cbg.NewIntNode(2)},
+	})
+	if _, err := v.UnmarshalCBOR(bytes.NewReader(enc)); err == nil {
+		t.Fatal("expected an error decoding both a key and its alias")
+	}
+}
+
+func TestDecodeOnlyEncodeOnlyFields(t *testing.T) {
+	// Old data with the legacy field is still read...
+	enc := encodeNode(t, cbg.ListNode{cbg.StringNode("a"), cbg.StringNode("old")})
+	var tv types.MigratingTuple
+	if _, err := tv.UnmarshalCBOR(bytes.NewReader(enc)); err != nil {
+		t.Fatal(err)
+	}
+	if tv != (types.MigratingTuple{Name: "a", Legacy: "old"}) {
+		t.Fatalf("unexpected decoded value: %+v", tv)
+	}
+	// ...but not written back.
+	buf := new(bytes.Buffer)
+	if n, err := tv.MarshalCBOR(buf); err != nil {
+		t.Fatal(err)
+	} else if n != buf.Len() {
+		t.Fatal("returned length does not match the byte length")
+	}
+	if diag, err := cbg.Diagnose(buf.Bytes()); err != nil {
+		t.Fatal(err)
+	} else if diag != `["a"]` {
+		t.Fatalf("unexpected encoding: %s", diag)
+	}
+
+	enc = encodeNode(t, cbg.MapNode{
+		{Key: "Name", Value: cbg.StringNode("a")},
+		{Key: "count", Value: cbg.NewIntNode(3)},
+		{Key: "total", Value: cbg.NewIntNode(4)},
+	})
+	var mv types.MigratingMap
+	if _, err := mv.UnmarshalCBOR(bytes.NewReader(enc)); err != nil {
+		t.Fatal(err)
+	}
+	if mv != (types.MigratingMap{Name: "a", Count: 3}) {
+		t.Fatalf("unexpected decoded value: %+v", mv)
+	}
+	mv.Total = mv.Count
+	buf.Reset()
+	if n, err := mv.MarshalCBOR(buf); err != nil {
+		t.Fatal(err)
+	} else if n != buf.Len() {
+		t.Fatal("returned length does not match the byte length")
+	}
+	if diag, err := cbg.Diagnose(buf.Bytes()); err != nil {
+		t.Fatal(err)
+	} else if diag != `{"Name": "a", "total": 3}` {
+		t.Fatalf("unexpected encoding: %s", diag)
+	}
+}
+
+func TestDefaultValues(t *testing.T) {
+	var v types.Defaults
+	if _, err := v.UnmarshalCBOR(bytes.NewReader(encodeNode(t, cbg.MapNode{}))); err != nil {
+		t.Fatal(err)
+	}
+	want := types.Defaults{Name: "anon", Retries: 3, Delta: -1, Enabled: true}
+	if v != want {
+		t.Fatalf("unexpected decoded value: %+v", v)
+	}
+
+	// Keys present with the zero value aren't defaulted.
+	enc := encodeNode(t, cbg.MapNode{
+		{Key: "name", Value: cbg.StringNode("")},
+		{Key: "delta", Value: cbg.NewIntNode(0)},
+		{Key: "enabled", Value: cbg.BoolNode(false)},
+	})
+	if _, err := v.UnmarshalCBOR(bytes.NewReader(enc)); err != nil {
+		t.Fatal(err)
+	}
+	if v != (types.Defaults{Retries: 3}) {
+		t.Fatalf("unexpected decoded value: %+v", v)
+	}
+
+	// Fields equal to their default values are omitted.
+	buf := new(bytes.Buffer)
+	if n, err := want.MarshalCBOR(buf); err != nil {
+		t.Fatal(err)
+	} else if n != buf.Len() {
+		t.Fatal("returned length does not match the byte length")
+	}
+	if diag, err := cbg.Diagnose(buf.Bytes()); err != nil {
+		t.Fatal(err)
+	} else if diag != `{"name": "anon", "delta": -1}` {
+		t.Fatalf("unexpected encoding: %s", diag)
+	}
+
+	buf.Reset()
+	if _, err := (&types.Defaults{Retries: 4, Note: "n"}).MarshalCBOR(buf); err != nil {
+		t.Fatal(err)
+	}
+	if diag, err := cbg.Diagnose(buf.Bytes()); err != nil {
+		t.Fatal(err)
+	} else if diag != `{"name": "", "note": "n", "delta": 0, "enabled": false, "retries": 4}` {
+		t.Fatalf("unexpected encoding: %s", diag)
+	}
+}
+
+func TestLifecycleHooks(t *testing.T) {
+	sv := types.SortedValues{Name: "a", Values: []uint64{3, 1, 2}}
+	buf := new(bytes.Buffer)
+	if _, err := sv.MarshalCBOR(buf); err != nil {
+		t.Fatal(err)
+	}
+	if diag, err := cbg.Diagnose(buf.Bytes()); err != nil {
+		t.Fatal(err)
+	} else if diag != `["a", [1, 2, 3]]` {
+		t.Fatalf("unexpected encoding: %s", diag)
+	}
+
+	enc := encodeNode(t, cbg.ListNode{cbg.StringNode("a"), cbg.ListNode{cbg.NewIntNode(2), cbg.NewIntNode(1)}})
+	if _, err := new(types.SortedValues).UnmarshalCBOR(bytes.NewReader(enc)); err == nil {
+		t.Fatal("expected PostUnmarshalCBOR to reject unsorted values")
+	}
+
+	buf.Reset()
+	if _, err := (&types.ValidatedMap{Min: 2, Max: 1}).MarshalCBOR(buf); err == nil {
+		t.Fatal("expected PreMarshalCBOR to reject the value")
+	} else if buf.Len() != 0 {
+		t.Fatalf("wrote %x before failing", buf.Bytes())
+	}
+
+	enc = encodeNode(t, cbg.MapNode{
+		{Key: "Max", Value: cbg.NewIntNode(1)},
+		{Key: "Min", Value: cbg.NewIntNode(2)},
+	})
+	if _, err := new(types.ValidatedMap).UnmarshalCBOR(bytes.NewReader(enc)); err == nil {
+		t.Fatal("expected PostUnmarshalCBOR to reject the value")
+	}
+}
+
+func TestConstraints(t *testing.T) {
+	parent := uint64(100)
+	valid := types.Constrained{
+		Hash:   make([]byte, 32),
+		Name:   "n",
+		Kind:   "tx",
+		Level:  -1,
+		Port:   443,
+		Parent: &parent,
+	}
+	buf := new(bytes.Buffer)
+	if _, err := valid.MarshalCBOR(buf); err != nil {
+		t.Fatal(err)
+	}
+	var out types.Constrained
+	if _, err := out.UnmarshalCBOR(bytes.NewReader(buf.Bytes())); err != nil {
+		t.Fatal(err)
+	}
+
+	tooBig := uint64(101)
+	for _, tc := range []struct {
+		mutate func(*types.Constrained)
+		field  string
+		err    string
+	}{
+		{func(v *types.Constrained) { v.Hash = v.Hash[:31] }, "Hash", "length 31 violates len=32"},
+		{func(v *types.Constrained) { v.Name = "" }, "Name", "length 0 violates min=1"},
+		{func(v *types.Constrained) { v.Name = "seventeen chars.." }, "Name", "length 17 violates max=16"},
+		{func(v *types.Constrained) { v.Kind = "msg" }, "Kind", `"msg" violates enum=block|tx`},
+		{func(v *types.Constrained) { v.Level = 6 }, "Level", "6 violates max=5"},
+		{func(v *types.Constrained) { v.Port = 8080 }, "Port", "8080 violates enum=80|443"},
+		{func(v *types.Constrained) { v.Parent = &tooBig }, "Parent", "101 violates max=100"},
+		{func(v *types.Constrained) { v.Tags = []uint64{1, 2, 3, 4} }, "Tags", "length 4 violates max=3"},
+	} {
+		v := valid
+		tc.mutate(&v)
+		buf.Reset()
+		if _, err := v.MarshalCBOR(buf); err != nil {
+			t.Fatal(err)
+		}
+		_, err := new(types.Constrained).UnmarshalCBOR(bytes.NewReader(buf.Bytes()))
+		var derr *cbg.DecodeError
+		var cerr *cbg.ConstraintError
+		if !errors.As(err, &derr) || derr.Type != "Constrained" || derr.Field != tc.field ||
+			!errors.As(err, &cerr) || cerr.Error() != tc.err {
+			t.Errorf("expected %q on %s, got %v", tc.err, tc.field, err)
+		}
+	}
+
+	// Length constraints are checked on headers, before reading the values.
+	buf.Reset()
+	if _, err := valid.MarshalCBOR(buf); err != nil {
+		t.Fatal(err)
+	}
+	header := bytes.Index(buf.Bytes(), []byte("dhash")) + 5
+	enc := append(buf.Bytes()[:header:header], 0x5a, 0x00, 0x10, 0x00, 0x00)
+	_, err := new(types.Constrained).UnmarshalCBOR(bytes.NewReader(enc))
+	var derr *cbg.DecodeError
+	var cerr *cbg.ConstraintError
+	if !errors.As(err, &derr) || derr.Field != "Hash" || derr.Offset != header ||
+		!errors.As(err, &cerr) || cerr.Error() != "length 1048576 violates len=32" {
+		t.Errorf("expected a constraint error on the header of Hash, got %v", err)
+	}
+
+	// Missing optional fields aren't checked.
+	enc = encodeNode(t, cbg.ListNode{cbg.NewIntNode(1)})
+	if _, err := new(types.ConstrainedTuple).UnmarshalCBOR(bytes.NewReader(enc)); err != nil {
+		t.Fatal(err)
+	}
+	enc = encodeNode(t, cbg.ListNode{cbg.NewIntNode(1), cbg.StringNode("abc")})
+	if _, err := new(types.ConstrainedTuple).UnmarshalCBOR(bytes.NewReader(enc)); err == nil {
+		t.Fatal("expected a constraint error")
+	}
+}
+
+func TestDecodeErrors(t *testing.T) {
+	one := func(value cbg.Node, i8 int64) cbg.Node {
+		return cbg.ListNode{
+			cbg.StringNode("foo"), value, cbg.BytesNode(nil), cbg.NewIntNode(0), cbg.StringNode(""),
+			cbg.NewIntNode(0), cbg.NewIntNode(0), cbg.NewIntNode(0), cbg.NewIntNode(i8), cbg.NewIntNode(0), cbg.NewIntNode(0),
+		}
+	}
+	two := cbg.ListNode{
+		cbg.NullNode{}, cbg.ListNode{}, cbg.ListNode{}, cbg.ListNode{}, cbg.StringNode("dog"), cbg.ListNode{},
+		cbg.NullNode{}, cbg.NullNode{},
+		cbg.ListNode{one(cbg.NewIntNode(1), 0), one(cbg.StringNode("x"), 0), one(cbg.NewIntNode(1), 0)},
+	}
+	tuple := func(name string, count int64) cbg.Node {
+		return cbg.ListNode{cbg.StringNode(name), cbg.NewIntNode(count)}
+	}
+	page := cbg.ListNode{
+		tuple("a", 1), cbg.ListNode{}, cbg.MapNode{{Key: "b", Value: tuple("b", -1)}}, cbg.StringNode(""),
+	}
+
+	for _, tc := range []struct {
+		name  string
+		value cbg.CBORUnmarshaler
+		enc   []byte
+		field string
+		found byte
+		is    error
+		err   string
+	}{
+		{
+			"nested field", new(types.SimpleTypeTwo), encodeNode(t, two),
+			"Arrrrrghay[1].Value", cbg.MajTextString, cbg.ErrUnexpectedType,
+			"unexpected major type 3, expected 0",
+		},
+		{
+			"overflow", new(types.SimpleTypeOne), encodeNode(t, one(cbg.NewIntNode(1), 200)),
+			"I8", cbg.MajUnsignedInt, cbg.ErrOverflow,
+			"integer overflow",
+		},
+		{
+			"map value", new(types.Page[*types.TupleV1]), encodeNode(t, page),
+			`ByKey["b"].Count`, cbg.MajNegativeInt, cbg.ErrUnexpectedType,
+			"unexpected major type 1, expected 0",
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := tc.value.UnmarshalCBOR(bytes.NewReader(tc.enc))
+			if !errors.Is(err, tc.is) {
+				t.Fatalf("expected %v, got %v", tc.is, err)
+			}
+			var derr *cbg.DecodeError
+			if !errors.As(err, &derr) {
+				t.Fatalf("expected a DecodeError, got %v", err)
+			}
+			if derr.Field != tc.field || derr.Found != tc.found {
+				t.Fatalf("unexpected field %q or major type %d", derr.Field, derr.Found)
+			}
+			if maj := tc.enc[derr.Offset] >> 5; maj != tc.found {
+				t.Fatalf("offset %d points at major type %d", derr.Offset, maj)
+			}
+			want := fmt.Sprintf("%s.%s at byte %d: %s", derr.Type, tc.field, derr.Offset, tc.err)
+			if err.Error() != want {
+				t.Fatalf("expected %q, got %q", want, err)
+			}
+		})
+	}
+
+	// Truncated input reports the field being read.
+	enc := encodeNode(t, one(cbg.NewIntNode(1), 0))
+	_, err := new(types.SimpleTypeOne).UnmarshalCBOR(bytes.NewReader(enc[:3]))
+	var derr *cbg.DecodeError
+	if !errors.As(err, &derr) || derr.Field != "Foo" || !errors.Is(err, io.ErrUnexpectedEOF) {
+		t.Fatalf("expected a truncated Foo field, got %v", err)
+	}
+}
+
+func TestDecodeLimits(t *testing.T) {
+	tree := &types.SimpleTypeTree{Dog: "root"}
+	for i := 0; i < 3; i++ {
+		tree = &types.SimpleTypeTree{Stuff: tree, Others: []uint64{1, 2}, Dog: "dog"}
+	}
+	buf := new(bytes.Buffer)
+	if _, err := tree.MarshalCBOR(buf); err != nil {
+		t.Fatal(err)
+	}
+	enc := buf.Bytes()
+
+	// Four trees of 7 map entries, with 2 elements of Others but for the
+	// root, and 64 bytes of map keys besides the 3 or 4 of Dog. Keys are
+	// sorted, so each tree reads Dog before Stuff, and Others after it.
+	limits := &cbg.DecodeLimits{MaxDepth: 4, MaxItems: 34, MaxBytes: 4*64 + 13}
+	if _, err := new(types.SimpleTypeTree).UnmarshalCBOR(limits.Reader(bytes.NewReader(enc))); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, tc := range []struct {
+		limits cbg.DecodeLimits
+		field  string
+	}{
+		{cbg.DecodeLimits{MaxDepth: 3}, "Stuff.Stuff.Stuff"},
+		{cbg.DecodeLimits{MaxItems: 27}, "Stuff.Stuff.Stuff"},
+		{cbg.DecodeLimits{MaxItems: 33}, "Others"},
+		// The first three trees read 15 bytes up to Stuff, the root its Dog
+		// key.
+		{cbg.DecodeLimits{MaxBytes: 3*15 + 3}, "Stuff.Stuff.Stuff.Dog"},
+	} {
+		limits := tc.limits
+		_, err := new(types.SimpleTypeTree).UnmarshalCBOR(limits.Reader(bytes.NewReader(enc)))
+		var derr *cbg.DecodeError
+		if !errors.Is(err, cbg.ErrDecodeLimit) || !errors.As(err, &derr) || derr.Field != tc.field {
+			t.Errorf("%+v: expected a decode limit error on %s, got %v", tc.limits, tc.field, err)
+		}
+	}
+
+	// Without limits, only the per-item limits apply.
+	if _, err := new(types.SimpleTypeTree).UnmarshalCBOR(bytes.NewReader(enc)); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func encodeNode(t *testing.T, nd cbg.Node) []byte {
+	buf := new(bytes.Buffer)
+	if _, err := cbg.EncodeAny(buf, nd); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+func TestGenericTypes(t *testing.T) {
+	a := &types.TupleV1{Name: "a", Count: 1}
+	b := &types.TupleV1{Name: "b", Count: 2}
+	page := types.Page[*types.TupleV1]{
+		First: a,
+		Items: []*types.TupleV1{a, nil, b},
+		ByKey: map[string]*types.TupleV1{"b": b},
+		Next:  "next",
+	}
+
+	buf := new(bytes.Buffer)
+	if _, err := page.MarshalCBOR(buf); err != nil {
+		t.Fatal(err)
+	}
+	enc := append([]byte(nil), buf.Bytes()...)
+
+	var npage types.Page[*types.TupleV1]
+	if read, err := npage.UnmarshalCBOR(bytes.NewReader(enc)); err != nil {
+		t.Fatal(err)
+	} else if read != len(enc) {
+		t.Fatalf("read %d bytes out of %d", read, len(enc))
+	}
+	if diff := cmp.Diff(page, npage); diff != "" {
+		t.Fatal(diff)
+	}
+
+	pair := types.Pair[*types.RenamedFields, *types.TupleV1]{
+		Key:   &types.RenamedFields{Foo: -1, Bar: "bar"},
+		Value: nil,
+	}
+	buf.Reset()
+	if _, err := pair.MarshalCBOR(buf); err != nil {
+		t.Fatal(err)
+	}
+	var npair types.Pair[*types.RenamedFields, *types.TupleV1]
+	if _, err := npair.UnmarshalCBOR(bytes.NewReader(buf.Bytes())); err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff(pair, npair); diff != "" {
+		t.Fatal(diff)
+	}
+
+	c, err := cid.Parse("bafkqaaa")
+	if err != nil {
+		t.Fatal(err)
+	}
+	links := types.Page[*types.LinkContainer]{Items: []*types.LinkContainer{nil, {Link: c}}}
+	var paths []string
+	if err := links.ForEachLink(func(path string, _ cid.Cid) error {
+		paths = append(paths, path)
+		return nil
+	}); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(paths, []string{"1/1/0"}) {
+		t.Fatalf("unexpected link paths: %q", paths)
+	}
+}
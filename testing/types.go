@@ -1,6 +1,10 @@
 package testing
 
 import (
+	"fmt"
+	"math/big"
+	"sort"
+
 	"github.com/ipfs/go-cid"
 
 	cbg "github.com/daotl/cbor-gen"
@@ -108,6 +112,15 @@ type ThingWithSomeTime struct {
 	CatName string
 }
 
+type BigIntContainer struct {
+	Int *big.Int
+}
+
+// BigIntHolder holds big.Int values through another type.
+type BigIntHolder struct {
+	Ints []BigIntContainer
+}
+
 // Do not add fields to this type.
 type NeedScratchForMap struct {
 	Thing bool
@@ -117,3 +130,168 @@ type RenamedFields struct {
 	Foo int64  `cborgen:"foo"`
 	Bar string `cborgen:"beep"`
 }
+
+// AliasedFields decodes data encoded as RenamedFields, whose keys it renamed.
+type AliasedFields struct {
+	Foo int64  `cborgen:"f,alias=foo"`
+	Bar string `cborgen:"bar,alias=beep,intalias=2"`
+}
+
+type LinkContainer struct {
+	Link     cid.Cid
+	Ptr      *cid.Cid
+	Cids     []cid.Cid
+	Nested   *SimpleStructV2
+	Structs  []SimpleStructV2
+	Map      map[string]SimpleStructV2
+	Deferred *cbg.Deferred
+}
+
+type TupleV1 struct {
+	Name  string
+	Count uint64
+}
+
+// TupleV2 adds optional fields to TupleV1, so it can decode its encoding.
+type TupleV2 struct {
+	Name  string
+	Count uint64
+	Limit uint64 `cborgen:",optional"`
+	Note  string `cborgen:",optional"`
+}
+
+// Upgrade defaults Limit to Count for data encoded as a TupleV1.
+func (t *TupleV2) Upgrade(present int) error {
+	if present < 3 {
+		t.Limit = t.Count
+	}
+	return nil
+}
+
+type UpgradedMap struct {
+	Name  string
+	Limit uint64 `cborgen:"limit,optional"`
+}
+
+func (t *UpgradedMap) Upgrade(present int) error {
+	if t.Limit == 0 {
+		t.Limit = 10
+	}
+	return nil
+}
+
+// MigratingTuple still reads the Legacy field of old data, but no longer
+// writes it.
+type MigratingTuple struct {
+	Name   string
+	Legacy string `cborgen:",decodeonly,optional"`
+}
+
+// MigratingLinks still reads the Prev link of old data, but no longer writes
+// it.
+type MigratingLinks struct {
+	Name string
+	Head cid.Cid
+	Prev cid.Cid `cborgen:",decodeonly,optional"`
+}
+
+// MigratingMap reads the deprecated "count" key, and writes "total" instead.
+type MigratingMap struct {
+	Name  string
+	Count uint64 `cborgen:"count,decodeonly"`
+	Total uint64 `cborgen:"total,encodeonly"`
+}
+
+// Defaults has default values for missing map keys, some of which are
+// omitted when encoding.
+type Defaults struct {
+	Name    string `cborgen:"name,default=anon"`
+	Retries uint64 `cborgen:"retries,default=3,omitdefault"`
+	Delta   int32  `cborgen:"delta,default=-1"`
+	Enabled bool   `cborgen:"enabled,default=true,omitdefault"`
+	Note    string `cborgen:"note,omitdefault"`
+}
+
+// SortedValues sorts its values before encoding, and rejects unsorted values
+// when decoding.
+type SortedValues struct {
+	Name   string
+	Values []uint64
+}
+
+func (t *SortedValues) PreMarshalCBOR() error {
+	sort.Slice(t.Values, func(i, j int) bool { return t.Values[i] < t.Values[j] })
+	return nil
+}
+
+func (t *SortedValues) PostUnmarshalCBOR() error {
+	if !sort.SliceIsSorted(t.Values, func(i, j int) bool { return t.Values[i] < t.Values[j] }) {
+		return fmt.Errorf("values of %s aren't sorted", t.Name)
+	}
+	return nil
+}
+
+// ValidatedMap rejects a Min greater than its Max, in both directions.
+type ValidatedMap struct {
+	Min uint64
+	Max uint64
+}
+
+func (t *ValidatedMap) PreMarshalCBOR() error {
+	return t.validate()
+}
+
+func (t *ValidatedMap) PostUnmarshalCBOR() error {
+	return t.validate()
+}
+
+func (t *ValidatedMap) validate() error {
+	if t.Min > t.Max {
+		return fmt.Errorf("min %d is greater than max %d", t.Min, t.Max)
+	}
+	return nil
+}
+
+// Constrained has value constraints checked by decoders.
+type Constrained struct {
+	Hash   []byte   `cborgen:"hash,len=32"`
+	Name   string   `cborgen:"name,min=1,max=16"`
+	Kind   string   `cborgen:"kind,enum=block|tx"`
+	Level  int8     `cborgen:"level,min=-1,max=5"`
+	Port   uint16   `cborgen:"port,enum=80|443"`
+	Parent *uint64  `cborgen:"parent,max=100"`
+	Tags   []uint64 `cborgen:"tags,max=3,optional"`
+}
+
+// ConstrainedTuple has constraints on optional fields.
+type ConstrainedTuple struct {
+	Count uint64 `cborgen:",min=1"`
+	Note  string `cborgen:",max=2,optional"`
+}
+
+// Page is a generic type, whose items are typically pointers to generated
+// types.
+type Page[T cbg.CBORMarshalUnmarshaler] struct {
+	First T
+	Items []T
+	ByKey map[string]T
+	Next  string
+}
+
+type Pair[K, V cbg.CBORMarshalUnmarshaler] struct {
+	Key   K
+	Value V
+}
+
+// IntKeyed uses integer map keys, like COSE and CWT structures.
+type IntKeyed struct {
+	Alg     int64  `cborgen:"1,intkey"`
+	Kid     []byte `cborgen:"4,intkey"`
+	Payload string `cborgen:"-2,intkey"`
+	Note    string `cborgen:"note,optional"`
+}
+
+// IntKeyedList holds integer map keys through IntKeyed.
+type IntKeyedList struct {
+	Headers []IntKeyed
+}
@@ -10,6 +10,7 @@ import (
 	"io/ioutil"
 	"math"
 	"sort"
+	"strconv"
 	"time"
 
 	"github.com/ipfs/go-cid"
@@ -48,8 +49,12 @@ func discard(br io.Reader, n int) error {
 func ScanForLinks(br io.Reader, cb func(cid.Cid)) (int, error) {
 	bytesRead := 0
 
+	limits := GetDecodeLimits(br)
+	nest := newNesting(limits)
+	defer nest.close()
+
 	scratch := make([]byte, maxCidLength)
-	for remaining := uint64(1); remaining > 0; remaining-- {
+	for nest.next() {
 		maj, extra, read, err := CborReadHeaderBuf(br, scratch)
 		if err != nil {
 			return bytesRead, err
@@ -59,6 +64,9 @@ func ScanForLinks(br io.Reader, cb func(cid.Cid)) (int, error) {
 		switch maj {
 		case MajUnsignedInt, MajNegativeInt, MajOther:
 		case MajByteString, MajTextString:
+			if err := limits.AddBytes(extra); err != nil {
+				return bytesRead, err
+			}
 			err := discard(br, int(extra))
 			if err != nil {
 				return bytesRead, err
@@ -73,11 +81,11 @@ func ScanForLinks(br io.Reader, cb func(cid.Cid)) (int, error) {
 				bytesRead += read
 
 				if maj != MajByteString {
-					return bytesRead, fmt.Errorf("expected cbor type 'byte string' in input")
+					return bytesRead, &DecodeError{Offset: bytesRead - read, Expected: MajByteString, Found: maj, Err: ErrUnexpectedType}
 				}
 
 				if extra > maxCidLength {
-					return bytesRead, fmt.Errorf("string in cbor input too long")
+					return bytesRead, &DecodeError{Offset: bytesRead - read, Expected: MajByteString, Found: maj, Err: ErrMaxLength}
 				}
 
 				if read, err := io.ReadAtLeast(br, scratch[:extra], int(extra)); err != nil {
@@ -93,12 +101,16 @@ func ScanForLinks(br io.Reader, cb func(cid.Cid)) (int, error) {
 				cb(c)
 
 			} else {
-				remaining++
+				nest.tagged()
 			}
 		case MajArray:
-			remaining += extra
+			if err := nest.open(extra, extra); err != nil {
+				return bytesRead, err
+			}
 		case MajMap:
-			remaining += (extra * 2)
+			if err := nest.open(extra, extra*2); err != nil {
+				return bytesRead, err
+			}
 		default:
 			return bytesRead, fmt.Errorf("unhandled cbor type: %d", maj)
 		}
@@ -117,8 +129,6 @@ const (
 	MajOther       = 7
 )
 
-var maxLengthError = fmt.Errorf("length beyond maximum allowed")
-
 type Initter interface {
 	// InitNilEmbeddedStruct prepare the struct for marshalling & unmarshalling by
 	// recursively initialize the structs embedded by pointer to their zero values.
@@ -137,6 +147,27 @@ type CBORMarshaler interface {
 	MarshalCBOR(io.Writer) (int, error)
 }
 
+type Upgrader interface {
+	// Upgrade is called by generated decoders after decoding input from an
+	// older version of the type, missing some of its optional fields. present
+	// is the number of fields found in the input, the missing ones are left
+	// to their zero value for Upgrade to fill in.
+	Upgrade(present int) error
+}
+
+type PreMarshaler interface {
+	// PreMarshalCBOR is called by generated encoders before encoding the
+	// value, e.g. to normalize or validate it. An error aborts the encoding.
+	PreMarshalCBOR() error
+}
+
+type PostUnmarshaler interface {
+	// PostUnmarshalCBOR is called by generated decoders after decoding the
+	// value, and calling Upgrade if needed, e.g. to validate it. An error is
+	// returned by UnmarshalCBOR.
+	PostUnmarshalCBOR() error
+}
+
 type Deferred struct {
 	Raw []byte
 }
@@ -165,14 +196,21 @@ func (d *Deferred) UnmarshalCBOR(br io.Reader) (int, error) {
 	// Algorithm:
 	//
 	// 1. We start off expecting to read one element.
-	// 2. If we see a tag, we expect to read one more element so we increment "remaining".
-	// 3. If see an array, we expect to read "extra" elements so we add "extra" to "remaining".
-	// 4. If see a map, we expect to read "2*extra" elements so we add "2*extra" to "remaining".
-	// 5. While "remaining" is non-zero, read more elements.
+	// 2. If we see a tag, we expect to read one more element.
+	// 3. If see an array, we expect to read "extra" more elements.
+	// 4. If see a map, we expect to read "2*extra" more elements.
+	// 5. While elements are left, read more elements.
+	//
+	// nest counts the elements left, and their nesting depth if limits
+	// require it.
+
+	limits := GetDecodeLimits(br)
+	nest := newNesting(limits)
+	defer nest.close()
 
 	// define this once so we don't keep allocating it.
 	limitedReader := io.LimitedReader{R: br}
-	for remaining := uint64(1); remaining > 0; remaining-- {
+	for nest.next() {
 		maj, extra, read, err := CborReadHeaderBuf(br, scratch)
 		if err != nil {
 			return bytesRead, err
@@ -188,7 +226,10 @@ func (d *Deferred) UnmarshalCBOR(br io.Reader) (int, error) {
 			// nothing fancy to do
 		case MajByteString, MajTextString:
 			if extra > ByteArrayMaxLen {
-				return bytesRead, maxLengthError
+				return bytesRead, ErrMaxLength
+			}
+			if err := limits.AddBytes(extra); err != nil {
+				return bytesRead, err
 			}
 			// Copy the bytes
 			limitedReader.N = int64(extra)
@@ -202,17 +243,21 @@ func (d *Deferred) UnmarshalCBOR(br io.Reader) (int, error) {
 				}
 			}
 		case MajTag:
-			remaining++
+			nest.tagged()
 		case MajArray:
 			if extra > MaxLength {
-				return bytesRead, maxLengthError
+				return bytesRead, ErrMaxLength
+			}
+			if err := nest.open(extra, extra); err != nil {
+				return bytesRead, err
 			}
-			remaining += extra
 		case MajMap:
 			if extra > MaxLength {
-				return bytesRead, maxLengthError
+				return bytesRead, ErrMaxLength
+			}
+			if err := nest.open(extra, extra*2); err != nil {
+				return bytesRead, err
 			}
-			remaining += extra * 2
 		default:
 			return bytesRead, fmt.Errorf("unhandled deferred cbor type: %d", maj)
 		}
@@ -242,6 +287,10 @@ func readByte(r io.Reader) (byte, error) {
 }
 
 func CborReadHeader(br io.Reader) (byte, uint64, int, error) {
+	if p, ok := br.(*peeker); ok {
+		return p.readHeader()
+	}
+
 	bytesRead := 0
 
 	first, err := readByte(br)
@@ -318,6 +367,8 @@ func readByteBuf(r io.Reader, scratch []byte) (byte, error) {
 		return r.ReadByte()
 	case *bufio.Reader:
 		return r.ReadByte()
+	case *peeker:
+		return r.ReadByte()
 	}
 	n, err := r.Read(scratch[:1])
 	if err != nil {
@@ -331,6 +382,10 @@ func readByteBuf(r io.Reader, scratch []byte) (byte, error) {
 
 // same as the above, just tries to allocate less by using a passed in scratch buffer
 func CborReadHeaderBuf(br io.Reader, scratch []byte) (byte, uint64, int, error) {
+	if p, ok := br.(*peeker); ok {
+		return p.readHeader()
+	}
+
 	bytesRead := 0
 
 	first, err := readByteBuf(br, scratch)
@@ -487,19 +542,20 @@ func ReadTaggedByteArray(br io.Reader, exptag uint64, maxlen uint64) ([]byte, in
 	bytesRead += read
 
 	if maj != MajTag {
-		return nil, bytesRead, fmt.Errorf("expected cbor type 'tag' in input")
+		return nil, bytesRead, &DecodeError{Expected: MajTag, Found: maj, Err: ErrUnexpectedType}
 	}
 
 	if extra != exptag {
-		return nil, bytesRead, fmt.Errorf("expected tag %d", exptag)
+		return nil, bytesRead, &DecodeError{Expected: MajTag, Found: maj, Err: fmt.Errorf("expected tag %d, got %d", exptag, extra)}
 	}
 
 	bs, read, err := ReadByteArray(br, maxlen)
-	if err == nil {
-		bytesRead += read
+	if err != nil {
+		return nil, bytesRead, WrapDecodeError(err, "", "", bytesRead)
 	}
+	bytesRead += read
 
-	return bs, bytesRead, err
+	return bs, bytesRead, nil
 }
 
 func ReadByteArray(br io.Reader, maxlen uint64) ([]byte, int, error) {
@@ -512,11 +568,14 @@ func ReadByteArray(br io.Reader, maxlen uint64) ([]byte, int, error) {
 	bytesRead += read
 
 	if maj != MajByteString {
-		return nil, bytesRead, fmt.Errorf("expected cbor type 'byte string' in input")
+		return nil, bytesRead, &DecodeError{Expected: MajByteString, Found: maj, Err: ErrUnexpectedType}
 	}
 
 	if extra > maxlen {
-		return nil, bytesRead, fmt.Errorf("string in cbor input too long, maxlen: %d", maxlen)
+		return nil, bytesRead, &DecodeError{Expected: MajByteString, Found: maj, Err: ErrMaxLength}
+	}
+	if err := GetDecodeLimits(br).AddBytes(extra); err != nil {
+		return nil, bytesRead, &DecodeError{Expected: MajByteString, Found: maj, Err: err}
 	}
 
 	buf := make([]byte, extra)
@@ -556,11 +615,14 @@ func ReadString(r io.Reader) (string, int, error) {
 	bytesRead += read
 
 	if maj != MajTextString {
-		return "", bytesRead, fmt.Errorf("got tag %d while reading string value (l = %d)", maj, l)
+		return "", bytesRead, &DecodeError{Expected: MajTextString, Found: maj, Err: ErrUnexpectedType}
 	}
 
 	if l > MaxLength {
-		return "", bytesRead, fmt.Errorf("string in input was too long")
+		return "", bytesRead, &DecodeError{Expected: MajTextString, Found: maj, Err: ErrMaxLength}
+	}
+	if err := GetDecodeLimits(r).AddBytes(l); err != nil {
+		return "", bytesRead, &DecodeError{Expected: MajTextString, Found: maj, Err: err}
 	}
 
 	buf := make([]byte, l)
@@ -583,11 +645,14 @@ func ReadStringBuf(r io.Reader, scratch []byte) (string, int, error) {
 	bytesRead += read
 
 	if maj != MajTextString {
-		return "", bytesRead, fmt.Errorf("got tag %d while reading string value (l = %d)", maj, l)
+		return "", bytesRead, &DecodeError{Expected: MajTextString, Found: maj, Err: ErrUnexpectedType}
 	}
 
 	if l > MaxLength {
-		return "", bytesRead, fmt.Errorf("string in input was too long")
+		return "", bytesRead, &DecodeError{Expected: MajTextString, Found: maj, Err: ErrMaxLength}
+	}
+	if err := GetDecodeLimits(r).AddBytes(l); err != nil {
+		return "", bytesRead, &DecodeError{Expected: MajTextString, Found: maj, Err: err}
 	}
 
 	buf := make([]byte, l)
@@ -600,6 +665,64 @@ func ReadStringBuf(r io.Reader, scratch []byte) (string, int, error) {
 	return string(buf), bytesRead, nil
 }
 
+// MapKey is a map key of a struct in map representation with integer keys,
+// either a text string or an integer. Generated decoders read it with
+// ReadMapKeyBuf and compare it with TextKey and IntKey values.
+type MapKey struct {
+	Int   bool
+	Text  string
+	Value int64
+}
+
+// String returns the key as written in Go: quoted if it's a text string.
+func (k MapKey) String() string {
+	if k.Int {
+		return strconv.FormatInt(k.Value, 10)
+	}
+	return strconv.Quote(k.Text)
+}
+
+func TextKey(s string) MapKey {
+	return MapKey{Text: s}
+}
+
+func IntKey(v int64) MapKey {
+	return MapKey{Int: true, Value: v}
+}
+
+// ReadMapKeyBuf reads a text string or integer map key.
+func ReadMapKeyBuf(r io.Reader, scratch []byte) (MapKey, int, error) {
+	maj, extra, read, err := CborReadHeaderBuf(r, scratch)
+	if err != nil {
+		return MapKey{}, read, err
+	}
+
+	switch maj {
+	case MajTextString:
+		if extra > MaxLength {
+			return MapKey{}, read, &DecodeError{Expected: maj, Found: maj, Err: ErrMaxLength}
+		}
+		if err := GetDecodeLimits(r).AddBytes(extra); err != nil {
+			return MapKey{}, read, &DecodeError{Expected: maj, Found: maj, Err: err}
+		}
+		buf := make([]byte, extra)
+		n, err := io.ReadFull(r, buf)
+		return TextKey(string(buf)), read + n, err
+	case MajUnsignedInt:
+		if extra > math.MaxInt64 {
+			return MapKey{}, read, &DecodeError{Expected: maj, Found: maj, Err: ErrOverflow}
+		}
+		return IntKey(int64(extra)), read, nil
+	case MajNegativeInt:
+		if extra > math.MaxInt64 {
+			return MapKey{}, read, &DecodeError{Expected: maj, Found: maj, Err: ErrOverflow}
+		}
+		return IntKey(-1 - int64(extra)), read, nil
+	default:
+		return MapKey{}, read, &DecodeError{Expected: MajTextString, Found: maj, Err: ErrUnexpectedType}
+	}
+}
+
 func ReadCid(br io.Reader) (cid.Cid, int, error) {
 	bytesRead := 0
 
@@ -714,7 +837,7 @@ func (cb *CborBool) UnmarshalCBOR(r io.Reader) (int, error) {
 	bytesRead += read
 
 	if t != MajOther {
-		return bytesRead, fmt.Errorf("booleans should be major type 7")
+		return bytesRead, &DecodeError{Expected: MajOther, Found: t, Err: ErrUnexpectedType}
 	}
 
 	switch val {
@@ -723,7 +846,7 @@ func (cb *CborBool) UnmarshalCBOR(r io.Reader) (int, error) {
 	case 21:
 		*cb = true
 	default:
-		return bytesRead, fmt.Errorf("booleans are either major type 7, value 20 or 21 (got %d)", val)
+		return bytesRead, &DecodeError{Expected: MajOther, Found: t, Err: fmt.Errorf("booleans are either major type 7, value 20 or 21 (got %d)", val)}
 	}
 	return bytesRead, nil
 }
@@ -762,16 +885,16 @@ func (ci *CborInt) UnmarshalCBOR(r io.Reader) (int, error) {
 	case MajUnsignedInt:
 		extraI = int64(extra)
 		if extraI < 0 {
-			return bytesRead, fmt.Errorf("int64 positive overflow")
+			return bytesRead, &DecodeError{Expected: maj, Found: maj, Err: ErrOverflow}
 		}
 	case MajNegativeInt:
 		extraI = int64(extra)
 		if extraI < 0 {
-			return bytesRead, fmt.Errorf("int64 negative oveflow")
+			return bytesRead, &DecodeError{Expected: maj, Found: maj, Err: ErrOverflow}
 		}
 		extraI = -1 - extraI
 	default:
-		return bytesRead, fmt.Errorf("wrong type for int64 field: %d", maj)
+		return bytesRead, &DecodeError{Expected: MajUnsignedInt, Found: maj, Err: ErrUnexpectedType}
 	}
 
 	*ci = CborInt(extraI)
@@ -37,10 +37,47 @@ func TestDeferredMaxLengthSingle(t *testing.T) {
 
 	var deferred Deferred
 	n, err := deferred.UnmarshalCBOR(&header)
-	if err != maxLengthError {
+	if err != ErrMaxLength {
 		t.Fatal("deferred: allowed more than the maximum allocation supported")
 	}
 	if n != l {
 		t.Fatal("returned length does not match the byte length")
 	}
 }
+
+func TestReadMapKeyBuf(t *testing.T) {
+	scratch := make([]byte, 9)
+	for _, tc := range []struct {
+		in   string
+		want MapKey
+	}{
+		{"01", IntKey(1)},
+		{"21", IntKey(-2)},
+		{"1903e8", IntKey(1000)},
+		{"3b7fffffffffffffff", IntKey(-1 << 63)},
+		{"6131", TextKey("1")},
+		{"60", TextKey("")},
+	} {
+		in, err := hex.DecodeString(tc.in)
+		if err != nil {
+			t.Fatal(err)
+		}
+		k, n, err := ReadMapKeyBuf(bytes.NewReader(in), scratch)
+		if err != nil {
+			t.Fatalf("%s: %s", tc.in, err)
+		}
+		if k != tc.want || n != len(in) {
+			t.Errorf("%s: got %+v reading %d bytes", tc.in, k, n)
+		}
+	}
+
+	for _, in := range []string{"1bffffffffffffffff", "3bffffffffffffffff", "4131", "f6"} {
+		b, err := hex.DecodeString(in)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, _, err := ReadMapKeyBuf(bytes.NewReader(b), scratch); err == nil {
+			t.Errorf("%s: expected an error", in)
+		}
+	}
+}
@@ -8,15 +8,23 @@ import (
 
 // ValidateCBOR validates that a byte array is a single valid CBOR object.
 func ValidateCBOR(b []byte) error {
-	// The code here is basically identical to the previous function, it
+	return ValidateCBORWithLimits(b, nil)
+}
+
+// ValidateCBORWithLimits is like ValidateCBOR, but also checks that the object
+// is within limits, see DecodeLimits.
+func ValidateCBORWithLimits(b []byte, limits *DecodeLimits) error {
+	// The code here is basically identical to Deferred.UnmarshalCBOR, it
 	// just doesn't copy.
 
 	br := bytes.NewReader(b)
+	nest := newNesting(limits)
+	defer nest.close()
 
 	// Allocate some scratch space.
 	scratch := make([]byte, maxHeaderSize)
 
-	for remaining := uint64(1); remaining > 0; remaining-- {
+	for nest.next() {
 		maj, extra, _, err := CborReadHeaderBuf(br, scratch)
 		if err != nil {
 			return err
@@ -27,7 +35,10 @@ func ValidateCBOR(b []byte) error {
 			// nothing fancy to do
 		case MajByteString, MajTextString:
 			if extra > ByteArrayMaxLen {
-				return maxLengthError
+				return ErrMaxLength
+			}
+			if err := limits.AddBytes(extra); err != nil {
+				return err
 			}
 			if uint64(br.Len()) < extra {
 				return io.ErrUnexpectedEOF
@@ -37,17 +48,21 @@ func ValidateCBOR(b []byte) error {
 				return err
 			}
 		case MajTag:
-			remaining++
+			nest.tagged()
 		case MajArray:
 			if extra > MaxLength {
-				return maxLengthError
+				return ErrMaxLength
+			}
+			if err := nest.open(extra, extra); err != nil {
+				return err
 			}
-			remaining += extra
 		case MajMap:
 			if extra > MaxLength {
-				return maxLengthError
+				return ErrMaxLength
+			}
+			if err := nest.open(extra, extra*2); err != nil {
+				return err
 			}
-			remaining += extra * 2
 		default:
 			return fmt.Errorf("unhandled deferred cbor type: %d", maj)
 		}
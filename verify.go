@@ -0,0 +1,318 @@
+package typegen
+
+import (
+	"bytes"
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"golang.org/x/xerrors"
+)
+
+// VerifyTupleEncodersInFile checks that the file fname holds the code
+// WriteTupleEncodersToFile would generate with the same arguments, without
+// writing anything. If it doesn't, it returns a *StaleError.
+func VerifyTupleEncodersInFile(fname, pkg string, flattenEmbeddedStruct bool,
+	fieldOrder []string, types ...interface{}) error {
+	g := Gen{
+		FlattenEmbeddedStruct: flattenEmbeddedStruct,
+		FieldOrder:            fieldOrder,
+	}
+	return g.VerifyTupleEncodersInFile(fname, pkg, types...)
+}
+
+// VerifyMapEncodersInFile checks that the file fname holds the code
+// WriteMapEncodersToFile would generate with the same arguments, without
+// writing anything. If it doesn't, it returns a *StaleError.
+func VerifyMapEncodersInFile(fname, pkg string, flattenEmbeddedStruct bool,
+	types ...interface{}) error {
+	g := Gen{
+		FlattenEmbeddedStruct: flattenEmbeddedStruct,
+	}
+	return g.VerifyMapEncodersInFile(fname, pkg, types...)
+}
+
+// StaleError is returned when a generated file doesn't match the code
+// generated from the current type definitions.
+type StaleError struct {
+	File string
+	// Types lists the types whose generated code changed. The file header,
+	// imports included, is reported as "(header)".
+	Types []string
+	// Diff is a line diff from the file on disk to the generated code, for
+	// the changed types only.
+	Diff string
+}
+
+func (e *StaleError) Error() string {
+	return fmt.Sprintf("%s is out of date (%s):\n%s", e.File, strings.Join(e.Types, ", "), e.Diff)
+}
+
+const headerDecls = "(header)"
+
+func verifyFile(fname string, data []byte) error {
+	current, err := ioutil.ReadFile(fname)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return &StaleError{File: fname, Types: []string{headerDecls}, Diff: lineDiff(nil, data)}
+		}
+		return xerrors.Errorf("failed to read file: %w", err)
+	}
+	if bytes.Equal(current, data) {
+		return nil
+	}
+
+	split := declsByType
+	if filepath.Ext(fname) != ".go" {
+		split = schemaDeclsByType
+	}
+	want, err := split(data)
+	if err != nil {
+		return xerrors.Errorf("failed to parse generated code: %w", err)
+	}
+	got, err := split(current)
+	if err != nil {
+		// Not even valid Go, diff the whole file.
+		return &StaleError{File: fname, Types: []string{headerDecls}, Diff: lineDiff(current, data)}
+	}
+
+	names := map[string]struct{}{}
+	for name := range want {
+		names[name] = struct{}{}
+	}
+	for name := range got {
+		names[name] = struct{}{}
+	}
+	sorted := make([]string, 0, len(names))
+	for name := range names {
+		sorted = append(sorted, name)
+	}
+	sort.Strings(sorted)
+
+	e := &StaleError{File: fname}
+	var diff strings.Builder
+	for _, name := range sorted {
+		if want[name] == got[name] {
+			continue
+		}
+		e.Types = append(e.Types, name)
+		fmt.Fprintf(&diff, "@@ %s @@\n", name)
+		diff.WriteString(lineDiff([]byte(got[name]), []byte(want[name])))
+	}
+	if len(e.Types) == 0 {
+		// Only comments or whitespace between declarations differ.
+		e.Types = []string{headerDecls}
+		diff.WriteString(lineDiff(current, data))
+	}
+	e.Diff = diff.String()
+	return e
+}
+
+// declsByType splits Go source into the source of its top level declarations,
+// grouped by the generated type they belong to.
+func declsByType(src []byte) (map[string]string, error) {
+	fset := token.NewFileSet()
+	f, err := parser.ParseFile(fset, "", src, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	decls := map[string]string{}
+	offset := func(p token.Pos) int {
+		return fset.Position(p).Offset
+	}
+	decls[headerDecls] = string(src[:offset(f.Name.End())])
+	for _, d := range f.Decls {
+		name := headerDecls
+		switch d := d.(type) {
+		case *ast.FuncDecl:
+			name = funcDeclType(d)
+		case *ast.GenDecl:
+			// The length headers of tuple types.
+			if len(d.Specs) == 1 {
+				if vs, ok := d.Specs[0].(*ast.ValueSpec); ok && len(vs.Names) == 1 &&
+					strings.HasPrefix(vs.Names[0].Name, "lengthBuf") {
+					name = strings.TrimPrefix(vs.Names[0].Name, "lengthBuf")
+				}
+			}
+		}
+		decls[name] += string(src[offset(d.Pos()):offset(d.End())]) + "\n"
+	}
+	return decls, nil
+}
+
+// schemaDeclsByType splits a generated IPLD Schema or CDDL file into its type
+// definitions, which start with a line beginning with "type <Name>" or
+// "<Name> =" respectively.
+func schemaDeclsByType(src []byte) (map[string]string, error) {
+	decls := map[string]string{}
+	name := headerDecls
+	for _, line := range splitLines(src) {
+		fields := strings.Fields(strings.TrimPrefix(line, "type "))
+		if len(fields) > 0 && line[0] != '\t' && line[0] != '#' && line[0] != ';' &&
+			line[0] != '}' && line[0] != ']' {
+			// Generic CDDL rules have parameters, e.g. Page<T0>.
+			name = strings.SplitN(fields[0], "<", 2)[0]
+		}
+		decls[name] += line + "\n"
+	}
+	return decls, nil
+}
+
+// funcDeclType returns the name of the type a generated function belongs to.
+func funcDeclType(fd *ast.FuncDecl) string {
+	if fd.Recv != nil && len(fd.Recv.List) == 1 {
+		t := fd.Recv.List[0].Type
+		if star, ok := t.(*ast.StarExpr); ok {
+			t = star.X
+		}
+		// Receivers of generic types have type parameters.
+		switch x := t.(type) {
+		case *ast.IndexExpr:
+			t = x.X
+		case *ast.IndexListExpr:
+			t = x.X
+		}
+		if id, ok := t.(*ast.Ident); ok {
+			return id.Name
+		}
+	}
+	for _, prefix := range []string{"FuzzUnmarshal", "TestRoundtrip"} {
+		if strings.HasPrefix(fd.Name.Name, prefix) {
+			return strings.TrimPrefix(fd.Name.Name, prefix)
+		}
+	}
+	return headerDecls
+}
+
+// diffContext is the number of unchanged lines shown around changes.
+const diffContext = 3
+
+// maxDiffCells bounds the size of the table lineDiff computes the changes
+// with, the product of the numbers of lines differing between both sides.
+const maxDiffCells = 1 << 20
+
+// diffLine is a line of a diff, whose op is '-', '+' or ' '.
+type diffLine struct {
+	op   byte
+	text string
+}
+
+// lineDiff returns a minimal line diff from a to b, with removed lines
+// prefixed by "-", added lines by "+" and common lines by " ". If the lines
+// differing between a and b are too many to compare, only the first different
+// line of each side is shown.
+func lineDiff(a, b []byte) string {
+	al := splitLines(a)
+	bl := splitLines(b)
+
+	// Only the lines between the common prefix and suffix need comparing.
+	prefix := 0
+	for prefix < len(al) && prefix < len(bl) && al[prefix] == bl[prefix] {
+		prefix++
+	}
+	suffix := 0
+	for suffix < len(al)-prefix && suffix < len(bl)-prefix &&
+		al[len(al)-1-suffix] == bl[len(bl)-1-suffix] {
+		suffix++
+	}
+
+	var lines []diffLine
+	for _, l := range al[:prefix] {
+		lines = append(lines, diffLine{' ', l})
+	}
+	am, bm := al[prefix:len(al)-suffix], bl[prefix:len(bl)-suffix]
+	truncated := len(am)*len(bm) > maxDiffCells
+	if truncated {
+		lines = append(lines, diffLine{'-', am[0]}, diffLine{'+', bm[0]})
+	} else {
+		lines = append(lines, diffLines(am, bm)...)
+		for _, l := range al[len(al)-suffix:] {
+			lines = append(lines, diffLine{' ', l})
+		}
+	}
+
+	// Only keep diffContext common lines around the changes.
+	keep := make([]bool, len(lines))
+	for i, l := range lines {
+		if l.op == ' ' {
+			continue
+		}
+		for k := i - diffContext; k <= i+diffContext; k++ {
+			if k >= 0 && k < len(lines) {
+				keep[k] = true
+			}
+		}
+	}
+
+	var sb strings.Builder
+	for i, l := range lines {
+		if !keep[i] {
+			if i > 0 && keep[i-1] {
+				sb.WriteString("...\n")
+			}
+			continue
+		}
+		sb.WriteByte(l.op)
+		sb.WriteString(l.text)
+		sb.WriteByte('\n')
+	}
+	if truncated {
+		fmt.Fprintf(&sb, "... %d lines removed and %d added, too many to diff\n", len(am), len(bm))
+	}
+	return sb.String()
+}
+
+// diffLines returns a minimal diff from a to b, computed from the longest
+// common subsequence of their lines.
+func diffLines(a, b []string) []diffLine {
+	// lcs[i][j] is the length of the longest common subsequence of a[i:] and
+	// b[j:].
+	lcs := make([][]int, len(a)+1)
+	for i := range lcs {
+		lcs[i] = make([]int, len(b)+1)
+	}
+	for i := len(a) - 1; i >= 0; i-- {
+		for j := len(b) - 1; j >= 0; j-- {
+			if a[i] == b[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else if lcs[i+1][j] >= lcs[i][j+1] {
+				lcs[i][j] = lcs[i+1][j]
+			} else {
+				lcs[i][j] = lcs[i][j+1]
+			}
+		}
+	}
+
+	var lines []diffLine
+	i, j := 0, 0
+	for i < len(a) || j < len(b) {
+		switch {
+		case i < len(a) && j < len(b) && a[i] == b[j]:
+			lines = append(lines, diffLine{' ', a[i]})
+			i++
+			j++
+		case i < len(a) && (j == len(b) || lcs[i+1][j] >= lcs[i][j+1]):
+			lines = append(lines, diffLine{'-', a[i]})
+			i++
+		default:
+			lines = append(lines, diffLine{'+', b[j]})
+			j++
+		}
+	}
+	return lines
+}
+
+func splitLines(b []byte) []string {
+	s := strings.TrimSuffix(string(b), "\n")
+	if s == "" {
+		return nil
+	}
+	return strings.Split(s, "\n")
+}
@@ -0,0 +1,90 @@
+package typegen
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"golang.org/x/xerrors"
+)
+
+type verifyTypeOne struct {
+	Foo string
+	Bar uint64
+}
+
+type verifyTypeTwo struct {
+	Baz []byte
+}
+
+func TestVerifyEncodersInFile(t *testing.T) {
+	dir, err := ioutil.TempDir("", "cbor-gen-verify")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	fname := filepath.Join(dir, "cbor_gen.go")
+
+	g := Gen{GenerateTests: true}
+	err = g.VerifyMapEncodersInFile(fname, "verify", verifyTypeOne{}, verifyTypeTwo{})
+	var stale *StaleError
+	if !xerrors.As(err, &stale) {
+		t.Fatalf("expected a missing file to be stale, got %v", err)
+	}
+
+	if err := g.WriteMapEncodersToFile(fname, "verify", verifyTypeOne{}, verifyTypeTwo{}); err != nil {
+		t.Fatal(err)
+	}
+	if err := g.VerifyMapEncodersInFile(fname, "verify", verifyTypeOne{}, verifyTypeTwo{}); err != nil {
+		t.Fatal(err)
+	}
+
+	// Generating as tuples changes the code of both types.
+	err = g.VerifyTupleEncodersInFile(fname, "verify", verifyTypeOne{}, verifyTypeTwo{})
+	if !xerrors.As(err, &stale) {
+		t.Fatalf("expected a stale file, got %v", err)
+	}
+	if !equalStrings(stale.Types, []string{"verifyTypeOne", "verifyTypeTwo"}) {
+		t.Fatalf("unexpected stale types: %v", stale.Types)
+	}
+
+	// Editing the generated code of a type only reports that type.
+	data, err := ioutil.ReadFile(fname)
+	if err != nil {
+		t.Fatal(err)
+	}
+	data = bytes.Replace(data, []byte(`"Baz"`), []byte(`"Qux"`), -1)
+	if err := ioutil.WriteFile(fname, data, 0644); err != nil {
+		t.Fatal(err)
+	}
+	err = g.VerifyMapEncodersInFile(fname, "verify", verifyTypeOne{}, verifyTypeTwo{})
+	if !xerrors.As(err, &stale) {
+		t.Fatalf("expected a stale file, got %v", err)
+	}
+	if stale.File != fname || !equalStrings(stale.Types, []string{"verifyTypeTwo"}) {
+		t.Fatalf("unexpected stale file: %s %v", stale.File, stale.Types)
+	}
+	if !strings.Contains(stale.Diff, "-\t\tcase \"Qux\":\n+\t\tcase \"Baz\":\n") {
+		t.Fatalf("unexpected diff:\n%s", stale.Diff)
+	}
+}
+
+func TestLineDiff(t *testing.T) {
+	a := []byte("1\n2\n3\n4\n5\n6\n7\n8\n9\n")
+	b := []byte("1\n2\n3\n4\n5\nsix\n7\n8\n9\n")
+	expected := " 3\n 4\n 5\n-6\n+six\n 7\n 8\n 9\n"
+	if diff := lineDiff(a, b); diff != expected {
+		t.Fatalf("unexpected diff:\n%s", diff)
+	}
+
+	// Files entirely different are too large to compare line by line.
+	a = []byte("same\n" + strings.Repeat("a\n", 2000))
+	b = []byte("same\n" + strings.Repeat("b\n", 2000))
+	expected = " same\n-a\n+b\n... 2000 lines removed and 2000 added, too many to diff\n"
+	if diff := lineDiff(a, b); diff != expected {
+		t.Fatalf("unexpected diff:\n%s", diff)
+	}
+}
@@ -4,11 +4,46 @@ import (
 	"bytes"
 	"go/format"
 	"os"
+	"reflect"
 	"sort"
 
 	"golang.org/x/xerrors"
 )
 
+// Gen holds the options of the code generator.
+type Gen struct {
+	// FlattenEmbeddedStruct flattens the fields of embedded structs into the
+	// embedding struct in serialization.
+	FlattenEmbeddedStruct bool
+
+	// FieldOrder lists the fields to serialize first in tuple representation,
+	// the remaining fields follow in declaration order. It doesn't apply to
+	// types whose fields have `cborgen:",index=N"` tags.
+	FieldOrder []string
+
+	// JSONTagKeys makes fields without a map key in their cborgen tag use the
+	// name in their json tag, if any, as map key.
+	JSONTagKeys bool
+
+	// KeyNaming, if set, derives the map keys of fields from their Go names,
+	// when their cborgen tag, or json tag with JSONTagKeys, doesn't set one,
+	// e.g. SnakeCase, CamelCase or LowerCase.
+	KeyNaming func(fieldName string) string
+
+	// GenerateTests makes the generator also write native fuzz targets
+	// (FuzzUnmarshal<Type>) and roundtrip property tests (TestRoundtrip<Type>)
+	// for every type, in a _test.go file next to the generated file.
+	GenerateTests bool
+
+	// IpldSchemaFile, if set, is the path of a file to write the IPLD Schema
+	// (DSL) of the types to, see GenIpldSchemaForType.
+	IpldSchemaFile string
+
+	// CddlFile, if set, is the path of a file to write the CDDL (RFC 8610)
+	// description of the types to, see GenCddlForType.
+	CddlFile string
+}
+
 // WriteTupleFileEncodersToFile generates array backed MarshalCBOR and UnmarshalCBOR implementations for the
 // given types in the specified file, with the specified package name.
 //
@@ -16,77 +51,151 @@ import (
 // fixed-length CBOR array of field values.
 func WriteTupleEncodersToFile(fname, pkg string, flattenEmbeddedStruct bool,
 	fieldOrder []string, types ...interface{}) error {
+	g := Gen{
+		FlattenEmbeddedStruct: flattenEmbeddedStruct,
+		FieldOrder:            fieldOrder,
+	}
+	return g.WriteTupleEncodersToFile(fname, pkg, types...)
+}
+
+// WriteTupleEncodersToFile is like the WriteTupleEncodersToFile function, with
+// the options of g.
+func (g Gen) WriteTupleEncodersToFile(fname, pkg string, types ...interface{}) error {
+	files, err := g.genTupleEncoders(fname, pkg, types)
+	if err != nil {
+		return err
+	}
+	return files.write()
+}
+
+// VerifyTupleEncodersInFile is like the VerifyTupleEncodersInFile function,
+// with the options of g.
+func (g Gen) VerifyTupleEncodersInFile(fname, pkg string, types ...interface{}) error {
+	files, err := g.genTupleEncoders(fname, pkg, types)
+	if err != nil {
+		return err
+	}
+	return files.verify()
+}
+
+func (g Gen) genTupleEncoders(fname, pkg string, types []interface{}) (genFiles, error) {
 	buf := new(bytes.Buffer)
 
 	typeInfos := make([]*GenTypeInfo, len(types))
 	embeddedByPointerStructsInfos := make([]*[]string, len(types))
 	for i, t := range types {
-		gti, embeddedByPointerStructs, err := ParseTypeInfo(t, flattenEmbeddedStruct)
+		gti, embeddedByPointerStructs, err := g.parseTupleTypeInfo(t)
 		if err != nil {
-			return xerrors.Errorf("failed to parse type info: %w", err)
-		}
-		if fieldOrder != nil {
-			ordered := make([]Field, 0, len(gti.Fields))
-			fieldMap := map[string]*Field{}
-			for i, f := range gti.Fields {
-				fieldMap[f.Name] = &gti.Fields[i]
-			}
-			// First the fields specified in `fieldOrder`
-			for _, name := range fieldOrder {
-				if f, ok := fieldMap[name]; ok {
-					// Mark as picked
-					delete(fieldMap, name)
-					ordered = append(ordered, *f)
-				}
-			}
-			// The remaining fields
-			for _, f := range gti.Fields {
-				if _, ok := fieldMap[f.Name]; ok {
-					ordered = append(ordered, f)
-				}
-			}
-			// Assert that len(ordered) matches the field count, should never panic
-			if len(ordered) != len(gti.Fields) {
-				panic("Bug: len(ordered) != len(gti.Fields)")
-			}
-			// Replace gti.Fields with ordered fields
-			gti.Fields = ordered
+			return nil, xerrors.Errorf("failed to parse type info: %w", err)
 		}
 		typeInfos[i] = gti
-		if flattenEmbeddedStruct {
+		if g.FlattenEmbeddedStruct {
 			embeddedByPointerStructsInfos[i] = embeddedByPointerStructs
 		}
 	}
 
 	if err := PrintHeaderAndUtilityMethods(buf, pkg, typeInfos); err != nil {
-		return xerrors.Errorf("failed to write header: %w", err)
+		return nil, xerrors.Errorf("failed to write header: %w", err)
 	}
 
 	for i, t := range typeInfos {
-		if err := GenTupleEncodersForType(t, flattenEmbeddedStruct,
+		if err := GenTupleEncodersForType(t, g.FlattenEmbeddedStruct,
 			embeddedByPointerStructsInfos[i], buf); err != nil {
-			return xerrors.Errorf("failed to generate encoders: %w", err)
+			return nil, xerrors.Errorf("failed to generate encoders: %w", err)
 		}
 	}
 
-	data, err := format.Source(buf.Bytes())
-	if err != nil {
-		return err
-	}
+	return g.genFiles(fname, pkg, ReprTuple, buf.Bytes(), typeInfos, types)
+}
 
-	fi, err := os.Create(fname)
+// parseTypeInfo parses the type info of t with the options of g.
+func (g Gen) parseTypeInfo(t interface{}) (*GenTypeInfo, *[]string, error) {
+	return parseTypeInfo(t, g.FlattenEmbeddedStruct, mapKeyOptions{
+		jsonTags: g.JSONTagKeys,
+		naming:   g.KeyNaming,
+	})
+}
+
+// parseTupleTypeInfo parses the type info of t, with its fields in tuple order.
+func (g Gen) parseTupleTypeInfo(t interface{}) (*GenTypeInfo, *[]string, error) {
+	gti, embeddedByPointerStructs, err := g.parseTypeInfo(t)
 	if err != nil {
-		return xerrors.Errorf("failed to open file: %w", err)
+		return nil, nil, err
+	}
+	if indexed, err := orderByIndex(gti); err != nil {
+		return nil, nil, err
+	} else if indexed {
+		return gti, embeddedByPointerStructs, nil
 	}
+	if g.FieldOrder != nil {
+		ordered := make([]Field, 0, len(gti.Fields))
+		fieldMap := map[string]*Field{}
+		for i, f := range gti.Fields {
+			fieldMap[f.Name] = &gti.Fields[i]
+		}
+		// First the fields specified in `fieldOrder`
+		for _, name := range g.FieldOrder {
+			if f, ok := fieldMap[name]; ok {
+				// Mark as picked
+				delete(fieldMap, name)
+				ordered = append(ordered, *f)
+			}
+		}
+		// The remaining fields
+		for _, f := range gti.Fields {
+			if _, ok := fieldMap[f.Name]; ok {
+				ordered = append(ordered, f)
+			}
+		}
+		// Assert that len(ordered) matches the field count, should never panic
+		if len(ordered) != len(gti.Fields) {
+			panic("Bug: len(ordered) != len(gti.Fields)")
+		}
+		// Replace gti.Fields with ordered fields
+		gti.Fields = ordered
+	}
+	return gti, embeddedByPointerStructs, nil
+}
 
-	_, err = fi.Write(data)
-	if err != nil {
-		_ = fi.Close()
-		return err
+// orderByIndex sorts the fields of gti by their index tags, and reports
+// whether they have some.
+func orderByIndex(gti *GenTypeInfo) (bool, error) {
+	byIndex := make([]*Field, len(gti.Fields))
+	indexed := false
+	for i, f := range gti.Fields {
+		if !f.HasIndex {
+			continue
+		}
+		indexed = true
+		// Such fields are left out of the encoding or the decoding, which
+		// shifts the positions of the fields after them in one direction only.
+		if f.DecodeOnly || f.EncodeOnly {
+			return false, xerrors.Errorf("%s: field %s has a tuple index, which can't be combined with decodeonly or encodeonly",
+				gti.Name, f.Name)
+		}
+		if f.Index >= len(gti.Fields) {
+			return false, xerrors.Errorf("%s: field %s has tuple index %d, but there are only %d fields",
+				gti.Name, f.Name, f.Index, len(gti.Fields))
+		}
+		if g := byIndex[f.Index]; g != nil {
+			return false, xerrors.Errorf("%s: fields %s and %s have the same tuple index %d",
+				gti.Name, g.Name, f.Name, f.Index)
+		}
+		byIndex[f.Index] = &gti.Fields[i]
+	}
+	if !indexed {
+		return false, nil
 	}
-	_ = fi.Close()
 
-	return nil
+	ordered := make([]Field, len(gti.Fields))
+	for _, f := range gti.Fields {
+		if !f.HasIndex {
+			return false, xerrors.Errorf("%s: field %s has no tuple index, as other fields do", gti.Name, f.Name)
+		}
+		ordered[f.Index] = f
+	}
+	gti.Fields = ordered
+	return true, nil
 }
 
 // WriteMapFileEncodersToFile generates map backed MarshalCBOR and UnmarshalCBOR implementations for
@@ -96,40 +205,180 @@ func WriteTupleEncodersToFile(fname, pkg string, flattenEmbeddedStruct bool,
 // map of field names to field values.
 func WriteMapEncodersToFile(fname, pkg string, flattenEmbeddedStruct bool,
 	types ...interface{}) error {
+	g := Gen{
+		FlattenEmbeddedStruct: flattenEmbeddedStruct,
+	}
+	return g.WriteMapEncodersToFile(fname, pkg, types...)
+}
+
+// WriteMapEncodersToFile is like the WriteMapEncodersToFile function, with the
+// options of g. FieldOrder is ignored, as map keys are always sorted.
+func (g Gen) WriteMapEncodersToFile(fname, pkg string, types ...interface{}) error {
+	files, err := g.genMapEncoders(fname, pkg, types)
+	if err != nil {
+		return err
+	}
+	return files.write()
+}
+
+// VerifyMapEncodersInFile is like the VerifyMapEncodersInFile function, with
+// the options of g.
+func (g Gen) VerifyMapEncodersInFile(fname, pkg string, types ...interface{}) error {
+	files, err := g.genMapEncoders(fname, pkg, types)
+	if err != nil {
+		return err
+	}
+	return files.verify()
+}
+
+func (g Gen) genMapEncoders(fname, pkg string, types []interface{}) (genFiles, error) {
 	buf := new(bytes.Buffer)
 
 	typeInfos := make([]*GenTypeInfo, len(types))
 	embeddedByPointerStructsInfos := make([]*[]string, len(types))
 	for i, t := range types {
-		gti, embeddedByPointerStructs, err := ParseTypeInfo(t, flattenEmbeddedStruct)
+		gti, embeddedByPointerStructs, err := g.parseMapTypeInfo(t)
 		if err != nil {
-			return xerrors.Errorf("failed to parse type info: %w", err)
+			return nil, xerrors.Errorf("failed to parse type info: %w", err)
 		}
-		sort.Slice(gti.Fields, func(i, j int) bool {
-			return mapKeySort_RFC7049Less(gti.Fields[i].Name, gti.Fields[j].Name)
-		})
 		typeInfos[i] = gti
-		if flattenEmbeddedStruct {
+		if g.FlattenEmbeddedStruct {
 			embeddedByPointerStructsInfos[i] = embeddedByPointerStructs
 		}
 	}
 
 	if err := PrintHeaderAndUtilityMethods(buf, pkg, typeInfos); err != nil {
-		return xerrors.Errorf("failed to write header: %w", err)
+		return nil, xerrors.Errorf("failed to write header: %w", err)
 	}
 
 	for i, t := range typeInfos {
-		if err := GenMapEncodersForType(t, flattenEmbeddedStruct,
+		if err := GenMapEncodersForType(t, g.FlattenEmbeddedStruct,
 			embeddedByPointerStructsInfos[i], buf); err != nil {
-			return xerrors.Errorf("failed to generate encoders: %w", err)
+			return nil, xerrors.Errorf("failed to generate encoders: %w", err)
 		}
 	}
 
-	data, err := format.Source(buf.Bytes())
+	return g.genFiles(fname, pkg, ReprMap, buf.Bytes(), typeInfos, types)
+}
+
+// parseMapTypeInfo parses the type info of t, with its fields in map order.
+func (g Gen) parseMapTypeInfo(t interface{}) (*GenTypeInfo, *[]string, error) {
+	gti, embeddedByPointerStructs, err := g.parseTypeInfo(t)
 	if err != nil {
-		return err
+		return nil, nil, err
 	}
+	// Fields have always been sorted by name, which existing encodings depend
+	// on even when map keys are renamed. Integer keys are new, so they get the
+	// canonical order of their encoded keys.
+	if gti.HasIntKeys() {
+		sort.Slice(gti.Fields, func(i, j int) bool {
+			return mapKeyLess(gti.Fields[i], gti.Fields[j])
+		})
+	} else {
+		sort.Slice(gti.Fields, func(i, j int) bool {
+			return mapKeySort_RFC7049Less(gti.Fields[i].Name, gti.Fields[j].Name)
+		})
+	}
+	// An encode-only field may replace a decode-only field with the same key.
+	for _, fields := range [][]Field{gti.forEncoding().Fields, gti.forDecoding().Fields} {
+		keys := map[MapKey]string{}
+		for _, f := range fields {
+			for _, k := range f.mapKeys() {
+				if other, ok := keys[k]; ok {
+					return nil, nil, xerrors.Errorf("%s: fields %s and %s have the same map key or alias %s",
+						gti.Name, other, f.Name, k)
+				}
+				keys[k] = f.Name
+			}
+		}
+	}
+	return gti, embeddedByPointerStructs, nil
+}
+
+// genFile is a generated file.
+type genFile struct {
+	name string
+	data []byte
+}
+
+type genFiles []genFile
+
+// genFiles formats the generated encoders src, and generates the tests and
+// the schema if asked to.
+func (g Gen) genFiles(fname, pkg, repr string, src []byte, typeInfos []*GenTypeInfo,
+	types []interface{}) (genFiles, error) {
+	data, err := format.Source(src)
+	if err != nil {
+		return nil, err
+	}
+	files := genFiles{{name: fname, data: data}}
+
+	if g.GenerateTests {
+		buf := new(bytes.Buffer)
+		if err := PrintTestHeader(buf, pkg); err != nil {
+			return nil, xerrors.Errorf("failed to write test header: %w", err)
+		}
+		for i, t := range typeInfos {
+			if err := GenTestsForType(t, reflect.TypeOf(types[i]), buf); err != nil {
+				return nil, xerrors.Errorf("failed to generate tests: %w", err)
+			}
+		}
+		data, err := format.Source(buf.Bytes())
+		if err != nil {
+			return nil, err
+		}
+		files = append(files, genFile{name: testFileName(fname), data: data})
+	}
+
+	if g.IpldSchemaFile != "" {
+		buf := new(bytes.Buffer)
+		if err := PrintIpldSchemaHeader(buf); err != nil {
+			return nil, xerrors.Errorf("failed to write schema header: %w", err)
+		}
+		for _, t := range typeInfos {
+			if err := GenIpldSchemaForType(t, repr, buf); err != nil {
+				return nil, xerrors.Errorf("failed to generate schema: %w", err)
+			}
+		}
+		data := append(bytes.TrimRight(buf.Bytes(), "\n"), '\n')
+		files = append(files, genFile{name: g.IpldSchemaFile, data: data})
+	}
+
+	if g.CddlFile != "" {
+		buf := new(bytes.Buffer)
+		if err := PrintCddlHeader(buf); err != nil {
+			return nil, xerrors.Errorf("failed to write CDDL header: %w", err)
+		}
+		for _, t := range typeInfos {
+			if err := GenCddlForType(t, repr, buf); err != nil {
+				return nil, xerrors.Errorf("failed to generate CDDL: %w", err)
+			}
+		}
+		data := append(bytes.TrimRight(buf.Bytes(), "\n"), '\n')
+		files = append(files, genFile{name: g.CddlFile, data: data})
+	}
+	return files, nil
+}
+
+func (files genFiles) write() error {
+	for _, f := range files {
+		if err := writeFile(f.name, f.data); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (files genFiles) verify() error {
+	for _, f := range files {
+		if err := verifyFile(f.name, f.data); err != nil {
+			return err
+		}
+	}
+	return nil
+}
 
+func writeFile(fname string, data []byte) error {
 	fi, err := os.Create(fname)
 	if err != nil {
 		return xerrors.Errorf("failed to open file: %w", err)